@@ -15,6 +15,8 @@ import (
 	"github.com/kastheco/kasmos/config/taskstore"
 	daemonpkg "github.com/kastheco/kasmos/daemon"
 	"github.com/kastheco/kasmos/internal/clickup"
+	"github.com/kastheco/kasmos/internal/github"
+	"github.com/kastheco/kasmos/internal/jira"
 	"github.com/kastheco/kasmos/internal/mcpclient"
 	sentrypkg "github.com/kastheco/kasmos/internal/sentry"
 	"github.com/kastheco/kasmos/log"
@@ -27,6 +29,7 @@ import (
 	"github.com/kastheco/kasmos/ui/overlay"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"charm.land/bubbles/v2/spinner"
@@ -36,10 +39,18 @@ import (
 	zone "github.com/lrstanley/bubblezone/v2"
 )
 
-const GlobalInstanceLimit = 20
-
 const clickUpOpTimeout = 30 * time.Second
 
+// errClickUpCanceled is returned by a ClickUp search or fetch the user aborted.
+var errClickUpCanceled = errors.New("operation canceled")
+
+// clickUpRetryAttempts and clickUpRetryBaseDelay are the MCP client's retry
+// policy for transport failures (500ms, then 1s between the three attempts).
+const (
+	clickUpRetryAttempts  = 3
+	clickUpRetryBaseDelay = 500 * time.Millisecond
+)
+
 var repoManagedByDaemon = func(repoPath string) bool {
 	if repoPath == "" {
 		return false
@@ -77,17 +88,21 @@ func defaultDaemonSocketPath() string {
 }
 
 // Run is the main entrypoint into the application.
-func Run(ctx context.Context, program string, autoYes bool, version string) error {
+func Run(ctx context.Context, program, reviewerProgram string, autoYes bool, version string) error {
 	// Set the terminal's default background to the theme base color so every
-	// ANSI reset and unstyled cell falls back to #232136 instead of black.
-	restore := ui.SetTerminalBackground("#232136")
+	// ANSI reset and unstyled cell falls back to it instead of the terminal default.
+	restore := ui.SetTerminalBackground(ui.ActiveTheme().Base)
 	defer restore()
 	defer sentrypkg.RecoverPanic()
 
 	zone.NewGlobal()
 	h := newHome(ctx, program, autoYes, version)
+	if reviewerProgram != "" {
+		h.appConfig.ReviewerProgram = reviewerProgram
+	}
 	defer h.embeddedServer.Stop()
 	defer h.auditLogger.Close()
+	defer h.configWatcher.Close()
 	if h.permissionStore != nil {
 		defer h.permissionStore.Close()
 	}
@@ -116,6 +131,8 @@ const (
 	stateNewPlanDeriving
 	// stateNewPlanTopic is the state when the user is picking a topic for a new plan.
 	stateNewPlanTopic
+	// stateNewPlanTemplate is the state when the user is picking a template for a new plan.
+	stateNewPlanTemplate
 	// stateSpawnAgent is the state when the user is spawning an ad-hoc agent session.
 	stateSpawnAgent
 	// statePRTitle is the state when the user is entering a PR title.
@@ -138,6 +155,10 @@ const (
 	stateChangeTopic
 	// stateSetStatus is the state when the user is force-overriding a plan's status via picker.
 	stateSetStatus
+	// stateSetBlockers is the state when the user is choosing which plans block a plan.
+	stateSetBlockers
+	// stateSetPriority is the state when the user is picking a plan's priority.
+	stateSetPriority
 	// stateClickUpSearch is the state when the user is typing a ClickUp search query.
 	stateClickUpSearch
 	// stateClickUpPicker is the state when the user is picking from ClickUp search results.
@@ -146,6 +167,18 @@ const (
 	stateClickUpFetching
 	// stateClickUpWorkspacePicker is when the user must pick a ClickUp workspace.
 	stateClickUpWorkspacePicker
+	// stateGitHubSearch is the state when the user is typing a GitHub issue search query.
+	stateGitHubSearch
+	// stateGitHubPicker is the state when the user is picking from GitHub search results.
+	stateGitHubPicker
+	// stateGitHubFetching is when kasmos is fetching a full issue from GitHub.
+	stateGitHubFetching
+	// stateJiraSearch is the state when the user is typing a Jira search query or JQL.
+	stateJiraSearch
+	// stateJiraPicker is the state when the user is picking from Jira search results.
+	stateJiraPicker
+	// stateJiraFetching is when kasmos is fetching a full issue from Jira.
+	stateJiraFetching
 	// statePermission is when an opencode permission prompt is detected and the modal is shown.
 	statePermission
 	// stateTmuxBrowser is the state when the tmux session browser overlay is shown.
@@ -159,6 +192,22 @@ const (
 	stateLauncher
 	// stateKeybindBrowser is the state when the keybind browser overlay is shown.
 	stateKeybindBrowser
+	// stateBroadcastPrompt is the state when the user is typing a prompt to send
+	// to every running session of a plan.
+	stateBroadcastPrompt
+	// stateGlobalSearch is the state when the user is typing a query to search
+	// the content of every plan.
+	stateGlobalSearch
+	// stateGlobalSearchPicker is the state when the user is picking from plan
+	// content matches.
+	stateGlobalSearchPicker
+	// statePreviewSearch is the state when the user is typing a scrollback
+	// search query in the preview's scroll mode.
+	statePreviewSearch
+	// stateOverview is the state when the cross-repo overview overlay is shown.
+	stateOverview
+	// stateSettings is the state when the settings form overlay is shown.
+	stateSettings
 )
 
 type home struct {
@@ -179,11 +228,17 @@ type home struct {
 	appConfig *config.Config
 	// appState stores persistent application state like seen help screens
 	appState config.AppState
+	// configWatcher reloads appConfig when config.toml is edited externally.
+	// Nil when the watcher could not be started.
+	configWatcher *configWatcher
 
 	// -- State --
 
 	// allInstances stores every instance across all repos (master list)
 	allInstances []*session.Instance
+	// undo holds instances recently removed from the list so KeyUndo can
+	// put them back.
+	undo undoStack
 
 	// state is the current discrete state of the application
 	state state
@@ -203,6 +258,10 @@ type home struct {
 	// promptAfterName tracks if we should enter prompt mode after naming
 	promptAfterName bool
 
+	// settingsBefore holds the values the settings form opened with, so that
+	// only the fields the user changed are written back.
+	settingsBefore overlay.Settings
+
 	// keySent is used to manage underlining menu items
 	keySent bool
 
@@ -236,26 +295,52 @@ type home struct {
 	pendingPlanName string
 	// pendingPlanDesc stores the plan description during the two-step plan creation flow
 	pendingPlanDesc string
+	// pendingPlanTopic stores the chosen topic while the template picker is open
+	pendingPlanTopic string
+	// planTemplates holds the templates offered by the open template picker
+	planTemplates []config.PlanTemplate
 	// pendingPRTitle stores the PR title during the two-step PR creation flow
 	pendingPRTitle string
+	// pendingPRDraft is true when the PR being created should open as a draft.
+	// Seeded from config.DefaultDraftPR and flipped with keys.KeyToggleDraft.
+	pendingPRDraft bool
 	// pendingPRWorktree is a GitWorktree built from taskState for plan-level PR
 	// creation flows where no running instance is available. Cleared after use.
 	pendingPRWorktree *gitpkg.GitWorktree
+	// pendingPRPlan is the plan filename during the plan-level PR flow; its
+	// wave/task summary is added to the generated body. Cleared after use.
+	pendingPRPlan string
 	// pendingChangeTopicTask stores the plan filename during the change-topic flow
 	pendingChangeTopicTask string
 	// pendingSetStatusTask stores the plan filename during the set-status flow
 	pendingSetStatusTask string
+	// pendingSetStatusTopic stores the topic name during the bulk set-status flow
+	pendingSetStatusTopic string
+	// pendingSetBlockersTask stores the plan filename during the set-blockers flow
+	pendingSetBlockersTask string
+	// pendingSetPriorityTask stores the plan filename during the set-priority flow
+	pendingSetPriorityTask string
 	// pendingChatAboutTask stores the plan filename during the chat-about-plan flow
 	pendingChatAboutTask string
+	// pendingBroadcastPlan stores the plan filename during the broadcast-prompt flow
+	pendingBroadcastPlan string
+	// contentMatches stores the latest plan content search results for the picker
+	contentMatches []taskstate.ContentMatch
 	// pendingLogEvent stores the audit event that triggered the log-action context
 	// menu. Consumed by executeContextAction for "log_*" actions.
 	pendingLogEvent *ui.AuditEventDisplay
 	// pendingPRToastID stores the toast ID for the in-progress PR creation
 	pendingPRToastID string
+	// lastPRURL is the URL of the most recently created PR, and lastPRToastID
+	// the success toast that offers to open it with o.
+	lastPRURL     string
+	lastPRToastID string
 	// pendingAttachInstance is the instance queued for tea.Exec attach after the
 	// help overlay is dismissed. Set in the keys.KeyEnter handler; consumed and
 	// cleared in handleHelpState once the user acknowledges the attach help screen.
 	pendingAttachInstance *session.Instance
+	// pendingAttachReadonly marks pendingAttachInstance for a read-only attach.
+	pendingAttachReadonly bool
 
 	// tmuxSessionCount is the latest count of kas_-prefixed tmux sessions.
 	tmuxSessionCount int
@@ -263,6 +348,8 @@ type home struct {
 	clickUpConfig *clickup.MCPServerConfig
 	// clickUpImporter handles search/fetch via MCP (nil until first use)
 	clickUpImporter *clickup.Importer
+	// clickUpCancel aborts the in-flight ClickUp search or fetch (nil when idle)
+	clickUpCancel context.CancelFunc
 	// clickUpCommenter handles posting progress comments to ClickUp tasks (nil until first use)
 	clickUpCommenter *clickup.Commenter
 	// clickUpMCPClient is the raw MCP caller shared by importer and commenter
@@ -273,6 +360,18 @@ type home struct {
 	clickUpPendingQuery string
 	// clickUpWorkspaceMap maps picker labels ("name (id)") back to bare workspace IDs.
 	clickUpWorkspaceMap map[string]string
+	// githubRepo stores the detected GitHub origin (nil if origin is not on github.com)
+	githubRepo *github.Repo
+	// githubImporter handles issue search/fetch via the REST API (nil until first use)
+	githubImporter *github.Importer
+	// githubResults stores the latest issue search results for the picker
+	githubResults []github.SearchResult
+	// jiraConfig stores the detected Jira site config (nil if not configured)
+	jiraConfig *jira.Config
+	// jiraImporter handles issue search/fetch via the REST API (nil until first use)
+	jiraImporter *jira.Importer
+	// jiraResults stores the latest issue search results for the picker
+	jiraResults []jira.SearchResult
 
 	// Layout dimensions for mouse hit-testing
 	navWidth      int
@@ -292,11 +391,16 @@ type home struct {
 	previewTerminalInstance string // title of the instance the terminal is attached to
 	previewRequested        bool   // true after the user explicitly selects the live agent pane
 	previewClipboardPending bool
-	previewClipboardTarget  byte
+	// previewScrollbackPending is true while a scroll-mode history capture is
+	// in flight, so live output triggers at most one capture at a time.
+	previewScrollbackPending bool
+	previewClipboardTarget   byte
 
 	// taskState holds the parsed task state from the store for the active repo.
 	taskState *taskstate.TaskState
-	// taskStateDir is the legacy plans directory path. Retained only for JSON migration.
+	// taskStateDir is the repo's plans directory (config plans_dir, default
+	// docs/plans). Plan content lives in the store; this path holds wave-state
+	// snapshots, files opened for $EDITOR and the legacy plan-state.json.
 	// New code should not depend on this path existing on disk.
 	taskStateDir string
 	// signalsDir is the directory where agent sentinel files are written.
@@ -346,6 +450,9 @@ type home struct {
 	// pendingWaveNextAction is the advance action for a failed-wave decision dialog.
 	// Triggered when the user presses 'n' (next wave) while the failed-wave overlay is active.
 	pendingWaveNextAction tea.Cmd
+	// pendingWaveRetryTimedOutAction retries only the timed-out tasks of a failed wave.
+	// Triggered when the user presses 't' while the failed-wave overlay is active.
+	pendingWaveRetryTimedOutAction tea.Cmd
 
 	// plannerPrompted tracks plan files whose planner-exit dialog has been
 	// answered (yes or no). Prevents re-prompting every metadata tick.
@@ -391,6 +498,14 @@ type home struct {
 	// planBrowserOpener starts or reuses kas serve and opens the admin plan browser.
 	// Injected for testability.
 	planBrowserOpener func(repoRoot, project, planFile string) (string, bool, error)
+	// instanceResumer resumes a paused instance. Nil uses Instance.Resume;
+	// injected for testability.
+	instanceResumer func(*session.Instance) error
+	// autoPusher pushes an instance's committed work for auto-push. Nil uses
+	// GitWorktree.Push; injected for testability.
+	autoPusher func(inst *session.Instance) error
+	// autoPush detects new commits on coder worktrees and debounces auto-pushes.
+	autoPush autoPushTracker
 
 	// pendingReviewFeedback holds review feedback from sentinel files, keyed by
 	// plan filename, to be injected as context for the next coder session.
@@ -413,12 +528,14 @@ type home struct {
 	// across multiple metadata ticks while opencode processes the first response.
 	// Cleared when the pane no longer contains a permission prompt for that instance.
 	permissionHandled map[*session.Instance]string
+	// permissionNotified records the prompt (same guard key as
+	// permissionHandled) each instance was last notified about, so a prompt
+	// that stays on screen across ticks or overlay dismissals notifies once.
+	// Cleared together with permissionHandled when the prompt goes away.
+	permissionNotified map[*session.Instance]string
 }
 
 func newHome(ctx context.Context, program string, autoYes bool, version string) *home {
-	// Load application config
-	appConfig := config.LoadConfig()
-
 	// Load application state
 	appState := config.LoadState()
 
@@ -438,6 +555,9 @@ func newHome(ctx context.Context, program string, autoYes bool, version string)
 		activeRepoPath = repoRoot
 	}
 
+	// Load application config, with the active repo's overrides applied
+	appConfig := config.LoadConfigForRepo(activeRepoPath)
+
 	project := resolveTaskStoreProject(activeRepoPath)
 	h := &home{
 		ctx:                   ctx,
@@ -448,13 +568,14 @@ func newHome(ctx context.Context, program string, autoYes bool, version string)
 		tabbedWindow:          ui.NewTabbedWindow(ui.NewPreviewPane(), ui.NewInfoPane()),
 		storage:               storage,
 		appConfig:             appConfig,
+		configWatcher:         newConfigWatcher(),
 		program:               program,
 		version:               version,
 		autoYes:               autoYes,
 		state:                 stateDefault,
 		appState:              appState,
 		activeRepoPath:        activeRepoPath,
-		taskStateDir:          filepath.Join(activeRepoPath, "docs", "plans"), // legacy: only for JSON migration
+		taskStateDir:          appConfig.PlansDirFor(activeRepoPath),
 		signalsDir:            filepath.Join(activeRepoPath, ".kasmos", "signals"),
 		taskStoreProject:      project,
 		daemonStatusChecker:   checkDaemonStatus,
@@ -497,6 +618,8 @@ func newHome(ctx context.Context, program string, autoYes bool, version string)
 
 	h.taskStore = taskstore.NewHTTPStore(storeURL, project)
 	h.fsm = taskfsm.New(h.taskStore, project, h.taskStateDir)
+	h.fsm.SetHooks(h.clickUpStatusHooks())
+	h.menu.SetVimMode(h.vimMode())
 
 	// One-time migration: import plan-state.json into the DB if it exists.
 	// Use the embedded store directly (bypasses HTTP round-trip).
@@ -524,6 +647,7 @@ func newHome(ctx context.Context, program string, autoYes bool, version string)
 	}
 
 	h.nav = ui.NewNavigationPanel(&h.spinner)
+	h.nav.SetGrouping(ui.NavGrouping(appState.GetNavGrouping()))
 	h.toastManager = overlay.NewToastManager(&h.spinner)
 	h.overlays = overlay.NewManager()
 
@@ -541,9 +665,11 @@ func newHome(ctx context.Context, program string, autoYes bool, version string)
 		if migrateErr := config.MigratePermissionCache(permCacheDir, project, permStore); migrateErr != nil {
 			log.WarningLog.Printf("permission cache migration failed: %v", migrateErr)
 		}
+		permStore.SetTTL(time.Duration(appConfig.PermissionCacheTTLDays) * 24 * time.Hour)
 		h.permissionStore = permStore
 	}
 	h.permissionHandled = make(map[*session.Instance]string)
+	h.permissionNotified = make(map[*session.Instance]string)
 
 	h.tabbedWindow.SetAnimateBanner(appConfig.AnimateBanner)
 	h.setFocusSlot(slotNav)
@@ -565,6 +691,7 @@ func newHome(ctx context.Context, program string, autoYes bool, version string)
 			h.nav.AddInstance(instance)()
 			if autoYes {
 				instance.AutoYes = true
+				instance.AutoYesPatterns = appConfig.AutoYesPatterns
 			}
 		}
 	}
@@ -702,10 +829,13 @@ func (m *home) Init() tea.Cmd {
 			time.Sleep(50 * time.Millisecond)
 			return previewTickMsg{}
 		},
-		tickUpdateMetadataCmd,
+		m.tickUpdateMetadataCmd(),
 		m.toastTickCmd(),
 		m.daemonStartupCheckCmd(),
 		detectClickUpCmd(m.activeRepoPath),
+		detectGitHubCmd(m.activeRepoPath),
+		detectJiraCmd(m.activeRepoPath),
+		m.configWatcher.wait(),
 	)
 }
 
@@ -717,11 +847,88 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, m.toastTickCmd()
 		}
 		return m, nil
+	case configChangedMsg:
+		return m, tea.Batch(m.reloadConfig(), m.configWatcher.wait())
+	case planCopiedMsg:
+		if msg.err != nil {
+			return m, m.handleError(msg.err)
+		}
+		return m, m.copyToClipboard(msg.content, "plan copied")
+	case planRefreshMsg:
+		return m, m.finishEditPlan(msg)
+	case diffExportedMsg:
+		if msg.err != nil {
+			return m, m.handleError(fmt.Errorf("export diff: %w", msg.err))
+		}
+		m.toastManager.Success(fmt.Sprintf("diff exported to %s", msg.path))
+		return m, m.toastTickCmd()
+	case planRebasedMsg:
+		if msg.err != nil {
+			log.ErrorLog.Printf("%v", msg.err)
+			m.audit(auditlog.EventGitRebase, msg.err.Error(),
+				auditlog.WithPlan(msg.planFile), auditlog.WithLevel("error"))
+			m.toastManager.Error(msg.err.Error())
+			return m, m.toastTickCmd()
+		}
+		m.audit(auditlog.EventGitRebase, fmt.Sprintf("rebased %s onto main", msg.branch),
+			auditlog.WithPlan(msg.planFile))
+		m.toastManager.Success(fmt.Sprintf("rebased %s onto main", msg.branch))
+		return m, m.toastTickCmd()
+	case branchReattachedMsg:
+		return m, m.handleBranchReattached(msg)
+	case planBranchRenamedMsg:
+		if msg.err != nil {
+			log.ErrorLog.Printf("%v", msg.err)
+			m.audit(auditlog.EventBranchRenamed, msg.err.Error(),
+				auditlog.WithPlan(msg.planFile), auditlog.WithLevel("error"))
+			m.toastManager.Error(msg.err.Error())
+			return m, m.toastTickCmd()
+		}
+		if m.taskState != nil {
+			if err := m.taskState.SetBranch(msg.planFile, msg.newBranch); err != nil {
+				return m, m.handleError(fmt.Errorf("branch renamed to %s but saving the task failed: %w", msg.newBranch, err))
+			}
+		}
+		m.audit(auditlog.EventBranchRenamed, fmt.Sprintf("renamed branch %s to %s", msg.oldBranch, msg.newBranch),
+			auditlog.WithPlan(msg.planFile))
+		m.toastManager.Success(fmt.Sprintf("renamed branch to %s", msg.newBranch))
+		return m, m.toastTickCmd()
+	case quitAndKillMsg:
+		return m.startQuitAndKill()
+	case quitAndKillResultMsg:
+		m.audit(auditlog.EventSessionStopped, fmt.Sprintf("kasmos stopped (%d sessions stopped)", msg.stopped))
+		_ = m.saveAllInstances()
+		return m, tea.Quit
+	case pauseAllMsg:
+		return m.startPauseAll(msg.resume)
+	case pauseAllResultMsg:
+		verb := "paused"
+		if msg.resume {
+			verb = "resumed"
+		}
+		text := fmt.Sprintf("%s %d session(s)", verb, msg.done)
+		typ := overlay.ToastSuccess
+		if len(msg.failed) > 0 {
+			text += fmt.Sprintf("; %d failed: %s", len(msg.failed), strings.Join(msg.failed, ", "))
+			typ = overlay.ToastError
+		}
+		m.toastManager.Resolve(msg.toastID, typ, text)
+		_ = m.saveAllInstances()
+		m.updateNavPanelStatus()
+		return m, tea.Batch(tea.RequestWindowSize, m.instanceChanged(), m.toastTickCmd())
 	case prCreatedMsg:
-		m.toastManager.Resolve(m.pendingPRToastID, overlay.ToastSuccess, "PR created!")
+		if msg.url == "" {
+			m.toastManager.Resolve(m.pendingPRToastID, overlay.ToastSuccess, "PR created!")
+		} else {
+			m.toastManager.Resolve(m.pendingPRToastID, overlay.ToastSuccess, "PR created! press o to open it")
+			m.toastManager.SetDismissAfter(m.pendingPRToastID, overlay.ActionDismissAfter)
+			m.lastPRURL = msg.url
+			m.lastPRToastID = m.pendingPRToastID
+		}
 		m.pendingPRToastID = ""
 		m.audit(auditlog.EventPRCreated, fmt.Sprintf("PR created: %s", msg.prTitle),
 			auditlog.WithInstance(msg.instanceTitle),
+			auditlog.WithPlan(msg.planFile),
 		)
 		return m, m.toastTickCmd()
 	case daemonStatusMsg:
@@ -753,7 +960,13 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loadTaskState()
 		m.updateInfoPane()
 		planName := taskstate.DisplayName(msg.planFile)
-		m.toastManager.Success(fmt.Sprintf("pr created for '%s'", planName))
+		if msg.url == "" {
+			m.toastManager.Success(fmt.Sprintf("pr created for '%s'", planName))
+			return m, m.toastTickCmd()
+		}
+		id := m.toastManager.Success(fmt.Sprintf("pr created for '%s'! press o to open it", planName))
+		m.toastManager.SetDismissAfter(id, overlay.ActionDismissAfter)
+		m.lastPRURL, m.lastPRToastID = msg.url, id
 		return m, m.toastTickCmd()
 	case planRenderedMsg:
 		if msg.err != nil {
@@ -766,16 +979,22 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	case previewTickMsg:
 		// If previewTerminal is active, render from it (zero-latency VT emulator).
+		var scrollbackCmd tea.Cmd
 		if m.previewTerminal != nil && !m.tabbedWindow.IsDocumentMode() {
 			if content, changed := m.previewTerminal.Render(); changed {
-				m.tabbedWindow.SetPreviewContent(content)
+				if m.tabbedWindow.HandlePreviewOutput(content) && !m.previewScrollbackPending {
+					if selected := m.nav.GetSelectedInstance(); selected != nil {
+						m.previewScrollbackPending = true
+						scrollbackCmd = captureScrollbackCmd(selected)
+					}
+				}
 			}
 			if !m.previewClipboardPending {
 				if selection, ok := m.previewTerminal.PollClipboardRequest(); ok {
 					m.previewClipboardPending = true
 					m.previewClipboardTarget = selection
 					term := m.previewTerminal
-					return m, tea.Batch(nextPreviewTickCmd(term), readClipboardCmd(selection))
+					return m, tea.Batch(nextPreviewTickCmd(term), readClipboardCmd(selection), scrollbackCmd)
 				}
 			}
 		} else if m.previewTerminal == nil && !m.tabbedWindow.IsDocumentMode() {
@@ -801,15 +1020,35 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		// Use event-driven wakeup when terminal is live, fall back to 50ms poll otherwise.
 		term := m.previewTerminal
-		return m, nextPreviewTickCmd(term)
+		return m, tea.Batch(nextPreviewTickCmd(term), scrollbackCmd)
+	case previewScrollbackMsg:
+		m.previewScrollbackPending = false
+		if msg.err != nil {
+			log.InfoLog.Printf("failed to refresh scrollback: %v", msg.err)
+			return m, nil
+		}
+		// Drop captures for an instance the user has since moved away from.
+		if selected := m.nav.GetSelectedInstance(); selected != nil && selected.Title == msg.title {
+			m.tabbedWindow.SetPreviewScrollback(msg.content)
+		}
+		return m, nil
 	case keyupMsg:
 		m.menu.ClearKeydown()
 		return m, nil
 	case clickUpDetectedMsg:
 		m.clickUpConfig = &msg.Config
 		m.nav.SetClickUpAvailable(true)
-		return m, nil
+		return m, m.pollClickUpWatch()
+	case clickUpWatchTickMsg:
+		return m, m.pollClickUpWatch()
+	case clickUpWatchResultMsg:
+		return m, m.finishClickUpWatch(msg)
 	case clickUpSearchResultMsg:
+		if errors.Is(msg.Err, errClickUpCanceled) {
+			// Aborted with Esc; the key handler already reset the state.
+			return m, nil
+		}
+		m.clickUpCancel = nil
 		if msg.Err != nil {
 			// Check if the error is a multiple-workspaces error — show picker instead of failing.
 			var mwErr *clickup.MultipleWorkspacesError
@@ -856,14 +1095,69 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.state = stateClickUpPicker
 		m.overlays.Show(overlay.NewPickerOverlay("select clickup task", items))
 		return m, nil
+	case githubDetectedMsg:
+		m.githubRepo = &msg.Repo
+		m.nav.SetGitHubAvailable(true)
+		return m, nil
+	case githubSearchResultMsg:
+		if msg.Err != nil {
+			m.toastManager.Error("github search failed: " + msg.Err.Error())
+			m.state = stateDefault
+			return m, m.toastTickCmd()
+		}
+		if len(msg.Results) == 0 {
+			m.toastManager.Info("no github issues found")
+			m.state = stateDefault
+			return m, m.toastTickCmd()
+		}
+		m.githubResults = msg.Results
+		items := make([]string, len(msg.Results))
+		for i, r := range msg.Results {
+			items[i] = githubPickerLabel(r)
+		}
+		m.state = stateGitHubPicker
+		m.overlays.Show(overlay.NewPickerOverlay("select github issue", items))
+		return m, nil
+	case jiraDetectedMsg:
+		m.jiraConfig = &msg.Config
+		m.nav.SetJiraAvailable(true)
+		return m, nil
+	case jiraSearchResultMsg:
+		if msg.Err != nil {
+			m.toastManager.Error("jira search failed: " + msg.Err.Error())
+			m.state = stateDefault
+			return m, m.toastTickCmd()
+		}
+		if len(msg.Results) == 0 {
+			m.toastManager.Info("no jira issues found")
+			m.state = stateDefault
+			return m, m.toastTickCmd()
+		}
+		m.jiraResults = msg.Results
+		items := make([]string, len(msg.Results))
+		for i, r := range msg.Results {
+			items[i] = jiraPickerLabel(r)
+		}
+		m.state = stateJiraPicker
+		m.overlays.Show(overlay.NewPickerOverlay("select jira issue", items))
+		return m, nil
 	case tickUpdateMetadataMessage:
 		// Snapshot the instance list for the goroutine. The slice header is
 		// copied but the pointers are shared — CollectMetadata only reads
 		// instance fields that don't change between ticks (started, Status,
-		// tmuxSession, gitWorktree, Program).
+		// tmuxSession, gitWorktree, Program) and touches no state shared
+		// between instances, so the pool may collect several at once.
 		instances := m.nav.GetInstances()
-		snapshots := make([]*session.Instance, len(instances))
-		copy(snapshots, instances)
+		snapshots := make([]*session.Instance, 0, len(instances))
+		for _, inst := range instances {
+			if inst.Started() && !inst.Paused() {
+				snapshots = append(snapshots, inst)
+			}
+		}
+		workers := 1
+		if m.appConfig != nil {
+			workers = m.appConfig.MetadataWorkerCount()
+		}
 		taskStateDir := m.taskStateDir // snapshot for goroutine
 		signalsDir := m.signalsDir     // snapshot for goroutine
 		store := m.taskStore           // snapshot for goroutine
@@ -871,15 +1165,12 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		repoPath := m.activeRepoPath   // snapshot for goroutine
 		m.metadataTickCount++
 		tickCount := m.metadataTickCount // capture by value for goroutine
+		tickInterval := m.metadataTickInterval()
 
 		return m, func() tea.Msg {
-			results := make([]instanceMetadata, 0, len(snapshots))
-			for _, inst := range snapshots {
-				if !inst.Started() || inst.Paused() {
-					continue
-				}
+			results := collectMetadataPool(snapshots, workers, func(inst *session.Instance) instanceMetadata {
 				md := inst.CollectMetadata()
-				results = append(results, instanceMetadata{
+				return instanceMetadata{
 					Title:              inst.Title,
 					Content:            md.Content,
 					ContentCaptured:    md.ContentCaptured,
@@ -890,8 +1181,15 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					ResourceUsageValid: md.ResourceUsageValid,
 					TmuxAlive:          md.TmuxAlive,
 					PermissionPrompt:   md.PermissionPrompt,
-				})
-			}
+					Usage:              md.Usage,
+					ConflictsChecked:   md.ConflictsChecked,
+					HasConflicts:       md.HasConflicts,
+					ConflictFiles:      md.ConflictFiles,
+					HeadSHA:            md.HeadSHA,
+					DetachedChecked:    md.DetachedChecked,
+					Detached:           md.Detached,
+				}
+			})
 
 			// Load plan state — moved here from the synchronous Update handler
 			// to avoid blocking the event loop every 500ms.
@@ -1003,7 +1301,7 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
-			time.Sleep(200 * time.Millisecond)
+			time.Sleep(tickInterval)
 			return metadataResultMsg{Results: results, PlanState: ps, Signals: signals, TaskSignals: taskSignals, WaveSignals: waveSignals, ElaborationSignals: elaborationSignals, DaemonManagedRepo: daemonManagedRepo, TmuxSessionCount: tmuxCount, PRStateUpdates: prStateUpdates}
 		}
 	case metadataResultMsg:
@@ -1055,6 +1353,7 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						m.audit(auditlog.EventPlanTransition, "reviewing → done (review approved)",
 							auditlog.WithPlan(a.PlanFile))
 						m.toastManager.Success(fmt.Sprintf("review approved: %s", planName))
+						session.SendNotification("kas", fmt.Sprintf("plan complete: %s", planName))
 						if cmd := m.postClickUpProgress(a.PlanFile, "review_approved", ""); cmd != nil {
 							signalCmds = append(signalCmds, cmd)
 						}
@@ -1084,13 +1383,9 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							signalCmds = append(signalCmds, cmd)
 						}
 					case loop.ReviewCycleLimitAction:
-						planName := taskstate.DisplayName(a.PlanFile)
-						m.toastManager.Error(fmt.Sprintf(
-							"review-fix loop stopped: cycle limit reached (%d/%d) for %s",
-							a.Cycle, a.Limit, planName))
-						m.audit(auditlog.EventPlanTransition,
-							fmt.Sprintf("review-fix cycle limit reached (%d/%d)", a.Cycle, a.Limit),
-							auditlog.WithPlan(a.PlanFile))
+						if cmd := m.reviewCycleLimitReached(a.PlanFile, a.Cycle, a.Limit); cmd != nil {
+							signalCmds = append(signalCmds, cmd)
+						}
 					case loop.PlannerCompleteAction:
 						capturedPlanFile := a.PlanFile
 						{
@@ -1176,6 +1471,7 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						m.audit(auditlog.EventPlanTransition, "reviewing → done (review approved)",
 							auditlog.WithPlan(sig.TaskFile))
 						m.toastManager.Success(fmt.Sprintf("review approved: %s", planName))
+						session.SendNotification("kas", fmt.Sprintf("plan complete: %s", planName))
 						if cmd := m.postClickUpProgress(sig.TaskFile, "review_approved", ""); cmd != nil {
 							signalCmds = append(signalCmds, cmd)
 						}
@@ -1208,13 +1504,9 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						if m.appConfig != nil && m.appConfig.MaxReviewFixCycles > 0 {
 							if cycle, err := m.taskState.ReviewCycle(sig.TaskFile); err == nil {
 								if cycle+1 > m.appConfig.MaxReviewFixCycles {
-									planName := taskstate.DisplayName(sig.TaskFile)
-									m.toastManager.Error(fmt.Sprintf(
-										"review-fix loop stopped: cycle limit reached (%d/%d) for %s",
-										cycle+1, m.appConfig.MaxReviewFixCycles, planName))
-									m.audit(auditlog.EventPlanTransition,
-										fmt.Sprintf("review-fix cycle limit reached (%d/%d)", cycle+1, m.appConfig.MaxReviewFixCycles),
-										auditlog.WithPlan(sig.TaskFile))
+									if cmd := m.reviewCycleLimitReached(sig.TaskFile, cycle+1, m.appConfig.MaxReviewFixCycles); cmd != nil {
+										signalCmds = append(signalCmds, cmd)
+									}
 									continue // skip spawning fixer
 								}
 							}
@@ -1366,8 +1658,7 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					continue
 				}
 
-				orch := orchestration.NewWaveOrchestrator(ws.TaskFile, plan)
-				orch.SetStore(m.taskStore, m.taskStoreProject)
+				orch := m.newWaveOrchestrator(ws.TaskFile, plan)
 				m.waveOrchestrators[ws.TaskFile] = orch
 
 				// Fast-forward to the requested wave
@@ -1471,12 +1762,16 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				} else {
 					if md.HasPrompt {
 						inst.PromptDetected = true
-						// Defer tmux send-keys to async Cmd (was blocking Update).
-						i := inst
-						asyncCmds = append(asyncCmds, func() tea.Msg {
-							i.TapEnter()
-							return nil
-						})
+						// Prompts outside the auto-yes allowlist stay blocked
+						// so the user is notified and answers them.
+						if inst.ShouldAutoYes(md.PermissionPrompt) {
+							// Defer tmux send-keys to async Cmd (was blocking Update).
+							i := inst
+							asyncCmds = append(asyncCmds, func() tea.Msg {
+								i.TapEnter()
+								return nil
+							})
+						}
 					} else {
 						inst.SetStatus(session.Ready)
 					}
@@ -1519,6 +1814,7 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.overlays.Show(perm)
 					m.pendingPermissionInstance = inst
 					m.state = statePermission
+					m.notifyPermissionPrompt(inst, guardKey, pp.Description)
 					m.audit(auditlog.EventPermissionDetected,
 						fmt.Sprintf("permission prompt detected for %s", inst.Title),
 						auditlog.WithInstance(inst.Title),
@@ -1528,6 +1824,7 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Prompt cleared — remove the in-flight guard so a future permission
 				// prompt for this instance can trigger auto-approve again.
 				delete(m.permissionHandled, inst)
+				delete(m.permissionNotified, inst)
 			}
 
 			// Deliver queued prompt via async Cmd — SendPrompt contains a 100ms
@@ -1548,7 +1845,33 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if md.ResourceUsageValid {
 				inst.CPUPercent = md.CPUPercent
 				inst.MemMB = md.MemMB
+				if m.checkResourceAlert(inst) {
+					asyncCmds = append(asyncCmds, m.toastTickCmd())
+				}
+			}
+
+			// Keep the last known figures when the header scrolls out of view.
+			if md.Usage != (session.TokenUsage{}) {
+				inst.TokensUsed = md.Usage.Tokens
+				inst.CostUSD = md.Usage.CostUSD
 			}
+
+			if md.ConflictsChecked {
+				m.applyConflictState(inst, md.HasConflicts, md.ConflictFiles)
+			}
+			if md.DetachedChecked {
+				m.applyDetachedState(inst, md.Detached)
+			}
+
+			if md.HeadSHA != "" {
+				if cmd := m.maybeAutoPush(inst, md.HeadSHA, time.Now()); cmd != nil {
+					asyncCmds = append(asyncCmds, cmd)
+				}
+			}
+		}
+
+		if cmd := m.autoPauseIdleInstances(time.Now()); cmd != nil {
+			asyncCmds = append(asyncCmds, cmd)
 		}
 
 		// Clear activity for non-started / paused instances
@@ -1719,7 +2042,29 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if orchState == orchestration.WaveStateRunning {
 					// Check task status updates only while the wave is actively running.
 					planName := taskstate.DisplayName(planFile)
+
+					// Fail tasks that have outlived the wave task timeout so a hung agent
+					// (no prompt ever detected, tmux alive forever) can't deadlock the wave.
+					for _, taskNum := range orch.TimedOutTasks(time.Now()) {
+						waveNum := orch.CurrentWaveNumber()
+						orch.MarkTaskTimedOut(taskNum)
+						taskTitle := fmt.Sprintf("%s-W%d-T%d", planName, waveNum, taskNum)
+						for _, navInst := range m.nav.GetInstances() {
+							if navInst.Title == taskTitle {
+								navInst.StopTmux()
+								break
+							}
+						}
+						m.audit(auditlog.EventWaveFailed,
+							fmt.Sprintf("wave %d task %d timed out after %s", waveNum, taskNum, orch.TaskTimeout),
+							auditlog.WithPlan(planFile),
+							auditlog.WithWave(waveNum, taskNum))
+					}
+
 					for _, task := range orch.CurrentWaveTasks() {
+						if !orch.IsTaskRunning(task.Number) {
+							continue // resolved, or queued behind the concurrency cap
+						}
 						taskTitle := fmt.Sprintf("%s-W%d-T%d", planName, orch.CurrentWaveNumber(), task.Number)
 						inst, exists := instanceMap[taskTitle]
 						if !exists {
@@ -1753,6 +2098,14 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						}
 					}
 					orchState = orch.State() // refresh after task updates
+
+					// Backfill freed concurrency slots with queued tasks.
+					if orchState == orchestration.WaveStateRunning && orch.QueuedTaskCount() > 0 {
+						entry, _ := m.taskState.Entry(planFile)
+						if _, cmd := m.spawnQueuedTasks(orch, entry); cmd != nil {
+							asyncCmds = append(asyncCmds, cmd)
+						}
+					}
 				}
 
 				// All waves complete — pause the last wave's tasks, prompt for review.
@@ -1772,6 +2125,7 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						}
 					}
 					delete(m.waveOrchestrators, planFile)
+					m.removeWaveState(planFile)
 					m.audit(auditlog.EventWaveCompleted, "all waves complete: "+planName,
 						auditlog.WithPlan(capturedPlanFile))
 					// Post wave complete comment to ClickUp for multi-wave plans.
@@ -1837,11 +2191,10 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							fmt.Sprintf("wave %d: %d/%d tasks failed", waveNum, failed, total),
 							auditlog.WithPlan(capturedPlanFile),
 							auditlog.WithWave(waveNum, 0))
-						message := fmt.Sprintf(
-							"%s — wave %d: %d/%d tasks complete, %d failed.\n\n"+
-								"[r] retry failed   [n] next wave   [a] abort",
-							planName, waveNum, completed, total, failed)
-						m.waveFailedConfirmAction(message, capturedPlanFile, capturedEntry)
+						timedOut := orch.TimedOutTaskCount()
+						message := waveFailedMessage(planName, waveNum, completed, total, failed, timedOut,
+							m.planWorktreeDirty(capturedPlanFile))
+						m.waveFailedConfirmAction(message, capturedPlanFile, capturedEntry, timedOut > 0)
 					} else if m.appConfig.AutoAdvanceWaves {
 						// Auto-advance: skip confirmation, directly advance to next wave
 						m.audit(auditlog.EventWaveCompleted,
@@ -1892,7 +2245,7 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.updateInfoPane()
 		completionCmd := m.checkPlanCompletion()
 		asyncCmds = append(asyncCmds, signalCmds...)
-		asyncCmds = append(asyncCmds, tickUpdateMetadataCmd, completionCmd)
+		asyncCmds = append(asyncCmds, m.tickUpdateMetadataCmd(), completionCmd)
 		// Restart toast tick loop if any toasts were created during this tick
 		// (e.g. by transitionToReview or spawnFixerWithFeedback).
 		if m.toastManager.HasActiveToasts() {
@@ -1924,6 +2277,24 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.previewTerminal = msg.term
 		m.previewTerminalInstance = msg.instanceTitle
 		return m, nil
+	case overviewLoadedMsg:
+		return m.showOverview(msg.repos)
+	case plannerPromptMsg:
+		return m.spawnTaskAgent(msg.planFile, "plan", msg.prompt)
+	case autoPauseResultMsg:
+		return m, m.handleAutoPauseResult(msg)
+	case autoPushResultMsg:
+		return m, m.handleAutoPushResult(msg)
+	case requeuePromptMsg:
+		for _, inst := range m.allInstances {
+			if inst.Title == msg.title && inst.LastPrompt != "" {
+				// Delivered by the metadata tick once the agent is ready.
+				inst.QueuedPrompt = inst.LastPrompt
+				m.saveAllInstances()
+				break
+			}
+		}
+		return m, nil
 	case killInstanceMsg:
 		// Async pre-kill checks passed — pause instead of destroying (branch preserved).
 		for _, inst := range m.allInstances {
@@ -1940,12 +2311,18 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case taskStageConfirmedMsg:
 		// User confirmed past the topic-concurrency gate — execute the stage.
 		return m.executeTaskStage(msg.planFile, msg.stage)
+	case topicStatusSetMsg:
+		return m.applyTopicStatus(msg)
 	case taskRefreshMsg:
 		// Reload plan state and refresh sidebar after async plan mutation.
 		m.loadTaskState()
 		m.updateSidebarTasks()
 		return m, tea.RequestWindowSize
 	case clickUpTaskFetchedMsg:
+		if errors.Is(msg.Err, errClickUpCanceled) {
+			return m, nil
+		}
+		m.clickUpCancel = nil
 		if msg.Err != nil {
 			m.toastManager.Error("clickup fetch failed: " + msg.Err.Error())
 			m.state = stateDefault
@@ -1953,6 +2330,22 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.state = stateDefault
 		return m.importClickUpTask(msg.Task)
+	case githubTaskFetchedMsg:
+		if msg.Err != nil {
+			m.toastManager.Error("github fetch failed: " + msg.Err.Error())
+			m.state = stateDefault
+			return m, m.toastTickCmd()
+		}
+		m.state = stateDefault
+		return m.importGitHubTask(msg.Task)
+	case jiraTaskFetchedMsg:
+		if msg.Err != nil {
+			m.toastManager.Error("jira fetch failed: " + msg.Err.Error())
+			m.state = stateDefault
+			return m, m.toastTickCmd()
+		}
+		m.state = stateDefault
+		return m.importJiraTask(msg.Task)
 	case waveAdvanceMsg:
 		orch, ok := m.waveOrchestrators[msg.planFile]
 		if !ok {
@@ -1976,12 +2369,18 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if !ok {
 			return m, nil
 		}
+		if msg.timedOutOnly {
+			return m.retryTimedOutWaveTasks(orch, msg.entry)
+		}
 		return m.retryFailedWaveTasks(orch, msg.entry)
 	case waveAbortMsg:
 		delete(m.waveOrchestrators, msg.planFile)
+		m.removeWaveState(msg.planFile)
 		// Kill and remove all task instances that belong to the aborted plan.
-		// Their tmux sessions are already dead (tasks failed), so no worktree
-		// check is needed — just clean them out of the list.
+		// Their tmux sessions are already dead (tasks failed). They share the
+		// plan worktree, which the kill leaves in place along with any
+		// uncommitted work (the decision dialog says so), so just clean them
+		// out of the list.
 		// Collect first to avoid mutating m.allInstances while iterating it.
 		var taskInsts []*session.Instance
 		for _, inst := range m.allInstances {
@@ -1990,6 +2389,7 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 		for _, inst := range taskInsts {
+			m.undo.push(undoEntry{inst: inst, tmuxDead: true})
 			if m.nav.SelectInstance(inst) {
 				m.nav.Kill()
 			}
@@ -2155,6 +2555,8 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 		return m, nil
+	case reviewCycleOverrideMsg:
+		return m, m.overrideReviewCycleLimit(msg.planFile)
 	case plannerCompleteMsg:
 		// User confirmed: start implementation. Kill the planner instance (may still be alive
 		// when triggered by the PlannerFinished sentinel, unlike the tmux-death path).
@@ -2184,6 +2586,7 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		if m.autoYes {
 			msg.instance.AutoYes = true
+			msg.instance.AutoYesPatterns = m.appConfig.AutoYesPatterns
 		}
 		return m, tea.Batch(tea.RequestWindowSize, m.instanceChanged())
 	case instanceStartedMsg:
@@ -2207,6 +2610,7 @@ func (m *home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		if m.autoYes {
 			msg.instance.AutoYes = true
+			msg.instance.AutoYesPatterns = m.appConfig.AutoYesPatterns
 		}
 		return m, tea.Batch(tea.RequestWindowSize, m.instanceChanged())
 	case tea.ClipboardMsg:
@@ -2273,6 +2677,16 @@ func nextPreviewTickCmd(term *session.EmbeddedTerminal) tea.Cmd {
 	}
 }
 
+// captureScrollbackCmd captures the instance's full tmux history off the
+// update loop for the scroll-mode preview.
+func captureScrollbackCmd(inst *session.Instance) tea.Cmd {
+	title := inst.Title
+	return func() tea.Msg {
+		content, err := inst.PreviewFullHistory()
+		return previewScrollbackMsg{title: title, content: content, err: err}
+	}
+}
+
 func readClipboardCmd(selection byte) tea.Cmd {
 	return func() tea.Msg {
 		if selection == ansi.PrimaryClipboard {
@@ -2308,6 +2722,118 @@ func (m *home) handleQuit() (tea.Model, tea.Cmd) {
 	return m, tea.Quit
 }
 
+// handleQuitAndKill confirms, then tears down every started session before
+// quitting: sessions are stopped and instance-owned worktrees removed, while
+// branches are kept so the instances can be resumed later.
+func (m *home) handleQuitAndKill() (tea.Model, tea.Cmd) {
+	return m, m.confirmAction("quit kasmos and stop all sessions? worktrees will be removed; branches are kept.",
+		func() tea.Msg { return quitAndKillMsg{} })
+}
+
+// startQuitAndKill shows a progress toast and stops the sessions in the
+// background; quitAndKillResultMsg finishes the quit.
+func (m *home) startQuitAndKill() (tea.Model, tea.Cmd) {
+	var targets []*session.Instance
+	for _, inst := range m.nav.GetInstances() {
+		if inst.Started() && !inst.Paused() {
+			targets = append(targets, inst)
+		}
+	}
+	m.toastManager.Loading(fmt.Sprintf("stopping %d session(s)...", len(targets)))
+	return m, tea.Batch(m.toastTickCmd(), func() tea.Msg {
+		return quitAndKillResultMsg{stopped: stopInstances(targets)}
+	})
+}
+
+// pauseAllTargets returns the active repo's instances that pause-all (or,
+// when resume is set, resume-all) applies to: started, live sessions that are
+// not paused, or paused ones for resume.
+func (m *home) pauseAllTargets(resume bool) []*session.Instance {
+	var out []*session.Instance
+	for _, inst := range m.nav.GetInstances() {
+		if !inst.Started() || inst.Exited || inst.Paused() != resume {
+			continue
+		}
+		if !resume && inst.Status == session.Loading {
+			continue
+		}
+		out = append(out, inst)
+	}
+	return out
+}
+
+// confirmPauseAll asks once before pausing (or resuming) every applicable
+// instance in the active repo.
+func (m *home) confirmPauseAll(resume bool) (tea.Model, tea.Cmd) {
+	n := len(m.pauseAllTargets(resume))
+	repo := filepath.Base(m.activeRepoPath)
+	if n == 0 {
+		if resume {
+			m.toastManager.Info("no paused sessions to resume")
+		} else {
+			m.toastManager.Info("no running sessions to pause")
+		}
+		return m, m.toastTickCmd()
+	}
+	message := fmt.Sprintf("pause all %d running session(s) in %s?", n, repo)
+	if resume {
+		message = fmt.Sprintf("resume all %d paused session(s) in %s?", n, repo)
+	}
+	return m, m.confirmAction(message, func() tea.Msg { return pauseAllMsg{resume: resume} })
+}
+
+// startPauseAll shows a progress toast and pauses (or resumes) the targets in
+// the background, since each one may remove or recreate a git worktree.
+func (m *home) startPauseAll(resume bool) (tea.Model, tea.Cmd) {
+	targets := m.pauseAllTargets(resume)
+	verb := "pausing"
+	if resume {
+		verb = "resuming"
+	}
+	toastID := m.toastManager.Loading(fmt.Sprintf("%s %d session(s)...", verb, len(targets)))
+	return m, tea.Batch(m.toastTickCmd(), func() tea.Msg {
+		done, failed := pauseAllInstances(targets, resume)
+		return pauseAllResultMsg{resume: resume, toastID: toastID, done: done, failed: failed}
+	})
+}
+
+// pauseAllInstances pauses (or resumes) each instance, carrying on past
+// failures. Returns the number that succeeded and the titles that failed.
+func pauseAllInstances(targets []*session.Instance, resume bool) (done int, failed []string) {
+	for _, inst := range targets {
+		var err error
+		if resume {
+			err = inst.Resume()
+		} else {
+			err = inst.Pause()
+		}
+		if err != nil {
+			log.WarningLog.Printf("pause all: %s: %v", inst.Title, err)
+			failed = append(failed, inst.Title)
+			continue
+		}
+		done++
+	}
+	return done, failed
+}
+
+// stopInstances kills each instance and marks it paused.
+// Instances whose worktree cannot be removed (e.g. uncommitted changes) keep
+// their worktree but still have their session stopped. Returns the number of
+// instances stopped.
+func stopInstances(targets []*session.Instance) int {
+	n := 0
+	for _, inst := range targets {
+		if err := inst.Kill(); err != nil {
+			log.WarningLog.Printf("quit: stopping %s: %v", inst.Title, err)
+			inst.StopTmux()
+		}
+		inst.SetStatus(session.Paused)
+		n++
+	}
+	return n
+}
+
 func (m *home) View() tea.View {
 	// All columns use identical padding and height for uniform alignment.
 	colStyle := lipgloss.NewStyle().Height(m.contentHeight)
@@ -2381,10 +2907,57 @@ type permissionResponseMsg struct {
 	pattern  string
 }
 
+// planCopiedMsg carries a plan's raw markdown read for copying to the clipboard.
+type planCopiedMsg struct {
+	content string
+	err     error
+}
+
+// planRefreshMsg is sent when the editor launched by editSelectedPlan exits.
+// path holds the edited markdown; created is set when kasmos wrote the file
+// only for the edit and should remove it afterwards.
+type planRefreshMsg struct {
+	planFile string
+	path     string
+	created  bool
+	err      error
+}
+
+// diffExportedMsg is sent when exporting an instance's diff to a .patch file
+// finishes.
+type diffExportedMsg struct {
+	path string
+	err  error
+}
+
+// planRebasedMsg is sent when rebasing a plan's worktree onto main finishes.
+type planRebasedMsg struct {
+	planFile string
+	branch   string
+	err      error
+}
+
+// branchReattachedMsg is sent when reattaching an instance's detached HEAD to
+// its branch finishes.
+type branchReattachedMsg struct {
+	instance *session.Instance
+	err      error
+}
+
+// planBranchRenamedMsg is sent when renaming a plan's git branch finishes.
+type planBranchRenamedMsg struct {
+	planFile  string
+	oldBranch string
+	newBranch string
+	err       error
+}
+
 // prCreatedMsg is sent when async PR creation succeeds.
 type prCreatedMsg struct {
 	instanceTitle string
 	prTitle       string
+	planFile      string // set for plan-level PRs
+	url           string // PR/MR URL printed by the forge CLI; "" when unknown
 }
 
 // prCreatedForPlanMsg is sent when automatic PR creation on review approval succeeds.
@@ -2400,6 +2973,13 @@ type prStateUpdateMsg struct {
 	checkStatus    string
 }
 
+// prSquashAction is the PR body overlay toggle action requesting that branch
+// commits be squashed into one before the PR is created.
+const prSquashAction = "squash"
+
+// prDraftSuffix is appended to the PR overlay titles while draft mode is on.
+const prDraftSuffix = " [draft]"
+
 // prErrorMsg is sent when async PR creation fails.
 type prErrorMsg struct {
 	id  string
@@ -2409,6 +2989,13 @@ type prErrorMsg struct {
 // previewTickMsg implements tea.Msg and triggers a preview update
 type previewTickMsg struct{}
 
+// previewScrollbackMsg carries a scroll-mode history capture back to Update.
+type previewScrollbackMsg struct {
+	title   string
+	content string
+	err     error
+}
+
 type tickUpdateMetadataMessage struct{}
 
 // previewTerminalReadyMsg signals that the async terminal attach completed.
@@ -2426,6 +3013,12 @@ type killInstanceMsg struct {
 	title string
 }
 
+// requeuePromptMsg is sent when the user confirms re-sending an instance's
+// last prompt after resuming it.
+type requeuePromptMsg struct {
+	title string
+}
+
 // taskStageConfirmedMsg is sent when the user confirms proceeding past the
 // topic-concurrency gate. Re-enters plan stage execution skipping the
 // concurrency check that was already acknowledged.
@@ -2437,6 +3030,36 @@ type taskStageConfirmedMsg struct {
 // taskRefreshMsg triggers a plan state reload and sidebar refresh in Update.
 type taskRefreshMsg struct{}
 
+// topicStatusSetMsg is sent when the user confirms a bulk status change for
+// every plan in a topic.
+type topicStatusSetMsg struct {
+	topic     string
+	filenames []string
+	status    taskstate.Status
+}
+
+// quitAndKillMsg is sent when the user confirms quitting and stopping every
+// session.
+type quitAndKillMsg struct{}
+
+// quitAndKillResultMsg reports how many sessions the quit-and-kill stopped.
+type quitAndKillResultMsg struct {
+	stopped int
+}
+
+// pauseAllMsg is sent when the user confirms pause-all (or resume-all).
+type pauseAllMsg struct {
+	resume bool
+}
+
+// pauseAllResultMsg reports the outcome of a background pause-all/resume-all.
+type pauseAllResultMsg struct {
+	resume  bool
+	toastID string
+	done    int
+	failed  []string // titles that could not be paused/resumed
+}
+
 // waveAdvanceMsg is sent when the user confirms advancing to the next wave.
 type waveAdvanceMsg struct {
 	planFile string
@@ -2444,9 +3067,11 @@ type waveAdvanceMsg struct {
 }
 
 // waveRetryMsg is sent when the user chooses "retry" on the failed-wave decision prompt.
+// timedOutOnly restricts the retry to tasks failed by the wave task timeout.
 type waveRetryMsg struct {
-	planFile string
-	entry    taskstate.TaskEntry
+	planFile     string
+	entry        taskstate.TaskEntry
+	timedOutOnly bool
 }
 
 // waveAbortMsg is sent when the user chooses "abort" on the failed-wave decision prompt.
@@ -2477,6 +3102,12 @@ type plannerCompleteMsg struct {
 	planFile string
 }
 
+// reviewCycleOverrideMsg is sent when the user confirms spawning another fixer
+// after the review-fix loop hit its cycle limit.
+type reviewCycleOverrideMsg struct {
+	planFile string
+}
+
 // tmuxSessionsMsg carries discovered kas_ tmux sessions (managed + orphaned).
 type tmuxSessionsMsg struct {
 	sessions []tmux.SessionInfo
@@ -2510,6 +3141,40 @@ type clickUpTaskFetchedMsg struct {
 	Err  error
 }
 
+// githubDetectedMsg is sent at startup when the origin remote is on github.com.
+type githubDetectedMsg struct {
+	Repo github.Repo
+}
+
+// githubSearchResultMsg is sent when a GitHub issue search completes.
+type githubSearchResultMsg struct {
+	Results []github.SearchResult
+	Err     error
+}
+
+// githubTaskFetchedMsg is sent when a full GitHub issue is fetched.
+type githubTaskFetchedMsg struct {
+	Task *github.Task
+	Err  error
+}
+
+// jiraDetectedMsg is sent at startup when a Jira site is configured for the repo.
+type jiraDetectedMsg struct {
+	Config jira.Config
+}
+
+// jiraSearchResultMsg is sent when a Jira issue search completes.
+type jiraSearchResultMsg struct {
+	Results []jira.SearchResult
+	Err     error
+}
+
+// jiraTaskFetchedMsg is sent when a full Jira issue is fetched.
+type jiraTaskFetchedMsg struct {
+	Task *jira.Task
+	Err  error
+}
+
 // addInstanceFinalizer registers a finalizer for the given instance.
 // Lazily initializes the map so tests that don't pre-initialize it still work.
 func (m *home) addInstanceFinalizer(inst *session.Instance, fn func()) {
@@ -2547,6 +3212,13 @@ type instanceMetadata struct {
 	ResourceUsageValid bool
 	TmuxAlive          bool
 	PermissionPrompt   *session.PermissionPrompt // non-nil when opencode shows a permission dialog
+	Usage              session.TokenUsage        // token/cost figures parsed from the pane; zero when absent
+	ConflictsChecked   bool                      // true when the worktree was probed for unmerged paths
+	HasConflicts       bool
+	ConflictFiles      []string
+	HeadSHA            string // coder worktree HEAD, used to detect new commits for auto-push
+	DetachedChecked    bool   // true when the worktree was probed for a detached HEAD
+	Detached           bool
 }
 
 // metadataResultMsg carries all per-instance metadata collected by the async tick.
@@ -2562,12 +3234,31 @@ type metadataResultMsg struct {
 	PRStateUpdates     []prStateUpdateMsg          // PR review/check state refreshed this tick
 }
 
-// tickUpdateMetadataCmd is the callback to update the metadata of the instances every 200ms. We iterate
-// over all instances and capture their output, but each tmux capture-pane call is <5ms so this is fine
-// even at 20 instances (~100ms total). 200ms gives 5 ticks/sec for responsive signal processing.
-var tickUpdateMetadataCmd = func() tea.Msg {
-	time.Sleep(200 * time.Millisecond)
-	return tickUpdateMetadataMessage{}
+// tickUpdateMetadataCmd schedules the next metadata update after the configured
+// metadata tick (200ms by default). We iterate over all instances and capture
+// their output, but each tmux capture-pane call is <5ms so this is fine even at
+// 20 instances (~100ms total). 200ms gives 5 ticks/sec for responsive signal
+// processing; slower machines can raise metadata_tick_ms to save battery.
+func (m *home) tickUpdateMetadataCmd() tea.Cmd {
+	interval := m.metadataTickInterval()
+	return func() tea.Msg {
+		time.Sleep(interval)
+		return tickUpdateMetadataMessage{}
+	}
+}
+
+// metadataTickInterval returns the configured metadata tick, or the default
+// when no config is loaded.
+func (m *home) metadataTickInterval() time.Duration {
+	if m.appConfig == nil {
+		return config.DefaultMetadataTickMs * time.Millisecond
+	}
+	return m.appConfig.MetadataTickInterval()
+}
+
+// vimMode reports whether h/j/k/l navigation is enabled in the config.
+func (m *home) vimMode() bool {
+	return m.appConfig != nil && m.appConfig.VimMode
 }
 
 func (m *home) toastTickCmd() tea.Cmd {
@@ -2577,9 +3268,25 @@ func (m *home) toastTickCmd() tea.Cmd {
 	}
 }
 
+// startClickUpOp creates the context of a ClickUp search or fetch and keeps
+// its cancel func so Esc can abort the operation.
+func (m *home) startClickUpOp() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(m.ctx, clickUpOpTimeout)
+	m.clickUpCancel = cancel
+	return ctx, cancel
+}
+
+// cancelClickUpOp aborts the in-flight ClickUp search or fetch, if any.
+func (m *home) cancelClickUpOp() {
+	if m.clickUpCancel != nil {
+		m.clickUpCancel()
+		m.clickUpCancel = nil
+	}
+}
+
 func (m *home) searchClickUp(query string) tea.Cmd {
+	ctx, cancel := m.startClickUpOp()
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(m.ctx, clickUpOpTimeout)
 		defer cancel()
 
 		importer, err := m.getOrCreateImporter(ctx)
@@ -2616,8 +3323,8 @@ func (m *home) searchClickUp(query string) tea.Cmd {
 }
 
 func (m *home) fetchClickUpTaskWithTimeout(taskID string) tea.Cmd {
+	ctx, cancel := m.startClickUpOp()
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(m.ctx, clickUpOpTimeout)
 		defer cancel()
 
 		if m.clickUpImporter == nil {
@@ -2649,7 +3356,7 @@ func normalizeClickUpError(err error) error {
 		return nil
 	}
 	if errors.Is(err, context.Canceled) {
-		return fmt.Errorf("operation canceled")
+		return errClickUpCanceled
 	}
 	if errors.Is(err, context.DeadlineExceeded) {
 		return fmt.Errorf("operation timed out after %s", clickUpOpTimeout)
@@ -2670,7 +3377,14 @@ func (m *home) getOrCreateImporter(ctx context.Context) (*clickup.Importer, erro
 		return nil, err
 	}
 
-	client, err := mcpclient.NewClient(transport)
+	// Transient drops of the MCP server are retried with a fresh transport.
+	cfg := *m.clickUpConfig
+	client, err := mcpclient.NewClient(transport,
+		mcpclient.WithRetry(clickUpRetryAttempts, clickUpRetryBaseDelay),
+		mcpclient.WithReconnect(func() (mcpclient.Transport, error) {
+			return m.createTransport(m.ctx, cfg)
+		}),
+	)
 	if err != nil {
 		_ = transport.Close()
 		return nil, err
@@ -2683,6 +3397,12 @@ func (m *home) getOrCreateImporter(ctx context.Context) (*clickup.Importer, erro
 		_ = client.Close()
 		return nil, fmt.Errorf("MCP list tools: %w", err)
 	}
+	// The handshake does not watch ctx; drop a client the user gave up on
+	// rather than caching it.
+	if err := ctx.Err(); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
 
 	m.clickUpMCPClient = client
 	m.clickUpImporter = clickup.NewImporter(client)
@@ -2703,6 +3423,12 @@ func (m *home) createTransport(ctx context.Context, cfg clickup.MCPServerConfig)
 			return nil, err
 		}
 		return mcpclient.NewHTTPTransport(cfg.URL, token), nil
+	case "sse":
+		token, err := m.getClickUpToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return mcpclient.NewSSETransport(cfg.URL, token), nil
 	case "stdio":
 		envSlice := make([]string, 0, len(cfg.Env))
 		for k, v := range cfg.Env {
@@ -2744,6 +3470,119 @@ func (m *home) getClickUpToken(ctx context.Context) (string, error) {
 	return tok.AccessToken, nil
 }
 
+func (m *home) searchGitHub(query string) tea.Cmd {
+	importer, err := m.getOrCreateGitHubImporter()
+	return func() tea.Msg {
+		if err != nil {
+			return githubSearchResultMsg{Err: err}
+		}
+		results, searchErr := importer.Search(query)
+		return githubSearchResultMsg{Results: results, Err: searchErr}
+	}
+}
+
+func (m *home) fetchGitHubTask(number int) tea.Cmd {
+	importer, err := m.getOrCreateGitHubImporter()
+	return func() tea.Msg {
+		if err != nil {
+			return githubTaskFetchedMsg{Err: err}
+		}
+		task, fetchErr := importer.FetchTask(number)
+		return githubTaskFetchedMsg{Task: task, Err: fetchErr}
+	}
+}
+
+// getOrCreateGitHubImporter lazily builds the issue importer for the detected
+// origin. Auth comes from GITHUB_TOKEN; without it only public repositories
+// are reachable.
+func (m *home) getOrCreateGitHubImporter() (*github.Importer, error) {
+	if m.githubImporter != nil {
+		return m.githubImporter, nil
+	}
+	if m.githubRepo == nil {
+		return nil, fmt.Errorf("no github origin remote detected")
+	}
+	m.githubImporter = github.NewImporter(*m.githubRepo, os.Getenv("GITHUB_TOKEN"))
+	return m.githubImporter, nil
+}
+
+// githubPickerLabel renders a search result as a picker row ("#42 · title (open)").
+func githubPickerLabel(r github.SearchResult) string {
+	label := fmt.Sprintf("#%d · %s", r.Number, r.Title)
+	if r.State != "" {
+		label += " (" + r.State + ")"
+	}
+	return label
+}
+
+func detectGitHubCmd(repoPath string) tea.Cmd {
+	return func() tea.Msg {
+		repo, found := github.DetectGitHub(repoPath)
+		if !found {
+			return nil
+		}
+		return githubDetectedMsg{Repo: repo}
+	}
+}
+
+func (m *home) searchJira(query string) tea.Cmd {
+	importer, err := m.getOrCreateJiraImporter()
+	return func() tea.Msg {
+		if err != nil {
+			return jiraSearchResultMsg{Err: err}
+		}
+		results, searchErr := importer.Search(query)
+		return jiraSearchResultMsg{Results: results, Err: searchErr}
+	}
+}
+
+func (m *home) fetchJiraTask(key string) tea.Cmd {
+	importer, err := m.getOrCreateJiraImporter()
+	return func() tea.Msg {
+		if err != nil {
+			return jiraTaskFetchedMsg{Err: err}
+		}
+		task, fetchErr := importer.FetchTask(key)
+		return jiraTaskFetchedMsg{Task: task, Err: fetchErr}
+	}
+}
+
+// getOrCreateJiraImporter lazily builds the issue importer for the detected
+// Jira site. Auth comes from JIRA_TOKEN.
+func (m *home) getOrCreateJiraImporter() (*jira.Importer, error) {
+	if m.jiraImporter != nil {
+		return m.jiraImporter, nil
+	}
+	if m.jiraConfig == nil {
+		return nil, fmt.Errorf("no jira site configured")
+	}
+	token := os.Getenv(jira.EnvToken)
+	if token == "" {
+		return nil, fmt.Errorf("%s is not set", jira.EnvToken)
+	}
+	m.jiraImporter = jira.NewImporter(*m.jiraConfig, token)
+	return m.jiraImporter, nil
+}
+
+// jiraPickerLabel renders a search result as a picker row ("WID-42 · title (To Do)").
+func jiraPickerLabel(r jira.SearchResult) string {
+	label := fmt.Sprintf("%s · %s", r.Key, r.Summary)
+	if r.Status != "" {
+		label += " (" + r.Status + ")"
+	}
+	return label
+}
+
+func detectJiraCmd(repoPath string) tea.Cmd {
+	return func() tea.Msg {
+		cfg, found := jira.DetectJira(repoPath)
+		if !found {
+			return nil
+		}
+		return jiraDetectedMsg{Config: cfg}
+	}
+}
+
 func detectClickUpCmd(repoPath string) tea.Cmd {
 	return func() tea.Msg {
 		claudeDir := filepath.Join(os.Getenv("HOME"), ".claude")
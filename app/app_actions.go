@@ -1,16 +1,23 @@
 package app
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/kastheco/kasmos/config"
 	"github.com/kastheco/kasmos/config/auditlog"
 	"github.com/kastheco/kasmos/config/taskfsm"
 	"github.com/kastheco/kasmos/config/taskparser"
 	"github.com/kastheco/kasmos/config/taskstate"
+	"github.com/kastheco/kasmos/config/taskstore"
 	"github.com/kastheco/kasmos/internal/initcmd/scaffold"
 	"github.com/kastheco/kasmos/keys"
 	"github.com/kastheco/kasmos/orchestration"
@@ -18,6 +25,7 @@ import (
 	gitpkg "github.com/kastheco/kasmos/session/git"
 	"github.com/kastheco/kasmos/session/tmux"
 	"github.com/kastheco/kasmos/ui"
+	kclipboard "github.com/kastheco/kasmos/ui/clipboard"
 	"github.com/kastheco/kasmos/ui/overlay"
 
 	tea "charm.land/bubbletea/v2"
@@ -88,6 +96,7 @@ func (m *home) executeContextAction(action string) (tea.Model, tea.Cmd) {
 				auditlog.WithPlan(selected.TaskFile),
 			)
 			m.saveAllInstances()
+			m.offerRequeuePrompt(selected)
 		}
 		return m, tea.Batch(tea.RequestWindowSize, m.instanceChanged())
 
@@ -103,10 +112,7 @@ func (m *home) executeContextAction(action string) (tea.Model, tea.Cmd) {
 		if selected == nil {
 			return m, nil
 		}
-		m.state = statePRTitle
-		tio := overlay.NewTextInputOverlay("pr title", selected.Title)
-		tio.SetSize(60, 3)
-		m.overlays.Show(tio)
+		m.openPRTitleOverlay(selected.Title)
 		return m, nil
 
 	case "send_prompt_instance":
@@ -133,12 +139,32 @@ func (m *home) executeContextAction(action string) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case "copy_branch_name":
+		if m.nav.GetSelectedInstance() == nil {
+			return m, nil
+		}
+		return m, m.copySelectedBranch()
+
+	case "reattach_branch":
 		selected := m.nav.GetSelectedInstance()
-		if selected == nil {
+		if selected == nil || !selected.DetachedHead {
 			return m, nil
 		}
-		_ = clipboard.WriteAll(selected.Branch)
-		return m, nil
+		worktree, err := selected.GetGitWorktree()
+		if err != nil {
+			return m, m.handleError(err)
+		}
+		orphaned, err := worktree.OrphanedCommits()
+		if err != nil {
+			return m, m.handleError(err)
+		}
+		if orphaned > 0 {
+			message := fmt.Sprintf("%d commit(s) made on the detached HEAD are not on %s and will be left behind. reattach anyway?",
+				orphaned, selected.Branch)
+			return m, m.confirmAction(message, func() tea.Msg {
+				return branchReattachedMsg{instance: selected, err: worktree.Reattach(true)}
+			})
+		}
+		return m, m.handleBranchReattached(branchReattachedMsg{instance: selected, err: worktree.Reattach(false)})
 
 	case "rename_instance":
 		selected := m.nav.GetSelectedInstance()
@@ -158,10 +184,19 @@ func (m *home) executeContextAction(action string) (tea.Model, tea.Cmd) {
 		}
 		orch, ok := m.waveOrchestrators[selected.TaskFile]
 		if !ok {
-			return m, nil
+			m.toastManager.Error(fmt.Sprintf("no wave orchestration running for %s", taskstate.DisplayName(selected.TaskFile)))
+			return m, m.toastTickCmd()
 		}
+		// Mark the task before pausing: the wave monitor treats a paused
+		// instance whose task is still running as a failure.
 		orch.MarkTaskComplete(selected.TaskNumber)
-		selected.SetStatus(session.Ready)
+		selected.ImplementationComplete = true
+		if err := selected.Pause(); err != nil {
+			// Not started or already gone; the task still counts as done.
+			selected.SetStatus(session.Ready)
+		}
+		m.saveAllInstances()
+		m.updateNavPanelStatus()
 		m.toastManager.Success(fmt.Sprintf("task %d marked complete", selected.TaskNumber))
 		return m, tea.Batch(m.instanceChanged(), m.toastTickCmd())
 
@@ -179,14 +214,83 @@ func (m *home) executeContextAction(action string) (tea.Model, tea.Cmd) {
 		m.state = stateChangeTopic
 		return m, nil
 
-	case "set_status":
+	case "set_priority":
 		planFile := m.nav.GetSelectedPlanFile()
 		if planFile == "" {
 			return m, nil
 		}
-		m.pendingSetStatusTask = planFile
-		statuses := []string{"ready", "planning", "implementing", "reviewing", "done", "cancelled"}
-		m.overlays.Show(overlay.NewPickerOverlay("set status", statuses))
+		m.pendingSetPriorityTask = planFile
+		m.overlays.Show(overlay.NewPickerOverlay("set priority", priorityLabels))
+		m.state = stateSetPriority
+		return m, nil
+
+	case "toggle_pin_plan":
+		planFile := m.nav.GetSelectedPlanFile()
+		if planFile == "" || m.taskState == nil {
+			return m, nil
+		}
+		entry, _ := m.taskState.Entry(planFile)
+		if err := m.taskState.SetPinned(planFile, !entry.Pinned); err != nil {
+			return m, m.handleError(err)
+		}
+		m.updateSidebarTasks()
+		if entry.Pinned {
+			m.toastManager.Info(fmt.Sprintf("unpinned '%s'", taskstate.DisplayName(planFile)))
+		} else {
+			m.toastManager.Success(fmt.Sprintf("pinned '%s'", taskstate.DisplayName(planFile)))
+		}
+		return m, m.toastTickCmd()
+
+	case "toggle_auto_push":
+		planFile := m.nav.GetSelectedPlanFile()
+		if planFile == "" || m.taskState == nil {
+			return m, nil
+		}
+		enabled := !m.taskState.AutoPushEnabled(planFile, m.appConfig != nil && m.appConfig.AutoPush)
+		if err := m.taskState.SetAutoPush(planFile, &enabled); err != nil {
+			return m, m.handleError(err)
+		}
+		if enabled {
+			m.toastManager.Success(fmt.Sprintf("auto-push on for '%s'", taskstate.DisplayName(planFile)))
+		} else {
+			m.toastManager.Info(fmt.Sprintf("auto-push off for '%s'", taskstate.DisplayName(planFile)))
+		}
+		return m, m.toastTickCmd()
+
+	case "set_status":
+		return m.openSetStatusPicker(m.nav.GetSelectedPlanFile())
+
+	case "set_blockers":
+		planFile := m.nav.GetSelectedPlanFile()
+		if planFile == "" || m.taskState == nil {
+			return m, nil
+		}
+		var candidates []string
+		for f, entry := range m.taskState.Plans {
+			if f != planFile && entry.Status != taskstate.StatusArchived {
+				candidates = append(candidates, f)
+			}
+		}
+		if len(candidates) == 0 {
+			m.toastManager.Info("no other tasks to block on")
+			return m, m.toastTickCmd()
+		}
+		sort.Strings(candidates)
+		entry, _ := m.taskState.Entry(planFile)
+		po := overlay.NewPickerOverlay(fmt.Sprintf("blockers for '%s'", taskstate.DisplayName(planFile)), candidates)
+		po.SetMultiSelect(entry.BlockedBy)
+		m.pendingSetBlockersTask = planFile
+		m.overlays.Show(po)
+		m.state = stateSetBlockers
+		return m, nil
+
+	case "set_topic_status":
+		topic := m.nav.GetSelectedTopic()
+		if topic == "" {
+			return m, nil
+		}
+		m.pendingSetStatusTopic = topic
+		m.overlays.Show(overlay.NewPickerOverlay("set status for '"+topic+"'", setStatusOptions))
 		m.state = stateSetStatus
 		return m, nil
 
@@ -244,11 +348,7 @@ func (m *home) executeContextAction(action string) (tea.Model, tea.Cmd) {
 		if entry.Status == taskstate.StatusReviewing {
 			if m.appConfig != nil && m.appConfig.MaxReviewFixCycles > 0 {
 				if cycle, err := m.taskState.ReviewCycle(planFile); err == nil && cycle+1 > m.appConfig.MaxReviewFixCycles {
-					planName := taskstate.DisplayName(planFile)
-					m.toastManager.Error(fmt.Sprintf(
-						"review-fix loop stopped: cycle limit reached (%d/%d) for %s",
-						cycle+1, m.appConfig.MaxReviewFixCycles, planName))
-					return m, m.toastTickCmd()
+					return m, tea.Batch(append(cmds, m.reviewCycleLimitReached(planFile, cycle+1, m.appConfig.MaxReviewFixCycles))...)
 				}
 			}
 			if err := m.fsm.Transition(planFile, taskfsm.ReviewChangesRequested); err != nil {
@@ -278,6 +378,18 @@ func (m *home) executeContextAction(action string) (tea.Model, tea.Cmd) {
 	case "view_plan":
 		return m.viewSelectedPlan()
 
+	case "edit_plan":
+		return m.editSelectedPlan()
+
+	case "diagnose_plan":
+		planFile := m.nav.GetSelectedPlanFile()
+		if planFile == "" {
+			return m, nil
+		}
+		m.overlays.Show(overlay.NewTextOverlay(m.diagnosePlan(planFile)))
+		m.state = stateHelp
+		return m, nil
+
 	case "open_plan_browser":
 		return m.openPlanBrowserForSelection()
 
@@ -293,6 +405,13 @@ func (m *home) executeContextAction(action string) (tea.Model, tea.Cmd) {
 		m.overlays.Show(tio)
 		return m, nil
 
+	case "duplicate_plan":
+		planFile := m.nav.GetSelectedPlanFile()
+		if planFile == "" {
+			return m, nil
+		}
+		return m.duplicatePlan(planFile)
+
 	case "chat_about_plan":
 		planFile := m.nav.GetSelectedPlanFile()
 		if planFile == "" {
@@ -330,7 +449,7 @@ func (m *home) executeContextAction(action string) (tea.Model, tea.Cmd) {
 		message := fmt.Sprintf("push changes from plan '%s'?", planInst.Title)
 		return m, m.confirmAction(message, pushAction)
 
-	case "create_plan_pr":
+	case "rebase_plan":
 		planFile := m.nav.GetSelectedPlanFile()
 		if planFile == "" || m.taskState == nil {
 			return m, m.handleError(fmt.Errorf("no plan selected"))
@@ -339,24 +458,25 @@ func (m *home) executeContextAction(action string) (tea.Model, tea.Cmd) {
 		if !ok || entry.Branch == "" {
 			return m, m.handleError(fmt.Errorf("plan has no branch — implement it first"))
 		}
-		// Prefer to use the running instance so GetSelectedInstance() works
-		// in the PR-body submission path. Fall back to a worktree-only approach
-		// (pendingPRWorktree) when there is no instance or the instance has an
-		// empty branch (e.g. started on main without a worktree).
-		planInst := m.findTaskInstance()
-		if planInst != nil && planInst.Branch != "" {
-			m.nav.SelectInstance(planInst)
-			m.pendingPRWorktree = nil
-		} else {
-			// No valid running instance — build a GitWorktree directly from the
-			// task store's authoritative branch so PR creation still works.
-			m.pendingPRWorktree = gitpkg.NewSharedTaskWorktree(m.activeRepoPath, entry.Branch)
+		return m, rebasePlanCmd(m.activeRepoPath, planFile, entry.Branch)
+
+	case "rename_plan_branch":
+		return m.renamePlanBranch()
+
+	case "create_plan_pr":
+		planFile := m.nav.GetSelectedPlanFile()
+		if planFile == "" || m.taskState == nil {
+			return m, m.handleError(fmt.Errorf("no plan selected"))
 		}
-		defaultTitle := taskstate.DisplayName(planFile)
-		m.state = statePRTitle
-		tio := overlay.NewTextInputOverlay("pr title", defaultTitle)
-		tio.SetSize(60, 3)
-		m.overlays.Show(tio)
+		entry, ok := m.taskState.Entry(planFile)
+		if !ok || entry.Branch == "" {
+			return m, m.handleError(fmt.Errorf("plan has no branch — implement it first"))
+		}
+		// The PR always comes from the plan's shared branch so it carries every
+		// wave task's commits, not just those of one instance.
+		m.pendingPRWorktree = gitpkg.NewSharedTaskWorktree(m.activeRepoPath, entry.Branch)
+		m.pendingPRPlan = planFile
+		m.openPRTitleOverlay(gitpkg.BuildPRTitle(entry.Description, taskstate.DisplayName(planFile)))
 		return m, nil
 
 	case "merge_plan":
@@ -408,6 +528,31 @@ func (m *home) executeContextAction(action string) (tea.Model, tea.Cmd) {
 		}
 		return m, m.confirmAction(fmt.Sprintf("merge '%s' branch into main?", planName), mergeAction)
 
+	case "reopen_plan":
+		planFile := m.nav.GetSelectedPlanFile()
+		if planFile == "" || m.taskState == nil {
+			return m, nil
+		}
+		entry, ok := m.taskState.Entry(planFile)
+		if !ok {
+			return m, m.handleError(fmt.Errorf("task not found: %s", planFile))
+		}
+		if entry.Status != taskstate.StatusDone && entry.Status != taskstate.StatusCancelled {
+			return m, m.handleError(fmt.Errorf("only done or cancelled tasks can be reopened"))
+		}
+		if err := m.fsm.Transition(planFile, taskfsm.Reopen); err != nil {
+			return m, m.handleError(err)
+		}
+		m.loadTaskState()
+		reopened, _ := m.taskState.Entry(planFile)
+		m.audit(auditlog.EventPlanReopened,
+			fmt.Sprintf("%s → %s (reopened)", entry.Status, reopened.Status),
+			auditlog.WithPlan(planFile))
+		m.updateSidebarTasks()
+		m.nav.SelectByID(ui.SidebarPlanPrefix + planFile)
+		m.toastManager.Success(fmt.Sprintf("reopened '%s'", taskstate.DisplayName(planFile)))
+		return m, tea.Batch(tea.RequestWindowSize, m.toastTickCmd())
+
 	case "mark_plan_done":
 		planFile := m.nav.GetSelectedPlanFile()
 		if planFile == "" || m.taskState == nil {
@@ -462,6 +607,13 @@ func (m *home) executeContextAction(action string) (tea.Model, tea.Cmd) {
 		m.updateSidebarTasks()
 		return m, tea.RequestWindowSize
 
+	case "resume_plan_agents":
+		planFile := m.nav.GetSelectedPlanFile()
+		if planFile == "" {
+			return m, nil
+		}
+		return m.resumePlanAgents(planFile)
+
 	case "cancel_plan":
 		planFile := m.nav.GetSelectedPlanFile()
 		if planFile == "" || m.taskState == nil {
@@ -546,6 +698,13 @@ func (m *home) executeContextAction(action string) (tea.Model, tea.Cmd) {
 			return instanceChangedMsg{}
 		}
 
+	case "clone_instance":
+		selected := m.nav.GetSelectedInstance()
+		if selected == nil {
+			return m, nil
+		}
+		return m.cloneInstance(selected)
+
 	case "toggle_auto_advance":
 		if m.appConfig == nil {
 			return m, nil
@@ -773,6 +932,8 @@ func (m *home) openContextMenu() (tea.Model, tea.Cmd) {
 			// fall through to instance context menu below
 		} else if planFile := m.nav.GetSelectedPlanFile(); planFile != "" {
 			return m.openTaskContextMenu()
+		} else if m.nav.GetSelectedTopic() != "" {
+			return m.openTopicContextMenu()
 		} else {
 			return m, nil
 		}
@@ -789,6 +950,7 @@ func (m *home) openContextMenu() (tea.Model, tea.Cmd) {
 		{Label: "open", Action: "open_instance"},
 		{Label: "kill", Action: "kill_instance"},
 		{Label: "restart", Action: "restart_instance"},
+		{Label: "clone session", Action: "clone_instance"},
 	}
 	if selected.Status == session.Paused {
 		sessionItems = append(sessionItems, overlay.ContextMenuItem{Label: "resume", Action: "resume_instance"})
@@ -807,6 +969,9 @@ func (m *home) openContextMenu() (tea.Model, tea.Cmd) {
 	if selected.TaskFile != "" {
 		syncItems = append(syncItems, overlay.ContextMenuItem{Label: "open in browser", Action: "open_plan_browser"})
 	}
+	if selected.DetachedHead {
+		syncItems = append(syncItems, overlay.ContextMenuItem{Label: "reattach to branch", Action: "reattach_branch"})
+	}
 
 	// manage group: rename and wave task completion
 	manageItems := []overlay.ContextMenuItem{
@@ -814,7 +979,7 @@ func (m *home) openContextMenu() (tea.Model, tea.Cmd) {
 	}
 	if selected.TaskNumber > 0 {
 		if orch, ok := m.waveOrchestrators[selected.TaskFile]; ok && orch.IsTaskRunning(selected.TaskNumber) {
-			manageItems = append(manageItems, overlay.ContextMenuItem{Label: "mark complete", Action: "mark_task_complete"})
+			manageItems = append(manageItems, overlay.ContextMenuItem{Label: "mark task complete", Action: "mark_task_complete"})
 		}
 	}
 
@@ -834,6 +999,41 @@ func (m *home) openContextMenu() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// openSetStatusPicker opens the status override picker for planFile.
+func (m *home) openSetStatusPicker(planFile string) (tea.Model, tea.Cmd) {
+	if planFile == "" {
+		return m, nil
+	}
+	m.pendingSetStatusTask = planFile
+	m.overlays.Show(overlay.NewPickerOverlay("set status", setStatusOptions))
+	m.state = stateSetStatus
+	return m, nil
+}
+
+// fastAbortExited removes an exited instance from the list without asking for
+// confirmation: its session is already dead, so only the worktree is left to
+// clean up, which happens in the background. The removal can be undone while
+// the worktree still exists.
+func (m *home) fastAbortExited(inst *session.Instance) (tea.Model, tea.Cmd) {
+	m.audit(auditlog.EventAgentKilled, "exited instance removed",
+		auditlog.WithInstance(inst.Title),
+		auditlog.WithAgent(inst.AgentType),
+		auditlog.WithPlan(inst.TaskFile),
+	)
+	m.undo.push(undoEntry{inst: inst, tmuxDead: true})
+	m.nav.RemoveByTitle(inst.Title)
+	m.removeFromAllInstances(inst.Title)
+	_ = m.saveAllInstances()
+	m.updateNavPanelStatus()
+	cleanup := func() tea.Msg {
+		if err := inst.RemoveWorktree(); err != nil {
+			return fmt.Errorf("removed '%s': %w", inst.Title, err)
+		}
+		return nil
+	}
+	return m, tea.Batch(tea.RequestWindowSize, m.instanceChanged(), cleanup)
+}
+
 func (m *home) openTaskContextMenu() (tea.Model, tea.Cmd) {
 	planFile := m.nav.GetSelectedPlanFile()
 	if planFile == "" {
@@ -877,11 +1077,17 @@ func (m *home) openTaskContextMenu() (tea.Model, tea.Cmd) {
 		}
 	}
 
+	if len(m.pausedPlanInstances(planFile)) > 0 {
+		startItems = append(startItems, overlay.ContextMenuItem{Label: "resume all agents", Action: "resume_plan_agents"})
+	}
+
 	// view group: read-only inspection and browsing.
 	viewItems := []overlay.ContextMenuItem{
 		{Label: "chat about this", Action: "chat_about_plan"},
 		{Label: "view task", Action: "view_plan"},
+		{Label: "edit in $EDITOR", Action: "edit_plan"},
 		{Label: "open in browser", Action: "open_plan_browser"},
+		{Label: "diagnose", Action: "diagnose_plan"},
 	}
 	// History plans get an "inspect task" option to move them to the dead section.
 	if m.nav.IsSelectedHistoryPlan() {
@@ -890,7 +1096,9 @@ func (m *home) openTaskContextMenu() (tea.Model, tea.Cmd) {
 
 	// sync group: branch and PR operations.
 	syncItems := []overlay.ContextMenuItem{
-		{Label: "create pr", Action: "create_plan_pr"},
+		{Label: "open plan pr", Action: "create_plan_pr"},
+		{Label: "rebase onto main", Action: "rebase_plan"},
+		{Label: "rename branch", Action: "rename_plan_branch"},
 		{Label: "merge to main", Action: "merge_plan"},
 	}
 
@@ -903,11 +1111,24 @@ func (m *home) openTaskContextMenu() (tea.Model, tea.Cmd) {
 	if m.appConfig != nil && m.appConfig.AutoReviewFix {
 		autoReviewFixLabel = "auto review-fix loop: on"
 	}
+	pinLabel := "pin to top"
+	if m.taskState != nil && m.taskState.Plans[planFile].Pinned {
+		pinLabel = "unpin"
+	}
+	autoPushLabel := "auto-push: off"
+	if m.taskState != nil && m.taskState.AutoPushEnabled(planFile, m.appConfig != nil && m.appConfig.AutoPush) {
+		autoPushLabel = "auto-push: on"
+	}
 	configItems := []overlay.ContextMenuItem{
 		{Label: "rename task", Action: "rename_plan"},
+		{Label: "duplicate task", Action: "duplicate_plan"},
 		{Label: "set topic", Action: "change_topic"},
+		{Label: "set priority", Action: "set_priority"},
+		{Label: "set blockers", Action: "set_blockers"},
+		{Label: pinLabel, Action: "toggle_pin_plan"},
 		{Label: autoAdvanceLabel, Action: "toggle_auto_advance"},
 		{Label: autoReviewFixLabel, Action: "toggle_auto_review_fix"},
+		{Label: autoPushLabel, Action: "toggle_auto_push"},
 		{Label: "set status", Action: "set_status"},
 	}
 
@@ -917,6 +1138,11 @@ func (m *home) openTaskContextMenu() (tea.Model, tea.Cmd) {
 		{Label: "start over", Action: "start_over_plan"},
 		{Label: "cancel task", Action: "cancel_plan"},
 	}
+	if m.taskState != nil {
+		if status := m.taskState.Plans[planFile].Status; status == taskstate.StatusDone || status == taskstate.StatusCancelled {
+			lifecycleItems = append(lifecycleItems, overlay.ContextMenuItem{Label: "reopen", Action: "reopen_plan"})
+		}
+	}
 
 	// Assemble top-level category items; only include 'start' when non-empty.
 	var items []overlay.ContextMenuItem
@@ -937,6 +1163,153 @@ func (m *home) openTaskContextMenu() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// rebasePlanCmd rebases the plan branch's shared worktree onto origin/main in
+// the background and reports the outcome as a planRebasedMsg.
+func rebasePlanCmd(repoPath, planFile, branch string) tea.Cmd {
+	return func() tea.Msg {
+		wt := gitpkg.NewSharedTaskWorktree(repoPath, branch)
+		if _, err := os.Stat(wt.GetWorktreePath()); err != nil {
+			return planRebasedMsg{planFile: planFile, branch: branch,
+				err: fmt.Errorf("no worktree for %s — start the plan first", branch)}
+		}
+		return planRebasedMsg{planFile: planFile, branch: branch, err: wt.RebaseOntoMain()}
+	}
+}
+
+// renamePlanBranch asks to rename the selected plan's git branch to the name
+// its current slug renders to, e.g. after the plan itself was renamed. It is
+// refused while any instance still has the old branch checked out.
+func (m *home) renamePlanBranch() (tea.Model, tea.Cmd) {
+	planFile := m.nav.GetSelectedPlanFile()
+	if planFile == "" || m.taskState == nil {
+		return m, m.handleError(fmt.Errorf("no plan selected"))
+	}
+	entry, ok := m.taskState.Entry(planFile)
+	if !ok || entry.Branch == "" {
+		return m, m.handleError(fmt.Errorf("plan has no branch — implement it first"))
+	}
+	newBranch, err := m.newTaskBranch(planFile)
+	if err != nil {
+		return m, m.handleError(err)
+	}
+	if newBranch == entry.Branch {
+		return m, m.handleError(fmt.Errorf("branch %s already matches the task name", entry.Branch))
+	}
+	for _, inst := range m.allInstances {
+		if inst.Branch == entry.Branch {
+			return m, m.handleError(fmt.Errorf("branch %s is checked out by %s — kill it first", entry.Branch, inst.Title))
+		}
+	}
+	repoPath, oldBranch := m.activeRepoPath, entry.Branch
+	message := fmt.Sprintf("rename branch %s to %s?", oldBranch, newBranch)
+	return m, m.confirmAction(message, func() tea.Msg {
+		return planBranchRenamedMsg{planFile: planFile, oldBranch: oldBranch, newBranch: newBranch,
+			err: gitpkg.RenameTaskBranch(repoPath, oldBranch, newBranch)}
+	})
+}
+
+// duplicatePlan copies planFile into a new "<name>-copy" plan and selects it.
+func (m *home) duplicatePlan(planFile string) (tea.Model, tea.Cmd) {
+	if m.taskState == nil {
+		return m, nil
+	}
+	newName := dedupePlanFilenameInState(m.taskState, taskstate.DisplayName(planFile)+"-copy")
+	branch, err := m.newTaskBranch(newName)
+	if err != nil {
+		return m, m.handleError(err)
+	}
+	newFile, err := m.taskState.Duplicate(planFile, newName, branch)
+	if err != nil {
+		return m, m.handleError(err)
+	}
+	m.audit(auditlog.EventPlanCreated, "duplicated from "+planFile, auditlog.WithPlan(newFile))
+	m.loadTaskState()
+	m.updateSidebarTasks()
+	m.nav.SelectByID(ui.SidebarPlanPrefix + newFile)
+	m.toastManager.Success(fmt.Sprintf("duplicated '%s' → '%s'", taskstate.DisplayName(planFile), taskstate.DisplayName(newFile)))
+	return m, m.toastTickCmd()
+}
+
+// openTopicContextMenu shows the context menu for the selected topic header.
+func (m *home) openTopicContextMenu() (tea.Model, tea.Cmd) {
+	topic := m.nav.GetSelectedTopic()
+	if topic == "" || m.taskState == nil {
+		return m, nil
+	}
+	items := []overlay.ContextMenuItem{
+		{Label: "set status for all tasks", Action: "set_topic_status"},
+	}
+	x := m.navWidth
+	y := 1 + 4 + m.nav.GetSelectedIdx()
+	m.overlays.ShowPositioned(overlay.NewContextMenu(items), x, y, false)
+	m.state = stateContextMenu
+	return m, nil
+}
+
+// confirmTopicStatus asks before force-setting every plan in topic to status.
+// Plans with a running agent are called out, since changing their status
+// underneath the agent can confuse the lifecycle that follows.
+func (m *home) confirmTopicStatus(topic string, status taskstate.Status) (tea.Model, tea.Cmd) {
+	plans := m.taskState.TasksByTopic(topic)
+	if len(plans) == 0 {
+		m.toastManager.Info(fmt.Sprintf("no tasks in '%s'", topic))
+		return m, tea.Batch(tea.RequestWindowSize, m.toastTickCmd())
+	}
+	filenames := make([]string, 0, len(plans))
+	for _, p := range plans {
+		filenames = append(filenames, p.Filename)
+	}
+
+	message := fmt.Sprintf("set %d task(s) in '%s' to %s?", len(filenames), topic, status)
+	if running := m.plansWithRunningInstances(filenames); len(running) > 0 {
+		names := make([]string, len(running))
+		for i, f := range running {
+			names[i] = taskstate.DisplayName(f)
+		}
+		message += fmt.Sprintf("\n\n⚠ agents still running on: %s", strings.Join(names, ", "))
+	}
+	return m, m.confirmAction(message, func() tea.Msg {
+		return topicStatusSetMsg{topic: topic, filenames: filenames, status: status}
+	})
+}
+
+// plansWithRunningInstances returns the subset of planFiles that have at least
+// one started, unpaused instance, in the order given.
+func (m *home) plansWithRunningInstances(planFiles []string) []string {
+	running := make(map[string]bool)
+	for _, inst := range m.allInstances {
+		if inst.TaskFile != "" && inst.Started() && !inst.Paused() && !inst.Exited {
+			running[inst.TaskFile] = true
+		}
+	}
+	var out []string
+	for _, f := range planFiles {
+		if running[f] {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// applyTopicStatus writes a confirmed bulk status change in a single store call.
+func (m *home) applyTopicStatus(msg topicStatusSetMsg) (tea.Model, tea.Cmd) {
+	if m.taskState == nil {
+		return m, nil
+	}
+	if err := m.taskState.ForceSetStatusBulk(msg.filenames, msg.status); err != nil {
+		return m, m.handleError(err)
+	}
+	for _, f := range msg.filenames {
+		m.audit(auditlog.EventPlanTransition, "manual override → "+string(msg.status),
+			auditlog.WithPlan(f),
+			auditlog.WithDetail("manual override (topic "+msg.topic+")"))
+	}
+	m.loadTaskState()
+	m.updateSidebarTasks()
+	m.toastManager.Success(fmt.Sprintf("%d task(s) in '%s' → %s", len(msg.filenames), msg.topic, msg.status))
+	return m, tea.Batch(tea.RequestWindowSize, m.toastTickCmd())
+}
+
 // pushSelectedInstance pushes the selected instance's branch changes.
 func (m *home) pushSelectedInstance() (tea.Model, tea.Cmd) {
 	selected := m.nav.GetSelectedInstance()
@@ -1045,7 +1418,7 @@ func (m *home) executeTaskStage(planFile, stage string) (tea.Model, tea.Cmd) {
 			auditlog.WithPlan(planFile))
 		m.loadTaskState()
 		m.updateSidebarTasks()
-		return m.spawnTaskAgent(planFile, "plan", buildPlanningPrompt(planFile, taskstate.DisplayName(planFile), entry.Description))
+		return m.spawnPlanner(planFile, entry.Description)
 	case "solo":
 		// Check store content before fsmSetImplementing — the FSM transition calls
 		// store.Update which overwrites the content field with an empty string.
@@ -1098,7 +1471,7 @@ func (m *home) executeTaskStage(planFile, stage string) (tea.Model, tea.Cmd) {
 			m.loadTaskState()
 			m.updateSidebarTasks()
 			m.toastManager.Info("plan content missing — respawning planner to write plan content.")
-			_, spawnCmd := m.spawnTaskAgent(planFile, "plan", buildPlanningPrompt(planFile, taskstate.DisplayName(planFile), entry.Description))
+			_, spawnCmd := m.spawnPlanner(planFile, entry.Description)
 			return m, tea.Batch(m.toastTickCmd(), func() tea.Msg { return taskRefreshMsg{} }, spawnCmd)
 		}
 		plan, err := taskparser.Parse(rawContent)
@@ -1124,8 +1497,7 @@ func (m *home) executeTaskStage(planFile, stage string) (tea.Model, tea.Cmd) {
 			return m.spawnBlueprintSkipAgent(planFile, plan)
 		}
 
-		orch := orchestration.NewWaveOrchestrator(planFile, plan)
-		orch.SetStore(m.taskStore, m.taskStoreProject)
+		orch := m.newWaveOrchestrator(planFile, plan)
 		m.waveOrchestrators[planFile] = orch
 
 		if err := m.fsmSetImplementing(planFile); err != nil {
@@ -1170,7 +1542,7 @@ func (m *home) executeTaskStage(planFile, stage string) (tea.Model, tea.Cmd) {
 			m.loadTaskState()
 			m.updateSidebarTasks()
 			m.toastManager.Info("plan content missing — respawning planner to write plan content.")
-			_, spawnCmd := m.spawnTaskAgent(planFile, "plan", buildPlanningPrompt(planFile, taskstate.DisplayName(planFile), entry.Description))
+			_, spawnCmd := m.spawnPlanner(planFile, entry.Description)
 			return m, tea.Batch(m.toastTickCmd(), func() tea.Msg { return taskRefreshMsg{} }, spawnCmd)
 		}
 		plan, err := taskparser.Parse(rawContent)
@@ -1192,8 +1564,7 @@ func (m *home) executeTaskStage(planFile, stage string) (tea.Model, tea.Cmd) {
 			return m.spawnBlueprintSkipAgent(planFile, plan)
 		}
 
-		orch := orchestration.NewWaveOrchestrator(planFile, plan)
-		orch.SetStore(m.taskStore, m.taskStoreProject)
+		orch := m.newWaveOrchestrator(planFile, plan)
 		m.waveOrchestrators[planFile] = orch
 
 		if err := m.fsmSetImplementing(planFile); err != nil {
@@ -1293,6 +1664,20 @@ func (m *home) handleTmuxBrowserAction(browser *overlay.TmuxBrowserOverlay, acti
 			return tmuxAttachReturnMsg{}
 		})
 
+	case "attach_readonly":
+		if browser == nil {
+			return m, nil
+		}
+		item := browser.SelectedItem()
+		if item.Name == "" {
+			return m, nil
+		}
+		m.state = stateDefault
+		name := item.Name
+		return m, tea.ExecProcess(exec.Command("tmux", "attach-session", "-r", "-t", name), func(err error) tea.Msg {
+			return tmuxAttachReturnMsg{}
+		})
+
 	default:
 		return m, nil
 	}
@@ -1324,7 +1709,7 @@ func (m *home) openCommandLauncher() (tea.Model, tea.Cmd) {
 		{Label: "search", Hint: "/", Action: "search"},
 		{Label: "interactive mode", Hint: "i", Action: "interactive"},
 		{Label: "send yes", Hint: "y", Action: "send_yes"},
-		{Label: "kill session", Hint: "k", Action: "kill"},
+		{Label: "kill session", Hint: killKey(m.vimMode()), Action: "kill"},
 		{Label: "stop session", Hint: "K", Action: "abort"},
 		{Label: "resume session", Hint: "r", Action: "resume"},
 		{Label: "checkout branch", Hint: "c", Action: "checkout"},
@@ -1336,7 +1721,9 @@ func (m *home) openCommandLauncher() (tea.Model, tea.Cmd) {
 		{Label: "toggle audit log", Hint: "L", Action: "toggle_audit"},
 		{Label: "audit log actions", Hint: "A", Action: "audit_cursor"},
 		{Label: "info tab", Hint: "g", Action: "info_tab"},
+		{Label: "copy task report", Action: "copy_task_report"},
 		{Label: "quit", Hint: "q", Action: "quit"},
+		{Label: "quit and stop sessions", Hint: "Q", Action: "quit_and_kill"},
 	}
 	launcher := overlay.NewCommandLauncherOverlay("commands", items)
 	m.overlays.Show(launcher)
@@ -1347,7 +1734,7 @@ func (m *home) openCommandLauncher() (tea.Model, tea.Cmd) {
 // openKeybindBrowser builds and shows a read-only keybind browser overlay
 // using all configured keybinds from the keys package.
 func (m *home) openKeybindBrowser() (tea.Model, tea.Cmd) {
-	items := buildKeybindBrowserItems()
+	items := buildKeybindBrowserItems(m.vimMode())
 	browser := overlay.NewCommandLauncherOverlay("keybinds", items)
 	m.overlays.Show(browser)
 	m.state = stateKeybindBrowser
@@ -1355,11 +1742,12 @@ func (m *home) openKeybindBrowser() (tea.Model, tea.Cmd) {
 }
 
 // buildKeybindBrowserItems creates a sorted list of all global keybinds for
-// the keybind browser. Uses keys.GlobalkeyBindings to get label and key text.
-func buildKeybindBrowserItems() []overlay.LauncherItem {
+// the keybind browser. Uses keys.Binding to get label and key text, so vim
+// mode shows its own keys.
+func buildKeybindBrowserItems(vim bool) []overlay.LauncherItem {
 	var items []overlay.LauncherItem
-	for _, binding := range keys.GlobalkeyBindings {
-		help := binding.Help()
+	for name := range keys.GlobalkeyBindings {
+		help := keys.Binding(name, vim).Help()
 		if help.Key == "" || help.Desc == "" {
 			continue
 		}
@@ -1390,15 +1778,15 @@ func (m *home) executeLauncherAction(action string) (tea.Model, tea.Cmd) {
 		m.overlays.Show(tio)
 		return m, nil
 	case "new_instance":
-		if m.tmuxSessionCount >= GlobalInstanceLimit {
-			return m, m.handleError(
-				fmt.Errorf("you can't create more than %d instances (%d tmux sessions active)",
-					GlobalInstanceLimit, m.tmuxSessionCount))
+		if err := m.checkInstanceLimit(1); err != nil {
+			return m, m.handleError(err)
 		}
 		instance, err := session.NewInstance(session.InstanceOptions{
-			Title:   "",
-			Path:    m.activeRepoPath,
-			Program: m.programForAgent(""),
+			Title:          "",
+			Path:           m.activeRepoPath,
+			Program:        m.programForAgent(""),
+			RecordSessions: m.recordSessions(),
+			Container:      m.containerConfig(),
 		})
 		if err != nil {
 			return m, m.handleError(err)
@@ -1411,13 +1799,11 @@ func (m *home) executeLauncherAction(action string) (tea.Model, tea.Cmd) {
 		m.promptAfterName = true
 		return m, nil
 	case "spawn_agent":
-		if m.tmuxSessionCount >= GlobalInstanceLimit {
-			return m, m.handleError(
-				fmt.Errorf("you can't create more than %d instances (%d tmux sessions active)",
-					GlobalInstanceLimit, m.tmuxSessionCount))
+		if err := m.checkInstanceLimit(1); err != nil {
+			return m, m.handleError(err)
 		}
 		m.state = stateSpawnAgent
-		m.overlays.Show(overlay.NewSpawnFormOverlay("spawn agent", 60))
+		m.overlays.Show(overlay.NewSpawnFormOverlay("spawn agent", 60, m.appConfig.CustomRoles()...))
 		return m, nil
 	case "search":
 		m.nav.ActivateSearch()
@@ -1507,10 +1893,7 @@ func (m *home) executeLauncherAction(action string) (tea.Model, tea.Cmd) {
 		if selected == nil {
 			return m, nil
 		}
-		m.state = statePRTitle
-		tio := overlay.NewTextInputOverlay("pr title", selected.Title)
-		tio.SetSize(60, 3)
-		m.overlays.Show(tio)
+		m.openPRTitleOverlay(selected.Title)
 		return m, nil
 	case "preview":
 		return m.viewSelectedPlan()
@@ -1540,6 +1923,520 @@ func (m *home) executeLauncherAction(action string) (tea.Model, tea.Cmd) {
 		return m, nil
 	case "quit":
 		return m.handleQuit()
+	case "quit_and_kill":
+		return m.handleQuitAndKill()
+	case "copy_task_report":
+		return m, m.copyTaskReport()
+	}
+	return m, nil
+}
+
+// copyTaskReport copies a markdown summary of every plan, with live instance
+// counts, to the clipboard.
+func (m *home) copyTaskReport() tea.Cmd {
+	if m.taskState == nil {
+		return nil
+	}
+	counts := make(map[string]int)
+	for _, inst := range m.nav.GetInstances() {
+		if inst.TaskFile != "" {
+			counts[inst.TaskFile]++
+		}
+	}
+	return m.copyToClipboard(m.taskState.RenderReport(counts), "task report copied")
+}
+
+// planPRBody prefixes gitBody with the plan's goal and wave/task summary for
+// the plan-level PR flow. Missing content or subtasks just shrink the summary.
+func (m *home) planPRBody(planFile, gitBody string) string {
+	if m.taskState == nil {
+		return gitBody
+	}
+	entry, _ := m.taskState.Entry(planFile)
+	var plan *taskparser.Plan
+	if content, err := m.taskState.GetContent(planFile); err == nil && content != "" {
+		plan, _ = taskparser.Parse(content)
+	}
+	subtasks, _ := m.taskState.GetSubtasks(planFile)
+	return buildPlanPRBody(taskstate.DisplayName(planFile), entry.Goal, plan, subtasks, gitBody)
+}
+
+// buildPlanPRBody assembles a plan-level PR body: a summary of the plan and
+// its goal, one checklist per wave (tasks ticked when their subtask is done),
+// then the git-generated sections.
+func buildPlanPRBody(planName, goal string, plan *taskparser.Plan, subtasks []taskstore.SubtaskEntry, gitBody string) string {
+	summary := []string{"- plan: " + planName}
+	if g := strings.TrimSpace(goal); g != "" {
+		summary = append(summary, "- goal: "+g)
+	}
+	sections := []string{"## summary\n\n" + strings.Join(summary, "\n")}
+
+	if plan != nil && len(plan.Waves) > 0 {
+		done := make(map[int]bool, len(subtasks))
+		for _, s := range subtasks {
+			done[s.TaskNumber] = s.Status == taskstore.SubtaskStatusDone || s.Status == taskstore.SubtaskStatusComplete
+		}
+		var waves []string
+		for _, w := range plan.Waves {
+			lines := []string{fmt.Sprintf("### wave %d", w.Number)}
+			for _, t := range w.Tasks {
+				box := "[ ]"
+				if done[t.Number] {
+					box = "[x]"
+				}
+				lines = append(lines, fmt.Sprintf("- %s task %d: %s", box, t.Number, t.Title))
+			}
+			waves = append(waves, strings.Join(lines, "\n"))
+		}
+		sections = append(sections, "## waves\n\n"+strings.Join(waves, "\n\n"))
+	}
+
+	if g := strings.TrimSpace(gitBody); g != "" {
+		sections = append(sections, g)
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+// openPRTitleOverlay starts the two-step PR creation flow with the title
+// prompt. Draft mode is seeded from config and toggled with keys.KeyToggleDraft.
+func (m *home) openPRTitleOverlay(defaultTitle string) {
+	m.state = statePRTitle
+	m.pendingPRDraft = m.appConfig != nil && m.appConfig.DefaultDraftPR
+	tio := overlay.NewTextInputOverlay(prOverlayTitle("pr title", m.pendingPRDraft), defaultTitle)
+	tio.SetSize(60, 3)
+	m.overlays.Show(tio)
+}
+
+// togglePRDraft flips draft mode for the PR being created and refreshes the
+// active overlay header.
+func (m *home) togglePRDraft() {
+	m.pendingPRDraft = !m.pendingPRDraft
+	if tio, ok := m.overlays.Current().(*overlay.TextInputOverlay); ok {
+		tio.Title = prOverlayTitle(tio.Title, m.pendingPRDraft)
+	}
+}
+
+// prOverlayTitle returns base with the draft marker added or removed.
+func prOverlayTitle(base string, draft bool) string {
+	base = strings.TrimSuffix(base, prDraftSuffix)
+	if draft {
+		return base + prDraftSuffix
+	}
+	return base
+}
+
+// diffExportDir resolves the directory exported .patch files are written to.
+// Tests override it.
+var diffExportDir = func() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".kasmos", "diffs"), nil
+}
+
+// diffFileNameRe matches characters that are unsafe in exported patch names.
+var diffFileNameRe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// exportSelectedDiff writes the selected instance's diff to
+// ~/.kasmos/diffs/<title>-<timestamp>.patch in the background.
+func (m *home) exportSelectedDiff() tea.Cmd {
+	selected := m.nav.GetSelectedInstance()
+	if selected == nil || !selected.Started() {
+		return nil
+	}
+	title := selected.Title
+	return func() tea.Msg {
+		worktree, err := selected.GetGitWorktree()
+		if err != nil {
+			return diffExportedMsg{err: err}
+		}
+		dir, err := diffExportDir()
+		if err != nil {
+			return diffExportedMsg{err: err}
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return diffExportedMsg{err: err}
+		}
+		name := diffFileNameRe.ReplaceAllString(title, "_")
+		path := filepath.Join(dir, fmt.Sprintf("%s-%s.patch", name, time.Now().Format("20060102-150405")))
+		if err := worktree.WriteDiff(path); err != nil {
+			return diffExportedMsg{err: err}
+		}
+		return diffExportedMsg{path: path}
+	}
+}
+
+// openBroadcastPrompt opens the prompt overlay for sending one message to
+// every running session of the selected plan header.
+func (m *home) openBroadcastPrompt() (tea.Model, tea.Cmd) {
+	if !m.nav.IsSelectedPlanHeader() {
+		return m, nil
+	}
+	planFile := m.nav.GetSelectedPlanFile()
+	if planFile == "" {
+		return m, nil
+	}
+	if len(m.broadcastTargets(planFile)) == 0 {
+		m.toastManager.Info(fmt.Sprintf("no running sessions for %s", taskstate.DisplayName(planFile)))
+		return m, m.toastTickCmd()
+	}
+	m.pendingBroadcastPlan = planFile
+	m.state = stateBroadcastPrompt
+	tio := overlay.NewTextInputOverlay("send to all sessions of "+taskstate.DisplayName(planFile), "")
+	tio.SetSize(60, 5)
+	tio.SetMultiline(true)
+	tio.SetPlaceholder("prompt for every running session")
+	tio.SetHistory(m.promptHistory())
+	m.overlays.Show(tio)
+	return m, nil
+}
+
+// openGlobalSearch shows the query prompt for searching the content of every plan.
+func (m *home) openGlobalSearch() (tea.Model, tea.Cmd) {
+	if m.taskState == nil {
+		return m, nil
+	}
+	m.state = stateGlobalSearch
+	tio := overlay.NewTextInputOverlay("search plan contents", "")
+	tio.SetSize(50, 1)
+	tio.SetPlaceholder("case-insensitive text")
+	m.overlays.Show(tio)
+	return m, nil
+}
+
+// finishGlobalSearch runs the submitted query and shows the matches in a picker.
+func (m *home) finishGlobalSearch(result overlay.Result) (tea.Model, tea.Cmd) {
+	m.state = stateDefault
+	query := strings.TrimSpace(result.Value)
+	if !result.Submitted || query == "" {
+		return m, nil
+	}
+	matches, err := m.taskState.GrepPlans(query)
+	if err != nil {
+		return m, m.handleError(err)
+	}
+	if len(matches) == 0 {
+		m.toastManager.Info(fmt.Sprintf("no plans mention %q", query))
+		return m, m.toastTickCmd()
+	}
+	m.contentMatches = matches
+	items := make([]string, len(matches))
+	for i, cm := range matches {
+		items[i] = contentMatchLabel(cm)
+	}
+	m.state = stateGlobalSearchPicker
+	m.overlays.Show(overlay.NewPickerOverlay(fmt.Sprintf("%d matches for %q", len(matches), query), items))
+	return m, nil
+}
+
+// finishGlobalSearchPicker selects the picked match's plan in the sidebar and
+// opens it in the preview.
+func (m *home) finishGlobalSearchPicker(result overlay.Result) (tea.Model, tea.Cmd) {
+	m.state = stateDefault
+	matches := m.contentMatches
+	m.contentMatches = nil
+	if !result.Submitted {
+		return m, nil
+	}
+	for _, cm := range matches {
+		if contentMatchLabel(cm) != result.Value {
+			continue
+		}
+		if !m.nav.SelectPlan(cm.Filename) {
+			m.toastManager.Info(fmt.Sprintf("%s is hidden by the current filter", taskstate.DisplayName(cm.Filename)))
+			return m, m.toastTickCmd()
+		}
+		return m.viewSelectedPlan()
 	}
 	return m, nil
 }
+
+// contentMatchLabel formats a plan content match for the picker.
+func contentMatchLabel(cm taskstate.ContentMatch) string {
+	return fmt.Sprintf("%s:%d · %s", taskstate.DisplayName(cm.Filename), cm.Line, cm.Text)
+}
+
+// broadcastTargets returns the started, non-paused instances of the active
+// repo bound to planFile.
+func (m *home) broadcastTargets(planFile string) []*session.Instance {
+	var targets []*session.Instance
+	for _, inst := range m.nav.GetInstances() {
+		if inst.TaskFile == planFile && inst.Started() && !inst.Paused() {
+			targets = append(targets, inst)
+		}
+	}
+	return targets
+}
+
+// broadcastPrompt queues prompt on every broadcast target of planFile. The
+// metadata tick delivers it once each session is ready for input. Returns the
+// number of sessions the prompt was queued for.
+func (m *home) broadcastPrompt(planFile, prompt string) int {
+	targets := m.broadcastTargets(planFile)
+	for _, inst := range targets {
+		if inst.QueuedPrompt != "" {
+			inst.QueuedPrompt += "\n\n" + prompt
+		} else {
+			inst.QueuedPrompt = prompt
+		}
+	}
+	auditMsg := prompt
+	if len(auditMsg) > 200 {
+		auditMsg = auditMsg[:200]
+	}
+	m.audit(auditlog.EventPromptSent, auditMsg,
+		auditlog.WithPlan(planFile),
+		auditlog.WithDetail(fmt.Sprintf("broadcast to %d sessions", len(targets))))
+	return len(targets)
+}
+
+// copySelectedPlan reads the selected plan's markdown in the background and
+// copies it to the clipboard.
+func (m *home) copySelectedPlan() tea.Cmd {
+	planFile := m.nav.GetSelectedPlanFile()
+	if planFile == "" || m.taskStore == nil {
+		return nil
+	}
+	store, project := m.taskStore, m.taskStoreProject
+	return func() tea.Msg {
+		content, err := store.GetContent(project, planFile)
+		if err != nil {
+			return planCopiedMsg{err: fmt.Errorf("could not read plan %s: %w", planFile, err)}
+		}
+		return planCopiedMsg{content: content}
+	}
+}
+
+// offerRequeuePrompt asks whether to re-send inst's last prompt after a resume:
+// the restarted agent comes back idle, without the work it was given. On
+// confirm the prompt is queued and delivered once the agent is ready.
+func (m *home) offerRequeuePrompt(inst *session.Instance) {
+	if inst.LastPrompt == "" {
+		return
+	}
+	preview := inst.LastPrompt
+	if r := []rune(preview); len(r) > 200 {
+		preview = string(r[:200]) + "…"
+	}
+	title := inst.Title
+	m.confirmAction(fmt.Sprintf("re-send the last prompt to '%s'?\n\n%s", title, preview), func() tea.Msg {
+		return requeuePromptMsg{title: title}
+	})
+}
+
+// handleBranchReattached clears the instance's detached-HEAD flag once its
+// branch is checked out again.
+func (m *home) handleBranchReattached(msg branchReattachedMsg) tea.Cmd {
+	if msg.err != nil {
+		return m.handleError(msg.err)
+	}
+	msg.instance.DetachedHead = false
+	m.toastManager.Success(fmt.Sprintf("%s is back on %s", msg.instance.Title, msg.instance.Branch))
+	m.updateInfoPane()
+	return m.toastTickCmd()
+}
+
+// copySelectedBranch copies the branch of the selected instance, or of the
+// selected plan when no instance is selected, to the clipboard.
+func (m *home) copySelectedBranch() tea.Cmd {
+	var branch string
+	if inst := m.nav.GetSelectedInstance(); inst != nil {
+		branch = inst.Branch
+		if wt, err := inst.GetGitWorktree(); branch == "" && err == nil {
+			branch = wt.GetBranchName()
+		}
+	} else if planFile := m.nav.GetSelectedPlanFile(); planFile != "" {
+		branch = m.taskBranch(planFile)
+	}
+	if branch == "" {
+		m.toastManager.Info("no branch to copy")
+		return m.toastTickCmd()
+	}
+	return m.copyToClipboard(branch, "copied "+branch)
+}
+
+// editSelectedPlan writes the selected plan's markdown to the plans dir and
+// suspends the TUI to open it in $EDITOR. The edited content is read back
+// into the store when the editor exits (see finishEditPlan).
+func (m *home) editSelectedPlan() (tea.Model, tea.Cmd) {
+	planFile := m.nav.GetSelectedPlanFile()
+	if planFile == "" || m.taskState == nil || m.taskStateDir == "" {
+		return m, nil
+	}
+	content, err := m.taskState.GetContent(planFile)
+	if err != nil {
+		return m, m.handleError(fmt.Errorf("could not read plan %s: %w", planFile, err))
+	}
+	path := filepath.Join(m.taskStateDir, planFile)
+	_, statErr := os.Stat(path)
+	created := os.IsNotExist(statErr)
+	if err := os.MkdirAll(m.taskStateDir, 0o755); err != nil {
+		return m, m.handleError(fmt.Errorf("edit plan: %w", err))
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return m, m.handleError(fmt.Errorf("edit plan: %w", err))
+	}
+	cmd := editPlanCommand(os.Getenv("EDITOR"), path)
+	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return planRefreshMsg{planFile: planFile, path: path, created: created, err: err}
+	})
+}
+
+// editPlanCommand builds the command that opens path in editor, falling back
+// to vi when editor is empty. editor may carry arguments (e.g. "code --wait").
+func editPlanCommand(editor, path string) *exec.Cmd {
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		args = []string{"vi"}
+	}
+	return exec.Command(args[0], append(args[1:], path)...)
+}
+
+// finishEditPlan reads the edited plan back into the store, removes the file
+// if it was written only for the edit, and refreshes the sidebar and preview.
+func (m *home) finishEditPlan(msg planRefreshMsg) tea.Cmd {
+	if msg.created {
+		defer os.Remove(msg.path)
+	}
+	if msg.err != nil {
+		return tea.Batch(tea.RequestWindowSize, m.handleError(fmt.Errorf("editor: %w", msg.err)))
+	}
+	data, err := os.ReadFile(msg.path)
+	if err != nil {
+		return tea.Batch(tea.RequestWindowSize, m.handleError(fmt.Errorf("could not read edited plan: %w", err)))
+	}
+	old, _ := m.taskState.GetContent(msg.planFile)
+	if string(data) != old {
+		var warn *taskstate.IngestWarning
+		if err := m.taskState.IngestContent(msg.planFile, string(data)); err != nil && !errors.As(err, &warn) {
+			return tea.Batch(tea.RequestWindowSize, m.handleError(fmt.Errorf("save edited plan: %w", err)))
+		}
+		if m.cachedPlanFile == msg.planFile {
+			m.cachedPlanFile, m.cachedPlanRendered = "", ""
+		}
+		m.toastManager.Success(fmt.Sprintf("saved %s", taskstate.DisplayName(msg.planFile)))
+	}
+	m.loadTaskState()
+	m.updateSidebarTasks()
+	return tea.Batch(tea.RequestWindowSize, m.toastTickCmd())
+}
+
+// diagnosePlan reports why planFile might be stuck: its FSM status, wave
+// orchestrator state, the plan's instances, any dialogs deferred behind an
+// overlay and sentinel signals that have not been consumed yet.
+func (m *home) diagnosePlan(planFile string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "diagnose: %s\n\n", taskstate.DisplayName(planFile))
+
+	status := "unknown (not in task store)"
+	if m.taskState != nil {
+		if entry, ok := m.taskState.Entry(planFile); ok {
+			status = string(entry.Status)
+			if entry.ReviewCycle > 0 {
+				status += fmt.Sprintf(" (review cycle %d)", entry.ReviewCycle)
+			}
+		}
+	}
+	fmt.Fprintf(&b, "fsm status:    %s\n", status)
+
+	if orch, ok := m.waveOrchestrators[planFile]; ok {
+		fmt.Fprintf(&b, "orchestrator:  %s, wave %d/%d, %d/%d tasks done, %d failed\n",
+			waveStateString(orch.State()), orch.CurrentWaveNumber(), orch.TotalWaves(),
+			orch.CompletedTaskCount(), len(orch.CurrentWaveTasks()), orch.FailedTaskCount())
+	} else {
+		b.WriteString("orchestrator:  none\n")
+	}
+
+	var insts []*session.Instance
+	for _, inst := range m.nav.GetInstances() {
+		if inst.TaskFile == planFile {
+			insts = append(insts, inst)
+		}
+	}
+	fmt.Fprintf(&b, "\ninstances (%d):\n", len(insts))
+	for _, inst := range insts {
+		tmux := "dead"
+		if inst.Started() && inst.TmuxAlive() {
+			tmux = "alive"
+		}
+		agent := inst.AgentType
+		if agent == "" {
+			agent = "agent"
+		}
+		fmt.Fprintf(&b, "  %s [%s] %s, tmux %s\n", inst.Title, agent, statusString(inst.Status), tmux)
+	}
+
+	var pending []string
+	if slices.Contains(m.deferredPlannerDialogs, planFile) {
+		pending = append(pending, "planner finished (waiting for overlay to close)")
+	}
+	if slices.Contains(m.pendingAllComplete, planFile) {
+		pending = append(pending, "all waves complete (waiting for overlay to close)")
+	}
+	if m.pendingWaveConfirmTaskFile == planFile {
+		pending = append(pending, "wave confirmation open")
+	}
+	if m.pendingPlannerTaskFile == planFile {
+		pending = append(pending, "start implementation confirmation open")
+	}
+	b.WriteString("\npending dialogs:\n")
+	if len(pending) == 0 {
+		b.WriteString("  none\n")
+	}
+	for _, p := range pending {
+		fmt.Fprintf(&b, "  %s\n", p)
+	}
+
+	b.WriteString("\nunconsumed signals:\n")
+	found := false
+	if m.signalsDir != "" {
+		for _, sig := range taskfsm.ScanSignals(m.signalsDir) {
+			if sig.TaskFile == planFile {
+				fmt.Fprintf(&b, "  %s (%s)\n", sig.Event, sig.Filename())
+				found = true
+			}
+		}
+	}
+	if !found {
+		b.WriteString("  none\n")
+	}
+	return b.String()
+}
+
+// waveStateString returns a short lowercase label for a wave orchestrator state.
+func waveStateString(s orchestration.WaveState) string {
+	switch s {
+	case orchestration.WaveStateIdle:
+		return "idle"
+	case orchestration.WaveStateElaborating:
+		return "elaborating"
+	case orchestration.WaveStateRunning:
+		return "running"
+	case orchestration.WaveStateWaveComplete:
+		return "wave complete (awaiting confirmation)"
+	case orchestration.WaveStateAllComplete:
+		return "all waves complete"
+	default:
+		return "unknown"
+	}
+}
+
+// writeNativeClipboard copies text with the platform clipboard tool. Tests
+// override it.
+var writeNativeClipboard = kclipboard.WriteNative
+
+// copyToClipboard copies text via OSC 52 (emitted through the renderer so it
+// works over SSH) and the native clipboard tool, then shows toast. It reports
+// an error only when neither path is available.
+func (m *home) copyToClipboard(text, toast string) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, chunk := range kclipboard.OSC52Chunks(text, kclipboard.InTmux()) {
+		cmds = append(cmds, tea.Raw(chunk))
+	}
+	if err := writeNativeClipboard(text); err != nil && len(cmds) == 0 {
+		return m.handleError(fmt.Errorf("copy to clipboard: %w", err))
+	}
+	m.toastManager.Success(toast)
+	return tea.Batch(tea.Sequence(cmds...), m.toastTickCmd())
+}
@@ -232,7 +232,7 @@ func TestAuditHomeEmit_AgentSpawned(t *testing.T) {
 	h.taskStoreProject = "myproject"
 
 	// spawnAdHocAgent should emit EventAgentSpawned
-	h.spawnAdHocAgent("my-fixer", "", "")
+	h.spawnAdHocAgent("my-fixer", "", "", "", false)
 
 	events, err := logger.Query(auditlog.QueryFilter{
 		Project: "myproject",
@@ -587,3 +587,143 @@ func TestAuditHomeEmit_WaveStarted(t *testing.T) {
 	assert.Equal(t, 1, events[0].WaveNumber)
 	assert.Contains(t, events[0].Message, "wave 1")
 }
+
+func TestApplyConflictState_AuditsOncePerTransition(t *testing.T) {
+	logger, err := auditlog.NewSQLiteLogger(":memory:")
+	require.NoError(t, err)
+	defer logger.Close()
+
+	h := newTestHome()
+	h.auditLogger = logger
+	h.taskStoreProject = "test"
+
+	inst, err := newTestInstance("coder")
+	require.NoError(t, err)
+
+	h.applyConflictState(inst, true, []string{"a.go"})
+	h.applyConflictState(inst, true, []string{"a.go"})
+	assert.True(t, inst.HasConflicts)
+	assert.Equal(t, []string{"a.go"}, inst.ConflictFiles)
+
+	h.applyConflictState(inst, false, nil)
+	assert.False(t, inst.HasConflicts)
+	h.applyConflictState(inst, true, []string{"b.go"})
+
+	events, err := logger.Query(auditlog.QueryFilter{
+		Project: "test",
+		Kinds:   []auditlog.EventKind{auditlog.EventMergeConflict},
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "coder", events[0].InstanceTitle)
+}
+
+func TestApplyDetachedState_AuditsOncePerTransition(t *testing.T) {
+	logger, err := auditlog.NewSQLiteLogger(":memory:")
+	require.NoError(t, err)
+	defer logger.Close()
+
+	h := newTestHome()
+	h.auditLogger = logger
+	h.taskStoreProject = "test"
+
+	inst, err := newTestInstance("coder")
+	require.NoError(t, err)
+
+	h.applyDetachedState(inst, true)
+	h.applyDetachedState(inst, true)
+	assert.True(t, inst.DetachedHead)
+
+	h.applyDetachedState(inst, false)
+	assert.False(t, inst.DetachedHead)
+
+	events, err := logger.Query(auditlog.QueryFilter{
+		Project: "test",
+		Kinds:   []auditlog.EventKind{auditlog.EventDetachedHead},
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "coder", events[0].InstanceTitle)
+}
+
+func TestPlanRebasedMsg_AuditsOutcome(t *testing.T) {
+	logger, err := auditlog.NewSQLiteLogger(":memory:")
+	require.NoError(t, err)
+	defer logger.Close()
+
+	h := newTestHome()
+	h.auditLogger = logger
+	h.taskStoreProject = "test"
+
+	msg := rebasePlanCmd(t.TempDir(), "feature.md", "plan/feature")()
+	rebased, ok := msg.(planRebasedMsg)
+	require.True(t, ok)
+	require.Error(t, rebased.err, "missing worktree is reported")
+
+	_, _ = h.Update(rebased)
+	_, _ = h.Update(planRebasedMsg{planFile: "feature.md", branch: "plan/feature"})
+
+	events, err := logger.Query(auditlog.QueryFilter{
+		Project: "test",
+		Kinds:   []auditlog.EventKind{auditlog.EventGitRebase},
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	levels := []string{events[0].Level, events[1].Level}
+	assert.Contains(t, levels, "error")
+	for _, e := range events {
+		assert.Equal(t, "feature.md", e.TaskFile)
+	}
+}
+
+func TestCheckResourceAlert_DebouncesConsecutiveTicks(t *testing.T) {
+	logger, err := auditlog.NewSQLiteLogger(":memory:")
+	require.NoError(t, err)
+	defer logger.Close()
+
+	h := newTestHome()
+	h.auditLogger = logger
+	h.taskStoreProject = "test"
+	h.appConfig.CPUAlertPercent = 100
+	h.appConfig.MemAlertMB = 1024
+
+	inst, err := newTestInstance("hog")
+	require.NoError(t, err)
+	sample := func(cpu, mem float64) bool {
+		inst.CPUPercent, inst.MemMB = cpu, mem
+		return h.checkResourceAlert(inst)
+	}
+	alerts := func() int {
+		events, err := logger.Query(auditlog.QueryFilter{
+			Project: "test",
+			Kinds:   []auditlog.EventKind{auditlog.EventResourceAlert},
+			Limit:   10,
+		})
+		require.NoError(t, err)
+		return len(events)
+	}
+
+	// A single spike resets once usage drops.
+	assert.False(t, sample(300, 100))
+	assert.False(t, sample(10, 100))
+	assert.Equal(t, 0, inst.ResourceBreachTicks)
+
+	for i := 1; i < resourceAlertTicks; i++ {
+		assert.False(t, sample(10, 2048), "tick %d is below the debounce", i)
+	}
+	assert.Equal(t, 0, alerts())
+	assert.True(t, sample(10, 2048), "alert fires on the Nth consecutive tick")
+	assert.False(t, sample(300, 2048), "alert fires once per breach")
+	assert.Equal(t, 1, alerts())
+
+	// Recovering re-arms the alert.
+	assert.False(t, sample(10, 100))
+	assert.False(t, inst.ResourceAlerted)
+	for i := 0; i < resourceAlertTicks; i++ {
+		sample(300, 100)
+	}
+	assert.Equal(t, 2, alerts())
+}
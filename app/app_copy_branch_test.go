@@ -0,0 +1,67 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/kastheco/kasmos/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubClipboard replaces the native clipboard writer and returns a pointer to
+// the texts written to it.
+func stubClipboard(t *testing.T) *[]string {
+	t.Helper()
+	var copied []string
+	orig := writeNativeClipboard
+	t.Cleanup(func() { writeNativeClipboard = orig })
+	writeNativeClipboard = func(text string) error {
+		copied = append(copied, text)
+		return nil
+	}
+	return &copied
+}
+
+func TestCopyBranch_CopiesSelectedInstanceBranch(t *testing.T) {
+	copied := stubClipboard(t)
+	h := newTestHome()
+	inst, err := newTestInstance("branch-agent")
+	require.NoError(t, err)
+	inst.Branch = "jane/branch-agent"
+	_ = h.nav.AddInstance(inst)
+	require.True(t, h.nav.SelectInstance(inst))
+
+	h.keySent = true // skip the menu-highlight re-dispatch
+	_, cmd := h.handleKeyPress(tea.KeyPressMsg{Code: 'B', Text: "B"})
+	require.NotNil(t, cmd)
+	assert.Equal(t, []string{"jane/branch-agent"}, *copied)
+}
+
+func TestCopyBranch_CopiesSelectedPlanBranch(t *testing.T) {
+	copied := stubClipboard(t)
+	plansDir := filepath.Join(t.TempDir(), "docs", "plans")
+	require.NoError(t, os.MkdirAll(plansDir, 0o755))
+	ps, err := newTestPlanState(t, plansDir)
+	require.NoError(t, err)
+	require.NoError(t, ps.Register("auth-refactor", "auth", "feature/auth-refactor", time.Now()))
+
+	h := newTestHome()
+	h.taskState = ps
+	h.nav.SetPlans([]ui.PlanDisplay{{Filename: "auth-refactor", Status: "ready"}})
+	require.True(t, h.nav.SelectByID(ui.SidebarPlanPrefix+"auth-refactor"))
+
+	require.NotNil(t, h.copySelectedBranch())
+	assert.Equal(t, []string{"feature/auth-refactor"}, *copied)
+}
+
+func TestCopyBranch_NothingSelected(t *testing.T) {
+	copied := stubClipboard(t)
+	h := newTestHome()
+
+	require.NotNil(t, h.copySelectedBranch(), "toast explains there is nothing to copy")
+	assert.Empty(t, *copied)
+}
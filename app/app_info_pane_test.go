@@ -9,6 +9,7 @@ import (
 
 	"charm.land/bubbles/v2/spinner"
 	"github.com/kastheco/kasmos/config"
+	"github.com/kastheco/kasmos/config/taskfsm"
 	"github.com/kastheco/kasmos/config/taskparser"
 	"github.com/kastheco/kasmos/config/taskstate"
 	"github.com/kastheco/kasmos/config/taskstore"
@@ -126,6 +127,27 @@ func TestUpdateInfoPaneForPlanHeader_LifecycleTimestamps(t *testing.T) {
 	assert.True(t, data.ReviewingAt.IsZero(), "ReviewingAt must be zero — not set")
 }
 
+// TestUpdateInfoPaneForPlanHeader_History verifies that the FSM status
+// timeline is passed to the info pane.
+func TestUpdateInfoPaneForPlanHeader_History(t *testing.T) {
+	h, _, store, _ := buildInfoPaneHome(t)
+	fsm := taskfsm.New(store, "test", "")
+	require.NoError(t, fsm.Transition("plan.md", taskfsm.PlanStart))
+	require.NoError(t, fsm.Transition("plan.md", taskfsm.PlannerFinished))
+	reloaded, err := taskstate.Load(store, "test", "")
+	require.NoError(t, err)
+	h.taskState = reloaded
+
+	require.True(t, h.nav.SelectByID(ui.SidebarPlanPrefix+"plan.md"))
+	h.updateInfoPaneForPlanHeader()
+
+	data := h.tabbedWindow.GetInfoData()
+	require.Len(t, data.PlanHistory, 2)
+	assert.Equal(t, "planning", data.PlanHistory[0].Status)
+	assert.Equal(t, "ready", data.PlanHistory[1].Status)
+	assert.False(t, data.PlanHistory[0].At.IsZero())
+}
+
 // TestUpdateInfoPaneForPlanHeader_SubtaskProgress verifies CompletedTasks, TotalSubtasks, and AllWaveSubtasks.
 func TestUpdateInfoPaneForPlanHeader_SubtaskProgress(t *testing.T) {
 	h, _, _, _ := buildInfoPaneHome(t)
@@ -301,6 +323,9 @@ func (f *failingSubtaskStore) Get(project, filename string) (taskstore.TaskEntry
 func (f *failingSubtaskStore) Update(project, filename string, entry taskstore.TaskEntry) error {
 	return f.inner.Update(project, filename, entry)
 }
+func (f *failingSubtaskStore) SetStatuses(project string, filenames []string, status taskstore.Status) error {
+	return f.inner.SetStatuses(project, filenames, status)
+}
 func (f *failingSubtaskStore) Rename(project, oldFilename, newFilename string) error {
 	return f.inner.Rename(project, oldFilename, newFilename)
 }
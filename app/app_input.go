@@ -1,14 +1,19 @@
 package app
 
 import (
+	"errors"
 	"fmt"
 	"github.com/kastheco/kasmos/config"
 	"github.com/kastheco/kasmos/config/auditlog"
 	"github.com/kastheco/kasmos/config/taskstate"
+	"github.com/kastheco/kasmos/config/taskstore"
 	"github.com/kastheco/kasmos/internal/clickup"
+	"github.com/kastheco/kasmos/internal/github"
+	"github.com/kastheco/kasmos/internal/jira"
 	"github.com/kastheco/kasmos/keys"
 	"github.com/kastheco/kasmos/log"
 	"github.com/kastheco/kasmos/session"
+	gitpkg "github.com/kastheco/kasmos/session/git"
 	"github.com/kastheco/kasmos/session/tmux"
 	"github.com/kastheco/kasmos/ui"
 	"github.com/kastheco/kasmos/ui/overlay"
@@ -16,6 +21,7 @@ import (
 	"time"
 	"unicode"
 
+	"charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
 	zone "github.com/lrstanley/bubblezone/v2"
 	"github.com/mattn/go-runewidth"
@@ -28,11 +34,11 @@ func (m *home) handleMenuHighlighting(msg tea.KeyPressMsg) (cmd tea.Cmd, returnE
 		m.keySent = false
 		return nil, false
 	}
-	if m.state == statePrompt || m.state == stateHelp || m.state == stateConfirm || m.state == stateNewPlan || m.state == stateNewPlanDeriving || m.state == stateNewPlanTopic || m.state == stateSpawnAgent || m.state == stateSearch || m.state == stateContextMenu || m.state == statePRTitle || m.state == statePRBody || m.state == stateRenameInstance || m.state == stateRenameTask || m.state == stateSendPrompt || m.state == stateFocusAgent || m.state == stateChangeTopic || m.state == stateSetStatus || m.state == stateClickUpSearch || m.state == stateClickUpPicker || m.state == stateClickUpFetching || m.state == stateClickUpWorkspacePicker || m.state == statePermission || m.state == stateTmuxBrowser || m.state == stateChatAboutTask || m.state == stateAuditCursor || m.state == stateLauncher || m.state == stateKeybindBrowser {
+	if m.state == statePrompt || m.state == stateHelp || m.state == stateConfirm || m.state == stateNewPlan || m.state == stateNewPlanDeriving || m.state == stateNewPlanTopic || m.state == stateNewPlanTemplate || m.state == stateSpawnAgent || m.state == stateSearch || m.state == stateContextMenu || m.state == statePRTitle || m.state == statePRBody || m.state == stateRenameInstance || m.state == stateRenameTask || m.state == stateSendPrompt || m.state == stateFocusAgent || m.state == stateChangeTopic || m.state == stateSetStatus || m.state == stateSetBlockers || m.state == stateSetPriority || m.state == stateClickUpSearch || m.state == stateClickUpPicker || m.state == stateClickUpFetching || m.state == stateClickUpWorkspacePicker || m.state == stateGitHubSearch || m.state == stateGitHubPicker || m.state == stateGitHubFetching || m.state == stateJiraSearch || m.state == stateJiraPicker || m.state == stateJiraFetching || m.state == statePermission || m.state == stateTmuxBrowser || m.state == stateChatAboutTask || m.state == stateAuditCursor || m.state == stateLauncher || m.state == stateKeybindBrowser || m.state == stateBroadcastPrompt || m.state == stateGlobalSearch || m.state == stateGlobalSearchPicker || m.state == statePreviewSearch || m.state == stateOverview || m.state == stateSettings {
 		return nil, false
 	}
 	// If it's in the global keymap, we should try to highlight it.
-	name, ok := keys.GlobalKeyStringsMap[msg.String()]
+	name, ok := keys.Lookup(msg.String(), m.vimMode())
 	if !ok {
 		return nil, false
 	}
@@ -125,6 +131,23 @@ func (m *home) handleMouseClick(msg tea.MouseClickMsg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	// Zone-based click: plan status in the status bar opens the status override.
+	if zone.Get(ui.ZonePlanStatus).InBounds(msg) {
+		return m.openSetStatusPicker(m.nav.GetSelectedPlanFile())
+	}
+
+	// Zone-based click: a failed task row in the info tab's wave grid retries it.
+	if m.nav.IsSelectedPlanHeader() {
+		if orch, ok := m.waveOrchestrators[m.nav.GetSelectedPlanFile()]; ok {
+			for _, t := range orch.CurrentWaveTasks() {
+				if zone.Get(ui.WaveTaskZoneID(t.Number)).InBounds(msg) {
+					mod, cmd, _ := m.retrySelectedPlanWaveTask(t.Number)
+					return mod, cmd
+				}
+			}
+		}
+	}
+
 	// Zone-based click: "view plan doc" button in info tab
 	if zone.Get(ui.ZoneViewPlan).InBounds(msg) {
 		return m.viewSelectedPlan()
@@ -173,15 +196,11 @@ func (m *home) handleActiveOverlayMouse(msg tea.MouseClickMsg) (tea.Model, tea.C
 			to.OnDismiss()
 		}
 		m.state = stateDefault
-		pending := m.pendingAttachInstance
+		pending, readonly := m.pendingAttachInstance, m.pendingAttachReadonly
 		m.pendingAttachInstance = nil
+		m.pendingAttachReadonly = false
 		if pending != nil && pending.Started() && !pending.Paused() && pending.TmuxAlive() {
-			return m, tea.Exec(tmux.NewAttachExecCommand(pending), func(err error) tea.Msg {
-				if err != nil {
-					return err
-				}
-				return instanceChangedMsg{}
-			})
+			return m, attachExecCmd(pending, readonly)
 		}
 		return m, tea.Sequence(tea.RequestWindowSize, func() tea.Msg {
 			m.menu.SetState(ui.StateDefault)
@@ -197,6 +216,7 @@ func (m *home) handleActiveOverlayMouse(msg tea.MouseClickMsg) (tea.Model, tea.C
 			m.state = stateDefault
 			m.pendingConfirmAction = nil
 			m.pendingWaveAbortAction = nil
+			m.pendingWaveRetryTimedOutAction = nil
 			m.pendingWaveNextAction = nil
 			m.pendingWaveConfirmTaskFile = ""
 			return m, action
@@ -219,6 +239,7 @@ func (m *home) handleActiveOverlayMouse(msg tea.MouseClickMsg) (tea.Model, tea.C
 		m.state = stateDefault
 		m.pendingConfirmAction = nil
 		m.pendingWaveAbortAction = nil
+		m.pendingWaveRetryTimedOutAction = nil
 		m.pendingWaveNextAction = nil
 		return m, nil
 
@@ -234,13 +255,15 @@ func (m *home) handleActiveOverlayMouse(msg tea.MouseClickMsg) (tea.Model, tea.C
 			tea.RequestWindowSize,
 			func() tea.Msg {
 				m.menu.SetState(ui.StateDefault)
-				m.showHelpScreen(helpStart(selected), nil)
+				m.showHelpScreen(helpStart(selected, m.vimMode()), nil)
 				return nil
 			},
 		)
 
 	case statePRTitle:
 		m.pendingPRWorktree = nil
+		m.pendingPRPlan = ""
+		m.pendingPRDraft = false
 		m.state = stateDefault
 		m.menu.SetState(ui.StateDefault)
 		return m, tea.RequestWindowSize
@@ -248,6 +271,8 @@ func (m *home) handleActiveOverlayMouse(msg tea.MouseClickMsg) (tea.Model, tea.C
 	case statePRBody:
 		m.pendingPRTitle = ""
 		m.pendingPRWorktree = nil
+		m.pendingPRPlan = ""
+		m.pendingPRDraft = false
 		m.state = stateDefault
 		m.menu.SetState(ui.StateDefault)
 		return m, tea.RequestWindowSize
@@ -268,39 +293,34 @@ func (m *home) handleActiveOverlayMouse(msg tea.MouseClickMsg) (tea.Model, tea.C
 		m.menu.SetState(ui.StateDefault)
 		return m, tea.RequestWindowSize
 
-	case stateSendPrompt:
+	case stateBroadcastPrompt:
+		m.pendingBroadcastPlan = ""
 		m.state = stateDefault
 		m.menu.SetState(ui.StateDefault)
 		return m, tea.RequestWindowSize
 
-	case stateNewPlan:
+	case stateGlobalSearch:
+		return m.finishGlobalSearch(result)
+
+	case stateGlobalSearchPicker:
+		return m.finishGlobalSearchPicker(result)
+
+	case stateSendPrompt:
 		m.state = stateDefault
 		m.menu.SetState(ui.StateDefault)
 		return m, tea.RequestWindowSize
 
-	case stateNewPlanTopic:
-		topic := ""
-		if result.Submitted {
-			picked := result.Value
-			if picked != "(No topic)" {
-				topic = picked
-			}
-		}
-		if err := m.createTaskEntry(m.pendingPlanName, m.pendingPlanDesc, topic); err != nil {
-			m.state = stateDefault
-			m.menu.SetState(ui.StateDefault)
-			m.pendingPlanName = ""
-			m.pendingPlanDesc = ""
-			return m, m.handleError(err)
-		}
-		m.loadTaskState()
-		m.updateSidebarTasks()
+	case stateNewPlan:
 		m.state = stateDefault
 		m.menu.SetState(ui.StateDefault)
-		m.pendingPlanName = ""
-		m.pendingPlanDesc = ""
 		return m, tea.RequestWindowSize
 
+	case stateNewPlanTopic:
+		return m.finishNewPlanTopic(result)
+
+	case stateNewPlanTemplate:
+		return m.finishNewPlanTemplate(result)
+
 	case stateSpawnAgent:
 		m.state = stateDefault
 		m.menu.SetState(ui.StateDefault)
@@ -324,29 +344,14 @@ func (m *home) handleActiveOverlayMouse(msg tea.MouseClickMsg) (tea.Model, tea.C
 		m.pendingChangeTopicTask = ""
 		return m, tea.RequestWindowSize
 
+	case stateSetPriority:
+		return m.finishSetPriority(result)
+
 	case stateSetStatus:
-		if result.Submitted && m.taskState != nil && m.pendingSetStatusTask != "" {
-			picked := result.Value
-			if picked != "" {
-				if err := m.taskState.ForceSetStatus(m.pendingSetStatusTask, taskstate.Status(picked)); err != nil {
-					m.state = stateDefault
-					m.pendingSetStatusTask = ""
-					return m, m.handleError(err)
-				}
-				m.audit(auditlog.EventPlanTransition, "manual override → "+picked,
-					auditlog.WithPlan(m.pendingSetStatusTask),
-					auditlog.WithDetail("manual override"))
-				m.loadTaskState()
-				m.updateSidebarTasks()
-				m.toastManager.Success(fmt.Sprintf("status → %s", picked))
-				m.state = stateDefault
-				m.pendingSetStatusTask = ""
-				return m, tea.Batch(tea.RequestWindowSize, m.toastTickCmd())
-			}
-		}
-		m.state = stateDefault
-		m.pendingSetStatusTask = ""
-		return m, tea.RequestWindowSize
+		return m.finishSetStatus(result)
+
+	case stateSetBlockers:
+		return m.finishSetBlockers(result)
 
 	case stateClickUpSearch:
 		m.state = stateDefault
@@ -394,6 +399,36 @@ func (m *home) handleActiveOverlayMouse(msg tea.MouseClickMsg) (tea.Model, tea.C
 		m.clickUpWorkspaceMap = nil
 		return m, nil
 
+	case stateGitHubSearch:
+		m.state = stateDefault
+		return m, nil
+
+	case stateGitHubPicker:
+		if result.Submitted {
+			if r, ok := m.selectedGitHubResult(result.Value); ok {
+				m.state = stateGitHubFetching
+				m.toastManager.Info("fetching issue details...")
+				return m, tea.Batch(m.fetchGitHubTask(r.Number), m.toastTickCmd())
+			}
+		}
+		m.state = stateDefault
+		return m, nil
+
+	case stateJiraSearch:
+		m.state = stateDefault
+		return m, nil
+
+	case stateJiraPicker:
+		if result.Submitted {
+			if r, ok := m.selectedJiraResult(result.Value); ok {
+				m.state = stateJiraFetching
+				m.toastManager.Info("fetching issue details...")
+				return m, tea.Batch(m.fetchJiraTask(r.Key), m.toastTickCmd())
+			}
+		}
+		m.state = stateDefault
+		return m, nil
+
 	case stateTmuxBrowser:
 		browser, _ := current.(*overlay.TmuxBrowserOverlay)
 		m.state = stateDefault
@@ -403,6 +438,225 @@ func (m *home) handleActiveOverlayMouse(msg tea.MouseClickMsg) (tea.Model, tea.C
 	return m, nil
 }
 
+// attachSelectedInstance attaches to the selected instance's tmux session,
+// showing the attach help screen first if it hasn't been seen. With readonly
+// set, the attach goes through a read-only tmux client so keystrokes never
+// reach the agent.
+func (m *home) attachSelectedInstance(readonly bool) (tea.Model, tea.Cmd) {
+	if m.nav.NumInstances() == 0 {
+		return m, nil
+	}
+	selected := m.nav.GetSelectedInstance()
+	if selected == nil || !selected.Started() || selected.Paused() {
+		return m, nil
+	}
+	if !selected.TmuxAlive() {
+		m.toastManager.Error(fmt.Sprintf("session for '%s' is not running", selected.Title))
+		return m, m.toastTickCmd()
+	}
+	if config.NormalizeExecutionMode(string(selected.ExecutionMode)) == config.ExecutionModeHeadless {
+		m.toastManager.Info(fmt.Sprintf("%s is running in headless mode; attach is disabled", selected.Title))
+		return m, nil
+	}
+	// Queue the selected instance, then show the attach help overlay.
+	// Actual attach (via tea.Exec) happens in handleHelpState once the user
+	// dismisses the help screen — this keeps bubbletea's event loop free.
+	m.pendingAttachInstance = selected
+	m.pendingAttachReadonly = readonly
+	var help helpText = helpTypeInstanceAttach{}
+	if readonly {
+		help = helpTypeInstanceAttachReadonly{}
+	}
+	m.showHelpScreen(help, nil)
+	// If the overlay was skipped (already seen), showHelpScreen returns without
+	// setting m.state = stateHelp. In that case consume pendingAttachInstance
+	// immediately so the attach is not silently abandoned.
+	if m.state != stateHelp && m.pendingAttachInstance != nil {
+		pending := m.pendingAttachInstance
+		m.pendingAttachInstance = nil
+		m.pendingAttachReadonly = false
+		return m, attachExecCmd(pending, readonly)
+	}
+	return m, nil
+}
+
+// attachExecCmd hands the terminal to inst's tmux session via tea.Exec,
+// optionally through a read-only client.
+func attachExecCmd(inst *session.Instance, readonly bool) tea.Cmd {
+	cmd := tmux.NewAttachExecCommand(inst)
+	if readonly {
+		cmd = tmux.NewReadonlyAttachExecCommand(inst)
+	}
+	return tea.Exec(cmd, func(err error) tea.Msg {
+		if err != nil {
+			return err
+		}
+		return instanceChangedMsg{}
+	})
+}
+
+// priorityLabels lists the set-priority picker options, highest first. The
+// label's distance from the end of the slice is the stored priority value.
+var priorityLabels = []string{"urgent", "high", "low", "none"}
+
+// setStatusOptions lists the statuses offered by the set-status picker.
+var setStatusOptions = []string{"ready", "planning", "implementing", "reviewing", "done", "cancelled"}
+
+// priorityFromLabel maps a set-priority picker label to its priority value.
+func priorityFromLabel(label string) (int, bool) {
+	for i, l := range priorityLabels {
+		if l == label {
+			return len(priorityLabels) - 1 - i, true
+		}
+	}
+	return 0, false
+}
+
+// finishSetPriority applies the set-priority picker result to the pending plan
+// and returns to the default state.
+func (m *home) finishSetPriority(result overlay.Result) (tea.Model, tea.Cmd) {
+	planFile := m.pendingSetPriorityTask
+	m.state = stateDefault
+	m.pendingSetPriorityTask = ""
+	if !result.Submitted || m.taskState == nil || planFile == "" {
+		return m, tea.RequestWindowSize
+	}
+	priority, ok := priorityFromLabel(result.Value)
+	if !ok {
+		return m, tea.RequestWindowSize
+	}
+	if err := m.taskState.SetPriority(planFile, priority); err != nil {
+		return m, m.handleError(err)
+	}
+	m.updateSidebarTasks()
+	m.toastManager.Success(fmt.Sprintf("priority → %s", result.Value))
+	return m, tea.Batch(tea.RequestWindowSize, m.toastTickCmd())
+}
+
+// finishSetBlockers stores the plans checked in the set-blockers picker as the
+// pending plan's blockers and returns to the default state.
+func (m *home) finishSetBlockers(result overlay.Result) (tea.Model, tea.Cmd) {
+	planFile := m.pendingSetBlockersTask
+	m.state = stateDefault
+	m.pendingSetBlockersTask = ""
+	if !result.Submitted || m.taskState == nil || planFile == "" {
+		return m, tea.RequestWindowSize
+	}
+	var blockers []string
+	for _, b := range strings.Split(result.Value, "\n") {
+		if b != "" {
+			blockers = append(blockers, b)
+		}
+	}
+	if err := m.taskState.SetBlockers(planFile, blockers); err != nil {
+		return m, m.handleError(err)
+	}
+	m.updateSidebarTasks()
+	if len(blockers) == 0 {
+		m.toastManager.Success(fmt.Sprintf("cleared blockers for '%s'", taskstate.DisplayName(planFile)))
+	} else {
+		m.toastManager.Success(fmt.Sprintf("'%s' blocked by %d task(s)", taskstate.DisplayName(planFile), len(blockers)))
+	}
+	return m, tea.Batch(tea.RequestWindowSize, m.toastTickCmd())
+}
+
+// blankTemplateLabel is the template picker entry that keeps the default stub.
+const blankTemplateLabel = "(blank)"
+
+// finishNewPlanTopic records the picked topic, then either shows the template
+// picker (when plan templates exist) or creates the plan from the default stub.
+func (m *home) finishNewPlanTopic(result overlay.Result) (tea.Model, tea.Cmd) {
+	topic := ""
+	if result.Submitted && result.Value != "(No topic)" {
+		topic = result.Value
+	}
+
+	templates, err := config.LoadPlanTemplates()
+	if err != nil {
+		log.WarningLog.Printf("new plan: %v", err)
+	}
+	if len(templates) == 0 {
+		return m.createPendingPlan(topic, "")
+	}
+
+	m.pendingPlanTopic = topic
+	m.planTemplates = templates
+	names := []string{blankTemplateLabel}
+	for _, t := range templates {
+		names = append(names, t.Name)
+	}
+	m.overlays.Show(overlay.NewPickerOverlay(fmt.Sprintf("template for '%s'", m.pendingPlanName), names))
+	m.state = stateNewPlanTemplate
+	return m, nil
+}
+
+// finishNewPlanTemplate creates the pending plan from the picked template.
+// Dismissing the picker or choosing "(blank)" uses the default stub.
+func (m *home) finishNewPlanTemplate(result overlay.Result) (tea.Model, tea.Cmd) {
+	content := ""
+	if result.Submitted {
+		for _, t := range m.planTemplates {
+			if t.Name == result.Value {
+				content = t.Content
+				break
+			}
+		}
+	}
+	return m.createPendingPlan(m.pendingPlanTopic, content)
+}
+
+// createPendingPlan creates the plan gathered by the new-plan wizard and
+// returns to the default state.
+func (m *home) createPendingPlan(topic, template string) (tea.Model, tea.Cmd) {
+	name, description := m.pendingPlanName, m.pendingPlanDesc
+	m.state = stateDefault
+	m.menu.SetState(ui.StateDefault)
+	m.resetPendingPlan()
+	if err := m.createTaskEntryFromTemplate(name, description, topic, template); err != nil {
+		return m, m.handleError(err)
+	}
+	m.loadTaskState()
+	m.updateSidebarTasks()
+	return m, tea.RequestWindowSize
+}
+
+// resetPendingPlan clears the state carried between new-plan wizard steps.
+func (m *home) resetPendingPlan() {
+	m.pendingPlanName = ""
+	m.pendingPlanDesc = ""
+	m.pendingPlanTopic = ""
+	m.planTemplates = nil
+}
+
+// finishSetStatus applies the set-status picker result. For a single plan the
+// status is written immediately; for a topic the user confirms first.
+func (m *home) finishSetStatus(result overlay.Result) (tea.Model, tea.Cmd) {
+	planFile, topic := m.pendingSetStatusTask, m.pendingSetStatusTopic
+	m.state = stateDefault
+	m.pendingSetStatusTask = ""
+	m.pendingSetStatusTopic = ""
+	picked := result.Value
+	if !result.Submitted || m.taskState == nil || picked == "" {
+		return m, tea.RequestWindowSize
+	}
+	if topic != "" {
+		return m.confirmTopicStatus(topic, taskstate.Status(picked))
+	}
+	if planFile == "" {
+		return m, tea.RequestWindowSize
+	}
+	if err := m.taskState.ForceSetStatus(planFile, taskstate.Status(picked)); err != nil {
+		return m, m.handleError(err)
+	}
+	m.audit(auditlog.EventPlanTransition, "manual override → "+picked,
+		auditlog.WithPlan(planFile),
+		auditlog.WithDetail("manual override"))
+	m.loadTaskState()
+	m.updateSidebarTasks()
+	m.toastManager.Success(fmt.Sprintf("status → %s", picked))
+	return m, tea.Batch(tea.RequestWindowSize, m.toastTickCmd())
+}
+
 func (m *home) finishPermissionOverlay(result overlay.Result) (tea.Model, tea.Cmd) {
 	if result.Submitted {
 		cacheKey := config.CacheKey(m.pendingPermissionPattern, m.pendingPermissionDesc)
@@ -482,6 +736,9 @@ func (m *home) handleRightClick(msg tea.MouseClickMsg) (tea.Model, tea.Cmd) {
 		if planFile := m.nav.GetSelectedPlanFile(); planFile != "" {
 			return m.openTaskContextMenu()
 		}
+		if m.nav.GetSelectedTopic() != "" {
+			return m.openTopicContextMenu()
+		}
 		return m, nil
 	}
 	return m, nil
@@ -563,6 +820,7 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 				m.menu.SetState(ui.StatePrompt)
 				tio := overlay.NewTextInputOverlay("enter prompt", "")
 				tio.SetSize(50, 5)
+				tio.SetHistory(m.promptHistory())
 				m.overlays.Show(tio)
 				m.promptAfterName = false
 			}
@@ -626,6 +884,7 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 					// TODO: we probably end up in a bad state here.
 					return m, m.handleError(err)
 				}
+				m.rememberPrompt(promptText)
 				// Emit audit event for prompt sent (truncate to 200 chars).
 				msg := promptText
 				if len(msg) > 200 {
@@ -642,7 +901,7 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 				tea.RequestWindowSize,
 				func() tea.Msg {
 					m.menu.SetState(ui.StateDefault)
-					m.showHelpScreen(helpStart(selected), nil)
+					m.showHelpScreen(helpStart(selected, m.vimMode()), nil)
 					return nil
 				},
 			)
@@ -656,6 +915,11 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 		if !m.overlays.IsActive() {
 			m.state = stateDefault
 			m.pendingPRWorktree = nil
+			m.pendingPRPlan = ""
+			return m, nil
+		}
+		if key.Matches(msg, keys.GlobalkeyBindings[keys.KeyToggleDraft]) {
+			m.togglePRDraft()
 			return m, nil
 		}
 		result := m.overlays.HandleKey(msg)
@@ -684,16 +948,22 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 							generatedBody = body
 						}
 					}
+					if m.pendingPRPlan != "" {
+						generatedBody = m.planPRBody(m.pendingPRPlan, generatedBody)
+					}
 
 					// Transition to PR body editing state
 					m.state = statePRBody
-					tio := overlay.NewTextInputOverlay("pr description (edit or submit)", generatedBody)
+					tio := overlay.NewTextInputOverlay(prOverlayTitle("pr description (edit or submit)", m.pendingPRDraft), generatedBody)
 					tio.SetSize(80, 20)
+					tio.SetToggle("squash commits", prSquashAction, false)
 					m.overlays.Show(tio)
 					return m, nil
 				}
 			}
 			m.pendingPRWorktree = nil
+			m.pendingPRPlan = ""
+			m.pendingPRDraft = false
 			m.state = stateDefault
 			m.menu.SetState(ui.StateDefault)
 			return m, tea.RequestWindowSize
@@ -706,6 +976,11 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 		if !m.overlays.IsActive() {
 			m.state = stateDefault
 			m.pendingPRWorktree = nil
+			m.pendingPRPlan = ""
+			return m, nil
+		}
+		if key.Matches(msg, keys.GlobalkeyBindings[keys.KeyToggleDraft]) {
+			m.togglePRDraft()
 			return m, nil
 		}
 		result := m.overlays.HandleKey(msg)
@@ -713,8 +988,11 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 			if result.Submitted {
 				prBody := result.Value
 				prTitle := m.pendingPRTitle
+				squash := result.Action == prSquashAction
+				prOpts := gitpkg.PROptions{Draft: m.pendingPRDraft}
 				if prTitle != "" {
 					m.pendingPRTitle = ""
+					m.pendingPRDraft = false
 					m.state = stateDefault
 					m.menu.SetState(ui.StateDefault)
 					m.pendingPRToastID = m.toastManager.Loading("creating PR...")
@@ -724,14 +1002,22 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 					// Use pendingPRWorktree (plan-level PR without a running instance)
 					// when available; otherwise fall back to the selected instance's worktree.
 					if pendingWT := m.pendingPRWorktree; pendingWT != nil {
+						capturedPlan := m.pendingPRPlan
 						m.pendingPRWorktree = nil
+						m.pendingPRPlan = ""
 						capturedWT := pendingWT
 						return m, tea.Batch(tea.RequestWindowSize, func() tea.Msg {
 							commitMsg := fmt.Sprintf("[kas] update on %s", time.Now().Format(time.RFC822))
-							if err := capturedWT.CreatePR(capturedPRTitle, prBody, commitMsg); err != nil {
+							if squash {
+								if err := capturedWT.SquashAll(capturedPRTitle); err != nil {
+									return prErrorMsg{id: prToastID, err: err}
+								}
+							}
+							url, err := capturedWT.CreateReviewWithOptions(capturedPRTitle, prBody, commitMsg, prOpts)
+							if err != nil {
 								return prErrorMsg{id: prToastID, err: err}
 							}
-							return prCreatedMsg{instanceTitle: capturedPRTitle, prTitle: capturedPRTitle}
+							return prCreatedMsg{instanceTitle: capturedPRTitle, prTitle: capturedPRTitle, planFile: capturedPlan, url: url}
 						}, m.toastTickCmd())
 					}
 
@@ -744,10 +1030,16 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 							if err != nil {
 								return prErrorMsg{id: prToastID, err: err}
 							}
-							if err := worktree.CreatePR(capturedPRTitle, prBody, commitMsg); err != nil {
+							if squash {
+								if err := worktree.SquashAll(capturedPRTitle); err != nil {
+									return prErrorMsg{id: prToastID, err: err}
+								}
+							}
+							url, err := worktree.CreateReviewWithOptions(capturedPRTitle, prBody, commitMsg, prOpts)
+							if err != nil {
 								return prErrorMsg{id: prToastID, err: err}
 							}
-							return prCreatedMsg{instanceTitle: capturedTitle, prTitle: capturedPRTitle}
+							return prCreatedMsg{instanceTitle: capturedTitle, prTitle: capturedPRTitle, url: url}
 						}, m.toastTickCmd())
 					}
 
@@ -758,6 +1050,8 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 			}
 			m.pendingPRTitle = ""
 			m.pendingPRWorktree = nil
+			m.pendingPRPlan = ""
+			m.pendingPRDraft = false
 			m.state = stateDefault
 			m.menu.SetState(ui.StateDefault)
 			return m, tea.RequestWindowSize
@@ -857,6 +1151,45 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 		return m, nil
 	}
 
+	// Handle broadcast prompt input
+	if m.state == stateBroadcastPrompt {
+		if !m.overlays.IsActive() {
+			m.state = stateDefault
+			return m, nil
+		}
+		result := m.overlays.HandleKey(msg)
+		if result.Dismissed {
+			planFile := m.pendingBroadcastPlan
+			m.pendingBroadcastPlan = ""
+			m.state = stateDefault
+			m.menu.SetState(ui.StateDefault)
+			if result.Submitted && planFile != "" && strings.TrimSpace(result.Value) != "" {
+				n := m.broadcastPrompt(planFile, result.Value)
+				m.rememberPrompt(result.Value)
+				m.toastManager.Success(fmt.Sprintf("sent to %d sessions", n))
+				return m, tea.Batch(tea.RequestWindowSize, m.toastTickCmd())
+			}
+			return m, tea.RequestWindowSize
+		}
+		return m, nil
+	}
+
+	// Handle plan content search input and result picker
+	if m.state == stateGlobalSearch || m.state == stateGlobalSearchPicker {
+		if !m.overlays.IsActive() {
+			m.state = stateDefault
+			return m, nil
+		}
+		result := m.overlays.HandleKey(msg)
+		if !result.Dismissed {
+			return m, nil
+		}
+		if m.state == stateGlobalSearch {
+			return m.finishGlobalSearch(result)
+		}
+		return m.finishGlobalSearchPicker(result)
+	}
+
 	// Handle focus mode — forward keys directly to the agent's PTY
 	if m.state == stateFocusAgent {
 		// Ctrl+Space exits focus mode
@@ -923,6 +1256,7 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 						return m, m.handleError(err)
 					}
 					selected.SetStatus(session.Running)
+					m.rememberPrompt(value)
 					// Emit audit event for prompt sent (truncate to 200 chars).
 					auditMsg := value
 					if len(auditMsg) > 200 {
@@ -953,10 +1287,23 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 			m.state = stateDefault
 			m.pendingConfirmAction = nil
 			m.pendingWaveAbortAction = nil
+			m.pendingWaveRetryTimedOutAction = nil
 			m.pendingWaveNextAction = nil
 			m.pendingWaveConfirmTaskFile = ""
 			return m, abortAction
 		}
+		// Pre-intercept 't' (retry timed-out tasks only) for the failed-wave dialog.
+		if msg.String() == "t" && m.pendingWaveRetryTimedOutAction != nil {
+			retryAction := m.pendingWaveRetryTimedOutAction
+			m.overlays.Dismiss()
+			m.state = stateDefault
+			m.pendingConfirmAction = nil
+			m.pendingWaveAbortAction = nil
+			m.pendingWaveNextAction = nil
+			m.pendingWaveRetryTimedOutAction = nil
+			m.pendingWaveConfirmTaskFile = ""
+			return m, retryAction
+		}
 		// Pre-intercept 'enter' as an alias for the confirm key.
 		// ConfirmationOverlay.HandleKey only handles ConfirmKey ("y"/"r"), not "enter".
 		effectiveMsg := msg
@@ -973,6 +1320,7 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 				m.state = stateDefault
 				m.pendingConfirmAction = nil
 				m.pendingWaveAbortAction = nil
+				m.pendingWaveRetryTimedOutAction = nil
 				m.pendingWaveNextAction = nil
 				m.pendingWaveConfirmTaskFile = ""
 				// Return the action as a tea.Cmd so bubbletea runs it asynchronously.
@@ -1002,6 +1350,7 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 				m.state = stateDefault
 				m.pendingConfirmAction = nil
 				m.pendingWaveAbortAction = nil
+				m.pendingWaveRetryTimedOutAction = nil
 				m.pendingWaveNextAction = nil
 				return m, nil
 			}
@@ -1011,6 +1360,7 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 				m.state = stateDefault
 				m.pendingConfirmAction = nil
 				m.pendingWaveAbortAction = nil
+				m.pendingWaveRetryTimedOutAction = nil
 				m.pendingWaveNextAction = nil
 				m.pendingWaveConfirmTaskFile = ""
 				return m, nextAction
@@ -1036,6 +1386,7 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 			m.state = stateDefault
 			m.pendingConfirmAction = nil
 			m.pendingWaveAbortAction = nil
+			m.pendingWaveRetryTimedOutAction = nil
 			m.pendingWaveNextAction = nil
 			return m, nil
 		}
@@ -1115,33 +1466,26 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 	if m.state == stateNewPlanTopic {
 		if !m.overlays.IsActive() {
 			m.state = stateDefault
-			m.pendingPlanName = ""
-			m.pendingPlanDesc = ""
+			m.resetPendingPlan()
 			return m, nil
 		}
 		result := m.overlays.HandleKey(msg)
 		if result.Dismissed {
-			topic := ""
-			if result.Submitted {
-				picked := result.Value
-				if picked != "(No topic)" {
-					topic = picked
-				}
-			}
-			if err := m.createTaskEntry(m.pendingPlanName, m.pendingPlanDesc, topic); err != nil {
-				m.state = stateDefault
-				m.menu.SetState(ui.StateDefault)
-				m.pendingPlanName = ""
-				m.pendingPlanDesc = ""
-				return m, m.handleError(err)
-			}
-			m.loadTaskState()
-			m.updateSidebarTasks()
+			return m.finishNewPlanTopic(result)
+		}
+		return m, nil
+	}
+
+	// Handle new plan template picker state
+	if m.state == stateNewPlanTemplate {
+		if !m.overlays.IsActive() {
 			m.state = stateDefault
-			m.menu.SetState(ui.StateDefault)
-			m.pendingPlanName = ""
-			m.pendingPlanDesc = ""
-			return m, tea.RequestWindowSize
+			m.resetPendingPlan()
+			return m, nil
+		}
+		result := m.overlays.HandleKey(msg)
+		if result.Dismissed {
+			return m.finishNewPlanTemplate(result)
 		}
 		return m, nil
 	}
@@ -1160,6 +1504,8 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 				name := fo.Name()
 				branch := fo.Branch()
 				workPath := fo.WorkPath()
+				shared := fo.SharedWorktree()
+				role := fo.Role()
 
 				if name == "" {
 					m.state = stateDefault
@@ -1167,7 +1513,7 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 					return m, m.handleError(fmt.Errorf("name cannot be empty"))
 				}
 
-				return m.spawnAdHocAgent(name, branch, workPath)
+				return m.spawnAdHocAgent(name, branch, workPath, role, shared)
 			}
 			m.state = stateDefault
 			m.menu.SetState(ui.StateDefault)
@@ -1205,37 +1551,45 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 		return m, nil
 	}
 
+	// Handle set-priority picker for existing plans
+	if m.state == stateSetPriority {
+		if !m.overlays.IsActive() {
+			m.state = stateDefault
+			m.pendingSetPriorityTask = ""
+			return m, nil
+		}
+		result := m.overlays.HandleKey(msg)
+		if result.Dismissed {
+			return m.finishSetPriority(result)
+		}
+		return m, nil
+	}
+
+	// Handle set-blockers multi-picker
+	if m.state == stateSetBlockers {
+		if !m.overlays.IsActive() {
+			m.state = stateDefault
+			m.pendingSetBlockersTask = ""
+			return m, nil
+		}
+		result := m.overlays.HandleKey(msg)
+		if result.Dismissed {
+			return m.finishSetBlockers(result)
+		}
+		return m, nil
+	}
+
 	// Handle set-status picker for force-overriding a plan's status
 	if m.state == stateSetStatus {
 		if !m.overlays.IsActive() {
 			m.state = stateDefault
 			m.pendingSetStatusTask = ""
+			m.pendingSetStatusTopic = ""
 			return m, nil
 		}
 		result := m.overlays.HandleKey(msg)
 		if result.Dismissed {
-			if result.Submitted && m.taskState != nil && m.pendingSetStatusTask != "" {
-				picked := result.Value
-				if picked != "" {
-					if err := m.taskState.ForceSetStatus(m.pendingSetStatusTask, taskstate.Status(picked)); err != nil {
-						m.state = stateDefault
-						m.pendingSetStatusTask = ""
-						return m, m.handleError(err)
-					}
-					m.audit(auditlog.EventPlanTransition, "manual override → "+picked,
-						auditlog.WithPlan(m.pendingSetStatusTask),
-						auditlog.WithDetail("manual override"))
-					m.loadTaskState()
-					m.updateSidebarTasks()
-					m.toastManager.Success(fmt.Sprintf("status → %s", picked))
-					m.state = stateDefault
-					m.pendingSetStatusTask = ""
-					return m, tea.Batch(tea.RequestWindowSize, m.toastTickCmd())
-				}
-			}
-			m.state = stateDefault
-			m.pendingSetStatusTask = ""
-			return m, tea.RequestWindowSize
+			return m.finishSetStatus(result)
 		}
 		return m, nil
 	}
@@ -1288,6 +1642,12 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 	}
 
 	if m.state == stateClickUpFetching {
+		if msg.Code == tea.KeyEscape {
+			m.cancelClickUpOp()
+			m.state = stateDefault
+			m.toastManager.Info("clickup request canceled")
+			return m, m.toastTickCmd()
+		}
 		return m, nil
 	}
 
@@ -1329,6 +1689,96 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 		return m, nil
 	}
 
+	// Handle GitHub issue search input state
+	if m.state == stateGitHubSearch {
+		if !m.overlays.IsActive() {
+			m.state = stateDefault
+			return m, nil
+		}
+		result := m.overlays.HandleKey(msg)
+		if result.Dismissed {
+			if result.Submitted {
+				query := strings.TrimSpace(result.Value)
+				if query != "" {
+					m.state = stateGitHubFetching
+					m.toastManager.Info("searching github...")
+					return m, tea.Batch(m.searchGitHub(query), m.toastTickCmd())
+				}
+			}
+			m.state = stateDefault
+		}
+		return m, nil
+	}
+
+	// Handle GitHub issue picker state
+	if m.state == stateGitHubPicker {
+		if !m.overlays.IsActive() {
+			m.state = stateDefault
+			return m, nil
+		}
+		result := m.overlays.HandleKey(msg)
+		if result.Dismissed {
+			if result.Submitted {
+				if r, ok := m.selectedGitHubResult(result.Value); ok {
+					m.state = stateGitHubFetching
+					m.toastManager.Info("fetching issue details...")
+					return m, tea.Batch(m.fetchGitHubTask(r.Number), m.toastTickCmd())
+				}
+			}
+			m.state = stateDefault
+		}
+		return m, nil
+	}
+
+	if m.state == stateGitHubFetching {
+		return m, nil
+	}
+
+	// Handle Jira issue search input state
+	if m.state == stateJiraSearch {
+		if !m.overlays.IsActive() {
+			m.state = stateDefault
+			return m, nil
+		}
+		result := m.overlays.HandleKey(msg)
+		if result.Dismissed {
+			if result.Submitted {
+				query := strings.TrimSpace(result.Value)
+				if query != "" {
+					m.state = stateJiraFetching
+					m.toastManager.Info("searching jira...")
+					return m, tea.Batch(m.searchJira(query), m.toastTickCmd())
+				}
+			}
+			m.state = stateDefault
+		}
+		return m, nil
+	}
+
+	// Handle Jira issue picker state
+	if m.state == stateJiraPicker {
+		if !m.overlays.IsActive() {
+			m.state = stateDefault
+			return m, nil
+		}
+		result := m.overlays.HandleKey(msg)
+		if result.Dismissed {
+			if result.Submitted {
+				if r, ok := m.selectedJiraResult(result.Value); ok {
+					m.state = stateJiraFetching
+					m.toastManager.Info("fetching issue details...")
+					return m, tea.Batch(m.fetchJiraTask(r.Key), m.toastTickCmd())
+				}
+			}
+			m.state = stateDefault
+		}
+		return m, nil
+	}
+
+	if m.state == stateJiraFetching {
+		return m, nil
+	}
+
 	if m.state == stateTmuxBrowser {
 		if !m.overlays.IsActive() {
 			m.state = stateDefault
@@ -1365,6 +1815,37 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 		return m, nil
 	}
 
+	// Handle the read-only overview; it only closes.
+	if m.state == stateOverview {
+		if !m.overlays.IsActive() {
+			m.state = stateDefault
+			return m, nil
+		}
+		if m.overlays.HandleKey(msg).Dismissed {
+			m.state = stateDefault
+			return m, tea.RequestWindowSize
+		}
+		return m, nil
+	}
+
+	// Handle the settings form
+	if m.state == stateSettings {
+		if !m.overlays.IsActive() {
+			m.state = stateDefault
+			return m, nil
+		}
+		so, _ := m.overlays.Current().(*overlay.SettingsOverlay)
+		result := m.overlays.HandleKey(msg)
+		if result.Dismissed {
+			m.state = stateDefault
+			if result.Submitted && so != nil {
+				return m.saveSettings(so.Values())
+			}
+			return m, tea.RequestWindowSize
+		}
+		return m, nil
+	}
+
 	// Handle keybind browser state
 	if m.state == stateKeybindBrowser {
 		if !m.overlays.IsActive() {
@@ -1379,6 +1860,29 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 		return m, nil
 	}
 
+	// Handle scrollback search input in the preview's scroll mode.
+	if m.state == statePreviewSearch {
+		q := m.tabbedWindow.PreviewSearchQuery()
+		switch {
+		case msg.String() == "esc":
+			m.tabbedWindow.ClearPreviewSearch()
+			m.state = stateDefault
+		case msg.String() == "enter":
+			m.tabbedWindow.EndPreviewSearchInput()
+			m.state = stateDefault
+		case msg.Code == tea.KeyBackspace:
+			if len(q) > 0 {
+				runes := []rune(q)
+				m.tabbedWindow.SetPreviewSearchQuery(string(runes[:len(runes)-1]))
+			}
+		case msg.Code == tea.KeySpace:
+			m.tabbedWindow.SetPreviewSearchQuery(q + " ")
+		case len(msg.Text) > 0:
+			m.tabbedWindow.SetPreviewSearchQuery(q + msg.Text)
+		}
+		return m, nil
+	}
+
 	// Handle search state — allows typing to filter AND arrow keys to navigate
 	if m.state == stateSearch {
 		switch {
@@ -1396,6 +1900,15 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 		case msg.String() == "down":
 			m.nav.Down()
 			return m, m.instanceChanged()
+		case msg.String() == "ctrl+r":
+			// Regex mode is a leading "/" on the query; toggle it in place.
+			q := m.nav.GetSearchQuery()
+			if strings.HasPrefix(q, "/") {
+				m.nav.SetSearchQuery(strings.TrimPrefix(q, "/"))
+			} else {
+				m.nav.SetSearchQuery("/" + q)
+			}
+			return m, nil
 		case msg.Code == tea.KeyBackspace:
 			q := m.nav.GetSearchQuery()
 			if len(q) > 0 {
@@ -1421,6 +1934,11 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 			m.tabbedWindow.ClearDocumentMode()
 			return m, m.instanceChanged()
 		}
+		// A scrollback search is cleared before leaving scroll mode.
+		if m.tabbedWindow.IsPreviewSearchActive() {
+			m.tabbedWindow.ClearPreviewSearch()
+			return m, nil
+		}
 		// If in scroll mode, exit scroll mode
 		if m.tabbedWindow.IsPreviewInScrollMode() {
 			// Use the selected instance from the list
@@ -1433,6 +1951,26 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 		}
 	}
 
+	// Scroll mode: / searches the captured history, n/N cycle the matches.
+	if m.tabbedWindow.IsPreviewInScrollMode() {
+		switch msg.String() {
+		case "/":
+			if m.tabbedWindow.StartPreviewSearch() {
+				m.state = statePreviewSearch
+			}
+			return m, nil
+		case "n", "N":
+			if m.tabbedWindow.IsPreviewSearchActive() {
+				if msg.String() == "n" {
+					m.tabbedWindow.NextPreviewMatch()
+				} else {
+					m.tabbedWindow.PrevPreviewMatch()
+				}
+				return m, nil
+			}
+		}
+	}
+
 	// Forward key events to the viewport when in document or scroll mode.
 	// This enables viewport native keys like PgUp/PgDn and arrow keys.
 	if m.tabbedWindow.IsDocumentMode() || m.tabbedWindow.IsPreviewInScrollMode() {
@@ -1481,10 +2019,15 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 	}
 
 	// Delete key: dismiss a finished (non-running) instance from the list.
+	// Exited instances are aborted outright, cleaning up their worktree.
 	if msg.Code == tea.KeyDelete || msg.Code == tea.KeyBackspace {
 		selected := m.nav.GetSelectedInstance()
-		if selected != nil && (selected.Exited || (selected.Status != session.Running && selected.Status != session.Loading)) {
+		if selected != nil && selected.Exited {
+			return m.fastAbortExited(selected)
+		}
+		if selected != nil && selected.Status != session.Running && selected.Status != session.Loading {
 			title := selected.Title
+			m.undo.push(undoEntry{inst: selected})
 			m.nav.Remove()
 			m.removeFromAllInstances(title)
 			_ = m.saveAllInstances()
@@ -1494,7 +2037,21 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 		return m, nil
 	}
 
-	name, ok := keys.GlobalKeyStringsMap[msg.String()]
+	// While the "PR created" toast is up, o opens the PR instead of the
+	// selected item.
+	if msg.String() == "o" && m.lastPRURL != "" && m.toastManager.IsShowing(m.lastPRToastID) {
+		return m, m.openLastPR()
+	}
+
+	// Number keys retry that failed task of the selected plan's current wave;
+	// otherwise they keep their normal binding.
+	if len(msg.Text) == 1 && msg.Text[0] >= '1' && msg.Text[0] <= '9' {
+		if mod, cmd, handled := m.retrySelectedPlanWaveTask(int(msg.Text[0] - '0')); handled {
+			return mod, cmd
+		}
+	}
+
+	name, ok := keys.Lookup(msg.String(), m.vimMode())
 	if !ok {
 		return m, nil
 	}
@@ -1503,14 +2060,15 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 	case keys.KeyHelp:
 		return m.openKeybindBrowser()
 	case keys.KeyPrompt:
-		if m.tmuxSessionCount >= GlobalInstanceLimit {
-			return m, m.handleError(
-				fmt.Errorf("you can't create more than %d instances (%d tmux sessions active)", GlobalInstanceLimit, m.tmuxSessionCount))
+		if err := m.checkInstanceLimit(1); err != nil {
+			return m, m.handleError(err)
 		}
 		instance, err := session.NewInstance(session.InstanceOptions{
-			Title:   "",
-			Path:    m.activeRepoPath,
-			Program: m.programForAgent(""),
+			Title:          "",
+			Path:           m.activeRepoPath,
+			Program:        m.programForAgent(""),
+			RecordSessions: m.recordSessions(),
+			Container:      m.containerConfig(),
 		})
 		if err != nil {
 			return m, m.handleError(err)
@@ -1525,15 +2083,16 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 
 		return m, nil
 	case keys.KeyNewSkipPermissions:
-		if m.tmuxSessionCount >= GlobalInstanceLimit {
-			return m, m.handleError(
-				fmt.Errorf("you can't create more than %d instances (%d tmux sessions active)", GlobalInstanceLimit, m.tmuxSessionCount))
+		if err := m.checkInstanceLimit(1); err != nil {
+			return m, m.handleError(err)
 		}
 		instance, err := session.NewInstance(session.InstanceOptions{
 			Title:           "",
 			Path:            m.activeRepoPath,
 			Program:         m.programForAgent(""),
 			SkipPermissions: true,
+			RecordSessions:  m.recordSessions(),
+			Container:       m.containerConfig(),
 		})
 		if err != nil {
 			return m, m.handleError(err)
@@ -1570,6 +2129,12 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 			m.overlays.Show(tio)
 			return m, nil
 		}
+		if m.focusSlot == slotNav && m.nav.GetSelectedID() == ui.SidebarImportGitHub {
+			return m.openGitHubSearch()
+		}
+		if m.focusSlot == slotNav && m.nav.GetSelectedID() == ui.SidebarImportJira {
+			return m.openJiraSearch()
+		}
 		if m.focusSlot == slotNav && m.nav.ToggleSelectedExpand() {
 			return m, nil
 		}
@@ -1634,6 +2199,10 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 		if selected == nil {
 			return m, nil
 		}
+		// Nothing is running in an exited instance, so there is nothing to confirm.
+		if selected.Exited {
+			return m.fastAbortExited(selected)
+		}
 
 		// Pre-kill checks run async; model mutations happen in Update via killInstanceMsg.
 		title := selected.Title
@@ -1678,15 +2247,65 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 		// Show confirmation modal
 		message := fmt.Sprintf("[!] push changes from session '%s'?", selected.Title)
 		return m, m.confirmAction(message, pushAction)
+	case keys.KeyCopyPlan:
+		return m, m.copySelectedPlan()
+	case keys.KeyCopyBranch:
+		return m, m.copySelectedBranch()
+	case keys.KeyBroadcastPrompt:
+		return m.openBroadcastPrompt()
+	case keys.KeyGlobalSearch:
+		return m.openGlobalSearch()
+	case keys.KeyEditPlan:
+		return m.editSelectedPlan()
+	case keys.KeyQuitAndKill:
+		return m.handleQuitAndKill()
+	case keys.KeyUndo:
+		return m.undoLastRemoval()
+	case keys.KeyToggleFollow:
+		if m.tabbedWindow.ToggleFollow() {
+			m.toastManager.Info("following new output")
+		} else {
+			m.toastManager.Info("stopped following output")
+		}
+		return m, m.toastTickCmd()
+	case keys.KeyOverview:
+		return m.openOverview()
+	case keys.KeyPauseAll:
+		return m.confirmPauseAll(false)
+	case keys.KeyResumeAll:
+		return m.confirmPauseAll(true)
+	case keys.KeySettings:
+		return m.openSettings()
+	case keys.KeyToggleGrouping:
+		return m, m.toggleNavGrouping()
+	case keys.KeyToggleCollapseAll:
+		m.nav.SetAllExpanded(!m.nav.AnyExpanded())
+		return m, m.instanceChanged()
+	case keys.KeyNextNotification:
+		if !m.nav.CycleNextNotified() {
+			return m, nil
+		}
+		return m, m.instanceChanged()
+	case keys.KeyMoveUp, keys.KeyMoveDown:
+		delta := 1
+		if name == keys.KeyMoveUp {
+			delta = -1
+		}
+		if m.focusSlot != slotNav || !m.nav.MoveSelectedInstance(delta) {
+			return m, nil
+		}
+		if err := m.saveAllInstances(); err != nil {
+			return m, m.handleError(err)
+		}
+		return m, nil
+	case keys.KeyExportDiff:
+		return m, m.exportSelectedDiff()
 	case keys.KeyCreatePR:
 		selected := m.nav.GetSelectedInstance()
 		if selected == nil {
 			return m, nil
 		}
-		m.state = statePRTitle
-		tio := overlay.NewTextInputOverlay("pr title", selected.Title)
-		tio.SetSize(60, 3)
-		m.overlays.Show(tio)
+		m.openPRTitleOverlay(selected.Title)
 		return m, nil
 	case keys.KeyCheckout:
 		selected := m.nav.GetSelectedInstance()
@@ -1710,6 +2329,7 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 		if err := selected.Resume(); err != nil {
 			return m, m.handleError(err)
 		}
+		m.offerRequeuePrompt(selected)
 		return m, tea.RequestWindowSize
 	case keys.KeyEnter:
 		// Sidebar always has focus: handle plan/instance interactions first.
@@ -1720,6 +2340,12 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 			m.overlays.Show(tio)
 			return m, nil
 		}
+		if m.nav.GetSelectedID() == ui.SidebarImportGitHub {
+			return m.openGitHubSearch()
+		}
+		if m.nav.GetSelectedID() == ui.SidebarImportJira {
+			return m.openJiraSearch()
+		}
 		// Plan header or plan file: open plan context menu
 		if m.nav.IsSelectedPlanHeader() {
 			return m.openTaskContextMenu()
@@ -1727,44 +2353,16 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 		if planFile := m.nav.GetSelectedPlanFile(); planFile != "" {
 			return m.openTaskContextMenu()
 		}
-		if m.nav.NumInstances() == 0 {
-			return m, nil
+		if m.nav.GetSelectedTopic() != "" {
+			return m.openTopicContextMenu()
 		}
-		selected := m.nav.GetSelectedInstance()
-		if selected == nil || !selected.Started() || selected.Paused() {
+		// Vim mode reserves entering an agent for an explicit `i`.
+		if m.vimMode() {
 			return m, nil
 		}
-		if !selected.TmuxAlive() {
-			m.toastManager.Error(fmt.Sprintf("session for '%s' is not running", selected.Title))
-			return m, m.toastTickCmd()
-		}
-		if config.NormalizeExecutionMode(string(selected.ExecutionMode)) == config.ExecutionModeHeadless {
-			m.toastManager.Info(fmt.Sprintf("%s is running in headless mode; attach is disabled", selected.Title))
-			return m, nil
-		}
-		// Queue the selected instance, then show the attach help overlay.
-		// Actual attach (via tea.Exec) happens in handleHelpState once the user
-		// dismisses the help screen — this keeps bubbletea's event loop free.
-		m.pendingAttachInstance = selected
-		m.showHelpScreen(helpTypeInstanceAttach{}, nil)
-		// If the overlay was skipped (already seen), showHelpScreen returns without
-		// setting m.state = stateHelp. In that case consume pendingAttachInstance
-		// immediately so the attach is not silently abandoned.
-		if m.state != stateHelp && m.pendingAttachInstance != nil {
-			pending := m.pendingAttachInstance
-			m.pendingAttachInstance = nil
-			if config.NormalizeExecutionMode(string(pending.ExecutionMode)) == config.ExecutionModeHeadless {
-				m.toastManager.Info(fmt.Sprintf("%s is running in headless mode; attach is disabled", pending.Title))
-				return m, nil
-			}
-			return m, tea.Exec(tmux.NewAttachExecCommand(pending), func(err error) tea.Msg {
-				if err != nil {
-					return err
-				}
-				return instanceChangedMsg{}
-			})
-		}
-		return m, nil
+		return m.attachSelectedInstance(false)
+	case keys.KeyAttachReadonly:
+		return m.attachSelectedInstance(true)
 	case keys.KeyFocusList:
 		// t key always jumps directly to the instance list — no-op when list is hidden.
 		if m.nav.TotalInstances() > 0 {
@@ -1817,6 +2415,12 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 			m.overlays.Show(tio)
 			return m, nil
 		}
+		if m.nav.GetSelectedID() == ui.SidebarImportGitHub {
+			return m.openGitHubSearch()
+		}
+		if m.nav.GetSelectedID() == ui.SidebarImportJira {
+			return m.openJiraSearch()
+		}
 		// Right on an instance: open the instance context menu (same as space).
 		if m.nav.GetSelectedInstance() != nil {
 			return m.openContextMenu()
@@ -1833,12 +2437,11 @@ func (m *home) handleKeyPress(msg tea.KeyPressMsg) (mod tea.Model, cmd tea.Cmd)
 		m.overlays.Show(tio)
 		return m, nil
 	case keys.KeySpawnAgent:
-		if m.tmuxSessionCount >= GlobalInstanceLimit {
-			return m, m.handleError(
-				fmt.Errorf("you can't create more than %d instances (%d tmux sessions active)", GlobalInstanceLimit, m.tmuxSessionCount))
+		if err := m.checkInstanceLimit(1); err != nil {
+			return m, m.handleError(err)
 		}
 		m.state = stateSpawnAgent
-		m.overlays.Show(overlay.NewSpawnFormOverlay("spawn agent", 60))
+		m.overlays.Show(overlay.NewSpawnFormOverlay("spawn agent", 60, m.appConfig.CustomRoles()...))
 		return m, nil
 	case keys.KeyTmuxBrowser:
 		return m, m.discoverTmuxSessions()
@@ -1940,6 +2543,15 @@ func kittyCSIu(code rune, mod tea.KeyMod) []byte {
 }
 
 func (m *home) handleError(err error) tea.Cmd {
+	if errors.Is(err, taskstore.ErrVersionConflict) {
+		// Another client wrote the plan first: pick up its state instead of
+		// reporting a failure, so the user can retry on fresh data.
+		log.WarningLog.Printf("%v", err)
+		m.toastManager.Info("plan changed remotely, reloading")
+		m.loadTaskState()
+		m.updateSidebarTasks()
+		return m.toastTickCmd()
+	}
 	log.ErrorLog.Printf("%v", err)
 	m.toastManager.Error(err.Error())
 	m.audit(auditlog.EventError, err.Error(), auditlog.WithLevel("error"))
@@ -1972,8 +2584,9 @@ func (m *home) waveStandardConfirmAction(message, planFile string, entry tasksta
 
 // waveFailedConfirmAction shows a three-choice dialog for a wave that has failed tasks.
 // Keys: r=retry, n=next wave/advance, a=abort. The abort action is stored separately so the
-// stateConfirm key handler can dispatch it on 'a'.
-func (m *home) waveFailedConfirmAction(message, planFile string, entry taskstate.TaskEntry) {
+// stateConfirm key handler can dispatch it on 'a'. When timedOut is true, 't' additionally
+// retries only the tasks that hit the wave task timeout.
+func (m *home) waveFailedConfirmAction(message, planFile string, entry taskstate.TaskEntry, timedOut bool) {
 	m.pendingWaveConfirmTaskFile = planFile
 	capturedPlanFile := planFile
 	capturedEntry := entry
@@ -1994,6 +2607,12 @@ func (m *home) waveFailedConfirmAction(message, planFile string, entry taskstate
 	m.pendingWaveAbortAction = func() tea.Msg {
 		return waveAbortMsg{planFile: capturedPlanFile}
 	}
+	m.pendingWaveRetryTimedOutAction = nil
+	if timedOut {
+		m.pendingWaveRetryTimedOutAction = func() tea.Msg {
+			return waveRetryMsg{planFile: capturedPlanFile, entry: capturedEntry, timedOutOnly: true}
+		}
+	}
 }
 
 // keydownCallback clears the menu option highlighting after 500ms.
@@ -2008,3 +2627,47 @@ func (m *home) keydownCallback(name keys.KeyName) tea.Cmd {
 		return keyupMsg{}
 	}
 }
+
+// openGitHubSearch shows the issue search prompt for the GitHub importer.
+func (m *home) openGitHubSearch() (tea.Model, tea.Cmd) {
+	m.state = stateGitHubSearch
+	tio := overlay.NewTextInputOverlay("search github issues or enter #number", "")
+	tio.SetSize(50, 1)
+	m.overlays.Show(tio)
+	return m, nil
+}
+
+// selectedGitHubResult maps a picker label back to its search result.
+func (m *home) selectedGitHubResult(selected string) (github.SearchResult, bool) {
+	if selected == "" {
+		return github.SearchResult{}, false
+	}
+	for _, r := range m.githubResults {
+		if selected == githubPickerLabel(r) {
+			return r, true
+		}
+	}
+	return github.SearchResult{}, false
+}
+
+// openJiraSearch shows the search prompt for the Jira importer.
+func (m *home) openJiraSearch() (tea.Model, tea.Cmd) {
+	m.state = stateJiraSearch
+	tio := overlay.NewTextInputOverlay("search jira, enter jql or an issue key", "")
+	tio.SetSize(50, 1)
+	m.overlays.Show(tio)
+	return m, nil
+}
+
+// selectedJiraResult maps a picker label back to its search result.
+func (m *home) selectedJiraResult(selected string) (jira.SearchResult, bool) {
+	if selected == "" {
+		return jira.SearchResult{}, false
+	}
+	for _, r := range m.jiraResults {
+		if selected == jiraPickerLabel(r) {
+			return r, true
+		}
+	}
+	return jira.SearchResult{}, false
+}
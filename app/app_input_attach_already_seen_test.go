@@ -19,11 +19,25 @@ import (
 
 // mockAppState is a minimal in-test implementation of config.AppState.
 type mockAppState struct {
-	seen uint32
+	seen     uint32
+	grouping string
+	prompts  map[string][]string
 }
 
 func (s *mockAppState) GetHelpScreensSeen() uint32        { return s.seen }
 func (s *mockAppState) SetHelpScreensSeen(v uint32) error { s.seen = v; return nil }
+func (s *mockAppState) GetNavGrouping() string            { return s.grouping }
+func (s *mockAppState) SetNavGrouping(v string) error     { s.grouping = v; return nil }
+func (s *mockAppState) GetPromptHistory(repo string) []string {
+	return s.prompts[repo]
+}
+func (s *mockAppState) AddPromptHistory(repo, prompt string) error {
+	if s.prompts == nil {
+		s.prompts = make(map[string][]string)
+	}
+	s.prompts[repo] = config.PushPromptHistory(s.prompts[repo], prompt)
+	return nil
+}
 
 // noopPtyFactory satisfies tmux.PtyFactory without spawning a real PTY.
 type noopPtyFactory struct{}
@@ -99,3 +99,38 @@ func TestHandleMouseWheel_DocumentModeScrollsWithoutSelectedInstance(t *testing.
 	after := h.tabbedWindow.String()
 	assert.NotEqual(t, before, after, "mouse wheel should scroll plan document in preview tab")
 }
+
+func TestHandleKeyPress_VimNavigationOnlyInVimMode(t *testing.T) {
+	newHome := func(vim bool) *home {
+		spin := spinner.New(spinner.WithSpinner(spinner.Dot))
+		cfg := config.DefaultConfig()
+		cfg.VimMode = vim
+		h := &home{
+			ctx:          context.Background(),
+			state:        stateDefault,
+			appConfig:    cfg,
+			nav:          ui.NewNavigationPanel(&spin),
+			menu:         ui.NewMenu(),
+			tabbedWindow: ui.NewTabbedWindow(ui.NewPreviewPane(), ui.NewInfoPane()),
+			focusSlot:    slotNav,
+			keySent:      true,
+		}
+		h.nav.SetData([]ui.PlanDisplay{{Filename: "a"}, {Filename: "b"}}, nil, nil, nil, nil)
+		h.nav.SelectFirst()
+		return h
+	}
+
+	h := newHome(false)
+	first := h.nav.GetSelectedPlanFile()
+	_, _ = h.handleKeyPress(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	assert.Equal(t, first, h.nav.GetSelectedPlanFile(), "j is not bound outside vim mode")
+
+	h = newHome(true)
+	_, _ = h.handleKeyPress(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	second := h.nav.GetSelectedPlanFile()
+	assert.NotEqual(t, first, second, "j moves down in vim mode")
+	assert.NotEmpty(t, second)
+	h.keySent = true
+	_, _ = h.handleKeyPress(tea.KeyPressMsg{Code: 'k', Text: "k"})
+	assert.Equal(t, first, h.nav.GetSelectedPlanFile(), "k moves up in vim mode")
+}
@@ -466,3 +466,39 @@ func TestUpdate_PermissionPrompt_FocusesInstanceBeforeOverlay(t *testing.T) {
 	assert.Equal(t, inst2, m.nav.GetSelectedInstance(),
 		"permission overlay should auto-focus the instance that triggered it")
 }
+
+// recordingNotifier captures notifications sent through session.SendNotification.
+type recordingNotifier struct{ bodies []string }
+
+func (r *recordingNotifier) Notify(_, body string) { r.bodies = append(r.bodies, body) }
+
+// TestUpdate_PermissionPrompt_NotifiesOncePerAppearance verifies that a prompt
+// left on screen across several ticks (including after the overlay is
+// dismissed unanswered) sends a single notification, and that a new appearance
+// after the prompt clears notifies again.
+func TestUpdate_PermissionPrompt_NotifiesOncePerAppearance(t *testing.T) {
+	rec := &recordingNotifier{}
+	session.SetNotifiers(rec)
+	t.Cleanup(func() { session.SetNotifiers(session.DesktopNotifier{}) })
+
+	m := newTestHomeWithCache(t)
+	inst := &session.Instance{Title: "test-agent", Program: "opencode"}
+	inst.MarkStartedForTest()
+	m.nav.AddInstance(inst)()
+
+	pp := &session.PermissionPrompt{Pattern: "/opt/*", Description: "Access /opt"}
+	prompt := metadataResultMsg{Results: []instanceMetadata{{Title: "test-agent", PermissionPrompt: pp}}}
+
+	for i := 0; i < 3; i++ {
+		_, _ = m.Update(prompt)
+		// Dismiss the overlay without answering so the next tick re-detects it.
+		m.overlays.Dismiss()
+		m.state = stateDefault
+	}
+	require.Len(t, rec.bodies, 1)
+	assert.Equal(t, "Agent test-agent needs permission: Access /opt", rec.bodies[0])
+
+	_, _ = m.Update(metadataResultMsg{Results: []instanceMetadata{{Title: "test-agent"}}})
+	_, _ = m.Update(prompt)
+	assert.Len(t, rec.bodies, 2, "a new prompt appearance notifies again")
+}
@@ -0,0 +1,32 @@
+package app
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/kastheco/kasmos/ui/overlay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPRTitleOverlay_DraftToggle(t *testing.T) {
+	h := newTestHome()
+	h.appConfig.DefaultDraftPR = true
+	h.openPRTitleOverlay("feat: thing")
+
+	tio, ok := h.overlays.Current().(*overlay.TextInputOverlay)
+	require.True(t, ok)
+	assert.True(t, h.pendingPRDraft, "draft defaults from config")
+	assert.Equal(t, "pr title [draft]", tio.Title)
+
+	_, _ = h.handleKeyPress(tea.KeyPressMsg{Code: 'd', Mod: tea.ModCtrl})
+	assert.False(t, h.pendingPRDraft)
+	assert.Equal(t, "pr title", tio.Title)
+	assert.Contains(t, tio.View(), "feat: thing", "ctrl+d must not reach the textarea")
+}
+
+func TestPROverlayTitle(t *testing.T) {
+	assert.Equal(t, "pr title [draft]", prOverlayTitle("pr title", true))
+	assert.Equal(t, "pr title [draft]", prOverlayTitle("pr title [draft]", true))
+	assert.Equal(t, "pr title", prOverlayTitle("pr title [draft]", false))
+}
@@ -44,3 +44,52 @@ func TestWithOpenCodeModelFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestWithModelOverride(t *testing.T) {
+	tests := []struct {
+		name    string
+		program string
+		model   string
+		want    string
+	}{
+		{
+			name:    "empty wave model inherits profile program",
+			program: "opencode --agent coder --model anthropic/claude-sonnet-4-6",
+			model:   "",
+			want:    "opencode --agent coder --model anthropic/claude-sonnet-4-6",
+		},
+		{
+			name:    "wave model appended when profile has none",
+			program: "opencode --agent coder",
+			model:   "anthropic/claude-opus-4",
+			want:    "opencode --agent coder --model anthropic/claude-opus-4",
+		},
+		{
+			name:    "wave model replaces profile model flag",
+			program: "opencode --model anthropic/claude-sonnet-4-6 --agent coder",
+			model:   "claude-opus-4",
+			want:    "opencode --agent coder --model anthropic/claude-opus-4",
+		},
+		{
+			name:    "wave model replaces inline model flag",
+			program: "opencode --model=anthropic/claude-sonnet-4-6",
+			model:   "openai/gpt-5",
+			want:    "opencode --model openai/gpt-5",
+		},
+		{
+			name:    "non opencode command ignores wave model",
+			program: "claude --agent coder --model sonnet",
+			model:   "anthropic/claude-opus-4",
+			want:    "claude --agent coder --model sonnet",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := withModelOverride(tt.program, tt.model)
+			if got != tt.want {
+				t.Fatalf("withModelOverride() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
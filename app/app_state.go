@@ -20,8 +20,10 @@ import (
 	"github.com/kastheco/kasmos/config/taskstore"
 	daemonpkg "github.com/kastheco/kasmos/daemon"
 	"github.com/kastheco/kasmos/internal/clickup"
+	"github.com/kastheco/kasmos/internal/github"
 	"github.com/kastheco/kasmos/internal/initcmd/harness"
 	"github.com/kastheco/kasmos/internal/initcmd/scaffold"
+	"github.com/kastheco/kasmos/internal/jira"
 	"github.com/kastheco/kasmos/keys"
 	"github.com/kastheco/kasmos/log"
 	"github.com/kastheco/kasmos/orchestration"
@@ -116,6 +118,12 @@ func (m *home) ensureProcessor() *loop.Processor {
 		if len(m.appConfig.Hooks) > 0 {
 			hooks = taskfsm.BuildHookRegistry(toTaskFSMHooks(m.appConfig.Hooks))
 		}
+		if len(m.appConfig.ClickUpStatusMap) > 0 {
+			if hooks == nil {
+				hooks = taskfsm.NewHookRegistry()
+			}
+			hooks.Add(&clickUpStatusHook{m: m}, nil)
+		}
 	}
 	m.processor = loop.NewProcessor(loop.ProcessorConfig{
 		AutoReviewFix:      autoReviewFix,
@@ -130,6 +138,7 @@ func (m *home) ensureProcessor() *loop.Processor {
 
 func (m *home) handleReviewChangesRequested(planFile, feedback string) tea.Cmd {
 	m.pendingReviewFeedback[planFile] = feedback
+	session.SendNotification("kas", fmt.Sprintf("review requested changes: %s", taskstate.DisplayName(planFile)))
 
 	var cmds []tea.Cmd
 	truncated := feedback
@@ -151,6 +160,60 @@ func (m *home) handleReviewChangesRequested(planFile, feedback string) tea.Cmd {
 	return tea.Batch(cmds...)
 }
 
+// reviewCycleLimitReached stops the automatic review→fix loop for planFile
+// once max_review_fix_cycles is exceeded. The cap hit is audited and the user is
+// asked whether to spawn another fixer anyway; if another dialog is already
+// open the user gets an error toast instead.
+func (m *home) reviewCycleLimitReached(planFile string, cycle, limit int) tea.Cmd {
+	planName := taskstate.DisplayName(planFile)
+	m.audit(auditlog.EventReviewCycleLimit,
+		fmt.Sprintf("review-fix cycle limit reached (%d/%d)", cycle, limit),
+		auditlog.WithPlan(planFile))
+	session.SendNotification("kas", fmt.Sprintf("review-fix limit reached: %s", planName))
+
+	m.exitFocusModeForDialog()
+	if m.isUserInOverlay() {
+		m.toastManager.Error(fmt.Sprintf(
+			"review-fix loop stopped: cycle limit reached (%d/%d) for %s",
+			cycle, limit, planName))
+		return m.toastTickCmd()
+	}
+	capturedPlanFile := planFile
+	m.confirmAction(
+		fmt.Sprintf("%s — review-fix limit reached (%d/%d). spawn another fixer anyway?", planName, cycle, limit),
+		func() tea.Msg {
+			return reviewCycleOverrideMsg{planFile: capturedPlanFile}
+		},
+	)
+	return nil
+}
+
+// overrideReviewCycleLimit spawns one more fixer past the review-fix cycle limit
+// after the user confirmed it. The cycle counter still advances so the next
+// changes-requested verdict asks again.
+func (m *home) overrideReviewCycleLimit(planFile string) tea.Cmd {
+	if m.taskState == nil {
+		return nil
+	}
+	entry, ok := m.taskState.Entry(planFile)
+	if !ok {
+		return m.handleError(fmt.Errorf("task not found: %s", planFile))
+	}
+	if entry.Status == taskstate.StatusReviewing {
+		if err := m.fsm.Transition(planFile, taskfsm.ReviewChangesRequested); err != nil {
+			return m.handleError(err)
+		}
+	}
+	if err := m.taskState.IncrementReviewCycle(planFile); err != nil {
+		return m.handleError(err)
+	}
+	m.audit(auditlog.EventPlanTransition, "review-fix cycle limit overridden (manual fixer)",
+		auditlog.WithPlan(planFile))
+	m.loadTaskState()
+	m.updateSidebarTasks()
+	return m.spawnFixerWithFeedback(planFile, m.pendingReviewFeedback[planFile])
+}
+
 func assemblePRMetadata(
 	entry taskstore.TaskEntry,
 	subtasks []taskstore.SubtaskEntry,
@@ -261,19 +324,24 @@ func (m *home) createPRAfterApproval(planFile, reviewBody string) tea.Cmd {
 		title := gitpkg.BuildPRTitle(entry.Description, planName)
 		body := gitpkg.BuildPRBody(meta)
 		commitMsg := fmt.Sprintf("[kas] implementation of '%s'", planName)
-		if err := shared.CreatePR(title, body, commitMsg); err != nil {
+		url, err := shared.CreateReview(title, body, commitMsg)
+		if err != nil {
 			log.WarningLog.Printf("createPRAfterApproval: PR creation failed for %q: %v", planFile, err)
 			return nil
 		}
 
+		// The PR exists from here on, so report it even when its state
+		// cannot be read back; the URL printed at creation stands in.
 		state, err := shared.QueryPRState()
 		if err != nil {
 			log.WarningLog.Printf("createPRAfterApproval: QueryPRState failed for %q: %v", planFile, err)
-			return nil
+			return prCreatedForPlanMsg{planFile: planFile, url: url}
+		}
+		if state.URL != "" {
+			url = state.URL
 		}
-		if state.URL == "" {
+		if url == "" {
 			log.WarningLog.Printf("createPRAfterApproval: empty URL for %q after PR creation", planFile)
-			return nil
 		}
 
 		if state.Number > 0 {
@@ -283,7 +351,7 @@ func (m *home) createPRAfterApproval(planFile, reviewBody string) tea.Cmd {
 			}
 		}
 
-		return prCreatedForPlanMsg{planFile: planFile, url: state.URL}
+		return prCreatedForPlanMsg{planFile: planFile, url: url}
 	}
 }
 
@@ -340,6 +408,7 @@ func (m *home) computeStatusBarData() ui.StatusBarData {
 			data.Branch = entry.Branch
 			data.PlanName = taskstate.DisplayName(planFile)
 			data.PlanStatus = string(entry.Status)
+			data.PlanTokensUsed, data.PlanCostUSD = m.planTokenUsage(planFile)
 
 			if orch, orchOK := m.waveOrchestrators[planFile]; orchOK {
 				waveNum := orch.CurrentWaveNumber()
@@ -378,6 +447,7 @@ func (m *home) computeStatusBarData() ui.StatusBarData {
 			if ok {
 				data.PlanName = taskstate.DisplayName(selected.TaskFile)
 				data.PlanStatus = string(entry.Status)
+				data.PlanTokensUsed, data.PlanCostUSD = m.planTokenUsage(selected.TaskFile)
 
 				// Populate PR state from the task store.
 				if m.taskStore != nil {
@@ -961,6 +1031,8 @@ func (m *home) updateInfoPaneForPlanHeader() {
 			continue
 		}
 		data.PlanInstanceCount++
+		data.PlanTokensUsed += inst.TokensUsed
+		data.PlanCostUSD += inst.CostUSD
 		switch {
 		case inst.Status == session.Running || inst.Status == session.Loading:
 			data.PlanRunningCount++
@@ -976,6 +1048,9 @@ func (m *home) updateInfoPaneForPlanHeader() {
 	data.ImplementingAt = entry.ImplementingAt
 	data.ReviewingAt = entry.ReviewingAt
 	data.DoneAt = entry.DoneAt
+	for _, change := range entry.History {
+		data.PlanHistory = append(data.PlanHistory, ui.StatusChange{Status: string(change.Status), At: change.At})
+	}
 
 	// Include wave progress if an orchestrator exists for this plan.
 	var orch *orchestration.WaveOrchestrator
@@ -1031,16 +1106,26 @@ func (m *home) updateInfoPane() {
 	}
 
 	data := ui.InfoData{
-		HasInstance: true,
-		Title:       selected.Title,
-		Program:     selected.Program,
-		Branch:      selected.Branch,
-		Path:        selected.Path,
-		Status:      statusString(selected.Status),
-		AgentType:   selected.AgentType,
-		TaskNumber:  selected.TaskNumber,
-		WaveNumber:  selected.WaveNumber,
-	}
+		HasInstance:   true,
+		Title:         selected.Title,
+		Program:       selected.Program,
+		Branch:        selected.Branch,
+		Path:          selected.Path,
+		Status:        statusString(selected.Status),
+		AgentType:     selected.AgentType,
+		TaskNumber:    selected.TaskNumber,
+		WaveNumber:    selected.WaveNumber,
+		Recording:     selected.RecordingPath,
+		OutputLog:     selected.OutputLogPath,
+		HasConflicts:  selected.HasConflicts,
+		ConflictFiles: selected.ConflictFiles,
+		Detached:      selected.DetachedHead,
+		CPUPercent:    selected.CPUPercent,
+		MemMB:         selected.MemMB,
+		TokensUsed:    selected.TokensUsed,
+		CostUSD:       selected.CostUSD,
+	}
+	data.CPUOverThreshold, data.MemOverThreshold = m.overResourceThreshold(selected)
 
 	if !selected.CreatedAt.IsZero() {
 		data.Created = selected.CreatedAt.Format("2006-01-02 15:04")
@@ -1063,6 +1148,7 @@ func (m *home) updateInfoPane() {
 				if !entry.CreatedAt.IsZero() {
 					data.PlanCreated = entry.CreatedAt.Format("2006-01-02")
 				}
+				data.PlanTokensUsed, data.PlanCostUSD = m.planTokenUsage(selected.TaskFile)
 				// Enrich with goal and lifecycle timestamps.
 				data.PlanGoal = entry.Goal
 				data.PlanningAt = entry.PlanningAt
@@ -1143,8 +1229,8 @@ func (m *home) updateSidebarTasks() {
 		plans := m.taskState.TasksByTopic(t.Name)
 		planDisplays := make([]ui.PlanDisplay, 0, len(plans))
 		for _, p := range plans {
-			if p.Status == taskstate.StatusDone || p.Status == taskstate.StatusCancelled {
-				continue // finished/cancelled plans handled separately
+			if p.Status == taskstate.StatusDone || p.Status == taskstate.StatusCancelled || p.Status == taskstate.StatusArchived {
+				continue // finished/cancelled plans handled separately; archived never shown
 			}
 			planDisplays = append(planDisplays, ui.PlanDisplay{
 				Filename:    p.Filename,
@@ -1152,6 +1238,9 @@ func (m *home) updateSidebarTasks() {
 				Description: p.Description,
 				Branch:      p.Branch,
 				Topic:       p.Topic,
+				Priority:    p.Priority,
+				Blocked:     len(m.taskState.UnfinishedBlockers(p.Filename)) > 0,
+				Pinned:      p.Pinned,
 			})
 		}
 		if len(planDisplays) > 0 {
@@ -1168,6 +1257,9 @@ func (m *home) updateSidebarTasks() {
 			Status:      string(p.Status),
 			Description: p.Description,
 			Branch:      p.Branch,
+			Priority:    p.Priority,
+			Blocked:     len(m.taskState.UnfinishedBlockers(p.Filename)) > 0,
+			Pinned:      p.Pinned,
 		})
 	}
 
@@ -1290,13 +1382,15 @@ func (m *home) spawnReviewer(planFile string) tea.Cmd {
 
 	cycle, _ := m.taskState.ReviewCycle(planFile)
 	reviewerInst, err := session.NewInstance(session.InstanceOptions{
-		Title:         fmt.Sprintf("%s-review-%d", planName, cycle+1),
-		Path:          m.activeRepoPath,
-		Program:       m.programForAgent(session.AgentTypeReviewer),
-		ExecutionMode: m.executionModeForAgent(session.AgentTypeReviewer),
-		TaskFile:      planFile,
-		AgentType:     session.AgentTypeReviewer,
-		ReviewCycle:   cycle + 1,
+		Title:          fmt.Sprintf("%s-review-%d", planName, cycle+1),
+		Path:           m.activeRepoPath,
+		Program:        m.programForAgent(session.AgentTypeReviewer),
+		ExecutionMode:  m.executionModeForAgent(session.AgentTypeReviewer),
+		TaskFile:       planFile,
+		AgentType:      session.AgentTypeReviewer,
+		ReviewCycle:    cycle + 1,
+		RecordSessions: m.recordSessions(),
+		Container:      m.containerConfig(),
 	})
 	if err != nil {
 		log.WarningLog.Printf("could not create reviewer instance for %q: %v", planFile, err)
@@ -1360,6 +1454,32 @@ func withOpenCodeModelFlag(program, model string) string {
 	return program + " --model " + model
 }
 
+// withModelOverride forces program to run with model, replacing any --model
+// flag already present (e.g. from profile flags). Unlike withOpenCodeModelFlag,
+// an explicit override always wins. Empty model leaves program unchanged.
+func withModelOverride(program, model string) string {
+	if strings.TrimSpace(model) == "" {
+		return program
+	}
+	tokens := strings.Fields(program)
+	if len(tokens) == 0 || filepath.Base(tokens[0]) != "opencode" {
+		return program
+	}
+	kept := make([]string, 0, len(tokens))
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		if tok == "--model" || tok == "-m" {
+			i++ // skip the flag value too
+			continue
+		}
+		if strings.HasPrefix(tok, "--model=") {
+			continue
+		}
+		kept = append(kept, tok)
+	}
+	return withOpenCodeModelFlag(strings.Join(kept, " "), model)
+}
+
 func (m *home) profileForAgent(agentType string) config.AgentProfile {
 	if m.appConfig == nil {
 		return config.AgentProfile{Program: m.program, ExecutionMode: config.ExecutionModeTmux}
@@ -1390,13 +1510,17 @@ func (m *home) profileForAgent(agentType string) config.AgentProfile {
 
 // programForAgent resolves the program command for a given agent type
 // (e.g. "coder", "planner") using the kasmos config profile. Falls back to
-// m.program if no profile is configured.
+// m.program if no profile is configured. Reviewers use ReviewerProgram
+// verbatim when it is set.
 //
 // For typed agents (coder/planner/reviewer), opencode's own --agent flag
 // handles model selection via its agent config, so we do NOT append --model.
 // For ad-hoc instances (no agent type), we append --model since there is no
 // --agent flag to drive model selection.
 func (m *home) programForAgent(agentType string) string {
+	if agentType == session.AgentTypeReviewer && m.appConfig != nil && m.appConfig.ReviewerProgram != "" {
+		return m.appConfig.ReviewerProgram
+	}
 	profile := m.profileForAgent(agentType)
 	if agentType == "" {
 		return withOpenCodeModelFlag(profile.BuildCommand(), profile.Model)
@@ -1404,6 +1528,17 @@ func (m *home) programForAgent(agentType string) string {
 	return profile.BuildCommand()
 }
 
+// programForRole resolves the command for an explicitly chosen agent role
+// (an [agents.<role>] profile) via ResolveProfile, passing the profile's model
+// to opencode. Falls back to m.program when the role is missing or disabled.
+func (m *home) programForRole(role string) string {
+	if m.appConfig == nil {
+		return m.program
+	}
+	profile := m.appConfig.ResolveProfile(role, m.program)
+	return withOpenCodeModelFlag(profile.BuildCommand(), profile.Model)
+}
+
 func (m *home) executionModeForAgent(agentType string) session.ExecutionMode {
 	mode := session.ExecutionMode(config.NormalizeExecutionMode(m.profileForAgent(agentType).ExecutionMode))
 	// Headless execution is only wired for coder sessions right now.
@@ -1415,6 +1550,34 @@ func (m *home) executionModeForAgent(agentType string) session.ExecutionMode {
 	return mode
 }
 
+// planTokenUsage sums the token counts and costs reported by the plan's
+// instances.
+func (m *home) planTokenUsage(planFile string) (tokens int, costUSD float64) {
+	if m.nav == nil {
+		return 0, 0
+	}
+	for _, inst := range m.nav.GetInstances() {
+		if inst.TaskFile == planFile {
+			tokens += inst.TokensUsed
+			costUSD += inst.CostUSD
+		}
+	}
+	return tokens, costUSD
+}
+
+// recordSessions reports whether newly spawned agent sessions are recorded.
+func (m *home) recordSessions() bool {
+	return m.appConfig != nil && m.appConfig.RecordSessions
+}
+
+// containerConfig returns the container newly spawned agents run in.
+func (m *home) containerConfig() config.ContainerConfig {
+	if m.appConfig == nil {
+		return config.ContainerConfig{}
+	}
+	return m.appConfig.Container
+}
+
 func normalizeOpenCodeModelID(model string) string {
 	model = strings.TrimSpace(model)
 	if model == "" || strings.Contains(model, "/") {
@@ -1598,13 +1761,15 @@ func (m *home) spawnFixerWithFeedback(planFile, feedback string) tea.Cmd {
 
 	cycle, _ := m.taskState.ReviewCycle(planFile)
 	fixerInst, err := session.NewInstance(session.InstanceOptions{
-		Title:         fmt.Sprintf("%s-fix-%d", planName, cycle),
-		Path:          m.activeRepoPath,
-		Program:       m.programForAgent(session.AgentTypeFixer),
-		ExecutionMode: m.executionModeForAgent(session.AgentTypeFixer),
-		TaskFile:      planFile,
-		AgentType:     session.AgentTypeFixer,
-		ReviewCycle:   cycle,
+		Title:          fmt.Sprintf("%s-fix-%d", planName, cycle),
+		Path:           m.activeRepoPath,
+		Program:        m.programForAgent(session.AgentTypeFixer),
+		ExecutionMode:  m.executionModeForAgent(session.AgentTypeFixer),
+		TaskFile:       planFile,
+		AgentType:      session.AgentTypeFixer,
+		ReviewCycle:    cycle,
+		RecordSessions: m.recordSessions(),
+		Container:      m.containerConfig(),
 	})
 	if err != nil {
 		log.WarningLog.Printf("could not create fixer instance for %q: %v", planFile, err)
@@ -1666,11 +1831,13 @@ func (m *home) spawnElaborator(planFile string) (tea.Model, tea.Cmd) {
 	taskfsm.ClearElaborationSignal(m.signalsDir, planFile)
 
 	inst, err := session.NewInstance(session.InstanceOptions{
-		Title:         fmt.Sprintf("%s-elaborator", planName),
-		Path:          m.activeRepoPath,
-		Program:       m.programForAgent(session.AgentTypeElaborator),
-		ExecutionMode: m.executionModeForAgent(session.AgentTypeElaborator),
-		TaskFile:      planFile,
+		Title:          fmt.Sprintf("%s-elaborator", planName),
+		Path:           m.activeRepoPath,
+		Program:        m.programForAgent(session.AgentTypeElaborator),
+		ExecutionMode:  m.executionModeForAgent(session.AgentTypeElaborator),
+		TaskFile:       planFile,
+		RecordSessions: m.recordSessions(),
+		Container:      m.containerConfig(),
 	})
 	if err != nil {
 		return m, m.handleError(err)
@@ -1710,12 +1877,166 @@ func (m *home) blueprintSkipThreshold() int {
 	return m.appConfig.BlueprintSkipThreshold()
 }
 
+// checkInstanceLimit returns an error when starting n more sessions would take
+// the tmux session count past the configured instance limit.
+func (m *home) checkInstanceLimit(n int) error {
+	limit := m.appConfig.InstanceLimit()
+	if m.tmuxSessionCount+n > limit {
+		return fmt.Errorf("you can't create more than %d instances (%d tmux sessions active)", limit, m.tmuxSessionCount)
+	}
+	return nil
+}
+
+// maxWaveConcurrency returns the configured cap on simultaneously running wave
+// task instances, defaulting to 4 when no config is loaded.
+func (m *home) maxWaveConcurrency() int {
+	if m.appConfig == nil {
+		return 4
+	}
+	return m.appConfig.MaxWaveConcurrency()
+}
+
+// waveTaskTimeout returns how long a wave task may run before it is failed as
+// hung, defaulting to 30 minutes when no config is loaded.
+func (m *home) waveTaskTimeout() time.Duration {
+	if m.appConfig == nil {
+		return 30 * time.Minute
+	}
+	return time.Duration(m.appConfig.WaveTaskTimeoutMinutes()) * time.Minute
+}
+
+// applyWaveLimits copies the configured concurrency cap and task timeout onto
+// orch so config changes take effect on the next spawn.
+func (m *home) applyWaveLimits(orch *orchestration.WaveOrchestrator) {
+	orch.MaxConcurrentTasks = m.maxWaveConcurrency()
+	orch.TaskTimeout = m.waveTaskTimeout()
+}
+
+// capWaveToInstanceLimit lowers orch.MaxConcurrentTasks so the next dequeue
+// starts no more tasks than the instance limit has room for; the rest stay
+// queued. It must run before the orchestrator marks tasks running, and
+// returns an error, leaving orch untouched, when there is no room at all.
+func (m *home) capWaveToInstanceLimit(orch *orchestration.WaveOrchestrator) error {
+	if err := m.checkInstanceLimit(1); err != nil {
+		return err
+	}
+	running := 0
+	for _, t := range orch.CurrentWaveTasks() {
+		if orch.IsTaskRunning(t.Number) {
+			running++
+		}
+	}
+	limit := running + m.appConfig.InstanceLimit() - m.tmuxSessionCount
+	if orch.MaxConcurrentTasks <= 0 || orch.MaxConcurrentTasks > limit {
+		orch.MaxConcurrentTasks = limit
+	}
+	return nil
+}
+
+// newWaveOrchestrator creates an orchestrator for plan with subtask status
+// persistence and wave-state snapshots enabled.
+func (m *home) newWaveOrchestrator(planFile string, plan *taskparser.Plan) *orchestration.WaveOrchestrator {
+	orch := orchestration.NewWaveOrchestrator(planFile, plan)
+	orch.SetStore(m.taskStore, m.taskStoreProject)
+	orch.SetStateDir(m.waveStateDir())
+	return orch
+}
+
+// waveStateDir returns the directory holding wave-state snapshots
+// (<plans_dir>/.waves). Returns "" when no plans directory is configured,
+// which disables snapshotting.
+func (m *home) waveStateDir() string {
+	if m.taskStateDir == "" {
+		return ""
+	}
+	return filepath.Join(m.taskStateDir, ".waves")
+}
+
+// loadWaveState restores the orchestrator for planFile from its wave-state
+// snapshot. Returns nil when there is no usable snapshot.
+func (m *home) loadWaveState(planFile string, plan *taskparser.Plan) *orchestration.WaveOrchestrator {
+	dir := m.waveStateDir()
+	if dir == "" {
+		return nil
+	}
+	orch, err := orchestration.LoadWaveState(dir, planFile, plan)
+	if err != nil {
+		log.WarningLog.Printf("could not load wave state for %s: %v", planFile, err)
+		return nil
+	}
+	if orch == nil {
+		return nil
+	}
+	orch.SetStore(m.taskStore, m.taskStoreProject)
+	return orch
+}
+
+// removeWaveState deletes the wave-state snapshot for planFile, if any.
+func (m *home) removeWaveState(planFile string) {
+	dir := m.waveStateDir()
+	if dir == "" {
+		return
+	}
+	if err := orchestration.RemoveWaveState(dir, planFile); err != nil {
+		log.WarningLog.Printf("could not remove wave state for %s: %v", planFile, err)
+	}
+}
+
+// planWorktreeDirty reports whether the worktree planFile's wave task
+// instances share holds uncommitted changes, so the failed-wave dialog can say
+// where that work is left after an abort.
+func (m *home) planWorktreeDirty(planFile string) bool {
+	seen := make(map[string]bool)
+	for _, inst := range m.allInstances {
+		if inst.TaskFile != planFile || inst.TaskNumber == 0 {
+			continue
+		}
+		wt, err := inst.GetGitWorktree()
+		if err != nil || seen[wt.GetWorktreePath()] {
+			continue
+		}
+		seen[wt.GetWorktreePath()] = true
+		isDirty, err := wt.IsDirty()
+		if err != nil {
+			log.WarningLog.Printf("could not check %s for uncommitted changes: %v", inst.Title, err)
+			continue
+		}
+		if isDirty {
+			return true
+		}
+	}
+	return false
+}
+
+// waveFailedMessage builds the body of the failed-wave decision dialog. When
+// dirty is set it notes that an abort leaves the plan worktree's uncommitted
+// changes in place: the tasks share it, and killing them does not remove it.
+func waveFailedMessage(planName string, waveNum, completed, total, failed, timedOut int, dirty bool) string {
+	var msg string
+	if timedOut > 0 {
+		msg = fmt.Sprintf(
+			"%s — wave %d: %d/%d tasks complete, %d failed (%d timed out).\n\n"+
+				"[r] retry failed   [t] retry timed out   [n] next wave   [a] abort",
+			planName, waveNum, completed, total, failed, timedOut)
+	} else {
+		msg = fmt.Sprintf(
+			"%s — wave %d: %d/%d tasks complete, %d failed.\n\n"+
+				"[r] retry failed   [n] next wave   [a] abort",
+			planName, waveNum, completed, total, failed)
+	}
+	if dirty {
+		msg += "\n\n⚠ the plan worktree has uncommitted changes; abort stops the tasks and leaves them there."
+	}
+	return msg
+}
+
 // clearWaveOrchestratorState removes any wave-orchestrator bookkeeping for the
 // given plan from both the home model and the processor-backed signal gate.
 // This is required before switching an implementing plan onto the single-agent
 // blueprint-skip path so later implement_finished signals are not suppressed.
 func (m *home) clearWaveOrchestratorState(planFile string) {
 	delete(m.waveOrchestrators, planFile)
+	m.removeWaveState(planFile)
 	if proc := m.ensureProcessor(); proc != nil {
 		proc.SetWaveOrchestratorActive(planFile, false)
 	}
@@ -1810,6 +2131,12 @@ func (m *home) viewSelectedPlan() (tea.Model, tea.Cmd) {
 
 // createTaskEntry creates a new plan entry in the store.
 func (m *home) createTaskEntry(name, description, topic string) error {
+	return m.createTaskEntryFromTemplate(name, description, topic, "")
+}
+
+// createTaskEntryFromTemplate creates a new plan entry whose content is the
+// rendered template, or the default stub when template is empty.
+func (m *home) createTaskEntryFromTemplate(name, description, topic, template string) error {
 	if m.taskState == nil {
 		if m.taskStore == nil {
 			return fmt.Errorf("task store not configured")
@@ -1823,14 +2150,20 @@ func (m *home) createTaskEntry(name, description, topic string) error {
 
 	slug := slugifyPlanName(name)
 	filename := slug
-	branch := "plan/" + slug
+	branch, err := m.newTaskBranch(filename)
+	if err != nil {
+		if m.toastManager != nil {
+			m.toastManager.Error(err.Error())
+		}
+		return err
+	}
 	if err := m.taskState.Create(filename, description, branch, topic, time.Now().UTC()); err != nil {
 		if m.toastManager != nil {
 			m.toastManager.Error("task store error: " + err.Error())
 		}
 		return err
 	}
-	if err := m.taskState.SetContent(filename, renderPlanStub(name, description, filename)); err != nil {
+	if err := m.taskState.SetContent(filename, renderTemplate(template, name, description, filename, time.Now())); err != nil {
 		if m.toastManager != nil {
 			m.toastManager.Error("task store error: " + err.Error())
 		}
@@ -1863,6 +2196,19 @@ func renderPlanStub(name, description, filename string) string {
 	return fmt.Sprintf("# %s\n\n## Context\n\n%s\n\n## Notes\n\n- Created by kas lifecycle flow\n- Plan file: %s\n", name, description, filename)
 }
 
+// renderTemplate fills the {{name}}, {{description}} and {{date}} placeholders
+// of a plan template. An empty template falls back to renderPlanStub.
+func renderTemplate(template, name, description, filename string, now time.Time) string {
+	if strings.TrimSpace(template) == "" {
+		return renderPlanStub(name, description, filename)
+	}
+	return strings.NewReplacer(
+		"{{name}}", name,
+		"{{description}}", description,
+		"{{date}}", now.Format("2006-01-02"),
+	).Replace(template)
+}
+
 // createPlanRecord registers the plan in the store.
 func (m *home) createPlanRecord(planFile, description, branch string, now time.Time) error {
 	if m.taskState == nil {
@@ -1884,13 +2230,17 @@ func (m *home) createPlanRecord(planFile, description, branch string, now time.T
 	return nil
 }
 
-// finalizePlanCreation writes the plan stub content to the store, registers it,
-// and creates the feature branch. Called at the end of the plan creation wizard.
-func (m *home) finalizePlanCreation(name, description string) error {
+// finalizePlanCreation writes the plan content (the rendered template, or the
+// default stub when template is empty) to the store, registers it, and creates
+// the feature branch. Called at the end of the plan creation wizard.
+func (m *home) finalizePlanCreation(name, description, template string) error {
 	now := time.Now().UTC()
 	planFile := buildPlanFilename(name, now)
-	branch := gitpkg.TaskBranchFromFile(planFile)
-	content := renderPlanStub(name, description, planFile)
+	branch, err := m.newTaskBranch(planFile)
+	if err != nil {
+		return err
+	}
+	content := renderTemplate(template, name, description, planFile, now)
 	if err := m.createPlanRecord(planFile, description, branch, now); err != nil {
 		return err
 	}
@@ -1912,8 +2262,6 @@ func (m *home) importClickUpTask(task *clickup.Task) (tea.Model, tea.Cmd) {
 		return m, m.toastTickCmd()
 	}
 
-	filename := clickup.ScaffoldFilename(task.Name)
-
 	if m.taskState == nil {
 		m.loadTaskState()
 	}
@@ -1922,12 +2270,78 @@ func (m *home) importClickUpTask(task *clickup.Task) (tea.Model, tea.Cmd) {
 		return m, m.toastTickCmd()
 	}
 
-	filename = dedupePlanFilenameInState(m.taskState, filename)
+	filename, err := m.registerClickUpTask(task)
+	if err != nil {
+		m.toastManager.Error(err.Error())
+		return m, m.toastTickCmd()
+	}
+
+	if err := m.fsm.Transition(filename, taskfsm.PlanStart); err != nil {
+		log.WarningLog.Printf("clickup import transition failed for %q: %v", filename, err)
+	}
 
-	scaffold := clickup.ScaffoldPlan(*task)
+	m.loadTaskState()
+	m.updateSidebarTasks()
+
+	prompt := fmt.Sprintf(`Analyze this imported ClickUp task. The task details and subtasks are included as reference in the plan.
 
-	branch := gitpkg.TaskBranchFromFile(filename)
+Determine if the task is well-specified enough for implementation or needs further analysis. Write a proper implementation plan with ## Wave sections, task breakdowns, architecture notes, and tech stack. Use the ClickUp subtasks as a starting point but reorganize into waves based on dependencies.
+
+Retrieve the current plan content with: kas task show %s`, filename)
+
+	m.toastManager.Success("imported! spawning planner...")
+	model, cmd := m.spawnTaskAgent(filename, "plan", prompt)
+	if cmd == nil {
+		return model, m.toastTickCmd()
+	}
+	return model, tea.Batch(cmd, m.toastTickCmd())
+}
+
+// registerClickUpTask registers task as a ready plan with its scaffolded
+// content and links the ClickUp task ID. Returns the new plan filename.
+func (m *home) registerClickUpTask(task *clickup.Task) (string, error) {
+	filename := dedupePlanFilenameInState(m.taskState, clickup.ScaffoldFilename(task.Name))
+	branch, err := m.newTaskBranch(filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to register imported plan: %w", err)
+	}
 	if err := m.taskState.Register(filename, task.Name, branch, time.Now()); err != nil {
+		return "", fmt.Errorf("failed to register imported plan: %w", err)
+	}
+	if err := m.taskState.SetContent(filename, clickup.ScaffoldPlan(*task)); err != nil {
+		return "", fmt.Errorf("failed to save imported plan content: %w", err)
+	}
+	if task.ID != "" {
+		if err := m.taskState.SetClickUpTaskID(filename, task.ID); err != nil {
+			log.WarningLog.Printf("registerClickUpTask: failed to set clickup task id for %q: %v", filename, err)
+		}
+	}
+	return filename, nil
+}
+
+func (m *home) importGitHubTask(task *github.Task) (tea.Model, tea.Cmd) {
+	if task == nil {
+		m.toastManager.Error("github fetch failed: empty issue payload")
+		return m, m.toastTickCmd()
+	}
+
+	if m.taskState == nil {
+		m.loadTaskState()
+	}
+	if m.taskState == nil {
+		m.toastManager.Error("failed to register imported plan: plan state unavailable")
+		return m, m.toastTickCmd()
+	}
+
+	filename := dedupePlanFilenameInState(m.taskState, github.ScaffoldFilename(task.Title))
+	scaffold := github.ScaffoldPlan(*task)
+
+	branch, err := m.newTaskBranch(filename)
+	if err != nil {
+		m.toastManager.Error("failed to register imported plan: " + err.Error())
+		return m, m.toastTickCmd()
+	}
+	if err := m.taskState.Register(filename, task.Title, branch, time.Now()); err != nil {
 		m.toastManager.Error("failed to register imported plan: " + err.Error())
 		return m, m.toastTickCmd()
 	}
@@ -1935,22 +2349,69 @@ func (m *home) importClickUpTask(task *clickup.Task) (tea.Model, tea.Cmd) {
 		m.toastManager.Error("failed to save imported plan content: " + err.Error())
 		return m, m.toastTickCmd()
 	}
-	if task.ID != "" {
-		if err := m.taskState.SetClickUpTaskID(filename, task.ID); err != nil {
-			log.WarningLog.Printf("importClickUpTask: failed to set clickup task id for %q: %v", filename, err)
-		}
+
+	if err := m.fsm.Transition(filename, taskfsm.PlanStart); err != nil {
+		log.WarningLog.Printf("github import transition failed for %q: %v", filename, err)
+	}
+
+	m.loadTaskState()
+	m.updateSidebarTasks()
+
+	prompt := fmt.Sprintf(`Analyze this imported GitHub issue. The issue body and its checklist items are included as reference in the plan.
+
+Determine if the issue is well-specified enough for implementation or needs further analysis. Write a proper implementation plan with ## Wave sections, task breakdowns, architecture notes, and tech stack. Use the checklist items under "Task Candidates" as a starting point for tasks but reorganize them into waves based on dependencies.
+
+Retrieve the current plan content with: kas task show %s`, filename)
+
+	m.toastManager.Success("imported! spawning planner...")
+	model, cmd := m.spawnTaskAgent(filename, "plan", prompt)
+	if cmd == nil {
+		return model, m.toastTickCmd()
+	}
+	return model, tea.Batch(cmd, m.toastTickCmd())
+}
+
+func (m *home) importJiraTask(task *jira.Task) (tea.Model, tea.Cmd) {
+	if task == nil {
+		m.toastManager.Error("jira fetch failed: empty issue payload")
+		return m, m.toastTickCmd()
+	}
+
+	if m.taskState == nil {
+		m.loadTaskState()
+	}
+	if m.taskState == nil {
+		m.toastManager.Error("failed to register imported plan: plan state unavailable")
+		return m, m.toastTickCmd()
+	}
+
+	filename := dedupePlanFilenameInState(m.taskState, jira.ScaffoldFilename(task.Summary))
+	scaffold := jira.ScaffoldPlan(*task)
+
+	branch, err := m.newTaskBranch(filename)
+	if err != nil {
+		m.toastManager.Error("failed to register imported plan: " + err.Error())
+		return m, m.toastTickCmd()
+	}
+	if err := m.taskState.Register(filename, task.Summary, branch, time.Now()); err != nil {
+		m.toastManager.Error("failed to register imported plan: " + err.Error())
+		return m, m.toastTickCmd()
+	}
+	if err := m.taskState.SetContent(filename, scaffold); err != nil {
+		m.toastManager.Error("failed to save imported plan content: " + err.Error())
+		return m, m.toastTickCmd()
 	}
 
 	if err := m.fsm.Transition(filename, taskfsm.PlanStart); err != nil {
-		log.WarningLog.Printf("clickup import transition failed for %q: %v", filename, err)
+		log.WarningLog.Printf("jira import transition failed for %q: %v", filename, err)
 	}
 
 	m.loadTaskState()
 	m.updateSidebarTasks()
 
-	prompt := fmt.Sprintf(`Analyze this imported ClickUp task. The task details and subtasks are included as reference in the plan.
+	prompt := fmt.Sprintf(`Analyze this imported Jira issue. The issue description and its sub-tasks are included as reference in the plan.
 
-Determine if the task is well-specified enough for implementation or needs further analysis. Write a proper implementation plan with ## Wave sections, task breakdowns, architecture notes, and tech stack. Use the ClickUp subtasks as a starting point but reorganize into waves based on dependencies.
+Determine if the issue is well-specified enough for implementation or needs further analysis. Write a proper implementation plan with ## Wave sections, task breakdowns, architecture notes, and tech stack. Use the sub-tasks under "Task Candidates" as a starting point for tasks but reorganize them into waves based on dependencies.
 
 Retrieve the current plan content with: kas task show %s`, filename)
 
@@ -2054,6 +2515,15 @@ func (m *home) promptPushBranchThenAdvance(inst *session.Instance) tea.Cmd {
 	return m.confirmAction(message, func() tea.Msg { return pushAction() })
 }
 
+// newTaskBranch renders the configured branch template for a new plan.
+func (m *home) newTaskBranch(planFile string) (string, error) {
+	template := ""
+	if m.appConfig != nil {
+		template = m.appConfig.BranchTemplate
+	}
+	return gitpkg.RenderTaskBranch(template, planFile, config.Username(), time.Now())
+}
+
 // taskBranch resolves the branch name for a plan, backfilling if needed.
 func (m *home) taskBranch(planFile string) string {
 	if m.taskState == nil {
@@ -2073,7 +2543,8 @@ func (m *home) taskBranch(planFile string) string {
 // buildPlanningPrompt returns the initial prompt for a planner agent session.
 // The prompt explicitly requires ## Wave N headers because kasmos uses them
 // for wave orchestration — without them, implementation cannot start.
-func buildPlanningPrompt(planFile, planName, description string) string {
+// repoContext (see gatherPlannerContext) is appended as-is.
+func buildPlanningPrompt(planFile, planName, description, repoContext string) string {
 	return fmt.Sprintf(
 		"Plan %s. Goal: %s. "+
 			"Use the `kasmos-planner` skill. "+
@@ -2081,7 +2552,29 @@ func buildPlanningPrompt(planFile, planName, description string) string {
 			"grouping all tasks — kasmos requires Wave headers to orchestrate implementation. "+
 			"After writing the plan, store it with `kas task update-content %s` and then signal completion with `touch .kasmos/signals/planner-finished-%s`.",
 		planName, description, planFile, planFile,
-	)
+	) + repoContext
+}
+
+// plannerPromptMsg carries a planning prompt whose planner_context files were
+// gathered in the background; its planner is spawned on receipt.
+type plannerPromptMsg struct {
+	planFile string
+	prompt   string
+}
+
+// spawnPlanner spawns the planner for planFile. When planner_context globs
+// are configured, the files are read in a Cmd and the planner is spawned from
+// the resulting plannerPromptMsg, keeping git and file I/O out of Update.
+func (m *home) spawnPlanner(planFile, description string) (tea.Model, tea.Cmd) {
+	name := taskstate.DisplayName(planFile)
+	if m.appConfig == nil || len(m.appConfig.PlannerContext.Globs) == 0 {
+		return m.spawnTaskAgent(planFile, "plan", buildPlanningPrompt(planFile, name, description, ""))
+	}
+	repoPath, pc := m.activeRepoPath, m.appConfig.PlannerContext
+	return m, func() tea.Msg {
+		repoContext := gatherPlannerContext(repoPath, pc.Globs, pc.FileByteLimit())
+		return plannerPromptMsg{planFile: planFile, prompt: buildPlanningPrompt(planFile, name, description, repoContext)}
+	}
 }
 
 // buildImplementPrompt returns the prompt for a coder agent session.
@@ -2133,7 +2626,7 @@ func agentTypeForSubItem(action string) (string, bool) {
 
 // spawnAdHocAgent creates and starts an ad-hoc agent session (no plan, no lifecycle).
 // branch and workPath are optional overrides - empty strings use defaults.
-func (m *home) spawnAdHocAgent(name, branch, workPath string) (tea.Model, tea.Cmd) {
+func (m *home) spawnAdHocAgent(name, branch, workPath, role string, sharedWorktree bool) (tea.Model, tea.Cmd) {
 	if !m.requireDaemonForAgents() {
 		return m, nil
 	}
@@ -2142,16 +2635,25 @@ func (m *home) spawnAdHocAgent(name, branch, workPath string) (tea.Model, tea.Cm
 		path = workPath
 	}
 
+	agentType := session.AgentTypeFixer
+	program := m.programForAgent(session.AgentTypeFixer)
+	if role != "" {
+		agentType = role
+		program = m.programForRole(role)
+	}
+
 	inst, err := session.NewInstance(session.InstanceOptions{
-		Title:   name,
-		Path:    path,
-		Program: m.programForAgent(session.AgentTypeFixer),
+		Title:          name,
+		Path:           path,
+		Program:        program,
+		RecordSessions: m.recordSessions(),
+		Container:      m.containerConfig(),
 	})
 	if err != nil {
 		return m, m.handleError(err)
 	}
 
-	inst.AgentType = session.AgentTypeFixer
+	inst.AgentType = agentType
 	inst.SetStatus(session.Loading)
 	inst.LoadingTotal = 8
 	inst.LoadingMessage = "preparing session..."
@@ -2168,8 +2670,16 @@ func (m *home) spawnAdHocAgent(name, branch, workPath string) (tea.Model, tea.Cm
 		}
 
 	case branch != "":
-		// Branch override - create worktree on specified branch
+		// Branch override - join the branch's worktree when asked and one
+		// exists, otherwise create a worktree on the specified branch.
 		startCmd = func() tea.Msg {
+			wt, err := adHocBranchWorktree(path, branch, sharedWorktree)
+			if err != nil {
+				return instanceStartedMsg{instance: inst, err: err}
+			}
+			if wt != nil {
+				return instanceStartedMsg{instance: inst, err: inst.StartInSharedWorktree(wt, branch)}
+			}
 			return instanceStartedMsg{instance: inst, err: inst.StartOnBranch(branch)}
 		}
 
@@ -2180,9 +2690,9 @@ func (m *home) spawnAdHocAgent(name, branch, workPath string) (tea.Model, tea.Cm
 		}
 	}
 
-	m.audit(auditlog.EventAgentSpawned, fmt.Sprintf("spawned fixer agent: %s", name),
+	m.audit(auditlog.EventAgentSpawned, fmt.Sprintf("spawned %s agent: %s", agentType, name),
 		auditlog.WithInstance(name),
-		auditlog.WithAgent(session.AgentTypeFixer),
+		auditlog.WithAgent(agentType),
 	)
 
 	m.addInstanceFinalizer(inst, m.nav.AddInstance(inst))
@@ -2190,6 +2700,20 @@ func (m *home) spawnAdHocAgent(name, branch, workPath string) (tea.Model, tea.Cm
 	return m, tea.Batch(tea.RequestWindowSize, startCmd)
 }
 
+// adHocBranchWorktree returns the existing worktree an ad-hoc agent on branch
+// should join, or nil when a new worktree must be created. Existing worktrees
+// are only joined when shared is set.
+func adHocBranchWorktree(repoPath, branch string, shared bool) (*gitpkg.GitWorktree, error) {
+	if !shared {
+		return nil, nil
+	}
+	wt, err := gitpkg.NewSharedBranchWorktree(repoPath, branch)
+	if err != nil {
+		return nil, fmt.Errorf("find worktree for %s: %w", branch, err)
+	}
+	return wt, nil
+}
+
 // spawnTaskAgent creates and starts an agent session for the given plan and action.
 func (m *home) spawnTaskAgent(planFile, action, prompt string) (tea.Model, tea.Cmd) {
 	if !m.requireDaemonForAgents() {
@@ -2225,12 +2749,14 @@ func (m *home) spawnTaskAgent(planFile, action, prompt string) (tea.Model, tea.C
 		title = fmt.Sprintf("%s-review-%d", planName, reviewCycle+1)
 	}
 	inst, err := session.NewInstance(session.InstanceOptions{
-		Title:         title,
-		Path:          m.activeRepoPath,
-		Program:       m.programForAgent(agentType),
-		ExecutionMode: m.executionModeForAgent(agentType),
-		TaskFile:      planFile,
-		AgentType:     agentType,
+		Title:          title,
+		Path:           m.activeRepoPath,
+		Program:        m.programForAgent(agentType),
+		ExecutionMode:  m.executionModeForAgent(agentType),
+		TaskFile:       planFile,
+		AgentType:      agentType,
+		RecordSessions: m.recordSessions(),
+		Container:      m.containerConfig(),
 	})
 	if err != nil {
 		return m, m.handleError(err)
@@ -2279,24 +2805,13 @@ func (m *home) spawnTaskAgent(planFile, action, prompt string) (tea.Model, tea.C
 			}
 		}
 	} else {
-		// Backfill branch name for plans created before the branch field was introduced.
-		if entry.Branch == "" {
-			entry.Branch = gitpkg.TaskBranchFromFile(planFile)
-			if err := m.taskState.SetBranch(planFile, entry.Branch); err != nil {
-				return m, m.handleError(fmt.Errorf("failed to assign branch for plan: %w", err))
-			}
-		}
-
 		// Coder and reviewer share the plan's feature branch worktree
-		shared := gitpkg.NewSharedTaskWorktree(m.activeRepoPath, entry.Branch)
-		if err := shared.Setup(); err != nil {
-			return m, m.handleError(err)
-		}
-		if err := scaffold.PatchWorktreeConfig(shared.GetWorktreePath(), m.opencodeAgentConfigs()); err != nil {
+		shared, branch, err := m.prepareTaskWorktree(planFile, entry)
+		if err != nil {
 			return m, m.handleError(err)
 		}
 		startCmd = func() tea.Msg {
-			err := inst.StartInSharedWorktree(shared, entry.Branch)
+			err := inst.StartInSharedWorktree(shared, branch)
 			return instanceStartedMsg{instance: inst, err: err}
 		}
 	}
@@ -2314,6 +2829,132 @@ func (m *home) spawnTaskAgent(planFile, action, prompt string) (tea.Model, tea.C
 	return m, tea.Batch(tea.RequestWindowSize, startCmd)
 }
 
+// cloneInstance starts a fresh copy of orig on the same plan, agent role and
+// branch, leaving the original session untouched. Plan agents join the plan's
+// shared worktree; ad-hoc agents join their branch's worktree when it exists.
+func (m *home) cloneInstance(orig *session.Instance) (tea.Model, tea.Cmd) {
+	if !m.requireDaemonForAgents() {
+		return m, nil
+	}
+	inst, err := m.newCloneInstance(orig)
+	if err != nil {
+		return m, m.handleError(err)
+	}
+	inst.SetStatus(session.Loading)
+	inst.LoadingTotal = 5
+	inst.LoadingMessage = "Preparing session..."
+
+	var startCmd tea.Cmd
+	switch {
+	case orig.Branch == "" || orig.SoloAgent || orig.AgentType == session.AgentTypePlanner:
+		startCmd = func() tea.Msg {
+			return instanceStartedMsg{instance: inst, err: inst.StartOnMainBranch()}
+		}
+	case orig.TaskFile != "":
+		entry, ok := m.taskState.Entry(orig.TaskFile)
+		if !ok {
+			return m, m.handleError(fmt.Errorf("task not found: %s", orig.TaskFile))
+		}
+		shared, branch, err := m.prepareTaskWorktree(orig.TaskFile, entry)
+		if err != nil {
+			return m, m.handleError(err)
+		}
+		startCmd = func() tea.Msg {
+			return instanceStartedMsg{instance: inst, err: inst.StartInSharedWorktree(shared, branch)}
+		}
+	default:
+		repoPath, branch := orig.Path, orig.Branch
+		startCmd = func() tea.Msg {
+			wt, err := adHocBranchWorktree(repoPath, branch, true)
+			if err != nil {
+				return instanceStartedMsg{instance: inst, err: err}
+			}
+			if wt != nil {
+				return instanceStartedMsg{instance: inst, err: inst.StartInSharedWorktree(wt, branch)}
+			}
+			return instanceStartedMsg{instance: inst, err: inst.StartOnBranch(branch)}
+		}
+	}
+
+	m.audit(auditlog.EventAgentSpawned, fmt.Sprintf("cloned %s as %s", orig.Title, inst.Title),
+		auditlog.WithPlan(inst.TaskFile),
+		auditlog.WithInstance(inst.Title),
+		auditlog.WithAgent(inst.AgentType),
+	)
+
+	m.addInstanceFinalizer(inst, m.nav.AddInstance(inst))
+	m.nav.SelectInstance(inst)
+	return m, tea.Batch(tea.RequestWindowSize, startCmd)
+}
+
+// newCloneInstance builds an unstarted copy of orig titled <orig>-N. Identity
+// fields (plan, role, branch, program) are copied; runtime state such as the
+// status and QueuedPrompt is not.
+func (m *home) newCloneInstance(orig *session.Instance) (*session.Instance, error) {
+	inst, err := session.NewInstance(session.InstanceOptions{
+		Title:           m.cloneTitle(orig.Title),
+		Path:            orig.Path,
+		Program:         orig.Program,
+		ExecutionMode:   orig.ExecutionMode,
+		AutoYes:         orig.AutoYes,
+		AutoYesPatterns: orig.AutoYesPatterns,
+		SkipPermissions: orig.SkipPermissions,
+		TaskFile:        orig.TaskFile,
+		AgentType:       orig.AgentType,
+		TaskNumber:      orig.TaskNumber,
+		WaveNumber:      orig.WaveNumber,
+		PeerCount:       orig.PeerCount,
+		ReviewCycle:     orig.ReviewCycle,
+		RecordSessions:  m.recordSessions(),
+		Container:       orig.Container,
+	})
+	if err != nil {
+		return nil, err
+	}
+	inst.Branch = orig.Branch
+	inst.Topic = orig.Topic
+	inst.IsReviewer = orig.IsReviewer
+	inst.SoloAgent = orig.SoloAgent
+	return inst, nil
+}
+
+// cloneTitle returns the first unused "<title>-N" for N >= 2.
+func (m *home) cloneTitle(title string) string {
+	taken := make(map[string]bool)
+	for _, inst := range m.allInstances {
+		taken[inst.Title] = true
+	}
+	for _, inst := range m.nav.GetInstances() {
+		taken[inst.Title] = true
+	}
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s-%d", title, n)
+		if !taken[candidate] {
+			return candidate
+		}
+	}
+}
+
+// prepareTaskWorktree sets up the plan's shared feature-branch worktree and
+// patches its opencode agent config. Plans created before the branch field was
+// introduced get their branch name backfilled first.
+func (m *home) prepareTaskWorktree(planFile string, entry taskstate.TaskEntry) (*gitpkg.GitWorktree, string, error) {
+	if entry.Branch == "" {
+		entry.Branch = gitpkg.TaskBranchFromFile(planFile)
+		if err := m.taskState.SetBranch(planFile, entry.Branch); err != nil {
+			return nil, "", fmt.Errorf("failed to assign branch for plan: %w", err)
+		}
+	}
+	shared := gitpkg.NewSharedTaskWorktree(m.activeRepoPath, entry.Branch)
+	if err := shared.Setup(); err != nil {
+		return nil, "", err
+	}
+	if err := scaffold.PatchWorktreeConfig(shared.GetWorktreePath(), m.opencodeAgentConfigs()); err != nil {
+		return nil, "", err
+	}
+	return shared, entry.Branch, nil
+}
+
 // getTopicNames returns existing topic names for the picker.
 func (m *home) getTopicNames() []string {
 	if m.taskState == nil {
@@ -2411,6 +3052,15 @@ func (m *home) rebuildOrphanedOrchestrators() {
 			continue
 		}
 
+		// Prefer the exact snapshot written by the orchestrator before the restart;
+		// fall back to the paused-instance heuristic only when none exists.
+		if orch := m.loadWaveState(planFile, plan); orch != nil {
+			m.waveOrchestrators[planFile] = orch
+			log.WarningLog.Printf("rebuildOrphanedOrchestrators: restored orchestrator for %s from snapshot (wave %d)",
+				planFile, orch.CurrentWaveNumber())
+			continue
+		}
+
 		orch := orchestration.NewWaveOrchestrator(planFile, plan)
 		orch.SetStore(m.taskStore, m.taskStoreProject)
 
@@ -2425,6 +3075,7 @@ func (m *home) rebuildOrphanedOrchestrators() {
 		// Fast-forward the orchestrator to the target wave, marking earlier waves
 		// as complete and applying actual task states for the target wave.
 		orch.RestoreToWave(targetWave, completedTasks)
+		orch.SetStateDir(m.waveStateDir())
 
 		m.waveOrchestrators[planFile] = orch
 		log.WarningLog.Printf("rebuildOrphanedOrchestrators: restored orchestrator for %s (wave %d, %d tasks)",
@@ -2450,20 +3101,25 @@ func (m *home) spawnWaveTasks(orch *orchestration.WaveOrchestrator, tasks []task
 		return m, m.handleError(err)
 	}
 
+	// A "Model:" line on the wave overrides the coder profile's model.
+	program := withModelOverride(m.programForAgent(session.AgentTypeCoder), orch.CurrentWaveModel())
+
 	var cmds []tea.Cmd
 	for _, task := range tasks {
 		prompt := orch.BuildTaskPrompt(task, len(tasks))
 
 		inst, err := session.NewInstance(session.InstanceOptions{
-			Title:         fmt.Sprintf("%s-W%d-T%d", planName, orch.CurrentWaveNumber(), task.Number),
-			Path:          m.activeRepoPath,
-			Program:       m.programForAgent(session.AgentTypeCoder),
-			ExecutionMode: m.executionModeForAgent(session.AgentTypeCoder),
-			TaskFile:      planFile,
-			AgentType:     session.AgentTypeCoder,
-			TaskNumber:    task.Number,
-			WaveNumber:    orch.CurrentWaveNumber(),
-			PeerCount:     len(tasks),
+			Title:          fmt.Sprintf("%s-W%d-T%d", planName, orch.CurrentWaveNumber(), task.Number),
+			Path:           m.activeRepoPath,
+			Program:        program,
+			ExecutionMode:  m.executionModeForAgent(session.AgentTypeCoder),
+			TaskFile:       planFile,
+			AgentType:      session.AgentTypeCoder,
+			TaskNumber:     task.Number,
+			WaveNumber:     orch.CurrentWaveNumber(),
+			PeerCount:      len(tasks),
+			RecordSessions: m.recordSessions(),
+			Container:      m.containerConfig(),
 		})
 		if err != nil {
 			return m, m.handleError(err)
@@ -2489,34 +3145,93 @@ func (m *home) spawnWaveTasks(orch *orchestration.WaveOrchestrator, tasks []task
 }
 
 // startNextWave advances the orchestrator to the next wave and spawns its task instances.
+// At most maxWaveConcurrency tasks are spawned; the rest are queued and picked up by
+// spawnQueuedTasks as running tasks resolve.
 func (m *home) startNextWave(orch *orchestration.WaveOrchestrator, entry taskstate.TaskEntry) (tea.Model, tea.Cmd) {
+	m.applyWaveLimits(orch)
+	if err := m.capWaveToInstanceLimit(orch); err != nil {
+		return m, m.handleError(err)
+	}
 	tasks := orch.StartNextWave()
 	if len(tasks) == 0 {
 		return m, nil
 	}
 
 	waveNum := orch.CurrentWaveNumber()
-	m.toastManager.Info(fmt.Sprintf("wave %d started: %d task(s) running", waveNum, len(tasks)))
+	if queued := orch.QueuedTaskCount(); queued > 0 {
+		m.toastManager.Info(fmt.Sprintf("wave %d started: %d task(s) running, %d queued", waveNum, len(tasks), queued))
+	} else {
+		m.toastManager.Info(fmt.Sprintf("wave %d started: %d task(s) running", waveNum, len(tasks)))
+	}
 	m.audit(auditlog.EventWaveStarted,
-		fmt.Sprintf("wave %d started: %d task(s)", waveNum, len(tasks)),
+		fmt.Sprintf("wave %d started: %d task(s)", waveNum, len(orch.CurrentWaveTasks())),
 		auditlog.WithPlan(orch.TaskFile()),
 		auditlog.WithWave(waveNum, 0))
 	return m.spawnWaveTasks(orch, tasks, entry)
 }
 
+// spawnQueuedTasks spawns instances for queued tasks in the current wave once
+// running tasks have freed up concurrency slots. Returns a nil command when no
+// task could be dequeued.
+func (m *home) spawnQueuedTasks(orch *orchestration.WaveOrchestrator, entry taskstate.TaskEntry) (tea.Model, tea.Cmd) {
+	m.applyWaveLimits(orch)
+	if err := m.capWaveToInstanceLimit(orch); err != nil {
+		return m, m.handleError(err)
+	}
+	tasks := orch.DequeueTasks()
+	if len(tasks) == 0 {
+		return m, nil
+	}
+	return m.spawnWaveTasks(orch, tasks, entry)
+}
+
 // retryFailedWaveTasks retries all failed tasks in the current wave by re-spawning them.
 // Old failed instances are removed first to prevent ghost duplicates that accumulate
 // across retries and all get marked ImplementationComplete when waves finish.
 func (m *home) retryFailedWaveTasks(orch *orchestration.WaveOrchestrator, entry taskstate.TaskEntry) (tea.Model, tea.Cmd) {
-	tasks := orch.RetryFailedTasks()
+	return m.retryWaveTasks(orch, entry, "failed", orch.RetryFailedTasks)
+}
+
+// retryTimedOutWaveTasks retries only the tasks in the current wave that were
+// failed by the task timeout, leaving other failures alone.
+func (m *home) retryTimedOutWaveTasks(orch *orchestration.WaveOrchestrator, entry taskstate.TaskEntry) (tea.Model, tea.Cmd) {
+	return m.retryWaveTasks(orch, entry, "timed-out", orch.RetryTimedOutTasks)
+}
+
+// retryWaveTask retries a single failed task in the current wave, leaving the
+// rest of the wave as it is.
+func (m *home) retryWaveTask(orch *orchestration.WaveOrchestrator, entry taskstate.TaskEntry, taskNumber int) (tea.Model, tea.Cmd) {
+	return m.retryWaveTasks(orch, entry, "failed", func() []taskparser.Task {
+		return orch.RetryTask(taskNumber)
+	})
+}
+
+// retryWaveTasks requeues failed tasks via requeue and re-spawns as many as the
+// concurrency limit allows, removing the requeued tasks' stale instances first.
+func (m *home) retryWaveTasks(orch *orchestration.WaveOrchestrator, entry taskstate.TaskEntry, kind string, requeue func() []taskparser.Task) (tea.Model, tea.Cmd) {
+	m.applyWaveLimits(orch)
+	if err := m.capWaveToInstanceLimit(orch); err != nil {
+		return m, m.handleError(err)
+	}
+	failedBefore := make(map[int]bool)
+	for _, t := range orch.CurrentWaveTasks() {
+		if orch.IsTaskFailed(t.Number) {
+			failedBefore[t.Number] = true
+		}
+	}
+	tasks := requeue()
 	if len(tasks) == 0 {
 		return m, nil
 	}
 
-	// Build a set of task numbers being retried for fast lookup.
-	retryingTasks := make(map[int]bool, len(tasks))
-	for _, t := range tasks {
-		retryingTasks[t.Number] = true
+	// Build a set of task numbers being retried for fast lookup: every task
+	// that was failed and no longer is, which includes retries queued behind
+	// the concurrency cap.
+	retryingTasks := make(map[int]bool)
+	for n := range failedBefore {
+		if !orch.IsTaskFailed(n) {
+			retryingTasks[n] = true
+		}
 	}
 
 	// Remove old failed instances for the tasks being retried.
@@ -2533,11 +3248,36 @@ func (m *home) retryFailedWaveTasks(orch *orchestration.WaveOrchestrator, entry
 		m.removeFromAllInstances(inst.Title)
 	}
 
-	m.toastManager.Info(fmt.Sprintf("retrying %d failed task(s) in wave %d",
-		len(tasks), orch.CurrentWaveNumber()))
+	m.toastManager.Info(fmt.Sprintf("retrying %d %s task(s) in wave %d",
+		len(retryingTasks), kind, orch.CurrentWaveNumber()))
 	return m.spawnWaveTasks(orch, tasks, entry)
 }
 
+// retrySelectedPlanWaveTask retries task taskNumber of the selected plan's
+// current wave when the plan header is selected and that task has failed.
+// handled is false when there is nothing to retry, so callers can fall
+// through to the key's normal binding.
+func (m *home) retrySelectedPlanWaveTask(taskNumber int) (mod tea.Model, cmd tea.Cmd, handled bool) {
+	if m.nav == nil || !m.nav.IsSelectedPlanHeader() || m.taskState == nil {
+		return m, nil, false
+	}
+	planFile := m.nav.GetSelectedPlanFile()
+	orch, ok := m.waveOrchestrators[planFile]
+	if !ok || !orch.IsTaskFailed(taskNumber) {
+		return m, nil, false
+	}
+	inWave := false
+	for _, t := range orch.CurrentWaveTasks() {
+		inWave = inWave || t.Number == taskNumber
+	}
+	entry, ok := m.taskState.Entry(planFile)
+	if !inWave || !ok {
+		return m, nil, false
+	}
+	mod, cmd = m.retryWaveTask(orch, entry, taskNumber)
+	return mod, cmd, true
+}
+
 // discoverTmuxSessions returns a tea.Cmd that lists all kas_ tmux sessions (managed + orphaned).
 func (m *home) discoverTmuxSessions() tea.Cmd {
 	knownNames := make([]string, 0, len(m.allInstances))
@@ -2592,11 +3332,13 @@ func (m *home) spawnChatAboutTask(planFile, question string) (tea.Model, tea.Cmd
 	title := planName + "-chat"
 
 	inst, err := session.NewInstance(session.InstanceOptions{
-		Title:     title,
-		Path:      m.activeRepoPath,
-		Program:   m.programForAgent(session.AgentTypeFixer),
-		TaskFile:  planFile,
-		AgentType: session.AgentTypeFixer,
+		Title:          title,
+		Path:           m.activeRepoPath,
+		Program:        m.programForAgent(session.AgentTypeFixer),
+		TaskFile:       planFile,
+		AgentType:      session.AgentTypeFixer,
+		RecordSessions: m.recordSessions(),
+		Container:      m.containerConfig(),
 	})
 	if err != nil {
 		return m, m.handleError(err)
@@ -2661,6 +3403,154 @@ func (m *home) adoptOrphanSession(item overlay.TmuxBrowserItem) (tea.Model, tea.
 	}
 }
 
+// resourceAlertTicks is how many consecutive metadata ticks an instance must
+// stay over a resource threshold before it is reported, so a single spike
+// doesn't alert.
+const resourceAlertTicks = 3
+
+// overResourceThreshold reports whether inst's last sampled CPU and memory
+// exceed the configured alert thresholds. A zero threshold is disabled.
+func (m *home) overResourceThreshold(inst *session.Instance) (cpu, mem bool) {
+	if m.appConfig == nil {
+		return false, false
+	}
+	cpu = m.appConfig.CPUAlertPercent > 0 && inst.CPUPercent > m.appConfig.CPUAlertPercent
+	mem = m.appConfig.MemAlertMB > 0 && inst.MemMB > m.appConfig.MemAlertMB
+	return cpu, mem
+}
+
+// checkResourceAlert advances inst's breach counter after a resource sample
+// and, once the breach has lasted resourceAlertTicks ticks, audits and toasts
+// it a single time. Dropping back under the thresholds re-arms the alert.
+// Returns true when a toast was shown.
+func (m *home) checkResourceAlert(inst *session.Instance) bool {
+	cpuOver, memOver := m.overResourceThreshold(inst)
+	if !cpuOver && !memOver {
+		inst.ResourceBreachTicks = 0
+		inst.ResourceAlerted = false
+		return false
+	}
+	inst.ResourceBreachTicks++
+	if inst.ResourceAlerted || inst.ResourceBreachTicks < resourceAlertTicks {
+		return false
+	}
+	inst.ResourceAlerted = true
+	msg := fmt.Sprintf("%s is using %.0f%% cpu, %.0fM memory", inst.Title, inst.CPUPercent, inst.MemMB)
+	m.audit(auditlog.EventResourceAlert, msg,
+		auditlog.WithPlan(inst.TaskFile),
+		auditlog.WithInstance(inst.Title),
+		auditlog.WithLevel("warn"))
+	m.toastManager.Error(msg)
+	return true
+}
+
+// pauseIdleInstance pauses an instance that sat idle past the auto-pause
+// timeout, leaving the clipboard alone. Tests override it.
+var pauseIdleInstance = func(inst *session.Instance) error { return inst.PauseQuietly() }
+
+// autoPauseResult is the outcome of auto-pausing one idle instance.
+type autoPauseResult struct {
+	inst *session.Instance
+	idle time.Duration
+	err  error
+}
+
+// autoPauseResultMsg reports a finished background auto-pause.
+type autoPauseResultMsg struct {
+	results []autoPauseResult
+}
+
+// autoPauseIdleInstances returns a Cmd pausing, in the background, every
+// started instance that has been Ready for longer than
+// idle_auto_pause_minutes, or nil when none has. Reviewers are left alone so
+// their verdict stays on screen, as are wave tasks still running in their
+// orchestrator, which would otherwise fail the wave. Each picked instance's
+// idle clock restarts, so it is not picked again while its pause runs and a
+// pause that fails (e.g. uncommitted changes) is not retried every tick.
+func (m *home) autoPauseIdleInstances(now time.Time) tea.Cmd {
+	if m.appConfig == nil || m.appConfig.IdleAutoPauseMinutes <= 0 {
+		return nil
+	}
+	limit := time.Duration(m.appConfig.IdleAutoPauseMinutes) * time.Minute
+	var targets []autoPauseResult
+	for _, inst := range m.nav.GetInstances() {
+		if !inst.Started() || inst.Exited || inst.IdleFor(now) < limit {
+			continue
+		}
+		if inst.AgentType == session.AgentTypeReviewer {
+			continue
+		}
+		if orch, ok := m.waveOrchestrators[inst.TaskFile]; ok && inst.TaskNumber > 0 && orch.IsTaskRunning(inst.TaskNumber) {
+			continue
+		}
+		targets = append(targets, autoPauseResult{inst: inst, idle: inst.IdleFor(now).Round(time.Minute)})
+		inst.IdleSince = now
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		for i := range targets {
+			targets[i].err = pauseIdleInstance(targets[i].inst)
+		}
+		return autoPauseResultMsg{results: targets}
+	}
+}
+
+// handleAutoPauseResult audits each instance a background auto-pause
+// stopped and logs those it could not.
+func (m *home) handleAutoPauseResult(msg autoPauseResultMsg) tea.Cmd {
+	paused := false
+	for _, r := range msg.results {
+		if r.err != nil {
+			log.WarningLog.Printf("auto-pause of %q failed: %v", r.inst.Title, r.err)
+			continue
+		}
+		m.audit(auditlog.EventAgentPaused, fmt.Sprintf("agent auto-paused after %s idle", r.idle),
+			auditlog.WithInstance(r.inst.Title),
+			auditlog.WithAgent(r.inst.AgentType),
+			auditlog.WithPlan(r.inst.TaskFile),
+		)
+		paused = true
+	}
+	if !paused {
+		return nil
+	}
+	_ = m.saveAllInstances()
+	m.updateNavPanelStatus()
+	return m.instanceChanged()
+}
+
+// applyConflictState records the latest merge conflict probe on inst, emitting
+// an audit event only when the worktree transitions into a conflicted state.
+func (m *home) applyConflictState(inst *session.Instance, has bool, files []string) {
+	if has && !inst.HasConflicts {
+		m.audit(auditlog.EventMergeConflict,
+			fmt.Sprintf("merge conflicts in %s", inst.Title),
+			auditlog.WithPlan(inst.TaskFile),
+			auditlog.WithInstance(inst.Title),
+			auditlog.WithAgent(inst.AgentType),
+			auditlog.WithDetail(strings.Join(files, ", ")),
+			auditlog.WithLevel("warn"))
+	}
+	inst.HasConflicts = has
+	inst.ConflictFiles = files
+}
+
+// applyDetachedState records the latest detached HEAD probe on inst, emitting
+// an audit event only when the worktree transitions off its branch.
+func (m *home) applyDetachedState(inst *session.Instance, detached bool) {
+	if detached && !inst.DetachedHead {
+		m.audit(auditlog.EventDetachedHead,
+			fmt.Sprintf("%s is on a detached HEAD", inst.Title),
+			auditlog.WithPlan(inst.TaskFile),
+			auditlog.WithInstance(inst.Title),
+			auditlog.WithAgent(inst.AgentType),
+			auditlog.WithLevel("warn"))
+	}
+	inst.DetachedHead = detached
+}
+
 // audit emits a structured audit event, automatically filling in the Project
 // field from m.taskStoreProject. Optional fields (PlanFile, InstanceTitle,
 // AgentType, WaveNumber, TaskNumber, Detail, Level) can be set via EventOption
@@ -2866,3 +3756,52 @@ func coalesceRestarts(displays []ui.AuditEventDisplay) []ui.AuditEventDisplay {
 	}
 	return out
 }
+
+// notifyPermissionPrompt sends a notification that inst is waiting on a
+// permission prompt, at most once per prompt appearance (keyed by guardKey).
+func (m *home) notifyPermissionPrompt(inst *session.Instance, guardKey, description string) {
+	if m.permissionNotified == nil {
+		m.permissionNotified = make(map[*session.Instance]string)
+	}
+	if m.permissionNotified[inst] == guardKey {
+		return
+	}
+	m.permissionNotified[inst] = guardKey
+	session.SendNotification("kas", fmt.Sprintf("Agent %s needs permission: %s", inst.Title, description))
+}
+
+// toggleNavGrouping switches the sidebar between the plan tree and agent-type
+// sections and remembers the choice in the app state.
+func (m *home) toggleNavGrouping() tea.Cmd {
+	grouping := m.nav.ToggleGrouping()
+	if m.appState != nil {
+		if err := m.appState.SetNavGrouping(string(grouping)); err != nil {
+			log.WarningLog.Printf("failed to save sidebar grouping: %v", err)
+		}
+	}
+	if grouping == ui.NavGroupByAgentType {
+		m.toastManager.Info("grouping agents by type")
+	} else {
+		m.toastManager.Info("grouping by plan")
+	}
+	return tea.Batch(m.instanceChanged(), m.toastTickCmd())
+}
+
+// promptHistory returns the prompts previously sent in the active repo,
+// oldest first, for recall in prompt overlays.
+func (m *home) promptHistory() []string {
+	if m.appState == nil {
+		return nil
+	}
+	return m.appState.GetPromptHistory(m.activeRepoPath)
+}
+
+// rememberPrompt adds a sent prompt to the active repo's prompt history.
+func (m *home) rememberPrompt(prompt string) {
+	if m.appState == nil {
+		return
+	}
+	if err := m.appState.AddPromptHistory(m.activeRepoPath, prompt); err != nil {
+		log.WarningLog.Printf("failed to save prompt history: %v", err)
+	}
+}
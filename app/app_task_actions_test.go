@@ -12,7 +12,9 @@ import (
 
 	"charm.land/bubbles/v2/spinner"
 	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/kastheco/kasmos/config"
+	"github.com/kastheco/kasmos/config/auditlog"
 	"github.com/kastheco/kasmos/config/taskfsm"
 	"github.com/kastheco/kasmos/config/taskparser"
 	"github.com/kastheco/kasmos/config/taskstate"
@@ -23,12 +25,13 @@ import (
 	gitpkg "github.com/kastheco/kasmos/session/git"
 	"github.com/kastheco/kasmos/ui"
 	"github.com/kastheco/kasmos/ui/overlay"
+	zone "github.com/lrstanley/bubblezone/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestBuildPlanPrompt(t *testing.T) {
-	prompt := buildPlanningPrompt("auth-refactor", "Auth Refactor", "Refactor JWT auth")
+	prompt := buildPlanningPrompt("auth-refactor", "Auth Refactor", "Refactor JWT auth", "")
 	if !strings.Contains(prompt, "Plan Auth Refactor") {
 		t.Fatalf("prompt missing title")
 	}
@@ -859,6 +862,50 @@ func TestExecuteContextAction_SetStatusForceOverridesWithoutFSM(t *testing.T) {
 	assert.Equal(t, planFile, h.pendingSetStatusTask, "pending plan file should be stored")
 }
 
+func TestStatusBarClick_OpensSetStatusPicker(t *testing.T) {
+	dir := t.TempDir()
+	plansDir := filepath.Join(dir, "docs", "plans")
+	require.NoError(t, os.MkdirAll(plansDir, 0o755))
+
+	ps, err := newTestPlanState(t, plansDir)
+	require.NoError(t, err)
+
+	planFile := "status-click.md"
+	require.NoError(t, ps.Register(planFile, "status click", "plan/status-click", time.Now()))
+	seedPlanStatus(t, ps, planFile, taskstate.StatusImplementing)
+
+	sp := spinner.New(spinner.WithSpinner(spinner.Dot))
+	h := &home{
+		taskState:      ps,
+		taskStateDir:   plansDir,
+		nav:            ui.NewNavigationPanel(&sp),
+		menu:           ui.NewMenu(),
+		tabbedWindow:   ui.NewTabbedWindow(ui.NewPreviewPane(), ui.NewInfoPane()),
+		toastManager:   overlay.NewToastManager(&sp),
+		overlays:       overlay.NewManager(),
+		activeRepoPath: dir,
+	}
+	h.updateSidebarTasks()
+	require.True(t, h.nav.SelectByID(ui.SidebarPlanPrefix+planFile))
+
+	// Render the status bar through the zone manager so the segment is hit-testable.
+	bar := ui.NewStatusBar()
+	bar.SetSize(120)
+	bar.SetData(h.computeStatusBarData())
+	zone.Scan(bar.String())
+	var z *zone.ZoneInfo
+	require.Eventually(t, func() bool {
+		z = zone.Get(ui.ZonePlanStatus)
+		return !z.IsZero()
+	}, time.Second, 5*time.Millisecond)
+
+	_, _ = h.handleMouseClick(tea.MouseClickMsg{X: z.StartX, Y: z.StartY, Button: tea.MouseLeft})
+
+	assert.Equal(t, stateSetStatus, h.state)
+	assert.True(t, h.overlays.IsActive())
+	assert.Equal(t, planFile, h.pendingSetStatusTask)
+}
+
 func TestExecuteTaskStage_BlocksWhenDaemonUnavailable(t *testing.T) {
 	dir := t.TempDir()
 	plansDir := filepath.Join(dir, "docs", "plans")
@@ -921,7 +968,7 @@ func TestSpawnAdHocAgent_BlocksWhenDaemonUnavailable(t *testing.T) {
 		},
 	}
 
-	model, cmd := h.spawnAdHocAgent("my-agent", "", "")
+	model, cmd := h.spawnAdHocAgent("my-agent", "", "", "", false)
 	updated := model.(*home)
 
 	require.Nil(t, cmd)
@@ -1202,3 +1249,628 @@ func TestExecuteContextAction_MarkPlanDoneFromReadyTransitionsToDone(t *testing.
 	assert.Equal(t, taskstate.StatusDone, entry.Status,
 		"mark_plan_done should walk ready->implementing->reviewing->done")
 }
+
+func TestExecuteContextAction_ReopenDonePlan(t *testing.T) {
+	dir := t.TempDir()
+	plansDir := filepath.Join(dir, "docs", "plans")
+	store := taskstore.NewTestSQLiteStore(t)
+	planFile := "qa-regression.md"
+	require.NoError(t, store.Create("test", taskstore.TaskEntry{Filename: planFile, Status: taskstore.StatusReviewing}))
+	fsm := taskfsm.New(store, "test", plansDir)
+	require.NoError(t, fsm.Transition(planFile, taskfsm.ReviewApproved))
+
+	ps, err := taskstate.Load(store, "test", plansDir)
+	require.NoError(t, err)
+	logger, err := auditlog.NewSQLiteLogger(":memory:")
+	require.NoError(t, err)
+	defer logger.Close()
+
+	sp := spinner.New(spinner.WithSpinner(spinner.Dot))
+	h := &home{
+		taskState:        ps,
+		taskStore:        store,
+		taskStoreProject: "test",
+		taskStateDir:     plansDir,
+		fsm:              fsm,
+		auditLogger:      logger,
+		nav:              ui.NewNavigationPanel(&sp),
+		menu:             ui.NewMenu(),
+		tabbedWindow:     ui.NewTabbedWindow(ui.NewPreviewPane(), ui.NewInfoPane()),
+		toastManager:     overlay.NewToastManager(&sp),
+		overlays:         overlay.NewManager(),
+		activeRepoPath:   dir,
+	}
+	h.updateSidebarTasks()
+	require.True(t, h.nav.SelectByID(ui.SidebarPlanHistoryToggle))
+	require.True(t, h.nav.ToggleSelectedExpand())
+	require.True(t, h.nav.SelectByID(ui.SidebarPlanPrefix+planFile))
+	require.True(t, h.nav.IsSelectedHistoryPlan())
+
+	_, _ = h.executeContextAction("reopen_plan")
+
+	entry, ok := h.taskState.Entry(planFile)
+	require.True(t, ok)
+	assert.Equal(t, taskstate.StatusReady, entry.Status)
+	assert.True(t, entry.DoneAt.IsZero())
+	assert.False(t, h.nav.IsSelectedHistoryPlan(), "reopened plan moves back into the active tree")
+	assert.Equal(t, planFile, h.nav.GetSelectedPlanFile())
+
+	events, err := logger.Query(auditlog.QueryFilter{
+		Project: "test",
+		Kinds:   []auditlog.EventKind{auditlog.EventPlanReopened},
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "done → ready (reopened)", events[0].Message)
+
+	// Active plans cannot be reopened.
+	_, _ = h.executeContextAction("reopen_plan")
+	assert.Equal(t, taskstate.StatusReady, h.taskState.Plans[planFile].Status)
+}
+
+func TestExecuteContextAction_SetPriority(t *testing.T) {
+	dir := t.TempDir()
+	plansDir := filepath.Join(dir, "docs", "plans")
+	require.NoError(t, os.MkdirAll(plansDir, 0o755))
+
+	ps, err := newTestPlanState(t, plansDir)
+	require.NoError(t, err)
+
+	planFile := "test-set-priority.md"
+	require.NoError(t, ps.Register(planFile, "test set priority", "plan/test-set-priority", time.Now()))
+
+	sp := spinner.New(spinner.WithSpinner(spinner.Dot))
+	h := &home{
+		taskState:      ps,
+		taskStateDir:   plansDir,
+		fsm:            newFSMForTest(t, plansDir).TaskStateMachine,
+		nav:            ui.NewNavigationPanel(&sp),
+		menu:           ui.NewMenu(),
+		tabbedWindow:   ui.NewTabbedWindow(ui.NewPreviewPane(), ui.NewInfoPane()),
+		toastManager:   overlay.NewToastManager(&sp),
+		overlays:       overlay.NewManager(),
+		activeRepoPath: dir,
+	}
+
+	h.updateSidebarTasks()
+	require.True(t, h.nav.SelectByID(ui.SidebarPlanPrefix+planFile))
+
+	_, _ = h.executeContextAction("set_priority")
+	assert.Equal(t, stateSetPriority, h.state)
+	assert.True(t, h.overlays.IsActive(), "picker overlay should be created for priority selection")
+	assert.Equal(t, planFile, h.pendingSetPriorityTask)
+
+	_, _ = h.finishSetPriority(overlay.Result{Dismissed: true, Submitted: true, Value: "urgent"})
+	assert.Equal(t, stateDefault, h.state)
+	assert.Empty(t, h.pendingSetPriorityTask)
+	entry, ok := h.taskState.Entry(planFile)
+	require.True(t, ok)
+	assert.Equal(t, taskstate.PriorityUrgent, entry.Priority)
+}
+
+func TestExecuteContextAction_TogglePinPlan(t *testing.T) {
+	dir := t.TempDir()
+	plansDir := filepath.Join(dir, "docs", "plans")
+	require.NoError(t, os.MkdirAll(plansDir, 0o755))
+
+	ps, err := newTestPlanState(t, plansDir)
+	require.NoError(t, err)
+
+	planFile := "test-pin.md"
+	require.NoError(t, ps.Register(planFile, "test pin", "plan/test-pin", time.Now()))
+
+	sp := spinner.New(spinner.WithSpinner(spinner.Dot))
+	h := &home{
+		taskState:      ps,
+		taskStateDir:   plansDir,
+		fsm:            newFSMForTest(t, plansDir).TaskStateMachine,
+		nav:            ui.NewNavigationPanel(&sp),
+		menu:           ui.NewMenu(),
+		tabbedWindow:   ui.NewTabbedWindow(ui.NewPreviewPane(), ui.NewInfoPane()),
+		toastManager:   overlay.NewToastManager(&sp),
+		overlays:       overlay.NewManager(),
+		activeRepoPath: dir,
+	}
+
+	h.updateSidebarTasks()
+	require.True(t, h.nav.SelectByID(ui.SidebarPlanPrefix+planFile))
+
+	_, _ = h.executeContextAction("toggle_pin_plan")
+	assert.True(t, h.taskState.Plans[planFile].Pinned)
+
+	require.True(t, h.nav.SelectByID(ui.SidebarPlanPrefix+planFile))
+	_, _ = h.executeContextAction("toggle_pin_plan")
+	assert.False(t, h.taskState.Plans[planFile].Pinned)
+}
+
+func TestPriorityFromLabel(t *testing.T) {
+	for label, want := range map[string]int{"urgent": 3, "high": 2, "low": 1, "none": 0} {
+		got, ok := priorityFromLabel(label)
+		require.True(t, ok, label)
+		assert.Equal(t, want, got, label)
+	}
+	_, ok := priorityFromLabel("bogus")
+	assert.False(t, ok)
+}
+
+func TestTopicSetStatus_WarnsAboutRunningAgentsAndAppliesBulk(t *testing.T) {
+	dir := t.TempDir()
+	plansDir := filepath.Join(dir, "docs", "plans")
+	require.NoError(t, os.MkdirAll(plansDir, 0o755))
+
+	ps, err := newTestPlanState(t, plansDir)
+	require.NoError(t, err)
+	for _, f := range []string{"alpha", "beta", "gamma"} {
+		require.NoError(t, ps.Create(f, f, "plan/"+f, "infra", time.Now()))
+	}
+	require.NoError(t, ps.Create("other", "other", "plan/other", "", time.Now()))
+
+	inst, err := session.NewInstance(session.InstanceOptions{
+		Title: "beta-coder", Path: dir, Program: "opencode", TaskFile: "beta",
+	})
+	require.NoError(t, err)
+	inst.MarkStartedForTest()
+	inst.SetStatus(session.Running)
+
+	sp := spinner.New(spinner.WithSpinner(spinner.Dot))
+	h := &home{
+		taskState:      ps,
+		taskStateDir:   plansDir,
+		fsm:            newFSMForTest(t, plansDir).TaskStateMachine,
+		nav:            ui.NewNavigationPanel(&sp),
+		menu:           ui.NewMenu(),
+		tabbedWindow:   ui.NewTabbedWindow(ui.NewPreviewPane(), ui.NewInfoPane()),
+		toastManager:   overlay.NewToastManager(&sp),
+		overlays:       overlay.NewManager(),
+		activeRepoPath: dir,
+		allInstances:   []*session.Instance{inst},
+	}
+
+	h.updateSidebarTasks()
+	require.True(t, h.nav.SelectByID(ui.SidebarTopicPrefix+"infra"))
+	assert.Equal(t, "infra", h.nav.GetSelectedTopic())
+
+	_, _ = h.executeContextAction("set_topic_status")
+	require.Equal(t, stateSetStatus, h.state)
+	assert.Equal(t, "infra", h.pendingSetStatusTopic)
+
+	_, _ = h.finishSetStatus(overlay.Result{Submitted: true, Dismissed: true, Value: "done"})
+	require.Equal(t, stateConfirm, h.state)
+	require.NotNil(t, h.pendingConfirmAction)
+	co, ok := h.overlays.Current().(*overlay.ConfirmationOverlay)
+	require.True(t, ok)
+	view := co.View()
+	assert.Contains(t, view, "set 3 task(s)")
+	assert.Contains(t, view, "agents still running on: beta")
+
+	msg := h.pendingConfirmAction()
+	setMsg, ok := msg.(topicStatusSetMsg)
+	require.True(t, ok)
+	assert.Equal(t, []string{"alpha", "beta", "gamma"}, setMsg.filenames)
+
+	_, _ = h.Update(setMsg)
+	for _, f := range []string{"alpha", "beta", "gamma"} {
+		entry, ok := h.taskState.Entry(f)
+		require.True(t, ok)
+		assert.Equal(t, taskstate.StatusDone, entry.Status, f)
+	}
+	other, ok := h.taskState.Entry("other")
+	require.True(t, ok)
+	assert.Equal(t, taskstate.StatusReady, other.Status)
+}
+
+func TestPlansWithRunningInstances_SkipsPausedAndExited(t *testing.T) {
+	mk := func(title, plan string, status session.Status, exited bool) *session.Instance {
+		inst, err := session.NewInstance(session.InstanceOptions{
+			Title: title, Path: t.TempDir(), Program: "opencode", TaskFile: plan,
+		})
+		require.NoError(t, err)
+		inst.MarkStartedForTest()
+		inst.SetStatus(status)
+		inst.Exited = exited
+		return inst
+	}
+	h := &home{allInstances: []*session.Instance{
+		mk("a", "plan-a", session.Running, false),
+		mk("b", "plan-b", session.Paused, false),
+		mk("c", "plan-c", session.Ready, true),
+	}}
+
+	assert.Equal(t, []string{"plan-a"}, h.plansWithRunningInstances([]string{"plan-a", "plan-b", "plan-c", "plan-d"}))
+}
+
+func TestExecuteContextAction_DuplicatePlan(t *testing.T) {
+	dir := t.TempDir()
+	plansDir := filepath.Join(dir, "docs", "plans")
+	require.NoError(t, os.MkdirAll(plansDir, 0o755))
+
+	ps, err := newTestPlanState(t, plansDir)
+	require.NoError(t, err)
+	require.NoError(t, ps.CreateWithContent("auth", "auth refactor", "plan/auth", "backend", time.Now(), "# Auth\n"))
+	_, err = ps.Duplicate("auth", "auth-copy", "plan/auth-copy")
+	require.NoError(t, err)
+
+	sp := spinner.New(spinner.WithSpinner(spinner.Dot))
+	h := &home{
+		taskState:      ps,
+		taskStateDir:   plansDir,
+		nav:            ui.NewNavigationPanel(&sp),
+		menu:           ui.NewMenu(),
+		tabbedWindow:   ui.NewTabbedWindow(ui.NewPreviewPane(), ui.NewInfoPane()),
+		toastManager:   overlay.NewToastManager(&sp),
+		overlays:       overlay.NewManager(),
+		activeRepoPath: dir,
+		appConfig:      &config.Config{BranchTemplate: "feature/{slug}"},
+	}
+	h.updateSidebarTasks()
+	require.True(t, h.nav.SelectByID(ui.SidebarPlanPrefix+"auth"))
+
+	_, _ = h.executeContextAction("duplicate_plan")
+
+	entry, ok := h.taskState.Entry("auth-copy-2")
+	require.True(t, ok, "existing copy name is deduped")
+	assert.Equal(t, "backend", entry.Topic)
+	assert.Equal(t, "feature/auth-copy-2", entry.Branch, "the copy's branch follows branch_template")
+	assert.Equal(t, "auth-copy-2", h.nav.GetSelectedPlanFile())
+}
+
+func TestExecuteContextAction_SetBlockers(t *testing.T) {
+	dir := t.TempDir()
+	plansDir := filepath.Join(dir, "docs", "plans")
+	require.NoError(t, os.MkdirAll(plansDir, 0o755))
+
+	ps, err := newTestPlanState(t, plansDir)
+	require.NoError(t, err)
+	for _, f := range []string{"api", "db", "ui"} {
+		require.NoError(t, ps.Create(f, f, "plan/"+f, "", time.Now()))
+	}
+
+	sp := spinner.New(spinner.WithSpinner(spinner.Dot))
+	h := &home{
+		taskState:      ps,
+		taskStateDir:   plansDir,
+		nav:            ui.NewNavigationPanel(&sp),
+		menu:           ui.NewMenu(),
+		tabbedWindow:   ui.NewTabbedWindow(ui.NewPreviewPane(), ui.NewInfoPane()),
+		toastManager:   overlay.NewToastManager(&sp),
+		overlays:       overlay.NewManager(),
+		activeRepoPath: dir,
+	}
+	h.updateSidebarTasks()
+	require.True(t, h.nav.SelectByID(ui.SidebarPlanPrefix+"ui"))
+
+	_, _ = h.executeContextAction("set_blockers")
+	require.Equal(t, stateSetBlockers, h.state)
+	picker, ok := h.overlays.Current().(*overlay.PickerOverlay)
+	require.True(t, ok)
+	assert.NotContains(t, picker.View(), "[ ] ui", "a plan cannot block itself")
+
+	_, _ = h.finishSetBlockers(overlay.Result{Submitted: true, Dismissed: true, Value: "api\ndb"})
+	assert.Equal(t, stateDefault, h.state)
+	assert.Empty(t, h.pendingSetBlockersTask)
+	assert.Equal(t, []string{"api", "db"}, h.taskState.Plans["ui"].BlockedBy)
+}
+
+func TestBroadcastPrompt_QueuesForRunningPlanSessions(t *testing.T) {
+	dir := t.TempDir()
+	plansDir := filepath.Join(dir, "docs", "plans")
+	require.NoError(t, os.MkdirAll(plansDir, 0o755))
+
+	ps, err := newTestPlanState(t, plansDir)
+	require.NoError(t, err)
+	require.NoError(t, ps.Create("alpha", "alpha", "plan/alpha", "", time.Now()))
+	require.NoError(t, ps.Create("beta", "beta", "plan/beta", "", time.Now()))
+
+	sp := spinner.New(spinner.WithSpinner(spinner.Dot))
+	h := &home{
+		taskState:      ps,
+		taskStateDir:   plansDir,
+		nav:            ui.NewNavigationPanel(&sp),
+		menu:           ui.NewMenu(),
+		tabbedWindow:   ui.NewTabbedWindow(ui.NewPreviewPane(), ui.NewInfoPane()),
+		toastManager:   overlay.NewToastManager(&sp),
+		overlays:       overlay.NewManager(),
+		activeRepoPath: dir,
+	}
+	mk := func(title, plan string, status session.Status, started bool) *session.Instance {
+		inst, err := session.NewInstance(session.InstanceOptions{
+			Title: title, Path: dir, Program: "opencode", TaskFile: plan,
+		})
+		require.NoError(t, err)
+		if started {
+			inst.MarkStartedForTest()
+		}
+		inst.SetStatus(status)
+		h.nav.AddInstance(inst)
+		h.allInstances = append(h.allInstances, inst)
+		return inst
+	}
+	task1 := mk("alpha-task-1", "alpha", session.Running, true)
+	task2 := mk("alpha-task-2", "alpha", session.Ready, true)
+	task2.QueuedPrompt = "pending task"
+	paused := mk("alpha-task-3", "alpha", session.Paused, true)
+	unstarted := mk("alpha-task-4", "alpha", session.Ready, false)
+	other := mk("beta-coder", "beta", session.Running, true)
+
+	h.updateSidebarTasks()
+	require.True(t, h.nav.SelectByID(ui.SidebarPlanPrefix+"alpha"))
+
+	h.keySent = true
+	_, _ = h.handleKeyPress(tea.KeyPressMsg{Code: 'I', Text: "I"})
+	require.Equal(t, stateBroadcastPrompt, h.state)
+	assert.Equal(t, "alpha", h.pendingBroadcastPlan)
+
+	assert.Equal(t, 2, h.broadcastPrompt("alpha", "rename foo to bar everywhere"))
+	assert.Equal(t, "rename foo to bar everywhere", task1.QueuedPrompt)
+	assert.Equal(t, "pending task\n\nrename foo to bar everywhere", task2.QueuedPrompt)
+	assert.Empty(t, paused.QueuedPrompt)
+	assert.Empty(t, unstarted.QueuedPrompt)
+	assert.Empty(t, other.QueuedPrompt)
+}
+
+func TestBroadcastPrompt_RecallsAndRecordsPromptHistory(t *testing.T) {
+	dir := t.TempDir()
+	ps, err := newTestPlanState(t, dir)
+	require.NoError(t, err)
+	require.NoError(t, ps.Create("alpha", "alpha", "plan/alpha", "", time.Now()))
+
+	h := newTestHome()
+	h.taskState = ps
+	h.taskStateDir = dir
+	h.activeRepoPath = dir
+	state := &mockAppState{prompts: map[string][]string{dir: {"run the tests", "fix lint"}}}
+	h.appState = state
+	inst, err := session.NewInstance(session.InstanceOptions{Title: "alpha-coder", Path: dir, Program: "opencode", TaskFile: "alpha"})
+	require.NoError(t, err)
+	inst.MarkStartedForTest()
+	inst.SetStatus(session.Running)
+	h.nav.AddInstance(inst)
+	h.allInstances = append(h.allInstances, inst)
+	h.updateSidebarTasks()
+	require.True(t, h.nav.SelectByID(ui.SidebarPlanPrefix+"alpha"))
+
+	h.keySent = true
+	_, _ = h.handleKeyPress(tea.KeyPressMsg{Code: 'I', Text: "I"})
+	require.Equal(t, stateBroadcastPrompt, h.state)
+	_, _ = h.handleKeyPress(tea.KeyPressMsg{Code: tea.KeyUp})
+	_, _ = h.handleKeyPress(tea.KeyPressMsg{Code: tea.KeyUp})
+	_, _ = h.handleKeyPress(tea.KeyPressMsg{Code: tea.KeyTab})
+	_, _ = h.handleKeyPress(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	assert.Equal(t, "run the tests", inst.QueuedPrompt, "up twice recalls the older prompt")
+	assert.Equal(t, []string{"run the tests", "fix lint", "run the tests"}, state.prompts[dir])
+}
+
+func TestGlobalSearch_PickerSelectsMatchingPlan(t *testing.T) {
+	dir := t.TempDir()
+	plansDir := filepath.Join(dir, "docs", "plans")
+	require.NoError(t, os.MkdirAll(plansDir, 0o755))
+
+	store := taskstore.NewTestSQLiteStore(t)
+	ps, err := newTestPlanStateWithStore(t, store, plansDir)
+	require.NoError(t, err)
+	require.NoError(t, ps.CreateWithContent("alpha", "alpha", "plan/alpha", "", time.Now(), "# Alpha\nnothing relevant"))
+	require.NoError(t, ps.CreateWithContent("beta", "beta", "plan/beta", "", time.Now(), "# Beta\nMigrate the Billing tables"))
+
+	sp := spinner.New(spinner.WithSpinner(spinner.Dot))
+	h := &home{
+		taskState:        ps,
+		taskStore:        store,
+		taskStoreProject: "test",
+		taskStateDir:     plansDir,
+		nav:              ui.NewNavigationPanel(&sp),
+		menu:             ui.NewMenu(),
+		tabbedWindow:     ui.NewTabbedWindow(ui.NewPreviewPane(), ui.NewInfoPane()),
+		toastManager:     overlay.NewToastManager(&sp),
+		overlays:         overlay.NewManager(),
+		activeRepoPath:   dir,
+	}
+	h.updateSidebarTasks()
+	require.True(t, h.nav.SelectByID(ui.SidebarPlanPrefix+"alpha"))
+
+	h.keySent = true
+	_, _ = h.handleKeyPress(tea.KeyPressMsg{Code: 'F', Text: "F"})
+	require.Equal(t, stateGlobalSearch, h.state)
+
+	_, _ = h.finishGlobalSearch(overlay.Result{Submitted: true, Value: "billing"})
+	require.Equal(t, stateGlobalSearchPicker, h.state)
+	require.Len(t, h.contentMatches, 1)
+	label := contentMatchLabel(h.contentMatches[0])
+	assert.Contains(t, label, "Migrate the Billing tables")
+
+	_, cmd := h.finishGlobalSearchPicker(overlay.Result{Submitted: true, Value: label})
+	assert.Equal(t, stateDefault, h.state)
+	assert.Equal(t, "beta", h.nav.GetSelectedPlanFile())
+	assert.NotNil(t, cmd, "selecting a match renders the plan preview")
+}
+
+func TestEditPlanCommand(t *testing.T) {
+	cmd := editPlanCommand("nvim", "/repo/docs/plans/alpha.md")
+	assert.Equal(t, []string{"nvim", "/repo/docs/plans/alpha.md"}, cmd.Args)
+
+	cmd = editPlanCommand("code --wait", "/repo/docs/plans/alpha.md")
+	assert.Equal(t, []string{"code", "--wait", "/repo/docs/plans/alpha.md"}, cmd.Args)
+
+	cmd = editPlanCommand("", "/repo/docs/plans/alpha.md")
+	assert.Equal(t, []string{"vi", "/repo/docs/plans/alpha.md"}, cmd.Args)
+}
+
+func TestFinishEditPlan_StoresEditedContent(t *testing.T) {
+	dir := t.TempDir()
+	plansDir := filepath.Join(dir, "docs", "plans")
+	require.NoError(t, os.MkdirAll(plansDir, 0o755))
+
+	store := taskstore.NewTestSQLiteStore(t)
+	ps, err := newTestPlanStateWithStore(t, store, plansDir)
+	require.NoError(t, err)
+	require.NoError(t, ps.CreateWithContent("alpha.md", "alpha", "plan/alpha", "", time.Now(), "# Alpha\nold"))
+
+	sp := spinner.New(spinner.WithSpinner(spinner.Dot))
+	h := &home{
+		taskState:          ps,
+		taskStore:          store,
+		taskStoreProject:   "test",
+		taskStateDir:       plansDir,
+		nav:                ui.NewNavigationPanel(&sp),
+		menu:               ui.NewMenu(),
+		tabbedWindow:       ui.NewTabbedWindow(ui.NewPreviewPane(), ui.NewInfoPane()),
+		toastManager:       overlay.NewToastManager(&sp),
+		overlays:           overlay.NewManager(),
+		activeRepoPath:     dir,
+		cachedPlanFile:     "alpha.md",
+		cachedPlanRendered: "stale",
+	}
+	path := filepath.Join(plansDir, "alpha.md")
+	require.NoError(t, os.WriteFile(path, []byte("# Alpha\nedited"), 0o644))
+
+	h.finishEditPlan(planRefreshMsg{planFile: "alpha.md", path: path, created: true})
+
+	content, err := store.GetContent("test", "alpha.md")
+	require.NoError(t, err)
+	assert.Equal(t, "# Alpha\nedited", content)
+	assert.Empty(t, h.cachedPlanRendered, "edited plan must be re-rendered")
+	assert.NoFileExists(t, path, "file written only for the edit is removed")
+}
+
+func TestBuildPlanPRBody(t *testing.T) {
+	plan := &taskparser.Plan{Waves: []taskparser.Wave{
+		{Number: 1, Tasks: []taskparser.Task{{Number: 1, Title: "add schema"}, {Number: 2, Title: "add api"}}},
+		{Number: 2, Tasks: []taskparser.Task{{Number: 3, Title: "wire ui"}}},
+	}}
+	subtasks := []taskstore.SubtaskEntry{
+		{TaskNumber: 1, Status: taskstore.SubtaskStatusDone},
+		{TaskNumber: 2, Status: taskstore.SubtaskStatusComplete},
+		{TaskNumber: 3, Status: taskstore.SubtaskStatusFailed},
+	}
+
+	body := buildPlanPRBody("auth-refactor", "Move auth to tokens", plan, subtasks, "## Commits\n\nabc123 add schema")
+	assert.Equal(t, "## summary\n\n- plan: auth-refactor\n- goal: Move auth to tokens\n\n"+
+		"## waves\n\n### wave 1\n- [x] task 1: add schema\n- [x] task 2: add api\n\n### wave 2\n- [ ] task 3: wire ui\n\n"+
+		"## Commits\n\nabc123 add schema", body)
+
+	body = buildPlanPRBody("tiny", "", nil, nil, "")
+	assert.Equal(t, "## summary\n\n- plan: tiny", body)
+}
+
+func TestCreatePlanPR_PrefillsTitleFromPlan(t *testing.T) {
+	h := newTestHome()
+	h.setupPlanState(t, "auth-refactor", taskstate.StatusReviewing, "")
+	h.focusSlot = slotNav
+	require.True(t, h.nav.SelectByID(ui.SidebarPlanPrefix+"auth-refactor"))
+
+	_, _ = h.executeContextAction("create_plan_pr")
+	require.Equal(t, statePRTitle, h.state)
+	assert.Equal(t, "auth-refactor", h.pendingPRPlan)
+	require.NotNil(t, h.pendingPRWorktree)
+	assert.Equal(t, "plan/auth-refactor", h.pendingPRWorktree.GetBranchName())
+	tio, ok := h.overlays.Current().(*overlay.TextInputOverlay)
+	require.True(t, ok)
+	result := tio.HandleKey(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.Equal(t, "auth-refactor", result.Value, "title is pre-filled from the plan")
+}
+
+func TestDiagnosePlan_ReportsStatusInstancesAndSignals(t *testing.T) {
+	dir := t.TempDir()
+	plansDir := filepath.Join(dir, "docs", "plans")
+	signalsDir := filepath.Join(dir, ".kasmos", "signals")
+	require.NoError(t, os.MkdirAll(plansDir, 0o755))
+	require.NoError(t, os.MkdirAll(signalsDir, 0o755))
+
+	ps, err := newTestPlanState(t, plansDir)
+	require.NoError(t, err)
+	require.NoError(t, ps.Create("beta", "beta", "plan/beta", "", time.Now()))
+	seedPlanStatus(t, ps, "beta", taskstate.StatusImplementing)
+	require.NoError(t, os.WriteFile(filepath.Join(signalsDir, "implement-finished-beta"), nil, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(signalsDir, "implement-finished-other"), nil, 0o644))
+
+	sp := spinner.New(spinner.WithSpinner(spinner.Dot))
+	h := &home{
+		taskState:              ps,
+		taskStateDir:           plansDir,
+		signalsDir:             signalsDir,
+		nav:                    ui.NewNavigationPanel(&sp),
+		waveOrchestrators:      make(map[string]*orchestration.WaveOrchestrator),
+		deferredPlannerDialogs: []string{"beta"},
+	}
+	for _, title := range []string{"beta-coder", "beta-reviewer"} {
+		inst, err := session.NewInstance(session.InstanceOptions{
+			Title: title, Path: dir, Program: "opencode", TaskFile: "beta",
+		})
+		require.NoError(t, err)
+		inst.MarkStartedForTest()
+		inst.SetStatus(session.Running)
+		h.nav.AddInstance(inst)()
+	}
+
+	out := h.diagnosePlan("beta")
+	assert.Contains(t, out, "fsm status:    implementing")
+	assert.Contains(t, out, "orchestrator:  none")
+	assert.Contains(t, out, "instances (2):")
+	assert.Contains(t, out, "beta-coder [agent] running, tmux dead")
+	assert.Contains(t, out, "planner finished")
+	assert.Contains(t, out, "implement_finished (implement-finished-beta)")
+	assert.NotContains(t, out, "implement-finished-other")
+}
+
+func TestPlansDir_CustomDirUsedForStateLoading(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	repo := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, ".kasmos"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ".kasmos", config.TOMLConfigFileName),
+		[]byte("plans_dir = \".project/plans\"\n"), 0o644))
+	cfg := config.LoadConfigForRepo(repo)
+	plansDir := cfg.PlansDirFor(repo)
+	require.Equal(t, filepath.Join(repo, ".project", "plans"), plansDir)
+
+	store := taskstore.NewTestSQLiteStore(t)
+	require.NoError(t, store.Create("proj", taskstore.TaskEntry{
+		Filename: "alpha.md",
+		Status:   taskstore.StatusReady,
+	}))
+
+	h := &home{
+		appConfig:        cfg,
+		taskStore:        store,
+		taskStoreProject: "proj",
+		taskStateDir:     plansDir,
+	}
+	h.loadTaskState()
+
+	require.NotNil(t, h.taskState)
+	assert.Equal(t, plansDir, h.taskState.Dir)
+	assert.Contains(t, h.taskState.Plans, "alpha.md")
+	assert.Equal(t, filepath.Join(plansDir, ".waves"), h.waveStateDir())
+}
+
+func TestHandleError_VersionConflictReloadsTaskState(t *testing.T) {
+	dir := t.TempDir()
+	store := taskstore.NewTestStore(t)
+	ps, err := newTestPlanStateWithStore(t, store, dir)
+	require.NoError(t, err)
+	require.NoError(t, ps.Register("plan", "plan", "plan/plan", time.Now()))
+
+	h := newTestHomeWithToast()
+	h.taskStore = store
+	h.taskStoreProject = "test"
+	h.taskStateDir = dir
+	h.loadTaskState()
+
+	// Another client moves the plan on after h loaded it.
+	remote, err := store.Get("test", "plan")
+	require.NoError(t, err)
+	remote.Status = taskstore.StatusPlanning
+	require.NoError(t, store.Update("test", "plan", remote))
+
+	err = h.taskState.SetPinned("plan", true)
+	require.ErrorIs(t, err, taskstore.ErrVersionConflict)
+	require.NotNil(t, h.handleError(err))
+	assert.Contains(t, ansi.Strip(h.toastManager.View()), "plan changed remotely, reloading")
+
+	entry, ok := h.taskState.Entry("plan")
+	require.True(t, ok)
+	assert.Equal(t, taskstate.StatusPlanning, entry.Status, "state is reloaded from the store")
+	require.NoError(t, h.taskState.SetPinned("plan", true), "retry after reload succeeds")
+}
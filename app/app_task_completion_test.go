@@ -1012,6 +1012,62 @@ func TestReviewCycle_InstanceStructHasCycleSet(t *testing.T) {
 		"reviewer instance must have ReviewCycle=1 for first review cycle (cycle=0 → display=1)")
 }
 
+// TestSpawnReviewer_UsesReviewerProgramOverride verifies that a configured
+// ReviewerProgram replaces the profile-resolved reviewer command, and that the
+// profile is used again once the override is cleared.
+func TestSpawnReviewer_UsesReviewerProgramOverride(t *testing.T) {
+	const planFile = "feature"
+
+	dir := t.TempDir()
+	plansDir := filepath.Join(dir, "docs", "plans")
+	require.NoError(t, os.MkdirAll(plansDir, 0o755))
+
+	ps, err := newTestPlanState(t, plansDir)
+	require.NoError(t, err)
+	require.NoError(t, ps.Register(planFile, "feature", "plan/feature", time.Now()))
+	seedPlanStatus(t, ps, planFile, taskstate.StatusImplementing)
+
+	sp := spinner.New(spinner.WithSpinner(spinner.Dot))
+	cfg := config.DefaultConfig()
+	cfg.ReviewerProgram = "claude --model opus"
+
+	h := &home{
+		ctx:                   context.Background(),
+		state:                 stateDefault,
+		appConfig:             cfg,
+		nav:                   ui.NewNavigationPanel(&sp),
+		menu:                  ui.NewMenu(),
+		tabbedWindow:          ui.NewTabbedWindow(ui.NewPreviewPane(), ui.NewInfoPane()),
+		toastManager:          overlay.NewToastManager(&sp),
+		taskState:             ps,
+		taskStateDir:          plansDir,
+		fsm:                   newPlanFSMForTest(t, plansDir),
+		pendingReviewFeedback: make(map[string]string),
+		plannerPrompted:       make(map[string]bool),
+		coderPushPrompted:     make(map[string]bool),
+		activeRepoPath:        dir,
+		program:               "opencode",
+	}
+	require.NoError(t, h.fsm.Transition(planFile, taskfsm.ImplementFinished))
+
+	_ = h.spawnReviewer(planFile)
+
+	var reviewerInst *session.Instance
+	for _, inst := range h.nav.GetInstances() {
+		if inst.TaskFile == planFile && inst.IsReviewer {
+			reviewerInst = inst
+			break
+		}
+	}
+	require.NotNil(t, reviewerInst, "spawnReviewer must create a reviewer instance")
+	assert.Equal(t, "claude --model opus", reviewerInst.Program)
+
+	assert.Equal(t, "opencode", h.programForAgent(session.AgentTypeCoder), "the override only applies to reviewers")
+	cfg.ReviewerProgram = ""
+	assert.Equal(t, h.profileForAgent(session.AgentTypeReviewer).BuildCommand(), h.programForAgent(session.AgentTypeReviewer),
+		"profile resolution is the fallback")
+}
+
 // TestIsLocked_FinishedLockedWhenDone verifies that the "finished" stage is
 // locked when the plan is already done, preventing a spurious FSM error.
 func TestIsLocked_FinishedLockedWhenDone(t *testing.T) {
@@ -1220,3 +1276,84 @@ func TestReviewApproved_NoReviewerNoPanic(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, taskstate.StatusDone, entry.Status)
 }
+
+// newReviewLoopTestHome builds a home with a single plan in reviewing state for
+// exercising the review→fix loop.
+func newReviewLoopTestHome(t *testing.T, planFile string, maxCycles int) (*home, *taskstate.TaskState) {
+	t.Helper()
+
+	dir := t.TempDir()
+	plansDir := filepath.Join(dir, "docs", "plans")
+	require.NoError(t, os.MkdirAll(plansDir, 0o755))
+
+	ps, err := newTestPlanState(t, plansDir)
+	require.NoError(t, err)
+	require.NoError(t, ps.Register(planFile, "feature", "plan/feature", time.Now()))
+	seedPlanStatus(t, ps, planFile, taskstate.StatusReviewing)
+
+	sp := spinner.New(spinner.WithSpinner(spinner.Dot))
+	cfg := config.DefaultConfig()
+	cfg.MaxReviewFixCycles = maxCycles
+
+	h := &home{
+		ctx:                   context.Background(),
+		state:                 stateDefault,
+		appConfig:             cfg,
+		nav:                   ui.NewNavigationPanel(&sp),
+		menu:                  ui.NewMenu(),
+		tabbedWindow:          ui.NewTabbedWindow(ui.NewPreviewPane(), ui.NewInfoPane()),
+		toastManager:          overlay.NewToastManager(&sp),
+		overlays:              overlay.NewManager(),
+		taskState:             ps,
+		taskStateDir:          plansDir,
+		fsm:                   newPlanFSMForTest(t, plansDir),
+		pendingReviewFeedback: make(map[string]string),
+	}
+	return h, ps
+}
+
+func TestReviewChangesRequested_IncrementsReviewCycle(t *testing.T) {
+	const planFile = "feature"
+	h, _ := newReviewLoopTestHome(t, planFile, 5)
+
+	_, _ = h.Update(metadataResultMsg{
+		PlanState: h.taskState,
+		Signals: []taskfsm.Signal{{
+			Event:    taskfsm.ReviewChangesRequested,
+			TaskFile: planFile,
+			Body:     "fix the tests",
+		}},
+	})
+
+	cycle, err := h.taskState.ReviewCycle(planFile)
+	require.NoError(t, err)
+	assert.Equal(t, 1, cycle)
+	assert.Equal(t, stateDefault, h.state, "under the cap the loop must not ask for confirmation")
+	assert.Equal(t, "fix the tests", h.pendingReviewFeedback[planFile])
+}
+
+func TestReviewChangesRequested_CapHitAsksUser(t *testing.T) {
+	const planFile = "feature"
+	h, ps := newReviewLoopTestHome(t, planFile, 1)
+	require.NoError(t, ps.IncrementReviewCycle(planFile))
+
+	_, _ = h.Update(metadataResultMsg{
+		PlanState: h.taskState,
+		Signals: []taskfsm.Signal{{
+			Event:    taskfsm.ReviewChangesRequested,
+			TaskFile: planFile,
+			Body:     "still broken",
+		}},
+	})
+
+	cycle, err := h.taskState.ReviewCycle(planFile)
+	require.NoError(t, err)
+	assert.Equal(t, 1, cycle, "cap hit must not bump the review cycle")
+	assert.Equal(t, stateConfirm, h.state)
+	require.NotNil(t, h.pendingConfirmAction)
+	msg := h.pendingConfirmAction()
+	override, ok := msg.(reviewCycleOverrideMsg)
+	require.True(t, ok, "confirming must request a fixer override, got %T", msg)
+	assert.Equal(t, planFile, override.planFile)
+	assert.Equal(t, "still broken", h.pendingReviewFeedback[planFile])
+}
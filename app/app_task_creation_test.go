@@ -10,6 +10,7 @@ import (
 
 	"charm.land/bubbles/v2/spinner"
 	tea "charm.land/bubbletea/v2"
+	"github.com/kastheco/kasmos/config"
 	"github.com/kastheco/kasmos/ui"
 	"github.com/kastheco/kasmos/ui/overlay"
 	"github.com/stretchr/testify/require"
@@ -33,6 +34,20 @@ func TestRenderPlanStub(t *testing.T) {
 	}
 }
 
+func TestRenderTemplate(t *testing.T) {
+	tmpl := "# {{name}}\n\ncreated {{date}}\n\n{{description}}\n\n{{unknown}}\n"
+	got := renderTemplate(tmpl, "Auth Refactor", "Refactor JWT auth", "auth-refactor",
+		time.Date(2026, 2, 21, 10, 0, 0, 0, time.UTC))
+	require.Equal(t, "# Auth Refactor\n\ncreated 2026-02-21\n\nRefactor JWT auth\n\n{{unknown}}\n", got)
+}
+
+func TestRenderTemplate_EmptyFallsBackToStub(t *testing.T) {
+	for _, tmpl := range []string{"", "  \n"} {
+		got := renderTemplate(tmpl, "Auth Refactor", "Refactor JWT auth", "auth-refactor", time.Now())
+		require.Equal(t, renderPlanStub("Auth Refactor", "Refactor JWT auth", "auth-refactor"), got)
+	}
+}
+
 func TestCreatePlanRecord(t *testing.T) {
 	dir := t.TempDir()
 	plansDir := filepath.Join(dir, "docs", "plans")
@@ -316,3 +331,99 @@ func TestNewPlanOverlaySizePreservedOnSpuriousWindowSize(t *testing.T) {
 	require.Equal(t, 70, cur.Width())
 	require.Equal(t, 8, cur.Height())
 }
+
+// newTemplateTestHome returns a home in the topic-picker step of the new-plan
+// wizard, with HOME pointed at a temp dir holding the given templates.
+func newTemplateTestHome(t *testing.T, templates map[string]string) *home {
+	t.Helper()
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	if len(templates) > 0 {
+		dir := filepath.Join(homeDir, ".config", "kasmos", "templates")
+		require.NoError(t, os.MkdirAll(dir, 0o755))
+		for name, content := range templates {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name+".md"), []byte(content), 0o644))
+		}
+	}
+
+	plansDir := filepath.Join(t.TempDir(), "docs", "plans")
+	require.NoError(t, os.MkdirAll(plansDir, 0o755))
+	ps, err := newTestPlanState(t, plansDir)
+	require.NoError(t, err)
+
+	sp := spinner.New(spinner.WithSpinner(spinner.Dot))
+	return &home{
+		state:           stateNewPlanTopic,
+		taskState:       ps,
+		taskStateDir:    plansDir,
+		nav:             ui.NewNavigationPanel(&sp),
+		menu:            ui.NewMenu(),
+		toastManager:    overlay.NewToastManager(&sp),
+		overlays:        overlay.NewManager(),
+		pendingPlanName: "auth refactor",
+		pendingPlanDesc: "Refactor JWT auth",
+	}
+}
+
+func TestNewPlanTopic_ShowsTemplatePickerWhenTemplatesExist(t *testing.T) {
+	h := newTemplateTestHome(t, map[string]string{"feature": "# {{name}}\n\n{{description}}\n"})
+
+	_, _ = h.finishNewPlanTopic(overlay.Result{Submitted: true, Dismissed: true, Value: "backend"})
+	require.Equal(t, stateNewPlanTemplate, h.state)
+	require.Equal(t, "backend", h.pendingPlanTopic)
+	picker, ok := h.overlays.Current().(*overlay.PickerOverlay)
+	require.True(t, ok)
+	require.Contains(t, picker.View(), blankTemplateLabel)
+
+	_, _ = h.finishNewPlanTemplate(overlay.Result{Submitted: true, Dismissed: true, Value: "feature"})
+	require.Equal(t, stateDefault, h.state)
+	require.Empty(t, h.pendingPlanName)
+	require.Empty(t, h.planTemplates)
+
+	content, err := h.taskState.GetContent("auth-refactor")
+	require.NoError(t, err)
+	require.Equal(t, "# auth refactor\n\nRefactor JWT auth\n", content)
+	entry, ok := h.taskState.Entry("auth-refactor")
+	require.True(t, ok)
+	require.Equal(t, "backend", entry.Topic)
+}
+
+func TestNewPlanTemplate_BlankUsesStub(t *testing.T) {
+	h := newTemplateTestHome(t, map[string]string{"feature": "# {{name}}"})
+
+	_, _ = h.finishNewPlanTopic(overlay.Result{Submitted: true, Dismissed: true, Value: "(No topic)"})
+	require.Equal(t, stateNewPlanTemplate, h.state)
+	_, _ = h.finishNewPlanTemplate(overlay.Result{Submitted: true, Dismissed: true, Value: blankTemplateLabel})
+
+	content, err := h.taskState.GetContent("auth-refactor")
+	require.NoError(t, err)
+	require.Equal(t, renderPlanStub("auth refactor", "Refactor JWT auth", "auth-refactor"), content)
+}
+
+func TestNewPlanTopic_NoTemplatesCreatesPlanDirectly(t *testing.T) {
+	h := newTemplateTestHome(t, nil)
+
+	_, _ = h.finishNewPlanTopic(overlay.Result{Submitted: true, Dismissed: true, Value: "(No topic)"})
+	require.Equal(t, stateDefault, h.state)
+	_, ok := h.taskState.Entry("auth-refactor")
+	require.True(t, ok)
+}
+
+func TestNewPlan_UsesBranchTemplate(t *testing.T) {
+	h := newTemplateTestHome(t, nil)
+	h.appConfig = &config.Config{BranchTemplate: "feature/{slug}"}
+
+	_, _ = h.finishNewPlanTopic(overlay.Result{Submitted: true, Dismissed: true, Value: "(No topic)"})
+	entry, ok := h.taskState.Entry("auth-refactor")
+	require.True(t, ok)
+	require.Equal(t, "feature/auth-refactor", entry.Branch)
+}
+
+func TestNewPlan_IllegalBranchTemplateCreatesNothing(t *testing.T) {
+	h := newTemplateTestHome(t, nil)
+	h.appConfig = &config.Config{BranchTemplate: "feature/../{slug}"}
+
+	_, _ = h.finishNewPlanTopic(overlay.Result{Submitted: true, Dismissed: true, Value: "(No topic)"})
+	_, ok := h.taskState.Entry("auth-refactor")
+	require.False(t, ok)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
 	"time"
@@ -115,7 +116,7 @@ func TestView_UsesCellMotionMouseMode(t *testing.T) {
 
 func TestSpawnAdHocAgent_DefaultCreatesWorktree(t *testing.T) {
 	h := newTestHome()
-	model, cmd := h.spawnAdHocAgent("my-agent", "", "")
+	model, cmd := h.spawnAdHocAgent("my-agent", "", "", "", false)
 	updated := model.(*home)
 	instances := updated.nav.GetInstances()
 	require.NotEmpty(t, instances)
@@ -127,7 +128,7 @@ func TestSpawnAdHocAgent_DefaultCreatesWorktree(t *testing.T) {
 
 func TestSpawnAdHocAgent_BranchOverride(t *testing.T) {
 	h := newTestHome()
-	model, cmd := h.spawnAdHocAgent("my-agent", "feature/login", "")
+	model, cmd := h.spawnAdHocAgent("my-agent", "feature/login", "", "", false)
 	updated := model.(*home)
 	instances := updated.nav.GetInstances()
 	require.NotEmpty(t, instances)
@@ -136,9 +137,26 @@ func TestSpawnAdHocAgent_BranchOverride(t *testing.T) {
 	assert.NotNil(t, cmd)
 }
 
+func TestSpawnAdHocAgent_CustomRoleUsesItsProfile(t *testing.T) {
+	h := newTestHome()
+	h.appConfig.PhaseRoles = map[string]string{"implementing": "coder"}
+	h.appConfig.Profiles = map[string]config.AgentProfile{
+		"coder":          {Program: "claude", Enabled: true},
+		"security_audit": {Program: "opencode", Model: "anthropic/claude-opus-4", Enabled: true},
+	}
+	assert.Equal(t, []string{"security_audit"}, h.appConfig.CustomRoles(), "phase-bound roles are not offered")
+
+	model, _ := h.spawnAdHocAgent("audit", "", "", "security_audit", false)
+	instances := model.(*home).nav.GetInstances()
+	require.NotEmpty(t, instances)
+	last := instances[len(instances)-1]
+	assert.Equal(t, "security_audit", last.AgentType)
+	assert.Equal(t, "opencode --model anthropic/claude-opus-4", last.Program)
+}
+
 func TestSpawnAdHocAgent_PathOverride(t *testing.T) {
 	h := newTestHome()
-	model, cmd := h.spawnAdHocAgent("my-agent", "", "/tmp/custom-path")
+	model, cmd := h.spawnAdHocAgent("my-agent", "", "/tmp/custom-path", "", false)
 	updated := model.(*home)
 	instances := updated.nav.GetInstances()
 	require.NotEmpty(t, instances)
@@ -147,6 +165,73 @@ func TestSpawnAdHocAgent_PathOverride(t *testing.T) {
 	assert.NotNil(t, cmd)
 }
 
+func TestAdHocBranchWorktree_SharedJoinsExisting(t *testing.T) {
+	dir := t.TempDir()
+	runGit := func(args ...string) {
+		t.Helper()
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		require.NoErrorf(t, err, "git %v failed: %s", args, string(out))
+	}
+	runGit("init")
+	runGit("config", "user.email", "test@test.com")
+	runGit("config", "user.name", "Test")
+	runGit("commit", "--allow-empty", "-m", "init")
+	runGit("worktree", "add", "-b", "feature/shared", filepath.Join(t.TempDir(), "wt"))
+
+	wt, err := adHocBranchWorktree(dir, "feature/shared", false)
+	require.NoError(t, err)
+	assert.Nil(t, wt, "without the shared flag a new worktree is created")
+
+	wt, err = adHocBranchWorktree(dir, "feature/shared", true)
+	require.NoError(t, err)
+	require.NotNil(t, wt, "shared flag joins the existing worktree")
+	assert.Equal(t, "feature/shared", wt.GetBranchName())
+
+	wt, err = adHocBranchWorktree(dir, "feature/other", true)
+	require.NoError(t, err)
+	assert.Nil(t, wt, "no existing worktree falls back to a new one")
+}
+
+func TestNewCloneInstance_CopiesIdentityNotState(t *testing.T) {
+	h := newTestHome()
+	orig, err := session.NewInstance(session.InstanceOptions{
+		Title:       "auth-implement",
+		Path:        t.TempDir(),
+		Program:     "opencode",
+		TaskFile:    "auth.md",
+		AgentType:   session.AgentTypeCoder,
+		TaskNumber:  3,
+		WaveNumber:  2,
+		ReviewCycle: 1,
+	})
+	require.NoError(t, err)
+	orig.Branch = "plan/auth"
+	orig.Topic = "security"
+	orig.QueuedPrompt = "fix the login test"
+	orig.SetStatus(session.Running)
+	h.nav.AddInstance(orig)
+	h.allInstances = append(h.allInstances, orig)
+
+	clone, err := h.newCloneInstance(orig)
+	require.NoError(t, err)
+	assert.Equal(t, "auth-implement-2", clone.Title)
+	assert.Equal(t, orig.Path, clone.Path)
+	assert.Equal(t, orig.Program, clone.Program)
+	assert.Equal(t, orig.TaskFile, clone.TaskFile)
+	assert.Equal(t, orig.AgentType, clone.AgentType)
+	assert.Equal(t, orig.Branch, clone.Branch)
+	assert.Equal(t, orig.Topic, clone.Topic)
+	assert.Equal(t, orig.TaskNumber, clone.TaskNumber)
+	assert.Equal(t, orig.WaveNumber, clone.WaveNumber)
+	assert.Equal(t, orig.ReviewCycle, clone.ReviewCycle)
+	assert.Empty(t, clone.QueuedPrompt, "queued prompt must not be copied")
+	assert.NotEqual(t, orig.Status, clone.Status, "status must not be copied")
+	assert.False(t, clone.Started())
+
+	h.nav.AddInstance(clone)
+	assert.Equal(t, "auth-implement-3", h.cloneTitle(orig.Title), "clone titles skip taken suffixes")
+}
+
 func TestSpawnAgent_KeyOpensFormOverlay(t *testing.T) {
 	h := newTestHome()
 	h.keySent = true
@@ -158,6 +243,48 @@ func TestSpawnAgent_KeyOpensFormOverlay(t *testing.T) {
 	require.True(t, ok, "active overlay must be a FormOverlay")
 }
 
+func TestSpawnAgent_RespectsConfiguredInstanceLimit(t *testing.T) {
+	h := newTestHome()
+	h.appConfig.MaxInstances = 30
+	h.tmuxSessionCount = 25 // above the default of 20, below the configured limit
+	h.keySent = true
+	model, _ := h.handleKeyPress(tea.KeyPressMsg{Code: 's', Text: "s"})
+	assert.Equal(t, stateSpawnAgent, model.(*home).state)
+
+	h = newTestHome()
+	h.appConfig.MaxInstances = 3
+	h.tmuxSessionCount = 3
+	h.keySent = true
+	model, _ = h.handleKeyPress(tea.KeyPressMsg{Code: 's', Text: "s"})
+	assert.Equal(t, stateDefault, model.(*home).state, "spawn must be refused at the limit")
+	assert.Contains(t, h.checkInstanceLimit(1).Error(), "more than 3 instances")
+	assert.NoError(t, h.checkInstanceLimit(0))
+}
+
+func TestNextNotification_ClearsFlagAfterVisit(t *testing.T) {
+	h := newTestHome()
+	first, err := newTestInstance("first")
+	require.NoError(t, err)
+	second, err := newTestInstance("second")
+	require.NoError(t, err)
+	first.Notified, second.Notified = true, true
+	h.nav.AddInstance(first)
+	h.nav.AddInstance(second)
+	press := func() {
+		h.keySent = true
+		h.handleKeyPress(tea.KeyPressMsg{Code: ']', Text: "]"})
+	}
+
+	press()
+	visited := h.nav.GetSelectedInstance()
+	require.NotNil(t, visited)
+	assert.True(t, visited.Notified, "flag stays while the user is looking at it")
+
+	press()
+	assert.NotSame(t, visited, h.nav.GetSelectedInstance())
+	assert.False(t, visited.Notified, "flag clears once the user moves on")
+}
+
 func TestSpawnAgent_EscCancels(t *testing.T) {
 	h := newTestHome()
 	h.state = stateSpawnAgent
@@ -1033,6 +1160,17 @@ func TestPreviewTerminal_SelectionChange(t *testing.T) {
 		assert.NotNil(t, cmd, "errored terminals should be closed asynchronously")
 		// errTerm.Close() was called internally by the handler
 	})
+
+	t.Run("previewScrollbackMsg clears the in-flight capture", func(t *testing.T) {
+		h, _, instB := newTestHomeWithInstances(t)
+		h.nav.SelectInstance(instB)
+		h.previewScrollbackPending = true
+
+		_, cmd := h.Update(previewScrollbackMsg{title: "instance-A", content: "stale"})
+
+		assert.False(t, h.previewScrollbackPending, "a finished capture allows the next one")
+		assert.Nil(t, cmd)
+	})
 }
 
 // TestPreviewTerminal_RenderTickIntegration tests the full preview terminal lifecycle:
@@ -1415,6 +1553,102 @@ func TestHandleQuit_ActiveSessions_ShowsConfirmation(t *testing.T) {
 	assert.NotNil(t, h.pendingConfirmAction, "pending action must be set")
 }
 
+func TestHandleQuitAndKill_StopsSessions(t *testing.T) {
+	h := newTestHome()
+	h.toastManager = overlay.NewToastManager(&h.spinner)
+	running := &session.Instance{Title: "running-agent", Status: session.Running}
+	running.MarkStartedForTest()
+	unstarted := &session.Instance{Title: "unstarted-agent", Status: session.Ready}
+	h.nav.AddInstance(running)
+	h.nav.AddInstance(unstarted)
+
+	_, _ = h.handleQuitAndKill()
+	require.Equal(t, stateConfirm, h.state)
+	require.NotNil(t, h.pendingConfirmAction)
+
+	msg := h.pendingConfirmAction()
+	require.IsType(t, quitAndKillMsg{}, msg)
+	assert.Equal(t, session.Running, running.Status, "nothing is stopped off the update loop")
+
+	_, cmd := h.Update(msg)
+	require.NotNil(t, cmd)
+	assert.Equal(t, session.Running, running.Status, "sessions are stopped in the returned command")
+	var result quitAndKillResultMsg
+	batch, ok := cmd().(tea.BatchMsg)
+	require.True(t, ok)
+	for _, sub := range batch {
+		if rm, ok := sub().(quitAndKillResultMsg); ok {
+			result = rm
+		}
+	}
+	assert.Equal(t, quitAndKillResultMsg{stopped: 1}, result)
+	assert.Equal(t, session.Paused, running.Status, "kill path stops started sessions")
+	assert.Equal(t, session.Ready, unstarted.Status, "unstarted instances are left alone")
+
+	_, cmd = h.Update(quitAndKillResultMsg{stopped: 1})
+	require.NotNil(t, cmd)
+	assert.IsType(t, tea.QuitMsg{}, cmd())
+}
+
+func TestHandleQuit_PreservesSessions(t *testing.T) {
+	h := newTestHome()
+	h.toastManager = overlay.NewToastManager(&h.spinner)
+	running := &session.Instance{Title: "running-agent", Status: session.Running}
+	running.MarkStartedForTest()
+	h.nav.AddInstance(running)
+
+	_, _ = h.handleQuit()
+	require.NotNil(t, h.pendingConfirmAction)
+
+	msg := h.pendingConfirmAction()
+	assert.IsType(t, tea.QuitMsg{}, msg)
+	assert.Equal(t, session.Running, running.Status, "normal quit leaves sessions running")
+}
+
+func TestPauseAll_PausesActiveInstancesAndSkipsPaused(t *testing.T) {
+	h := newTestHome()
+	var running []*session.Instance
+	for _, title := range []string{"coder-a", "coder-b"} {
+		inst := newStartedInstanceWithMockTmux(t)
+		inst.Title = title
+		inst.SetStatus(session.Running)
+		running = append(running, inst)
+		h.nav.AddInstance(inst)
+	}
+	paused := newStartedInstanceWithMockTmux(t)
+	paused.Title = "already-paused"
+	paused.SetStatus(session.Paused)
+	unstarted := &session.Instance{Title: "unstarted", Status: session.Ready}
+	h.nav.AddInstance(paused)
+	h.nav.AddInstance(unstarted)
+
+	_, _ = h.confirmPauseAll(false)
+	require.Equal(t, stateConfirm, h.state, "pause all asks once")
+	require.NotNil(t, h.pendingConfirmAction)
+	msg, ok := h.pendingConfirmAction().(pauseAllMsg)
+	require.True(t, ok)
+	assert.False(t, msg.resume)
+
+	targets := h.pauseAllTargets(false)
+	assert.Equal(t, running, targets, "paused and unstarted instances are skipped")
+	done, failed := pauseAllInstances(targets, false)
+	assert.Equal(t, 2, done)
+	assert.Empty(t, failed)
+	for _, inst := range running {
+		assert.Equal(t, session.Paused, inst.Status, inst.Title)
+	}
+	assert.Equal(t, session.Ready, unstarted.Status, "unstarted instances are left alone")
+	assert.Empty(t, h.pauseAllTargets(false), "nothing is left running")
+	assert.ElementsMatch(t, []*session.Instance{running[0], running[1], paused}, h.pauseAllTargets(true))
+}
+
+func TestPauseAll_NothingToPause(t *testing.T) {
+	h := newTestHome()
+	_, _ = h.confirmPauseAll(false)
+	assert.Equal(t, stateDefault, h.state, "no confirmation when nothing would change")
+	assert.Nil(t, h.pendingConfirmAction)
+}
+
 // setupPlanState sets up an in-memory plan state on h for test use.
 // It creates a temp directory, registers the plan, seeds the status, and
 // refreshes the nav panel so SelectByID works immediately afterward.
@@ -1649,6 +1883,50 @@ func TestDeleteKey_AllowsRemovalOfExitedRunningInstance(t *testing.T) {
 		"delete should remove exited instance even if status is Running")
 }
 
+func TestFastAbort_RemovesExitedInstanceWithoutConfirm(t *testing.T) {
+	for _, msg := range []tea.KeyPressMsg{
+		{Code: tea.KeyDelete},
+		{Code: 'K', Text: "K"},
+	} {
+		t.Run(msg.String(), func(t *testing.T) {
+			h := newTestHome()
+			inst, err := newTestInstance("exited-coder")
+			require.NoError(t, err)
+			inst.Exited = true
+			inst.MarkStartedForTest()
+			_ = h.nav.AddInstance(inst)
+			h.nav.SelectInstance(inst)
+			h.allInstances = append(h.allInstances, inst)
+
+			h.keySent = true
+			_, cmd := h.handleKeyPress(msg)
+
+			assert.NotEqual(t, stateConfirm, h.state, "exited instances are removed without confirmation")
+			assert.Equal(t, 0, h.nav.TotalInstances())
+			assert.Empty(t, h.allInstances)
+			assert.Equal(t, 1, h.undo.len(), "the removal can be undone")
+			assert.NotNil(t, cmd, "worktree cleanup runs in the background")
+		})
+	}
+}
+
+func TestAbortKey_ConfirmsLiveInstance(t *testing.T) {
+	h := newTestHome()
+	inst, err := newTestInstance("live-coder")
+	require.NoError(t, err)
+	inst.Status = session.Running
+	inst.MarkStartedForTest()
+	_ = h.nav.AddInstance(inst)
+	h.nav.SelectInstance(inst)
+	h.allInstances = append(h.allInstances, inst)
+
+	h.keySent = true
+	_, _ = h.handleKeyPress(tea.KeyPressMsg{Code: 'K', Text: "K"})
+
+	assert.Equal(t, stateConfirm, h.state)
+	assert.Equal(t, 1, h.nav.TotalInstances())
+}
+
 func TestKillKey_NoopsOnExitedInstance(t *testing.T) {
 	h := newTestHome()
 	inst, err := newTestInstance("exited-reviewer")
@@ -1938,3 +2216,91 @@ func TestTaskContextMenu_ReadyStatus_StartGroupHasAllOptions(t *testing.T) {
 	assert.Contains(t, startActions, "start_solo", "start group must contain start_solo for ready status")
 	assert.Contains(t, startActions, "start_review", "start group must contain start_review for ready status")
 }
+
+func TestSaveSettings_WritesChangedFieldsAndAppliesThem(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	origNotify := session.NotificationsEnabled
+	t.Cleanup(func() { session.NotificationsEnabled = origNotify })
+
+	h := newTestHome()
+	h.program = "flag-program" // e.g. from -p; must not be persisted unchanged
+	_, _ = h.openSettings()
+	require.Equal(t, stateSettings, h.state)
+	require.IsType(t, &overlay.SettingsOverlay{}, h.overlays.Current())
+
+	after := h.settingsBefore
+	after.AnimateBanner = true
+	after.Notifications = false
+	_, _ = h.saveSettings(after)
+
+	assert.True(t, h.appConfig.AnimateBanner, "banner applies immediately")
+	assert.False(t, session.NotificationsEnabled, "notifications apply immediately")
+	saved := config.LoadSavedConfig()
+	assert.True(t, saved.AnimateBanner)
+	assert.False(t, saved.AreNotificationsEnabled())
+	assert.NotEqual(t, "flag-program", saved.DefaultProgram, "unchanged fields keep the file's value")
+}
+
+func TestSaveSettings_KeepsUnparseableConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("HOME", t.TempDir())
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".kasmos"), 0o755))
+	broken := []byte("default_program = \"aider\"\n[ui\n")
+	path := filepath.Join(dir, ".kasmos", config.TOMLConfigFileName)
+	require.NoError(t, os.WriteFile(path, broken, 0o644))
+
+	h := newTestHome()
+	_, _ = h.openSettings()
+	after := h.settingsBefore
+	after.AnimateBanner = !after.AnimateBanner
+	_, _ = h.saveSettings(after)
+
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, broken, got, "a config that fails to parse is never overwritten")
+	assert.Equal(t, h.settingsBefore.AnimateBanner, h.appConfig.AnimateBanner, "nothing is applied")
+}
+
+func TestToggleNavGrouping_PersistsMode(t *testing.T) {
+	h := newTestHome()
+	state := &mockAppState{}
+	h.appState = state
+
+	h.toggleNavGrouping()
+	assert.Equal(t, ui.NavGroupByAgentType, h.nav.Grouping())
+	assert.Equal(t, string(ui.NavGroupByAgentType), state.grouping)
+
+	h.toggleNavGrouping()
+	assert.Equal(t, ui.NavGroupByPlan, h.nav.Grouping())
+	assert.Empty(t, state.grouping)
+}
+
+func TestOfferRequeuePrompt_ConfirmQueuesLastPrompt(t *testing.T) {
+	h := newTestHome()
+	inst, err := newTestInstance("requeue-agent")
+	require.NoError(t, err)
+	inst.LastPrompt = "implement the auth middleware"
+	h.allInstances = append(h.allInstances, inst)
+
+	h.offerRequeuePrompt(inst)
+	require.Equal(t, stateConfirm, h.state, "a resumed instance with a last prompt offers to requeue it")
+	require.True(t, h.overlays.IsActive())
+	require.NotNil(t, h.pendingConfirmAction)
+
+	msg := h.pendingConfirmAction()
+	require.Equal(t, requeuePromptMsg{title: "requeue-agent"}, msg)
+	_, _ = h.Update(msg)
+	assert.Equal(t, "implement the auth middleware", inst.QueuedPrompt)
+}
+
+func TestOfferRequeuePrompt_NoLastPromptDoesNothing(t *testing.T) {
+	h := newTestHome()
+	inst, err := newTestInstance("fresh-agent")
+	require.NoError(t, err)
+
+	h.offerRequeuePrompt(inst)
+	assert.Equal(t, stateDefault, h.state)
+	assert.False(t, h.overlays.IsActive())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
 	"time"
@@ -17,6 +18,7 @@ import (
 	"github.com/kastheco/kasmos/config/taskstore"
 	"github.com/kastheco/kasmos/orchestration"
 	"github.com/kastheco/kasmos/session"
+	gitpkg "github.com/kastheco/kasmos/session/git"
 	"github.com/kastheco/kasmos/ui"
 	"github.com/kastheco/kasmos/ui/overlay"
 	"github.com/stretchr/testify/assert"
@@ -814,6 +816,186 @@ func TestRetryFailedWaveTasks_RemovesOldInstances(t *testing.T) {
 	assert.True(t, foundTask1, "task 1 instance must not be affected by task 6 retry")
 }
 
+// TestRetryWaveTask_RetriesOnlyTargetedTask verifies that retrying a single
+// failed task removes only that task's stale instance and leaves the other
+// failed task failed, with its instance in place.
+func TestRetryWaveTask_RetriesOnlyTargetedTask(t *testing.T) {
+	const planFile = "retry-one"
+
+	plan := &taskparser.Plan{
+		Waves: []taskparser.Wave{
+			{Number: 1, Tasks: []taskparser.Task{
+				{Number: 2, Title: "Task 2", Body: "flaky"},
+				{Number: 3, Title: "Task 3", Body: "also flaky"},
+			}},
+		},
+	}
+	orch := orchestration.NewWaveOrchestrator(planFile, plan)
+	orch.StartNextWave()
+	orch.MarkTaskFailed(2)
+	orch.MarkTaskFailed(3)
+
+	dir := t.TempDir()
+	t.Cleanup(func() { os.RemoveAll(filepath.Join(dir, ".worktrees")) })
+	plansDir := filepath.Join(dir, "docs", "plans")
+	require.NoError(t, os.MkdirAll(plansDir, 0o755))
+	ps, err := newTestPlanState(t, plansDir)
+	require.NoError(t, err)
+	require.NoError(t, ps.Register(planFile, "retry one test", "plan/retry-one", time.Now()))
+	seedPlanStatus(t, ps, planFile, taskstate.StatusImplementing)
+
+	h := waveFlowHome(t, ps, plansDir, map[string]*orchestration.WaveOrchestrator{planFile: orch})
+	storage, err := session.NewStorage(config.DefaultState())
+	require.NoError(t, err)
+	h.storage = storage
+	h.activeRepoPath = dir
+	h.program = "claude"
+	var failed []*session.Instance
+	for _, n := range []int{2, 3} {
+		inst, err := session.NewInstance(session.InstanceOptions{
+			Title:      fmt.Sprintf("%s-W1-T%d", taskstate.DisplayName(planFile), n),
+			Path:       t.TempDir(),
+			Program:    "claude",
+			TaskFile:   planFile,
+			TaskNumber: n,
+			WaveNumber: 1,
+		})
+		require.NoError(t, err)
+		inst.SetStatus(session.Paused)
+		failed = append(failed, inst)
+		h.allInstances = append(h.allInstances, inst)
+		_ = h.nav.AddInstance(inst)
+	}
+
+	entry, _ := ps.Entry(planFile)
+	h.retryWaveTask(orch, entry, 2)
+
+	assert.False(t, orch.IsTaskFailed(2), "task 2 is requeued")
+	assert.True(t, orch.IsTaskFailed(3), "task 3 stays failed")
+	assert.NotContains(t, h.nav.GetInstances(), failed[0], "task 2's stale instance is removed")
+	assert.Contains(t, h.nav.GetInstances(), failed[1], "task 3's instance is untouched")
+}
+
+// TestWaveMonitor_SpawnsQueuedTaskWhenSlotFrees verifies that when a wave has more
+// tasks than the concurrency cap, the metadata tick dequeues the next pending task
+// as soon as a running task completes.
+func TestWaveMonitor_SpawnsQueuedTaskWhenSlotFrees(t *testing.T) {
+	const planFile = "queued-tasks"
+
+	plan := &taskparser.Plan{
+		Waves: []taskparser.Wave{
+			{Number: 1, Tasks: []taskparser.Task{
+				{Number: 1, Title: "Task 1", Body: "do first"},
+				{Number: 2, Title: "Task 2", Body: "do second"},
+				{Number: 3, Title: "Task 3", Body: "do third"},
+			}},
+		},
+	}
+	orch := orchestration.NewWaveOrchestrator(planFile, plan)
+	orch.MaxConcurrentTasks = 2
+	require.Len(t, orch.StartNextWave(), 2)
+	require.Equal(t, 1, orch.QueuedTaskCount())
+	orch.MarkTaskComplete(1)
+
+	dir := t.TempDir()
+	// spawnWaveTasks → Setup() creates .worktrees/ inside dir before failing
+	// (no real git repo). Force-remove it so t.TempDir cleanup doesn't fail.
+	t.Cleanup(func() { os.RemoveAll(filepath.Join(dir, ".worktrees")) })
+	plansDir := filepath.Join(dir, "docs", "plans")
+	require.NoError(t, os.MkdirAll(plansDir, 0o755))
+	ps, err := newTestPlanState(t, plansDir)
+	require.NoError(t, err)
+	require.NoError(t, ps.Register(planFile, "queued tasks test", "plan/queued-tasks", time.Now()))
+	seedPlanStatus(t, ps, planFile, taskstate.StatusImplementing)
+
+	planName := taskstate.DisplayName(planFile)
+	inst2, err := session.NewInstance(session.InstanceOptions{
+		Title:      planName + "-W1-T2",
+		Path:       t.TempDir(),
+		Program:    "claude",
+		TaskFile:   planFile,
+		TaskNumber: 2,
+		WaveNumber: 1,
+	})
+	require.NoError(t, err)
+	inst2.SetStatus(session.Loading) // still spawning — must not be marked failed
+
+	concurrency := 2
+	h := waveFlowHome(t, ps, plansDir, map[string]*orchestration.WaveOrchestrator{planFile: orch})
+	h.appConfig.MaxWaveConcurrencyValue = &concurrency
+	h.activeRepoPath = dir
+	_ = h.nav.AddInstance(inst2)
+
+	msg := metadataResultMsg{
+		Results:   []instanceMetadata{},
+		PlanState: ps,
+	}
+	_, _ = h.Update(msg)
+
+	assert.True(t, orch.IsTaskRunning(3), "queued task 3 must be dequeued once task 1 frees a slot")
+	assert.Equal(t, 0, orch.QueuedTaskCount())
+	assert.Equal(t, orchestration.WaveStateRunning, orch.State())
+}
+
+// TestWaveMonitor_TimedOutTaskFailsWave verifies that a task running longer than the
+// wave task timeout is marked failed even though its tmux session is still alive, and
+// that the failed-wave dialog offers retrying just the timed-out task.
+func TestWaveMonitor_TimedOutTaskFailsWave(t *testing.T) {
+	const planFile = "hung-task"
+
+	plan := &taskparser.Plan{
+		Waves: []taskparser.Wave{
+			{Number: 1, Tasks: []taskparser.Task{{Number: 1, Title: "Task 1", Body: "do it"}}},
+			{Number: 2, Tasks: []taskparser.Task{{Number: 2, Title: "Task 2", Body: "follow up"}}},
+		},
+	}
+	orch := orchestration.NewWaveOrchestrator(planFile, plan)
+	orch.TaskTimeout = time.Nanosecond // any elapsed time counts as hung
+	orch.StartNextWave()
+	time.Sleep(time.Millisecond)
+
+	dir := t.TempDir()
+	plansDir := filepath.Join(dir, "docs", "plans")
+	require.NoError(t, os.MkdirAll(plansDir, 0o755))
+	ps, err := newTestPlanState(t, plansDir)
+	require.NoError(t, err)
+	require.NoError(t, ps.Register(planFile, "hung task test", "plan/hung-task", time.Now()))
+	seedPlanStatus(t, ps, planFile, taskstate.StatusImplementing)
+
+	inst, err := session.NewInstance(session.InstanceOptions{
+		Title:      taskstate.DisplayName(planFile) + "-W1-T1",
+		Path:       t.TempDir(),
+		Program:    "claude",
+		TaskFile:   planFile,
+		TaskNumber: 1,
+		WaveNumber: 1,
+	})
+	require.NoError(t, err)
+	inst.SetStatus(session.Running)
+
+	h := waveFlowHome(t, ps, plansDir, map[string]*orchestration.WaveOrchestrator{planFile: orch})
+	_ = h.nav.AddInstance(inst)
+
+	msg := metadataResultMsg{
+		Results:   []instanceMetadata{{Title: inst.Title, TmuxAlive: true}},
+		PlanState: ps,
+	}
+	model, _ := h.Update(msg)
+	updated := model.(*home)
+
+	assert.True(t, orch.IsTaskTimedOut(1), "hung task must be marked timed out")
+	assert.Equal(t, stateConfirm, updated.state, "timed-out task must trigger the failed-wave prompt")
+	co, ok := updated.overlays.Current().(*overlay.ConfirmationOverlay)
+	require.True(t, ok, "current overlay must be a ConfirmationOverlay")
+	assert.Equal(t, "r", co.ConfirmKey)
+	require.NotNil(t, updated.pendingWaveRetryTimedOutAction, "'t' must retry only timed-out tasks")
+
+	retryMsg, ok := updated.pendingWaveRetryTimedOutAction().(waveRetryMsg)
+	require.True(t, ok)
+	assert.True(t, retryMsg.timedOutOnly)
+	assert.Equal(t, planFile, retryMsg.planFile)
+}
+
 // TestWaveSignal_TriggersImplementation verifies that a wave signal file written
 // in .signals/ is correctly picked up by ScanWaveSignals and parsed into a
 // WaveSignal with the correct WaveNumber and PlanFile fields, ready for TUI consumption.
@@ -1735,3 +1917,204 @@ func TestCoderExit_FocusesCoderInstance_BeforePushConfirm(t *testing.T) {
 	assert.Equal(t, coderInst, updated.nav.GetSelectedInstance(),
 		"coder-exit overlay should auto-focus the coder instance")
 }
+
+// TestRebuildOrphanedOrchestrators_PrefersWaveStateSnapshot verifies restart
+// recovery uses the persisted wave-state snapshot instead of inferring task
+// status from instance state, so failed tasks are not revived as running.
+func TestRebuildOrphanedOrchestrators_PrefersWaveStateSnapshot(t *testing.T) {
+	const planFile = "snapshot-wave"
+
+	dir := t.TempDir()
+	plansDir := filepath.Join(dir, "docs", "plans")
+	require.NoError(t, os.MkdirAll(plansDir, 0o755))
+
+	store := taskstore.NewTestSQLiteStore(t)
+	content := "**Goal:** snapshot test\n\n## Wave 1\n\n### Task 1: First\n\nDo first.\n\n### Task 2: Second\n\nDo second.\n"
+	require.NoError(t, store.Create("proj", taskstore.TaskEntry{
+		Filename: planFile,
+		Status:   taskstore.StatusReady,
+		Branch:   "plan/snapshot-wave",
+		Content:  content,
+	}))
+
+	ps, err := taskstate.Load(store, "proj", plansDir)
+	require.NoError(t, err)
+	seedPlanStatus(t, ps, planFile, taskstate.StatusImplementing)
+
+	h := waveFlowHome(t, ps, plansDir, make(map[string]*orchestration.WaveOrchestrator))
+	h.taskStore = store
+	h.taskStoreProject = "proj"
+
+	plan, err := taskparser.Parse(content)
+	require.NoError(t, err)
+	before := h.newWaveOrchestrator(planFile, plan)
+	before.StartNextWave()
+	before.MarkTaskFailed(2)
+
+	inst, err := session.NewInstance(session.InstanceOptions{
+		Title:      "snapshot-wave-W1-T1",
+		Path:       dir,
+		Program:    "opencode",
+		TaskFile:   planFile,
+		TaskNumber: 1,
+		WaveNumber: 1,
+	})
+	require.NoError(t, err)
+	inst.MarkStartedForTest()
+	h.nav.AddInstance(inst)
+
+	h.rebuildOrphanedOrchestrators()
+	orch, exists := h.waveOrchestrators[planFile]
+	require.True(t, exists)
+	assert.True(t, orch.IsTaskRunning(1))
+	assert.True(t, orch.IsTaskFailed(2), "failed task must be restored from the snapshot")
+
+	_, _ = h.Update(waveAbortMsg{planFile: planFile})
+	_, err = os.Stat(filepath.Join(plansDir, ".waves", planFile+".json"))
+	assert.True(t, os.IsNotExist(err), "aborting the wave must remove its snapshot")
+}
+
+// TestMarkTaskComplete_AdvancesWave verifies that the "mark task complete" context
+// action resolves a still-running task, pauses its instance, and lets the wave
+// complete even though auto-detection never fired.
+func TestMarkTaskComplete_AdvancesWave(t *testing.T) {
+	const planFile = "mark-complete"
+
+	plan := &taskparser.Plan{
+		Waves: []taskparser.Wave{
+			{Number: 1, Tasks: []taskparser.Task{
+				{Number: 1, Title: "Task 1", Body: "done"},
+				{Number: 2, Title: "Task 2", Body: "stuck"},
+			}},
+			{Number: 2, Tasks: []taskparser.Task{
+				{Number: 3, Title: "Task 3", Body: "next"},
+			}},
+		},
+	}
+	orch := orchestration.NewWaveOrchestrator(planFile, plan)
+	orch.StartNextWave()
+	orch.MarkTaskComplete(1)
+
+	plansDir := filepath.Join(t.TempDir(), "docs", "plans")
+	require.NoError(t, os.MkdirAll(plansDir, 0o755))
+	ps, err := newTestPlanState(t, plansDir)
+	require.NoError(t, err)
+	require.NoError(t, ps.Register(planFile, "mark complete test", "plan/mark-complete", time.Now()))
+	seedPlanStatus(t, ps, planFile, taskstate.StatusImplementing)
+
+	h := waveFlowHome(t, ps, plansDir, map[string]*orchestration.WaveOrchestrator{planFile: orch})
+	inst := newStartedInstanceWithMockTmux(t)
+	inst.TaskFile = planFile
+	inst.TaskNumber = 2
+	inst.WaveNumber = 1
+	inst.SetStatus(session.Running)
+	_ = h.nav.AddInstance(inst)
+	h.updateSidebarTasks()
+	require.True(t, h.nav.SelectInstance(inst))
+	require.True(t, orch.IsTaskRunning(2))
+
+	_, _ = h.executeContextAction("mark_task_complete")
+
+	assert.False(t, orch.IsTaskRunning(2))
+	assert.Equal(t, orchestration.WaveStateWaveComplete, orch.State())
+	assert.True(t, inst.ImplementationComplete)
+	assert.True(t, inst.Paused(), "marked task instance must be paused")
+}
+
+// TestWaveFailedMessage_UncommittedWorkSurvivesAbort verifies that the
+// failed-wave dialog flags uncommitted changes in the plan worktree, and that
+// an abort leaves them there as the dialog says.
+func TestWaveFailedMessage_UncommittedWorkSurvivesAbort(t *testing.T) {
+	const planFile = "dirty-abort"
+
+	repo := t.TempDir()
+	for _, cmd := range [][]string{
+		{"git", "init", repo},
+		{"git", "-C", repo, "config", "user.email", "test@test.com"},
+		{"git", "-C", repo, "config", "user.name", "Test"},
+		{"git", "-C", repo, "commit", "--allow-empty", "-m", "init"},
+	} {
+		out, err := exec.Command(cmd[0], cmd[1:]...).CombinedOutput()
+		if err != nil {
+			t.Skipf("git setup failed (%v): %s", err, out)
+		}
+	}
+	branch := "plan/" + planFile
+	require.NoError(t, gitpkg.EnsureTaskBranch(repo, branch))
+	shared := gitpkg.NewSharedTaskWorktree(repo, branch)
+	require.NoError(t, shared.Setup())
+	t.Cleanup(func() { _ = shared.Cleanup() })
+
+	h := newTestHome()
+	for n := 1; n <= 3; n++ {
+		inst, err := session.FromInstanceData(session.InstanceData{
+			Title:      fmt.Sprintf("%s-W1-T%d", planFile, n),
+			Path:       repo,
+			Status:     session.Paused,
+			Program:    "claude",
+			TaskFile:   planFile,
+			TaskNumber: n,
+			WaveNumber: 1,
+			Worktree:   session.GitWorktreeData{RepoPath: repo, WorktreePath: shared.GetWorktreePath(), BranchName: branch},
+		})
+		require.NoError(t, err)
+		h.allInstances = append(h.allInstances, inst)
+		h.nav.AddInstance(inst)
+	}
+
+	assert.False(t, h.planWorktreeDirty(planFile))
+	assert.NotContains(t, waveFailedMessage(planFile, 1, 0, 3, 3, 0, false), "uncommitted")
+
+	wip := filepath.Join(shared.GetWorktreePath(), "wip.go")
+	require.NoError(t, os.WriteFile(wip, []byte("package wip\n"), 0o644))
+	require.True(t, h.planWorktreeDirty(planFile))
+	assert.False(t, h.planWorktreeDirty("other-plan"))
+	msg := waveFailedMessage(planFile, 1, 0, 3, 3, 0, h.planWorktreeDirty(planFile))
+	assert.Contains(t, msg, "the plan worktree has uncommitted changes; abort stops the tasks and leaves them there")
+
+	_, _ = h.Update(waveAbortMsg{planFile: planFile})
+	assert.Empty(t, h.allInstances, "abort removes the task instances")
+	assert.FileExists(t, wip, "abort leaves the uncommitted work in the plan worktree")
+}
+
+// TestStartNextWave_CapsTasksToInstanceLimit verifies that a wave only marks as
+// many tasks running as the instance limit has room for, queueing the rest,
+// and that it does not start at all when there is no room.
+func TestStartNextWave_CapsTasksToInstanceLimit(t *testing.T) {
+	const planFile = "capped-wave"
+
+	plan := &taskparser.Plan{
+		Waves: []taskparser.Wave{
+			{Number: 1, Tasks: []taskparser.Task{
+				{Number: 1, Title: "Task 1", Body: "do first"},
+				{Number: 2, Title: "Task 2", Body: "do second"},
+				{Number: 3, Title: "Task 3", Body: "do third"},
+			}},
+		},
+	}
+
+	dir := t.TempDir()
+	t.Cleanup(func() { os.RemoveAll(filepath.Join(dir, ".worktrees")) })
+	plansDir := filepath.Join(dir, "docs", "plans")
+	require.NoError(t, os.MkdirAll(plansDir, 0o755))
+	ps, err := newTestPlanState(t, plansDir)
+	require.NoError(t, err)
+	require.NoError(t, ps.Register(planFile, "capped wave test", "plan/capped-wave", time.Now()))
+	seedPlanStatus(t, ps, planFile, taskstate.StatusImplementing)
+	entry, _ := ps.Entry(planFile)
+
+	orch := orchestration.NewWaveOrchestrator(planFile, plan)
+	h := waveFlowHome(t, ps, plansDir, map[string]*orchestration.WaveOrchestrator{planFile: orch})
+	h.activeRepoPath = dir
+	h.tmuxSessionCount = h.appConfig.InstanceLimit() - 1
+
+	h.startNextWave(orch, entry)
+	assert.True(t, orch.IsTaskRunning(1), "the one free slot starts a task")
+	assert.Equal(t, 2, orch.QueuedTaskCount(), "the rest wait in the queue")
+
+	full := orchestration.NewWaveOrchestrator(planFile, plan)
+	h.tmuxSessionCount = h.appConfig.InstanceLimit()
+	h.startNextWave(full, entry)
+	assert.NotEqual(t, orchestration.WaveStateRunning, full.State(), "no room leaves the wave unstarted")
+	assert.False(t, full.IsTaskRunning(1))
+}
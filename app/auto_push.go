@@ -0,0 +1,126 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/kastheco/kasmos/config/auditlog"
+	"github.com/kastheco/kasmos/session"
+)
+
+// autoPushInterval is the minimum time between two auto-pushes of the same
+// instance. Commits landing inside the window are pushed together once it ends.
+const autoPushInterval = time.Minute
+
+// autoPushTracker detects new commits on coder worktrees from the HEAD SHAs
+// reported by the metadata tick, and debounces the resulting pushes.
+type autoPushTracker struct {
+	heads    map[string]string    // instance title → last seen HEAD
+	pending  map[string]bool      // instance title → commits not yet pushed
+	lastPush map[string]time.Time // instance title → last auto-push
+}
+
+// observe records head as the instance's current HEAD and reports whether a
+// push is due at now. The first observation only sets the baseline; a changed
+// HEAD marks new commits pending, and pending commits are pushed at most once
+// per autoPushInterval. Commits stay pending until pushed reports success, so
+// a failed push is retried once the interval ends.
+func (t *autoPushTracker) observe(title, head string, now time.Time) bool {
+	if t.heads == nil {
+		t.heads = make(map[string]string)
+		t.pending = make(map[string]bool)
+		t.lastPush = make(map[string]time.Time)
+	}
+	prev, seen := t.heads[title]
+	t.heads[title] = head
+	if seen && prev != head {
+		t.pending[title] = true
+	}
+	if !t.pending[title] || now.Sub(t.lastPush[title]) < autoPushInterval {
+		return false
+	}
+	t.lastPush[title] = now
+	return true
+}
+
+// pushed records a successful push of head. Commits that landed after the
+// push started stay pending.
+func (t *autoPushTracker) pushed(title, head string) {
+	if t.heads[title] == head {
+		delete(t.pending, title)
+	}
+}
+
+// forget drops everything tracked for the instance, so the next observation
+// sets a fresh baseline.
+func (t *autoPushTracker) forget(title string) {
+	delete(t.heads, title)
+	delete(t.pending, title)
+	delete(t.lastPush, title)
+}
+
+// autoPushResultMsg reports the outcome of a background auto-push.
+type autoPushResultMsg struct {
+	title    string
+	branch   string
+	planFile string
+	head     string
+	err      error
+}
+
+// autoPushEnabled reports whether inst's branch is auto-pushed: the plan's
+// override when set, otherwise the global auto_push setting.
+func (m *home) autoPushEnabled(inst *session.Instance) bool {
+	def := m.appConfig != nil && m.appConfig.AutoPush
+	if inst.TaskFile == "" || m.taskState == nil {
+		return def
+	}
+	return m.taskState.AutoPushEnabled(inst.TaskFile, def)
+}
+
+// maybeAutoPush feeds the instance's HEAD to the tracker and returns a Cmd
+// pushing its branch when new commits are due, or nil.
+func (m *home) maybeAutoPush(inst *session.Instance, head string, now time.Time) tea.Cmd {
+	if !m.autoPushEnabled(inst) {
+		m.autoPush.forget(inst.Title)
+		return nil
+	}
+	if !m.autoPush.observe(inst.Title, head, now) {
+		return nil
+	}
+	push := m.autoPusher
+	if push == nil {
+		// Push only what the agent committed: committing the worktree here
+		// would snapshot half-done edits and move HEAD, re-arming the tracker.
+		push = func(inst *session.Instance) error {
+			worktree, err := inst.GetGitWorktree()
+			if err != nil {
+				return err
+			}
+			return worktree.Push(false)
+		}
+	}
+	title, branch, planFile := inst.Title, inst.Branch, inst.TaskFile
+	return func() tea.Msg {
+		return autoPushResultMsg{title: title, branch: branch, planFile: planFile, head: head, err: push(inst)}
+	}
+}
+
+// handleAutoPushResult audits a finished auto-push and reports failures.
+func (m *home) handleAutoPushResult(msg autoPushResultMsg) tea.Cmd {
+	if msg.err != nil {
+		m.audit(auditlog.EventError, fmt.Sprintf("auto-push of %s failed: %v", msg.branch, msg.err),
+			auditlog.WithInstance(msg.title),
+			auditlog.WithPlan(msg.planFile),
+		)
+		m.toastManager.Error(fmt.Sprintf("auto-push for '%s' failed: %v", msg.title, msg.err))
+		return m.toastTickCmd()
+	}
+	m.autoPush.pushed(msg.title, msg.head)
+	m.audit(auditlog.EventGitPush, fmt.Sprintf("auto-pushed branch %s", msg.branch),
+		auditlog.WithInstance(msg.title),
+		auditlog.WithPlan(msg.planFile),
+	)
+	return nil
+}
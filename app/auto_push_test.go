@@ -0,0 +1,76 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kastheco/kasmos/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoPushTracker_DetectsNewCommitsAndDebounces(t *testing.T) {
+	var tr autoPushTracker
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.False(t, tr.observe("coder", "a1", start), "first sighting only sets the baseline")
+	assert.False(t, tr.observe("coder", "a1", start.Add(time.Second)), "unchanged HEAD")
+	assert.True(t, tr.observe("coder", "b2", start.Add(2*time.Second)), "new commit pushes")
+	tr.pushed("coder", "b2")
+
+	// A burst of commits inside the interval waits for it to end.
+	assert.False(t, tr.observe("coder", "c3", start.Add(3*time.Second)))
+	assert.False(t, tr.observe("coder", "d4", start.Add(4*time.Second)))
+	assert.True(t, tr.observe("coder", "d4", start.Add(2*time.Second+autoPushInterval)), "pending commits push once the interval ends")
+	tr.pushed("coder", "d4")
+	assert.False(t, tr.observe("coder", "d4", start.Add(2*time.Second+3*autoPushInterval)), "nothing left to push")
+
+	assert.False(t, tr.observe("other", "x", start), "instances are tracked independently")
+
+	assert.True(t, tr.observe("other", "y", start.Add(time.Second)))
+	assert.True(t, tr.observe("other", "y", start.Add(time.Second+autoPushInterval)), "a failed push is retried after the interval")
+	tr.pushed("other", "y")
+	assert.False(t, tr.observe("other", "y", start.Add(time.Second+2*autoPushInterval)))
+
+	assert.True(t, tr.observe("other", "z", start.Add(time.Second+4*autoPushInterval)))
+	tr.observe("other", "w", start.Add(time.Second+4*autoPushInterval+time.Second))
+	tr.pushed("other", "z")
+	assert.True(t, tr.observe("other", "w", start.Add(time.Second+5*autoPushInterval)), "commits landing mid-push stay pending")
+
+	tr.forget("coder")
+	assert.False(t, tr.observe("coder", "e5", start.Add(10*autoPushInterval)), "forgetting resets the baseline")
+}
+
+func TestMaybeAutoPush_FollowsPlanOverride(t *testing.T) {
+	h := newTestHome()
+	ps, err := newTestPlanState(t, t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, ps.Create("plan.md", "", "plan/plan", "", time.Now()))
+	h.taskState = ps
+	var pushed []string
+	h.autoPusher = func(inst *session.Instance) error {
+		pushed = append(pushed, inst.Title)
+		return nil
+	}
+	inst := &session.Instance{Title: "coder", TaskFile: "plan.md", Branch: "plan/plan"}
+	now := time.Now()
+
+	assert.Nil(t, h.maybeAutoPush(inst, "a1", now), "auto-push is off by default")
+	assert.Nil(t, h.maybeAutoPush(inst, "b2", now))
+
+	on := true
+	require.NoError(t, h.taskState.SetAutoPush("plan.md", &on))
+	assert.Nil(t, h.maybeAutoPush(inst, "b2", now), "enabling sets a fresh baseline")
+	cmd := h.maybeAutoPush(inst, "c3", now)
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(autoPushResultMsg)
+	require.True(t, ok)
+	assert.NoError(t, msg.err)
+	assert.Equal(t, "c3", msg.head)
+	assert.Equal(t, []string{"coder"}, pushed)
+
+	h.appConfig.AutoPush = true
+	off := false
+	require.NoError(t, h.taskState.SetAutoPush("plan.md", &off))
+	assert.Nil(t, h.maybeAutoPush(inst, "d4", now.Add(2*autoPushInterval)), "the plan override beats the global setting")
+}
@@ -1,11 +1,15 @@
 package app
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	tea "charm.land/bubbletea/v2"
 	"github.com/kastheco/kasmos/internal/clickup"
+	"github.com/kastheco/kasmos/internal/mcpclient"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -58,3 +62,48 @@ func TestScaffoldFilename_Dedup(t *testing.T) {
 	require.NoError(t, os.WriteFile(filepath.Join(dir, name2), []byte("x"), 0o644))
 	assert.Equal(t, "test-task-3", dedupePlanFilename(dir, base))
 }
+
+// blockingMCPStub holds every tool call until release is closed.
+type blockingMCPStub struct {
+	release chan struct{}
+}
+
+func (s *blockingMCPStub) CallTool(string, map[string]interface{}) (*mcpclient.ToolResult, error) {
+	<-s.release
+	return nil, errors.New("released")
+}
+
+func (s *blockingMCPStub) FindTool(sub string) (mcpclient.Tool, bool) {
+	return mcpclient.Tool{Name: "clickup_" + sub}, true
+}
+
+func TestClickUpFetching_EscCancelsInFlightSearch(t *testing.T) {
+	h := newTestHomeWithToast()
+	stub := &blockingMCPStub{release: make(chan struct{})}
+	defer close(stub.release)
+	h.clickUpImporter = clickup.NewImporter(stub)
+	h.clickUpImporter.SetWorkspaceID("ws")
+	h.state = stateClickUpFetching
+	cmd := h.searchClickUp("login bug")
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+
+	h.keySent = true
+	_, _ = h.handleKeyPress(tea.KeyPressMsg{Code: tea.KeyEscape})
+	assert.Equal(t, stateDefault, h.state)
+	assert.Nil(t, h.clickUpCancel)
+
+	var msg tea.Msg
+	select {
+	case msg = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("search did not return after cancel")
+	}
+	res, ok := msg.(clickUpSearchResultMsg)
+	require.True(t, ok)
+	assert.ErrorIs(t, res.Err, errClickUpCanceled)
+	assert.Nil(t, h.clickUpImporter, "an aborted importer is re-created on the next search")
+
+	_, _ = h.Update(res)
+	assert.Equal(t, stateDefault, h.state, "the aborted result is dropped")
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kastheco/kasmos/config/auditlog"
+	"github.com/kastheco/kasmos/config/taskfsm"
+	"github.com/kastheco/kasmos/internal/clickup"
+)
+
+// clickUpStatusHook mirrors plan status transitions onto the linked ClickUp
+// task using config.Config.ClickUpStatusMap. It is best-effort: a failed
+// update is recorded in the audit log and never blocks the transition.
+type clickUpStatusHook struct {
+	m *home
+}
+
+func (h *clickUpStatusHook) Name() string { return "clickup-status" }
+
+func (h *clickUpStatusHook) Run(ctx context.Context, ev taskfsm.TransitionEvent) error {
+	m := h.m
+	status := m.appConfig.ClickUpStatusFor(string(ev.ToStatus))
+	if status == "" || m.taskStore == nil {
+		return nil
+	}
+	if m.clickUpImporter == nil && m.clickUpConfig == nil {
+		return nil
+	}
+
+	entry, err := m.taskStore.Get(m.taskStoreProject, ev.PlanFile)
+	if err != nil {
+		return nil
+	}
+	taskID := entry.ClickUpTaskID
+	if taskID == "" {
+		content, _ := m.taskStore.GetContent(m.taskStoreProject, ev.PlanFile)
+		taskID = clickup.ParseClickUpTaskID(content)
+	}
+	if taskID == "" {
+		return nil
+	}
+
+	importer, err := m.getOrCreateImporter(ctx)
+	if err == nil {
+		err = importer.UpdateTaskStatus(taskID, status)
+	}
+	if err != nil && m.auditLogger != nil {
+		m.auditLogger.Emit(auditlog.Event{
+			Kind:     auditlog.EventError,
+			Project:  m.taskStoreProject,
+			TaskFile: ev.PlanFile,
+			Message:  fmt.Sprintf("clickup status update to %q failed: %v", status, err),
+		})
+	}
+	return err
+}
+
+// clickUpStatusHooks returns a hook registry holding only the ClickUp status
+// hook, or nil when no statuses are mapped.
+func (m *home) clickUpStatusHooks() *taskfsm.HookRegistry {
+	if m.appConfig == nil || len(m.appConfig.ClickUpStatusMap) == 0 {
+		return nil
+	}
+	reg := taskfsm.NewHookRegistry()
+	reg.Add(&clickUpStatusHook{m: m}, nil)
+	return reg
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kastheco/kasmos/config"
+	"github.com/kastheco/kasmos/config/taskfsm"
+	"github.com/kastheco/kasmos/config/taskstore"
+	"github.com/kastheco/kasmos/internal/clickup"
+	"github.com/kastheco/kasmos/internal/mcpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// statusMCPStub records clickup_update_task calls.
+type statusMCPStub struct {
+	calls []map[string]interface{}
+	err   error
+}
+
+func (s *statusMCPStub) CallTool(name string, args map[string]interface{}) (*mcpclient.ToolResult, error) {
+	s.calls = append(s.calls, args)
+	return &mcpclient.ToolResult{}, s.err
+}
+
+func (s *statusMCPStub) FindTool(sub string) (mcpclient.Tool, bool) {
+	return mcpclient.Tool{Name: "clickup_update_task"}, true
+}
+
+func newClickUpStatusTestHome(t *testing.T, stub *statusMCPStub) *home {
+	t.Helper()
+	store := taskstore.NewTestSQLiteStore(t)
+	require.NoError(t, store.Create("test", taskstore.TaskEntry{
+		Filename: "linked.md", Status: "implementing", ClickUpTaskID: "CU-abc1",
+	}))
+	require.NoError(t, store.Create("test", taskstore.TaskEntry{
+		Filename: "local.md", Status: "implementing",
+	}))
+	return &home{
+		ctx: context.Background(),
+		appConfig: &config.Config{ClickUpStatusMap: map[string]string{
+			"reviewing": "in review",
+			"done":      "complete",
+		}},
+		clickUpImporter:  clickup.NewImporter(stub),
+		taskStore:        store,
+		taskStoreProject: "test",
+	}
+}
+
+func TestClickUpStatusHook_PushesMappedStatus(t *testing.T) {
+	stub := &statusMCPStub{}
+	h := newClickUpStatusTestHome(t, stub)
+	hook := &clickUpStatusHook{m: h}
+
+	require.NoError(t, hook.Run(context.Background(), taskfsm.TransitionEvent{
+		PlanFile: "linked.md", ToStatus: taskfsm.StatusReviewing,
+	}))
+	require.Len(t, stub.calls, 1)
+	assert.Equal(t, "abc1", stub.calls[0]["task_id"])
+	assert.Equal(t, "in review", stub.calls[0]["status"])
+
+	// Unmapped statuses and plans without a ClickUp task are left alone.
+	require.NoError(t, hook.Run(context.Background(), taskfsm.TransitionEvent{
+		PlanFile: "linked.md", ToStatus: taskfsm.StatusImplementing,
+	}))
+	require.NoError(t, hook.Run(context.Background(), taskfsm.TransitionEvent{
+		PlanFile: "local.md", ToStatus: taskfsm.StatusDone,
+	}))
+	assert.Len(t, stub.calls, 1)
+}
+
+func TestClickUpStatusHook_FailureReturnsError(t *testing.T) {
+	stub := &statusMCPStub{err: errors.New("boom")}
+	h := newClickUpStatusTestHome(t, stub)
+
+	err := (&clickUpStatusHook{m: h}).Run(context.Background(), taskfsm.TransitionEvent{
+		PlanFile: "linked.md", ToStatus: taskfsm.StatusDone,
+	})
+	assert.ErrorContains(t, err, "boom")
+}
+
+func TestClickUpStatusHooks_NilWithoutStatusMap(t *testing.T) {
+	h := &home{appConfig: &config.Config{}}
+	assert.Nil(t, h.clickUpStatusHooks())
+
+	h.appConfig.ClickUpStatusMap = map[string]string{"done": "complete"}
+	assert.Equal(t, 1, h.clickUpStatusHooks().Len())
+}
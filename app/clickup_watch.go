@@ -0,0 +1,145 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kastheco/kasmos/config/auditlog"
+	"github.com/kastheco/kasmos/internal/clickup"
+	"github.com/kastheco/kasmos/log"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// clickUpWatchTickMsg triggers a ClickUp watch poll.
+type clickUpWatchTickMsg struct{}
+
+// clickUpWatchResultMsg carries the tagged ClickUp tasks a watch poll found
+// that are not yet linked to a plan.
+type clickUpWatchResultMsg struct {
+	Tasks []*clickup.Task
+	Err   error
+}
+
+// clickUpWatchTag returns the configured ClickUp watch tag, or "" when the
+// watch is disabled or no ClickUp MCP server was detected.
+func (m *home) clickUpWatchTag() string {
+	if m.appConfig == nil || m.clickUpConfig == nil {
+		return ""
+	}
+	return strings.TrimSpace(m.appConfig.ClickUpWatchTag)
+}
+
+// scheduleClickUpWatch schedules the next watch poll after the configured
+// interval. Returns nil when the watch is disabled.
+func (m *home) scheduleClickUpWatch() tea.Cmd {
+	if m.clickUpWatchTag() == "" {
+		return nil
+	}
+	return tea.Tick(m.appConfig.ClickUpWatchInterval(), func(time.Time) tea.Msg {
+		return clickUpWatchTickMsg{}
+	})
+}
+
+// knownClickUpTaskIDs returns the ClickUp task IDs already linked to plans.
+func (m *home) knownClickUpTaskIDs() map[string]bool {
+	known := make(map[string]bool)
+	if m.taskState == nil {
+		return known
+	}
+	for _, entry := range m.taskState.Plans {
+		if entry.ClickUpTaskID != "" {
+			known[normalizeClickUpID(entry.ClickUpTaskID)] = true
+		}
+	}
+	return known
+}
+
+// normalizeClickUpID strips the custom "CU-" prefix so IDs from search results
+// and plan records compare equal.
+func normalizeClickUpID(id string) string {
+	return strings.TrimPrefix(strings.TrimSpace(id), "CU-")
+}
+
+// pollClickUpWatch searches ClickUp for tasks carrying the watch tag and
+// fetches every one that is not already linked to a plan.
+func (m *home) pollClickUpWatch() tea.Cmd {
+	tag := m.clickUpWatchTag()
+	if tag == "" {
+		return nil
+	}
+	known := m.knownClickUpTaskIDs()
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(m.ctx, clickUpOpTimeout)
+		defer cancel()
+
+		importer, err := m.getOrCreateImporter(ctx)
+		if err != nil {
+			return clickUpWatchResultMsg{Err: normalizeClickUpError(err)}
+		}
+		results, err := importer.Search("tag:" + tag)
+		if err != nil {
+			m.clickUpImporter = nil // force re-init on next attempt
+			return clickUpWatchResultMsg{Err: normalizeClickUpError(err)}
+		}
+		var tasks []*clickup.Task
+		for _, r := range results {
+			id := normalizeClickUpID(r.ID)
+			if id == "" || known[id] {
+				continue
+			}
+			known[id] = true
+			task, err := importer.FetchTask(r.ID)
+			if err != nil {
+				return clickUpWatchResultMsg{Tasks: tasks, Err: normalizeClickUpError(err)}
+			}
+			if task != nil {
+				tasks = append(tasks, task)
+			}
+		}
+		return clickUpWatchResultMsg{Tasks: tasks}
+	}
+}
+
+// finishClickUpWatch registers the tasks found by a watch poll as ready plans,
+// audits each import and schedules the next poll. Poll errors are logged rather
+// than toasted so a flaky MCP server does not nag every interval.
+func (m *home) finishClickUpWatch(msg clickUpWatchResultMsg) tea.Cmd {
+	if msg.Err != nil {
+		log.WarningLog.Printf("clickup watch: %v", msg.Err)
+	}
+	if m.taskState == nil {
+		m.loadTaskState()
+	}
+	cmds := []tea.Cmd{m.scheduleClickUpWatch()}
+	if m.taskState == nil || len(msg.Tasks) == 0 {
+		return tea.Batch(cmds...)
+	}
+
+	known := m.knownClickUpTaskIDs()
+	imported := 0
+	for _, task := range msg.Tasks {
+		if task.ID != "" && known[normalizeClickUpID(task.ID)] {
+			continue
+		}
+		filename, err := m.registerClickUpTask(task)
+		if err != nil {
+			log.WarningLog.Printf("clickup watch: %v", err)
+			continue
+		}
+		known[normalizeClickUpID(task.ID)] = true
+		imported++
+		m.audit(auditlog.EventPlanCreated,
+			fmt.Sprintf("auto-imported clickup task %s (tag %s)", task.ID, m.clickUpWatchTag()),
+			auditlog.WithPlan(filename))
+	}
+	if imported == 0 {
+		return tea.Batch(cmds...)
+	}
+	m.loadTaskState()
+	m.updateSidebarTasks()
+	m.toastManager.Info(fmt.Sprintf("imported %d clickup task(s) tagged %s", imported, m.clickUpWatchTag()))
+	return tea.Batch(append(cmds, m.toastTickCmd())...)
+}
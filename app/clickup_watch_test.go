@@ -0,0 +1,102 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"charm.land/bubbles/v2/spinner"
+	"github.com/kastheco/kasmos/config"
+	"github.com/kastheco/kasmos/config/taskstate"
+	"github.com/kastheco/kasmos/config/taskstore"
+	"github.com/kastheco/kasmos/internal/clickup"
+	"github.com/kastheco/kasmos/internal/mcpclient"
+	"github.com/kastheco/kasmos/ui"
+	"github.com/kastheco/kasmos/ui/overlay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// watchMCPStub answers clickup_search with a fixed result list and
+// clickup_get_task with a minimal task, counting fetches.
+type watchMCPStub struct {
+	searchJSON string
+	queries    []string
+	fetches    int
+}
+
+func (s *watchMCPStub) CallTool(name string, args map[string]interface{}) (*mcpclient.ToolResult, error) {
+	text := s.searchJSON
+	if name == "clickup_get_task" {
+		s.fetches++
+		id, _ := args["task_id"].(string)
+		text = `{"id":"` + id + `","name":"Watch ` + id + `","description":"from clickup"}`
+	} else {
+		s.queries = append(s.queries, args["keywords"].(string))
+	}
+	return &mcpclient.ToolResult{Content: []mcpclient.ToolContent{{Type: "text", Text: text}}}, nil
+}
+
+func (s *watchMCPStub) FindTool(sub string) (mcpclient.Tool, bool) {
+	for _, name := range []string{"clickup_search", "clickup_get_task"} {
+		if strings.Contains(name, sub) {
+			return mcpclient.Tool{Name: name}, true
+		}
+	}
+	return mcpclient.Tool{}, false
+}
+
+func TestClickUpWatch_SkipsAlreadyImportedTasks(t *testing.T) {
+	store := taskstore.NewTestSQLiteStore(t)
+	ps, err := newTestPlanStateWithStore(t, store, t.TempDir())
+	require.NoError(t, err)
+
+	stub := &watchMCPStub{searchJSON: `[{"id":"abc1","name":"first"},{"id":"abc2","name":"second"}]`}
+	sp := spinner.New(spinner.WithSpinner(spinner.Dot))
+	h := &home{
+		ctx:              context.Background(),
+		appConfig:        &config.Config{ClickUpWatchTag: "kasmos"},
+		clickUpConfig:    &clickup.MCPServerConfig{},
+		clickUpImporter:  clickup.NewImporter(stub),
+		taskState:        ps,
+		taskStore:        store,
+		taskStoreProject: "test",
+		taskStateDir:     t.TempDir(),
+		nav:              ui.NewNavigationPanel(&sp),
+		toastManager:     overlay.NewToastManager(&sp),
+	}
+
+	msg := h.pollClickUpWatch()()
+	result, ok := msg.(clickUpWatchResultMsg)
+	require.True(t, ok)
+	require.NoError(t, result.Err)
+	require.Len(t, result.Tasks, 2)
+	assert.Equal(t, []string{"tag:kasmos"}, stub.queries)
+	require.NotNil(t, h.finishClickUpWatch(result), "next poll must be scheduled")
+
+	linked := map[string]taskstate.TaskEntry{}
+	for _, entry := range h.taskState.Plans {
+		linked[entry.ClickUpTaskID] = entry
+	}
+	require.Contains(t, linked, "abc1")
+	require.Contains(t, linked, "abc2")
+	assert.Equal(t, taskstate.StatusReady, linked["abc1"].Status)
+
+	// The next poll sees the same results but must not fetch or import again.
+	msg = h.pollClickUpWatch()()
+	result, ok = msg.(clickUpWatchResultMsg)
+	require.True(t, ok)
+	assert.Empty(t, result.Tasks)
+	assert.Equal(t, 2, stub.fetches)
+	h.finishClickUpWatch(result)
+	assert.Len(t, h.taskState.Plans, 2)
+}
+
+func TestClickUpWatch_DisabledWithoutTagOrServer(t *testing.T) {
+	h := &home{appConfig: &config.Config{ClickUpWatchTag: "kasmos"}}
+	assert.Nil(t, h.pollClickUpWatch(), "no ClickUp server detected")
+	assert.Nil(t, h.scheduleClickUpWatch())
+
+	h = &home{appConfig: &config.Config{}, clickUpConfig: &clickup.MCPServerConfig{}}
+	assert.Nil(t, h.pollClickUpWatch(), "no watch tag configured")
+}
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/fsnotify/fsnotify"
+	"github.com/kastheco/kasmos/config"
+	"github.com/kastheco/kasmos/log"
+	"github.com/kastheco/kasmos/session"
+)
+
+// configReloadDebounce absorbs the burst of events an editor's save produces
+// (truncate + write, or write-temp + rename) so the file is read once.
+const configReloadDebounce = 150 * time.Millisecond
+
+// configChangedMsg is sent when config.toml changes on disk.
+type configChangedMsg struct{}
+
+// configWatcher watches config.toml for external edits. It watches the
+// containing directory so that editors replacing the file via rename are
+// still seen.
+type configWatcher struct {
+	watcher *fsnotify.Watcher
+	path    string
+}
+
+// newConfigWatcher starts watching the config.toml in the config directory.
+// Returns nil when the watcher cannot be set up; hot-reload is best-effort.
+func newConfigWatcher() *configWatcher {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		log.WarningLog.Printf("config watch: %v", err)
+		return nil
+	}
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.WarningLog.Printf("config watch: %v", err)
+		return nil
+	}
+	if err := w.Add(dir); err != nil {
+		log.WarningLog.Printf("config watch: %v", err)
+		_ = w.Close()
+		return nil
+	}
+	return &configWatcher{watcher: w, path: filepath.Join(dir, config.TOMLConfigFileName)}
+}
+
+// Close stops the watcher. Safe on a nil receiver.
+func (c *configWatcher) Close() {
+	if c != nil {
+		_ = c.watcher.Close()
+	}
+}
+
+// wait returns a command that blocks until config.toml changes, then reports
+// it as a configChangedMsg. Returns nil once the watcher is closed.
+func (c *configWatcher) wait() tea.Cmd {
+	if c == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		for {
+			select {
+			case ev, ok := <-c.watcher.Events:
+				if !ok {
+					return nil
+				}
+				if filepath.Clean(ev.Name) != c.path || !ev.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+					continue
+				}
+				c.drain()
+				return configChangedMsg{}
+			case err, ok := <-c.watcher.Errors:
+				if !ok {
+					return nil
+				}
+				log.WarningLog.Printf("config watch: %v", err)
+			}
+		}
+	}
+}
+
+// drain discards further events until the watcher has been quiet for
+// configReloadDebounce.
+func (c *configWatcher) drain() {
+	for {
+		select {
+		case _, ok := <-c.watcher.Events:
+			if !ok {
+				return
+			}
+		case <-time.After(configReloadDebounce):
+			return
+		}
+	}
+}
+
+// reloadConfig re-reads the config after an external edit. A file that does
+// not parse leaves the running config untouched.
+func (m *home) reloadConfig() tea.Cmd {
+	if _, err := config.LoadTOMLConfig(); err != nil {
+		m.toastManager.Error(fmt.Sprintf("config not reloaded: %v", err))
+		return m.toastTickCmd()
+	}
+	return m.applyReloadedConfig(config.LoadConfigForRepo(m.activeRepoPath))
+}
+
+// applyReloadedConfig copies the fields that are safe to change while running
+// from cfg into the app config: agent profiles and phase roles, notifications,
+// the banner animation, the metadata tick and worker count, and the idle
+// auto-pause timeout. Everything else (the task store, tmux prefix, daemon
+// address, ...) still needs a restart.
+func (m *home) applyReloadedConfig(cfg *config.Config) tea.Cmd {
+	m.appConfig.Profiles = cfg.Profiles
+	m.appConfig.PhaseRoles = cfg.PhaseRoles
+	m.appConfig.NotificationsEnabled = cfg.NotificationsEnabled
+	session.NotificationsEnabled = cfg.AreNotificationsEnabled()
+	m.appConfig.AnimateBanner = cfg.AnimateBanner
+	m.tabbedWindow.SetAnimateBanner(cfg.AnimateBanner)
+	m.appConfig.MetadataTickMs = cfg.MetadataTickMs
+	m.appConfig.MetadataWorkers = cfg.MetadataWorkers
+	m.appConfig.IdleAutoPauseMinutes = cfg.IdleAutoPauseMinutes
+	m.toastManager.Info("config reloaded")
+	return m.toastTickCmd()
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kastheco/kasmos/config"
+	"github.com/kastheco/kasmos/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyReloadedConfig_AppliesSafeFields(t *testing.T) {
+	origNotify := session.NotificationsEnabled
+	t.Cleanup(func() { session.NotificationsEnabled = origNotify })
+
+	h := newTestHome()
+	h.appConfig.TmuxPrefix = "kas_"
+	off := false
+	changed := &config.Config{}
+	changed.Profiles = map[string]config.AgentProfile{"coding": {Program: "claude", Enabled: true}}
+	changed.NotificationsEnabled = &off
+	changed.AnimateBanner = true
+	changed.MetadataTickMs = 500
+	changed.MetadataWorkers = 3
+	changed.TmuxPrefix = "other_"
+
+	require.NotNil(t, h.applyReloadedConfig(changed))
+	assert.Equal(t, changed.Profiles, h.appConfig.Profiles)
+	assert.False(t, h.appConfig.AreNotificationsEnabled())
+	assert.False(t, session.NotificationsEnabled)
+	assert.True(t, h.appConfig.AnimateBanner)
+	assert.Equal(t, 500*time.Millisecond, h.metadataTickInterval())
+	assert.Equal(t, 3, h.appConfig.MetadataWorkerCount())
+	assert.Equal(t, "kas_", h.appConfig.TmuxPrefix, "restart-only fields are not reloaded")
+}
+
+func TestReloadConfig_KeepsConfigOnParseError(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("HOME", t.TempDir())
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".kasmos"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".kasmos", config.TOMLConfigFileName), []byte("metadata_tick_ms = [oops\n"), 0o644))
+
+	h := newTestHome()
+	h.appConfig.MetadataTickMs = 300
+	require.NotNil(t, h.reloadConfig())
+	assert.Equal(t, 300, h.appConfig.MetadataTickMs)
+}
+
+func TestConfigWatcher_ReportsWrites(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(dir, ".kasmos", config.TOMLConfigFileName)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte("metadata_tick_ms = 200\n"), 0o644))
+
+	w := newConfigWatcher()
+	require.NotNil(t, w)
+	t.Cleanup(w.Close)
+
+	done := make(chan any, 1)
+	go func() { done <- w.wait()() }()
+	require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(path), "state.json"), []byte("{}"), 0o644))
+	require.NoError(t, os.WriteFile(path, []byte("metadata_tick_ms = 400\n"), 0o644))
+
+	select {
+	case msg := <-done:
+		assert.IsType(t, configChangedMsg{}, msg)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no configChangedMsg after writing config.toml")
+	}
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kastheco/kasmos/internal/github"
+	"github.com/kastheco/kasmos/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubDetected_ShowsImportEntry(t *testing.T) {
+	h := newTestHome()
+
+	_, _ = h.Update(githubDetectedMsg{Repo: github.Repo{Owner: "acme", Name: "widgets"}})
+
+	require.NotNil(t, h.githubRepo)
+	assert.Equal(t, "acme/widgets", h.githubRepo.FullName())
+	assert.True(t, h.nav.SelectByID(ui.SidebarImportGitHub), "sidebar must offer the github import entry")
+}
+
+func TestGitHubSearchResults_OpenPicker(t *testing.T) {
+	h := newTestHome()
+	h.state = stateGitHubFetching
+
+	results := []github.SearchResult{
+		{Number: 42, Title: "Add dark mode", State: "open"},
+		{Number: 7, Title: "Fix crash", State: "closed"},
+	}
+	_, _ = h.Update(githubSearchResultMsg{Results: results})
+
+	assert.Equal(t, stateGitHubPicker, h.state)
+	assert.True(t, h.overlays.IsActive())
+
+	r, ok := h.selectedGitHubResult("#7 · Fix crash (closed)")
+	require.True(t, ok)
+	assert.Equal(t, 7, r.Number)
+
+	_, ok = h.selectedGitHubResult("#8 · Unknown")
+	assert.False(t, ok)
+}
+
+func TestGitHubSearch_EmptyAndErrorReturnToDefault(t *testing.T) {
+	h := newTestHome()
+
+	h.state = stateGitHubFetching
+	_, _ = h.Update(githubSearchResultMsg{})
+	assert.Equal(t, stateDefault, h.state)
+
+	h.state = stateGitHubFetching
+	_, _ = h.Update(githubSearchResultMsg{Err: errors.New("boom")})
+	assert.Equal(t, stateDefault, h.state)
+}
+
+func TestGetOrCreateGitHubImporter_RequiresDetectedRepo(t *testing.T) {
+	h := newTestHome()
+	_, err := h.getOrCreateGitHubImporter()
+	assert.Error(t, err)
+
+	t.Setenv("GITHUB_TOKEN", "tok")
+	h.githubRepo = &github.Repo{Owner: "acme", Name: "widgets"}
+	im, err := h.getOrCreateGitHubImporter()
+	require.NoError(t, err)
+	assert.Same(t, im, h.githubImporter)
+}
@@ -3,9 +3,9 @@ package app
 import (
 	"fmt"
 	"github.com/kastheco/kasmos/config"
+	"github.com/kastheco/kasmos/keys"
 	"github.com/kastheco/kasmos/log"
 	"github.com/kastheco/kasmos/session"
-	"github.com/kastheco/kasmos/session/tmux"
 	"github.com/kastheco/kasmos/ui"
 	"github.com/kastheco/kasmos/ui/overlay"
 
@@ -21,18 +21,28 @@ type helpText interface {
 	mask() uint32
 }
 
-type helpTypeGeneral struct{}
+type helpTypeGeneral struct {
+	vim bool // vim mode moves kill from k to x
+}
 
 type helpTypeInstanceStart struct {
 	instance *session.Instance
+	vim      bool
 }
 
 type helpTypeInstanceAttach struct{}
 
+type helpTypeInstanceAttachReadonly struct{}
+
 type helpTypeInstanceCheckout struct{}
 
-func helpStart(instance *session.Instance) helpText {
-	return helpTypeInstanceStart{instance: instance}
+func helpStart(instance *session.Instance, vim bool) helpText {
+	return helpTypeInstanceStart{instance: instance, vim: vim}
+}
+
+// killKey returns the key that kills a session in the given mode.
+func killKey(vim bool) string {
+	return keys.Binding(keys.KeyKill, vim).Help().Key
 }
 
 func (h helpTypeGeneral) toContent() string {
@@ -45,18 +55,26 @@ func (h helpTypeGeneral) toContent() string {
 		"",
 		headerStyle.Render("sessions:"),
 		keyStyle.Render("↵/o")+descStyle.Render("           - attach to tmux session fullscreen"),
+		keyStyle.Render("O")+descStyle.Render("             - attach read-only (keys are not sent)"),
 		keyStyle.Render("s")+descStyle.Render("             - spawn agent"),
 		keyStyle.Render("i")+descStyle.Render("             - interactive mode (type in pane)"),
 		keyStyle.Render("ctrl+space")+descStyle.Render("    - exit fullscreen or interactive mode"),
 		keyStyle.Render("ctrl+enter")+descStyle.Render("    - submit + exit interactive mode"),
-		keyStyle.Render("k")+descStyle.Render("             - kill tmux session (keeps instance)"),
+		keyStyle.Render(killKey(h.vim))+descStyle.Render("             - kill tmux session (keeps instance)"),
 		keyStyle.Render("K")+descStyle.Render("             - stop session (branch preserved)"),
 		keyStyle.Render("r")+descStyle.Render("             - resume paused session"),
 		keyStyle.Render("c")+descStyle.Render("             - checkout branch (pause + copy branch name)"),
+		keyStyle.Render("C/R")+descStyle.Render("           - pause / resume every session in this repo"),
 		keyStyle.Render("P")+descStyle.Render("             - create pull request"),
+		keyStyle.Render("D")+descStyle.Render("             - export diff to ~/.kasmos/diffs"),
+		keyStyle.Render("Y")+descStyle.Render("             - copy selected plan markdown"),
+		keyStyle.Render("B")+descStyle.Render("             - copy selected instance or plan branch name"),
 		keyStyle.Render("T")+descStyle.Render("             - browse orphaned tmux sessions"),
 		keyStyle.Render("1/2")+descStyle.Render("           - filter: all / active only"),
 		keyStyle.Render("3")+descStyle.Render("             - cycle sort mode"),
+		keyStyle.Render("shift+↑/↓")+descStyle.Render("     - reorder session within its group"),
+		keyStyle.Render("u")+descStyle.Render("             - undo the last session removed from the list"),
+		keyStyle.Render("]")+descStyle.Render("             - jump to the next session needing attention"),
 		descStyle.Render("agent profiles can choose tmux or headless execution; tmux stays attachable, headless favors automated wave work."),
 		descStyle.Render("headless sessions are not attachable; use the preview tab and logs for output while they run."),
 		"",
@@ -65,7 +83,9 @@ func (h helpTypeGeneral) toContent() string {
 		keyStyle.Render("space")+descStyle.Render("         - toggle plan, topic, or history"),
 		keyStyle.Render("↵/o")+descStyle.Render("           - select (context menu or run stage)"),
 		keyStyle.Render("v/p")+descStyle.Render("           - preview selected plan"),
+		keyStyle.Render("E")+descStyle.Render("             - edit selected plan in $EDITOR"),
 		keyStyle.Render("b")+descStyle.Render("             - open plan browser"),
+		keyStyle.Render("I")+descStyle.Render("             - send a prompt to all of the plan's sessions"),
 		"",
 		headerStyle.Render("navigation:"),
 		keyStyle.Render("t")+descStyle.Render("             - focus instance list"),
@@ -78,7 +98,16 @@ func (h helpTypeGeneral) toContent() string {
 		keyStyle.Render("ctrl+s")+descStyle.Render("        - toggle sidebar visibility"),
 		keyStyle.Render("L")+descStyle.Render("             - toggle audit log pane"),
 		keyStyle.Render("/")+descStyle.Render("             - search plans and instances"),
+		keyStyle.Render("F")+descStyle.Render("             - search the content of every plan"),
+		keyStyle.Render("ctrl+r")+descStyle.Render("        - toggle regex search (query starts with /)"),
+		keyStyle.Render("f")+descStyle.Render("             - toggle following new output in the preview"),
+		keyStyle.Render("z")+descStyle.Render("             - collapse all topics and plans, or expand them all"),
+		keyStyle.Render("G")+descStyle.Render("             - group the sidebar by agent type, or back to plans"),
+		keyStyle.Render("V")+descStyle.Render("             - overview of plans and running agents across repos"),
+		keyStyle.Render(",")+descStyle.Render("             - settings (program, auto-yes, banner, notifications)"),
+		keyStyle.Render("/ then n/N")+descStyle.Render("    - in preview scroll mode: search agent output, cycle matches"),
 		keyStyle.Render("q")+descStyle.Render("             - quit"),
+		keyStyle.Render("Q")+descStyle.Render("             - quit and stop all sessions"),
 	)
 	return content
 }
@@ -99,12 +128,13 @@ func (h helpTypeInstanceStart) toContent() string {
 		keyStyle.Render("↵/o")+descStyle.Render("   - attach to session"),
 		keyStyle.Render("tab")+descStyle.Render("   - cycle panes (# info tab)"),
 		keyStyle.Render("!")+descStyle.Render("     - interactive + shell mode"),
-		keyStyle.Render("k")+descStyle.Render("     - kill tmux session"),
+		keyStyle.Render(killKey(h.vim))+descStyle.Render("     - kill tmux session"),
 		keyStyle.Render("K")+descStyle.Render("     - stop session (branch preserved)"),
 		"",
 		headerStyle.Render("handoff:"),
 		keyStyle.Render("c")+descStyle.Render("     - checkout this instance's branch"),
 		keyStyle.Render("P")+descStyle.Render("     - create a pull request for this branch"),
+		keyStyle.Render("D")+descStyle.Render("     - export this branch's diff as a .patch"),
 	)
 	return content
 }
@@ -120,6 +150,19 @@ func (h helpTypeInstanceAttach) toContent() string {
 	return content
 }
 
+func (h helpTypeInstanceAttachReadonly) toContent() string {
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render("attaching to instance (read-only)"),
+		"",
+		descStyle.Render("keystrokes are not sent to the agent while attached read-only."),
+		"",
+		descStyle.Render("to detach from the session:"),
+		keyStyle.Render("ctrl-q")+descStyle.Render("     - detach"),
+		keyStyle.Render("ctrl+space")+descStyle.Render(" - detach"),
+	)
+	return content
+}
+
 func (h helpTypeInstanceCheckout) toContent() string {
 	content := lipgloss.JoinVertical(lipgloss.Left,
 		titleStyle.Render("checkout instance"),
@@ -147,14 +190,27 @@ func (h helpTypeInstanceAttach) mask() uint32 {
 func (h helpTypeInstanceCheckout) mask() uint32 {
 	return 1 << 3
 }
+func (h helpTypeInstanceAttachReadonly) mask() uint32 {
+	return 1 << 4
+}
 
 var (
-	titleStyle  = lipgloss.NewStyle().Bold(true).Underline(true).Foreground(ui.ColorIris)
-	headerStyle = lipgloss.NewStyle().Bold(true).Foreground(ui.ColorFoam)
-	keyStyle    = lipgloss.NewStyle().Bold(true).Foreground(ui.ColorGold)
-	descStyle   = lipgloss.NewStyle().Foreground(ui.ColorText)
+	titleStyle  lipgloss.Style
+	headerStyle lipgloss.Style
+	keyStyle    lipgloss.Style
+	descStyle   lipgloss.Style
 )
 
+func init() { ui.OnThemeChange(buildHelpStyles) }
+
+// buildHelpStyles derives the help screen styles from the active theme.
+func buildHelpStyles() {
+	titleStyle = lipgloss.NewStyle().Bold(true).Underline(true).Foreground(ui.ColorIris)
+	headerStyle = lipgloss.NewStyle().Bold(true).Foreground(ui.ColorFoam)
+	keyStyle = lipgloss.NewStyle().Bold(true).Foreground(ui.ColorGold)
+	descStyle = lipgloss.NewStyle().Foreground(ui.ColorText)
+}
+
 // showHelpScreen displays the help screen overlay if it hasn't been shown before
 func (m *home) showHelpScreen(helpType helpText, onDismiss func()) (tea.Model, tea.Cmd) {
 	// Get the flag for this help type
@@ -202,17 +258,13 @@ func (m *home) handleHelpState(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		// hand off to tea.Exec so bubbletea can release the terminal before attaching.
 		// This prevents stdin contention between bubbletea's event loop and the
 		// attach goroutines that read/write os.Stdin and os.Stdout directly.
-		pending := m.pendingAttachInstance
+		pending, readonly := m.pendingAttachInstance, m.pendingAttachReadonly
 		m.pendingAttachInstance = nil
+		m.pendingAttachReadonly = false
 
 		if pending != nil && pending.Started() && !pending.Paused() && pending.TmuxAlive() &&
 			config.NormalizeExecutionMode(string(pending.ExecutionMode)) == config.ExecutionModeTmux {
-			return m, tea.Exec(tmux.NewAttachExecCommand(pending), func(err error) tea.Msg {
-				if err != nil {
-					return err
-				}
-				return instanceChangedMsg{}
-			})
+			return m, attachExecCmd(pending, readonly)
 		}
 
 		return m, tea.Sequence(
@@ -0,0 +1,145 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kastheco/kasmos/config/auditlog"
+	"github.com/kastheco/kasmos/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubIdlePause replaces the idle pauser with one that marks instances paused
+// (or fails with err) and returns the titles it was called for.
+func stubIdlePause(t *testing.T, err error) *[]string {
+	t.Helper()
+	var paused []string
+	orig := pauseIdleInstance
+	t.Cleanup(func() { pauseIdleInstance = orig })
+	pauseIdleInstance = func(inst *session.Instance) error {
+		paused = append(paused, inst.Title)
+		if err != nil {
+			return err
+		}
+		inst.SetStatus(session.Paused)
+		return nil
+	}
+	return &paused
+}
+
+// runAutoPause runs one auto-pause pass to completion, as the tick and the
+// Cmd's result message would, and reports whether anything was dispatched.
+func runAutoPause(h *home, now time.Time) bool {
+	cmd := h.autoPauseIdleInstances(now)
+	if cmd == nil {
+		return false
+	}
+	h.handleAutoPauseResult(cmd().(autoPauseResultMsg))
+	return true
+}
+
+func newIdleTestInstance(t *testing.T, h *home, title string, status session.Status, idleSince time.Time) *session.Instance {
+	t.Helper()
+	inst, err := newTestInstance(title)
+	require.NoError(t, err)
+	inst.MarkStartedForTest()
+	inst.SetStatus(status)
+	if status == session.Ready {
+		inst.IdleSince = idleSince
+	}
+	_ = h.nav.AddInstance(inst)
+	return inst
+}
+
+func TestAutoPauseIdleInstances_PausesOnlyIdleSessions(t *testing.T) {
+	paused := stubIdlePause(t, nil)
+	logger, err := auditlog.NewSQLiteLogger(":memory:")
+	require.NoError(t, err)
+	defer logger.Close()
+
+	h := newTestHome()
+	h.auditLogger = logger
+	h.taskStoreProject = "test"
+	h.appConfig.IdleAutoPauseMinutes = 30
+	now := time.Now()
+
+	idle := newIdleTestInstance(t, h, "idle", session.Ready, now.Add(-45*time.Minute))
+	newIdleTestInstance(t, h, "recent", session.Ready, now.Add(-5*time.Minute))
+	newIdleTestInstance(t, h, "busy", session.Running, time.Time{})
+	reviewer := newIdleTestInstance(t, h, "reviewer", session.Ready, now.Add(-2*time.Hour))
+	reviewer.AgentType = session.AgentTypeReviewer
+
+	require.True(t, runAutoPause(h, now))
+	assert.Equal(t, []string{"idle"}, *paused)
+	assert.Equal(t, session.Paused, idle.Status)
+
+	events, err := logger.Query(auditlog.QueryFilter{Project: "test", Kinds: []auditlog.EventKind{auditlog.EventAgentPaused}, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "idle", events[0].InstanceTitle)
+	assert.Contains(t, events[0].Message, "auto-paused")
+}
+
+func TestAutoPauseIdleInstances_ActiveSessionsNeverPaused(t *testing.T) {
+	paused := stubIdlePause(t, nil)
+	h := newTestHome()
+	h.appConfig.IdleAutoPauseMinutes = 1
+	inst := newIdleTestInstance(t, h, "chatty", session.Running, time.Time{})
+
+	// Output keeps arriving: the instance flips between Running and Ready
+	// but never stays Ready long enough.
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		tick := start.Add(time.Duration(i) * 50 * time.Second)
+		inst.SetStatus(session.Ready)
+		inst.IdleSince = tick
+		assert.False(t, runAutoPause(h, tick.Add(30*time.Second)))
+		inst.SetStatus(session.Running)
+		assert.False(t, runAutoPause(h, tick.Add(time.Hour)))
+	}
+	assert.Empty(t, *paused)
+}
+
+func TestAutoPauseIdleInstances_PausesInBackground(t *testing.T) {
+	paused := stubIdlePause(t, nil)
+	h := newTestHome()
+	h.appConfig.IdleAutoPauseMinutes = 30
+	now := time.Now()
+	inst := newIdleTestInstance(t, h, "idle", session.Ready, now.Add(-time.Hour))
+
+	cmd := h.autoPauseIdleInstances(now)
+	require.NotNil(t, cmd)
+	assert.Empty(t, *paused, "nothing is paused inside Update")
+	assert.Nil(t, h.autoPauseIdleInstances(now.Add(time.Second)), "an in-flight pause is not dispatched twice")
+
+	msg, ok := cmd().(autoPauseResultMsg)
+	require.True(t, ok)
+	assert.Equal(t, []string{"idle"}, *paused)
+	assert.Equal(t, session.Paused, inst.Status)
+	h.handleAutoPauseResult(msg)
+}
+
+func TestAutoPauseIdleInstances_DisabledByDefault(t *testing.T) {
+	paused := stubIdlePause(t, nil)
+	h := newTestHome()
+	newIdleTestInstance(t, h, "idle", session.Ready, time.Now().Add(-24*time.Hour))
+
+	assert.False(t, runAutoPause(h, time.Now()))
+	assert.Empty(t, *paused)
+}
+
+func TestAutoPauseIdleInstances_FailedPauseRestartsIdleClock(t *testing.T) {
+	paused := stubIdlePause(t, fmt.Errorf("cannot pause instance with uncommitted changes"))
+	h := newTestHome()
+	h.appConfig.IdleAutoPauseMinutes = 30
+	now := time.Now()
+	inst := newIdleTestInstance(t, h, "dirty", session.Ready, now.Add(-time.Hour))
+
+	assert.True(t, runAutoPause(h, now))
+	assert.False(t, runAutoPause(h, now.Add(time.Minute)), "no retry until the timeout passes again")
+	assert.Equal(t, []string{"dirty"}, *paused)
+	assert.Equal(t, now, inst.IdleSince)
+	assert.Equal(t, session.Ready, inst.Status)
+}
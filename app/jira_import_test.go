@@ -0,0 +1,70 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kastheco/kasmos/internal/jira"
+	"github.com/kastheco/kasmos/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJiraDetected_ShowsImportEntry(t *testing.T) {
+	h := newTestHome()
+
+	_, _ = h.Update(jiraDetectedMsg{Config: jira.Config{BaseURL: "https://acme.atlassian.net", ProjectKey: "WID"}})
+
+	require.NotNil(t, h.jiraConfig)
+	assert.Equal(t, "WID", h.jiraConfig.ProjectKey)
+	assert.True(t, h.nav.SelectByID(ui.SidebarImportJira), "sidebar must offer the jira import entry")
+}
+
+func TestJiraSearchResults_OpenPicker(t *testing.T) {
+	h := newTestHome()
+	h.state = stateJiraFetching
+
+	results := []jira.SearchResult{
+		{Key: "WID-42", Summary: "Add dark mode", Status: "To Do"},
+		{Key: "WID-7", Summary: "Fix crash", Status: "Done"},
+	}
+	_, _ = h.Update(jiraSearchResultMsg{Results: results})
+
+	assert.Equal(t, stateJiraPicker, h.state)
+	assert.True(t, h.overlays.IsActive())
+
+	r, ok := h.selectedJiraResult("WID-7 · Fix crash (Done)")
+	require.True(t, ok)
+	assert.Equal(t, "WID-7", r.Key)
+
+	_, ok = h.selectedJiraResult("WID-8 · Unknown")
+	assert.False(t, ok)
+}
+
+func TestJiraSearch_EmptyAndErrorReturnToDefault(t *testing.T) {
+	h := newTestHome()
+
+	h.state = stateJiraFetching
+	_, _ = h.Update(jiraSearchResultMsg{})
+	assert.Equal(t, stateDefault, h.state)
+
+	h.state = stateJiraFetching
+	_, _ = h.Update(jiraSearchResultMsg{Err: errors.New("boom")})
+	assert.Equal(t, stateDefault, h.state)
+}
+
+func TestGetOrCreateJiraImporter_RequiresConfigAndToken(t *testing.T) {
+	h := newTestHome()
+	_, err := h.getOrCreateJiraImporter()
+	assert.Error(t, err)
+
+	h.jiraConfig = &jira.Config{BaseURL: "https://acme.atlassian.net"}
+	t.Setenv(jira.EnvToken, "")
+	_, err = h.getOrCreateJiraImporter()
+	assert.ErrorContains(t, err, jira.EnvToken)
+
+	t.Setenv(jira.EnvToken, "tok")
+	im, err := h.getOrCreateJiraImporter()
+	require.NoError(t, err)
+	assert.Same(t, im, h.jiraImporter)
+}
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"sync"
+
+	"github.com/kastheco/kasmos/session"
+)
+
+// collectMetadataPool runs collect for every instance on at most workers
+// goroutines and returns the results in the same order as instances, so the
+// merge in the metadata tick does not depend on which capture finished first.
+// A workers value below 1 is treated as 1.
+func collectMetadataPool(instances []*session.Instance, workers int, collect func(*session.Instance) instanceMetadata) []instanceMetadata {
+	results := make([]instanceMetadata, len(instances))
+	if len(instances) == 0 {
+		return results
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(instances) {
+		workers = len(instances)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				results[idx] = collect(instances[idx])
+			}
+		}()
+	}
+	for idx := range instances {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
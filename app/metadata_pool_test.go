@@ -0,0 +1,86 @@
+package app
+
+import (
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kastheco/kasmos/session"
+)
+
+func poolTestInstances(n int) []*session.Instance {
+	instances := make([]*session.Instance, n)
+	for i := range instances {
+		instances[i] = &session.Instance{Title: fmt.Sprintf("inst-%02d", i)}
+	}
+	return instances
+}
+
+func TestCollectMetadataPool_ReturnsEveryResultInInputOrder(t *testing.T) {
+	instances := poolTestInstances(25)
+
+	for _, workers := range []int{0, 1, 4, 64} {
+		t.Run(fmt.Sprintf("workers=%d", workers), func(t *testing.T) {
+			results := collectMetadataPool(instances, workers, func(inst *session.Instance) instanceMetadata {
+				// Finish in a scrambled order so the merge cannot rely on it.
+				time.Sleep(time.Duration(rand.Intn(3)) * time.Millisecond)
+				return instanceMetadata{Title: inst.Title, Content: "pane of " + inst.Title}
+			})
+
+			require.Len(t, results, len(instances))
+			for i, inst := range instances {
+				assert.Equal(t, inst.Title, results[i].Title)
+				assert.Equal(t, "pane of "+inst.Title, results[i].Content)
+			}
+		})
+	}
+}
+
+func TestCollectMetadataPool_BoundsConcurrency(t *testing.T) {
+	instances := poolTestInstances(20)
+	var running, peak int32
+
+	collectMetadataPool(instances, 3, func(inst *session.Instance) instanceMetadata {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(2 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return instanceMetadata{Title: inst.Title}
+	})
+
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(3))
+	assert.Greater(t, atomic.LoadInt32(&peak), int32(1), "collection should fan out across workers")
+}
+
+func TestCollectMetadataPool_NoInstances(t *testing.T) {
+	results := collectMetadataPool(nil, 4, func(*session.Instance) instanceMetadata {
+		t.Fatal("collect must not be called")
+		return instanceMetadata{}
+	})
+	assert.Empty(t, results)
+}
+
+func BenchmarkCollectMetadataPool(b *testing.B) {
+	instances := poolTestInstances(16)
+	collect := func(inst *session.Instance) instanceMetadata {
+		time.Sleep(100 * time.Microsecond) // stands in for a tmux capture
+		return instanceMetadata{Title: inst.Title}
+	}
+	for _, workers := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				collectMetadataPool(instances, workers, collect)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package app
+
+import (
+	"path/filepath"
+	"sort"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/kastheco/kasmos/config"
+	"github.com/kastheco/kasmos/config/taskstate"
+	"github.com/kastheco/kasmos/config/taskstore"
+	"github.com/kastheco/kasmos/daemon/api"
+	"github.com/kastheco/kasmos/session"
+	"github.com/kastheco/kasmos/ui/overlay"
+)
+
+// overviewStatusOrder is the order plan statuses are listed in the overview.
+var overviewStatusOrder = []taskstate.Status{
+	taskstate.StatusReady,
+	taskstate.StatusPlanning,
+	taskstate.StatusImplementing,
+	taskstate.StatusReviewing,
+	taskstate.StatusDone,
+	taskstate.StatusCancelled,
+}
+
+// overviewInput is the home state the overview is built from. It is captured
+// on the update loop so the build itself can run in a tea.Cmd.
+type overviewInput struct {
+	activeRepo    string
+	activeProject string
+	store         taskstore.Store
+	appConfig     *config.Config
+	instances     []overviewInstance
+}
+
+// overviewInstance is the part of an instance the overview counts.
+type overviewInstance struct {
+	path    string
+	running bool
+}
+
+// overviewLoadedMsg carries the overview built by loadOverviewCmd.
+type overviewLoadedMsg struct {
+	repos []overlay.OverviewRepo
+}
+
+// overviewInput snapshots what buildRepoOverview needs.
+func (m *home) overviewInput() overviewInput {
+	in := overviewInput{
+		activeRepo:    m.activeRepoPath,
+		activeProject: m.taskStoreProject,
+		store:         m.taskStore,
+		appConfig:     m.appConfig,
+	}
+	for _, inst := range m.allInstances {
+		in.instances = append(in.instances, overviewInstance{
+			path:    inst.Path,
+			running: inst.Status == session.Running || inst.Status == session.Loading,
+		})
+	}
+	return in
+}
+
+// knownRepos returns the repos shown in the overview: the active repo first,
+// then every repo registered with the daemon or running an instance, sorted
+// and deduplicated. daemonRepos is the daemon's repo list, fetched once by
+// the caller.
+func knownRepos(in overviewInput, daemonRepos []api.RepoStatus) []string {
+	seen := make(map[string]bool)
+	var repos, others []string
+	if in.activeRepo != "" {
+		seen[canonicalRepoPath(in.activeRepo)] = true
+		repos = append(repos, in.activeRepo)
+	}
+	add := func(path string) {
+		key := canonicalRepoPath(path)
+		if path == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		others = append(others, path)
+	}
+
+	for _, r := range daemonRepos {
+		add(r.Path)
+	}
+	for _, inst := range in.instances {
+		add(inst.path)
+	}
+	sort.Strings(others)
+	return append(repos, others...)
+}
+
+// runningByRepo counts the running or loading instances per canonical repo path.
+func runningByRepo(insts []overviewInstance) map[string]int {
+	counts := make(map[string]int)
+	for _, inst := range insts {
+		if inst.running {
+			counts[canonicalRepoPath(inst.path)]++
+		}
+	}
+	return counts
+}
+
+// summarizeRepo counts the plans of ps by status. ps may be nil when loading
+// failed; running is the repo's running instance count.
+func summarizeRepo(repoPath string, ps *taskstate.TaskState, running int) overlay.OverviewRepo {
+	repo := overlay.OverviewRepo{Name: filepath.Base(repoPath), Path: repoPath, Running: running}
+	if ps == nil {
+		return repo
+	}
+	counts := make(map[taskstate.Status]int)
+	for _, entry := range ps.Plans {
+		counts[entry.Status]++
+	}
+	for _, status := range overviewStatusOrder {
+		if n := counts[status]; n > 0 {
+			repo.Statuses = append(repo.Statuses, overlay.OverviewStatusCount{Status: string(status), Count: n})
+		}
+	}
+	return repo
+}
+
+// buildRepoOverview loads the plan state of every known repo and summarizes
+// it. The daemon is asked for its repos once; each repo's task store project
+// comes from that list, falling back to the directory name.
+func buildRepoOverview(in overviewInput) []overlay.OverviewRepo {
+	daemonRepos, _ := listDaemonRepoStatuses()
+	projects := make(map[string]string, len(daemonRepos))
+	for _, r := range daemonRepos {
+		projects[canonicalRepoPath(r.Path)] = r.Project
+	}
+
+	repos := knownRepos(in, daemonRepos)
+	running := runningByRepo(in.instances)
+	out := make([]overlay.OverviewRepo, 0, len(repos))
+	for _, path := range repos {
+		key := canonicalRepoPath(path)
+		var ps *taskstate.TaskState
+		var loadErr error
+		if in.store != nil {
+			project := projects[key]
+			if key == canonicalRepoPath(in.activeRepo) && in.activeProject != "" {
+				project = in.activeProject
+			}
+			if project == "" {
+				project = filepath.Base(path)
+			}
+			ps, loadErr = taskstate.Load(in.store, project, in.appConfig.PlansDirFor(path))
+		}
+		repo := summarizeRepo(path, ps, running[key])
+		if loadErr != nil {
+			repo.Err = loadErr.Error()
+		}
+		out = append(out, repo)
+	}
+	return out
+}
+
+// openOverview builds the cross-repo overview off the update loop; the
+// overlay is shown when overviewLoadedMsg arrives.
+func (m *home) openOverview() (tea.Model, tea.Cmd) {
+	in := m.overviewInput()
+	return m, func() tea.Msg {
+		return overviewLoadedMsg{repos: buildRepoOverview(in)}
+	}
+}
+
+// showOverview shows the full-screen cross-repo overview, unless another
+// overlay was opened while it was loading.
+func (m *home) showOverview(repos []overlay.OverviewRepo) (tea.Model, tea.Cmd) {
+	if m.state != stateDefault {
+		return m, nil
+	}
+	o := overlay.NewOverviewOverlay(repos)
+	o.SetSize(m.termWidth, m.termHeight)
+	m.overlays.ShowAt(o, true, false)
+	m.state = stateOverview
+	return m, nil
+}
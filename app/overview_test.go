@@ -0,0 +1,89 @@
+package app
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kastheco/kasmos/config/taskstate"
+	"github.com/kastheco/kasmos/daemon/api"
+	"github.com/kastheco/kasmos/session"
+	"github.com/kastheco/kasmos/ui/overlay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenOverview_AggregatesPlansAndRunningPerRepo(t *testing.T) {
+	store := newTestStore(t)
+	alphaRepo := filepath.Join(t.TempDir(), "alpha")
+	betaRepo := filepath.Join(t.TempDir(), "beta")
+
+	seed := func(project string, plans map[string]taskstate.Status) {
+		ps, err := taskstate.Load(store, project, "")
+		require.NoError(t, err)
+		for file, status := range plans {
+			require.NoError(t, ps.Create(file, "", "", "", time.Now()))
+			require.NoError(t, ps.ForceSetStatus(file, status))
+		}
+	}
+	seed("alpha", map[string]taskstate.Status{
+		"a1.md": taskstate.StatusReady,
+		"a2.md": taskstate.StatusImplementing,
+		"a3.md": taskstate.StatusImplementing,
+		"a4.md": taskstate.StatusDone,
+	})
+	seed("beta", map[string]taskstate.Status{"b1.md": taskstate.StatusReviewing})
+
+	old := listDaemonRepoStatuses
+	daemonCalls := 0
+	listDaemonRepoStatuses = func() ([]api.RepoStatus, error) {
+		daemonCalls++
+		return []api.RepoStatus{{Path: betaRepo, Project: "beta"}, {Path: alphaRepo, Project: "alpha"}}, nil
+	}
+	t.Cleanup(func() { listDaemonRepoStatuses = old })
+
+	h := newTestHome()
+	h.taskStore = store
+	h.taskStoreProject = "alpha"
+	h.activeRepoPath = alphaRepo
+	for _, tc := range []struct {
+		path   string
+		status session.Status
+	}{
+		{alphaRepo, session.Running},
+		{betaRepo, session.Running},
+		{betaRepo, session.Loading},
+		{betaRepo, session.Paused},
+	} {
+		h.allInstances = append(h.allInstances, &session.Instance{Path: tc.path, Status: tc.status})
+	}
+
+	_, cmd := h.openOverview()
+	require.NotNil(t, cmd)
+	assert.Zero(t, daemonCalls, "the overview is built in the returned command")
+	msg, ok := cmd().(overviewLoadedMsg)
+	require.True(t, ok)
+	assert.Equal(t, 1, daemonCalls, "the daemon is asked for its repos once")
+	repos := msg.repos
+
+	require.Len(t, repos, 2)
+	assert.Equal(t, overlay.OverviewRepo{
+		Name: "alpha",
+		Path: alphaRepo,
+		Statuses: []overlay.OverviewStatusCount{
+			{Status: "ready", Count: 1},
+			{Status: "implementing", Count: 2},
+			{Status: "done", Count: 1},
+		},
+		Running: 1,
+	}, repos[0], "active repo comes first")
+	assert.Equal(t, overlay.OverviewRepo{
+		Name:     "beta",
+		Path:     betaRepo,
+		Statuses: []overlay.OverviewStatusCount{{Status: "reviewing", Count: 1}},
+		Running:  2,
+	}, repos[1], "paused instances are not counted as running")
+
+	h.Update(msg)
+	assert.Equal(t, stateOverview, h.state)
+}
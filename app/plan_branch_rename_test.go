@@ -0,0 +1,72 @@
+package app
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kastheco/kasmos/session"
+	"github.com/kastheco/kasmos/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBranchRenameHome returns a home whose repo has a plan "renamed-plan"
+// still on the branch of its old slug, selected in the sidebar.
+func newBranchRenameHome(t *testing.T) *home {
+	t.Helper()
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "command %v failed: %s", args, out)
+	}
+	run("git", "init", "-b", "main")
+	run("git", "config", "user.email", "test@test.com")
+	run("git", "config", "user.name", "Test")
+	run("git", "commit", "--allow-empty", "-m", "init")
+	run("git", "branch", "plan/old-slug")
+
+	plansDir := filepath.Join(dir, "docs", "plans")
+	require.NoError(t, os.MkdirAll(plansDir, 0o755))
+	store, ps, _ := newSharedStoreForTest(t, plansDir)
+	require.NoError(t, ps.Register("renamed-plan", "renamed plan", "plan/old-slug", time.Now()))
+
+	h := newCancelDelayHome(t, store, ps, plansDir, dir)
+	h.updateSidebarTasks()
+	require.True(t, h.nav.SelectByID(ui.SidebarPlanPrefix+"renamed-plan"))
+	return h
+}
+
+func TestRenamePlanBranch_RenamesBranchAndUpdatesEntry(t *testing.T) {
+	h := newBranchRenameHome(t)
+
+	_, _ = h.executeContextAction("rename_plan_branch")
+	require.NotNil(t, h.pendingConfirmAction, "rename must be confirmed first")
+
+	msg, ok := h.pendingConfirmAction().(planBranchRenamedMsg)
+	require.True(t, ok)
+	require.NoError(t, msg.err)
+	assert.Equal(t, "plan/renamed-plan", msg.newBranch)
+
+	_, _ = h.Update(msg)
+	entry, ok := h.taskState.Entry("renamed-plan")
+	require.True(t, ok)
+	assert.Equal(t, "plan/renamed-plan", entry.Branch)
+}
+
+func TestRenamePlanBranch_RefusedWhileInstanceHasBranch(t *testing.T) {
+	h := newBranchRenameHome(t)
+	inst, err := session.NewInstance(session.InstanceOptions{Title: "coder", Path: h.activeRepoPath, Program: "claude"})
+	require.NoError(t, err)
+	inst.Branch = "plan/old-slug"
+	h.allInstances = []*session.Instance{inst}
+
+	_, cmd := h.executeContextAction("rename_plan_branch")
+	assert.NotNil(t, cmd, "an error is reported")
+	assert.Nil(t, h.pendingConfirmAction, "no rename is offered")
+}
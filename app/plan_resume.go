@@ -0,0 +1,62 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/kastheco/kasmos/config/auditlog"
+	"github.com/kastheco/kasmos/config/taskstate"
+	"github.com/kastheco/kasmos/session"
+)
+
+// pausedPlanInstances returns the started, paused instances working on planFile.
+func (m *home) pausedPlanInstances(planFile string) []*session.Instance {
+	var out []*session.Instance
+	for _, inst := range m.allInstances {
+		if inst.TaskFile == planFile && inst.Started() && inst.Status == session.Paused {
+			out = append(out, inst)
+		}
+	}
+	return out
+}
+
+// resumePlanAgents resumes every paused agent of planFile, restoring each
+// one's worktree and session. Failures do not stop the remaining resumes; they
+// are reported together in the summary toast.
+func (m *home) resumePlanAgents(planFile string) (tea.Model, tea.Cmd) {
+	paused := m.pausedPlanInstances(planFile)
+	planName := taskstate.DisplayName(planFile)
+	if len(paused) == 0 {
+		m.toastManager.Info(fmt.Sprintf("no paused agents for '%s'", planName))
+		return m, m.toastTickCmd()
+	}
+
+	resume := m.instanceResumer
+	if resume == nil {
+		resume = (*session.Instance).Resume
+	}
+	var failed []string
+	for _, inst := range paused {
+		if err := resume(inst); err != nil {
+			failed = append(failed, fmt.Sprintf("%s (%v)", inst.Title, err))
+			continue
+		}
+		m.audit(auditlog.EventAgentResumed, "agent resumed",
+			auditlog.WithInstance(inst.Title),
+			auditlog.WithAgent(inst.AgentType),
+			auditlog.WithPlan(inst.TaskFile),
+		)
+	}
+	_ = m.saveAllInstances()
+	m.updateNavPanelStatus()
+
+	resumed := len(paused) - len(failed)
+	if len(failed) == 0 {
+		m.toastManager.Success(fmt.Sprintf("resumed %d agent(s) for '%s'", resumed, planName))
+	} else {
+		m.toastManager.Error(fmt.Sprintf("resumed %d of %d agent(s) for '%s'; failed: %s",
+			resumed, len(paused), planName, strings.Join(failed, ", ")))
+	}
+	return m, tea.Batch(tea.RequestWindowSize, m.instanceChanged(), m.toastTickCmd())
+}
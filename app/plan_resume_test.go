@@ -0,0 +1,64 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kastheco/kasmos/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func addPlanInstance(t *testing.T, h *home, title, planFile string, status session.Status) *session.Instance {
+	t.Helper()
+	inst, err := newTestInstance(title)
+	require.NoError(t, err)
+	inst.TaskFile = planFile
+	inst.MarkStartedForTest()
+	inst.SetStatus(status)
+	h.nav.AddInstance(inst)
+	h.allInstances = append(h.allInstances, inst)
+	return inst
+}
+
+func TestResumePlanAgents_ResumesOnlyPausedPlanInstances(t *testing.T) {
+	h := newTestHomeWithToast()
+	coder := addPlanInstance(t, h, "auth-coder", "auth.md", session.Paused)
+	reviewer := addPlanInstance(t, h, "auth-reviewer", "auth.md", session.Paused)
+	running := addPlanInstance(t, h, "auth-fixer", "auth.md", session.Running)
+	other := addPlanInstance(t, h, "billing-coder", "billing.md", session.Paused)
+
+	var resumed []string
+	h.instanceResumer = func(inst *session.Instance) error {
+		resumed = append(resumed, inst.Title)
+		inst.SetStatus(session.Running)
+		return nil
+	}
+
+	_, _ = h.resumePlanAgents("auth.md")
+
+	assert.ElementsMatch(t, []string{"auth-coder", "auth-reviewer"}, resumed)
+	assert.Equal(t, session.Running, coder.Status)
+	assert.Equal(t, session.Running, reviewer.Status)
+	assert.Equal(t, session.Running, running.Status)
+	assert.Equal(t, session.Paused, other.Status, "other plans' agents stay paused")
+}
+
+func TestResumePlanAgents_ContinuesPastFailures(t *testing.T) {
+	h := newTestHomeWithToast()
+	addPlanInstance(t, h, "auth-coder", "auth.md", session.Paused)
+	ok := addPlanInstance(t, h, "auth-reviewer", "auth.md", session.Paused)
+
+	h.instanceResumer = func(inst *session.Instance) error {
+		if inst.Title == "auth-coder" {
+			return errors.New("branch is checked out")
+		}
+		inst.SetStatus(session.Running)
+		return nil
+	}
+
+	_, _ = h.resumePlanAgents("auth.md")
+
+	assert.Equal(t, session.Running, ok.Status, "a failure does not stop the remaining resumes")
+	assert.Len(t, h.pausedPlanInstances("auth.md"), 1)
+}
@@ -0,0 +1,135 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/kastheco/kasmos/log"
+)
+
+// plannerContextMaxFiles caps how many matched files the planner context
+// lists, so a broad glob cannot flood the prompt.
+const plannerContextMaxFiles = 200
+
+// plannerContextMaxBytes caps the whole planner context section. The prompt
+// reaches the agent as a single CLI argument (capped at 128 KiB on Linux) or
+// through tmux send-keys, so it has to stay well under that.
+const plannerContextMaxBytes = 64 << 10
+
+// gatherPlannerContext returns a prompt section listing the files in repoPath
+// that match globs, followed by their contents, each truncated to maxFileBytes.
+// Once the section reaches plannerContextMaxBytes the remaining files are only
+// listed. Files are taken from `git ls-files`, so anything git-ignored is
+// skipped, and binary files are listed but not inlined. Returns "" when
+// nothing matches or the file list cannot be read.
+func gatherPlannerContext(repoPath string, globs []string, maxFileBytes int) string {
+	if len(globs) == 0 || repoPath == "" {
+		return ""
+	}
+	files, err := listRepoFiles(repoPath)
+	if err != nil {
+		log.WarningLog.Printf("planner context: %v", err)
+		return ""
+	}
+
+	var matched []string
+	for _, f := range files {
+		for _, g := range globs {
+			if matchPlannerGlob(g, f) {
+				matched = append(matched, f)
+				break
+			}
+		}
+	}
+	if len(matched) == 0 {
+		return ""
+	}
+	omitted := 0
+	if len(matched) > plannerContextMaxFiles {
+		omitted = len(matched) - plannerContextMaxFiles
+		matched = matched[:plannerContextMaxFiles]
+	}
+
+	var b strings.Builder
+	b.WriteString("\n\n## Repository context\n\nFiles matching the configured planner_context globs:\n")
+	for _, f := range matched {
+		fmt.Fprintf(&b, "- %s\n", f)
+	}
+	if omitted > 0 {
+		fmt.Fprintf(&b, "- … %d more not shown\n", omitted)
+	}
+	for i, f := range matched {
+		budget := min(maxFileBytes, plannerContextMaxBytes-b.Len())
+		if budget <= 0 {
+			fmt.Fprintf(&b, "\n… %d more file(s) not inlined: context limit of %d bytes reached\n",
+				len(matched)-i, plannerContextMaxBytes)
+			break
+		}
+		data, err := os.ReadFile(filepath.Join(repoPath, filepath.FromSlash(f)))
+		if err != nil || bytes.IndexByte(data, 0) >= 0 {
+			continue // unreadable (e.g. deleted but still tracked) or binary
+		}
+		fmt.Fprintf(&b, "\n--- %s ---\n", f)
+		if len(data) > budget {
+			b.Write(data[:budget])
+			fmt.Fprintf(&b, "\n… (truncated at %d of %d bytes)\n", budget, len(data))
+			continue
+		}
+		b.Write(data)
+		if !bytes.HasSuffix(data, []byte("\n")) {
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+// listRepoFiles returns the tracked and untracked-but-not-ignored files in
+// repoPath as slash-separated paths relative to its root.
+func listRepoFiles(repoPath string) ([]string, error) {
+	out, err := exec.Command("git", "-C", repoPath, "ls-files", "-z", "--cached", "--others", "--exclude-standard").Output()
+	if err != nil {
+		return nil, fmt.Errorf("list files in %s: %w", repoPath, err)
+	}
+	var files []string
+	seen := make(map[string]bool)
+	for _, f := range strings.Split(string(out), "\x00") {
+		if f != "" && !seen[f] {
+			seen[f] = true
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// matchPlannerGlob reports whether the slash-separated name matches pattern.
+// Segments match with path.Match; a "**" segment matches zero or more
+// directories.
+func matchPlannerGlob(pattern, name string) bool {
+	return matchGlobSegments(strings.Split(strings.Trim(pattern, "/"), "/"), strings.Split(name, "/"))
+}
+
+func matchGlobSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchGlobSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
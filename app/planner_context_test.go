@@ -0,0 +1,104 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchPlannerGlob(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"README.md", "README.md", true},
+		{"README.md", "docs/README.md", false},
+		{"*.go", "main.go", true},
+		{"*.go", "app/app.go", false},
+		{"app/*.go", "app/app.go", true},
+		{"**/*.go", "main.go", true},
+		{"**/*.go", "app/ui/view.go", true},
+		{"docs/**", "docs/a/b.md", true},
+		{"docs/**/*.md", "docs/guide.md", true},
+		{"docs/**/*.md", "docs/a/b/guide.md", true},
+		{"docs/**/*.md", "web/docs/guide.md", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, matchPlannerGlob(tt.pattern, tt.name), "%s vs %s", tt.pattern, tt.name)
+	}
+}
+
+func initPlannerContextRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "init", dir).CombinedOutput(); err != nil {
+		t.Skipf("git init failed (%v): %s", err, out)
+	}
+	write := func(name, content string) {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	}
+	write(".gitignore", "secret.md\n")
+	write("README.md", "# project\n")
+	write("secret.md", "do not share\n")
+	write("docs/design.md", strings.Repeat("x", 100))
+	write("main.go", "package main\n")
+	return dir
+}
+
+func TestGatherPlannerContext_MatchesGlobsAndRespectsGitignore(t *testing.T) {
+	dir := initPlannerContextRepo(t)
+
+	got := gatherPlannerContext(dir, []string{"*.md", "docs/**"}, 1024)
+	assert.Contains(t, got, "- README.md\n")
+	assert.Contains(t, got, "- docs/design.md\n")
+	assert.Contains(t, got, "--- README.md ---\n# project\n")
+	assert.NotContains(t, got, "secret.md", "git-ignored files are excluded")
+	assert.NotContains(t, got, "main.go", "files outside the globs are excluded")
+
+	assert.Empty(t, gatherPlannerContext(dir, []string{"*.rs"}, 1024))
+	assert.Empty(t, gatherPlannerContext(dir, nil, 1024))
+}
+
+func TestGatherPlannerContext_TruncatesAtSizeCap(t *testing.T) {
+	dir := initPlannerContextRepo(t)
+
+	got := gatherPlannerContext(dir, []string{"docs/design.md"}, 10)
+	assert.Contains(t, got, "--- docs/design.md ---\n"+strings.Repeat("x", 10)+"\n… (truncated at 10 of 100 bytes)")
+	assert.NotContains(t, got, strings.Repeat("x", 11))
+}
+
+func TestSpawnPlanner_GathersRepoContextInCmd(t *testing.T) {
+	dir := initPlannerContextRepo(t)
+	h := newTestHome()
+	h.activeRepoPath = dir
+	h.appConfig.PlannerContext.Globs = []string{"README.md"}
+
+	_, cmd := h.spawnPlanner("auth", "goal")
+	require.NotNil(t, cmd, "context files are gathered in a Cmd")
+	msg, ok := cmd().(plannerPromptMsg)
+	require.True(t, ok)
+	assert.Equal(t, "auth", msg.planFile)
+	assert.Contains(t, msg.prompt, "## Repository context")
+	assert.Contains(t, msg.prompt, "# project")
+}
+
+func TestGatherPlannerContext_CapsTotalSize(t *testing.T) {
+	dir := initPlannerContextRepo(t)
+	for i := 0; i < 40; i++ {
+		name := filepath.Join(dir, "docs", fmt.Sprintf("big-%02d.md", i))
+		require.NoError(t, os.WriteFile(name, []byte(strings.Repeat("y", 8<<10)), 0o644))
+	}
+
+	got := gatherPlannerContext(dir, []string{"docs/**"}, 8<<10)
+	assert.LessOrEqual(t, len(got), plannerContextMaxBytes+256)
+	assert.Contains(t, got, "- docs/big-39.md\n", "files past the limit are still listed")
+	assert.Contains(t, got, "not inlined: context limit")
+}
@@ -0,0 +1,34 @@
+package app
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// openBrowserURL opens rawURL in the system browser. Tests override it.
+var openBrowserURL = func(rawURL string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux":
+		cmd = exec.Command("xdg-open", rawURL)
+	case "darwin":
+		cmd = exec.Command("open", rawURL)
+	default:
+		return fmt.Errorf("unsupported OS for browser open: %s", runtime.GOOS)
+	}
+	return cmd.Start()
+}
+
+// openLastPR opens the most recently created PR in the browser. The offer is
+// consumed, so a second o falls through to its normal binding.
+func (m *home) openLastPR() tea.Cmd {
+	url := m.lastPRURL
+	m.lastPRURL, m.lastPRToastID = "", ""
+	if err := openBrowserURL(url); err != nil {
+		return m.handleError(fmt.Errorf("open %s: %w", url, err))
+	}
+	return nil
+}
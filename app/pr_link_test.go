@@ -0,0 +1,62 @@
+package app
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubBrowser replaces the browser opener and returns a pointer to the URLs
+// opened with it.
+func stubBrowser(t *testing.T) *[]string {
+	t.Helper()
+	var opened []string
+	orig := openBrowserURL
+	t.Cleanup(func() { openBrowserURL = orig })
+	openBrowserURL = func(rawURL string) error {
+		opened = append(opened, rawURL)
+		return nil
+	}
+	return &opened
+}
+
+func TestPRCreatedMsg_OffersToOpenPR(t *testing.T) {
+	opened := stubBrowser(t)
+	h := newTestHome()
+	h.pendingPRToastID = h.toastManager.Loading("creating PR...")
+
+	_, _ = h.Update(prCreatedMsg{instanceTitle: "fix", prTitle: "fix", url: "https://github.com/kastheco/kasmos/pull/42"})
+	assert.Equal(t, "https://github.com/kastheco/kasmos/pull/42", h.lastPRURL)
+	require.True(t, h.toastManager.IsShowing(h.lastPRToastID))
+
+	h.keySent = true // skip the menu-highlight re-dispatch
+	_, _ = h.handleKeyPress(tea.KeyPressMsg{Code: 'o', Text: "o"})
+	assert.Equal(t, []string{"https://github.com/kastheco/kasmos/pull/42"}, *opened)
+	assert.Empty(t, h.lastPRURL, "the offer is consumed")
+
+	h.keySent = true
+	_, _ = h.handleKeyPress(tea.KeyPressMsg{Code: 'o', Text: "o"})
+	assert.Len(t, *opened, 1, "a second o falls through to its normal binding")
+}
+
+func TestPRCreatedMsg_NoURLKeepsPlainToast(t *testing.T) {
+	h := newTestHome()
+	h.pendingPRToastID = h.toastManager.Loading("creating PR...")
+
+	_, _ = h.Update(prCreatedMsg{instanceTitle: "fix", prTitle: "fix"})
+	assert.Empty(t, h.lastPRURL)
+}
+
+func TestPRCreatedForPlanMsg_OffersToOpenPR(t *testing.T) {
+	opened := stubBrowser(t)
+	h := newTestHome()
+
+	_, _ = h.Update(prCreatedForPlanMsg{planFile: "auth.md", url: "https://gitlab.com/kastheco/kasmos/-/merge_requests/7"})
+	require.True(t, h.toastManager.IsShowing(h.lastPRToastID))
+
+	h.keySent = true
+	_, _ = h.handleKeyPress(tea.KeyPressMsg{Code: 'o', Text: "o"})
+	assert.Equal(t, []string{"https://gitlab.com/kastheco/kasmos/-/merge_requests/7"}, *opened)
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/kastheco/kasmos/config"
+	"github.com/kastheco/kasmos/session"
+	"github.com/kastheco/kasmos/ui/overlay"
+)
+
+// currentSettings returns the settings in effect for this session.
+func (m *home) currentSettings() overlay.Settings {
+	return overlay.Settings{
+		DefaultProgram: m.program,
+		AutoYes:        m.autoYes,
+		AnimateBanner:  m.appConfig.AnimateBanner,
+		Notifications:  m.appConfig.AreNotificationsEnabled(),
+		Telemetry:      m.appConfig.IsTelemetryEnabled(),
+	}
+}
+
+// openSettings shows the settings form pre-filled with the current values.
+func (m *home) openSettings() (tea.Model, tea.Cmd) {
+	m.settingsBefore = m.currentSettings()
+	m.overlays.Show(overlay.NewSettingsOverlay("settings", 60, m.settingsBefore))
+	m.state = stateSettings
+	return m, nil
+}
+
+// saveSettings writes the fields the user changed in the settings form to
+// config.toml and applies them to the running session. Unchanged fields are
+// left alone so repo, flag and environment overrides never reach the file.
+// Telemetry is only read at startup, so a change to it applies on restart.
+// Nothing is saved or applied while config.toml fails to parse, since saving
+// would replace the user's file with defaults.
+func (m *home) saveSettings(after overlay.Settings) (tea.Model, tea.Cmd) {
+	before := m.settingsBefore
+	if after == before {
+		return m, tea.RequestWindowSize
+	}
+
+	if _, err := config.LoadTOMLConfig(); err != nil {
+		return m, m.handleError(fmt.Errorf("save settings: fix config.toml first: %w", err))
+	}
+	saved := config.LoadSavedConfig()
+	if after.DefaultProgram != before.DefaultProgram {
+		saved.DefaultProgram = after.DefaultProgram
+		m.program = after.DefaultProgram
+	}
+	if after.AutoYes != before.AutoYes {
+		saved.AutoYes = after.AutoYes
+		m.autoYes = after.AutoYes
+	}
+	if after.AnimateBanner != before.AnimateBanner {
+		saved.AnimateBanner = after.AnimateBanner
+		m.appConfig.AnimateBanner = after.AnimateBanner
+		m.tabbedWindow.SetAnimateBanner(after.AnimateBanner)
+	}
+	if after.Notifications != before.Notifications {
+		enabled := after.Notifications
+		saved.NotificationsEnabled = &enabled
+		m.appConfig.NotificationsEnabled = &enabled
+		session.NotificationsEnabled = enabled
+	}
+	if after.Telemetry != before.Telemetry {
+		enabled := after.Telemetry
+		saved.TelemetryEnabled = &enabled
+		m.appConfig.TelemetryEnabled = &enabled
+	}
+
+	if err := config.Save(saved); err != nil {
+		return m, m.handleError(fmt.Errorf("save settings: %w", err))
+	}
+	if after.Telemetry != before.Telemetry {
+		m.toastManager.Success("settings saved; telemetry applies on restart")
+	} else {
+		m.toastManager.Success("settings saved")
+	}
+	return m, tea.Batch(tea.RequestWindowSize, m.toastTickCmd())
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"fmt"
+	"os"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/kastheco/kasmos/session"
+)
+
+// maxUndoEntries bounds the undo stack; the oldest removal falls off first.
+const maxUndoEntries = 10
+
+// undoEntry records an instance removed from the list so it can be restored.
+type undoEntry struct {
+	inst *session.Instance
+	// tmuxDead notes that the instance's session was already gone when it was
+	// removed, so a restored instance comes back paused and can be resumed.
+	tmuxDead bool
+}
+
+// undoStack is a bounded LIFO of list removals. Only the list membership is
+// undoable — a worktree that has since been deleted is not recreated.
+type undoStack struct {
+	entries []undoEntry
+}
+
+func (s *undoStack) push(e undoEntry) {
+	if e.inst == nil {
+		return
+	}
+	s.entries = append(s.entries, e)
+	if len(s.entries) > maxUndoEntries {
+		s.entries = s.entries[len(s.entries)-maxUndoEntries:]
+	}
+}
+
+func (s *undoStack) pop() (undoEntry, bool) {
+	if len(s.entries) == 0 {
+		return undoEntry{}, false
+	}
+	e := s.entries[len(s.entries)-1]
+	s.entries = s.entries[:len(s.entries)-1]
+	return e, true
+}
+
+func (s *undoStack) len() int { return len(s.entries) }
+
+// undoLastRemoval puts the most recently removed instance back into the list,
+// re-adopting its worktree. It refuses when the worktree no longer exists.
+func (m *home) undoLastRemoval() (tea.Model, tea.Cmd) {
+	e, ok := m.undo.pop()
+	if !ok {
+		m.toastManager.Info("nothing to undo")
+		return m, m.toastTickCmd()
+	}
+	inst := e.inst
+	if path := inst.GetWorktreePath(); path != "" {
+		if _, err := os.Stat(path); err != nil {
+			m.toastManager.Error(fmt.Sprintf("cannot restore '%s': worktree is gone", inst.Title))
+			return m, m.toastTickCmd()
+		}
+	}
+	for _, existing := range m.allInstances {
+		if existing.Title == inst.Title {
+			m.toastManager.Error(fmt.Sprintf("cannot restore '%s': an instance with that name exists", inst.Title))
+			return m, m.toastTickCmd()
+		}
+	}
+
+	if e.tmuxDead && inst.Started() {
+		inst.SetStatus(session.Paused)
+	}
+	m.nav.AddInstance(inst)
+	m.allInstances = append(m.allInstances, inst)
+	m.nav.SelectInstance(inst)
+	_ = m.saveAllInstances()
+	m.updateNavPanelStatus()
+	m.toastManager.Info(fmt.Sprintf("restored '%s'", inst.Title))
+	return m, tea.Batch(tea.RequestWindowSize, m.instanceChanged(), m.toastTickCmd())
+}
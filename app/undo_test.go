@@ -0,0 +1,72 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUndoStack_PushPopIsBoundedLIFO(t *testing.T) {
+	var s undoStack
+	_, ok := s.pop()
+	assert.False(t, ok, "empty stack has nothing to pop")
+
+	s.push(undoEntry{}) // nil instance is ignored
+	assert.Equal(t, 0, s.len())
+
+	for i := 0; i < maxUndoEntries+3; i++ {
+		inst, err := newTestInstance(fmt.Sprintf("inst-%d", i))
+		require.NoError(t, err)
+		s.push(undoEntry{inst: inst})
+	}
+	assert.Equal(t, maxUndoEntries, s.len())
+
+	e, ok := s.pop()
+	require.True(t, ok)
+	assert.Equal(t, fmt.Sprintf("inst-%d", maxUndoEntries+2), e.inst.Title)
+
+	for s.len() > 1 {
+		s.pop()
+	}
+	e, _ = s.pop()
+	assert.Equal(t, "inst-3", e.inst.Title, "oldest entries fall off when the stack is full")
+}
+
+func TestUndoLastRemoval_RestoresInstanceToList(t *testing.T) {
+	h := newTestHome()
+	inst, err := newTestInstance("worker")
+	require.NoError(t, err)
+	h.nav.AddInstance(inst)
+	h.allInstances = append(h.allInstances, inst)
+
+	// Simulate the dismiss path: snapshot, then remove from both lists.
+	h.undo.push(undoEntry{inst: inst, tmuxDead: true})
+	h.nav.RemoveByTitle(inst.Title)
+	h.removeFromAllInstances(inst.Title)
+	require.Empty(t, h.allInstances)
+
+	_, _ = h.undoLastRemoval()
+
+	require.Len(t, h.allInstances, 1)
+	assert.Same(t, inst, h.allInstances[0])
+	assert.Same(t, inst, h.nav.GetSelectedInstance())
+	assert.Equal(t, 0, h.undo.len())
+}
+
+func TestUndoLastRemoval_RefusesDuplicateTitle(t *testing.T) {
+	h := newTestHome()
+	removed, err := newTestInstance("worker")
+	require.NoError(t, err)
+	replacement, err := newTestInstance("worker")
+	require.NoError(t, err)
+	h.nav.AddInstance(replacement)
+	h.allInstances = append(h.allInstances, replacement)
+	h.undo.push(undoEntry{inst: removed})
+
+	_, _ = h.undoLastRemoval()
+
+	assert.Len(t, h.allInstances, 1)
+	assert.Same(t, replacement, h.allInstances[0])
+}
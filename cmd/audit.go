@@ -1,9 +1,16 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"text/tabwriter"
+	"time"
 
 	"github.com/kastheco/kasmos/config/auditlog"
 	"github.com/kastheco/kasmos/config/taskstore"
@@ -43,9 +50,254 @@ func NewAuditCmd() *cobra.Command {
 	listCmd.Flags().IntVar(&limit, "limit", 50, "max rows")
 	listCmd.Flags().StringVar(&event, "event", "", "event kind filter")
 	auditCmd.AddCommand(listCmd)
+	auditCmd.AddCommand(newAuditExportCmd())
+	auditCmd.AddCommand(newAuditTailCmd())
 	return auditCmd
 }
 
+// newAuditExportCmd builds `kas audit export`, which dumps audit events as
+// newline-delimited JSON.
+func newAuditExportCmd() *cobra.Command {
+	var (
+		project string
+		since   string
+		kinds   []string
+		limit   int
+		outPath string
+	)
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "export audit events as newline-delimited json",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if limit < 0 {
+				return fmt.Errorf("limit must be >= 0")
+			}
+			filter := auditlog.QueryFilter{Project: project, Limit: limit}
+			if since != "" {
+				after, err := parseAuditSince(since, time.Now())
+				if err != nil {
+					return err
+				}
+				filter.After = after
+			}
+			for _, k := range kinds {
+				filter.Kinds = append(filter.Kinds, auditlog.EventKind(k))
+			}
+			if filter.Project == "" {
+				_, resolved, err := resolveRepoInfo()
+				if err != nil {
+					return err
+				}
+				filter.Project = resolved
+			}
+
+			logger, err := openAuditLogger()
+			if err != nil {
+				return err
+			}
+			defer logger.Close()
+
+			out := cmd.OutOrStdout()
+			if outPath != "" {
+				f, err := os.Create(outPath)
+				if err != nil {
+					return fmt.Errorf("create export file: %w", err)
+				}
+				defer f.Close()
+				out = f
+			}
+			n, err := executeAuditExport(logger, filter, out)
+			if err != nil {
+				return err
+			}
+			if outPath != "" {
+				fmt.Fprintf(cmd.ErrOrStderr(), "exported %d audit event(s) to %s\n", n, outPath)
+			}
+			return nil
+		},
+	}
+	exportCmd.Flags().StringVar(&project, "project", "", "project to export (default: current repo)")
+	exportCmd.Flags().StringVar(&since, "since", "", "only events after this RFC3339 time or duration ago (e.g. 24h)")
+	exportCmd.Flags().StringSliceVar(&kinds, "kind", nil, "event kind filter (repeatable)")
+	exportCmd.Flags().IntVar(&limit, "limit", 0, "max events (0 = all)")
+	exportCmd.Flags().StringVar(&outPath, "out", "", "write to file instead of stdout")
+	return exportCmd
+}
+
+// auditTailInterval is how often `kas audit tail` polls for new events.
+const auditTailInterval = time.Second
+
+// newAuditTailCmd builds `kas audit tail`, which streams new audit events to
+// stdout until interrupted.
+func newAuditTailCmd() *cobra.Command {
+	var (
+		project string
+		kinds   []string
+	)
+	tailCmd := &cobra.Command{
+		Use:   "tail",
+		Short: "follow new audit events as they are recorded",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filter := auditlog.QueryFilter{Project: project}
+			for _, k := range kinds {
+				filter.Kinds = append(filter.Kinds, auditlog.EventKind(k))
+			}
+			if filter.Project == "" {
+				_, resolved, err := resolveRepoInfo()
+				if err != nil {
+					return err
+				}
+				filter.Project = resolved
+			}
+
+			logger, err := openAuditLogger()
+			if err != nil {
+				return err
+			}
+			defer logger.Close()
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return executeAuditTail(ctx, logger, filter, cmd.OutOrStdout(), auditTailInterval)
+		},
+	}
+	tailCmd.Flags().StringVar(&project, "project", "", "project to follow (default: current repo)")
+	tailCmd.Flags().StringSliceVar(&kinds, "kind", nil, "event kind filter (repeatable)")
+	return tailCmd
+}
+
+// executeAuditTail prints events recorded after the call starts, polling every
+// interval, until ctx is cancelled. Cancellation is a clean exit.
+func executeAuditTail(ctx context.Context, logger auditlog.Logger, filter auditlog.QueryFilter, w io.Writer, interval time.Duration) error {
+	filter.After = time.Now()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		after, err := pollAuditTail(logger, filter, w)
+		if err != nil {
+			return err
+		}
+		filter.After = after
+	}
+}
+
+// pollAuditTail writes events newer than filter.After to w, oldest first, and
+// returns the newest timestamp seen (filter.After when nothing is new).
+func pollAuditTail(logger auditlog.Logger, filter auditlog.QueryFilter, w io.Writer) (time.Time, error) {
+	filter.Limit = 0
+	events, err := logger.Query(filter)
+	if err != nil {
+		return filter.After, fmt.Errorf("query audit events: %w", err)
+	}
+	latest := filter.After
+	for i := len(events) - 1; i >= 0; i-- {
+		e := events[i]
+		fmt.Fprintln(w, formatAuditTailLine(e))
+		if e.Timestamp.After(latest) {
+			latest = e.Timestamp
+		}
+	}
+	return latest, nil
+}
+
+// formatAuditTailLine renders one event as "time icon kind details".
+func formatAuditTailLine(e auditlog.Event) string {
+	ts := e.Timestamp.Local().Format("2006-01-02 15:04:05")
+	line := fmt.Sprintf("%s  %s %s", ts, e.Kind.Icon(), e.Kind)
+	if details := formatAuditDetails(e.Message, e.Detail); details != "" {
+		line += "  " + details
+	}
+	return line
+}
+
+// auditExportRecord is the NDJSON shape of an exported audit event.
+type auditExportRecord struct {
+	ID            int64  `json:"id"`
+	Kind          string `json:"kind"`
+	Timestamp     string `json:"timestamp"`
+	Project       string `json:"project,omitempty"`
+	TaskFile      string `json:"task_file,omitempty"`
+	InstanceTitle string `json:"instance_title,omitempty"`
+	AgentType     string `json:"agent_type,omitempty"`
+	WaveNumber    int    `json:"wave_number,omitempty"`
+	TaskNumber    int    `json:"task_number,omitempty"`
+	Message       string `json:"message,omitempty"`
+	Detail        string `json:"detail,omitempty"`
+	Level         string `json:"level,omitempty"`
+}
+
+// parseAuditSince accepts either an RFC3339 timestamp or a duration relative
+// to now (e.g. "24h").
+func parseAuditSince(since string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, since); err == nil {
+		return t, nil
+	}
+	if d, err := time.ParseDuration(since); err == nil && d > 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q: want RFC3339 time or duration like 24h", since)
+}
+
+// executeAuditExport queries events matching filter and writes them to w as
+// newline-delimited JSON, oldest first. The store caps each query, so results
+// are fetched in pages walking backwards along a (timestamp, id) cursor until
+// filter.Limit events (or all events, when Limit is 0) have been collected.
+// Returns the number of events written.
+func executeAuditExport(logger auditlog.Logger, filter auditlog.QueryFilter, w io.Writer) (int, error) {
+	want := filter.Limit
+	var events []auditlog.Event
+	page := filter
+	for {
+		page.Limit = 0
+		if want > 0 {
+			page.Limit = want - len(events)
+		}
+		batch, err := logger.Query(page)
+		if err != nil {
+			return 0, fmt.Errorf("query audit events: %w", err)
+		}
+		events = append(events, batch...)
+		if len(batch) == 0 || (want > 0 && len(events) >= want) {
+			break
+		}
+		oldest := batch[len(batch)-1]
+		if oldest.ID == page.BeforeID && oldest.Timestamp.Equal(page.Before) {
+			break
+		}
+		page.Before, page.BeforeID = oldest.Timestamp, oldest.ID
+	}
+
+	enc := json.NewEncoder(w)
+	for i := len(events) - 1; i >= 0; i-- {
+		e := events[i]
+		rec := auditExportRecord{
+			ID:            e.ID,
+			Kind:          string(e.Kind),
+			Timestamp:     e.Timestamp.UTC().Format(time.RFC3339),
+			Project:       e.Project,
+			TaskFile:      e.TaskFile,
+			InstanceTitle: e.InstanceTitle,
+			AgentType:     e.AgentType,
+			WaveNumber:    e.WaveNumber,
+			TaskNumber:    e.TaskNumber,
+			Message:       e.Message,
+			Detail:        e.Detail,
+			Level:         e.Level,
+		}
+		if err := enc.Encode(rec); err != nil {
+			return 0, fmt.Errorf("write audit export: %w", err)
+		}
+	}
+	return len(events), nil
+}
+
 // openAuditLogger opens the shared SQLite database for audit log queries.
 func openAuditLogger() (*auditlog.SQLiteLogger, error) {
 	return auditlog.NewSQLiteLogger(taskstore.ResolvedDBPath())
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
 	"strings"
 	"testing"
 	"time"
@@ -133,3 +135,179 @@ func TestAuditCmd_Wiring(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "list", cmd.Name())
 }
+
+func TestAuditExport_RoundTripsNDJSON(t *testing.T) {
+	logger := newTestAuditLogger(t)
+
+	t1 := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	t2 := time.Date(2026, 1, 2, 11, 0, 0, 0, time.UTC)
+	t3 := time.Date(2026, 1, 3, 12, 0, 0, 0, time.UTC)
+	logger.Emit(auditlog.Event{Kind: auditlog.EventPlanCreated, Timestamp: t1, Project: "proj", TaskFile: "plan.md", Message: "plan created"})
+	logger.Emit(auditlog.Event{Kind: auditlog.EventWaveStarted, Timestamp: t2, Project: "proj", TaskFile: "plan.md", WaveNumber: 1, Message: "wave 1 started", Level: "info"})
+	logger.Emit(auditlog.Event{Kind: auditlog.EventAgentSpawned, Timestamp: t3, Project: "proj", InstanceTitle: "plan-W1-T1", AgentType: "coder", Detail: `{"k":"v"}`})
+	logger.Emit(auditlog.Event{Kind: auditlog.EventAgentSpawned, Timestamp: t3, Project: "other", Message: "not exported"})
+
+	var buf bytes.Buffer
+	n, err := executeAuditExport(logger, auditlog.QueryFilter{Project: "proj"}, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+
+	var recs []auditExportRecord
+	for _, line := range lines {
+		var rec auditExportRecord
+		require.NoError(t, json.Unmarshal([]byte(line), &rec))
+		recs = append(recs, rec)
+	}
+
+	// Oldest first, RFC3339 timestamps, all fields preserved.
+	assert.Equal(t, "plan_created", recs[0].Kind)
+	assert.Equal(t, "2026-01-01T10:00:00Z", recs[0].Timestamp)
+	assert.Equal(t, "plan.md", recs[0].TaskFile)
+	assert.Equal(t, "wave_started", recs[1].Kind)
+	assert.Equal(t, 1, recs[1].WaveNumber)
+	assert.Equal(t, "agent_spawned", recs[2].Kind)
+	assert.Equal(t, "plan-W1-T1", recs[2].InstanceTitle)
+	assert.Equal(t, "coder", recs[2].AgentType)
+	assert.Equal(t, `{"k":"v"}`, recs[2].Detail)
+
+	ts, err := time.Parse(time.RFC3339, recs[2].Timestamp)
+	require.NoError(t, err)
+	assert.True(t, ts.Equal(t3))
+}
+
+func TestAuditExport_FiltersAndLimit(t *testing.T) {
+	logger := newTestAuditLogger(t)
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		logger.Emit(auditlog.Event{Kind: auditlog.EventAgentSpawned, Timestamp: base.Add(time.Duration(i) * time.Hour), Project: "proj"})
+	}
+	logger.Emit(auditlog.Event{Kind: auditlog.EventPlanCreated, Timestamp: base.Add(10 * time.Hour), Project: "proj"})
+
+	var buf bytes.Buffer
+	n, err := executeAuditExport(logger, auditlog.QueryFilter{
+		Project: "proj",
+		Kinds:   []auditlog.EventKind{auditlog.EventAgentSpawned},
+		After:   base.Add(30 * time.Minute),
+		Limit:   3,
+	}, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.NotContains(t, buf.String(), "plan_created")
+	// The newest three matches are kept, written oldest first.
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], "2026-01-01T02:00:00Z")
+	assert.Contains(t, lines[2], "2026-01-01T04:00:00Z")
+}
+
+func TestAuditExport_PagesPastQueryCap(t *testing.T) {
+	logger := newTestAuditLogger(t)
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	const total = 1200
+	for i := 0; i < total; i++ {
+		logger.Emit(auditlog.Event{Kind: auditlog.EventPromptSent, Timestamp: base.Add(time.Duration(i) * time.Second), Project: "proj"})
+	}
+
+	var buf bytes.Buffer
+	n, err := executeAuditExport(logger, auditlog.QueryFilter{Project: "proj"}, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, total, n)
+	assert.Equal(t, total, strings.Count(buf.String(), "\n"))
+}
+
+func TestAuditExport_PagesThroughSharedTimestamps(t *testing.T) {
+	logger := newTestAuditLogger(t)
+
+	// More events in one instant than a single query returns.
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	const total = 1500
+	for i := 0; i < total; i++ {
+		logger.Emit(auditlog.Event{Kind: auditlog.EventPromptSent, Timestamp: at, Project: "proj"})
+	}
+	logger.Emit(auditlog.Event{Kind: auditlog.EventPlanCreated, Timestamp: at.Add(-time.Second), Project: "proj"})
+
+	var buf bytes.Buffer
+	n, err := executeAuditExport(logger, auditlog.QueryFilter{Project: "proj"}, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, total+1, n)
+
+	ids := make(map[int64]bool)
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var rec auditExportRecord
+		require.NoError(t, json.Unmarshal([]byte(line), &rec))
+		assert.False(t, ids[rec.ID], "event %d exported twice", rec.ID)
+		ids[rec.ID] = true
+	}
+	assert.Len(t, ids, total+1)
+}
+
+func TestParseAuditSince(t *testing.T) {
+	now := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+
+	got, err := parseAuditSince("2026-01-15T08:00:00Z", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 15, 8, 0, 0, 0, time.UTC), got)
+
+	got, err = parseAuditSince("24h", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-24*time.Hour), got)
+
+	_, err = parseAuditSince("yesterday", now)
+	assert.Error(t, err)
+}
+
+func TestAuditCmd_ExportWiring(t *testing.T) {
+	cmd, _, err := NewRootCmd().Find([]string{"audit", "export"})
+	require.NoError(t, err)
+	assert.Equal(t, "export", cmd.Name())
+	for _, name := range []string{"project", "since", "kind", "limit", "out"} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), "missing --%s flag", name)
+	}
+}
+
+func TestPollAuditTail_PrintsNewEventsOldestFirst(t *testing.T) {
+	logger := newTestAuditLogger(t)
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	logger.Emit(auditlog.Event{Kind: auditlog.EventAgentSpawned, Project: "p", Timestamp: start.Add(-time.Minute), Message: "before tail"})
+	logger.Emit(auditlog.Event{Kind: auditlog.EventAgentSpawned, Project: "p", Timestamp: start.Add(time.Second), Message: "first"})
+	logger.Emit(auditlog.Event{Kind: auditlog.EventAgentFinished, Project: "p", Timestamp: start.Add(2 * time.Second), Message: "second"})
+	logger.Emit(auditlog.Event{Kind: auditlog.EventAgentSpawned, Project: "other", Timestamp: start.Add(3 * time.Second), Message: "other project"})
+
+	var buf bytes.Buffer
+	filter := auditlog.QueryFilter{Project: "p", After: start}
+	latest, err := pollAuditTail(logger, filter, &buf)
+	require.NoError(t, err)
+	assert.True(t, latest.Equal(start.Add(2*time.Second)))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "◆ agent_spawned  first")
+	assert.Contains(t, lines[1], "✓ agent_finished  second")
+
+	// Nothing new since latest: no output, watermark unchanged.
+	buf.Reset()
+	filter.After = latest
+	again, err := pollAuditTail(logger, filter, &buf)
+	require.NoError(t, err)
+	assert.Empty(t, buf.String())
+	assert.True(t, again.Equal(latest))
+}
+
+func TestPollAuditTail_KindFilter(t *testing.T) {
+	logger := newTestAuditLogger(t)
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	logger.Emit(auditlog.Event{Kind: auditlog.EventAgentSpawned, Project: "p", Timestamp: start.Add(time.Second), Message: "spawn"})
+	logger.Emit(auditlog.Event{Kind: auditlog.EventGitPush, Project: "p", Timestamp: start.Add(2 * time.Second), Message: "push"})
+
+	var buf bytes.Buffer
+	filter := auditlog.QueryFilter{Project: "p", After: start, Kinds: []auditlog.EventKind{auditlog.EventGitPush}}
+	_, err := pollAuditTail(logger, filter, &buf)
+	require.NoError(t, err)
+	assert.NotContains(t, buf.String(), "spawn")
+	assert.Contains(t, buf.String(), "↑ git_push  push")
+}
@@ -56,5 +56,8 @@ func NewRootCmd() *cobra.Command {
 	root.AddCommand(NewDaemonCmd())
 	root.AddCommand(NewMonitorCmd())
 	root.AddCommand(NewStatusCmd())
+	root.AddCommand(NewConfigCmd())
+	root.AddCommand(NewResumeCmd())
+	root.AddCommand(NewPermissionsCmd())
 	return root
 }
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kastheco/kasmos/config"
+	"github.com/spf13/cobra"
+)
+
+// ErrConfigInvalid is returned by `kas config validate` when the report
+// contains errors. Callers exit non-zero without printing it again.
+var ErrConfigInvalid = errors.New("config invalid")
+
+// configIssueSeverity classifies a validation finding.
+type configIssueSeverity string
+
+const (
+	configIssueError   configIssueSeverity = "error"
+	configIssueWarning configIssueSeverity = "warning"
+)
+
+// configIssue is one finding from validateConfig.
+type configIssue struct {
+	Severity configIssueSeverity
+	Subject  string
+	Message  string
+}
+
+// NewConfigCmd builds the `kas config` cobra command tree.
+func NewConfigCmd() *cobra.Command {
+	configCmd := &cobra.Command{Use: "config", Short: "inspect kasmos configuration"}
+	configCmd.AddCommand(&cobra.Command{
+		Use:   "validate",
+		Short: "check profiles, phases and the store url for mistakes",
+		Args:  cobra.NoArgs,
+		// Findings are reported in the output; the error only sets the exit code.
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := config.LoadConfig()
+			if root, _, err := resolveRepoInfo(); err == nil {
+				cfg = config.LoadConfigForRepo(root)
+			}
+			if errs := executeConfigValidate(cfg, exec.LookPath, cmd.OutOrStdout()); errs > 0 {
+				return ErrConfigInvalid
+			}
+			return nil
+		},
+	})
+	return configCmd
+}
+
+// executeConfigValidate writes a validation report for cfg to w and returns
+// the number of errors found. Warnings do not count.
+func executeConfigValidate(cfg *config.Config, lookPath func(string) (string, error), w io.Writer) int {
+	issues := validateConfig(cfg, lookPath)
+	errs := 0
+	for _, issue := range issues {
+		glyph := "!"
+		if issue.Severity == configIssueError {
+			glyph = "✗"
+			errs++
+		}
+		fmt.Fprintf(w, "%s %-8s %s: %s\n", glyph, issue.Severity, issue.Subject, issue.Message)
+	}
+	if len(issues) == 0 {
+		fmt.Fprintln(w, "✓ config ok")
+		return 0
+	}
+	fmt.Fprintf(w, "\n%d error(s), %d warning(s)\n", errs, len(issues)-errs)
+	return errs
+}
+
+// validateConfig checks cfg for mistakes that would otherwise be masked by
+// ResolveProfile silently falling back to the default program. lookPath
+// resolves program binaries; tests inject a fake.
+func validateConfig(cfg *config.Config, lookPath func(string) (string, error)) []configIssue {
+	var issues []configIssue
+	add := func(sev configIssueSeverity, subject, format string, args ...any) {
+		issues = append(issues, configIssue{Severity: sev, Subject: subject, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if bin := programBinary(cfg.DefaultProgram); bin == "" {
+		add(configIssueError, "default_program", "not set")
+	} else if _, err := lookPath(bin); err != nil {
+		add(configIssueError, "default_program", "%q not found on PATH", bin)
+	}
+
+	for _, name := range sortedProfileNames(cfg.Profiles) {
+		p := cfg.Profiles[name]
+		subject := "agents." + name
+		if !p.Enabled {
+			continue
+		}
+		bin := programBinary(p.Program)
+		if bin == "" {
+			add(configIssueError, subject, "enabled but has no program")
+			continue
+		}
+		if _, err := lookPath(bin); err != nil {
+			add(configIssueError, subject, "program %q not found on PATH", bin)
+		}
+		if msg := checkModelID(bin, p.Model); msg != "" {
+			add(configIssueWarning, subject, "%s", msg)
+		}
+	}
+
+	phases := make([]string, 0, len(cfg.PhaseRoles))
+	for phase := range cfg.PhaseRoles {
+		phases = append(phases, phase)
+	}
+	sort.Strings(phases)
+	for _, phase := range phases {
+		role := cfg.PhaseRoles[phase]
+		subject := "phases." + phase
+		p, ok := cfg.Profiles[role]
+		switch {
+		case !ok:
+			add(configIssueError, subject, "references unknown agent %q; falls back to %q", role, cfg.ResolveProfile(phase, cfg.DefaultProgram).BuildCommand())
+		case !p.Enabled:
+			add(configIssueError, subject, "agent %q is disabled; falls back to %q", role, cfg.ResolveProfile(phase, cfg.DefaultProgram).BuildCommand())
+		}
+	}
+
+	if cfg.DatabaseURL != "" {
+		if msg := checkStoreURL(cfg.DatabaseURL); msg != "" {
+			add(configIssueError, "database_url", "%s", msg)
+		}
+	}
+	return issues
+}
+
+// programBinary returns the executable name from a program string, which may
+// carry arguments (e.g. "claude --verbose").
+func programBinary(program string) string {
+	fields := strings.Fields(program)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// checkModelID returns a description of why model does not look normalized
+// for the given program binary, or "" when it looks fine.
+func checkModelID(bin, model string) string {
+	if model == "" {
+		return ""
+	}
+	if strings.TrimSpace(model) != model || strings.ContainsAny(model, " \t") {
+		return fmt.Sprintf("model %q contains whitespace", model)
+	}
+	if model != strings.ToLower(model) {
+		return fmt.Sprintf("model %q should be lowercase", model)
+	}
+	switch filepath.Base(bin) {
+	case "opencode":
+		if !strings.Contains(model, "/") {
+			return fmt.Sprintf("model %q is not provider-qualified (e.g. anthropic/%s)", model, model)
+		}
+	case "claude":
+		if strings.Contains(model, "/") {
+			return fmt.Sprintf("model %q should be a bare claude model id", model)
+		}
+	}
+	return ""
+}
+
+// checkStoreURL returns a description of why raw is not a usable store URL,
+// or "" when it parses as an http(s) URL with a host.
+func checkStoreURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Sprintf("invalid url: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Sprintf("%q must use http or https", raw)
+	}
+	if u.Host == "" {
+		return fmt.Sprintf("%q has no host", raw)
+	}
+	return ""
+}
+
+func sortedProfileNames(profiles map[string]config.AgentProfile) []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
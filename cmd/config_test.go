@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/kastheco/kasmos/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLookPath resolves only the given binaries.
+func fakeLookPath(bins ...string) func(string) (string, error) {
+	return func(name string) (string, error) {
+		for _, b := range bins {
+			if b == name {
+				return "/usr/bin/" + name, nil
+			}
+		}
+		return "", errors.New("not found")
+	}
+}
+
+func validTestConfig() *config.Config {
+	cfg := config.DefaultConfig()
+	cfg.DefaultProgram = "claude"
+	cfg.DatabaseURL = ""
+	cfg.Profiles = map[string]config.AgentProfile{
+		"coder":   {Program: "opencode", Model: "anthropic/claude-sonnet-4-5", Enabled: true},
+		"planner": {Program: "claude", Model: "claude-opus-4-1", Enabled: true},
+		"spare":   {Program: "missing-binary", Enabled: false},
+	}
+	cfg.PhaseRoles = map[string]string{
+		"implementing": "coder",
+		"planning":     "planner",
+	}
+	return cfg
+}
+
+func TestValidateConfig_Good(t *testing.T) {
+	cfg := validTestConfig()
+	cfg.DatabaseURL = "http://localhost:7433"
+
+	var out bytes.Buffer
+	errs := executeConfigValidate(cfg, fakeLookPath("claude", "opencode"), &out)
+
+	assert.Equal(t, 0, errs)
+	assert.Contains(t, out.String(), "config ok")
+}
+
+func TestValidateConfig_Broken(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutate   func(cfg *config.Config)
+		severity configIssueSeverity
+		subject  string
+		contains string
+	}{
+		{
+			name: "profile binary missing",
+			mutate: func(cfg *config.Config) {
+				cfg.Profiles["coder"] = config.AgentProfile{Program: "nope", Enabled: true}
+			},
+			severity: configIssueError,
+			subject:  "agents.coder",
+			contains: `"nope" not found`,
+		},
+		{
+			name: "phase references unknown agent",
+			mutate: func(cfg *config.Config) {
+				cfg.PhaseRoles["quality_review"] = "reviewer"
+			},
+			severity: configIssueError,
+			subject:  "phases.quality_review",
+			contains: `unknown agent "reviewer"; falls back to "claude"`,
+		},
+		{
+			name: "phase maps to disabled agent",
+			mutate: func(cfg *config.Config) {
+				cfg.PhaseRoles["fixer"] = "spare"
+			},
+			severity: configIssueError,
+			subject:  "phases.fixer",
+			contains: `agent "spare" is disabled`,
+		},
+		{
+			name: "store url without scheme",
+			mutate: func(cfg *config.Config) {
+				cfg.DatabaseURL = "localhost:7433"
+			},
+			severity: configIssueError,
+			subject:  "database_url",
+			contains: "http or https",
+		},
+		{
+			name: "opencode model not provider-qualified",
+			mutate: func(cfg *config.Config) {
+				cfg.Profiles["coder"] = config.AgentProfile{Program: "opencode", Model: "claude-sonnet-4-5", Enabled: true}
+			},
+			severity: configIssueWarning,
+			subject:  "agents.coder",
+			contains: "not provider-qualified",
+		},
+		{
+			name: "default program missing",
+			mutate: func(cfg *config.Config) {
+				cfg.DefaultProgram = "aider --yes"
+			},
+			severity: configIssueError,
+			subject:  "default_program",
+			contains: `"aider" not found`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validTestConfig()
+			tt.mutate(cfg)
+
+			issues := validateConfig(cfg, fakeLookPath("claude", "opencode"))
+			require.Len(t, issues, 1, "issues: %+v", issues)
+			assert.Equal(t, tt.severity, issues[0].Severity)
+			assert.Equal(t, tt.subject, issues[0].Subject)
+			assert.Contains(t, issues[0].Message, tt.contains)
+
+			var out bytes.Buffer
+			errs := executeConfigValidate(cfg, fakeLookPath("claude", "opencode"), &out)
+			if tt.severity == configIssueError {
+				assert.Equal(t, 1, errs)
+			} else {
+				assert.Equal(t, 0, errs)
+			}
+			assert.Contains(t, out.String(), tt.subject)
+		})
+	}
+}
+
+func TestNewRootCmd_HasConfigValidate(t *testing.T) {
+	cmd, _, err := NewRootCmd().Find([]string{"config", "validate"})
+	require.NoError(t, err)
+	assert.Equal(t, "validate", cmd.Name())
+}
@@ -3,11 +3,10 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"os/exec"
-	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"text/tabwriter"
 	"time"
 
@@ -113,54 +112,18 @@ func statusLabel(s instanceStatus) string {
 // whiteSpaceRe matches one or more whitespace characters for session name sanitisation.
 var whiteSpaceRe = regexp.MustCompile(`\s+`)
 
-// kasTmuxName converts a human-readable instance title to the kas_-prefixed tmux
+// tmuxPrefix returns the configured tmux session prefix (tmux_prefix in
+// config.toml, default "kas_"). Resolved once per process.
+var tmuxPrefix = sync.OnceValue(config.ResolveTmuxPrefix)
+
+// kasTmuxName converts a human-readable instance title to the prefixed tmux
 // session name used by the session package.  It replicates toKasTmuxName from
 // session/tmux without importing that package (which would create a cycle:
 // session/tmux → cmd → session/tmux).
 func kasTmuxName(title string) string {
 	name := whiteSpaceRe.ReplaceAllString(title, "")
 	name = strings.ReplaceAll(name, ".", "_")
-	return "kas_" + name
-}
-
-// buildResumeCommand reconstructs the tmux program command string for a resumed
-// instance.  It mirrors the env-var and flag injection performed by TmuxSession.Start()
-// so that the resumed agent is indistinguishable from a freshly started one.
-func buildResumeCommand(rec instanceRecord, worktreePath string) string {
-	program := rec.Program
-
-	// Append --dangerously-skip-permissions for Claude if originally enabled.
-	if rec.SkipPermissions && strings.HasSuffix(program, "claude") {
-		program += " --dangerously-skip-permissions"
-	}
-
-	// Append --agent flag for typed roles (planner, coder, reviewer, fixer).
-	if rec.AgentType != "" && !strings.Contains(program, "--agent") {
-		program += " --agent " + rec.AgentType
-	}
-
-	// Append opencode log redirection so debug logs are preserved.
-	// Use rec.Program (the unmodified base) for the suffix check, matching
-	// TmuxSession.Start() which uses t.program — the local `program` variable
-	// may already have --agent appended, changing the suffix.
-	if strings.HasSuffix(rec.Program, "opencode") {
-		logDir := filepath.Join(worktreePath, ".kasmos", "logs")
-		if err := os.MkdirAll(logDir, 0o755); err == nil {
-			logFile := filepath.Join(logDir, kasTmuxName(rec.Title)+".log")
-			program += " --print-logs 2>>'" + logFile + "'"
-		}
-	}
-
-	// Prepend KASMOS_MANAGED=1 so the agent knows it is managed by kasmos.
-	program = "KASMOS_MANAGED=1 " + program
-
-	// Prepend task identity env vars for parallel wave execution.
-	if rec.TaskNumber > 0 {
-		program = fmt.Sprintf("KASMOS_TASK=%d KASMOS_WAVE=%d KASMOS_PEERS=%d %s",
-			rec.TaskNumber, rec.WaveNumber, rec.PeerCount, program)
-	}
-
-	return program
+	return tmuxPrefix() + name
 }
 
 // executeInstanceList reads raw InstancesData from state, optionally filters by
@@ -371,6 +334,55 @@ func updateInstanceInState(state config.StateManager, title string, updater func
 	return state.SaveInstances(raw)
 }
 
+// ResumeInstance restores a paused instance from its stored JSON and returns
+// the worktree path it now runs in. cmd cannot import session (session/tmux
+// imports cmd), so main wires it to session.FromInstanceData and
+// Instance.Resume, the same path the TUI resumes through.
+var ResumeInstance func(data json.RawMessage) (worktreePath string, err error)
+
+// findInstanceJSON returns the stored JSON of the instance titled title, so
+// fields instanceRecord does not mirror survive the trip to ResumeInstance.
+func findInstanceJSON(state config.StateManager, title string) (json.RawMessage, error) {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(state.GetInstances(), &raws); err != nil {
+		return nil, fmt.Errorf("parse instances: %w", err)
+	}
+	for _, raw := range raws {
+		var head struct {
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(raw, &head); err == nil && head.Title == title {
+			return raw, nil
+		}
+	}
+	return nil, fmt.Errorf("instance not found: %q", title)
+}
+
+// resumeInstanceRecord resumes a paused instance through ResumeInstance and
+// marks it running in state. The caller validates the status.
+func resumeInstanceRecord(state config.StateManager, rec instanceRecord) error {
+	if rec.Worktree.RepoPath == "" || rec.Worktree.BranchName == "" {
+		return fmt.Errorf("instance %q has no stored worktree metadata; cannot resume", rec.Title)
+	}
+	if ResumeInstance == nil {
+		return fmt.Errorf("resume is not available in this build")
+	}
+	raw, err := findInstanceJSON(state, rec.Title)
+	if err != nil {
+		return err
+	}
+	worktreePath, err := ResumeInstance(raw)
+	if err != nil {
+		return err
+	}
+	// Update state: mark as running and store the restored worktree path.
+	return updateInstanceInState(state, rec.Title, func(r *instanceRecord) error {
+		r.Status = instanceRunning
+		r.Worktree.WorktreePath = worktreePath
+		return nil
+	})
+}
+
 func ensureCleanWorktree(worktreePath, action string) error {
 	if strings.TrimSpace(worktreePath) == "" {
 		return nil
@@ -582,32 +594,7 @@ func NewInstanceCmd() *cobra.Command {
 			if err := validateStatusForAction(rec, "resume"); err != nil {
 				return err
 			}
-			if rec.Worktree.RepoPath == "" || rec.Worktree.BranchName == "" {
-				return fmt.Errorf("instance %q has no stored worktree metadata; cannot resume", rec.Title)
-			}
-			// Derive the worktree path from the repo path and session name.
-			worktreePath := rec.Worktree.WorktreePath
-			if worktreePath == "" {
-				// Reconstruct using the conventional path: <worktree-root>/<title>
-				worktreePath = rec.Path
-			}
-			// Re-add the git worktree on the preserved branch.
-			if err := exec.Command("git", "-C", rec.Worktree.RepoPath, "worktree", "add", worktreePath, rec.Worktree.BranchName).Run(); err != nil {
-				return fmt.Errorf("recreate worktree: %w", err)
-			}
-			// Reconstruct the full program command (env vars + flags) matching the original session.
-			program := buildResumeCommand(rec, worktreePath)
-			// Start a new tmux session using the kas_-prefixed name.
-			sessionName := kasTmuxName(rec.Title)
-			if err := exec.Command("tmux", "new-session", "-d", "-s", sessionName, "-c", worktreePath, program).Run(); err != nil {
-				return fmt.Errorf("start tmux session: %w", err)
-			}
-			// Update state: mark as running and store the restored worktree path.
-			if err := updateInstanceInState(state, rec.Title, func(r *instanceRecord) error {
-				r.Status = instanceRunning
-				r.Worktree.WorktreePath = worktreePath
-				return nil
-			}); err != nil {
+			if err := resumeInstanceRecord(state, rec); err != nil {
 				return err
 			}
 			fmt.Printf("resumed: %s\n", rec.Title)
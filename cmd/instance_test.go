@@ -403,71 +403,6 @@ func TestKillCmd_SetsInstanceToPaused(t *testing.T) {
 	assert.Empty(t, target.Worktree.WorktreePath, "worktree path should be cleared")
 }
 
-// TestBuildResumeCommand_BasicClaude verifies that a basic Claude instance gets
-// KASMOS_MANAGED=1 prepended and no extra flags for default settings.
-func TestBuildResumeCommand_BasicClaude(t *testing.T) {
-	rec := instanceRecord{
-		Title:   "my-coder",
-		Program: "claude",
-	}
-	got := buildResumeCommand(rec, "/worktrees/my-coder")
-	assert.Equal(t, "KASMOS_MANAGED=1 claude", got)
-}
-
-// TestBuildResumeCommand_SkipPermissions verifies --dangerously-skip-permissions is appended for Claude.
-func TestBuildResumeCommand_SkipPermissions(t *testing.T) {
-	rec := instanceRecord{
-		Title:           "my-coder",
-		Program:         "claude",
-		SkipPermissions: true,
-	}
-	got := buildResumeCommand(rec, "/worktrees/my-coder")
-	assert.Contains(t, got, "--dangerously-skip-permissions")
-	assert.Contains(t, got, "KASMOS_MANAGED=1")
-}
-
-// TestBuildResumeCommand_AgentType verifies --agent flag is injected.
-func TestBuildResumeCommand_AgentType(t *testing.T) {
-	rec := instanceRecord{
-		Title:     "my-coder",
-		Program:   "claude",
-		AgentType: "coder",
-	}
-	got := buildResumeCommand(rec, "/worktrees/my-coder")
-	assert.Contains(t, got, "--agent coder")
-}
-
-// TestBuildResumeCommand_OpencodeWithAgent verifies that opencode log redirection
-// is included even when --agent is appended (regression: the suffix check must
-// use the unmodified base program, not the local variable).
-func TestBuildResumeCommand_OpencodeWithAgent(t *testing.T) {
-	workdir := t.TempDir()
-	rec := instanceRecord{
-		Title:     "my-planner",
-		Program:   "opencode",
-		AgentType: "planner",
-	}
-	got := buildResumeCommand(rec, workdir)
-	assert.Contains(t, got, "--agent planner")
-	assert.Contains(t, got, "--print-logs")
-	assert.Contains(t, got, "KASMOS_MANAGED=1")
-}
-
-// TestBuildResumeCommand_TaskEnvVars verifies task identity env vars are prepended.
-func TestBuildResumeCommand_TaskEnvVars(t *testing.T) {
-	rec := instanceRecord{
-		Title:      "my-coder",
-		Program:    "claude",
-		TaskNumber: 3,
-		WaveNumber: 2,
-		PeerCount:  4,
-	}
-	got := buildResumeCommand(rec, "/worktrees/my-coder")
-	assert.Contains(t, got, "KASMOS_TASK=3")
-	assert.Contains(t, got, "KASMOS_WAVE=2")
-	assert.Contains(t, got, "KASMOS_PEERS=4")
-}
-
 // TestSummarizeInstanceStatus_Mixed verifies aggregation across all known and
 // unknown status values including instanceLoading (which counts as running).
 func TestSummarizeInstanceStatus_Mixed(t *testing.T) {
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/kastheco/kasmos/config"
+	"github.com/kastheco/kasmos/config/taskstore"
+	"github.com/spf13/cobra"
+)
+
+// NewPermissionsCmd builds the `kas permissions` cobra command tree.
+func NewPermissionsCmd() *cobra.Command {
+	permCmd := &cobra.Command{Use: "permissions", Short: "share remembered \"allow always\" permission decisions"}
+	permCmd.AddCommand(newPermissionsExportCmd())
+	permCmd.AddCommand(newPermissionsImportCmd())
+	return permCmd
+}
+
+// newPermissionsExportCmd builds `kas permissions export`, which writes the
+// project's remembered decisions as JSON.
+func newPermissionsExportCmd() *cobra.Command {
+	var project, outPath string
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "export remembered permissions as json",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, project, err := openPermissionStore(project)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			out := cmd.OutOrStdout()
+			if outPath != "" {
+				f, err := os.Create(outPath)
+				if err != nil {
+					return fmt.Errorf("create export file: %w", err)
+				}
+				defer f.Close()
+				out = f
+			}
+			n, err := executePermissionsExport(store, project, out)
+			if err != nil {
+				return err
+			}
+			if outPath != "" {
+				fmt.Fprintf(cmd.ErrOrStderr(), "exported %d permission(s) to %s\n", n, outPath)
+			}
+			return nil
+		},
+	}
+	exportCmd.Flags().StringVar(&project, "project", "", "project to export (default: current repo)")
+	exportCmd.Flags().StringVar(&outPath, "out", "", "write to file instead of stdout")
+	return exportCmd
+}
+
+// newPermissionsImportCmd builds `kas permissions import <file>`, which merges
+// an exported file into the project's remembered decisions.
+func newPermissionsImportCmd() *cobra.Command {
+	var project string
+	importCmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "merge exported permissions into this project",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("read import file: %w", err)
+			}
+			store, project, err := openPermissionStore(project)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			n, err := executePermissionsImport(store, project, data)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "imported %d permission(s) into %s\n", n, project)
+			return nil
+		},
+	}
+	importCmd.Flags().StringVar(&project, "project", "", "project to import into (default: current repo)")
+	return importCmd
+}
+
+// openPermissionStore opens the shared permission store with the repo's
+// permission_cache_ttl_days applied, and resolves project to the current
+// repo's project when empty.
+func openPermissionStore(project string) (*config.SQLitePermissionStore, string, error) {
+	cfg := config.LoadConfig()
+	if root, resolved, err := resolveRepoInfo(); err == nil {
+		cfg = config.LoadConfigForRepo(root)
+		if project == "" {
+			project = resolved
+		}
+	} else if project == "" {
+		return nil, "", err
+	}
+	store, err := config.NewSQLitePermissionStore(taskstore.ResolvedDBPath())
+	if err != nil {
+		return nil, "", fmt.Errorf("open permission store: %w", err)
+	}
+	store.SetTTL(time.Duration(cfg.PermissionCacheTTLDays) * 24 * time.Hour)
+	return store, project, nil
+}
+
+// executePermissionsExport writes project's unexpired decisions to w and
+// returns how many were written.
+func executePermissionsExport(store *config.SQLitePermissionStore, project string, w io.Writer) (int, error) {
+	entries, err := store.Entries(project)
+	if err != nil {
+		return 0, err
+	}
+	if err := config.WritePermissionExport(w, project, entries); err != nil {
+		return 0, fmt.Errorf("write permission export: %w", err)
+	}
+	return len(entries), nil
+}
+
+// executePermissionsImport validates an exported document and merges it into
+// project. Nothing is written when the document is malformed. Returns how
+// many patterns were added or refreshed.
+func executePermissionsImport(store *config.SQLitePermissionStore, project string, data []byte) (int, error) {
+	entries, err := config.ParsePermissionExport(data)
+	if err != nil {
+		return 0, err
+	}
+	return store.Merge(project, entries)
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kastheco/kasmos/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPermissionStore(t *testing.T) *config.SQLitePermissionStore {
+	t.Helper()
+	store, err := config.NewSQLitePermissionStore(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestPermissionsExportImport_MergesIntoProject(t *testing.T) {
+	src := newTestPermissionStore(t)
+	src.Remember("team", "/opt/*")
+	src.Remember("team", "Execute bash command")
+
+	var buf bytes.Buffer
+	n, err := executePermissionsExport(src, "team", &buf)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	dst := newTestPermissionStore(t)
+	dst.Remember("mine", "/tmp/*")
+	n, err = executePermissionsImport(dst, "mine", buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"/opt/*", "/tmp/*", "Execute bash command"}, dst.ListPatterns("mine"))
+
+	n, err = executePermissionsImport(dst, "mine", buf.Bytes())
+	require.NoError(t, err)
+	assert.Zero(t, n, "re-importing the same file changes nothing")
+}
+
+func TestPermissionsImport_MalformedLeavesStoreUntouched(t *testing.T) {
+	dst := newTestPermissionStore(t)
+	dst.Remember("mine", "/tmp/*")
+
+	doc := `{"version": 1, "permissions": [
+		{"pattern": "/opt/*", "created_at": "2026-01-01T00:00:00Z"},
+		{"pattern": "", "created_at": "2026-01-01T00:00:00Z"}
+	]}`
+	_, err := executePermissionsImport(dst, "mine", []byte(doc))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing pattern")
+	assert.Equal(t, []string{"/tmp/*"}, dst.ListPatterns("mine"))
+}
+
+func TestPermissionsCmd_Wiring(t *testing.T) {
+	cmd, _, err := NewRootCmd().Find([]string{"permissions", "import"})
+	require.NoError(t, err)
+	assert.Equal(t, "import", cmd.Name())
+}
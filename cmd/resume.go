@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/kastheco/kasmos/config"
+	"github.com/spf13/cobra"
+)
+
+// planResumeResult lists the outcome of resuming a plan's paused instances.
+type planResumeResult struct {
+	Resumed []string
+	Failed  map[string]error
+}
+
+// matchesPlan reports whether taskFile names plan; the .md suffix is optional.
+func matchesPlan(taskFile, plan string) bool {
+	return taskFile != "" && strings.TrimSuffix(taskFile, ".md") == strings.TrimSuffix(plan, ".md")
+}
+
+// executeResumePlan resumes every paused instance record belonging to plan with
+// resume, continuing past failures. Instances of other plans and instances
+// that are not paused are left untouched.
+func executeResumePlan(state config.StateManager, plan string, resume func(instanceRecord) error) (planResumeResult, error) {
+	res := planResumeResult{Failed: map[string]error{}}
+	records, err := loadInstanceRecords(state)
+	if err != nil {
+		return res, err
+	}
+	for _, rec := range records {
+		if !matchesPlan(rec.TaskFile, plan) || rec.Status != instancePaused {
+			continue
+		}
+		if err := resume(rec); err != nil {
+			res.Failed[rec.Title] = err
+			continue
+		}
+		res.Resumed = append(res.Resumed, rec.Title)
+	}
+	return res, nil
+}
+
+// NewResumeCmd builds the `kas resume <plan>` command.
+func NewResumeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "resume <plan-file>",
+		Short: "resume every paused agent instance of a plan",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			state := config.LoadState()
+			res, err := executeResumePlan(state, args[0], func(rec instanceRecord) error {
+				return resumeInstanceRecord(state, rec)
+			})
+			if err != nil {
+				return err
+			}
+			for _, title := range res.Resumed {
+				fmt.Printf("resumed: %s\n", title)
+			}
+			for _, title := range slices.Sorted(maps.Keys(res.Failed)) {
+				fmt.Printf("failed: %s: %v\n", title, res.Failed[title])
+			}
+			if len(res.Resumed) == 0 && len(res.Failed) == 0 {
+				fmt.Printf("no paused instances for %s\n", args[0])
+				return nil
+			}
+			if len(res.Failed) > 0 {
+				return fmt.Errorf("resumed %d of %d instances", len(res.Resumed), len(res.Resumed)+len(res.Failed))
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteResumePlan_ResumesOnlyPausedPlanInstances(t *testing.T) {
+	state := newTestStateFromRecords(t, []instanceRecord{
+		{Title: "auth-coder", Status: instancePaused, TaskFile: "auth.md"},
+		{Title: "auth-reviewer", Status: instancePaused, TaskFile: "auth.md"},
+		{Title: "auth-fixer", Status: instanceRunning, TaskFile: "auth.md"},
+		{Title: "billing-coder", Status: instancePaused, TaskFile: "billing.md"},
+		{Title: "solo", Status: instancePaused},
+	})
+
+	var called []string
+	res, err := executeResumePlan(state, "auth", func(rec instanceRecord) error {
+		called = append(called, rec.Title)
+		if rec.Title == "auth-reviewer" {
+			return errors.New("branch is checked out")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"auth-coder", "auth-reviewer"}, called, "the .md suffix is optional")
+	assert.Equal(t, []string{"auth-coder"}, res.Resumed)
+	assert.ErrorContains(t, res.Failed["auth-reviewer"], "checked out")
+}
+
+func TestResumeInstanceRecord_ResumesThroughSession(t *testing.T) {
+	rec := instanceRecord{
+		Title:    "auth-coder",
+		Status:   instancePaused,
+		TaskFile: "auth.md",
+		Worktree: instanceWorktree{RepoPath: "/repo", BranchName: "plan/auth", WorktreePath: "/repo/.worktrees/plan-auth"},
+	}
+	state := newTestStateFromRecords(t, []instanceRecord{rec, {Title: "other", Status: instancePaused}})
+
+	orig := ResumeInstance
+	t.Cleanup(func() { ResumeInstance = orig })
+	var got map[string]any
+	ResumeInstance = func(data json.RawMessage) (string, error) {
+		require.NoError(t, json.Unmarshal(data, &got))
+		return "/repo/.worktrees/plan-auth", nil
+	}
+
+	require.NoError(t, resumeInstanceRecord(state, rec))
+	assert.Equal(t, "auth-coder", got["title"], "the instance's own stored record is resumed")
+
+	records, err := loadInstanceRecords(state)
+	require.NoError(t, err)
+	assert.Equal(t, instanceRunning, records[0].Status)
+	assert.Equal(t, "/repo/.worktrees/plan-auth", records[0].Worktree.WorktreePath)
+	assert.Equal(t, instancePaused, records[1].Status)
+}
+
+func TestResumeInstanceRecord_FailureLeavesStatePaused(t *testing.T) {
+	rec := instanceRecord{
+		Title:    "auth-coder",
+		Status:   instancePaused,
+		Worktree: instanceWorktree{RepoPath: "/repo", BranchName: "plan/auth"},
+	}
+	state := newTestStateFromRecords(t, []instanceRecord{rec})
+
+	orig := ResumeInstance
+	t.Cleanup(func() { ResumeInstance = orig })
+	ResumeInstance = func(json.RawMessage) (string, error) { return "", errors.New("branch is checked out") }
+
+	assert.ErrorContains(t, resumeInstanceRecord(state, rec), "checked out")
+	records, err := loadInstanceRecords(state)
+	require.NoError(t, err)
+	assert.Equal(t, instancePaused, records[0].Status)
+}
@@ -55,13 +55,13 @@ type statusData struct {
 //   - ex: executor for tmux discovery
 //   - format: "text" or "json"
 func executeStatus(state config.StateManager, store taskstore.Store, project string, ex Executor, format string) string {
-	// 1. Tasks section — filter to non-done, non-cancelled entries.
+	// 1. Tasks section — filter to non-done, non-cancelled, non-archived entries.
 	tasks := make([]statusTask, 0)
 	if store != nil {
 		entries, err := store.List(project)
 		if err == nil {
 			for _, e := range entries {
-				if e.Status == taskstore.StatusCancelled || e.Status == taskstore.StatusDone {
+				if e.Status == taskstore.StatusCancelled || e.Status == taskstore.StatusDone || e.Status == taskstore.StatusArchived {
 					continue
 				}
 				tasks = append(tasks, statusTask{
@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -42,7 +43,7 @@ func executeTaskRegister(project, filePath, branch, topic, description string, s
 		}
 	}
 	if branch == "" {
-		branch = "plan/" + planFile
+		branch = git.TaskBranchFromFile(planFile)
 	}
 	info, _ := os.Stat(filePath)
 	createdAt := info.ModTime()
@@ -51,7 +52,7 @@ func executeTaskRegister(project, filePath, branch, topic, description string, s
 
 // executeTaskList returns a formatted string listing all plans, optionally
 // filtered by status. Exported for testing without cobra plumbing.
-// When statusFilter is empty, cancelled tasks are hidden from the output.
+// When statusFilter is empty, cancelled and archived tasks are hidden from the output.
 func executeTaskList(project, statusFilter string, store taskstore.Store) string {
 	ps, err := loadTaskStateByProject(project, store)
 	if err != nil {
@@ -62,7 +63,7 @@ func executeTaskList(project, statusFilter string, store taskstore.Store) string
 		if statusFilter != "" && string(info.Status) != statusFilter {
 			continue
 		}
-		if statusFilter == "" && string(info.Status) == string(taskstore.StatusCancelled) {
+		if statusFilter == "" && (string(info.Status) == string(taskstore.StatusCancelled) || string(info.Status) == string(taskstore.StatusArchived)) {
 			continue
 		}
 		line := fmt.Sprintf("%-14s %-50s %s", info.Status, info.Filename, info.Branch)
@@ -74,7 +75,7 @@ func executeTaskList(project, statusFilter string, store taskstore.Store) string
 // executeTaskListWithStore returns a formatted string listing all plans from a
 // remote store backend. storeURL is the base URL of the task store server
 // (e.g. "http://athena:7433") and project is the project name to query.
-// When statusFilter is empty, cancelled tasks are hidden from the output.
+// When statusFilter is empty, cancelled and archived tasks are hidden from the output.
 func executeTaskListWithStore(storeURL, project, statusFilter string) string {
 	store := taskstore.NewHTTPStore(storeURL, project)
 	ps, err := taskstate.Load(store, project, "")
@@ -86,7 +87,7 @@ func executeTaskListWithStore(storeURL, project, statusFilter string) string {
 		if statusFilter != "" && string(info.Status) != statusFilter {
 			continue
 		}
-		if statusFilter == "" && string(info.Status) == string(taskstore.StatusCancelled) {
+		if statusFilter == "" && (string(info.Status) == string(taskstore.StatusCancelled) || string(info.Status) == string(taskstore.StatusArchived)) {
 			continue
 		}
 		line := fmt.Sprintf("%-14s %-50s %s", info.Status, info.Filename, info.Branch)
@@ -122,6 +123,7 @@ func executeTaskTransition(project, planFile, event string, store taskstore.Stor
 		"reimplement":        taskfsm.Reimplement,
 		"cancel":             taskfsm.Cancel,
 		"reopen":             taskfsm.Reopen,
+		"archive":            taskfsm.Archive,
 	}
 	fsmEvent, ok := eventMap[event]
 	if !ok {
@@ -143,6 +145,74 @@ func executeTaskTransition(project, planFile, event string, store taskstore.Stor
 	return string(entry.Status), nil
 }
 
+// executeTaskArchive archives, through the task FSM, every done or cancelled
+// plan that completed more than olderThan before now. Plans whose completion
+// time is unknown are left alone. Returns the archived filenames, sorted.
+func executeTaskArchive(project string, olderThan time.Duration, now time.Time, store taskstore.Store) ([]string, error) {
+	ps, err := loadTaskStateByProject(project, store)
+	if err != nil {
+		return nil, err
+	}
+	fsm := newFSMByProject(project, store)
+	cutoff := now.Add(-olderThan)
+	archived := make([]string, 0)
+	for _, info := range ps.List() {
+		if info.Status != taskstate.StatusDone && info.Status != taskstate.StatusCancelled {
+			continue
+		}
+		completedAt, ok := ps.CompletedAt(info.Filename)
+		if !ok || !completedAt.Before(cutoff) {
+			continue
+		}
+		if err := fsm.Transition(info.Filename, taskfsm.Archive); err != nil {
+			return archived, err
+		}
+		archived = append(archived, info.Filename)
+	}
+	return archived, nil
+}
+
+// executeTaskReport renders the markdown task report for project. Instance
+// counts come from state; pass nil to leave them at zero.
+func executeTaskReport(project string, store taskstore.Store, state config.StateManager) (string, error) {
+	ps, err := loadTaskStateByProject(project, store)
+	if err != nil {
+		return "", err
+	}
+	var counts map[string]int
+	if state != nil {
+		records, err := loadInstanceRecords(state)
+		if err != nil {
+			return "", err
+		}
+		counts = make(map[string]int, len(records))
+		for _, r := range records {
+			if r.TaskFile != "" {
+				counts[r.TaskFile]++
+			}
+		}
+	}
+	return ps.RenderReport(counts), nil
+}
+
+// parseAge parses a duration that additionally accepts a day suffix
+// (e.g. "30d", "1.5d") on top of the units understood by time.ParseDuration.
+func parseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.ParseFloat(days, 64)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid age %q: want a duration like 30d or 72h", s)
+		}
+		return time.Duration(n * float64(24*time.Hour)), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age %q: want a duration like 30d or 72h", s)
+	}
+	return d, nil
+}
+
 // executeTaskImplement transitions a plan into implementing state and writes
 // a wave signal file so the TUI metadata tick can pick it up.
 func executeTaskImplement(repoRoot, project, planFile string, wave int, store taskstore.Store) error {
@@ -298,12 +368,13 @@ func resolveTaskEntry(project, filename string, store taskstore.Store) (taskstat
 }
 
 // executeTaskCreate creates a new task entry in the store. name is the plan slug.
-// branch defaults to "plan/<name>" when empty. If content is non-empty, it is
-// stored alongside the metadata.
+// branch defaults to "plan/<name>" when empty; the command renders the configured
+// branch_template before calling it. If content is non-empty, it is stored
+// alongside the metadata.
 func executeTaskCreate(project, name, description, branch, topic, content string, store taskstore.Store) error {
 	filename := name
 	if branch == "" {
-		branch = "plan/" + name
+		branch = git.TaskBranchFromFile(name)
 	}
 	ps, err := loadTaskStateByProject(project, store)
 	if err != nil {
@@ -459,8 +530,8 @@ func executeTaskStartOver(repoRoot, project, planFile string, store taskstore.St
 
 // executeTaskPR resolves the task entry, derives the PR title from the task
 // description when title is empty, generates a PR body from the git log, and
-// creates (or reopens) the PR via the GitHub CLI. The PR URL is printed to
-// stdout by the gh CLI; the returned string is currently always empty.
+// creates (or reopens) the PR via the GitHub CLI. Returns the PR URL, or ""
+// when the CLI did not print one.
 func executeTaskPR(repoRoot, project, planFile, title string, store taskstore.Store) (string, error) {
 	if store == nil {
 		var err error
@@ -509,10 +580,7 @@ func executeTaskPR(repoRoot, project, planFile, title string, store taskstore.St
 	}
 	subtasks, _ := store.GetSubtasks(project, planFile)
 	body := git.BuildPRBody(buildCLIPRMetadata(entry, subtasks, gitChanges, gitCommits, gitStats))
-	if err := wt.CreatePR(title, body, "update from kas"); err != nil {
-		return "", err
-	}
-	return "", nil
+	return wt.CreateReview(title, body, "update from kas")
 }
 
 func buildCLIPRMetadata(
@@ -571,7 +639,7 @@ func NewTaskCmd() *cobra.Command {
 			return nil
 		},
 	}
-	listCmd.Flags().StringVar(&statusFilter, "status", "", "filter by status (ready, planning, implementing, reviewing, done, cancelled)")
+	listCmd.Flags().StringVar(&statusFilter, "status", "", "filter by status (ready, planning, implementing, reviewing, done, cancelled, archived)")
 	planCmd.AddCommand(listCmd)
 
 	// kq plan register
@@ -581,18 +649,24 @@ func NewTaskCmd() *cobra.Command {
 		Short: "register an untracked task file (sets status to ready)",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, project, err := resolveRepoInfo()
+			root, project, err := resolveRepoInfo()
 			if err != nil {
 				return err
 			}
-			if err := executeTaskRegister(project, args[0], branchFlag, topicFlag, descriptionFlag, resolveStore(project)); err != nil {
+			branch := branchFlag
+			if branch == "" {
+				if branch, err = newTaskBranch(root, strings.TrimSuffix(filepath.Base(args[0]), ".md")); err != nil {
+					return err
+				}
+			}
+			if err := executeTaskRegister(project, args[0], branch, topicFlag, descriptionFlag, resolveStore(project)); err != nil {
 				return err
 			}
 			fmt.Printf("registered: %s → ready\n", filepath.Base(args[0]))
 			return nil
 		},
 	}
-	registerCmd.Flags().StringVar(&branchFlag, "branch", "", "override branch name (default: plan/<slug>)")
+	registerCmd.Flags().StringVar(&branchFlag, "branch", "", "override branch name (default: rendered branch_template)")
 	registerCmd.Flags().StringVar(&topicFlag, "topic", "", "assign plan to a topic group (auto-creates topic if needed)")
 	registerCmd.Flags().StringVar(&descriptionFlag, "description", "", "override description (default: extracted from first # heading)")
 	planCmd.AddCommand(registerCmd)
@@ -723,12 +797,18 @@ func NewTaskCmd() *cobra.Command {
 		Short: "create a new task entry in the store",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, project, err := resolveRepoInfo()
+			root, project, err := resolveRepoInfo()
 			if err != nil {
 				return err
 			}
 			name := args[0]
-			if err := executeTaskCreate(project, name, createDescription, createBranch, createTopic, createContent, resolveStore(project)); err != nil {
+			branch := createBranch
+			if branch == "" {
+				if branch, err = newTaskBranch(root, name); err != nil {
+					return err
+				}
+			}
+			if err := executeTaskCreate(project, name, createDescription, branch, createTopic, createContent, resolveStore(project)); err != nil {
 				return err
 			}
 			fmt.Printf("created: %s → ready\n", name)
@@ -736,7 +816,7 @@ func NewTaskCmd() *cobra.Command {
 		},
 	}
 	createCmd.Flags().StringVar(&createDescription, "description", "", "task description")
-	createCmd.Flags().StringVar(&createBranch, "branch", "", "git branch name (default: plan/<name>)")
+	createCmd.Flags().StringVar(&createBranch, "branch", "", "git branch name (default: rendered branch_template)")
 	createCmd.Flags().StringVar(&createTopic, "topic", "", "topic group")
 	createCmd.Flags().StringVar(&createContent, "content", "", "initial plan content (markdown)")
 	planCmd.AddCommand(createCmd)
@@ -786,7 +866,7 @@ func NewTaskCmd() *cobra.Command {
 	var prTitle string
 	prCmd := &cobra.Command{
 		Use:   "pr <plan-file>",
-		Short: "push and open a pull request for the task branch",
+		Short: "push and open a pull request for the task branch, then open it in the browser",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			repoRoot, project, err := resolveRepoInfo()
@@ -799,6 +879,9 @@ func NewTaskCmd() *cobra.Command {
 			}
 			if url != "" {
 				fmt.Println(url)
+				if err := browserOpenURL(url); err != nil {
+					fmt.Fprintf(os.Stderr, "could not open browser: %v\n", err)
+				}
 			}
 			return nil
 		},
@@ -844,6 +927,64 @@ func NewTaskCmd() *cobra.Command {
 	}
 	planCmd.AddCommand(startOverCmd)
 
+	// kas task archive
+	var olderThan string
+	archiveCmd := &cobra.Command{
+		Use:   "archive",
+		Short: "archive done and cancelled tasks that finished before a cutoff",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			age, err := parseAge(olderThan)
+			if err != nil {
+				return err
+			}
+			_, project, err := resolveRepoInfo()
+			if err != nil {
+				return err
+			}
+			archived, err := executeTaskArchive(project, age, time.Now(), resolveStore(project))
+			for _, name := range archived {
+				fmt.Printf("%s → archived\n", name)
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Printf("archived %d task(s)\n", len(archived))
+			return nil
+		},
+	}
+	archiveCmd.Flags().StringVar(&olderThan, "older-than", "30d", "only archive tasks finished longer ago than this (e.g. 30d, 72h)")
+	planCmd.AddCommand(archiveCmd)
+
+	// kas task report
+	var reportOut string
+	reportCmd := &cobra.Command{
+		Use:   "report",
+		Short: "write a markdown summary of all tasks (stdout or --out)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, project, err := resolveRepoInfo()
+			if err != nil {
+				return err
+			}
+			report, err := executeTaskReport(project, resolveStore(project), config.LoadState())
+			if err != nil {
+				return err
+			}
+			if reportOut == "" {
+				fmt.Print(report)
+				return nil
+			}
+			if err := os.WriteFile(reportOut, []byte(report), 0o644); err != nil {
+				return fmt.Errorf("write report: %w", err)
+			}
+			fmt.Printf("wrote %s\n", reportOut)
+			return nil
+		},
+	}
+	reportCmd.Flags().StringVarP(&reportOut, "out", "o", "", "write the report to this file instead of stdout")
+	planCmd.AddCommand(reportCmd)
+
 	// kq plan link-clickup
 	var linkProject string
 	linkClickUpCmd := &cobra.Command{
@@ -877,6 +1018,50 @@ func NewTaskCmd() *cobra.Command {
 	linkClickUpCmd.Flags().StringVar(&linkProject, "project", "", "project name (default: derived from current directory)")
 	planCmd.AddCommand(linkClickUpCmd)
 
+	// kas task migrate
+	var migrateTo, migrateProject string
+	migrateCmd := &cobra.Command{
+		Use:   "migrate --to <url>",
+		Short: "copy all tasks from the current store into another task store",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, repoProject, err := resolveRepoInfo()
+			if err != nil {
+				return err
+			}
+			src, project := resolveStoreConfig(repoProject)
+			if src == nil {
+				src, err = localSQLiteStore()
+				if err != nil {
+					return fmt.Errorf("open local task store: %w", err)
+				}
+				project = repoProject
+			}
+			defer src.Close()
+			if migrateProject != "" {
+				project = migrateProject
+			}
+			dst, err := taskstore.NewStoreFromConfig(migrateTo, project)
+			if err != nil {
+				return fmt.Errorf("open destination store: %w", err)
+			}
+			defer dst.Close()
+			if err := dst.Ping(); err != nil {
+				return fmt.Errorf("destination store unreachable: %w", err)
+			}
+			migrated, skipped, err := taskstore.MigrateStore(src, dst, project)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("migrated %d task(s), skipped %d existing\n", migrated, skipped)
+			return nil
+		},
+	}
+	migrateCmd.Flags().StringVar(&migrateTo, "to", "", "destination store URL (e.g. http://host:7433)")
+	migrateCmd.Flags().StringVar(&migrateProject, "project", "", "project name (default: derived from current directory)")
+	_ = migrateCmd.MarkFlagRequired("to")
+	planCmd.AddCommand(migrateCmd)
+
 	return planCmd
 }
 
@@ -957,6 +1142,14 @@ func resolveRepoInfo() (repoRoot, project string, err error) {
 	return root, resolveTaskProject(root), nil
 }
 
+// newTaskBranch renders the repo's branch_template for a plan being created,
+// naming it the way the TUI does. Plans already stored without a branch are
+// backfilled with git.TaskBranchFromFile instead, which does not drift.
+func newTaskBranch(repoRoot, planFile string) (string, error) {
+	cfg := config.LoadConfigForRepo(repoRoot)
+	return git.RenderTaskBranch(cfg.BranchTemplate, planFile, config.Username(), time.Now())
+}
+
 // resolveRepoRoot delegates to the shared config-level resolver so task
 // commands and config/state paths stay aligned on the same repository root.
 func resolveRepoRoot(dir string) (string, error) {
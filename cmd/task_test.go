@@ -492,6 +492,24 @@ func TestExecuteTaskCreate_DefaultBranch(t *testing.T) {
 	assert.Equal(t, "plan/auto-branch", entry.Branch)
 }
 
+func TestNewTaskBranch_RendersRepoTemplate(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	repo := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, ".kasmos"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ".kasmos", "config.toml"),
+		[]byte("branch_template = \"feature/{slug}\"\n"), 0o644))
+
+	branch, err := newTaskBranch(repo, "auth-refactor")
+	require.NoError(t, err)
+	assert.Equal(t, "feature/auth-refactor", branch)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ".kasmos", "config.toml"),
+		[]byte("branch_template = \"no-slug\"\n"), 0o644))
+	_, err = newTaskBranch(repo, "auth-refactor")
+	assert.ErrorContains(t, err, "{slug}", "an invalid template is reported, not replaced")
+}
+
 func TestResolveTaskEntry(t *testing.T) {
 	store, _, project := setupTestPlanState(t)
 	entry, err := resolveTaskEntry(project, "test-plan", store)
@@ -753,3 +771,80 @@ func TestPlanList_WithStore(t *testing.T) {
 	assert.Contains(t, output, "cancelled.md")
 	assert.NotContains(t, output, "test.md")
 }
+
+func TestExecuteTaskArchive(t *testing.T) {
+	store := taskstore.NewTestSQLiteStore(t)
+	project := "archive-test"
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := now.Add(-45 * 24 * time.Hour)
+	recent := now.Add(-5 * 24 * time.Hour)
+
+	for _, e := range []taskstore.TaskEntry{
+		{Filename: "old-done", Status: taskstore.StatusDone, CreatedAt: old, DoneAt: old},
+		{Filename: "recent-done", Status: taskstore.StatusDone, CreatedAt: old, DoneAt: recent},
+		{Filename: "old-cancelled", Status: taskstore.StatusCancelled, CreatedAt: old,
+			History: []taskstore.StatusChange{{Status: taskstore.StatusCancelled, At: old}}},
+		{Filename: "recent-cancelled", Status: taskstore.StatusCancelled, CreatedAt: old,
+			History: []taskstore.StatusChange{{Status: taskstore.StatusCancelled, At: recent}}},
+		{Filename: "untracked-cancelled", Status: taskstore.StatusCancelled, CreatedAt: old},
+		{Filename: "old-ready", Status: taskstore.StatusReady, CreatedAt: old},
+	} {
+		require.NoError(t, store.Create(project, e))
+	}
+
+	archived, err := executeTaskArchive(project, 30*24*time.Hour, now, store)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"old-cancelled", "old-done"}, archived)
+
+	ps, err := taskstate.Load(store, project, "")
+	require.NoError(t, err)
+	for name, want := range map[string]taskstate.Status{
+		"old-done":            taskstate.StatusArchived,
+		"old-cancelled":       taskstate.StatusArchived,
+		"recent-done":         taskstate.StatusDone,
+		"recent-cancelled":    taskstate.StatusCancelled,
+		"untracked-cancelled": taskstate.StatusCancelled,
+		"old-ready":           taskstate.StatusReady,
+	} {
+		entry, ok := ps.Entry(name)
+		require.True(t, ok)
+		assert.Equal(t, want, entry.Status, name)
+	}
+	entry, _ := ps.Entry("old-done")
+	require.NotEmpty(t, entry.History, "archiving goes through the FSM")
+	assert.Equal(t, taskstate.StatusArchived, entry.History[len(entry.History)-1].Status)
+
+	output := executeTaskList(project, "", store)
+	assert.NotContains(t, output, "old-done", "archived tasks are hidden by default")
+	assert.Contains(t, executeTaskList(project, "archived", store), "old-done")
+}
+
+func TestParseAge(t *testing.T) {
+	d, err := parseAge("30d")
+	require.NoError(t, err)
+	assert.Equal(t, 30*24*time.Hour, d)
+
+	d, err = parseAge("72h")
+	require.NoError(t, err)
+	assert.Equal(t, 72*time.Hour, d)
+
+	for _, bad := range []string{"", "abc", "-1d", "xd"} {
+		_, err := parseAge(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestExecuteTaskReport(t *testing.T) {
+	store, _, project := setupTestPlanState(t)
+	state := newTestStateFromRaw(t, []instanceTestData{
+		{Title: "impl-coder", TaskFile: "implementing-plan"},
+		{Title: "impl-reviewer", TaskFile: "implementing-plan"},
+		{Title: "scratch"},
+	})
+
+	report, err := executeTaskReport(project, store, state)
+	require.NoError(t, err)
+	assert.Contains(t, report, "| test-plan | ready | plan/test-plan | 0 | test plan |")
+	assert.Contains(t, report, "| implementing-plan | implementing | plan/implementing-plan | 2 | implementing plan |")
+	assert.Contains(t, report, "| cancelled-plan | cancelled | plan/cancelled-plan | 0 | cancelled plan |")
+}
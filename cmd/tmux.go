@@ -45,13 +45,13 @@ func relativeAge(now, created time.Time) string {
 }
 
 // discoverKasSessions queries `tmux ls -F ...` through the injected Executor,
-// parses the output, and returns only kas_-prefixed sessions.
+// parses the output, and returns only sessions with the configured prefix.
 //
 // Behavior mirrors session/tmux.DiscoverAll (tmux_session.go:591):
 //   - *exec.ExitError (no server, no sessions) → empty list, no error.
 //   - Non-ExitError → propagated as error.
 //   - Malformed lines (fewer than 6 pipe-separated fields) → silently skipped.
-//   - Non-kas_ sessions → silently ignored.
+//   - Sessions without the configured prefix → silently ignored.
 //
 // The known map is keyed by raw tmux session names (e.g. "kas_foo").
 // Sessions whose name appears in known are marked Managed = true.
@@ -76,7 +76,7 @@ func discoverKasSessions(ex Executor, known map[string]struct{}) ([]tmuxSessionR
 			continue
 		}
 		name := parts[0]
-		if !strings.HasPrefix(name, "kas_") {
+		if !strings.HasPrefix(name, tmuxPrefix()) {
 			continue
 		}
 
@@ -92,7 +92,7 @@ func discoverKasSessions(ex Executor, known map[string]struct{}) ([]tmuxSessionR
 		_, managed := known[name]
 		rows = append(rows, tmuxSessionRow{
 			Name:     name,
-			Title:    strings.TrimPrefix(name, "kas_"),
+			Title:    strings.TrimPrefix(name, tmuxPrefix()),
 			Created:  created,
 			Windows:  windows,
 			Attached: attached,
@@ -10,6 +10,46 @@ func (k EventKind) String() string {
 	return string(k)
 }
 
+// Icon returns the single-glyph marker used when rendering the event kind in
+// the audit pane and `kas audit tail`.
+func (k EventKind) Icon() string {
+	switch k {
+	case EventAgentSpawned:
+		return "◆"
+	case EventAgentFinished, EventPermissionAnswered:
+		return "✓"
+	case EventAgentKilled, EventPlanCancelled:
+		return "✕"
+	case EventAgentPaused:
+		return "⏸"
+	case EventAgentResumed, EventSessionStarted, EventPlanReopened:
+		return "▶"
+	case EventPlanTransition:
+		return "⟳"
+	case EventPlanCreated:
+		return "✦"
+	case EventPlanMerged:
+		return "⇒"
+	case EventWaveStarted, EventWaveCompleted, EventWaveFailed:
+		return "↯"
+	case EventPromptSent:
+		return "→"
+	case EventGitPush:
+		return "↑"
+	case EventGitRebase:
+		return "↻"
+	case EventPRCreated:
+		return "⎇"
+	case EventPermissionDetected, EventFSMError, EventError, EventMergeConflict, EventResourceAlert,
+		EventDetachedHead, EventReviewCycleLimit:
+		return "!"
+	case EventSessionStopped:
+		return "■"
+	default:
+		return "·"
+	}
+}
+
 // Lifecycle events.
 const (
 	EventAgentSpawned   EventKind = "agent_spawned"
@@ -26,6 +66,9 @@ const (
 	EventPlanCreated    EventKind = "plan_created"
 	EventPlanMerged     EventKind = "plan_merged"
 	EventPlanCancelled  EventKind = "plan_cancelled"
+	EventPlanReopened   EventKind = "plan_reopened"
+	// EventReviewCycleLimit records the review→fix loop hitting max_review_fix_cycles.
+	EventReviewCycleLimit EventKind = "review_cycle_limit"
 )
 
 // Wave events.
@@ -39,11 +82,16 @@ const (
 const (
 	EventPromptSent         EventKind = "prompt_sent"
 	EventGitPush            EventKind = "git_push"
+	EventGitRebase          EventKind = "git_rebase"
 	EventPRCreated          EventKind = "pr_created"
 	EventPermissionDetected EventKind = "permission_detected"
 	EventPermissionAnswered EventKind = "permission_answered"
 	EventFSMError           EventKind = "fsm_error"
 	EventError              EventKind = "error"
+	EventMergeConflict      EventKind = "merge_conflict"
+	EventResourceAlert      EventKind = "resource_alert"
+	EventDetachedHead       EventKind = "detached_head"
+	EventBranchRenamed      EventKind = "branch_renamed"
 )
 
 // Session lifecycle events.
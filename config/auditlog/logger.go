@@ -10,7 +10,11 @@ type QueryFilter struct {
 	Kinds         []EventKind
 	Limit         int
 	Before        time.Time
-	After         time.Time
+	// BeforeID, when set with Before, turns Before into a (timestamp, id)
+	// cursor: events at exactly Before with an ID below BeforeID are still
+	// returned, so pages never skip events sharing a timestamp.
+	BeforeID int64
+	After    time.Time
 }
 
 // Logger is the interface for emitting and querying audit events.
@@ -123,8 +123,13 @@ func (l *SQLiteLogger) Query(f QueryFilter) ([]Event, error) {
 		args = append(args, auditFormatTime(f.After))
 	}
 	if !f.Before.IsZero() {
-		conditions = append(conditions, "timestamp < ?")
-		args = append(args, auditFormatTime(f.Before))
+		if f.BeforeID > 0 {
+			conditions = append(conditions, "(timestamp < ? OR (timestamp = ? AND id < ?))")
+			args = append(args, auditFormatTime(f.Before), auditFormatTime(f.Before), f.BeforeID)
+		} else {
+			conditions = append(conditions, "timestamp < ?")
+			args = append(args, auditFormatTime(f.Before))
+		}
 	}
 
 	q := `
@@ -135,7 +140,7 @@ func (l *SQLiteLogger) Query(f QueryFilter) ([]Event, error) {
 	if len(conditions) > 0 {
 		q += " WHERE " + strings.Join(conditions, " AND ")
 	}
-	q += fmt.Sprintf(" ORDER BY timestamp DESC LIMIT %d", limit)
+	q += fmt.Sprintf(" ORDER BY timestamp DESC, id DESC LIMIT %d", limit)
 
 	rows, err := l.db.Query(q, args...)
 	if err != nil {
@@ -8,7 +8,9 @@ import (
 	"os/user"
 	"path/filepath"
 	"regexp"
+	"runtime"
 	"strings"
+	"time"
 
 	"github.com/kastheco/kasmos/log"
 )
@@ -161,32 +163,125 @@ func copyIfMissing(src, dst string) {
 }
 
 // Config holds all persistent application configuration.
+//
+// Precedence, lowest to highest: built-in defaults, config.toml, a repo-local
+// .kasmos/config.toml (LoadConfigForRepo), then KASMOS_* environment variables
+// for the fields that document one.
 type Config struct {
 	// DefaultProgram is the command launched for new instances.
+	// Overridden by KASMOS_PROGRAM.
 	DefaultProgram string `json:"default_program"`
+	// ReviewerProgram, when set, is the command launched for reviewer agents
+	// instead of the quality_review profile. Overridden by --reviewer-program.
+	ReviewerProgram string `json:"reviewer_program,omitempty"`
 	// AutoYes makes the daemon automatically accept all agent prompts.
+	// Overridden by KASMOS_AUTOYES.
 	AutoYes bool `json:"auto_yes"`
+	// AutoYesPatterns, when set, limits auto-yes to permission prompts whose
+	// pattern matches one of these globs or whose description contains one of
+	// these strings. Other prompts still block for a human answer.
+	AutoYesPatterns []string `json:"auto_yes_patterns,omitempty"`
+	// MetadataTickMs is how often (ms) the TUI polls agent sessions for
+	// output, status and signals. Clamped to [MinMetadataTickMs,
+	// MaxMetadataTickMs]; 0 uses DefaultMetadataTickMs. The daemon polls on
+	// its own, slower DaemonPollInterval.
+	MetadataTickMs int `json:"metadata_tick_ms,omitempty"`
+	// MetadataWorkers caps how many sessions the TUI polls in parallel on
+	// each metadata tick. 0 uses runtime.NumCPU().
+	MetadataWorkers int `json:"metadata_workers,omitempty"`
 	// DaemonPollInterval is how often (ms) the daemon checks sessions.
 	DaemonPollInterval int `json:"daemon_poll_interval"`
+	// DaemonAddr, when set (e.g. "127.0.0.1:7434"), makes the auto-yes daemon
+	// serve GET /status, GET /metrics and GET /healthz over HTTP for monitoring. Empty
+	// disables the server.
+	DaemonAddr string `json:"daemon_addr,omitempty"`
 	// BranchPrefix is prepended to git branch names created by the app.
 	BranchPrefix string `json:"branch_prefix"`
+	// BranchTemplate names the branch of a new plan. {slug}, {user} and
+	// {date} expand to the plan name, the current user and YYYY-MM-DD.
+	// Empty uses "plan/{slug}".
+	BranchTemplate string `json:"branch_template,omitempty"`
+	// Theme selects the UI palette: "dark" (default), "light", or "auto" to
+	// follow the terminal background.
+	Theme string `json:"theme,omitempty"`
+	// PermissionCacheTTLDays expires remembered "allow always" permission
+	// decisions after this many days. 0 keeps them forever.
+	PermissionCacheTTLDays int `json:"permission_cache_ttl_days,omitempty"`
+	// CPUAlertPercent warns when an agent's CPU usage stays above this
+	// percentage for several consecutive metadata ticks. 0 disables the alert.
+	CPUAlertPercent float64 `json:"cpu_alert_percent,omitempty"`
+	// MemAlertMB warns when an agent's memory stays above this many megabytes
+	// for several consecutive metadata ticks. 0 disables the alert.
+	MemAlertMB float64 `json:"mem_alert_mb,omitempty"`
+	// IdleAutoPauseMinutes pauses an agent that has sat Ready with no pane
+	// activity for this many minutes, freeing its session and worktree.
+	// 0 disables auto-pause.
+	IdleAutoPauseMinutes int `json:"idle_auto_pause_minutes,omitempty"`
+	// DefaultDraftPR opens pull requests created from the TUI as drafts unless
+	// toggled off in the PR flow.
+	DefaultDraftPR bool `json:"default_draft_pr,omitempty"`
+	// AutoPush pushes a coder's branch whenever new commits appear in its
+	// worktree. Plans can override it individually.
+	AutoPush bool `json:"auto_push,omitempty"`
+	// ClickUpWatchTag, when set and a ClickUp MCP server is detected, polls
+	// ClickUp for tasks with this tag and imports new ones as ready plans.
+	ClickUpWatchTag string `json:"clickup_watch_tag,omitempty"`
+	// ClickUpWatchIntervalSec is how often (seconds) the ClickUp watch polls.
+	// 0 uses DefaultClickUpWatchIntervalSec; smaller values are raised to
+	// MinClickUpWatchIntervalSec.
+	ClickUpWatchIntervalSec int `json:"clickup_watch_interval_sec,omitempty"`
+	// MaxInstances caps the number of kasmos tmux sessions that may run at
+	// once. 0 uses DefaultMaxInstances; smaller values are raised to
+	// MinMaxInstances.
+	MaxInstances int `json:"max_instances,omitempty"`
+	// ClickUpStatusMap maps kasmos plan statuses (e.g. "reviewing", "done") to
+	// ClickUp status names. When a plan linked to a ClickUp task enters a mapped
+	// status, the ClickUp task is moved to the mapped status. Empty disables it.
+	ClickUpStatusMap map[string]string `json:"clickup_status_map,omitempty"`
+	// PlansDir is where plan files, wave state and legacy plan-state.json live,
+	// relative to the repo root unless absolute. Defaults to "docs/plans".
+	PlansDir string `json:"plans_dir,omitempty"`
+	// TmuxPrefix is prepended to the tmux session names kasmos creates and
+	// scopes session discovery, counting and cleanup. Defaults to "kas_".
+	TmuxPrefix string `json:"tmux_prefix,omitempty"`
+	// RecordSessions records each spawned agent session under ~/.kasmos/recordings
+	// (asciinema when installed, capture-pane snapshots otherwise).
+	RecordSessions bool `json:"record_sessions,omitempty"`
+	// Container runs agent programs inside a container instead of on the host.
+	Container ContainerConfig `json:"container,omitempty"`
+	// PlannerContext preloads repository files into the planner's prompt.
+	PlannerContext PlannerContextConfig `json:"planner_context,omitempty"`
 	// NotificationsEnabled controls desktop notifications; defaults to true when nil.
 	NotificationsEnabled *bool `json:"notifications_enabled,omitempty"`
+	// Notifiers selects the notification backends ("desktop", "slack").
+	// Empty means desktop only.
+	Notifiers []string `json:"notifiers,omitempty"`
+	// SlackWebhookURL is the incoming-webhook URL used by the "slack" notifier.
+	SlackWebhookURL string `json:"slack_webhook_url,omitempty"`
 	// Profiles maps role names to agent program configurations.
 	Profiles map[string]AgentProfile `json:"profiles,omitempty"`
 	// PhaseRoles maps lifecycle phase names to role names.
 	PhaseRoles map[string]string `json:"phase_roles,omitempty"`
 	// AnimateBanner enables the idle banner animation (off by default).
 	AnimateBanner bool `json:"animate_banner,omitempty"`
+	// VimMode maps h/j/k/l to sidebar navigation and makes `i` the only way
+	// into focus mode (enter no longer attaches to an instance).
+	VimMode bool `json:"vim_mode,omitempty"`
 	// AutoAdvanceWaves skips the confirmation dialog after a clean wave.
 	AutoAdvanceWaves bool `json:"auto_advance_waves,omitempty"`
 	// AutoReviewFix enables the automatic review→fix→re-review loop.
 	AutoReviewFix bool `json:"auto_review_fix,omitempty"`
 	// MaxReviewFixCycles caps the review-fix loop iterations (0 = unlimited).
+	// Once exceeded, kasmos stops auto-spawning fixers and asks the user.
 	MaxReviewFixCycles int `json:"max_review_fix_cycles,omitempty"`
+	// LogFormat selects the kas.log line format: "text" (default) or "json"
+	// for JSON lines with ts, level and msg fields.
+	LogFormat string `json:"log_format,omitempty"`
 	// TelemetryEnabled controls Sentry crash reporting; defaults to true when nil.
+	// Overridden by KASMOS_TELEMETRY.
 	TelemetryEnabled *bool `json:"telemetry_enabled,omitempty"`
 	// DatabaseURL is the remote kasmos store URL; uses local file when empty.
+	// Overridden by KASMOS_PLAN_STORE.
 	DatabaseURL string `json:"database_url,omitempty"`
 	// Hooks configures FSM transition hooks loaded from config.toml.
 	Hooks []TOMLHook `json:"hooks,omitempty"`
@@ -194,6 +289,53 @@ type Config struct {
 	// blueprint-skip mode is used instead of wave orchestration.
 	// When nil, the default threshold of 2 applies.
 	BlueprintSkipThresholdValue *int `json:"blueprint_skip_threshold,omitempty"`
+	// MaxWaveConcurrencyValue caps how many task instances of a wave run at once.
+	// When nil, the default of 4 applies; 0 disables the cap.
+	MaxWaveConcurrencyValue *int `json:"max_wave_concurrency,omitempty"`
+	// WaveTaskTimeoutMinutesValue is how long a wave task may run before it is
+	// failed as hung. When nil, the default of 30 applies; 0 disables the timeout.
+	WaveTaskTimeoutMinutesValue *int `json:"wave_task_timeout_minutes,omitempty"`
+}
+
+// ContainerConfig selects the container agents run in. The zero value runs
+// agents on the host.
+type ContainerConfig struct {
+	// Image runs each agent in a fresh `docker run` of this image, with the
+	// worktree and repo mounted at their host paths.
+	Image string `json:"image,omitempty"`
+	// Devcontainer runs agents through `devcontainer exec` in worktrees that
+	// have a devcontainer configuration. It takes precedence over Image.
+	Devcontainer bool `json:"devcontainer,omitempty"`
+}
+
+// Enabled reports whether any container runtime is configured.
+func (c ContainerConfig) Enabled() bool {
+	return c.Image != "" || c.Devcontainer
+}
+
+// DefaultPlannerContextMaxFileBytes is how much of each matched file the
+// planner context inlines when MaxFileBytes is unset.
+const DefaultPlannerContextMaxFileBytes = 8 * 1024
+
+// PlannerContextConfig selects repository files to preload into planner
+// prompts. The zero value adds nothing.
+type PlannerContextConfig struct {
+	// Globs select files by their slash-separated path relative to the repo
+	// root; "**" matches any number of directories. Git-ignored files are
+	// never included.
+	Globs []string `json:"globs,omitempty"`
+	// MaxFileBytes caps how much of each matched file's content is inlined;
+	// longer files are truncated. 0 uses DefaultPlannerContextMaxFileBytes.
+	MaxFileBytes int `json:"max_file_bytes,omitempty"`
+}
+
+// FileByteLimit returns MaxFileBytes, or DefaultPlannerContextMaxFileBytes
+// when it is not positive.
+func (c PlannerContextConfig) FileByteLimit() int {
+	if c.MaxFileBytes <= 0 {
+		return DefaultPlannerContextMaxFileBytes
+	}
+	return c.MaxFileBytes
 }
 
 // BlueprintSkipThreshold returns the configured threshold for single-agent mode.
@@ -206,6 +348,25 @@ func (c *Config) BlueprintSkipThreshold() int {
 	return *c.BlueprintSkipThresholdValue
 }
 
+// MaxWaveConcurrency returns the maximum number of wave task instances that may
+// run at once. Remaining tasks are queued until a slot frees up.
+// Defaults to 4 when not configured; 0 means unlimited.
+func (c *Config) MaxWaveConcurrency() int {
+	if c.MaxWaveConcurrencyValue == nil {
+		return 4
+	}
+	return *c.MaxWaveConcurrencyValue
+}
+
+// WaveTaskTimeoutMinutes returns how many minutes a wave task may run before it
+// is marked failed. Defaults to 30 when not configured; 0 disables the timeout.
+func (c *Config) WaveTaskTimeoutMinutes() int {
+	if c.WaveTaskTimeoutMinutesValue == nil {
+		return 30
+	}
+	return *c.WaveTaskTimeoutMinutesValue
+}
+
 // applyConfigDefaults fills in zero-value fields of cfg with sensible defaults.
 // It is nil-safe and centralises the default logic shared by DefaultConfig and configFromTOML.
 func applyConfigDefaults(cfg *Config) {
@@ -224,9 +385,139 @@ func applyConfigDefaults(cfg *Config) {
 	if cfg.DaemonPollInterval == 0 {
 		cfg.DaemonPollInterval = 1000
 	}
+	cfg.MetadataTickMs = clampMetadataTickMs(cfg.MetadataTickMs)
 	if cfg.BranchPrefix == "" {
 		cfg.BranchPrefix = branchPrefix()
 	}
+	if cfg.TmuxPrefix == "" {
+		cfg.TmuxPrefix = DefaultTmuxPrefix
+	}
+	if cfg.PlansDir == "" {
+		cfg.PlansDir = DefaultPlansDir
+	}
+}
+
+// DefaultMaxReviewFixCycles is the review-fix cycle cap used when
+// max_review_fix_cycles is not set.
+const DefaultMaxReviewFixCycles = 5
+
+// Metadata tick bounds, in milliseconds.
+const (
+	DefaultMetadataTickMs = 200
+	MinMetadataTickMs     = 50
+	MaxMetadataTickMs     = 2000
+)
+
+// clampMetadataTickMs maps 0 to DefaultMetadataTickMs and pins other values
+// into [MinMetadataTickMs, MaxMetadataTickMs].
+func clampMetadataTickMs(ms int) int {
+	switch {
+	case ms == 0:
+		return DefaultMetadataTickMs
+	case ms < MinMetadataTickMs:
+		return MinMetadataTickMs
+	case ms > MaxMetadataTickMs:
+		return MaxMetadataTickMs
+	}
+	return ms
+}
+
+// MetadataTickInterval returns MetadataTickMs as a duration, falling back to
+// the default for an unset or out-of-range value.
+func (c *Config) MetadataTickInterval() time.Duration {
+	return time.Duration(clampMetadataTickMs(c.MetadataTickMs)) * time.Millisecond
+}
+
+// Instance limit bounds. The minimum leaves room for a plan's coder and
+// reviewer to run side by side.
+const (
+	DefaultMaxInstances = 20
+	MinMaxInstances     = 2
+)
+
+// MetadataWorkerCount returns MetadataWorkers, or runtime.NumCPU() when it
+// is not positive.
+func (c *Config) MetadataWorkerCount() int {
+	if c == nil || c.MetadataWorkers <= 0 {
+		return runtime.NumCPU()
+	}
+	return c.MetadataWorkers
+}
+
+// InstanceLimit returns MaxInstances, using the default when unset and never
+// less than MinMaxInstances.
+func (c *Config) InstanceLimit() int {
+	if c == nil || c.MaxInstances == 0 {
+		return DefaultMaxInstances
+	}
+	if c.MaxInstances < MinMaxInstances {
+		return MinMaxInstances
+	}
+	return c.MaxInstances
+}
+
+// ClickUp watch poll bounds, in seconds.
+const (
+	DefaultClickUpWatchIntervalSec = 300
+	MinClickUpWatchIntervalSec     = 60
+)
+
+// ClickUpWatchInterval returns ClickUpWatchIntervalSec as a duration, using the
+// default when unset and never less than MinClickUpWatchIntervalSec.
+func (c *Config) ClickUpWatchInterval() time.Duration {
+	sec := c.ClickUpWatchIntervalSec
+	switch {
+	case sec == 0:
+		sec = DefaultClickUpWatchIntervalSec
+	case sec < MinClickUpWatchIntervalSec:
+		sec = MinClickUpWatchIntervalSec
+	}
+	return time.Duration(sec) * time.Second
+}
+
+// ClickUpStatusFor returns the ClickUp status name mapped to the kasmos plan
+// status, or "" when the status is not mapped.
+func (c *Config) ClickUpStatusFor(status string) string {
+	if c == nil {
+		return ""
+	}
+	return strings.TrimSpace(c.ClickUpStatusMap[status])
+}
+
+// Notification backend names accepted in Config.Notifiers.
+const (
+	NotifierDesktop = "desktop"
+	NotifierSlack   = "slack"
+)
+
+// DefaultPlansDir is the repo-relative plans directory used when none is configured.
+const DefaultPlansDir = "docs/plans"
+
+// PlansDirFor returns the plans directory for the repo rooted at repoRoot.
+// A relative PlansDir is resolved against repoRoot.
+func (c *Config) PlansDirFor(repoRoot string) string {
+	dir := DefaultPlansDir
+	if c != nil && c.PlansDir != "" {
+		dir = c.PlansDir
+	}
+	if filepath.IsAbs(dir) {
+		return filepath.Clean(dir)
+	}
+	return filepath.Join(repoRoot, filepath.FromSlash(dir))
+}
+
+// DefaultTmuxPrefix is the tmux session name prefix used when none is configured.
+const DefaultTmuxPrefix = "kas_"
+
+// ResolveTmuxPrefix returns the configured tmux session prefix without
+// creating or migrating any config files. Falls back to DefaultTmuxPrefix when
+// config.toml is missing, unreadable, or leaves the prefix unset.
+func ResolveTmuxPrefix() string {
+	result, err := LoadTOMLConfig()
+	if err != nil || result == nil || result.TmuxPrefix == "" {
+		return DefaultTmuxPrefix
+	}
+	return result.TmuxPrefix
 }
 
 // DefaultConfig builds a Config populated with sensible out-of-the-box values.
@@ -236,6 +527,7 @@ func DefaultConfig() *Config {
 		AutoYes:              false,
 		AutoAdvanceWaves:     true,
 		AutoReviewFix:        true,
+		MaxReviewFixCycles:   DefaultMaxReviewFixCycles,
 		NotificationsEnabled: &trueVal,
 	}
 	applyConfigDefaults(cfg)
@@ -245,12 +537,22 @@ func DefaultConfig() *Config {
 // branchPrefix derives the git branch prefix from the current OS user.
 // Falls back to "session/" when the username is unavailable.
 func branchPrefix() string {
+	name := Username()
+	if name == "" {
+		return "session/"
+	}
+	return fmt.Sprintf("%s/", name)
+}
+
+// Username returns the lowercased login name of the current user, or "" when
+// it cannot be determined.
+func Username() string {
 	u, err := user.Current()
 	if err != nil || u == nil || u.Username == "" {
 		log.ErrorLog.Printf("failed to get current user: %v", err)
-		return "session/"
+		return ""
 	}
-	return fmt.Sprintf("%s/", strings.ToLower(u.Username))
+	return strings.ToLower(u.Username)
 }
 
 // AreNotificationsEnabled reports whether desktop notifications are active.
@@ -339,17 +641,45 @@ func configFromTOML(result *TOMLConfigResult) *Config {
 	cfg := DefaultConfig()
 	if result != nil {
 		cfg.DefaultProgram = result.DefaultProgram
+		cfg.ReviewerProgram = result.ReviewerProgram
 		cfg.AutoYes = result.AutoYes
+		cfg.AutoYesPatterns = result.AutoYesPatterns
+		cfg.MetadataTickMs = result.MetadataTickMs
+		cfg.MetadataWorkers = result.MetadataWorkers
 		cfg.DaemonPollInterval = result.DaemonPollInterval
+		cfg.DaemonAddr = result.DaemonAddr
 		cfg.BranchPrefix = result.BranchPrefix
+		cfg.BranchTemplate = result.BranchTemplate
+		cfg.RecordSessions = result.RecordSessions
+		cfg.Container = result.Container
+		cfg.PlannerContext = result.PlannerContext
+		cfg.TmuxPrefix = result.TmuxPrefix
+		cfg.PlansDir = result.PlansDir
+		cfg.ClickUpWatchTag = result.ClickUpWatchTag
+		cfg.ClickUpWatchIntervalSec = result.ClickUpWatchIntervalSec
+		cfg.ClickUpStatusMap = result.ClickUpStatusMap
+		cfg.MaxInstances = result.MaxInstances
+		cfg.LogFormat = result.LogFormat
+		cfg.DefaultDraftPR = result.DefaultDraftPR
+		cfg.AutoPush = result.AutoPush
+		cfg.PermissionCacheTTLDays = result.PermissionCacheTTLDays
+		cfg.CPUAlertPercent = result.CPUAlertPercent
+		cfg.MemAlertMB = result.MemAlertMB
+		cfg.IdleAutoPauseMinutes = result.IdleAutoPauseMinutes
+		cfg.Theme = result.Theme
 		cfg.NotificationsEnabled = result.NotificationsEnabled
+		cfg.Notifiers = result.Notifiers
+		cfg.SlackWebhookURL = result.SlackWebhookURL
 		cfg.Profiles = result.Profiles
 		cfg.PhaseRoles = result.PhaseRoles
 		cfg.AnimateBanner = result.AnimateBanner
+		cfg.VimMode = result.VimMode
 		cfg.TelemetryEnabled = result.TelemetryEnabled
 		cfg.DatabaseURL = result.DatabaseURL
 		cfg.Hooks = result.Hooks
 		cfg.BlueprintSkipThresholdValue = result.BlueprintSkipThreshold
+		cfg.MaxWaveConcurrencyValue = result.MaxWaveConcurrency
+		cfg.WaveTaskTimeoutMinutesValue = result.WaveTaskTimeoutMinutes
 		if result.AutoAdvanceWaves != nil {
 			cfg.AutoAdvanceWaves = *result.AutoAdvanceWaves
 		}
@@ -393,16 +723,52 @@ func configToTOML(cfg *Config) *TOMLConfig {
 		Agents: agents,
 		UI: TOMLUIConfig{
 			AnimateBanner: cfg.AnimateBanner,
+			VimMode:       cfg.VimMode,
+		},
+		Telemetry: TOMLTelemetryConfig{Enabled: cfg.TelemetryEnabled},
+		Container: TOMLContainerConfig{
+			Image:        cfg.Container.Image,
+			Devcontainer: cfg.Container.Devcontainer,
+		},
+		PlannerContext: TOMLPlannerContextConfig{
+			Globs:        cfg.PlannerContext.Globs,
+			MaxFileBytes: cfg.PlannerContext.MaxFileBytes,
 		},
-		Telemetry:            TOMLTelemetryConfig{Enabled: cfg.TelemetryEnabled},
-		Orchestration:        TOMLOrchestrationConfig{BlueprintSkipThreshold: cfg.BlueprintSkipThresholdValue},
-		DatabaseURL:          cfg.DatabaseURL,
-		DefaultProgram:       cfg.DefaultProgram,
-		AutoYes:              cfg.AutoYes,
-		DaemonPollInterval:   cfg.DaemonPollInterval,
-		BranchPrefix:         cfg.BranchPrefix,
-		NotificationsEnabled: cfg.NotificationsEnabled,
-		Hooks:                cfg.Hooks,
+		Orchestration: TOMLOrchestrationConfig{
+			BlueprintSkipThreshold: cfg.BlueprintSkipThresholdValue,
+			MaxWaveConcurrency:     cfg.MaxWaveConcurrencyValue,
+			WaveTaskTimeoutMinutes: cfg.WaveTaskTimeoutMinutesValue,
+		},
+		DatabaseURL:             cfg.DatabaseURL,
+		DefaultProgram:          cfg.DefaultProgram,
+		ReviewerProgram:         cfg.ReviewerProgram,
+		AutoYes:                 cfg.AutoYes,
+		AutoYesPatterns:         cfg.AutoYesPatterns,
+		MetadataTickMs:          cfg.MetadataTickMs,
+		MetadataWorkers:         cfg.MetadataWorkers,
+		DaemonPollInterval:      cfg.DaemonPollInterval,
+		DaemonAddr:              cfg.DaemonAddr,
+		BranchPrefix:            cfg.BranchPrefix,
+		BranchTemplate:          cfg.BranchTemplate,
+		RecordSessions:          cfg.RecordSessions,
+		TmuxPrefix:              cfg.TmuxPrefix,
+		PlansDir:                cfg.PlansDir,
+		ClickUpWatchTag:         cfg.ClickUpWatchTag,
+		ClickUpWatchIntervalSec: cfg.ClickUpWatchIntervalSec,
+		ClickUpStatusMap:        cfg.ClickUpStatusMap,
+		MaxInstances:            cfg.MaxInstances,
+		LogFormat:               cfg.LogFormat,
+		DefaultDraftPR:          cfg.DefaultDraftPR,
+		AutoPush:                cfg.AutoPush,
+		PermissionCacheTTLDays:  cfg.PermissionCacheTTLDays,
+		CPUAlertPercent:         cfg.CPUAlertPercent,
+		MemAlertMB:              cfg.MemAlertMB,
+		IdleAutoPauseMinutes:    cfg.IdleAutoPauseMinutes,
+		Theme:                   cfg.Theme,
+		NotificationsEnabled:    cfg.NotificationsEnabled,
+		Notifiers:               cfg.Notifiers,
+		SlackWebhookURL:         cfg.SlackWebhookURL,
+		Hooks:                   cfg.Hooks,
 	}
 	autoReviewFix := cfg.AutoReviewFix
 	autoAdvanceWaves := cfg.AutoAdvanceWaves
@@ -452,7 +818,26 @@ func migrateJSONToTOML(configDir string) (*Config, bool) {
 // the JSON values are written to config.toml and config.json is renamed to
 // config.json.migrated. When neither file exists, a default config is created and
 // persisted as config.toml. On parse errors, defaults are returned without writing.
+// KASMOS_* environment overrides (see env.go) are applied last and win over the file.
 func LoadConfig() *Config {
+	return applyEnvOverrides(loadConfigFile())
+}
+
+// LoadSavedConfig returns the config as persisted in config.toml, without repo
+// or environment overrides. Edit it and pass it to Save to change settings
+// without baking overrides into the file.
+func LoadSavedConfig() *Config {
+	return loadConfigFile()
+}
+
+// Save writes cfg to config.toml in the config directory, replacing the file.
+func Save(cfg *Config) error {
+	return SaveTOMLConfig(configToTOML(cfg))
+}
+
+// loadConfigFile is LoadConfig without the environment overrides, so that
+// persisted defaults and repo merges never capture env values.
+func loadConfigFile() *Config {
 	dir, err := GetConfigDir()
 	if err != nil {
 		log.ErrorLog.Printf("failed to get config directory: %v", err)
@@ -478,3 +863,90 @@ func LoadConfig() *Config {
 	}
 	return def
 }
+
+// LoadConfigForRepo loads the config for the current checkout (see LoadConfig)
+// and, when repoPath has its own <repoPath>/.kasmos/config.toml, merges that
+// file over it. Repo values win for DefaultProgram, ReviewerProgram, Profiles
+// (per agent), AutoYes, AutoYesPatterns, Container and DatabaseURL (the plan
+// store). A missing repo file is a no-op.
+// Environment overrides still win over the repo file.
+func LoadConfigForRepo(repoPath string) *Config {
+	cfg := loadConfigFile()
+	if repoPath == "" {
+		return applyEnvOverrides(cfg)
+	}
+	repoFile := filepath.Join(repoPath, ".kasmos", TOMLConfigFileName)
+	if dir, err := GetConfigDir(); err == nil && filepath.Join(dir, TOMLConfigFileName) == repoFile {
+		return applyEnvOverrides(cfg) // already loaded by loadConfigFile
+	}
+	merged, err := mergeRepoConfig(cfg, repoFile)
+	if err != nil {
+		log.WarningLog.Printf("failed to load repo config for %s: %v", repoPath, err)
+		return applyEnvOverrides(cfg)
+	}
+	return applyEnvOverrides(merged)
+}
+
+// mergeRepoConfig returns a copy of base with the repo-overridable keys that
+// are set in the TOML file at path applied on top. base is returned unchanged
+// when the file does not exist.
+func mergeRepoConfig(base *Config, path string) (*Config, error) {
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return base, nil
+		}
+		return base, fmt.Errorf("stat repo config: %w", err)
+	}
+	repo, md, err := decodeTOMLConfig(path)
+	if err != nil {
+		return base, err
+	}
+
+	merged := *base
+	if md.IsDefined("default_program") {
+		merged.DefaultProgram = repo.DefaultProgram
+	}
+	if md.IsDefined("reviewer_program") {
+		merged.ReviewerProgram = repo.ReviewerProgram
+	}
+	if md.IsDefined("auto_yes") {
+		merged.AutoYes = repo.AutoYes
+	}
+	if md.IsDefined("auto_yes_patterns") {
+		merged.AutoYesPatterns = repo.AutoYesPatterns
+	}
+	if md.IsDefined("database_url") {
+		merged.DatabaseURL = repo.DatabaseURL
+	}
+	if md.IsDefined("branch_template") {
+		merged.BranchTemplate = repo.BranchTemplate
+	}
+	if md.IsDefined("plans_dir") && repo.PlansDir != "" {
+		merged.PlansDir = repo.PlansDir
+	}
+	if md.IsDefined("clickup_watch_tag") {
+		merged.ClickUpWatchTag = repo.ClickUpWatchTag
+	}
+	if md.IsDefined("clickup_watch_interval_sec") {
+		merged.ClickUpWatchIntervalSec = repo.ClickUpWatchIntervalSec
+	}
+	if md.IsDefined("clickup_status_map") {
+		merged.ClickUpStatusMap = repo.ClickUpStatusMap
+	}
+	if md.IsDefined("container") {
+		merged.Container = repo.Container
+	}
+	if md.IsDefined("planner_context") {
+		merged.PlannerContext = repo.PlannerContext
+	}
+	if len(repo.Profiles) > 0 {
+		merged.Profiles = make(map[string]AgentProfile, len(base.Profiles)+len(repo.Profiles))
+		for name, p := range base.Profiles {
+			merged.Profiles[name] = p
+		}
+		for name, p := range repo.Profiles {
+			merged.Profiles[name] = p
+		}
+	}
+	return &merged, nil
+}
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -118,6 +119,9 @@ func TestDefaultConfig(t *testing.T) {
 		assert.True(t, config.AutoAdvanceWaves)
 		assert.True(t, config.AutoReviewFix)
 		assert.Equal(t, 1000, config.DaemonPollInterval)
+		assert.Equal(t, 4, config.MaxWaveConcurrency())
+		assert.Equal(t, 30, config.WaveTaskTimeoutMinutes())
+		assert.Equal(t, DefaultMaxReviewFixCycles, config.MaxReviewFixCycles)
 		assert.NotEmpty(t, config.BranchPrefix)
 		assert.True(t, strings.HasSuffix(config.BranchPrefix, "/"))
 	})
@@ -262,6 +266,8 @@ func TestConfigFromTOML(t *testing.T) {
 	falseVal := false
 	zeroCycles := 0
 	threshold := 3
+	concurrency := 6
+	timeoutMinutes := 45
 	trueVal := true
 	result := &TOMLConfigResult{
 		DefaultProgram:         "test-cmd",
@@ -272,12 +278,15 @@ func TestConfigFromTOML(t *testing.T) {
 		Profiles:               map[string]AgentProfile{"coder": {Program: "opencode", Enabled: true}},
 		PhaseRoles:             map[string]string{"implementing": "coder"},
 		AnimateBanner:          true,
+		VimMode:                true,
 		AutoAdvanceWaves:       &trueVal,
 		AutoReviewFix:          &falseVal,
 		MaxReviewFixCycles:     &zeroCycles,
 		TelemetryEnabled:       &falseVal,
 		DatabaseURL:            "https://example.test/store",
 		BlueprintSkipThreshold: &threshold,
+		MaxWaveConcurrency:     &concurrency,
+		WaveTaskTimeoutMinutes: &timeoutMinutes,
 	}
 
 	cfg := configFromTOML(result)
@@ -289,6 +298,7 @@ func TestConfigFromTOML(t *testing.T) {
 	require.NotNil(t, cfg.NotificationsEnabled)
 	assert.False(t, cfg.AreNotificationsEnabled())
 	assert.True(t, cfg.AnimateBanner)
+	assert.True(t, cfg.VimMode)
 	assert.True(t, cfg.AutoAdvanceWaves)
 	assert.False(t, cfg.AutoReviewFix)
 	assert.Equal(t, 0, cfg.MaxReviewFixCycles)
@@ -296,6 +306,8 @@ func TestConfigFromTOML(t *testing.T) {
 	assert.False(t, cfg.IsTelemetryEnabled())
 	assert.Equal(t, "https://example.test/store", cfg.DatabaseURL)
 	assert.Equal(t, 3, cfg.BlueprintSkipThreshold())
+	assert.Equal(t, 6, cfg.MaxWaveConcurrency())
+	assert.Equal(t, 45, cfg.WaveTaskTimeoutMinutes())
 	assert.Equal(t, "opencode", cfg.Profiles["coder"].Program)
 }
 
@@ -309,12 +321,41 @@ func TestConfigFromTOML_Defaults(t *testing.T) {
 	require.NotNil(t, cfg)
 	assert.NotEmpty(t, cfg.DefaultProgram)
 	assert.Equal(t, 1000, cfg.DaemonPollInterval)
+	assert.Equal(t, DefaultMetadataTickMs, cfg.MetadataTickMs)
+	assert.Equal(t, 5, cfg.MaxReviewFixCycles)
 	assert.NotEmpty(t, cfg.BranchPrefix)
 	assert.True(t, cfg.AutoAdvanceWaves)
 	assert.True(t, cfg.AutoReviewFix)
 	assert.True(t, cfg.AreNotificationsEnabled())
 }
 
+func TestMetadataTickMs_Clamped(t *testing.T) {
+	tests := []struct {
+		raw  int
+		want int
+	}{
+		{raw: 0, want: DefaultMetadataTickMs},
+		{raw: 10, want: MinMetadataTickMs},
+		{raw: -5, want: MinMetadataTickMs},
+		{raw: 75, want: 75},
+		{raw: 5000, want: MaxMetadataTickMs},
+	}
+	for _, tt := range tests {
+		cfg := configFromTOML(&TOMLConfigResult{MetadataTickMs: tt.raw})
+		assert.Equal(t, tt.want, cfg.MetadataTickMs, "metadata_tick_ms = %d", tt.raw)
+		assert.Equal(t, time.Duration(tt.want)*time.Millisecond, cfg.MetadataTickInterval())
+	}
+
+	// LoadConfig validates values read from disk.
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+	t.Setenv("HOME", t.TempDir())
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, ".kasmos"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, ".kasmos", TOMLConfigFileName),
+		[]byte("metadata_tick_ms = 9000\n"), 0644))
+	assert.Equal(t, MaxMetadataTickMs, LoadConfig().MetadataTickMs)
+}
+
 func TestLoadConfig(t *testing.T) {
 	t.Run("returns default config when file doesn't exist", func(t *testing.T) {
 		tempDir := t.TempDir()
@@ -408,6 +449,117 @@ max_review_fix_cycles = 0
 	})
 }
 
+func TestMergeRepoConfig(t *testing.T) {
+	base := DefaultConfig()
+	base.DefaultProgram = "claude"
+	base.AutoYes = true
+	base.DatabaseURL = "http://global:7433"
+	base.BranchPrefix = "global/"
+	base.Profiles = map[string]AgentProfile{
+		"coder":   {Program: "claude", Enabled: true},
+		"planner": {Program: "claude", Enabled: true},
+	}
+
+	t.Run("repo file wins for overridable keys", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), TOMLConfigFileName)
+		require.NoError(t, os.WriteFile(path, []byte(`default_program = "opencode"
+auto_yes = false
+database_url = "http://repo:7433"
+branch_prefix = "ignored/"
+plans_dir = ".project/plans"
+
+[agents.coder]
+enabled = true
+program = "opencode"
+model = "gpt-5"
+`), 0644))
+
+		merged, err := mergeRepoConfig(base, path)
+		require.NoError(t, err)
+		assert.Equal(t, "opencode", merged.DefaultProgram)
+		assert.False(t, merged.AutoYes)
+		assert.Equal(t, "http://repo:7433", merged.DatabaseURL)
+		assert.Equal(t, ".project/plans", merged.PlansDir)
+		assert.Equal(t, "opencode", merged.Profiles["coder"].Program)
+		assert.Equal(t, "gpt-5", merged.Profiles["coder"].Model)
+		assert.Equal(t, "claude", merged.Profiles["planner"].Program, "profiles not in the repo file are kept")
+		assert.Equal(t, "global/", merged.BranchPrefix, "non-overridable keys keep the global value")
+
+		assert.Equal(t, "claude", base.DefaultProgram, "base must not be mutated")
+		assert.Equal(t, "claude", base.Profiles["coder"].Program, "base profiles must not be mutated")
+	})
+
+	t.Run("unset keys keep global values", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), TOMLConfigFileName)
+		require.NoError(t, os.WriteFile(path, []byte(`default_program = "opencode"
+`), 0644))
+
+		merged, err := mergeRepoConfig(base, path)
+		require.NoError(t, err)
+		assert.Equal(t, "opencode", merged.DefaultProgram)
+		assert.True(t, merged.AutoYes)
+		assert.Equal(t, "http://global:7433", merged.DatabaseURL)
+		assert.Len(t, merged.Profiles, 2)
+	})
+
+	t.Run("missing file is a no-op", func(t *testing.T) {
+		merged, err := mergeRepoConfig(base, filepath.Join(t.TempDir(), TOMLConfigFileName))
+		require.NoError(t, err)
+		assert.Same(t, base, merged)
+	})
+
+	t.Run("invalid toml returns error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), TOMLConfigFileName)
+		require.NoError(t, os.WriteFile(path, []byte(`[broken`), 0644))
+
+		merged, err := mergeRepoConfig(base, path)
+		require.Error(t, err)
+		assert.Same(t, base, merged)
+	})
+}
+
+func TestLoadConfigForRepo(t *testing.T) {
+	cwd := t.TempDir()
+	t.Chdir(cwd)
+	t.Setenv("HOME", t.TempDir())
+
+	repo := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, ".kasmos"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ".kasmos", TOMLConfigFileName),
+		[]byte("default_program = \"repo-program\"\n"), 0644))
+
+	assert.Equal(t, "repo-program", LoadConfigForRepo(repo).DefaultProgram)
+	assert.NotEqual(t, "repo-program", LoadConfigForRepo(t.TempDir()).DefaultProgram)
+}
+
+func TestConfig_ClickUpWatchInterval(t *testing.T) {
+	assert.Equal(t, DefaultClickUpWatchIntervalSec*time.Second, (&Config{}).ClickUpWatchInterval())
+	assert.Equal(t, MinClickUpWatchIntervalSec*time.Second, (&Config{ClickUpWatchIntervalSec: 5}).ClickUpWatchInterval())
+	assert.Equal(t, 10*time.Minute, (&Config{ClickUpWatchIntervalSec: 600}).ClickUpWatchInterval())
+}
+
+func TestConfig_InstanceLimit(t *testing.T) {
+	assert.Equal(t, DefaultMaxInstances, (&Config{}).InstanceLimit())
+	assert.Equal(t, DefaultMaxInstances, (*Config)(nil).InstanceLimit())
+	assert.Equal(t, MinMaxInstances, (&Config{MaxInstances: 1}).InstanceLimit())
+	assert.Equal(t, 64, (&Config{MaxInstances: 64}).InstanceLimit())
+}
+
+func TestConfig_ClickUpStatusFor(t *testing.T) {
+	cfg := &Config{ClickUpStatusMap: map[string]string{"reviewing": " in review ", "done": "complete"}}
+	assert.Equal(t, "in review", cfg.ClickUpStatusFor("reviewing"))
+	assert.Equal(t, "complete", cfg.ClickUpStatusFor("done"))
+	assert.Empty(t, cfg.ClickUpStatusFor("implementing"), "unmapped statuses are not pushed")
+	assert.Empty(t, (*Config)(nil).ClickUpStatusFor("done"))
+}
+
+func TestConfig_PlansDirFor(t *testing.T) {
+	assert.Equal(t, filepath.Join("/repo", "docs", "plans"), DefaultConfig().PlansDirFor("/repo"))
+	assert.Equal(t, filepath.Join("/repo", "docs", "plans"), (*Config)(nil).PlansDirFor("/repo"))
+	assert.Equal(t, filepath.Join("/repo", ".project", "plans"), (&Config{PlansDir: ".project/plans"}).PlansDirFor("/repo"))
+	assert.Equal(t, "/srv/plans", (&Config{PlansDir: "/srv/plans/"}).PlansDirFor("/repo"))
+}
+
 func TestLoadConfig_MigratesJSON(t *testing.T) {
 	tempDir := t.TempDir()
 	t.Chdir(tempDir)
@@ -471,3 +623,41 @@ func TestIsTelemetryEnabled(t *testing.T) {
 		})
 	}
 }
+
+func TestSave_RoundTrip(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+	t.Setenv("HOME", t.TempDir())
+
+	cfg := LoadSavedConfig()
+	cfg.DefaultProgram = "saved-program"
+	cfg.BranchPrefix = "saved/"
+	require.NoError(t, Save(cfg))
+
+	loaded := LoadSavedConfig()
+	assert.Equal(t, "saved-program", loaded.DefaultProgram)
+	assert.Equal(t, "saved/", loaded.BranchPrefix)
+	assert.Equal(t, cfg.DaemonPollInterval, loaded.DaemonPollInterval)
+}
+
+func TestSave_PersistsBooleanToggles(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+	t.Setenv("HOME", t.TempDir())
+
+	for _, on := range []bool{true, false} {
+		cfg := LoadSavedConfig()
+		enabled := on
+		cfg.AutoYes = on
+		cfg.AnimateBanner = on
+		cfg.NotificationsEnabled = &enabled
+		cfg.TelemetryEnabled = &enabled
+		require.NoError(t, Save(cfg))
+
+		loaded := LoadSavedConfig()
+		assert.Equal(t, on, loaded.AutoYes)
+		assert.Equal(t, on, loaded.AnimateBanner)
+		assert.Equal(t, on, loaded.AreNotificationsEnabled())
+		assert.Equal(t, on, loaded.IsTelemetryEnabled())
+	}
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Environment variables that override config file values. They are applied
+// last, so they win over both config.toml and repo-local overrides.
+const (
+	EnvProgram   = "KASMOS_PROGRAM"
+	EnvAutoYes   = "KASMOS_AUTOYES"
+	EnvPlanStore = "KASMOS_PLAN_STORE"
+	EnvTelemetry = "KASMOS_TELEMETRY"
+)
+
+// envWarnings receives warnings about unusable KASMOS_* values. Config loads
+// before log.Initialize, so they go to stderr rather than the log. Tests
+// override it.
+var envWarnings io.Writer = os.Stderr
+
+// applyEnvOverrides overwrites cfg fields from the KASMOS_* environment
+// variables that are set and non-empty. Unparseable booleans are reported on
+// envWarnings and ignored so a typo never flips a setting silently.
+func applyEnvOverrides(cfg *Config) *Config {
+	if v := strings.TrimSpace(os.Getenv(EnvProgram)); v != "" {
+		cfg.DefaultProgram = v
+	}
+	if b, ok := envBool(EnvAutoYes); ok {
+		cfg.AutoYes = b
+	}
+	if v := strings.TrimSpace(os.Getenv(EnvPlanStore)); v != "" {
+		cfg.DatabaseURL = v
+	}
+	if b, ok := envBool(EnvTelemetry); ok {
+		cfg.TelemetryEnabled = &b
+	}
+	return cfg
+}
+
+// envBool reads a boolean environment variable. Besides the strconv.ParseBool
+// forms it accepts yes/no and on/off, case-insensitively. ok is false when the
+// variable is unset, empty or invalid.
+func envBool(name string) (value bool, ok bool) {
+	raw := strings.TrimSpace(os.Getenv(name))
+	if raw == "" {
+		return false, false
+	}
+	switch strings.ToLower(raw) {
+	case "yes", "y", "on":
+		return true, true
+	case "no", "n", "off":
+		return false, true
+	}
+	b, err := strconv.ParseBool(raw)
+	if err != nil {
+		fmt.Fprintf(envWarnings, "kas: ignoring %s=%q: not a boolean\n", name, raw)
+		return false, false
+	}
+	return b, true
+}
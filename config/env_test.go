@@ -0,0 +1,134 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kastheco/kasmos/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig_EnvOverrides(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+	t.Setenv("HOME", t.TempDir())
+
+	configDir := filepath.Join(tempDir, ".kasmos")
+	require.NoError(t, os.MkdirAll(configDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, TOMLConfigFileName), []byte(`default_program = "file-program"
+auto_yes = false
+database_url = "http://file:7433"
+
+[telemetry]
+enabled = true
+`), 0644))
+
+	t.Setenv(EnvProgram, "env-program --flag")
+	t.Setenv(EnvAutoYes, "true")
+	t.Setenv(EnvPlanStore, "http://env:7433")
+	t.Setenv(EnvTelemetry, "0")
+
+	cfg := LoadConfig()
+	assert.Equal(t, "env-program --flag", cfg.DefaultProgram)
+	assert.True(t, cfg.AutoYes)
+	assert.Equal(t, "http://env:7433", cfg.DatabaseURL)
+	require.NotNil(t, cfg.TelemetryEnabled)
+	assert.False(t, *cfg.TelemetryEnabled)
+
+	// Env values must not be written back to the config file.
+	data, err := os.ReadFile(filepath.Join(configDir, TOMLConfigFileName))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "env-program")
+}
+
+func TestLoadConfig_EnvUnsetKeepsFile(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(EnvProgram, "")
+	t.Setenv(EnvAutoYes, "")
+
+	configDir := filepath.Join(tempDir, ".kasmos")
+	require.NoError(t, os.MkdirAll(configDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, TOMLConfigFileName),
+		[]byte("default_program = \"file-program\"\nauto_yes = true\n"), 0644))
+
+	cfg := LoadConfig()
+	assert.Equal(t, "file-program", cfg.DefaultProgram)
+	assert.True(t, cfg.AutoYes)
+}
+
+func TestLoadConfigForRepo_EnvWinsOverRepo(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	repo := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, ".kasmos"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ".kasmos", TOMLConfigFileName),
+		[]byte("default_program = \"repo-program\"\n"), 0644))
+	t.Setenv(EnvProgram, "env-program")
+
+	assert.Equal(t, "env-program", LoadConfigForRepo(repo).DefaultProgram)
+}
+
+func TestEnvBool(t *testing.T) {
+	tests := []struct {
+		raw    string
+		want   bool
+		wantOK bool
+	}{
+		{raw: "", wantOK: false},
+		{raw: "   ", wantOK: false},
+		{raw: "true", want: true, wantOK: true},
+		{raw: "TRUE", want: true, wantOK: true},
+		{raw: " 1 ", want: true, wantOK: true},
+		{raw: "t", want: true, wantOK: true},
+		{raw: "yes", want: true, wantOK: true},
+		{raw: "On", want: true, wantOK: true},
+		{raw: "false", want: false, wantOK: true},
+		{raw: "0", want: false, wantOK: true},
+		{raw: "No", want: false, wantOK: true},
+		{raw: "off", want: false, wantOK: true},
+		{raw: "maybe", wantOK: false},
+		{raw: "2", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			t.Setenv(EnvAutoYes, tt.raw)
+			got, ok := envBool(EnvAutoYes)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApplyEnvOverrides_InvalidBoolIgnored(t *testing.T) {
+	t.Setenv(EnvAutoYes, "sure")
+	cfg := DefaultConfig()
+	cfg.AutoYes = true
+
+	applyEnvOverrides(cfg)
+	assert.True(t, cfg.AutoYes)
+}
+
+func TestLoadConfig_InvalidEnvBoolBeforeLogInit(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(EnvAutoYes, "maybe")
+
+	// LoadConfig runs before log.Initialize at startup.
+	origWarn := log.WarningLog
+	log.WarningLog = nil
+	t.Cleanup(func() { log.WarningLog = origWarn })
+	var warnings bytes.Buffer
+	origOut := envWarnings
+	envWarnings = &warnings
+	t.Cleanup(func() { envWarnings = origOut })
+
+	cfg := LoadConfig()
+	assert.False(t, cfg.AutoYes)
+	assert.Contains(t, warnings.String(), `ignoring KASMOS_AUTOYES="maybe"`)
+}
@@ -46,6 +46,10 @@ type PermissionStore interface {
 // SQLitePermissionStore is a PermissionStore backed by a SQLite database.
 type SQLitePermissionStore struct {
 	db *sql.DB
+	// ttl is how long a remembered decision stays valid; 0 means forever.
+	ttl time.Duration
+	// now returns the current time. Tests override it.
+	now func() time.Time
 }
 
 // NewSQLitePermissionStore opens (or creates) a SQLite database at dbPath and
@@ -69,7 +73,7 @@ func NewSQLitePermissionStore(dbPath string) (*SQLitePermissionStore, error) {
 		return nil, fmt.Errorf("run schema migrations: %w", err)
 	}
 
-	return &SQLitePermissionStore{db: db}, nil
+	return &SQLitePermissionStore{db: db, now: time.Now}, nil
 }
 
 // Close releases the database connection.
@@ -77,20 +81,74 @@ func (s *SQLitePermissionStore) Close() error {
 	return s.db.Close()
 }
 
+// SetTTL sets how long remembered decisions stay valid (0 = never expire) and
+// prunes entries that are already older than ttl.
+func (s *SQLitePermissionStore) SetTTL(ttl time.Duration) {
+	s.ttl = ttl
+	s.PruneExpired()
+}
+
 // IsAllowedAlways returns true if the pattern has been stored as "allow_always"
-// for the given project.
+// for the given project and has not outlived the TTL.
 func (s *SQLitePermissionStore) IsAllowedAlways(project, pattern string) bool {
-	const q = `SELECT 1 FROM permissions WHERE project = ? AND pattern = ? AND decision = 'allow_always'`
-	var dummy int
-	err := s.db.QueryRow(q, project, pattern).Scan(&dummy)
-	return err == nil
+	const q = `SELECT created_at FROM permissions WHERE project = ? AND pattern = ? AND decision = 'allow_always'`
+	var createdAt string
+	if err := s.db.QueryRow(q, project, pattern).Scan(&createdAt); err != nil {
+		return false
+	}
+	return !s.expired(createdAt)
+}
+
+// PruneExpired deletes every entry older than the TTL and returns how many
+// were removed. It is a no-op when no TTL is set.
+func (s *SQLitePermissionStore) PruneExpired() int {
+	if s.ttl <= 0 {
+		return 0
+	}
+	rows, err := s.db.Query(`SELECT id, created_at FROM permissions`)
+	if err != nil {
+		return 0
+	}
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		var createdAt string
+		if err := rows.Scan(&id, &createdAt); err != nil {
+			continue
+		}
+		if s.expired(createdAt) {
+			ids = append(ids, id)
+		}
+	}
+	rows.Close()
+
+	pruned := 0
+	for _, id := range ids {
+		if _, err := s.db.Exec(`DELETE FROM permissions WHERE id = ?`, id); err == nil {
+			pruned++
+		}
+	}
+	return pruned
+}
+
+// expired reports whether an entry created at createdAt has outlived the TTL.
+// Unparseable timestamps are treated as expired once a TTL is set.
+func (s *SQLitePermissionStore) expired(createdAt string) bool {
+	if s.ttl <= 0 {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return true
+	}
+	return s.now().Sub(t) > s.ttl
 }
 
 // Remember stores a pattern as "allow_always" for the given project.
 // If the entry already exists, it is replaced (idempotent).
 func (s *SQLitePermissionStore) Remember(project, pattern string) {
 	const q = `INSERT OR REPLACE INTO permissions (project, pattern, decision, created_at) VALUES (?, ?, 'allow_always', ?)`
-	createdAt := time.Now().UTC().Format(time.RFC3339Nano)
+	createdAt := s.now().UTC().Format(time.RFC3339Nano)
 	_, _ = s.db.Exec(q, project, pattern, createdAt)
 }
 
@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -106,3 +107,42 @@ func TestSQLitePermissionStore_Interface(t *testing.T) {
 
 	var _ PermissionStore = store
 }
+
+func TestSQLitePermissionStore_TTLExpiresEntries(t *testing.T) {
+	store, err := NewSQLitePermissionStore(":memory:")
+	require.NoError(t, err)
+	defer store.Close()
+
+	clock := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return clock }
+	store.Remember("p", "/opt/*")
+
+	clock = clock.Add(100 * 24 * time.Hour)
+	assert.True(t, store.IsAllowedAlways("p", "/opt/*"), "no TTL: entries never expire")
+
+	store.ttl = 30 * 24 * time.Hour
+	assert.False(t, store.IsAllowedAlways("p", "/opt/*"), "entry older than TTL must re-prompt")
+
+	// Re-approving refreshes the timestamp.
+	store.Remember("p", "/opt/*")
+	assert.True(t, store.IsAllowedAlways("p", "/opt/*"))
+}
+
+func TestSQLitePermissionStore_SetTTLPrunesExpired(t *testing.T) {
+	store, err := NewSQLitePermissionStore(":memory:")
+	require.NoError(t, err)
+	defer store.Close()
+
+	clock := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return clock }
+	store.Remember("p", "old")
+	clock = clock.Add(20 * 24 * time.Hour)
+	store.Remember("p", "recent")
+	clock = clock.Add(20 * 24 * time.Hour)
+
+	store.SetTTL(30 * 24 * time.Hour)
+	assert.Equal(t, []string{"recent"}, store.ListPatterns("p"))
+
+	store.SetTTL(0)
+	assert.Equal(t, 0, store.PruneExpired(), "TTL 0 never prunes")
+}
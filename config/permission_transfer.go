@@ -0,0 +1,113 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// PermissionExportVersion is the format version written by
+// WritePermissionExport and accepted by ParsePermissionExport.
+const PermissionExportVersion = 1
+
+// PermissionEntry is one remembered "allow always" decision.
+type PermissionEntry struct {
+	Pattern   string    `json:"pattern"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// permissionExport is the JSON document shared between teammates.
+type permissionExport struct {
+	Version     int               `json:"version"`
+	Project     string            `json:"project,omitempty"`
+	Permissions []PermissionEntry `json:"permissions"`
+}
+
+// Entries returns the unexpired "allow always" decisions stored for project,
+// sorted by pattern.
+func (s *SQLitePermissionStore) Entries(project string) ([]PermissionEntry, error) {
+	const q = `SELECT pattern, created_at FROM permissions WHERE project = ? AND decision = 'allow_always' ORDER BY pattern`
+	rows, err := s.db.Query(q, project)
+	if err != nil {
+		return nil, fmt.Errorf("query permissions: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []PermissionEntry
+	for rows.Next() {
+		var pattern, createdAt string
+		if err := rows.Scan(&pattern, &createdAt); err != nil {
+			return nil, fmt.Errorf("scan permission: %w", err)
+		}
+		if s.expired(createdAt) {
+			continue
+		}
+		t, _ := time.Parse(time.RFC3339Nano, createdAt)
+		entries = append(entries, PermissionEntry{Pattern: pattern, CreatedAt: t})
+	}
+	return entries, rows.Err()
+}
+
+// Merge adds entries to project's decisions. Patterns already stored keep
+// whichever timestamp is newer, so a merge never shortens a decision's life
+// under the TTL. Returns how many patterns were added or refreshed.
+func (s *SQLitePermissionStore) Merge(project string, entries []PermissionEntry) (int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("begin merge: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	changed := 0
+	for _, e := range entries {
+		var existing string
+		err := tx.QueryRow(`SELECT created_at FROM permissions WHERE project = ? AND pattern = ?`, project, e.Pattern).Scan(&existing)
+		if err == nil {
+			if t, perr := time.Parse(time.RFC3339Nano, existing); perr == nil && !e.CreatedAt.After(t) {
+				continue
+			}
+		}
+		const q = `INSERT OR REPLACE INTO permissions (project, pattern, decision, created_at) VALUES (?, ?, 'allow_always', ?)`
+		if _, err := tx.Exec(q, project, e.Pattern, e.CreatedAt.UTC().Format(time.RFC3339Nano)); err != nil {
+			return 0, fmt.Errorf("merge %q: %w", e.Pattern, err)
+		}
+		changed++
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit merge: %w", err)
+	}
+	return changed, nil
+}
+
+// WritePermissionExport writes project's entries to w as indented JSON.
+func WritePermissionExport(w io.Writer, project string, entries []PermissionEntry) error {
+	if entries == nil {
+		entries = []PermissionEntry{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(permissionExport{Version: PermissionExportVersion, Project: project, Permissions: entries})
+}
+
+// ParsePermissionExport decodes a document written by WritePermissionExport.
+// The whole document is validated before anything is returned, so a bad
+// entry rejects the import rather than applying part of it.
+func ParsePermissionExport(data []byte) ([]PermissionEntry, error) {
+	var doc permissionExport
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse permission export: %w", err)
+	}
+	if doc.Version != PermissionExportVersion {
+		return nil, fmt.Errorf("unsupported permission export version %d (want %d)", doc.Version, PermissionExportVersion)
+	}
+	for i, e := range doc.Permissions {
+		if e.Pattern == "" {
+			return nil, fmt.Errorf("permission %d: missing pattern", i+1)
+		}
+		if e.CreatedAt.IsZero() {
+			return nil, fmt.Errorf("permission %d (%s): missing created_at", i+1, e.Pattern)
+		}
+	}
+	return doc.Permissions, nil
+}
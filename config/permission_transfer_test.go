@@ -0,0 +1,103 @@
+package config
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermissionExport_RoundTrip(t *testing.T) {
+	src, err := NewSQLitePermissionStore(":memory:")
+	require.NoError(t, err)
+	defer src.Close()
+	src.Remember("proj", "/opt/*")
+	src.Remember("proj", "Execute bash command")
+	src.Remember("other", "/var/*")
+
+	entries, err := src.Entries("proj")
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, WritePermissionExport(&buf, "proj", entries))
+
+	parsed, err := ParsePermissionExport(buf.Bytes())
+	require.NoError(t, err)
+
+	dst, err := NewSQLitePermissionStore(":memory:")
+	require.NoError(t, err)
+	defer dst.Close()
+	n, err := dst.Merge("proj", parsed)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"/opt/*", "Execute bash command"}, dst.ListPatterns("proj"))
+	assert.False(t, dst.IsAllowedAlways("proj", "/var/*"), "other projects are not exported")
+}
+
+func TestSQLitePermissionStore_MergeIsUnionNewestWins(t *testing.T) {
+	base := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	store, err := NewSQLitePermissionStore(":memory:")
+	require.NoError(t, err)
+	defer store.Close()
+	store.now = func() time.Time { return base }
+	store.Remember("proj", "/opt/*")
+	store.Remember("proj", "/tmp/*")
+
+	n, err := store.Merge("proj", []PermissionEntry{
+		{Pattern: "/opt/*", CreatedAt: base.Add(-time.Hour)},     // older: keep ours
+		{Pattern: "/tmp/*", CreatedAt: base.Add(48 * time.Hour)}, // newer: take theirs
+		{Pattern: "/srv/*", CreatedAt: base.Add(-24 * time.Hour)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	entries, err := store.Entries("proj")
+	require.NoError(t, err)
+	got := map[string]time.Time{}
+	for _, e := range entries {
+		got[e.Pattern] = e.CreatedAt
+	}
+	assert.Equal(t, map[string]time.Time{
+		"/opt/*": base,
+		"/srv/*": base.Add(-24 * time.Hour),
+		"/tmp/*": base.Add(48 * time.Hour),
+	}, got)
+}
+
+func TestSQLitePermissionStore_EntriesSkipsExpired(t *testing.T) {
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	store, err := NewSQLitePermissionStore(":memory:")
+	require.NoError(t, err)
+	defer store.Close()
+	store.now = func() time.Time { return now }
+
+	_, err = store.Merge("proj", []PermissionEntry{
+		{Pattern: "/old/*", CreatedAt: now.Add(-10 * 24 * time.Hour)},
+		{Pattern: "/new/*", CreatedAt: now.Add(-time.Hour)},
+	})
+	require.NoError(t, err)
+	store.ttl = 7 * 24 * time.Hour
+
+	entries, err := store.Entries("proj")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "/new/*", entries[0].Pattern)
+}
+
+func TestParsePermissionExport_RejectsMalformed(t *testing.T) {
+	cases := map[string]string{
+		"not json":        `{"version": 1, "permissions": [`,
+		"wrong version":   `{"version": 2, "permissions": []}`,
+		"missing version": `{"permissions": []}`,
+		"empty pattern":   `{"version": 1, "permissions": [{"pattern": "", "created_at": "2026-01-01T00:00:00Z"}]}`,
+		"missing time":    `{"version": 1, "permissions": [{"pattern": "/opt/*"}]}`,
+		"bad time":        `{"version": 1, "permissions": [{"pattern": "/opt/*", "created_at": "yesterday"}]}`,
+	}
+	for name, doc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParsePermissionExport([]byte(doc))
+			assert.Error(t, err)
+		})
+	}
+}
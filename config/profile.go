@@ -1,6 +1,9 @@
 package config
 
-import "strings"
+import (
+	"sort"
+	"strings"
+)
 
 // AgentProfile defines the program and flags for an agent in a specific role.
 type AgentProfile struct {
@@ -30,14 +33,16 @@ func NormalizeExecutionMode(mode string) string {
 }
 
 // ResolveProfile looks up the agent profile for a given lifecycle phase.
+// A phase that has no [phases] mapping is treated as a role name, so custom
+// roles (e.g. [agents.security_audit]) resolve directly.
 // Falls back to defaultProgram if any link is missing, empty, or disabled.
 func (c *Config) ResolveProfile(phase string, defaultProgram string) AgentProfile {
-	if c.PhaseRoles == nil || c.Profiles == nil {
+	if c.Profiles == nil {
 		return AgentProfile{Program: defaultProgram, ExecutionMode: ExecutionModeTmux}
 	}
 	roleName, ok := c.PhaseRoles[phase]
 	if !ok {
-		return AgentProfile{Program: defaultProgram, ExecutionMode: ExecutionModeTmux}
+		roleName = phase
 	}
 	profile, ok := c.Profiles[roleName]
 	if !ok {
@@ -50,6 +55,26 @@ func (c *Config) ResolveProfile(phase string, defaultProgram string) AgentProfil
 	return profile
 }
 
+// CustomRoles returns the enabled agent roles that no lifecycle phase maps
+// to, sorted by name. These can be spawned explicitly as ad-hoc agents.
+func (c *Config) CustomRoles() []string {
+	if c == nil {
+		return nil
+	}
+	bound := make(map[string]bool, len(c.PhaseRoles))
+	for _, role := range c.PhaseRoles {
+		bound[role] = true
+	}
+	var roles []string
+	for name, p := range c.Profiles {
+		if !bound[name] && p.Enabled && p.Program != "" {
+			roles = append(roles, name)
+		}
+	}
+	sort.Strings(roles)
+	return roles
+}
+
 // BuildCommand returns the full command string (program + flags) for this profile.
 func (p AgentProfile) BuildCommand() string {
 	return strings.Join(append([]string{p.Program}, p.Flags...), " ")
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/kastheco/kasmos/log"
 )
@@ -14,6 +15,8 @@ const (
 	StateFileName = "state.json"
 	// InstancesFileName is the legacy per-file instances store name.
 	InstancesFileName = "instances.json"
+	// MaxPromptHistory is how many sent prompts are kept per repo.
+	MaxPromptHistory = 50
 )
 
 // InstanceStorage is the interface for reading and writing serialised instance data.
@@ -32,6 +35,14 @@ type AppState interface {
 	GetHelpScreensSeen() uint32
 	// SetHelpScreensSeen stores an updated bitmask and persists it.
 	SetHelpScreensSeen(seen uint32) error
+	// GetNavGrouping returns the persisted sidebar grouping mode ("" is the plan tree).
+	GetNavGrouping() string
+	// SetNavGrouping stores the sidebar grouping mode and persists it.
+	SetNavGrouping(mode string) error
+	// GetPromptHistory returns the prompts sent in repo, oldest first.
+	GetPromptHistory(repo string) []string
+	// AddPromptHistory records a prompt sent in repo and persists it.
+	AddPromptHistory(repo, prompt string) error
 }
 
 // StateManager is the unified interface combining instance storage and app state.
@@ -44,6 +55,11 @@ type StateManager interface {
 type State struct {
 	// HelpScreensSeen is a bitmask tracking which help screens the user has seen.
 	HelpScreensSeen uint32 `json:"help_screens_seen"`
+	// NavGrouping is the sidebar grouping mode; empty means the plan tree.
+	NavGrouping string `json:"nav_grouping,omitempty"`
+	// PromptHistory holds the last MaxPromptHistory prompts sent per repo
+	// path, oldest first.
+	PromptHistory map[string][]string `json:"prompt_history,omitempty"`
 	// InstancesData holds the serialised instance list as a raw JSON value.
 	InstancesData json.RawMessage `json:"instances"`
 }
@@ -130,3 +146,45 @@ func (s *State) SetHelpScreensSeen(seen uint32) error {
 	s.HelpScreensSeen = seen
 	return SaveState(s)
 }
+
+// GetNavGrouping implements AppState: returns the sidebar grouping mode.
+func (s *State) GetNavGrouping() string {
+	return s.NavGrouping
+}
+
+// SetNavGrouping implements AppState: stores the sidebar grouping mode and persists.
+func (s *State) SetNavGrouping(mode string) error {
+	s.NavGrouping = mode
+	return SaveState(s)
+}
+
+// GetPromptHistory implements AppState: returns repo's sent prompts, oldest first.
+func (s *State) GetPromptHistory(repo string) []string {
+	return s.PromptHistory[repo]
+}
+
+// AddPromptHistory implements AppState: records prompt for repo and persists.
+func (s *State) AddPromptHistory(repo, prompt string) error {
+	if s.PromptHistory == nil {
+		s.PromptHistory = make(map[string][]string)
+	}
+	s.PromptHistory[repo] = PushPromptHistory(s.PromptHistory[repo], prompt)
+	return SaveState(s)
+}
+
+// PushPromptHistory appends prompt to history (oldest first), skipping blank
+// prompts and a repeat of the most recent one, and keeps the last
+// MaxPromptHistory entries.
+func PushPromptHistory(history []string, prompt string) []string {
+	if strings.TrimSpace(prompt) == "" {
+		return history
+	}
+	if n := len(history); n > 0 && history[n-1] == prompt {
+		return history
+	}
+	history = append(history, prompt)
+	if len(history) > MaxPromptHistory {
+		history = append([]string(nil), history[len(history)-MaxPromptHistory:]...)
+	}
+	return history
+}
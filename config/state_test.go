@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushPromptHistory_DedupsConsecutiveAndCaps(t *testing.T) {
+	var h []string
+	h = PushPromptHistory(h, "run the tests")
+	h = PushPromptHistory(h, "run the tests")
+	h = PushPromptHistory(h, "  ")
+	h = PushPromptHistory(h, "fix lint")
+	h = PushPromptHistory(h, "run the tests")
+	assert.Equal(t, []string{"run the tests", "fix lint", "run the tests"}, h,
+		"only a repeat of the latest prompt is dropped")
+
+	for i := 0; i < MaxPromptHistory+5; i++ {
+		h = PushPromptHistory(h, fmt.Sprintf("p%d", i))
+	}
+	require.Len(t, h, MaxPromptHistory)
+	assert.Equal(t, "p5", h[0])
+	assert.Equal(t, fmt.Sprintf("p%d", MaxPromptHistory+4), h[len(h)-1])
+}
+
+func TestState_PromptHistoryPerRepo(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	s := DefaultState()
+	require.NoError(t, s.AddPromptHistory("/repo-a", "first"))
+	require.NoError(t, s.AddPromptHistory("/repo-a", "second"))
+	require.NoError(t, s.AddPromptHistory("/repo-b", "other"))
+
+	loaded := LoadState()
+	assert.Equal(t, []string{"first", "second"}, loaded.GetPromptHistory("/repo-a"))
+	assert.Equal(t, []string{"other"}, loaded.GetPromptHistory("/repo-b"))
+	assert.Empty(t, loaded.GetPromptHistory("/repo-c"))
+}
@@ -2,6 +2,7 @@ package taskfsm
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/kastheco/kasmos/config/taskstate"
@@ -18,6 +19,7 @@ const (
 	StatusReviewing    Status = "reviewing"
 	StatusDone         Status = "done"
 	StatusCancelled    Status = "cancelled"
+	StatusArchived     Status = "archived"
 )
 
 // Event represents a lifecycle transition trigger.
@@ -35,13 +37,14 @@ const (
 	Reimplement            Event = "reimplement"
 	Cancel                 Event = "cancel"
 	Reopen                 Event = "reopen"
+	Archive                Event = "archive"
 )
 
 // IsUserOnly returns true if this event can only be triggered from the TUI,
 // never by agent sentinel files.
 func (e Event) IsUserOnly() bool {
 	switch e {
-	case StartOver, Reimplement, RequestReview, Cancel, Reopen:
+	case StartOver, Reimplement, RequestReview, Cancel, Reopen, Archive:
 		return true
 	}
 	return false
@@ -74,10 +77,14 @@ var transitionTable = map[Status]map[Event]Status{
 		Reimplement:   StatusImplementing, // resume implementation without resetting branch
 		RequestReview: StatusReviewing,    // retrigger review for unmerged branches
 		Cancel:        StatusCancelled,    // explicit user cancellation from done
+		Reopen:        StatusReady,        // QA found issues: back to the active backlog
+		Archive:       StatusArchived,
 	},
 	StatusCancelled: {
-		Reopen: StatusPlanning,
+		Reopen:  StatusPlanning,
+		Archive: StatusArchived,
 	},
+	StatusArchived: {},
 }
 
 // ApplyTransition returns the new status for the given current status and event.
@@ -95,7 +102,8 @@ func ApplyTransition(current Status, event Event) (Status, error) {
 }
 
 // TaskStateMachine is the sole writer of plan state. All plan status mutations
-// must flow through Transition(). The store handles concurrency via SQLite.
+// must flow through Transition(). Concurrent writers are detected through the
+// store's per-plan version (see taskstore.ErrVersionConflict).
 type TaskStateMachine struct {
 	dir     string          // legacy: retained for file rename operations (may be empty)
 	store   taskstore.Store // always non-nil
@@ -114,7 +122,9 @@ func (m *TaskStateMachine) SetHooks(h *HookRegistry) { m.hooks = h }
 
 // Transition applies an event to a plan's current status. It reads the current
 // state from the store, validates the transition, writes the new state, and returns.
-// Concurrency is handled server-side via SQLite's own locking.
+// The write is rejected with an error wrapping taskstore.ErrVersionConflict if
+// another client changed the plan after it was read; nothing is written then,
+// and callers should reload before retrying.
 func (m *TaskStateMachine) Transition(planFile string, event Event) error {
 	ps, err := taskstate.Load(m.store, m.project, m.dir)
 	if err != nil {
@@ -129,8 +139,15 @@ func (m *TaskStateMachine) Transition(planFile string, event Event) error {
 	if err != nil {
 		return err
 	}
-	// ForceSetStatus writes through to the store.
-	if err := ps.ForceSetStatus(planFile, taskstate.Status(newStatus)); err != nil {
+	// Changes requested by a reviewer resume work that already started, so
+	// only fresh entries into implementing are gated on blockers.
+	if newStatus == StatusImplementing && event != ReviewChangesRequested {
+		if blockers := ps.UnfinishedBlockers(planFile); len(blockers) > 0 {
+			return &BlockedError{PlanFile: planFile, Blockers: blockers, statuses: blockerStatuses(ps, blockers)}
+		}
+	}
+	// RecordStatus writes through to the store and appends to the history.
+	if err := ps.RecordStatus(planFile, taskstate.Status(newStatus), time.Now().UTC()); err != nil {
 		return err
 	}
 	if phase, ok := phaseNameForStatus(newStatus); ok {
@@ -138,6 +155,12 @@ func (m *TaskStateMachine) Transition(planFile string, event Event) error {
 			return fmt.Errorf("set phase timestamp: %w", err)
 		}
 	}
+	// A reopened plan is no longer complete; drop its completion time.
+	if event == Reopen && currentStatus == StatusDone {
+		if err := m.store.SetPhaseTimestamp(m.project, planFile, "done", time.Time{}); err != nil {
+			return fmt.Errorf("clear done timestamp: %w", err)
+		}
+	}
 	m.hooks.FireAll(TransitionEvent{
 		PlanFile:   planFile,
 		FromStatus: currentStatus,
@@ -149,6 +172,31 @@ func (m *TaskStateMachine) Transition(planFile string, event Event) error {
 	return nil
 }
 
+// BlockedError is returned by Transition when a plan cannot enter
+// implementing because some of its blockers are not done yet.
+type BlockedError struct {
+	PlanFile string
+	Blockers []string
+	statuses []taskstate.Status
+}
+
+func (e *BlockedError) Error() string {
+	parts := make([]string, len(e.Blockers))
+	for i, b := range e.Blockers {
+		parts[i] = fmt.Sprintf("%s (%s)", taskstate.DisplayName(b), e.statuses[i])
+	}
+	return fmt.Sprintf("%s is blocked by %s", taskstate.DisplayName(e.PlanFile), strings.Join(parts, ", "))
+}
+
+func blockerStatuses(ps *taskstate.TaskState, blockers []string) []taskstate.Status {
+	out := make([]taskstate.Status, len(blockers))
+	for i, b := range blockers {
+		entry, _ := ps.Entry(b)
+		out[i] = entry.Status
+	}
+	return out
+}
+
 func phaseNameForStatus(s Status) (string, bool) {
 	switch s {
 	case StatusPlanning:
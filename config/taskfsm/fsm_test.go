@@ -38,6 +38,9 @@ func TestTransition_ValidTransitions(t *testing.T) {
 		{StatusImplementing, Cancel, StatusCancelled},
 		{StatusReviewing, Cancel, StatusCancelled},
 		{StatusCancelled, Reopen, StatusPlanning},
+		{StatusDone, Reopen, StatusReady},
+		{StatusDone, Archive, StatusArchived},
+		{StatusCancelled, Archive, StatusArchived},
 	}
 	for _, tc := range cases {
 		t.Run(string(tc.from)+"_"+string(tc.event), func(t *testing.T) {
@@ -61,6 +64,15 @@ func TestTransition_InvalidTransitions(t *testing.T) {
 		{StatusDone, PlanStart},           // terminal
 		{StatusDone, ImplementFinished},   // terminal
 		{StatusCancelled, ImplementStart}, // must reopen first
+		{StatusReady, Archive},            // only finished plans archive
+		{StatusImplementing, Archive},
+		{StatusReviewing, Archive},
+		{StatusArchived, Reopen}, // terminal
+		{StatusReady, Reopen},    // only done/cancelled reopen
+		{StatusPlanning, Reopen},
+		{StatusImplementing, Reopen},
+		{StatusReviewing, Reopen},
+		{StatusArchived, StartOver}, // terminal
 	}
 	for _, tc := range cases {
 		t.Run(string(tc.from)+"_"+string(tc.event), func(t *testing.T) {
@@ -74,6 +86,7 @@ func TestIsUserOnly(t *testing.T) {
 	assert.True(t, StartOver.IsUserOnly())
 	assert.True(t, Cancel.IsUserOnly())
 	assert.True(t, Reopen.IsUserOnly())
+	assert.True(t, Archive.IsUserOnly())
 	assert.False(t, PlannerFinished.IsUserOnly())
 	assert.False(t, ReviewApproved.IsUserOnly())
 }
@@ -99,6 +112,100 @@ func TestTaskStateMachine_TransitionWritesToStore(t *testing.T) {
 	assert.Equal(t, "planning", string(entry.Status))
 }
 
+// racingStore simulates another client writing a plan between Transition's
+// load and its write, the first time Update is called.
+type racingStore struct {
+	taskstore.Store
+	raced bool
+}
+
+func (s *racingStore) Update(project, filename string, entry taskstore.TaskEntry) error {
+	if !s.raced {
+		s.raced = true
+		other, err := s.Store.Get(project, filename)
+		if err != nil {
+			return err
+		}
+		other.Description = "edited elsewhere"
+		if err := s.Store.Update(project, filename, other); err != nil {
+			return err
+		}
+	}
+	return s.Store.Update(project, filename, entry)
+}
+
+func TestTaskStateMachine_StaleWriteRejectedThenRetried(t *testing.T) {
+	backend := taskstore.NewTestSQLiteStore(t)
+	dir := t.TempDir()
+	ps, err := taskstate.Load(backend, "test-proj", dir)
+	require.NoError(t, err)
+	require.NoError(t, ps.Register("test", "test plan", "plan/test", time.Now()))
+
+	fsm := New(&racingStore{Store: backend}, "test-proj", dir)
+	err = fsm.Transition("test", PlanStart)
+	require.ErrorIs(t, err, taskstore.ErrVersionConflict)
+
+	got, err := backend.Get("test-proj", "test")
+	require.NoError(t, err)
+	assert.Equal(t, taskstore.StatusReady, got.Status, "stale write must not land")
+	assert.Equal(t, "edited elsewhere", got.Description)
+
+	// Transition reloads, so retrying applies on top of the remote change.
+	require.NoError(t, fsm.Transition("test", PlanStart))
+	got, err = backend.Get("test-proj", "test")
+	require.NoError(t, err)
+	assert.Equal(t, taskstore.StatusPlanning, got.Status)
+	assert.Equal(t, "edited elsewhere", got.Description, "retry must not clobber the remote change")
+}
+
+func TestTaskStateMachine_ReopenDoneClearsDoneAt(t *testing.T) {
+	fsm, store := newTestFSM(t)
+	require.NoError(t, store.Create("test-proj", taskstore.TaskEntry{Filename: "qa", Status: taskstore.StatusReviewing}))
+	require.NoError(t, fsm.Transition("qa", ReviewApproved))
+
+	entry, err := store.Get("test-proj", "qa")
+	require.NoError(t, err)
+	require.False(t, entry.DoneAt.IsZero())
+
+	require.NoError(t, fsm.Transition("qa", Reopen))
+	entry, err = store.Get("test-proj", "qa")
+	require.NoError(t, err)
+	assert.Equal(t, taskstore.StatusReady, entry.Status)
+	assert.True(t, entry.DoneAt.IsZero(), "reopen must clear the completion time")
+}
+
+func TestTaskStateMachine_TransitionAppendsHistory(t *testing.T) {
+	fsm, store := newTestFSM(t)
+	require.NoError(t, store.Create("test-proj", taskstore.TaskEntry{Filename: "h", Status: taskstore.StatusReady}))
+
+	require.NoError(t, fsm.Transition("h", PlanStart))
+	require.NoError(t, fsm.Transition("h", PlannerFinished))
+	require.Error(t, fsm.Transition("h", ReviewApproved), "rejected transitions leave no history")
+
+	entry, err := store.Get("test-proj", "h")
+	require.NoError(t, err)
+	require.Len(t, entry.History, 2)
+	assert.Equal(t, taskstore.StatusPlanning, entry.History[0].Status)
+	assert.Equal(t, taskstore.StatusReady, entry.History[1].Status)
+	assert.False(t, entry.History[1].At.Before(entry.History[0].At))
+}
+
+func TestTaskStateMachine_HistoryBounded(t *testing.T) {
+	fsm, store := newTestFSM(t)
+	require.NoError(t, store.Create("test-proj", taskstore.TaskEntry{Filename: "h", Status: taskstore.StatusPlanning}))
+
+	// planning → planning is allowed, so each PlanStart appends one record.
+	for i := 0; i < taskstate.MaxHistory+5; i++ {
+		require.NoError(t, fsm.Transition("h", PlanStart))
+	}
+	require.NoError(t, fsm.Transition("h", PlannerFinished))
+
+	entry, err := store.Get("test-proj", "h")
+	require.NoError(t, err)
+	require.Len(t, entry.History, taskstate.MaxHistory)
+	assert.Equal(t, taskstore.StatusReady, entry.History[len(entry.History)-1].Status)
+}
+
 func TestTaskStateMachine_RejectsInvalidTransition(t *testing.T) {
 	store := taskstore.NewTestSQLiteStore(t)
 	dir := t.TempDir()
@@ -119,6 +226,57 @@ func TestTaskStateMachine_RejectsInvalidTransition(t *testing.T) {
 	assert.Equal(t, "ready", string(entry.Status))
 }
 
+func TestTaskStateMachine_RejectsImplementWhileBlocked(t *testing.T) {
+	store := taskstore.NewTestSQLiteStore(t)
+	dir := t.TempDir()
+
+	ps, err := taskstate.Load(store, "test-proj", dir)
+	require.NoError(t, err)
+	require.NoError(t, ps.Register("api", "api plan", "plan/api", time.Now()))
+	require.NoError(t, ps.Register("ui", "ui plan", "plan/ui", time.Now()))
+	require.NoError(t, ps.SetBlockers("ui", []string{"api"}))
+
+	fsm := New(store, "test-proj", dir)
+	err = fsm.Transition("ui", ImplementStart)
+	var blocked *BlockedError
+	require.ErrorAs(t, err, &blocked)
+	assert.Equal(t, []string{"api"}, blocked.Blockers)
+	assert.Equal(t, "ui is blocked by api (ready)", err.Error())
+
+	// Planning is not gated; only implementing is.
+	require.NoError(t, fsm.Transition("ui", PlanStart))
+
+	reloaded, err := taskstate.Load(store, "test-proj", dir)
+	require.NoError(t, err)
+	entry, ok := reloaded.Entry("ui")
+	require.True(t, ok)
+	assert.Equal(t, "planning", string(entry.Status))
+}
+
+func TestTaskStateMachine_UnblocksOnceBlockerDone(t *testing.T) {
+	store := taskstore.NewTestSQLiteStore(t)
+	dir := t.TempDir()
+
+	ps, err := taskstate.Load(store, "test-proj", dir)
+	require.NoError(t, err)
+	require.NoError(t, ps.Register("api", "api plan", "plan/api", time.Now()))
+	require.NoError(t, ps.Register("ui", "ui plan", "plan/ui", time.Now()))
+	require.NoError(t, ps.SetBlockers("ui", []string{"api"}))
+
+	fsm := New(store, "test-proj", dir)
+	require.Error(t, fsm.Transition("ui", ImplementStart))
+
+	for _, ev := range []Event{ImplementStart, ImplementFinished, ReviewApproved} {
+		require.NoError(t, fsm.Transition("api", ev))
+	}
+	require.NoError(t, fsm.Transition("ui", ImplementStart))
+
+	// A review sending work back is never gated, even if a blocker reopens.
+	require.NoError(t, fsm.Transition("ui", ImplementFinished))
+	require.NoError(t, fsm.Transition("api", StartOver))
+	require.NoError(t, fsm.Transition("ui", ReviewChangesRequested))
+}
+
 func TestTaskStateMachine_MissingPlanReturnsError(t *testing.T) {
 	fsm, _ := newTestFSM(t)
 	err := fsm.Transition("nonexistent", PlanStart)
@@ -13,12 +13,19 @@ type Task struct {
 	Number int    // Task number (1-indexed, from ### Task N: Title)
 	Title  string // Task title (text after "Task N: ")
 	Body   string // Full task body (everything between this ### Task and the next heading)
+	// DependsOn lists task numbers that must complete before this task may start,
+	// parsed from a "Depends: 1, 2" line in the task body.
+	DependsOn []int
 }
 
 // Wave represents a group of tasks that can run in parallel.
 type Wave struct {
 	Number int    // Wave number (1-indexed)
 	Tasks  []Task // Tasks in this wave
+	// Model overrides the coder model for every task in this wave, parsed from
+	// a "Model: provider/model" line directly after the wave header.
+	// Empty means inherit the coder profile default.
+	Model string
 }
 
 // Plan represents a parsed plan with header metadata and wave-grouped tasks.
@@ -53,6 +60,11 @@ var (
 	goalRe       = regexp.MustCompile(`(?m)^\*\*Goal:\*\*\s*(.+)$`)
 	archRe       = regexp.MustCompile(`(?m)^\*\*Architecture:\*\*\s*(.+)$`)
 	techRe       = regexp.MustCompile(`(?m)^\*\*Tech Stack:\*\*\s*(.+)$`)
+	// Accept "Depends: 1,2" with optional bold markers, e.g. "**Depends:** 1, 2".
+	dependsRe = regexp.MustCompile(`(?mi)^\s*(?:\*\*)?Depends(?: on)?:(?:\*\*)?\s*(.+)$`)
+	numberRe  = regexp.MustCompile(`\d+`)
+	// Accept "Model: anthropic/claude-opus-4" with optional bold markers.
+	modelRe = regexp.MustCompile(`(?i)^(?:\*\*)?Model:(?:\*\*)?\s*(\S+)\s*$`)
 )
 
 // Parse extracts waves and tasks from plan markdown content.
@@ -105,6 +117,7 @@ func Parse(content string) (*Plan, error) {
 		plan.Waves = append(plan.Waves, Wave{
 			Number: waveNum,
 			Tasks:  tasks,
+			Model:  parseWaveModel(section),
 		})
 	}
 
@@ -135,11 +148,46 @@ func parseTasks(section string) ([]Task, error) {
 		body := strings.TrimSpace(section[bodyStart:bodyEnd])
 
 		tasks = append(tasks, Task{
-			Number: num,
-			Title:  title,
-			Body:   body,
+			Number:    num,
+			Title:     title,
+			Body:      body,
+			DependsOn: parseDependsOn(body),
 		})
 	}
 
 	return tasks, nil
 }
+
+// parseWaveModel returns the model from a "Model:" line when it is the first
+// non-blank line of a wave section. Returns "" otherwise.
+func parseWaveModel(section string) string {
+	for _, line := range strings.Split(section, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if m := modelRe.FindStringSubmatch(line); len(m) > 1 {
+			return m[1]
+		}
+		return ""
+	}
+	return ""
+}
+
+// parseDependsOn extracts task numbers from the first "Depends:" line in a task
+// body. Returns nil when the task declares no dependencies.
+func parseDependsOn(body string) []int {
+	m := dependsRe.FindStringSubmatch(body)
+	if len(m) < 2 {
+		return nil
+	}
+	var deps []int
+	for _, numStr := range numberRe.FindAllString(m[1], -1) {
+		n, err := strconv.Atoi(numStr)
+		if err != nil {
+			continue
+		}
+		deps = append(deps, n)
+	}
+	return deps
+}
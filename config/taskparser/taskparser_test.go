@@ -172,3 +172,76 @@ Do the thing.
 	assert.Equal(t, "My arch here", plan.Architecture)
 	assert.Equal(t, "Go, bubbletea", plan.TechStack)
 }
+
+func TestParsePlan_DependsOn(t *testing.T) {
+	input := `**Goal:** Diamond
+
+## Wave 1
+### Task 1: Root
+
+Set things up.
+
+### Task 2: Left
+
+Depends: 1
+
+Build the left side.
+
+### Task 3: Right
+
+**Depends:** 1
+
+Build the right side.
+
+### Task 4: Join
+
+Depends on: 2, 3
+
+Join both sides.
+`
+	plan, err := Parse(input)
+	require.NoError(t, err)
+	require.Len(t, plan.Waves, 1)
+
+	tasks := plan.Waves[0].Tasks
+	require.Len(t, tasks, 4)
+	assert.Nil(t, tasks[0].DependsOn)
+	assert.Equal(t, []int{1}, tasks[1].DependsOn)
+	assert.Equal(t, []int{1}, tasks[2].DependsOn)
+	assert.Equal(t, []int{2, 3}, tasks[3].DependsOn)
+}
+
+func TestParsePlan_WaveModel(t *testing.T) {
+	input := `**Goal:** Models
+
+## Wave 1
+Model: anthropic/claude-opus-4
+
+### Task 1: Architecture
+
+Model: not-a-wave-model
+
+## Wave 2
+
+### Task 2: Boilerplate
+
+Do it.
+
+## Wave 3
+
+**Model:** openai/gpt-5
+
+### Task 3: Cleanup
+
+Do it.
+`
+	plan, err := Parse(input)
+	require.NoError(t, err)
+	require.Len(t, plan.Waves, 3)
+
+	assert.Equal(t, "anthropic/claude-opus-4", plan.Waves[0].Model)
+	assert.Equal(t, "", plan.Waves[1].Model, "wave without a model line inherits the profile default")
+	assert.Equal(t, "openai/gpt-5", plan.Waves[2].Model)
+	require.Len(t, plan.Waves[0].Tasks, 1)
+	assert.Equal(t, "Architecture", plan.Waves[0].Tasks[0].Title)
+}
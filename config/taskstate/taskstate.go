@@ -21,6 +21,10 @@ const (
 	// Lifecycle-stage statuses — canonical names used by the FSM.
 	StatusPlanning     Status = "planning"
 	StatusImplementing Status = "implementing"
+
+	// StatusArchived hides a finished plan from every listing while keeping
+	// its record and content in the store.
+	StatusArchived Status = "archived"
 )
 
 type TaskEntry struct {
@@ -37,8 +41,39 @@ type TaskEntry struct {
 	Goal           string    `json:"goal,omitempty"`
 	ClickUpTaskID  string    `json:"clickup_task_id,omitempty"`
 	ReviewCycle    int       `json:"review_cycle,omitempty"`
+	Priority       int       `json:"priority,omitempty"`
+	// BlockedBy lists plan filenames that must be done before this plan can
+	// move into implementing.
+	BlockedBy []string `json:"blocked_by,omitempty"`
+	// Pinned keeps the plan (and its topic) at the top of the sidebar.
+	Pinned bool `json:"pinned,omitempty"`
+	// AutoPush overrides the global auto-push setting; nil inherits it.
+	AutoPush *bool `json:"auto_push,omitempty"`
+	// History records each status the plan entered via the FSM, oldest
+	// first, bounded to MaxHistory entries.
+	History []StatusChange `json:"history,omitempty"`
+	// Version is the store version the entry was loaded at. Writes of a stale
+	// version fail with taskstore.ErrVersionConflict.
+	Version int `json:"version,omitempty"`
+}
+
+// StatusChange records a plan entering a status.
+type StatusChange struct {
+	Status Status    `json:"status"`
+	At     time.Time `json:"at"`
 }
 
+// MaxHistory bounds TaskEntry.History; older changes are dropped first.
+const MaxHistory = 20
+
+// Plan priorities range from PriorityNone (the default) to PriorityUrgent.
+const (
+	PriorityNone   = 0
+	PriorityLow    = 1
+	PriorityHigh   = 2
+	PriorityUrgent = 3
+)
+
 type TopicEntry struct {
 	CreatedAt time.Time `json:"created_at"`
 }
@@ -59,6 +94,8 @@ type TaskInfo struct {
 	Topic       string
 	CreatedAt   time.Time
 	DoneAt      time.Time
+	Priority    int
+	Pinned      bool
 }
 
 type TopicInfo struct {
@@ -112,6 +149,12 @@ func Load(store taskstore.Store, project, dir string) (*TaskState, error) {
 			Goal:           goal,
 			ClickUpTaskID:  e.ClickUpTaskID,
 			ReviewCycle:    e.ReviewCycle,
+			Priority:       e.Priority,
+			BlockedBy:      e.BlockedBy,
+			Pinned:         e.Pinned,
+			AutoPush:       e.AutoPush,
+			History:        fromStoreHistory(e.History),
+			Version:        e.Version,
 		}
 	}
 
@@ -122,22 +165,35 @@ func Load(store taskstore.Store, project, dir string) (*TaskState, error) {
 	return ps, nil
 }
 
-// Topics returns all topic entries sorted by name.
+// Topics returns all topic entries sorted by name. Topics whose plans have all
+// been archived are omitted.
 func (ps *TaskState) Topics() []TopicInfo {
 	// Discover topics from both TopicEntries and plan topic fields.
 	seen := make(map[string]TopicInfo)
 	for name, entry := range ps.TopicEntries {
 		seen[name] = TopicInfo{Name: name, CreatedAt: entry.CreatedAt}
 	}
+	archived := make(map[string]bool)
 	for _, entry := range ps.Plans {
-		if entry.Topic != "" {
-			if _, ok := seen[entry.Topic]; !ok {
-				seen[entry.Topic] = TopicInfo{Name: entry.Topic}
+		if entry.Topic == "" {
+			continue
+		}
+		if _, ok := seen[entry.Topic]; !ok {
+			seen[entry.Topic] = TopicInfo{Name: entry.Topic}
+		}
+		if entry.Status == StatusArchived {
+			if _, ok := archived[entry.Topic]; !ok {
+				archived[entry.Topic] = true
 			}
+		} else {
+			archived[entry.Topic] = false
 		}
 	}
 	result := make([]TopicInfo, 0, len(seen))
-	for _, info := range seen {
+	for name, info := range seen {
+		if archived[name] {
+			continue
+		}
 		result = append(result, info)
 	}
 	sort.Slice(result, func(i, j int) bool {
@@ -155,6 +211,7 @@ func (ps *TaskState) TasksByTopic(topic string) []TaskInfo {
 				Filename: filename, Status: entry.Status,
 				Description: entry.Description, Branch: entry.Branch,
 				Topic: entry.Topic, CreatedAt: entry.CreatedAt,
+				Priority: entry.Priority, Pinned: entry.Pinned,
 			})
 		}
 	}
@@ -168,14 +225,15 @@ func (ps *TaskState) TasksByTopic(topic string) []TaskInfo {
 func (ps *TaskState) UngroupedTasks() []TaskInfo {
 	result := make([]TaskInfo, 0)
 	for filename, entry := range ps.Plans {
-		if entry.Status == StatusDone || entry.Status == StatusCancelled {
+		if entry.Status == StatusDone || entry.Status == StatusCancelled || entry.Status == StatusArchived {
 			continue
 		}
 		if entry.Topic == "" {
 			result = append(result, TaskInfo{
 				Filename: filename, Status: entry.Status,
 				Description: entry.Description, Branch: entry.Branch,
-				CreatedAt: entry.CreatedAt,
+				CreatedAt: entry.CreatedAt, Priority: entry.Priority,
+				Pinned: entry.Pinned,
 			})
 		}
 	}
@@ -211,11 +269,11 @@ func (ps *TaskState) HasRunningCoderInTopic(topic, excludePlan string) (bool, st
 	return false, ""
 }
 
-// Unfinished returns plans that are not done or cancelled, sorted by filename.
+// Unfinished returns plans that are not done, cancelled or archived, sorted by filename.
 func (ps *TaskState) Unfinished() []TaskInfo {
 	result := make([]TaskInfo, 0, len(ps.Plans))
 	for filename, entry := range ps.Plans {
-		if entry.Status == StatusDone || entry.Status == StatusCancelled {
+		if entry.Status == StatusDone || entry.Status == StatusCancelled || entry.Status == StatusArchived {
 			continue
 		}
 		result = append(result, TaskInfo{
@@ -291,6 +349,60 @@ func (ps *TaskState) List() []TaskInfo {
 	return result
 }
 
+// RenderReport renders every plan as a markdown summary: one table per topic,
+// then ungrouped plans, then history (done and cancelled). Archived plans are
+// left out. instanceCounts maps plan filenames to their instance count; pass
+// nil when unknown.
+func (ps *TaskState) RenderReport(instanceCounts map[string]int) string {
+	var b strings.Builder
+	b.WriteString("# task report\n")
+
+	active := func(infos []TaskInfo) []TaskInfo {
+		var out []TaskInfo
+		for _, info := range infos {
+			switch info.Status {
+			case StatusDone, StatusCancelled, StatusArchived:
+				continue
+			}
+			out = append(out, info)
+		}
+		return out
+	}
+	for _, topic := range ps.Topics() {
+		if plans := active(ps.TasksByTopic(topic.Name)); len(plans) > 0 {
+			writeReportSection(&b, topic.Name, plans, instanceCounts)
+		}
+	}
+	if plans := ps.UngroupedTasks(); len(plans) > 0 {
+		writeReportSection(&b, "ungrouped", plans, instanceCounts)
+	}
+	if history := append(ps.Finished(), ps.Cancelled()...); len(history) > 0 {
+		writeReportSection(&b, "history", history, instanceCounts)
+	}
+	if len(ps.Plans) == 0 {
+		b.WriteString("\nno tasks.\n")
+	}
+	return b.String()
+}
+
+// writeReportSection writes a "## title" heading and a markdown table of plans.
+func writeReportSection(b *strings.Builder, title string, plans []TaskInfo, instanceCounts map[string]int) {
+	fmt.Fprintf(b, "\n## %s\n\n", title)
+	b.WriteString("| task | status | branch | instances | description |\n")
+	b.WriteString("|------|--------|--------|-----------|-------------|\n")
+	for _, p := range plans {
+		fmt.Fprintf(b, "| %s | %s | %s | %d | %s |\n",
+			reportCell(DisplayName(p.Filename)), p.Status, reportCell(p.Branch),
+			instanceCounts[p.Filename], reportCell(p.Description))
+	}
+}
+
+// reportCell escapes s for use inside a single-line markdown table cell.
+func reportCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
+
 // IsDone returns true only if the given plan has status StatusDone.
 func (ps *TaskState) IsDone(filename string) bool {
 	entry, ok := ps.Plans[filename]
@@ -304,7 +416,7 @@ func (ps *TaskState) IsDone(filename string) bool {
 // Validates the status is a known value. Use only for manual overrides (e.g. kq plan set-status --force).
 func (ps *TaskState) ForceSetStatus(filename string, status Status) error {
 	if !isValidStatus(status) {
-		return fmt.Errorf("invalid status %q: must be one of ready, planning, implementing, reviewing, done, cancelled", status)
+		return fmt.Errorf("invalid status %q: must be one of ready, planning, implementing, reviewing, done, cancelled, archived", status)
 	}
 	if _, ok := ps.Plans[filename]; !ok {
 		return fmt.Errorf("plan not found: %s", filename)
@@ -312,21 +424,113 @@ func (ps *TaskState) ForceSetStatus(filename string, status Status) error {
 	entry := ps.Plans[filename]
 	entry.Status = status
 	ps.Plans[filename] = entry
-	if err := ps.store.Update(ps.project, filename, ps.toTaskstoreEntry(filename, entry)); err != nil {
+	if err := ps.updateEntry(filename, entry); err != nil {
 		return fmt.Errorf("task store: %w", err)
 	}
 	return nil
 }
 
+// RecordStatus sets the status of a plan like ForceSetStatus and appends the
+// change to its History, keeping the most recent MaxHistory entries.
+func (ps *TaskState) RecordStatus(filename string, status Status, at time.Time) error {
+	if !isValidStatus(status) {
+		return fmt.Errorf("invalid status %q: must be one of ready, planning, implementing, reviewing, done, cancelled, archived", status)
+	}
+	entry, ok := ps.Plans[filename]
+	if !ok {
+		return fmt.Errorf("plan not found: %s", filename)
+	}
+	entry.Status = status
+	history := append(append([]StatusChange(nil), entry.History...), StatusChange{Status: status, At: at})
+	if len(history) > MaxHistory {
+		history = history[len(history)-MaxHistory:]
+	}
+	entry.History = history
+	ps.Plans[filename] = entry
+	if err := ps.updateEntry(filename, entry); err != nil {
+		return fmt.Errorf("task store: %w", err)
+	}
+	return nil
+}
+
+// ForceSetStatusBulk overrides the status of several plans at once, like
+// ForceSetStatus, with a single store write. Nothing changes if the status is
+// invalid or any plan is unknown.
+func (ps *TaskState) ForceSetStatusBulk(filenames []string, status Status) error {
+	if !isValidStatus(status) {
+		return fmt.Errorf("invalid status %q: must be one of ready, planning, implementing, reviewing, done, cancelled, archived", status)
+	}
+	for _, filename := range filenames {
+		if _, ok := ps.Plans[filename]; !ok {
+			return fmt.Errorf("plan not found: %s", filename)
+		}
+	}
+	if len(filenames) == 0 {
+		return nil
+	}
+	if err := ps.store.SetStatuses(ps.project, filenames, taskstore.Status(status)); err != nil {
+		return fmt.Errorf("task store: %w", err)
+	}
+	for _, filename := range filenames {
+		entry := ps.Plans[filename]
+		entry.Status = status
+		if entry.Version != 0 {
+			entry.Version++
+		}
+		ps.Plans[filename] = entry
+	}
+	return nil
+}
+
 // isValidStatus returns true if s is a recognised lifecycle status.
 func isValidStatus(s Status) bool {
 	switch s {
-	case StatusReady, StatusPlanning, StatusImplementing, StatusReviewing, StatusDone, StatusCancelled:
+	case StatusReady, StatusPlanning, StatusImplementing, StatusReviewing, StatusDone, StatusCancelled, StatusArchived:
 		return true
 	}
 	return false
 }
 
+// Archive moves a done or cancelled plan into StatusArchived. The plan's record
+// and content stay in the store; it just stops appearing in Finished, Topics
+// and the other listings. Mirrors the taskfsm Archive transition, which only
+// accepts done and cancelled plans.
+func (ps *TaskState) Archive(filename string) error {
+	entry, ok := ps.Plans[filename]
+	if !ok {
+		return fmt.Errorf("plan not found: %s", filename)
+	}
+	if entry.Status != StatusDone && entry.Status != StatusCancelled {
+		return fmt.Errorf("cannot archive %s: status is %q, want done or cancelled", filename, entry.Status)
+	}
+	entry.Status = StatusArchived
+	ps.Plans[filename] = entry
+	if err := ps.updateEntry(filename, entry); err != nil {
+		return fmt.Errorf("task store: %w", err)
+	}
+	return nil
+}
+
+// CompletedAt returns when a finished plan reached its terminal status: the
+// done timestamp when set, otherwise the latest History entry for its current
+// status (cancelled plans carry no completion timestamp). Returns false if
+// the plan is not found or that time is unknown.
+func (ps *TaskState) CompletedAt(filename string) (time.Time, bool) {
+	entry, ok := ps.Plans[filename]
+	if !ok {
+		return time.Time{}, false
+	}
+	if !entry.DoneAt.IsZero() {
+		return entry.DoneAt, true
+	}
+	for i := len(entry.History) - 1; i >= 0; i-- {
+		if entry.History[i].Status == entry.Status {
+			return entry.History[i].At, true
+		}
+	}
+	return time.Time{}, false
+}
+
 // setStatus updates a plan's status and persists to the store.
 // Unexported: only for use within this package (tests). Production code must use taskfsm.Transition.
 func (ps *TaskState) setStatus(filename string, status Status) error {
@@ -336,7 +540,7 @@ func (ps *TaskState) setStatus(filename string, status Status) error {
 	entry := ps.Plans[filename]
 	entry.Status = status
 	ps.Plans[filename] = entry
-	if err := ps.store.Update(ps.project, filename, ps.toTaskstoreEntry(filename, entry)); err != nil {
+	if err := ps.updateEntry(filename, entry); err != nil {
 		return fmt.Errorf("task store: %w", err)
 	}
 	return nil
@@ -355,6 +559,30 @@ func (ps *TaskState) CreateWithContent(filename, description, branch, topic stri
 	return nil
 }
 
+// Duplicate creates newName as a copy of srcFile's description, topic,
+// priority and markdown content. The copy starts at StatusReady on branch,
+// which the caller names for the new plan; instances, subtasks and wave
+// progress are not copied. Returns the new filename.
+func (ps *TaskState) Duplicate(srcFile, newName, branch string) (string, error) {
+	src, ok := ps.Plans[srcFile]
+	if !ok {
+		return "", fmt.Errorf("plan not found: %s", srcFile)
+	}
+	content, err := ps.store.GetContent(ps.project, srcFile)
+	if err != nil {
+		return "", fmt.Errorf("task store get content: %w", err)
+	}
+	if err := ps.CreateWithContent(newName, src.Description, branch, src.Topic, time.Now(), content); err != nil {
+		return "", err
+	}
+	if src.Priority != PriorityNone {
+		if err := ps.SetPriority(newName, src.Priority); err != nil {
+			return "", err
+		}
+	}
+	return newName, nil
+}
+
 // GetContent retrieves the markdown content for the given plan filename from the store.
 func (ps *TaskState) GetContent(filename string) (string, error) {
 	return ps.store.GetContent(ps.project, filename)
@@ -365,6 +593,67 @@ func (ps *TaskState) SetContent(filename, content string) error {
 	return ps.store.SetContent(ps.project, filename, content)
 }
 
+// ContentMatch is a single line of plan content matched by GrepPlans.
+type ContentMatch struct {
+	Filename string
+	Line     int // 1-based
+	Text     string
+}
+
+// Bounds applied by GrepPlans so a broad query can't flood the picker.
+const (
+	MaxContentMatches  = 50
+	MaxMatchLineLength = 120
+)
+
+// GrepPlans searches the markdown content of every non-archived plan for
+// query, case-insensitively. Matches are ordered by filename then line and
+// capped at MaxContentMatches; matched lines are trimmed and truncated to
+// MaxMatchLineLength runes.
+func (ps *TaskState) GrepPlans(query string) ([]ContentMatch, error) {
+	needle := strings.ToLower(strings.TrimSpace(query))
+	if needle == "" {
+		return nil, nil
+	}
+	entries, err := ps.store.List(ps.project)
+	if err != nil {
+		return nil, fmt.Errorf("list plans: %w", err)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Filename < entries[j].Filename
+	})
+
+	var matches []ContentMatch
+	for _, e := range entries {
+		if Status(e.Status) == StatusArchived || e.Content == "" {
+			continue
+		}
+		for i, line := range strings.Split(e.Content, "\n") {
+			if !strings.Contains(strings.ToLower(line), needle) {
+				continue
+			}
+			matches = append(matches, ContentMatch{
+				Filename: e.Filename,
+				Line:     i + 1,
+				Text:     truncateLine(strings.TrimSpace(line), MaxMatchLineLength),
+			})
+			if len(matches) >= MaxContentMatches {
+				return matches, nil
+			}
+		}
+	}
+	return matches, nil
+}
+
+// truncateLine shortens s to at most max runes, marking the cut with "…".
+func truncateLine(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return string(r[:max-1]) + "…"
+}
+
 // IngestWarning is returned by IngestContent when content was stored
 // successfully but plan-structure parsing failed (e.g. no Wave sections yet).
 // Only parse failures are downgraded; store write errors remain fatal.
@@ -520,7 +809,7 @@ func (ps *TaskState) SetTopic(filename, topic string) error {
 			ps.TopicEntries[topic] = TopicEntry{CreatedAt: time.Now().UTC()}
 		}
 	}
-	if err := ps.store.Update(ps.project, filename, ps.toTaskstoreEntry(filename, entry)); err != nil {
+	if err := ps.updateEntry(filename, entry); err != nil {
 		return fmt.Errorf("task store: %w", err)
 	}
 	// Auto-create topic in store if needed
@@ -535,6 +824,62 @@ func (ps *TaskState) SetTopic(filename, topic string) error {
 	return nil
 }
 
+// SetPriority assigns a priority (PriorityNone through PriorityUrgent) to an
+// existing plan entry and persists to the store.
+func (ps *TaskState) SetPriority(filename string, priority int) error {
+	if priority < PriorityNone || priority > PriorityUrgent {
+		return fmt.Errorf("invalid priority %d: must be between %d and %d", priority, PriorityNone, PriorityUrgent)
+	}
+	entry, ok := ps.Plans[filename]
+	if !ok {
+		return fmt.Errorf("plan not found: %s", filename)
+	}
+	entry.Priority = priority
+	ps.Plans[filename] = entry
+	if err := ps.updateEntry(filename, entry); err != nil {
+		return fmt.Errorf("task store: %w", err)
+	}
+	return nil
+}
+
+// SetPinned pins or unpins an existing plan entry and persists to the store.
+func (ps *TaskState) SetPinned(filename string, pinned bool) error {
+	entry, ok := ps.Plans[filename]
+	if !ok {
+		return fmt.Errorf("plan not found: %s", filename)
+	}
+	entry.Pinned = pinned
+	ps.Plans[filename] = entry
+	if err := ps.updateEntry(filename, entry); err != nil {
+		return fmt.Errorf("task store: %w", err)
+	}
+	return nil
+}
+
+// SetAutoPush sets the plan's auto-push override and persists to the store.
+// nil clears the override so the global setting applies.
+func (ps *TaskState) SetAutoPush(filename string, enabled *bool) error {
+	entry, ok := ps.Plans[filename]
+	if !ok {
+		return fmt.Errorf("plan not found: %s", filename)
+	}
+	entry.AutoPush = enabled
+	ps.Plans[filename] = entry
+	if err := ps.updateEntry(filename, entry); err != nil {
+		return fmt.Errorf("task store: %w", err)
+	}
+	return nil
+}
+
+// AutoPushEnabled reports whether new commits on the plan's branch are pushed
+// automatically: the plan's override when set, otherwise def.
+func (ps *TaskState) AutoPushEnabled(filename string, def bool) bool {
+	if entry, ok := ps.Plans[filename]; ok && entry.AutoPush != nil {
+		return *entry.AutoPush
+	}
+	return def
+}
+
 // SetBranch assigns a branch name to an existing plan entry and persists to the store.
 func (ps *TaskState) SetBranch(filename, branch string) error {
 	entry, ok := ps.Plans[filename]
@@ -543,7 +888,7 @@ func (ps *TaskState) SetBranch(filename, branch string) error {
 	}
 	entry.Branch = branch
 	ps.Plans[filename] = entry
-	if err := ps.store.Update(ps.project, filename, ps.toTaskstoreEntry(filename, entry)); err != nil {
+	if err := ps.updateEntry(filename, entry); err != nil {
 		return fmt.Errorf("task store: %w", err)
 	}
 	return nil
@@ -561,6 +906,74 @@ func DisplayName(filename string) string {
 	return filename
 }
 
+// SetBlockers replaces the plans that block filename. Every blocker must be a
+// known plan other than filename itself, and the result must not introduce a
+// dependency cycle. Pass nil to clear all blockers.
+func (ps *TaskState) SetBlockers(filename string, blockers []string) error {
+	entry, ok := ps.Plans[filename]
+	if !ok {
+		return fmt.Errorf("plan not found: %s", filename)
+	}
+	for _, b := range blockers {
+		if b == filename {
+			return fmt.Errorf("plan %s cannot block itself", filename)
+		}
+		if _, ok := ps.Plans[b]; !ok {
+			return fmt.Errorf("blocker not found: %s", b)
+		}
+		if ps.dependsOn(b, filename) {
+			return fmt.Errorf("blocking %s on %s would create a cycle", filename, b)
+		}
+	}
+	if len(blockers) == 0 {
+		blockers = nil
+	} else {
+		blockers = append([]string(nil), blockers...)
+		sort.Strings(blockers)
+	}
+	entry.BlockedBy = blockers
+	ps.Plans[filename] = entry
+	if err := ps.updateEntry(filename, entry); err != nil {
+		return fmt.Errorf("task store: %w", err)
+	}
+	return nil
+}
+
+// dependsOn reports whether filename is transitively blocked by target.
+func (ps *TaskState) dependsOn(filename, target string) bool {
+	seen := make(map[string]bool)
+	var walk func(string) bool
+	walk = func(f string) bool {
+		if seen[f] {
+			return false
+		}
+		seen[f] = true
+		for _, b := range ps.Plans[f].BlockedBy {
+			if b == target || walk(b) {
+				return true
+			}
+		}
+		return false
+	}
+	return walk(filename)
+}
+
+// UnfinishedBlockers returns the blockers of filename that are not yet done
+// (or archived), in stored order. Blockers that no longer exist are ignored.
+func (ps *TaskState) UnfinishedBlockers(filename string) []string {
+	var out []string
+	for _, b := range ps.Plans[filename].BlockedBy {
+		blocker, ok := ps.Plans[b]
+		if !ok {
+			continue
+		}
+		if blocker.Status != StatusDone && blocker.Status != StatusArchived {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
 // Rename renames a plan by giving it a new display name slug.
 // It rekeys the taskstate entry and persists the updated task entry in the store.
 // newName should be a human-readable name (e.g., "auth refactor") which will be
@@ -630,6 +1043,20 @@ func isAlreadyExistsError(err error) bool {
 	return strings.Contains(msg, "already exists")
 }
 
+// updateEntry writes entry to the store as filename's new state. On success
+// the in-memory version follows the store's bump, so later writes through ps
+// are not mistaken for stale ones.
+func (ps *TaskState) updateEntry(filename string, entry TaskEntry) error {
+	if err := ps.store.Update(ps.project, filename, ps.toTaskstoreEntry(filename, entry)); err != nil {
+		return err
+	}
+	if entry.Version != 0 {
+		entry.Version++
+		ps.Plans[filename] = entry
+	}
+	return nil
+}
+
 // toTaskstoreEntry converts a local TaskEntry to a taskstore.TaskEntry for
 // writing to the store.
 func (ps *TaskState) toTaskstoreEntry(filename string, e TaskEntry) taskstore.TaskEntry {
@@ -648,7 +1075,35 @@ func (ps *TaskState) toTaskstoreEntry(filename string, e TaskEntry) taskstore.Ta
 		Goal:           e.Goal,
 		ClickUpTaskID:  e.ClickUpTaskID,
 		ReviewCycle:    e.ReviewCycle,
+		Priority:       e.Priority,
+		BlockedBy:      e.BlockedBy,
+		Pinned:         e.Pinned,
+		AutoPush:       e.AutoPush,
+		History:        toStoreHistory(e.History),
+		Version:        e.Version,
+	}
+}
+
+func fromStoreHistory(h []taskstore.StatusChange) []StatusChange {
+	if len(h) == 0 {
+		return nil
+	}
+	out := make([]StatusChange, len(h))
+	for i, c := range h {
+		out[i] = StatusChange{Status: Status(c.Status), At: c.At}
+	}
+	return out
+}
+
+func toStoreHistory(h []StatusChange) []taskstore.StatusChange {
+	if len(h) == 0 {
+		return nil
+	}
+	out := make([]taskstore.StatusChange, len(h))
+	for i, c := range h {
+		out[i] = taskstore.StatusChange{Status: taskstore.Status(c.Status), At: c.At}
 	}
+	return out
 }
 
 // SetClickUpTaskID assigns a ClickUp task ID to an existing plan entry and
@@ -1,6 +1,7 @@
 package taskstate
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -688,3 +689,283 @@ func TestTaskState_ReviewCycle(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 1, cycle)
 }
+
+func TestArchive(t *testing.T) {
+	ps, store := newTestPSWithStore(t)
+	now := time.Now()
+	require.NoError(t, ps.Create("shipped", "shipped", "plan/shipped", "infra", now))
+	require.NoError(t, ps.Create("dropped", "dropped", "plan/dropped", "", now))
+	require.NoError(t, ps.Create("active", "active", "plan/active", "", now))
+	require.NoError(t, ps.setStatus("shipped", StatusDone))
+	require.NoError(t, ps.setStatus("dropped", StatusCancelled))
+
+	require.NoError(t, ps.Archive("shipped"))
+	require.NoError(t, ps.Archive("dropped"))
+
+	err := ps.Archive("active")
+	assert.ErrorContains(t, err, "want done or cancelled")
+	assert.Error(t, ps.Archive("missing"))
+
+	assert.Empty(t, ps.Finished())
+	assert.Empty(t, ps.Cancelled())
+	for _, topic := range ps.Topics() {
+		assert.NotEqual(t, "infra", topic.Name, "topic with only archived plans must be hidden")
+	}
+
+	// The record survives in the store with the new status.
+	reloaded, err := Load(store, "test-proj", "")
+	require.NoError(t, err)
+	entry, ok := reloaded.Entry("shipped")
+	require.True(t, ok)
+	assert.Equal(t, StatusArchived, entry.Status)
+}
+
+func TestTopics_KeepsTopicWithActivePlan(t *testing.T) {
+	ps := &TaskState{
+		Plans: map[string]TaskEntry{
+			"old":  {Status: StatusArchived, Topic: "ui"},
+			"new":  {Status: StatusReady, Topic: "ui"},
+			"gone": {Status: StatusArchived, Topic: "legacy"},
+		},
+	}
+
+	topics := ps.Topics()
+	require.Len(t, topics, 1)
+	assert.Equal(t, "ui", topics[0].Name)
+}
+
+func TestCompletedAt(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	done := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	ps := &TaskState{
+		Plans: map[string]TaskEntry{
+			"done": {Status: StatusDone, CreatedAt: created, DoneAt: done},
+			"cancelled": {Status: StatusCancelled, CreatedAt: created, History: []StatusChange{
+				{Status: StatusCancelled, At: created.Add(time.Hour)},
+				{Status: StatusReady, At: created.Add(2 * time.Hour)},
+				{Status: StatusCancelled, At: done},
+			}},
+			"untracked": {Status: StatusCancelled, CreatedAt: created},
+		},
+	}
+
+	at, ok := ps.CompletedAt("done")
+	require.True(t, ok)
+	assert.True(t, at.Equal(done))
+
+	at, ok = ps.CompletedAt("cancelled")
+	require.True(t, ok)
+	assert.True(t, at.Equal(done), "cancelled plans use their latest cancellation")
+
+	_, ok = ps.CompletedAt("untracked")
+	assert.False(t, ok, "creation time is never taken as completion time")
+
+	_, ok = ps.CompletedAt("missing")
+	assert.False(t, ok)
+}
+
+func TestSetPriority(t *testing.T) {
+	ps, store := newTestPSWithStore(t)
+	require.NoError(t, ps.Create("plan", "plan", "plan/plan", "", time.Now()))
+
+	require.NoError(t, ps.SetPriority("plan", PriorityHigh))
+	assert.Error(t, ps.SetPriority("plan", PriorityUrgent+1))
+	assert.Error(t, ps.SetPriority("plan", -1))
+	assert.Error(t, ps.SetPriority("missing", PriorityLow))
+
+	reloaded, err := Load(store, "test-proj", "")
+	require.NoError(t, err)
+	entry, ok := reloaded.Entry("plan")
+	require.True(t, ok)
+	assert.Equal(t, PriorityHigh, entry.Priority)
+	require.Len(t, reloaded.UngroupedTasks(), 1)
+	assert.Equal(t, PriorityHigh, reloaded.UngroupedTasks()[0].Priority)
+}
+
+func TestSetPinned(t *testing.T) {
+	ps, store := newTestPSWithStore(t)
+	require.NoError(t, ps.Create("plan", "plan", "plan/plan", "", time.Now()))
+
+	require.NoError(t, ps.SetPinned("plan", true))
+	assert.Error(t, ps.SetPinned("missing", true))
+
+	reloaded, err := Load(store, "test-proj", "")
+	require.NoError(t, err)
+	entry, ok := reloaded.Entry("plan")
+	require.True(t, ok)
+	assert.True(t, entry.Pinned)
+	require.Len(t, reloaded.UngroupedTasks(), 1)
+	assert.True(t, reloaded.UngroupedTasks()[0].Pinned)
+
+	require.NoError(t, reloaded.SetPinned("plan", false))
+	reloaded, err = Load(store, "test-proj", "")
+	require.NoError(t, err)
+	assert.False(t, reloaded.Plans["plan"].Pinned)
+}
+
+func TestForceSetStatusBulk(t *testing.T) {
+	ps, store := newTestPSWithStore(t)
+	for _, f := range []string{"a", "b", "c"} {
+		require.NoError(t, ps.Create(f, f, "plan/"+f, "infra", time.Now()))
+	}
+	require.NoError(t, ps.ForceSetStatus("b", StatusImplementing))
+
+	require.NoError(t, ps.ForceSetStatusBulk([]string{"a", "b"}, StatusDone))
+	assert.Equal(t, StatusDone, ps.Plans["a"].Status)
+	assert.Equal(t, StatusDone, ps.Plans["b"].Status)
+	assert.Equal(t, StatusReady, ps.Plans["c"].Status)
+
+	reloaded, err := Load(store, "test-proj", "")
+	require.NoError(t, err)
+	assert.Equal(t, StatusDone, reloaded.Plans["b"].Status)
+}
+
+func TestForceSetStatusBulk_RejectsUnknownPlanAndStatus(t *testing.T) {
+	ps := newTestPS(t)
+	require.NoError(t, ps.Create("a", "a", "plan/a", "", time.Now()))
+
+	assert.Error(t, ps.ForceSetStatusBulk([]string{"a", "missing"}, StatusDone))
+	assert.Equal(t, StatusReady, ps.Plans["a"].Status, "nothing is written when a plan is unknown")
+
+	assert.Error(t, ps.ForceSetStatusBulk([]string{"a"}, Status("bogus")))
+	assert.NoError(t, ps.ForceSetStatusBulk(nil, StatusDone))
+}
+
+func TestDuplicate(t *testing.T) {
+	ps, store := newTestPSWithStore(t)
+	require.NoError(t, ps.CreateWithContent("auth", "auth refactor", "plan/auth", "backend", time.Now(), "# Auth\n\n## Wave 1\n"))
+	require.NoError(t, ps.SetPriority("auth", PriorityHigh))
+	require.NoError(t, ps.ForceSetStatus("auth", StatusImplementing))
+
+	newFile, err := ps.Duplicate("auth", "auth-copy", "plan/auth-copy")
+	require.NoError(t, err)
+	assert.Equal(t, "auth-copy", newFile)
+
+	reloaded, err := Load(store, "test-proj", "")
+	require.NoError(t, err)
+	entry, ok := reloaded.Entry("auth-copy")
+	require.True(t, ok)
+	assert.Equal(t, StatusReady, entry.Status, "copy starts fresh")
+	assert.Equal(t, "plan/auth-copy", entry.Branch)
+	assert.NotEqual(t, reloaded.Plans["auth"].Branch, entry.Branch)
+	assert.Equal(t, "backend", entry.Topic)
+	assert.Equal(t, "auth refactor", entry.Description)
+	assert.Equal(t, PriorityHigh, entry.Priority)
+
+	content, err := reloaded.GetContent("auth-copy")
+	require.NoError(t, err)
+	assert.Equal(t, "# Auth\n\n## Wave 1\n", content)
+}
+
+func TestDuplicate_Errors(t *testing.T) {
+	ps := newTestPS(t)
+	require.NoError(t, ps.Create("auth", "auth", "plan/auth", "", time.Now()))
+
+	_, err := ps.Duplicate("missing", "missing-copy", "plan/missing-copy")
+	assert.Error(t, err)
+	_, err = ps.Duplicate("auth", "auth", "plan/auth")
+	assert.Error(t, err, "target name must be free")
+}
+
+func TestSetBlockers(t *testing.T) {
+	ps, store := newTestPSWithStore(t)
+	for _, f := range []string{"api", "db", "ui"} {
+		require.NoError(t, ps.Create(f, f, "plan/"+f, "", time.Now()))
+	}
+
+	require.NoError(t, ps.SetBlockers("ui", []string{"db", "api"}))
+	assert.Equal(t, []string{"api", "db"}, ps.UnfinishedBlockers("ui"))
+
+	require.NoError(t, ps.ForceSetStatus("api", StatusDone))
+	assert.Equal(t, []string{"db"}, ps.UnfinishedBlockers("ui"))
+
+	reloaded, err := Load(store, "test-proj", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api", "db"}, reloaded.Plans["ui"].BlockedBy)
+
+	require.NoError(t, ps.SetBlockers("ui", nil))
+	assert.Empty(t, ps.UnfinishedBlockers("ui"))
+}
+
+func TestSetBlockers_Rejects(t *testing.T) {
+	ps := newTestPS(t)
+	for _, f := range []string{"a", "b", "c"} {
+		require.NoError(t, ps.Create(f, f, "plan/"+f, "", time.Now()))
+	}
+	require.NoError(t, ps.SetBlockers("b", []string{"a"}))
+	require.NoError(t, ps.SetBlockers("c", []string{"b"}))
+
+	assert.ErrorContains(t, ps.SetBlockers("a", []string{"a"}), "cannot block itself")
+	assert.ErrorContains(t, ps.SetBlockers("a", []string{"missing"}), "not found")
+	assert.ErrorContains(t, ps.SetBlockers("a", []string{"c"}), "cycle")
+	assert.ErrorContains(t, ps.SetBlockers("missing", nil), "not found")
+	assert.Empty(t, ps.Plans["a"].BlockedBy)
+}
+
+func TestGrepPlans(t *testing.T) {
+	ps := newTestPS(t)
+	now := time.Now()
+	require.NoError(t, ps.CreateWithContent("a.md", "a", "", "", now, "# Alpha\n\nUse the Widget cache.\nnothing here"))
+	require.NoError(t, ps.CreateWithContent("b.md", "b", "", "", now, "# Beta\n  rebuild widget index  "))
+	require.NoError(t, ps.CreateWithContent("c.md", "c", "", "", now, "widget in archived plan"))
+	require.NoError(t, ps.ForceSetStatus("c.md", StatusDone))
+	require.NoError(t, ps.Archive("c.md"))
+
+	matches, err := ps.GrepPlans("WIDGET")
+	require.NoError(t, err)
+	require.Len(t, matches, 2)
+	assert.Equal(t, ContentMatch{Filename: "a.md", Line: 3, Text: "Use the Widget cache."}, matches[0])
+	assert.Equal(t, ContentMatch{Filename: "b.md", Line: 2, Text: "rebuild widget index"}, matches[1])
+
+	matches, err = ps.GrepPlans("   ")
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestGrepPlans_Bounded(t *testing.T) {
+	ps := newTestPS(t)
+	var b strings.Builder
+	for i := 0; i < MaxContentMatches+10; i++ {
+		b.WriteString("match " + strings.Repeat("x", MaxMatchLineLength*2) + "\n")
+	}
+	require.NoError(t, ps.CreateWithContent("big.md", "big", "", "", time.Now(), b.String()))
+
+	matches, err := ps.GrepPlans("match")
+	require.NoError(t, err)
+	assert.Len(t, matches, MaxContentMatches)
+	assert.Len(t, []rune(matches[0].Text), MaxMatchLineLength)
+	assert.True(t, strings.HasSuffix(matches[0].Text, "…"))
+}
+
+func TestRenderReport(t *testing.T) {
+	ps := newTestPS(t)
+	now := time.Now()
+	require.NoError(t, ps.Create("auth.md", "rework auth | sessions", "plan/auth", "backend", now))
+	require.NoError(t, ps.Create("api.md", "api cleanup", "plan/api", "backend", now))
+	require.NoError(t, ps.Create("docs.md", "docs refresh", "plan/docs", "", now))
+	require.NoError(t, ps.Create("old.md", "old work", "plan/old", "backend", now))
+	require.NoError(t, ps.Create("gone.md", "dropped", "plan/gone", "", now))
+	require.NoError(t, ps.ForceSetStatus("api.md", StatusImplementing))
+	require.NoError(t, ps.ForceSetStatus("old.md", StatusDone))
+	require.NoError(t, ps.ForceSetStatus("gone.md", StatusCancelled))
+
+	report := ps.RenderReport(map[string]int{"api.md": 3})
+
+	for _, want := range []string{
+		"| api.md | implementing | plan/api | 3 | api cleanup |",
+		`| auth.md | ready | plan/auth | 0 | rework auth \| sessions |`,
+		"| docs.md | ready | plan/docs | 0 | docs refresh |",
+		"| old.md | done | plan/old | 0 | old work |",
+		"| gone.md | cancelled | plan/gone | 0 | dropped |",
+	} {
+		assert.Contains(t, report, want)
+	}
+
+	backend := strings.Index(report, "## backend")
+	ungrouped := strings.Index(report, "## ungrouped")
+	history := strings.Index(report, "## history")
+	require.True(t, backend >= 0 && ungrouped >= 0 && history >= 0, report)
+	assert.Less(t, backend, ungrouped)
+	assert.Less(t, ungrouped, history)
+	assert.Greater(t, strings.Index(report, "| old.md"), history, "done plans are reported under history, not their topic")
+}
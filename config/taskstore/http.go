@@ -71,6 +71,11 @@ func (s *HTTPStore) taskPRURLURL(project, filename string) string {
 	return fmt.Sprintf("%s/v1/projects/%s/tasks/%s/pr-url", s.baseURL, url.PathEscape(project), url.PathEscape(filename))
 }
 
+// statusesURL builds the URL for the bulk status update endpoint.
+func (s *HTTPStore) statusesURL(project string) string {
+	return fmt.Sprintf("%s/v1/projects/%s/statuses", s.baseURL, url.PathEscape(project))
+}
+
 // taskPRStateURL builds the URL for a task's PR state update endpoint.
 func (s *HTTPStore) taskPRStateURL(project, filename string) string {
 	return fmt.Sprintf("%s/v1/projects/%s/tasks/%s/pr-state", s.baseURL, url.PathEscape(project), url.PathEscape(filename))
@@ -180,7 +185,8 @@ func (s *HTTPStore) Get(project, filename string) (TaskEntry, error) {
 	return entry, nil
 }
 
-// Update replaces an existing task entry.
+// Update replaces an existing task entry. A 409 from the server means the
+// entry's version is stale and is reported as ErrVersionConflict.
 func (s *HTTPStore) Update(project, filename string, entry TaskEntry) error {
 	body, err := json.Marshal(entry)
 	if err != nil {
@@ -198,6 +204,9 @@ func (s *HTTPStore) Update(project, filename string, entry TaskEntry) error {
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusConflict {
+		return fmt.Errorf("task store: %w: %s", ErrVersionConflict, filename)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return decodeError(resp)
 	}
@@ -636,6 +645,33 @@ func (s *HTTPStore) SetPRURL(project, filename, prURL string) error {
 	return nil
 }
 
+// SetStatuses sets status on every listed plan in a single request.
+func (s *HTTPStore) SetStatuses(project string, filenames []string, status Status) error {
+	body, err := json.Marshal(struct {
+		Filenames []string `json:"filenames"`
+		Status    Status   `json:"status"`
+	}{Filenames: filenames, Status: status})
+	if err != nil {
+		return fmt.Errorf("task store: marshal statuses payload: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPut, s.statusesURL(project), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("task store: build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return decodeError(resp)
+	}
+	return nil
+}
+
 // SetPRState sets the review decision and check status for an existing task entry.
 func (s *HTTPStore) SetPRState(project, filename, reviewDecision, checkStatus string) error {
 	body, err := json.Marshal(struct {
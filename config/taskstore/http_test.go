@@ -42,6 +42,26 @@ func TestHTTPStore_ContentRoundTrip(t *testing.T) {
 	assert.Equal(t, "# Updated Plan", content)
 }
 
+func TestHTTPStore_UpdateStaleVersionConflicts(t *testing.T) {
+	store := newTestHTTPStore(t)
+	require.NoError(t, store.Create("proj", taskstore.TaskEntry{Filename: "test", Status: taskstore.StatusReady}))
+
+	stale, err := store.Get("proj", "test")
+	require.NoError(t, err)
+	fresh := stale
+	fresh.Status = taskstore.StatusPlanning
+	require.NoError(t, store.Update("proj", "test", fresh))
+
+	stale.Status = taskstore.StatusCancelled
+	require.ErrorIs(t, store.Update("proj", "test", stale), taskstore.ErrVersionConflict)
+
+	// After reloading, the write goes through.
+	reloaded, err := store.Get("proj", "test")
+	require.NoError(t, err)
+	reloaded.Status = taskstore.StatusCancelled
+	require.NoError(t, store.Update("proj", "test", reloaded))
+}
+
 func TestHTTPStore_RoundTrip(t *testing.T) {
 	backend := newTestStore(t)
 	srv := httptest.NewServer(taskstore.NewHandler(backend))
@@ -69,6 +89,36 @@ func TestHTTPStore_RoundTrip(t *testing.T) {
 	assert.Equal(t, taskstore.StatusImplementing, plans[0].Status)
 }
 
+func TestHTTPStore_PriorityRoundTrip(t *testing.T) {
+	store := newTestHTTPStore(t)
+	require.NoError(t, store.Create("kasmos", taskstore.TaskEntry{Filename: "test", Status: taskstore.StatusReady}))
+
+	got, err := store.Get("kasmos", "test")
+	require.NoError(t, err)
+	got.Priority = 3
+	require.NoError(t, store.Update("kasmos", "test", got))
+
+	got, err = store.Get("kasmos", "test")
+	require.NoError(t, err)
+	assert.Equal(t, 3, got.Priority)
+}
+
+func TestHTTPStore_SetStatuses(t *testing.T) {
+	store := newTestHTTPStore(t)
+	for _, f := range []string{"a", "b"} {
+		require.NoError(t, store.Create("kasmos", taskstore.TaskEntry{Filename: f, Status: taskstore.StatusReady}))
+	}
+
+	require.NoError(t, store.SetStatuses("kasmos", []string{"a", "b"}, taskstore.StatusDone))
+	plans, err := store.List("kasmos")
+	require.NoError(t, err)
+	for _, p := range plans {
+		assert.Equal(t, taskstore.StatusDone, p.Status, p.Filename)
+	}
+
+	assert.Error(t, store.SetStatuses("kasmos", []string{"a", "missing"}, taskstore.StatusReady))
+}
+
 func TestHTTPStore_ServerUnreachable(t *testing.T) {
 	client := taskstore.NewHTTPStore("http://127.0.0.1:1", "kasmos")
 	_, err := client.List("kasmos")
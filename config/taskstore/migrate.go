@@ -118,6 +118,81 @@ func MigrateFromJSON(store Store, project, plansDir string) (int, error) {
 	return migrated, nil
 }
 
+// MigrateStore copies every topic and task of project from src into dst, for
+// moving task state between backends (e.g. local SQLite to a remote server).
+// Each task is created with all of its metadata, then its content and
+// subtasks are copied. The migration is idempotent: topics and tasks that
+// already exist in dst are left untouched.
+//
+// Returns the number of tasks created and the number skipped as existing.
+func MigrateStore(src, dst Store, project string) (migrated, skipped int, err error) {
+	topics, err := src.ListTopics(project)
+	if err != nil {
+		return 0, 0, fmt.Errorf("list source topics: %w", err)
+	}
+	dstTopics, err := dst.ListTopics(project)
+	if err != nil {
+		return 0, 0, fmt.Errorf("list destination topics: %w", err)
+	}
+	haveTopic := make(map[string]bool, len(dstTopics))
+	for _, t := range dstTopics {
+		haveTopic[t.Name] = true
+	}
+	for _, t := range topics {
+		if haveTopic[t.Name] {
+			continue
+		}
+		if err := dst.CreateTopic(project, t); err != nil {
+			return 0, 0, fmt.Errorf("migrate topic %s: %w", t.Name, err)
+		}
+	}
+
+	entries, err := src.List(project)
+	if err != nil {
+		return 0, 0, fmt.Errorf("list source tasks: %w", err)
+	}
+	dstEntries, err := dst.List(project)
+	if err != nil {
+		return 0, 0, fmt.Errorf("list destination tasks: %w", err)
+	}
+	haveTask := make(map[string]bool, len(dstEntries))
+	for _, e := range dstEntries {
+		haveTask[e.Filename] = true
+	}
+
+	for _, entry := range entries {
+		if haveTask[entry.Filename] {
+			skipped++
+			continue
+		}
+		content, err := src.GetContent(project, entry.Filename)
+		if err != nil {
+			return migrated, skipped, fmt.Errorf("read content of %s: %w", entry.Filename, err)
+		}
+		subtasks, err := src.GetSubtasks(project, entry.Filename)
+		if err != nil {
+			return migrated, skipped, fmt.Errorf("read subtasks of %s: %w", entry.Filename, err)
+		}
+
+		if err := dst.Create(project, entry); err != nil {
+			return migrated, skipped, fmt.Errorf("migrate task %s: %w", entry.Filename, err)
+		}
+		if content != "" {
+			if err := dst.SetContent(project, entry.Filename, content); err != nil {
+				return migrated, skipped, fmt.Errorf("set content for %s: %w", entry.Filename, err)
+			}
+		}
+		if len(subtasks) > 0 {
+			if err := dst.SetSubtasks(project, entry.Filename, subtasks); err != nil {
+				return migrated, skipped, fmt.Errorf("set subtasks for %s: %w", entry.Filename, err)
+			}
+		}
+		migrated++
+	}
+
+	return migrated, skipped, nil
+}
+
 // migrateFromPlanstoreDB copies data from a legacy planstore.db file into the
 // current taskstore.db. This handles the rename-plan-to-task transition where
 // the DB filename changed but existing users still have their data in the old
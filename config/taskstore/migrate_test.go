@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -70,6 +71,58 @@ func TestMigrateFromJSON_NoFile(t *testing.T) {
 	assert.Equal(t, 0, migrated)
 }
 
+func TestMigrateStore(t *testing.T) {
+	src := newTestStore(t)
+	dst := newTestStore(t)
+
+	require.NoError(t, src.CreateTopic("proj", TopicEntry{Name: "auth", CreatedAt: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)}))
+	require.NoError(t, src.Create("proj", TaskEntry{Filename: "login", Status: StatusImplementing, Branch: "plan/login", Topic: "auth", Description: "login flow"}))
+	require.NoError(t, src.Create("proj", TaskEntry{Filename: "logout", Status: StatusDone, Branch: "plan/logout", Topic: "auth"}))
+	require.NoError(t, src.Create("proj", TaskEntry{Filename: "docs", Status: StatusReady}))
+	require.NoError(t, src.SetContent("proj", "login", "# Login"))
+	require.NoError(t, src.SetSubtasks("proj", "login", []SubtaskEntry{{TaskNumber: 1, Title: "form", Status: SubtaskStatusComplete}}))
+	require.NoError(t, src.Create("other", TaskEntry{Filename: "elsewhere", Status: StatusReady}))
+
+	// A task already present in the destination is kept as is.
+	require.NoError(t, dst.Create("proj", TaskEntry{Filename: "docs", Status: StatusPlanning}))
+
+	migrated, skipped, err := MigrateStore(src, dst, "proj")
+	require.NoError(t, err)
+	assert.Equal(t, 2, migrated)
+	assert.Equal(t, 1, skipped)
+
+	login, err := dst.Get("proj", "login")
+	require.NoError(t, err)
+	assert.Equal(t, StatusImplementing, login.Status)
+	assert.Equal(t, "plan/login", login.Branch)
+	assert.Equal(t, "auth", login.Topic)
+	assert.Equal(t, "login flow", login.Description)
+
+	content, err := dst.GetContent("proj", "login")
+	require.NoError(t, err)
+	assert.Equal(t, "# Login", content)
+	subtasks, err := dst.GetSubtasks("proj", "login")
+	require.NoError(t, err)
+	assert.Equal(t, []SubtaskEntry{{TaskNumber: 1, Title: "form", Status: SubtaskStatusComplete}}, subtasks)
+
+	docs, err := dst.Get("proj", "docs")
+	require.NoError(t, err)
+	assert.Equal(t, StatusPlanning, docs.Status, "existing task must not be overwritten")
+
+	topics, err := dst.ListTopics("proj")
+	require.NoError(t, err)
+	assert.Len(t, topics, 1)
+
+	_, err = dst.Get("other", "elsewhere")
+	assert.Error(t, err, "other projects are not migrated")
+
+	// Re-running is a no-op.
+	migrated, skipped, err = MigrateStore(src, dst, "proj")
+	require.NoError(t, err)
+	assert.Equal(t, 0, migrated)
+	assert.Equal(t, 3, skipped)
+}
+
 func TestMigrateFromPlanstoreDB(t *testing.T) {
 	dir := t.TempDir()
 	oldDBPath := filepath.Join(dir, "planstore.db")
@@ -2,6 +2,7 @@ package taskstore
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"strconv"
@@ -97,6 +98,10 @@ func NewHandler(store Store) http.Handler {
 				writeError(w, http.StatusNotFound, "task not found: "+filename)
 				return
 			}
+			if errors.Is(err, ErrVersionConflict) {
+				writeError(w, http.StatusConflict, err.Error())
+				return
+			}
 			writeError(w, http.StatusInternalServerError, err.Error())
 			return
 		}
@@ -334,6 +339,28 @@ func NewHandler(store Store) http.Handler {
 		w.WriteHeader(http.StatusOK)
 	})
 
+	// Set status on several tasks at once
+	mux.HandleFunc("PUT /v1/projects/{project}/statuses", func(w http.ResponseWriter, r *http.Request) {
+		project := r.PathValue("project")
+		var req struct {
+			Filenames []string `json:"filenames"`
+			Status    Status   `json:"status"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+			return
+		}
+		if err := store.SetStatuses(project, req.Filenames, req.Status); err != nil {
+			if isNotFound(err) {
+				writeError(w, http.StatusNotFound, err.Error())
+				return
+			}
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
 	// Set PR state
 	mux.HandleFunc("PUT /v1/projects/{project}/tasks/{filename}/pr-state", func(w http.ResponseWriter, r *http.Request) {
 		project := r.PathValue("project")
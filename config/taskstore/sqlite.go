@@ -2,6 +2,7 @@ package taskstore
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -105,6 +106,28 @@ const prReviewDecisionMigration = `ALTER TABLE tasks ADD COLUMN pr_review_decisi
 // prCheckStatusMigration adds the pr_check_status column to existing databases.
 const prCheckStatusMigration = `ALTER TABLE tasks ADD COLUMN pr_check_status TEXT NOT NULL DEFAULT ''`
 
+// priorityMigration adds the priority column to existing databases.
+const priorityMigration = `ALTER TABLE tasks ADD COLUMN priority INTEGER NOT NULL DEFAULT 0`
+
+// blockedByMigration adds the blocked_by column to existing databases. It holds
+// the blocking plan filenames, comma-separated.
+const blockedByMigration = `ALTER TABLE tasks ADD COLUMN blocked_by TEXT NOT NULL DEFAULT ''`
+
+// pinnedMigration adds the pinned column to existing databases.
+const pinnedMigration = `ALTER TABLE tasks ADD COLUMN pinned INTEGER NOT NULL DEFAULT 0`
+
+// autoPushMigration adds the auto_push column to existing databases. It holds
+// the plan's auto-push override: "on", "off", or "" to inherit the global setting.
+const autoPushMigration = `ALTER TABLE tasks ADD COLUMN auto_push TEXT NOT NULL DEFAULT ''`
+
+// versionMigration adds the version column to existing databases. It is bumped
+// on every full or status write and checked by Update for optimistic concurrency.
+const versionMigration = `ALTER TABLE tasks ADD COLUMN version INTEGER NOT NULL DEFAULT 1`
+
+// historyMigration adds the history column to existing databases. It holds the
+// status-change timeline as a JSON array.
+const historyMigration = `ALTER TABLE tasks ADD COLUMN history TEXT NOT NULL DEFAULT ''`
+
 // SQLiteStore is a Store implementation backed by a SQLite database.
 type SQLiteStore struct {
 	db *sql.DB
@@ -206,6 +229,30 @@ func NewSQLiteStore(dbPath string) (*SQLiteStore, error) {
 		db.Close()
 		return nil, fmt.Errorf("migrate pr_check_status column: %w", err)
 	}
+	if err := migrateAddColumn(db, "priority", priorityMigration); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrate priority column: %w", err)
+	}
+	if err := migrateAddColumn(db, "blocked_by", blockedByMigration); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrate blocked_by column: %w", err)
+	}
+	if err := migrateAddColumn(db, "pinned", pinnedMigration); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrate pinned column: %w", err)
+	}
+	if err := migrateAddColumn(db, "history", historyMigration); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrate history column: %w", err)
+	}
+	if err := migrateAddColumn(db, "auto_push", autoPushMigration); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrate auto_push column: %w", err)
+	}
+	if err := migrateAddColumn(db, "version", versionMigration); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrate version column: %w", err)
+	}
 
 	// Create subtasks table if missing.
 	if _, err := db.Exec(subtasksTableMigration); err != nil {
@@ -331,8 +378,8 @@ func (s *SQLiteStore) Ping() error {
 // Returns an error if a task with the same filename already exists in the project.
 func (s *SQLiteStore) Create(project string, entry TaskEntry) error {
 	const q = `
-		INSERT INTO tasks (project, filename, status, description, branch, topic, created_at, implemented, planning_at, implementing_at, reviewing_at, done_at, goal, content, clickup_task_id, review_cycle, pr_url, pr_review_decision, pr_check_status)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO tasks (project, filename, status, description, branch, topic, created_at, implemented, planning_at, implementing_at, reviewing_at, done_at, goal, content, clickup_task_id, review_cycle, pr_url, pr_review_decision, pr_check_status, priority, blocked_by, pinned, history, auto_push)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	_, err := s.db.Exec(q,
 		project,
@@ -354,6 +401,11 @@ func (s *SQLiteStore) Create(project string, entry TaskEntry) error {
 		entry.PRURL,
 		entry.PRReviewDecision,
 		entry.PRCheckStatus,
+		entry.Priority,
+		joinBlockedBy(entry.BlockedBy),
+		entry.Pinned,
+		encodeHistory(entry.History),
+		encodeAutoPush(entry.AutoPush),
 	)
 	if err != nil {
 		if isUniqueConstraintError(err) {
@@ -368,7 +420,7 @@ func (s *SQLiteStore) Create(project string, entry TaskEntry) error {
 // Returns an error if the task is not found.
 func (s *SQLiteStore) Get(project, filename string) (TaskEntry, error) {
 	const q = `
-		SELECT filename, status, description, branch, topic, created_at, implemented, planning_at, implementing_at, reviewing_at, done_at, goal, content, clickup_task_id, review_cycle, pr_url, pr_review_decision, pr_check_status
+		SELECT filename, status, description, branch, topic, created_at, implemented, planning_at, implementing_at, reviewing_at, done_at, goal, content, clickup_task_id, review_cycle, pr_url, pr_review_decision, pr_check_status, priority, blocked_by, pinned, history, auto_push, version
 		FROM tasks
 		WHERE project = ? AND filename = ?
 	`
@@ -376,13 +428,14 @@ func (s *SQLiteStore) Get(project, filename string) (TaskEntry, error) {
 	return scanTaskEntry(row)
 }
 
-// Update replaces all fields of an existing task entry.
-// Returns an error if the task is not found.
+// Update replaces all fields of an existing task entry and bumps its version.
+// Returns an error if the task is not found, or ErrVersionConflict if
+// entry.Version is set and does not match the stored version.
 func (s *SQLiteStore) Update(project, filename string, entry TaskEntry) error {
 	const q = `
 		UPDATE tasks
-		SET status = ?, description = ?, branch = ?, topic = ?, created_at = ?, implemented = ?, planning_at = ?, implementing_at = ?, reviewing_at = ?, done_at = ?, goal = ?, clickup_task_id = ?, review_cycle = ?
-		WHERE project = ? AND filename = ?
+		SET status = ?, description = ?, branch = ?, topic = ?, created_at = ?, implemented = ?, planning_at = ?, implementing_at = ?, reviewing_at = ?, done_at = ?, goal = ?, clickup_task_id = ?, review_cycle = ?, priority = ?, blocked_by = ?, pinned = ?, history = ?, auto_push = ?, version = version + 1
+		WHERE project = ? AND filename = ? AND (? = 0 OR version = ?)
 	`
 	result, err := s.db.Exec(q,
 		string(entry.Status),
@@ -398,8 +451,15 @@ func (s *SQLiteStore) Update(project, filename string, entry TaskEntry) error {
 		entry.Goal,
 		entry.ClickUpTaskID,
 		entry.ReviewCycle,
+		entry.Priority,
+		joinBlockedBy(entry.BlockedBy),
+		entry.Pinned,
+		encodeHistory(entry.History),
+		encodeAutoPush(entry.AutoPush),
 		project,
 		filename,
+		entry.Version,
+		entry.Version,
 	)
 	if err != nil {
 		return fmt.Errorf("update plan: %w", err)
@@ -409,7 +469,15 @@ func (s *SQLiteStore) Update(project, filename string, entry TaskEntry) error {
 		return fmt.Errorf("update plan rows affected: %w", err)
 	}
 	if n == 0 {
-		return fmt.Errorf("plan not found: %s/%s", project, filename)
+		var current int
+		err := s.db.QueryRow(`SELECT version FROM tasks WHERE project = ? AND filename = ?`, project, filename).Scan(&current)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("plan not found: %s/%s", project, filename)
+		}
+		if err != nil {
+			return fmt.Errorf("update plan version: %w", err)
+		}
+		return fmt.Errorf("%w: %s/%s is at version %d, not %d", ErrVersionConflict, project, filename, current, entry.Version)
 	}
 	return nil
 }
@@ -442,7 +510,7 @@ func (s *SQLiteStore) Rename(project, oldFilename, newFilename string) error {
 // List returns all task entries for the given project, sorted by filename.
 func (s *SQLiteStore) List(project string) ([]TaskEntry, error) {
 	const q = `
-		SELECT filename, status, description, branch, topic, created_at, implemented, planning_at, implementing_at, reviewing_at, done_at, goal, content, clickup_task_id, review_cycle, pr_url, pr_review_decision, pr_check_status
+		SELECT filename, status, description, branch, topic, created_at, implemented, planning_at, implementing_at, reviewing_at, done_at, goal, content, clickup_task_id, review_cycle, pr_url, pr_review_decision, pr_check_status, priority, blocked_by, pinned, history, auto_push, version
 		FROM tasks
 		WHERE project = ?
 		ORDER BY filename ASC
@@ -471,7 +539,7 @@ func (s *SQLiteStore) ListByStatus(project string, statuses ...Status) ([]TaskEn
 	}
 
 	q := fmt.Sprintf(`
-		SELECT filename, status, description, branch, topic, created_at, implemented, planning_at, implementing_at, reviewing_at, done_at, goal, content, clickup_task_id, review_cycle, pr_url, pr_review_decision, pr_check_status
+		SELECT filename, status, description, branch, topic, created_at, implemented, planning_at, implementing_at, reviewing_at, done_at, goal, content, clickup_task_id, review_cycle, pr_url, pr_review_decision, pr_check_status, priority, blocked_by, pinned, history, auto_push, version
 		FROM tasks
 		WHERE project = ? AND status IN (%s)
 		ORDER BY filename ASC
@@ -489,7 +557,7 @@ func (s *SQLiteStore) ListByStatus(project string, statuses ...Status) ([]TaskEn
 // sorted by filename.
 func (s *SQLiteStore) ListByTopic(project, topic string) ([]TaskEntry, error) {
 	const q = `
-		SELECT filename, status, description, branch, topic, created_at, implemented, planning_at, implementing_at, reviewing_at, done_at, goal, content, clickup_task_id, review_cycle, pr_url, pr_review_decision, pr_check_status
+		SELECT filename, status, description, branch, topic, created_at, implemented, planning_at, implementing_at, reviewing_at, done_at, goal, content, clickup_task_id, review_cycle, pr_url, pr_review_decision, pr_check_status, priority, blocked_by, pinned, history, auto_push, version
 		FROM tasks
 		WHERE project = ? AND topic = ?
 		ORDER BY filename ASC
@@ -770,6 +838,41 @@ func (s *SQLiteStore) SetPRURL(project, filename, url string) error {
 	return nil
 }
 
+// SetStatuses sets status on every listed plan in one transaction. Returns an
+// error, and changes nothing, if any plan is not found.
+func (s *SQLiteStore) SetStatuses(project string, filenames []string, status Status) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin status transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	for _, filename := range filenames {
+		var result sql.Result
+		result, err = tx.Exec(`UPDATE tasks SET status = ?, version = version + 1 WHERE project = ? AND filename = ?`, string(status), project, filename)
+		if err != nil {
+			return fmt.Errorf("set status: %w", err)
+		}
+		var n int64
+		if n, err = result.RowsAffected(); err != nil {
+			return fmt.Errorf("set status rows affected: %w", err)
+		}
+		if n == 0 {
+			err = fmt.Errorf("plan not found: %s/%s", project, filename)
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit statuses: %w", err)
+	}
+	return nil
+}
+
 // SetPRState sets the review decision and check status for an existing task entry.
 // Returns an error if the task is not found.
 func (s *SQLiteStore) SetPRState(project, filename, reviewDecision, checkStatus string) error {
@@ -887,8 +990,10 @@ func (s *SQLiteStore) ListPendingReviews(project, filename string) ([]PRReviewEn
 // scanTaskEntry scans a single row into a TaskEntry.
 func scanTaskEntry(row *sql.Row) (TaskEntry, error) {
 	var filename, status, description, branch, topic, createdAt, implemented, planningAt, implementingAt, reviewingAt, doneAt, goal, content, clickupTaskID string
-	var reviewCycle int
-	var prURL, prReviewDecision, prCheckStatus string
+	var reviewCycle, priority int
+	var prURL, prReviewDecision, prCheckStatus, blockedBy, history, autoPush string
+	var pinned bool
+	var version int
 	if err := row.Scan(
 		&filename,
 		&status,
@@ -908,6 +1013,12 @@ func scanTaskEntry(row *sql.Row) (TaskEntry, error) {
 		&prURL,
 		&prReviewDecision,
 		&prCheckStatus,
+		&priority,
+		&blockedBy,
+		&pinned,
+		&history,
+		&autoPush,
+		&version,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return TaskEntry{}, fmt.Errorf("plan not found")
@@ -933,6 +1044,12 @@ func scanTaskEntry(row *sql.Row) (TaskEntry, error) {
 		PRURL:            prURL,
 		PRReviewDecision: prReviewDecision,
 		PRCheckStatus:    prCheckStatus,
+		Priority:         priority,
+		BlockedBy:        splitBlockedBy(blockedBy),
+		Pinned:           pinned,
+		History:          decodeHistory(history),
+		AutoPush:         decodeAutoPush(autoPush),
+		Version:          version,
 	}, nil
 }
 
@@ -941,8 +1058,10 @@ func scanTaskEntries(rows *sql.Rows) ([]TaskEntry, error) {
 	var entries []TaskEntry
 	for rows.Next() {
 		var filename, status, description, branch, topic, createdAt, implemented, planningAt, implementingAt, reviewingAt, doneAt, goal, content, clickupTaskID string
-		var reviewCycle int
-		var prURL, prReviewDecision, prCheckStatus string
+		var reviewCycle, priority int
+		var prURL, prReviewDecision, prCheckStatus, blockedBy, history, autoPush string
+		var pinned bool
+		var version int
 		if err := rows.Scan(
 			&filename,
 			&status,
@@ -962,6 +1081,12 @@ func scanTaskEntries(rows *sql.Rows) ([]TaskEntry, error) {
 			&prURL,
 			&prReviewDecision,
 			&prCheckStatus,
+			&priority,
+			&blockedBy,
+			&pinned,
+			&history,
+			&autoPush,
+			&version,
 		); err != nil {
 			return nil, fmt.Errorf("scan plan: %w", err)
 		}
@@ -984,6 +1109,12 @@ func scanTaskEntries(rows *sql.Rows) ([]TaskEntry, error) {
 			PRURL:            prURL,
 			PRReviewDecision: prReviewDecision,
 			PRCheckStatus:    prCheckStatus,
+			Priority:         priority,
+			BlockedBy:        splitBlockedBy(blockedBy),
+			Pinned:           pinned,
+			History:          decodeHistory(history),
+			AutoPush:         decodeAutoPush(autoPush),
+			Version:          version,
 		})
 	}
 	if err := rows.Err(); err != nil {
@@ -992,6 +1123,69 @@ func scanTaskEntries(rows *sql.Rows) ([]TaskEntry, error) {
 	return entries, nil
 }
 
+// joinBlockedBy encodes blocker filenames for the blocked_by column.
+func joinBlockedBy(blockers []string) string {
+	return strings.Join(blockers, ",")
+}
+
+// splitBlockedBy decodes the blocked_by column; "" yields nil.
+func splitBlockedBy(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+	return strings.Split(raw, ",")
+}
+
+// encodeAutoPush encodes an auto-push override for the auto_push column.
+func encodeAutoPush(v *bool) string {
+	switch {
+	case v == nil:
+		return ""
+	case *v:
+		return "on"
+	default:
+		return "off"
+	}
+}
+
+// decodeAutoPush decodes the auto_push column; "" or an unknown value yields nil.
+func decodeAutoPush(raw string) *bool {
+	var v bool
+	switch raw {
+	case "on":
+		v = true
+	case "off":
+	default:
+		return nil
+	}
+	return &v
+}
+
+// encodeHistory encodes a status timeline for the history column; empty
+// yields "".
+func encodeHistory(history []StatusChange) string {
+	if len(history) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(history)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// decodeHistory decodes the history column; "" or malformed JSON yields nil.
+func decodeHistory(raw string) []StatusChange {
+	if raw == "" {
+		return nil
+	}
+	var history []StatusChange
+	if err := json.Unmarshal([]byte(raw), &history); err != nil {
+		return nil
+	}
+	return history
+}
+
 // formatTime formats a time.Time as RFC3339 for storage. Zero time returns empty string.
 func formatTime(t time.Time) string {
 	if t.IsZero() {
@@ -108,6 +108,117 @@ func TestSQLiteStore_Update(t *testing.T) {
 	assert.Equal(t, "updated description", got.Description)
 }
 
+func TestSQLiteStore_UpdateRejectsStaleVersion(t *testing.T) {
+	store := newTestStore(t)
+	require.NoError(t, store.Create("kasmos", taskstore.TaskEntry{Filename: "v", Status: taskstore.StatusReady}))
+
+	mine, err := store.Get("kasmos", "v")
+	require.NoError(t, err)
+	theirs := mine
+	assert.Equal(t, 1, mine.Version)
+
+	theirs.Status = taskstore.StatusPlanning
+	require.NoError(t, store.Update("kasmos", "v", theirs))
+
+	mine.Status = taskstore.StatusCancelled
+	err = store.Update("kasmos", "v", mine)
+	require.ErrorIs(t, err, taskstore.ErrVersionConflict)
+
+	got, err := store.Get("kasmos", "v")
+	require.NoError(t, err)
+	assert.Equal(t, taskstore.StatusPlanning, got.Status)
+	assert.Equal(t, 2, got.Version)
+
+	// Status-only writes bump the version too.
+	require.NoError(t, store.SetStatuses("kasmos", []string{"v"}, taskstore.StatusReady))
+	got.Status = taskstore.StatusDone
+	require.ErrorIs(t, store.Update("kasmos", "v", got), taskstore.ErrVersionConflict)
+
+	// Version zero writes unconditionally.
+	got.Version = 0
+	require.NoError(t, store.Update("kasmos", "v", got))
+}
+
+func TestSQLiteStore_SetStatuses(t *testing.T) {
+	store := newTestStore(t)
+	for _, f := range []string{"one", "two", "three"} {
+		require.NoError(t, store.Create("kasmos", taskstore.TaskEntry{Filename: f, Status: taskstore.StatusReady}))
+	}
+
+	require.NoError(t, store.SetStatuses("kasmos", []string{"one", "two"}, taskstore.StatusCancelled))
+	for f, want := range map[string]taskstore.Status{
+		"one": taskstore.StatusCancelled, "two": taskstore.StatusCancelled, "three": taskstore.StatusReady,
+	} {
+		got, err := store.Get("kasmos", f)
+		require.NoError(t, err)
+		assert.Equal(t, want, got.Status, f)
+	}
+}
+
+func TestSQLiteStore_SetStatusesRollsBackOnMissingPlan(t *testing.T) {
+	store := newTestStore(t)
+	require.NoError(t, store.Create("kasmos", taskstore.TaskEntry{Filename: "one", Status: taskstore.StatusReady}))
+
+	err := store.SetStatuses("kasmos", []string{"one", "ghost"}, taskstore.StatusDone)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ghost")
+
+	got, err := store.Get("kasmos", "one")
+	require.NoError(t, err)
+	assert.Equal(t, taskstore.StatusReady, got.Status, "partial update must be rolled back")
+}
+
+func TestSQLiteStore_Priority(t *testing.T) {
+	store := newTestStore(t)
+	entry := taskstore.TaskEntry{Filename: "prio", Status: taskstore.StatusReady, Priority: 2}
+	require.NoError(t, store.Create("kasmos", entry))
+
+	got, err := store.Get("kasmos", "prio")
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.Priority)
+
+	got.Priority = 3
+	require.NoError(t, store.Update("kasmos", "prio", got))
+	plans, err := store.List("kasmos")
+	require.NoError(t, err)
+	require.Len(t, plans, 1)
+	assert.Equal(t, 3, plans[0].Priority)
+}
+
+func TestSQLiteStore_Pinned(t *testing.T) {
+	store := newTestStore(t)
+	require.NoError(t, store.Create("kasmos", taskstore.TaskEntry{Filename: "pin", Status: taskstore.StatusReady, Pinned: true}))
+
+	got, err := store.Get("kasmos", "pin")
+	require.NoError(t, err)
+	assert.True(t, got.Pinned)
+
+	got.Pinned = false
+	require.NoError(t, store.Update("kasmos", "pin", got))
+	plans, err := store.List("kasmos")
+	require.NoError(t, err)
+	require.Len(t, plans, 1)
+	assert.False(t, plans[0].Pinned)
+}
+
+func TestSQLiteStore_AutoPushOverride(t *testing.T) {
+	store := newTestStore(t)
+	require.NoError(t, store.Create("kasmos", taskstore.TaskEntry{Filename: "push", Status: taskstore.StatusReady}))
+
+	got, err := store.Get("kasmos", "push")
+	require.NoError(t, err)
+	assert.Nil(t, got.AutoPush, "no override by default")
+
+	off := false
+	got.AutoPush = &off
+	require.NoError(t, store.Update("kasmos", "push", got))
+	plans, err := store.List("kasmos")
+	require.NoError(t, err)
+	require.Len(t, plans, 1)
+	require.NotNil(t, plans[0].AutoPush)
+	assert.False(t, *plans[0].AutoPush)
+}
+
 // TestSQLiteStore_UpdatePreservesContent verifies that Update does not
 // overwrite content stored via SetContent. This is a regression test for a bug
 // where every FSM status transition would nuke the content column because
@@ -3,7 +3,15 @@
 // for client-server communication.
 package taskstore
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+// ErrVersionConflict is returned by Update when the entry's Version no longer
+// matches the stored one, i.e. someone else wrote the plan since it was loaded.
+// Callers should reload and retry.
+var ErrVersionConflict = errors.New("plan changed remotely")
 
 // PRReviewEntry holds a persisted PR review record for a single plan.
 type PRReviewEntry struct {
@@ -28,6 +36,7 @@ const (
 	StatusCancelled    Status = "cancelled"
 	StatusPlanning     Status = "planning"
 	StatusImplementing Status = "implementing"
+	StatusArchived     Status = "archived"
 )
 
 // TaskEntry holds the persisted metadata for a single plan.
@@ -50,6 +59,23 @@ type TaskEntry struct {
 	PRURL            string    `json:"pr_url,omitempty"`
 	PRReviewDecision string    `json:"pr_review_decision,omitempty"`
 	PRCheckStatus    string    `json:"pr_check_status,omitempty"`
+	Priority         int       `json:"priority,omitempty"`
+	BlockedBy        []string  `json:"blocked_by,omitempty"`
+	Pinned           bool      `json:"pinned,omitempty"`
+	// AutoPush overrides the global auto-push setting for the plan; nil inherits it.
+	AutoPush *bool `json:"auto_push,omitempty"`
+	// History lists the plan's status changes, oldest first.
+	History []StatusChange `json:"history,omitempty"`
+	// Version is bumped by every Update and SetStatuses. Update rejects a
+	// non-zero Version that differs from the stored one; zero writes
+	// unconditionally.
+	Version int `json:"version,omitempty"`
+}
+
+// StatusChange records a plan entering a status.
+type StatusChange struct {
+	Status Status    `json:"status"`
+	At     time.Time `json:"at"`
 }
 
 // SubtaskStatus represents the lifecycle state of a subtask.
@@ -86,7 +112,12 @@ type Store interface {
 	// Plan CRUD
 	Create(project string, entry TaskEntry) error
 	Get(project, filename string) (TaskEntry, error)
+	// Update replaces a plan. It fails with ErrVersionConflict when
+	// entry.Version is set and the plan was written since it was loaded.
 	Update(project, filename string, entry TaskEntry) error
+	// SetStatuses sets status on every listed plan in a single write. Either
+	// all plans are updated or none are (e.g. when one is not found).
+	SetStatuses(project string, filenames []string, status Status) error
 	Rename(project, oldFilename, newFilename string) error
 
 	// Content access
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// PlanTemplate is a markdown file offered as the starting content for a new plan.
+type PlanTemplate struct {
+	// Name is the file name without the .md extension.
+	Name string
+	// Content is the raw template body, before placeholder substitution.
+	Content string
+}
+
+// PlanTemplatesDir returns ~/.config/kasmos/templates.
+func PlanTemplatesDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("plan templates: resolve home dir: %w", err)
+	}
+	return filepath.Join(home, ".config", "kasmos", "templates"), nil
+}
+
+// LoadPlanTemplates reads every *.md file in PlanTemplatesDir, sorted by name.
+// A missing directory yields no templates and no error.
+func LoadPlanTemplates() ([]PlanTemplate, error) {
+	dir, err := PlanTemplatesDir()
+	if err != nil {
+		return nil, err
+	}
+	return loadPlanTemplatesFrom(dir)
+}
+
+func loadPlanTemplatesFrom(dir string) ([]PlanTemplate, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("plan templates: read %s: %w", dir, err)
+	}
+
+	var templates []PlanTemplate
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".md" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("plan templates: read %s: %w", e.Name(), err)
+		}
+		templates = append(templates, PlanTemplate{
+			Name:    strings.TrimSuffix(e.Name(), ".md"),
+			Content: string(data),
+		})
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates, nil
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadPlanTemplates(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	dir := filepath.Join(home, ".config", "kasmos", "templates")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "nested.md"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "feature.md"), []byte("# {{name}}"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bugfix.md"), []byte("## Repro"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0o644))
+
+	templates, err := LoadPlanTemplates()
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+	assert.Equal(t, PlanTemplate{Name: "bugfix", Content: "## Repro"}, templates[0])
+	assert.Equal(t, PlanTemplate{Name: "feature", Content: "# {{name}}"}, templates[1])
+}
+
+func TestLoadPlanTemplates_MissingDir(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	templates, err := LoadPlanTemplates()
+	require.NoError(t, err)
+	assert.Empty(t, templates)
+}
@@ -40,6 +40,7 @@ type TOMLUIConfig struct {
 	AutoAdvanceWaves   *bool `toml:"auto_advance_waves"`
 	AutoReviewFix      *bool `toml:"auto_review_fix"`
 	MaxReviewFixCycles *int  `toml:"max_review_fix_cycles"`
+	VimMode            bool  `toml:"vim_mode,omitempty"`
 }
 
 // TOMLTelemetryConfig holds telemetry settings from the [telemetry] TOML table.
@@ -47,6 +48,18 @@ type TOMLTelemetryConfig struct {
 	Enabled *bool `toml:"enabled,omitempty"`
 }
 
+// TOMLPlannerContextConfig holds the [planner_context] TOML table.
+type TOMLPlannerContextConfig struct {
+	Globs        []string `toml:"globs,omitempty"`
+	MaxFileBytes int      `toml:"max_file_bytes,omitempty"`
+}
+
+// TOMLContainerConfig holds container settings from the [container] TOML table.
+type TOMLContainerConfig struct {
+	Image        string `toml:"image,omitempty"`
+	Devcontainer bool   `toml:"devcontainer,omitempty"`
+}
+
 // TOMLHook is the TOML/JSON representation of a single FSM transition hook.
 // Maps directly to [[hooks]] entries in config.toml.
 // JSON tags are retained for marshaling (e.g. kas debug config output).
@@ -63,74 +76,167 @@ type TOMLOrchestrationConfig struct {
 	// BlueprintSkipThreshold is the maximum task count for single-agent mode.
 	// When <= this value, elaboration and wave orchestration are skipped.
 	BlueprintSkipThreshold *int `toml:"blueprint_skip_threshold,omitempty"`
+	// MaxWaveConcurrency caps how many tasks of a wave run at once (0 = unlimited).
+	MaxWaveConcurrency *int `toml:"max_wave_concurrency,omitempty"`
+	// WaveTaskTimeoutMinutes fails wave tasks that run longer than this (0 = disabled).
+	WaveTaskTimeoutMinutes *int `toml:"wave_task_timeout_minutes,omitempty"`
 }
 
 // TOMLConfig is the top-level TOML file structure.
 type TOMLConfig struct {
-	Phases               map[string]string       `toml:"phases"`
-	Agents               map[string]TOMLAgent    `toml:"agents"`
-	UI                   TOMLUIConfig            `toml:"ui"`
-	Telemetry            TOMLTelemetryConfig     `toml:"telemetry"`
-	Orchestration        TOMLOrchestrationConfig `toml:"orchestration"`
-	DatabaseURL          string                  `toml:"database_url,omitempty"`
-	DefaultProgram       string                  `toml:"default_program,omitempty"`
-	AutoYes              bool                    `toml:"auto_yes,omitempty"`
-	DaemonPollInterval   int                     `toml:"daemon_poll_interval,omitempty"`
-	BranchPrefix         string                  `toml:"branch_prefix,omitempty"`
-	NotificationsEnabled *bool                   `toml:"notifications_enabled,omitempty"`
-	Hooks                []TOMLHook              `toml:"hooks"`
+	Phases                  map[string]string        `toml:"phases"`
+	Agents                  map[string]TOMLAgent     `toml:"agents"`
+	UI                      TOMLUIConfig             `toml:"ui"`
+	Telemetry               TOMLTelemetryConfig      `toml:"telemetry"`
+	Orchestration           TOMLOrchestrationConfig  `toml:"orchestration"`
+	Container               TOMLContainerConfig      `toml:"container"`
+	PlannerContext          TOMLPlannerContextConfig `toml:"planner_context"`
+	DatabaseURL             string                   `toml:"database_url,omitempty"`
+	DefaultProgram          string                   `toml:"default_program,omitempty"`
+	ReviewerProgram         string                   `toml:"reviewer_program,omitempty"`
+	AutoYes                 bool                     `toml:"auto_yes,omitempty"`
+	AutoYesPatterns         []string                 `toml:"auto_yes_patterns,omitempty"`
+	MetadataTickMs          int                      `toml:"metadata_tick_ms,omitempty"`
+	MetadataWorkers         int                      `toml:"metadata_workers,omitempty"`
+	DaemonPollInterval      int                      `toml:"daemon_poll_interval,omitempty"`
+	DaemonAddr              string                   `toml:"daemon_addr,omitempty"`
+	BranchPrefix            string                   `toml:"branch_prefix,omitempty"`
+	BranchTemplate          string                   `toml:"branch_template,omitempty"`
+	RecordSessions          bool                     `toml:"record_sessions,omitempty"`
+	TmuxPrefix              string                   `toml:"tmux_prefix,omitempty"`
+	PlansDir                string                   `toml:"plans_dir,omitempty"`
+	ClickUpWatchTag         string                   `toml:"clickup_watch_tag,omitempty"`
+	ClickUpWatchIntervalSec int                      `toml:"clickup_watch_interval_sec,omitempty"`
+	ClickUpStatusMap        map[string]string        `toml:"clickup_status_map,omitempty"`
+	MaxInstances            int                      `toml:"max_instances,omitempty"`
+	LogFormat               string                   `toml:"log_format,omitempty"`
+	DefaultDraftPR          bool                     `toml:"default_draft_pr,omitempty"`
+	AutoPush                bool                     `toml:"auto_push,omitempty"`
+	PermissionCacheTTLDays  int                      `toml:"permission_cache_ttl_days,omitempty"`
+	CPUAlertPercent         float64                  `toml:"cpu_alert_percent,omitempty"`
+	MemAlertMB              float64                  `toml:"mem_alert_mb,omitempty"`
+	IdleAutoPauseMinutes    int                      `toml:"idle_auto_pause_minutes,omitempty"`
+	Theme                   string                   `toml:"theme,omitempty"`
+	NotificationsEnabled    *bool                    `toml:"notifications_enabled,omitempty"`
+	Notifiers               []string                 `toml:"notifiers,omitempty"`
+	SlackWebhookURL         string                   `toml:"slack_webhook_url,omitempty"`
+	Hooks                   []TOMLHook               `toml:"hooks"`
 }
 
 // TOMLConfigResult holds the parsed config in terms of internal types.
 type TOMLConfigResult struct {
-	Profiles               map[string]AgentProfile
-	PhaseRoles             map[string]string
-	AnimateBanner          bool
-	AutoAdvanceWaves       *bool
-	AutoReviewFix          *bool
-	MaxReviewFixCycles     *int
-	TelemetryEnabled       *bool
-	DatabaseURL            string
-	BlueprintSkipThreshold *int
-	DefaultProgram         string
-	AutoYes                bool
-	DaemonPollInterval     int
-	BranchPrefix           string
-	NotificationsEnabled   *bool
-	Hooks                  []TOMLHook
+	Profiles                map[string]AgentProfile
+	PhaseRoles              map[string]string
+	AnimateBanner           bool
+	VimMode                 bool
+	AutoAdvanceWaves        *bool
+	AutoReviewFix           *bool
+	MaxReviewFixCycles      *int
+	TelemetryEnabled        *bool
+	DatabaseURL             string
+	BlueprintSkipThreshold  *int
+	MaxWaveConcurrency      *int
+	WaveTaskTimeoutMinutes  *int
+	DefaultProgram          string
+	ReviewerProgram         string
+	AutoYes                 bool
+	AutoYesPatterns         []string
+	MetadataTickMs          int
+	MetadataWorkers         int
+	DaemonPollInterval      int
+	DaemonAddr              string
+	BranchPrefix            string
+	BranchTemplate          string
+	RecordSessions          bool
+	Container               ContainerConfig
+	PlannerContext          PlannerContextConfig
+	TmuxPrefix              string
+	PlansDir                string
+	ClickUpWatchTag         string
+	ClickUpWatchIntervalSec int
+	ClickUpStatusMap        map[string]string
+	MaxInstances            int
+	LogFormat               string
+	DefaultDraftPR          bool
+	AutoPush                bool
+	PermissionCacheTTLDays  int
+	CPUAlertPercent         float64
+	MemAlertMB              float64
+	IdleAutoPauseMinutes    int
+	Theme                   string
+	NotificationsEnabled    *bool
+	Notifiers               []string
+	SlackWebhookURL         string
+	Hooks                   []TOMLHook
 }
 
 // LoadTOMLConfigFrom reads and parses a TOML config file,
 // returning the result mapped to internal types.
 func LoadTOMLConfigFrom(path string) (*TOMLConfigResult, error) {
+	result, _, err := decodeTOMLConfig(path)
+	return result, err
+}
+
+// decodeTOMLConfig is LoadTOMLConfigFrom plus the decode metadata, which
+// callers use to tell keys set explicitly from zero values.
+func decodeTOMLConfig(path string) (*TOMLConfigResult, toml.MetaData, error) {
 	var tc TOMLConfig
-	if _, err := toml.DecodeFile(path, &tc); err != nil {
-		return nil, fmt.Errorf("decode TOML config: %w", err)
+	md, err := toml.DecodeFile(path, &tc)
+	if err != nil {
+		return nil, md, fmt.Errorf("decode TOML config: %w", err)
 	}
 
 	result := &TOMLConfigResult{
-		Profiles:               make(map[string]AgentProfile),
-		PhaseRoles:             tc.Phases,
-		AnimateBanner:          tc.UI.AnimateBanner,
-		AutoAdvanceWaves:       tc.UI.AutoAdvanceWaves,
-		AutoReviewFix:          tc.UI.AutoReviewFix,
-		MaxReviewFixCycles:     tc.UI.MaxReviewFixCycles,
-		TelemetryEnabled:       tc.Telemetry.Enabled,
-		DatabaseURL:            tc.DatabaseURL,
-		BlueprintSkipThreshold: tc.Orchestration.BlueprintSkipThreshold,
-		DefaultProgram:         tc.DefaultProgram,
-		AutoYes:                tc.AutoYes,
-		DaemonPollInterval:     tc.DaemonPollInterval,
-		BranchPrefix:           tc.BranchPrefix,
-		NotificationsEnabled:   tc.NotificationsEnabled,
-		Hooks:                  tc.Hooks,
+		Profiles:                make(map[string]AgentProfile),
+		PhaseRoles:              tc.Phases,
+		AnimateBanner:           tc.UI.AnimateBanner,
+		VimMode:                 tc.UI.VimMode,
+		AutoAdvanceWaves:        tc.UI.AutoAdvanceWaves,
+		AutoReviewFix:           tc.UI.AutoReviewFix,
+		MaxReviewFixCycles:      tc.UI.MaxReviewFixCycles,
+		TelemetryEnabled:        tc.Telemetry.Enabled,
+		DatabaseURL:             tc.DatabaseURL,
+		BlueprintSkipThreshold:  tc.Orchestration.BlueprintSkipThreshold,
+		MaxWaveConcurrency:      tc.Orchestration.MaxWaveConcurrency,
+		WaveTaskTimeoutMinutes:  tc.Orchestration.WaveTaskTimeoutMinutes,
+		DefaultProgram:          tc.DefaultProgram,
+		ReviewerProgram:         tc.ReviewerProgram,
+		AutoYes:                 tc.AutoYes,
+		AutoYesPatterns:         tc.AutoYesPatterns,
+		MetadataTickMs:          tc.MetadataTickMs,
+		MetadataWorkers:         tc.MetadataWorkers,
+		DaemonPollInterval:      tc.DaemonPollInterval,
+		DaemonAddr:              tc.DaemonAddr,
+		BranchPrefix:            tc.BranchPrefix,
+		BranchTemplate:          tc.BranchTemplate,
+		RecordSessions:          tc.RecordSessions,
+		Container:               ContainerConfig{Image: tc.Container.Image, Devcontainer: tc.Container.Devcontainer},
+		PlannerContext:          PlannerContextConfig{Globs: tc.PlannerContext.Globs, MaxFileBytes: tc.PlannerContext.MaxFileBytes},
+		TmuxPrefix:              tc.TmuxPrefix,
+		PlansDir:                tc.PlansDir,
+		ClickUpWatchTag:         tc.ClickUpWatchTag,
+		ClickUpWatchIntervalSec: tc.ClickUpWatchIntervalSec,
+		ClickUpStatusMap:        tc.ClickUpStatusMap,
+		MaxInstances:            tc.MaxInstances,
+		LogFormat:               tc.LogFormat,
+		DefaultDraftPR:          tc.DefaultDraftPR,
+		AutoPush:                tc.AutoPush,
+		PermissionCacheTTLDays:  tc.PermissionCacheTTLDays,
+		CPUAlertPercent:         tc.CPUAlertPercent,
+		MemAlertMB:              tc.MemAlertMB,
+		IdleAutoPauseMinutes:    tc.IdleAutoPauseMinutes,
+		Theme:                   tc.Theme,
+		NotificationsEnabled:    tc.NotificationsEnabled,
+		Notifiers:               tc.Notifiers,
+		SlackWebhookURL:         tc.SlackWebhookURL,
+		Hooks:                   tc.Hooks,
 	}
 
 	for name, agent := range tc.Agents {
 		result.Profiles[name] = agent.toProfile()
 	}
 
-	return result, nil
+	return result, md, nil
 }
 
 // LoadTOMLConfig loads the TOML config from the project-local config directory
@@ -3,7 +3,9 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -219,6 +221,7 @@ func TestAutoAdvanceWaves(t *testing.T) {
 [ui]
 animate_banner = true
 auto_advance_waves = true
+vim_mode = true
 `
 		err := os.WriteFile(tomlPath, []byte(content), 0o644)
 		require.NoError(t, err)
@@ -226,6 +229,7 @@ auto_advance_waves = true
 		require.NoError(t, err)
 		require.NotNil(t, tc.AutoAdvanceWaves)
 		assert.True(t, *tc.AutoAdvanceWaves)
+		assert.True(t, tc.VimMode)
 	})
 }
 
@@ -328,24 +332,89 @@ func TestLoadTOMLConfigFrom_RuntimeFields(t *testing.T) {
 	path := filepath.Join(dir, "config.toml")
 	content := `
 default_program = "/usr/bin/claude"
+reviewer_program = "claude --model opus"
 auto_yes = true
+auto_yes_patterns = ["/tmp/*", "read file"]
+metadata_tick_ms = 500
+metadata_workers = 6
 daemon_poll_interval = 2000
+daemon_addr = "127.0.0.1:7434"
 branch_prefix = "dev/"
+branch_template = "feature/{user}/{slug}"
 notifications_enabled = false
+notifiers = ["desktop", "slack"]
+slack_webhook_url = "https://hooks.slack.com/services/T/B/X"
+record_sessions = true
+tmux_prefix = "work_"
+plans_dir = ".project/plans"
+clickup_watch_tag = "kasmos"
+clickup_watch_interval_sec = 120
+max_instances = 48
+log_format = "json"
+default_draft_pr = true
+auto_push = true
+permission_cache_ttl_days = 30
+cpu_alert_percent = 150
+mem_alert_mb = 4096
+idle_auto_pause_minutes = 30
+theme = "light"
 
 [phases]
 plan = "planner"
+
+[clickup_status_map]
+reviewing = "in review"
+done = "complete"
+
+[container]
+image = "ghcr.io/acme/dev:latest"
+
+[planner_context]
+globs = ["README.md", "docs/**/*.md"]
+max_file_bytes = 2048
 `
 	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
 
 	result, err := LoadTOMLConfigFrom(path)
 	require.NoError(t, err)
 	assert.Equal(t, "/usr/bin/claude", result.DefaultProgram)
+	assert.Equal(t, "claude --model opus", configFromTOML(result).ReviewerProgram)
 	assert.True(t, result.AutoYes)
+	assert.Equal(t, []string{"/tmp/*", "read file"}, configFromTOML(result).AutoYesPatterns)
+	assert.Equal(t, 500, configFromTOML(result).MetadataTickMs)
+	assert.Equal(t, 6, configFromTOML(result).MetadataWorkerCount())
+	assert.Equal(t, runtime.NumCPU(), configFromTOML(&TOMLConfigResult{}).MetadataWorkerCount(), "unset uses one worker per CPU")
 	assert.Equal(t, 2000, result.DaemonPollInterval)
+	assert.Equal(t, "127.0.0.1:7434", configFromTOML(result).DaemonAddr)
 	assert.Equal(t, "dev/", result.BranchPrefix)
+	assert.Equal(t, "feature/{user}/{slug}", configFromTOML(result).BranchTemplate)
+	assert.True(t, result.RecordSessions)
+	assert.Equal(t, ContainerConfig{Image: "ghcr.io/acme/dev:latest"}, configFromTOML(result).Container)
+	assert.Equal(t, PlannerContextConfig{Globs: []string{"README.md", "docs/**/*.md"}, MaxFileBytes: 2048}, configFromTOML(result).PlannerContext)
+	assert.Equal(t, DefaultPlannerContextMaxFileBytes, PlannerContextConfig{}.FileByteLimit())
+	assert.Equal(t, "work_", result.TmuxPrefix)
+	assert.Equal(t, "work_", configFromTOML(result).TmuxPrefix)
+	assert.Equal(t, DefaultTmuxPrefix, configFromTOML(&TOMLConfigResult{}).TmuxPrefix, "unset prefix falls back to the default")
+	assert.Equal(t, ".project/plans", configFromTOML(result).PlansDir)
+	assert.Equal(t, "kasmos", configFromTOML(result).ClickUpWatchTag)
+	assert.Equal(t, 2*time.Minute, configFromTOML(result).ClickUpWatchInterval())
+	assert.Equal(t, "in review", configFromTOML(result).ClickUpStatusFor("reviewing"))
+	assert.Equal(t, 48, configFromTOML(result).InstanceLimit())
+	assert.Equal(t, "json", configFromTOML(result).LogFormat)
+	assert.Equal(t, "complete", configFromTOML(result).ClickUpStatusFor("done"))
+	assert.Equal(t, DefaultPlansDir, configFromTOML(&TOMLConfigResult{}).PlansDir, "unset plans dir falls back to the default")
+	assert.True(t, result.DefaultDraftPR)
+	assert.True(t, configFromTOML(result).DefaultDraftPR)
+	assert.True(t, configFromTOML(result).AutoPush)
+	assert.Equal(t, 30, configFromTOML(result).PermissionCacheTTLDays)
+	assert.Equal(t, 150.0, configFromTOML(result).CPUAlertPercent)
+	assert.Equal(t, 4096.0, configFromTOML(result).MemAlertMB)
+	assert.Equal(t, 30, configFromTOML(result).IdleAutoPauseMinutes)
+	assert.Equal(t, "light", configFromTOML(result).Theme)
 	require.NotNil(t, result.NotificationsEnabled)
 	assert.False(t, *result.NotificationsEnabled)
+	assert.Equal(t, []string{"desktop", "slack"}, configFromTOML(result).Notifiers)
+	assert.Equal(t, "https://hooks.slack.com/services/T/B/X", configFromTOML(result).SlackWebhookURL)
 	assert.Equal(t, "planner", result.PhaseRoles["plan"])
 }
 
@@ -388,6 +457,20 @@ func TestResolveProfileWithDisabledAgent(t *testing.T) {
 		assert.Equal(t, "claude --model opus", profile.BuildCommand())
 	})
 
+	t.Run("custom role resolves by name", func(t *testing.T) {
+		cfg := &Config{
+			PhaseRoles: map[string]string{"implementing": "coder"},
+			Profiles: map[string]AgentProfile{
+				"coder":          {Program: "opencode", Enabled: true},
+				"security_audit": {Program: "claude", Model: "opus", Enabled: true},
+			},
+		}
+		profile := cfg.ResolveProfile("security_audit", "opencode")
+		assert.Equal(t, "claude", profile.Program)
+		assert.Equal(t, "opus", profile.Model)
+		assert.Equal(t, []string{"security_audit"}, cfg.CustomRoles())
+	})
+
 	t.Run("spec_review falls back when no reviewer configured", func(t *testing.T) {
 		cfg := &Config{
 			PhaseRoles: map[string]string{"implementing": "coder"},
@@ -0,0 +1,74 @@
+package daemon
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/kastheco/kasmos/log"
+	"github.com/kastheco/kasmos/session"
+)
+
+// repoInstances is one repository's share of the sessions the legacy daemon
+// auto-accepts prompts for.
+type repoInstances struct {
+	Repo      string
+	Instances []*session.Instance
+}
+
+// instanceRepo returns the repository root an instance belongs to: its
+// worktree's repo when it has one, otherwise its workspace directory.
+func instanceRepo(inst *session.Instance) string {
+	if repo := inst.GetRepoPath(); repo != "" {
+		return repo
+	}
+	return filepath.Clean(inst.Path)
+}
+
+// groupInstancesByRepo splits instances by repository, sorted by repo path,
+// keeping each repo's instances in their original order.
+func groupInstancesByRepo(instances []*session.Instance) []repoInstances {
+	byRepo := make(map[string][]*session.Instance)
+	for _, inst := range instances {
+		repo := instanceRepo(inst)
+		byRepo[repo] = append(byRepo[repo], inst)
+	}
+	groups := make([]repoInstances, 0, len(byRepo))
+	for repo, insts := range byRepo {
+		groups = append(groups, repoInstances{Repo: repo, Instances: insts})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Repo < groups[j].Repo })
+	return groups
+}
+
+// pollAutoYes runs one auto-accept pass over every repo's instances. Each
+// instance is polled through its own tmux session and worktree paths, so
+// it does not matter which repo the daemon was started from. Returns a
+// status report per instance and how many prompts were accepted per repo.
+func pollAutoYes(groups []repoInstances) ([]InstanceReport, map[string]int) {
+	var reports []InstanceReport
+	accepted := make(map[string]int)
+	for _, g := range groups {
+		for _, inst := range g.Instances {
+			md := inst.CollectMetadata()
+			if md.HasPrompt && inst.ShouldAutoYes(md.PermissionPrompt) {
+				inst.TapEnter()
+				accepted[g.Repo]++
+			}
+			reports = append(reports, newInstanceReport(inst, md))
+		}
+	}
+	return reports, accepted
+}
+
+// logRepoSummaries logs how many sessions the daemon watches in each repo.
+func logRepoSummaries(groups []repoInstances) {
+	for _, g := range groups {
+		paused := 0
+		for _, inst := range g.Instances {
+			if inst.Paused() {
+				paused++
+			}
+		}
+		log.InfoLog.Printf("daemon: %s: %d session(s), %d paused", g.Repo, len(g.Instances), paused)
+	}
+}
@@ -0,0 +1,79 @@
+package daemon
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kastheco/kasmos/cmd/cmd_test"
+	"github.com/kastheco/kasmos/session"
+	"github.com/kastheco/kasmos/session/tmux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubPtyFactory struct{}
+
+func (stubPtyFactory) Start(*exec.Cmd) (*os.File, error) { return nil, nil }
+func (stubPtyFactory) Close()                            {}
+
+// promptingInstance builds an auto-yes claude instance in repo whose pane
+// shows a permission prompt, recording the tmux sessions sent Enter.
+func promptingInstance(t *testing.T, title, repo string, mu *sync.Mutex, entered map[string]int) *session.Instance {
+	t.Helper()
+	cmdExec := cmd_test.MockCmdExec{
+		RunFunc: func(c *exec.Cmd) error {
+			args := strings.Join(c.Args, " ")
+			if strings.Contains(args, "send-keys") && strings.HasSuffix(args, " Enter") {
+				mu.Lock()
+				entered[title]++
+				mu.Unlock()
+			}
+			return nil
+		},
+		OutputFunc: func(*exec.Cmd) ([]byte, error) {
+			return []byte("Do you want to proceed?\n❯ 1. Yes\n  3. No, and tell Claude what to do differently"), nil
+		},
+	}
+	inst, err := session.NewInstance(session.InstanceOptions{Title: title, Path: repo, Program: "claude", AutoYes: true})
+	require.NoError(t, err)
+	inst.MarkStartedForTest()
+	inst.SetTmuxSession(tmux.NewTmuxSessionWithDeps(title, "claude", false, stubPtyFactory{}, cmdExec))
+	return inst
+}
+
+func TestPollAutoYes_HandlesInstancesAcrossRepos(t *testing.T) {
+	repoA, repoB := t.TempDir(), t.TempDir()
+	var mu sync.Mutex
+	entered := make(map[string]int)
+	instances := []*session.Instance{
+		promptingInstance(t, "a-one", repoA, &mu, entered),
+		promptingInstance(t, "b-one", repoB, &mu, entered),
+		promptingInstance(t, "a-two", repoA, &mu, entered),
+	}
+	// The daemon's own CWD is unrelated to any instance's repo.
+	t.Chdir(t.TempDir())
+
+	groups := groupInstancesByRepo(instances)
+	require.Len(t, groups, 2)
+	for _, g := range groups {
+		switch g.Repo {
+		case repoA:
+			assert.Len(t, g.Instances, 2)
+			assert.Equal(t, "a-one", g.Instances[0].Title, "repo order is preserved")
+		case repoB:
+			assert.Len(t, g.Instances, 1)
+		default:
+			t.Fatalf("unexpected repo %q", g.Repo)
+		}
+	}
+
+	reports, accepted := pollAutoYes(groups)
+	assert.Len(t, reports, 3)
+	assert.Equal(t, map[string]int{repoA: 2, repoB: 1}, accepted)
+	for _, title := range []string{"a-one", "a-two", "b-one"} {
+		assert.Equal(t, 1, entered[title], "%s should be sent Enter", title)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/kastheco/kasmos/cmd"
 	"github.com/kastheco/kasmos/config"
 	"github.com/kastheco/kasmos/config/taskfsm"
 	"github.com/kastheco/kasmos/config/taskstate"
@@ -25,6 +26,7 @@ import (
 	"github.com/kastheco/kasmos/orchestration/loop"
 	"github.com/kastheco/kasmos/session"
 	gitpkg "github.com/kastheco/kasmos/session/git"
+	tmuxpkg "github.com/kastheco/kasmos/session/tmux"
 )
 
 // ---------------------------------------------------------------------------
@@ -697,7 +699,9 @@ func (d *Daemon) monitorRunningInstances(ctx context.Context, e RepoEntry) {
 				inst.PromptDetected = false
 			} else if md.HasPrompt {
 				inst.PromptDetected = true
-				inst.TapEnter()
+				if inst.ShouldAutoYes(md.PermissionPrompt) {
+					inst.TapEnter()
+				}
 			} else {
 				inst.SetStatus(session.Ready)
 			}
@@ -1179,13 +1183,35 @@ func RunDaemon(cfg *config.Config) error {
 		return fmt.Errorf("daemon: load instances failed: %w", err)
 	}
 
-	// Daemon always operates in auto-accept mode.
+	// Daemon always operates in auto-accept mode, for every stored session
+	// whichever repo launched it.
 	for _, inst := range instances {
 		inst.AutoYes = true
+		inst.AutoYesPatterns = cfg.AutoYesPatterns
 	}
+	groups := groupInstancesByRepo(instances)
+	logRepoSummaries(groups)
 
 	pollInterval := time.Duration(cfg.DaemonPollInterval) * time.Millisecond
 
+	board := &statusBoard{}
+	var statusSrv *http.Server
+	var planStores []projectStore
+	if cfg.DaemonAddr != "" {
+		planStores = openPlanStores(cfg.DatabaseURL, groups)
+		ln, err := net.Listen("tcp", cfg.DaemonAddr)
+		if err != nil {
+			return fmt.Errorf("daemon: listen %s: %w", cfg.DaemonAddr, err)
+		}
+		statusSrv = &http.Server{Handler: newStatusHandler(board), ReadHeaderTimeout: 5 * time.Second}
+		go func() {
+			if err := statusSrv.Serve(ln); err != nil && err != http.ErrServerClosed {
+				log.ErrorLog.Printf("daemon: status server: %v", err)
+			}
+		}()
+		log.InfoLog.Printf("daemon status server listening on %s", ln.Addr())
+	}
+
 	stopCh := make(chan struct{})
 	var wg sync.WaitGroup
 	wg.Add(1)
@@ -1194,13 +1220,17 @@ func RunDaemon(cfg *config.Config) error {
 		defer wg.Done()
 		t := time.NewTimer(pollInterval)
 		for {
-			for _, inst := range instances {
-				if inst.Started() && !inst.Paused() {
-					if _, hasPrompt := inst.HasUpdated(); hasPrompt {
-						inst.TapEnter()
-					}
+			reports, accepted := pollAutoYes(groups)
+			for _, g := range groups {
+				if n := accepted[g.Repo]; n > 0 {
+					log.InfoLog.Printf("daemon: %s: auto-accepted %d prompt(s)", g.Repo, n)
 				}
 			}
+			// Only the status server reads the tmux and plan counts; skip
+			// gathering them when it is off.
+			if statusSrv != nil {
+				board.set(reports, tmuxpkg.CountKasSessions(cmd.MakeExecutor()), countPlans(planStores))
+			}
 
 			// Check for stop before blocking on the timer.
 			select {
@@ -1224,6 +1254,15 @@ func RunDaemon(cfg *config.Config) error {
 	close(stopCh)
 	wg.Wait()
 
+	if statusSrv != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		_ = statusSrv.Shutdown(ctx)
+		cancel()
+	}
+	for _, ps := range planStores {
+		_ = ps.store.Close()
+	}
+
 	if saveErr := storage.SaveInstances(instances); saveErr != nil {
 		log.ErrorLog.Printf("daemon: failed to save instances on shutdown: %v", saveErr)
 	}
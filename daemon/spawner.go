@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/kastheco/kasmos/cmd"
+	"github.com/kastheco/kasmos/config"
 	"github.com/kastheco/kasmos/config/taskparser"
 	"github.com/kastheco/kasmos/config/taskstate"
 	"github.com/kastheco/kasmos/internal/initcmd/harness"
@@ -67,6 +68,8 @@ type TmuxSpawner struct {
 	discoverOrphans    func([]string) ([]tmuxpkg.SessionInfo, error)
 	restoreInstance    func(session.InstanceData) (*session.Instance, error)
 	cleanupGracePeriod time.Duration
+	// containerFor returns the container agents spawned in a repo run in.
+	containerFor func(repoPath string) config.ContainerConfig
 }
 
 // NewTmuxSpawner returns a TmuxSpawner. An optional TmuxSpawnerConfig may be
@@ -111,6 +114,9 @@ func newTmuxSpawnerWithConfig(logger *slog.Logger, drainTimeout time.Duration) *
 		},
 		restoreInstance:    session.FromInstanceData,
 		cleanupGracePeriod: 30 * time.Second,
+		containerFor: func(repoPath string) config.ContainerConfig {
+			return config.LoadConfigForRepo(repoPath).Container
+		},
 	}
 }
 
@@ -382,6 +388,7 @@ func (s *TmuxSpawner) spawnOnMainBranch(_ context.Context, opts loop.SpawnOpts,
 		Program:   program,
 		AgentType: agentType,
 		TaskFile:  opts.PlanFile,
+		Container: s.containerFor(opts.RepoPath),
 	})
 	if err != nil {
 		return fmt.Errorf("TmuxSpawner.%s: create instance: %w", agentType, err)
@@ -512,6 +519,7 @@ func (s *TmuxSpawner) SpawnWaveTask(_ context.Context, opts loop.SpawnOpts, task
 		TaskNumber: task.Number,
 		WaveNumber: opts.Wave,
 		PeerCount:  peerCount,
+		Container:  s.containerFor(opts.RepoPath),
 	})
 	if err != nil {
 		return fmt.Errorf("TmuxSpawner.wave-task: create instance: %w", err)
@@ -563,6 +571,7 @@ func (s *TmuxSpawner) spawnInSharedWorktree(_ context.Context, opts loop.SpawnOp
 		Program:   program,
 		AgentType: agentType,
 		TaskFile:  opts.PlanFile,
+		Container: s.containerFor(opts.RepoPath),
 	})
 	if err != nil {
 		return fmt.Errorf("TmuxSpawner.%s: create instance: %w", agentType, err)
@@ -0,0 +1,225 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kastheco/kasmos/config/taskstore"
+	"github.com/kastheco/kasmos/log"
+	"github.com/kastheco/kasmos/session"
+)
+
+// InstanceReport is one instance in the auto-yes daemon's GET /status response.
+type InstanceReport struct {
+	Title      string  `json:"title"`
+	Status     string  `json:"status"`
+	Plan       string  `json:"plan,omitempty"`
+	CPUPercent float64 `json:"cpu_percent"`
+	MemMB      float64 `json:"mem_mb"`
+	TmuxAlive  bool    `json:"tmux_alive"`
+}
+
+// StatusReport is the GET /status response body.
+type StatusReport struct {
+	Instances []InstanceReport `json:"instances"`
+	// TmuxSessions is the number of kas tmux sessions on the host, including
+	// ones no tracked instance owns.
+	TmuxSessions int `json:"tmux_sessions"`
+	// Plans counts each repo's plans by status.
+	Plans []PlanStatusCount `json:"plans,omitempty"`
+}
+
+// PlanStatusCount is the number of plans in one project with one status.
+type PlanStatusCount struct {
+	Project string `json:"project"`
+	Status  string `json:"status"`
+	Count   int    `json:"count"`
+}
+
+// statusBoard holds the reports collected by the most recent poll so HTTP
+// handlers never run subprocesses themselves.
+type statusBoard struct {
+	mu           sync.RWMutex
+	reports      []InstanceReport
+	tmuxSessions int
+	plans        []PlanStatusCount
+}
+
+func (b *statusBoard) set(reports []InstanceReport, tmuxSessions int, plans []PlanStatusCount) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.reports = reports
+	b.tmuxSessions = tmuxSessions
+	b.plans = plans
+}
+
+func (b *statusBoard) snapshot() StatusReport {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	reports := make([]InstanceReport, len(b.reports))
+	copy(reports, b.reports)
+	plans := make([]PlanStatusCount, len(b.plans))
+	copy(plans, b.plans)
+	return StatusReport{Instances: reports, TmuxSessions: b.tmuxSessions, Plans: plans}
+}
+
+// projectStore is the task store holding one repo's plans.
+type projectStore struct {
+	project string
+	store   taskstore.Store
+}
+
+// openPlanStores opens the task store of each repo: the remote store when
+// databaseURL is set, otherwise the repo's .kasmos/taskstore.db. Repos
+// without a local store are skipped rather than given an empty one.
+func openPlanStores(databaseURL string, groups []repoInstances) []projectStore {
+	var stores []projectStore
+	for _, g := range groups {
+		project := filepath.Base(g.Repo)
+		if databaseURL != "" {
+			stores = append(stores, projectStore{project: project, store: taskstore.NewHTTPStore(databaseURL, project)})
+			continue
+		}
+		dbPath := filepath.Join(g.Repo, ".kasmos", "taskstore.db")
+		if _, err := os.Stat(dbPath); err != nil {
+			continue
+		}
+		store, err := taskstore.NewSQLiteStore(dbPath)
+		if err != nil {
+			log.WarningLog.Printf("daemon: open task store for %s: %v", g.Repo, err)
+			continue
+		}
+		stores = append(stores, projectStore{project: project, store: store})
+	}
+	return stores
+}
+
+// countPlans counts the plans in each store by status, sorted by project and
+// status. A store that cannot be listed is left out of this poll.
+func countPlans(stores []projectStore) []PlanStatusCount {
+	var out []PlanStatusCount
+	for _, ps := range stores {
+		entries, err := ps.store.List(ps.project)
+		if err != nil {
+			log.WarningLog.Printf("daemon: list plans for %s: %v", ps.project, err)
+			continue
+		}
+		counts := make(map[string]int)
+		for _, e := range entries {
+			counts[string(e.Status)]++
+		}
+		for status, n := range counts {
+			out = append(out, PlanStatusCount{Project: ps.project, Status: status, Count: n})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Project != out[j].Project {
+			return out[i].Project < out[j].Project
+		}
+		return out[i].Status < out[j].Status
+	})
+	return out
+}
+
+// newInstanceReport builds a report from inst and the metadata collected for
+// it this poll. Resource fields stay zero when sampling failed.
+func newInstanceReport(inst *session.Instance, md session.InstanceMetadata) InstanceReport {
+	r := InstanceReport{
+		Title:     inst.Title,
+		Status:    instanceStatusName(inst.Status),
+		Plan:      inst.TaskFile,
+		TmuxAlive: md.TmuxAlive,
+	}
+	if md.ResourceUsageValid {
+		r.CPUPercent, r.MemMB = md.CPUPercent, md.MemMB
+	}
+	return r
+}
+
+func instanceStatusName(s session.Status) string {
+	switch s {
+	case session.Running:
+		return "running"
+	case session.Ready:
+		return "ready"
+	case session.Loading:
+		return "loading"
+	case session.Paused:
+		return "paused"
+	default:
+		return "unknown"
+	}
+}
+
+// newStatusHandler serves GET /status and GET /metrics from board, and
+// GET /healthz.
+func newStatusHandler(board *statusBoard) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /status", func(w http.ResponseWriter, _ *http.Request) {
+		writeStatusJSON(w, board.snapshot())
+	})
+	mux.HandleFunc("GET /metrics", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		writeMetrics(w, board.snapshot())
+	})
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		writeStatusJSON(w, map[string]string{"status": "ok"})
+	})
+	return mux
+}
+
+func writeStatusJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// metricStatuses are the instance statuses always exported, so a status with
+// no instances reads 0 rather than disappearing from the series.
+var metricStatuses = []string{"running", "ready", "loading", "paused"}
+
+// writeMetrics renders report in the Prometheus text exposition format.
+func writeMetrics(w io.Writer, report StatusReport) {
+	byStatus := make(map[string]int, len(metricStatuses))
+	active := 0
+	for _, r := range report.Instances {
+		byStatus[r.Status]++
+		if r.Status != "paused" {
+			active++
+		}
+	}
+
+	writeGauge(w, "kasmos_instances_active", "Tracked agent instances that are not paused.")
+	fmt.Fprintf(w, "kasmos_instances_active %d\n", active)
+
+	writeGauge(w, "kasmos_instances", "Tracked agent instances by status.")
+	for _, status := range metricStatuses {
+		fmt.Fprintf(w, "kasmos_instances{status=%q} %d\n", status, byStatus[status])
+	}
+
+	writeGauge(w, "kasmos_tmux_sessions", "kas tmux sessions on the host.")
+	fmt.Fprintf(w, "kasmos_tmux_sessions %d\n", report.TmuxSessions)
+
+	writeGauge(w, "kasmos_plans", "Plans by project and status.")
+	for _, p := range report.Plans {
+		fmt.Fprintf(w, "kasmos_plans{project=\"%s\",status=\"%s\"} %d\n",
+			escapeLabel(p.Project), escapeLabel(p.Status), p.Count)
+	}
+}
+
+func writeGauge(w io.Writer, name, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+}
+
+// escapeLabel escapes a label value per the exposition format: backslash,
+// double quote and newline.
+func escapeLabel(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
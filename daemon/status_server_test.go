@@ -0,0 +1,153 @@
+package daemon
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kastheco/kasmos/config/taskstore"
+	"github.com/kastheco/kasmos/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusHandler_ReportsInstances(t *testing.T) {
+	coder, err := session.NewInstance(session.InstanceOptions{
+		Title: "auth-coder", Path: t.TempDir(), Program: "opencode", TaskFile: "auth.md",
+	})
+	require.NoError(t, err)
+	coder.SetStatus(session.Running)
+	adhoc, err := session.NewInstance(session.InstanceOptions{
+		Title: "scratch", Path: t.TempDir(), Program: "claude",
+	})
+	require.NoError(t, err)
+	adhoc.SetStatus(session.Paused)
+
+	board := &statusBoard{}
+	board.set([]InstanceReport{
+		newInstanceReport(coder, session.InstanceMetadata{
+			CPUPercent: 42.5, MemMB: 256, ResourceUsageValid: true, TmuxAlive: true,
+		}),
+		newInstanceReport(adhoc, session.InstanceMetadata{CPUPercent: 99}),
+	}, 3, nil)
+	srv := httptest.NewServer(newStatusHandler(board))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/status")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	var raw struct {
+		Instances    []map[string]any `json:"instances"`
+		TmuxSessions int              `json:"tmux_sessions"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&raw))
+	assert.Equal(t, 3, raw.TmuxSessions)
+	instances := raw.Instances
+	require.Len(t, instances, 2)
+	assert.Equal(t, map[string]any{
+		"title": "auth-coder", "status": "running", "plan": "auth.md",
+		"cpu_percent": 42.5, "mem_mb": 256.0, "tmux_alive": true,
+	}, instances[0])
+	assert.Equal(t, map[string]any{
+		"title": "scratch", "status": "paused",
+		"cpu_percent": 0.0, "mem_mb": 0.0, "tmux_alive": false,
+	}, instances[1], "plan is omitted and unsampled resources stay zero")
+}
+
+func TestStatusHandler_Healthz(t *testing.T) {
+	srv := httptest.NewServer(newStatusHandler(&statusBoard{}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/healthz")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body map[string]string
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "ok", body["status"])
+
+	resp, err = http.Get(srv.URL + "/status")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var report StatusReport
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	assert.Empty(t, report.Instances)
+}
+
+func TestStatusHandler_Metrics(t *testing.T) {
+	board := &statusBoard{}
+	board.set([]InstanceReport{
+		{Title: "auth-coder", Status: "running", Plan: "auth.md"},
+		{Title: "auth-reviewer", Status: "ready", Plan: "auth.md"},
+		{Title: "auth-task-2", Status: "running", Plan: "auth.md"},
+		{Title: "ui-coder", Status: "paused", Plan: `ui "v2".md`},
+		{Title: "scratch", Status: "running"},
+	}, 6, []PlanStatusCount{
+		{Project: "kasmos", Status: "implementing", Count: 2},
+		{Project: `my "repo"`, Status: "ready", Count: 1},
+	})
+	srv := httptest.NewServer(newStatusHandler(board))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/plain; version=0.0.4")
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `# HELP kasmos_instances_active Tracked agent instances that are not paused.
+# TYPE kasmos_instances_active gauge
+kasmos_instances_active 4
+# HELP kasmos_instances Tracked agent instances by status.
+# TYPE kasmos_instances gauge
+kasmos_instances{status="running"} 3
+kasmos_instances{status="ready"} 1
+kasmos_instances{status="loading"} 0
+kasmos_instances{status="paused"} 1
+# HELP kasmos_tmux_sessions kas tmux sessions on the host.
+# TYPE kasmos_tmux_sessions gauge
+kasmos_tmux_sessions 6
+# HELP kasmos_plans Plans by project and status.
+# TYPE kasmos_plans gauge
+kasmos_plans{project="kasmos",status="implementing"} 2
+kasmos_plans{project="my \"repo\"",status="ready"} 1
+`, string(body))
+}
+
+func TestCountPlans_CountsPlansByStatus(t *testing.T) {
+	repo := filepath.Join(t.TempDir(), "alpha")
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, ".kasmos"), 0o755))
+	store, err := taskstore.NewSQLiteStore(filepath.Join(repo, ".kasmos", "taskstore.db"))
+	require.NoError(t, err)
+	for file, status := range map[string]taskstore.Status{
+		"a.md": taskstore.StatusReady,
+		"b.md": taskstore.StatusImplementing,
+		"c.md": taskstore.StatusImplementing,
+	} {
+		require.NoError(t, store.Create("alpha", taskstore.TaskEntry{Filename: file, Status: status}))
+	}
+	require.NoError(t, store.Close())
+
+	stores := openPlanStores("", []repoInstances{{Repo: repo}, {Repo: filepath.Join(t.TempDir(), "no-store")}})
+	t.Cleanup(func() {
+		for _, ps := range stores {
+			_ = ps.store.Close()
+		}
+	})
+	require.Len(t, stores, 1, "repos without a task store are skipped")
+
+	assert.Equal(t, []PlanStatusCount{
+		{Project: "alpha", Status: "implementing", Count: 2},
+		{Project: "alpha", Status: "ready", Count: 1},
+	}, countPlans(stores))
+}
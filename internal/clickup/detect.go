@@ -178,7 +178,10 @@ func matchServers(servers map[string]json.RawMessage) (MCPServerConfig, bool) {
 		}
 
 		cfg := MCPServerConfig{Env: entry.Env}
-		if entry.Type == "http" || entry.URL != "" {
+		if entry.Type == "sse" && entry.URL != "" {
+			cfg.Type = "sse"
+			cfg.URL = entry.URL
+		} else if entry.Type == "http" || entry.URL != "" {
 			cfg.Type = "http"
 			cfg.URL = entry.URL
 		} else if entry.Command != "" {
@@ -21,6 +21,17 @@ func TestDetect_ProjectMCPJSON(t *testing.T) {
 	assert.Equal(t, "https://mcp.clickup.com/mcp", cfg.URL)
 }
 
+func TestDetect_SSEServer(t *testing.T) {
+	dir := t.TempDir()
+	mcpJSON := `{"mcpServers":{"clickup":{"type":"sse","url":"https://mcp.internal/clickup/sse"}}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".mcp.json"), []byte(mcpJSON), 0o644))
+
+	cfg, found := clickup.DetectMCP(dir, "")
+	assert.True(t, found)
+	assert.Equal(t, "sse", cfg.Type)
+	assert.Equal(t, "https://mcp.internal/clickup/sse", cfg.URL)
+}
+
 func TestDetect_StdioServer(t *testing.T) {
 	dir := t.TempDir()
 	mcpJSON := `{"mcpServers":{"clickup-tasks":{"command":"npx","args":["-y","@taazkareem/clickup-mcp-server@latest"],"env":{"CLICKUP_API_KEY":"test"}}}}`
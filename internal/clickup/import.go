@@ -208,6 +208,28 @@ func (im *Importer) FetchTask(taskID string) (*Task, error) {
 	return parseTask(raw), nil
 }
 
+// UpdateTaskStatus sets the status of a ClickUp task. status must be a status
+// name that exists on the task's list (e.g. "in review").
+func (im *Importer) UpdateTaskStatus(taskID, status string) error {
+	tool, found := im.client.FindTool("clickup_update_task")
+	if !found {
+		return fmt.Errorf("no update_task tool found in MCP server")
+	}
+
+	args := map[string]interface{}{
+		"task_id": stripCUPrefix(taskID),
+		"status":  status,
+	}
+	if im.workspaceID != "" {
+		args["workspace_id"] = im.workspaceID
+	}
+
+	if _, err := im.client.CallTool(tool.Name, args); err != nil {
+		return fmt.Errorf("update task %s status: %w", taskID, err)
+	}
+	return nil
+}
+
 func extractText(result *mcpclient.ToolResult) string {
 	for _, c := range result.Content {
 		if c.Type == "text" && c.Text != "" {
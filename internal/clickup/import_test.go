@@ -432,3 +432,19 @@ func TestFetchWorkspaceNames_LegacyFormat(t *testing.T) {
 	names := importer.FetchWorkspaceNames([]string{"789"})
 	assert.Equal(t, "Legacy Corp", names["789"])
 }
+
+func TestUpdateTaskStatus(t *testing.T) {
+	stub := &stubMCPClient{tools: []mcpclient.Tool{{Name: "clickup_update_task"}}}
+	importer := clickup.NewImporter(stub)
+	importer.SetWorkspaceID("ws1")
+
+	require.NoError(t, importer.UpdateTaskStatus("CU-abc123", "in review"))
+	assert.Equal(t, "abc123", stub.lastArgs["task_id"])
+	assert.Equal(t, "in review", stub.lastArgs["status"])
+	assert.Equal(t, "ws1", stub.lastArgs["workspace_id"])
+}
+
+func TestUpdateTaskStatus_NoTool(t *testing.T) {
+	importer := clickup.NewImporter(&stubMCPClient{})
+	assert.Error(t, importer.UpdateTaskStatus("abc123", "complete"))
+}
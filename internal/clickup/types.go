@@ -18,8 +18,8 @@ func (e *MultipleWorkspacesError) Error() string {
 
 // MCPServerConfig holds the detected ClickUp MCP server configuration.
 type MCPServerConfig struct {
-	Type    string            // "http" or "stdio"
-	URL     string            // for http type
+	Type    string            // "http", "sse", or "stdio"
+	URL     string            // for http and sse types
 	Command string            // for stdio type
 	Args    []string          // for stdio type
 	Env     map[string]string // for stdio type
@@ -0,0 +1,31 @@
+package github
+
+import (
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// remoteRe matches the owner/repo part of a github.com remote URL in any of
+// the common forms: git@github.com:o/r.git, https://github.com/o/r,
+// ssh://git@github.com/o/r.git.
+var remoteRe = regexp.MustCompile(`github\.com[:/]([^/\s]+)/([^/\s]+?)(?:\.git)?/?$`)
+
+// DetectGitHub reads the origin remote of the repository at repoPath and
+// returns its owner/repo when it is hosted on github.com.
+func DetectGitHub(repoPath string) (Repo, bool) {
+	out, err := exec.Command("git", "-C", repoPath, "remote", "get-url", "origin").Output()
+	if err != nil {
+		return Repo{}, false
+	}
+	return ParseRemoteURL(strings.TrimSpace(string(out)))
+}
+
+// ParseRemoteURL extracts owner/repo from a github.com remote URL.
+func ParseRemoteURL(url string) (Repo, bool) {
+	m := remoteRe.FindStringSubmatch(strings.TrimSpace(url))
+	if m == nil {
+		return Repo{}, false
+	}
+	return Repo{Owner: m[1], Name: m[2]}, true
+}
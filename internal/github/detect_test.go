@@ -0,0 +1,48 @@
+package github_test
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/kastheco/kasmos/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		url   string
+		want  github.Repo
+		found bool
+	}{
+		{"git@github.com:kastheco/kasmos.git", github.Repo{Owner: "kastheco", Name: "kasmos"}, true},
+		{"https://github.com/kastheco/kasmos", github.Repo{Owner: "kastheco", Name: "kasmos"}, true},
+		{"https://github.com/kastheco/kasmos.git", github.Repo{Owner: "kastheco", Name: "kasmos"}, true},
+		{"ssh://git@github.com/kastheco/kas.mos.git", github.Repo{Owner: "kastheco", Name: "kas.mos"}, true},
+		{"git@gitlab.com:kastheco/kasmos.git", github.Repo{}, false},
+		{"", github.Repo{}, false},
+	}
+	for _, tt := range tests {
+		got, found := github.ParseRemoteURL(tt.url)
+		assert.Equal(t, tt.found, found, "url: %q", tt.url)
+		assert.Equal(t, tt.want, got, "url: %q", tt.url)
+	}
+}
+
+func TestDetectGitHub_ReadsOrigin(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, exec.Command("git", "-C", dir, "init", "-q").Run())
+	require.NoError(t, exec.Command("git", "-C", dir, "remote", "add", "origin", "git@github.com:acme/widgets.git").Run())
+
+	repo, found := github.DetectGitHub(dir)
+	require.True(t, found)
+	assert.Equal(t, "acme/widgets", repo.FullName())
+}
+
+func TestDetectGitHub_NoOrigin(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, exec.Command("git", "-C", dir, "init", "-q").Run())
+
+	_, found := github.DetectGitHub(dir)
+	assert.False(t, found)
+}
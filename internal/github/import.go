@@ -0,0 +1,158 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the public GitHub REST API endpoint.
+const DefaultBaseURL = "https://api.github.com"
+
+// searchLimit caps how many issues a search returns.
+const searchLimit = 20
+
+// Importer searches and fetches GitHub issues via the REST API.
+type Importer struct {
+	repo    Repo
+	token   string
+	baseURL string
+	client  *http.Client
+}
+
+// NewImporter creates an Importer for repo. token may be empty, in which case
+// requests are unauthenticated (public repositories only, low rate limits).
+func NewImporter(repo Repo, token string) *Importer {
+	return &Importer{
+		repo:    repo,
+		token:   token,
+		baseURL: DefaultBaseURL,
+		client:  &http.Client{Timeout: 20 * time.Second},
+	}
+}
+
+// SetBaseURL overrides the API endpoint (GitHub Enterprise, tests).
+func (im *Importer) SetBaseURL(baseURL string) {
+	im.baseURL = strings.TrimRight(baseURL, "/")
+}
+
+// apiIssue is the subset of the GitHub issue payload the importer reads.
+type apiIssue struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	State   string `json:"state"`
+	HTMLURL string `json:"html_url"`
+	Labels  []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+// issueRefRe matches a bare issue reference: "123", "#123", or an issue URL.
+var issueRefRe = regexp.MustCompile(`^(?:#?(\d+)|https?://[^\s]+/issues/(\d+)/?)$`)
+
+// Search finds open and closed issues in the repository matching query.
+// A bare issue number ("42", "#42") or issue URL resolves directly to that
+// issue instead of running a text search.
+func (im *Importer) Search(query string) ([]SearchResult, error) {
+	query = strings.TrimSpace(query)
+	if m := issueRefRe.FindStringSubmatch(query); m != nil {
+		numStr := m[1]
+		if numStr == "" {
+			numStr = m[2]
+		}
+		number, _ := strconv.Atoi(numStr)
+		task, err := im.FetchTask(number)
+		if err != nil {
+			return nil, err
+		}
+		return []SearchResult{{Number: task.Number, Title: task.Title, State: task.State, URL: task.URL}}, nil
+	}
+
+	params := url.Values{}
+	params.Set("q", fmt.Sprintf("%s repo:%s is:issue", query, im.repo.FullName()))
+	params.Set("per_page", strconv.Itoa(searchLimit))
+
+	var resp struct {
+		Items []apiIssue `json:"items"`
+	}
+	if err := im.get("/search/issues?"+params.Encode(), &resp); err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+
+	results := make([]SearchResult, 0, len(resp.Items))
+	for _, it := range resp.Items {
+		results = append(results, SearchResult{
+			Number: it.Number,
+			Title:  it.Title,
+			State:  it.State,
+			URL:    it.HTMLURL,
+		})
+	}
+	return results, nil
+}
+
+// FetchTask gets full details for a GitHub issue by number.
+func (im *Importer) FetchTask(number int) (*Task, error) {
+	var issue apiIssue
+	path := fmt.Sprintf("/repos/%s/%s/issues/%d", url.PathEscape(im.repo.Owner), url.PathEscape(im.repo.Name), number)
+	if err := im.get(path, &issue); err != nil {
+		return nil, fmt.Errorf("fetch issue #%d: %w", number, err)
+	}
+
+	task := &Task{
+		Number:    issue.Number,
+		Title:     issue.Title,
+		Body:      issue.Body,
+		State:     issue.State,
+		URL:       issue.HTMLURL,
+		Checklist: ParseChecklist(issue.Body),
+	}
+	for _, l := range issue.Labels {
+		task.Labels = append(task.Labels, l.Name)
+	}
+	return task, nil
+}
+
+// get issues a GET against the API and decodes the JSON response into out.
+func (im *Importer) get(path string, out interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, im.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if im.token != "" {
+		req.Header.Set("Authorization", "Bearer "+im.token)
+	}
+
+	resp, err := im.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("github api %s: %s", resp.Status, apiErr.Message)
+		}
+		return fmt.Errorf("github api %s", resp.Status)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("parse response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package github_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kastheco/kasmos/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const issueJSON = `{
+	"number": 42,
+	"title": "Add dark mode",
+	"body": "We need dark mode.\n\n- [ ] add palette\n- [x] pick colors\n* [ ] wire toggle",
+	"state": "open",
+	"html_url": "https://github.com/acme/widgets/issues/42",
+	"labels": [{"name": "enhancement"}, {"name": "ui"}]
+}`
+
+func newTestImporter(t *testing.T, handler http.HandlerFunc) *github.Importer {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	im := github.NewImporter(github.Repo{Owner: "acme", Name: "widgets"}, "secret")
+	im.SetBaseURL(srv.URL)
+	return im
+}
+
+func TestImporter_Search(t *testing.T) {
+	var gotQuery, gotAuth string
+	im := newTestImporter(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/search/issues", r.URL.Path)
+		gotQuery = r.URL.Query().Get("q")
+		gotAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"items":[` + issueJSON + `]}`))
+	})
+
+	results, err := im.Search("dark mode")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, 42, results[0].Number)
+	assert.Equal(t, "Add dark mode", results[0].Title)
+	assert.Equal(t, "open", results[0].State)
+	assert.Equal(t, "https://github.com/acme/widgets/issues/42", results[0].URL)
+	assert.Equal(t, "dark mode repo:acme/widgets is:issue", gotQuery)
+	assert.Equal(t, "Bearer secret", gotAuth)
+}
+
+func TestImporter_SearchByIssueReference(t *testing.T) {
+	for _, query := range []string{"42", "#42", "https://github.com/acme/widgets/issues/42"} {
+		t.Run(query, func(t *testing.T) {
+			im := newTestImporter(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/repos/acme/widgets/issues/42", r.URL.Path)
+				_, _ = w.Write([]byte(issueJSON))
+			})
+
+			results, err := im.Search(query)
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+			assert.Equal(t, 42, results[0].Number)
+		})
+	}
+}
+
+func TestImporter_FetchTask(t *testing.T) {
+	im := newTestImporter(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(issueJSON))
+	})
+
+	task, err := im.FetchTask(42)
+	require.NoError(t, err)
+	assert.Equal(t, 42, task.Number)
+	assert.Equal(t, "Add dark mode", task.Title)
+	assert.Equal(t, []string{"enhancement", "ui"}, task.Labels)
+	assert.Equal(t, []github.ChecklistItem{
+		{Text: "add palette"},
+		{Text: "pick colors", Done: true},
+		{Text: "wire toggle"},
+	}, task.Checklist)
+}
+
+func TestImporter_APIError(t *testing.T) {
+	im := newTestImporter(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	})
+
+	_, err := im.FetchTask(7)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Not Found")
+}
@@ -0,0 +1,78 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ScaffoldPlan generates a plan markdown from a GitHub issue. The issue body is
+// embedded verbatim and its checklist items are listed as wave task candidates
+// for the planner.
+func ScaffoldPlan(task Task) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", task.Title)
+	fmt.Fprintf(&b, "**Goal:** %s\n\n", task.Title)
+
+	if task.Number != 0 {
+		fmt.Fprintf(&b, "**Source:** GitHub #%d", task.Number)
+		if task.URL != "" {
+			fmt.Fprintf(&b, " (%s)", task.URL)
+		}
+		b.WriteString("\n\n")
+	}
+
+	if task.State != "" {
+		fmt.Fprintf(&b, "**GitHub State:** %s\n\n", task.State)
+	}
+
+	if len(task.Labels) > 0 {
+		fmt.Fprintf(&b, "**Labels:** %s\n\n", strings.Join(task.Labels, ", "))
+	}
+
+	if body := strings.TrimSpace(task.Body); body != "" {
+		b.WriteString("## Reference: GitHub Issue\n\n")
+		b.WriteString(body)
+		b.WriteString("\n\n")
+	}
+
+	if len(task.Checklist) > 0 {
+		b.WriteString("## Reference: Task Candidates\n\n")
+		for _, item := range task.Checklist {
+			checkbox := "- [ ] "
+			if item.Done {
+				checkbox = "- [x] "
+			}
+			fmt.Fprintf(&b, "%s%s\n", checkbox, item.Text)
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// ScaffoldFilename generates a plan filename from an issue title.
+func ScaffoldFilename(name string) string {
+	slug := strings.ToLower(strings.TrimSpace(name))
+	slug = nonAlphanumeric.ReplaceAllString(slug, "-")
+	slug = strings.Trim(slug, "-")
+	return slug
+}
+
+// checklistRe matches markdown task-list items: "- [ ] text" or "* [x] text".
+var checklistRe = regexp.MustCompile(`(?m)^\s*[-*+]\s+\[([ xX])\]\s+(.+?)\s*$`)
+
+// ParseChecklist extracts task-list items from an issue body.
+func ParseChecklist(body string) []ChecklistItem {
+	var items []ChecklistItem
+	for _, m := range checklistRe.FindAllStringSubmatch(body, -1) {
+		items = append(items, ChecklistItem{
+			Text: m[2],
+			Done: m[1] != " ",
+		})
+	}
+	return items
+}
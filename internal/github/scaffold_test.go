@@ -0,0 +1,51 @@
+package github_test
+
+import (
+	"testing"
+
+	"github.com/kastheco/kasmos/internal/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScaffoldPlan_EmbedsBodyAndChecklist(t *testing.T) {
+	task := github.Task{
+		Number: 42,
+		Title:  "Add dark mode",
+		Body:   "We need dark mode.\n\n- [ ] add palette",
+		State:  "open",
+		URL:    "https://github.com/acme/widgets/issues/42",
+		Labels: []string{"enhancement", "ui"},
+		Checklist: []github.ChecklistItem{
+			{Text: "add palette"},
+			{Text: "pick colors", Done: true},
+		},
+	}
+
+	md := github.ScaffoldPlan(task)
+	assert.Contains(t, md, "# Add dark mode")
+	assert.Contains(t, md, "**Goal:** Add dark mode")
+	assert.Contains(t, md, "**Source:** GitHub #42 (https://github.com/acme/widgets/issues/42)")
+	assert.Contains(t, md, "**GitHub State:** open")
+	assert.Contains(t, md, "**Labels:** enhancement, ui")
+	assert.Contains(t, md, "## Reference: GitHub Issue\n\nWe need dark mode.")
+	assert.Contains(t, md, "## Reference: Task Candidates")
+	assert.Contains(t, md, "- [ ] add palette\n- [x] pick colors")
+}
+
+func TestScaffoldPlan_MinimalIssue(t *testing.T) {
+	md := github.ScaffoldPlan(github.Task{Title: "Bare"})
+	assert.Contains(t, md, "# Bare")
+	assert.NotContains(t, md, "## Reference")
+	assert.NotContains(t, md, "**Source:**")
+}
+
+func TestScaffoldFilename(t *testing.T) {
+	tests := map[string]string{
+		"Add Dark Mode":          "add-dark-mode",
+		"API v2 — New Endpoints": "api-v2-new-endpoints",
+		"  spaces & symbols!!! ": "spaces-symbols",
+	}
+	for input, want := range tests {
+		assert.Equal(t, want, github.ScaffoldFilename(input), "input: %q", input)
+	}
+}
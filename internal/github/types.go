@@ -0,0 +1,37 @@
+package github
+
+// Repo identifies a GitHub repository.
+type Repo struct {
+	Owner string
+	Name  string
+}
+
+// FullName returns the "owner/name" form used by the GitHub API.
+func (r Repo) FullName() string {
+	return r.Owner + "/" + r.Name
+}
+
+// SearchResult is a GitHub issue from search results.
+type SearchResult struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+	URL    string `json:"url"`
+}
+
+// Task is a full GitHub issue with details.
+type Task struct {
+	Number    int             `json:"number"`
+	Title     string          `json:"title"`
+	Body      string          `json:"body"`
+	State     string          `json:"state"`
+	URL       string          `json:"url"`
+	Labels    []string        `json:"labels"`
+	Checklist []ChecklistItem `json:"checklist"`
+}
+
+// ChecklistItem is a "- [ ] item" entry parsed from an issue body.
+type ChecklistItem struct {
+	Text string `json:"text"`
+	Done bool   `json:"done"`
+}
@@ -0,0 +1,144 @@
+package jira
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// adfNode is a node of an Atlassian Document Format tree, the rich-text
+// format REST v3 uses for descriptions and comments.
+type adfNode struct {
+	Type    string          `json:"type"`
+	Text    string          `json:"text"`
+	Attrs   json.RawMessage `json:"attrs"`
+	Content []adfNode       `json:"content"`
+}
+
+// ADFToText renders an ADF document as plain markdown-ish text. A JSON string
+// (REST v2 style plain description) is returned as-is; null or invalid input
+// yields "".
+func ADFToText(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var plain string
+	if json.Unmarshal(raw, &plain) == nil {
+		return strings.TrimSpace(plain)
+	}
+	var doc adfNode
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return ""
+	}
+	var b strings.Builder
+	renderADFBlocks(&b, doc.Content, "")
+	return strings.TrimSpace(b.String())
+}
+
+// renderADFBlocks writes block-level nodes, each followed by a blank line
+// (list items by a single newline). prefix indents nested list content.
+func renderADFBlocks(b *strings.Builder, nodes []adfNode, prefix string) {
+	for _, n := range nodes {
+		switch n.Type {
+		case "paragraph":
+			b.WriteString(prefix + renderADFInline(n.Content) + "\n\n")
+		case "heading":
+			var attrs struct {
+				Level int `json:"level"`
+			}
+			_ = json.Unmarshal(n.Attrs, &attrs)
+			if attrs.Level < 1 {
+				attrs.Level = 1
+			}
+			b.WriteString(prefix + strings.Repeat("#", attrs.Level) + " " + renderADFInline(n.Content) + "\n\n")
+		case "bulletList", "orderedList", "taskList":
+			renderADFList(b, n, prefix)
+			if prefix == "" {
+				b.WriteString("\n")
+			}
+		case "codeBlock":
+			b.WriteString(prefix + "```\n" + renderADFInline(n.Content) + "\n```\n\n")
+		case "blockquote":
+			renderADFBlocks(b, n.Content, prefix+"> ")
+		case "rule":
+			b.WriteString(prefix + "---\n\n")
+		default:
+			if len(n.Content) > 0 {
+				renderADFBlocks(b, n.Content, prefix)
+			} else if n.Text != "" {
+				b.WriteString(prefix + n.Text + "\n\n")
+			}
+		}
+	}
+}
+
+// renderADFList writes list items as "- " / "1. " / "- [ ] " lines.
+func renderADFList(b *strings.Builder, list adfNode, prefix string) {
+	for i, item := range list.Content {
+		marker := "- "
+		switch list.Type {
+		case "orderedList":
+			marker = strconv.Itoa(i+1) + ". "
+		case "taskList":
+			var attrs struct {
+				State string `json:"state"`
+			}
+			_ = json.Unmarshal(item.Attrs, &attrs)
+			marker = "- [ ] "
+			if attrs.State == "DONE" {
+				marker = "- [x] "
+			}
+		}
+		var text []string
+		for _, c := range item.Content {
+			switch c.Type {
+			case "bulletList", "orderedList", "taskList":
+				continue
+			case "text", "hardBreak", "mention", "emoji", "inlineCard":
+				text = append(text, renderADFInline([]adfNode{c}))
+			default:
+				text = append(text, renderADFInline(c.Content))
+			}
+		}
+		b.WriteString(prefix + marker + strings.TrimSpace(strings.Join(text, " ")) + "\n")
+		for _, c := range item.Content {
+			switch c.Type {
+			case "bulletList", "orderedList", "taskList":
+				renderADFList(b, c, prefix+"  ")
+			}
+		}
+	}
+}
+
+// renderADFInline concatenates inline nodes (text, mentions, breaks).
+func renderADFInline(nodes []adfNode) string {
+	var b strings.Builder
+	for _, n := range nodes {
+		switch n.Type {
+		case "text":
+			b.WriteString(n.Text)
+		case "hardBreak":
+			b.WriteString("\n")
+		case "mention", "emoji":
+			var attrs struct {
+				Text      string `json:"text"`
+				ShortName string `json:"shortName"`
+			}
+			_ = json.Unmarshal(n.Attrs, &attrs)
+			if attrs.Text != "" {
+				b.WriteString(attrs.Text)
+			} else {
+				b.WriteString(attrs.ShortName)
+			}
+		case "inlineCard":
+			var attrs struct {
+				URL string `json:"url"`
+			}
+			_ = json.Unmarshal(n.Attrs, &attrs)
+			b.WriteString(attrs.URL)
+		default:
+			b.WriteString(renderADFInline(n.Content))
+		}
+	}
+	return b.String()
+}
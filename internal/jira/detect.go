@@ -0,0 +1,45 @@
+package jira
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// projectConfigPath is the per-repo Jira config, relative to the repo root.
+const projectConfigPath = ".kasmos/jira.toml"
+
+// Environment variables that fill in or override .kasmos/jira.toml.
+const (
+	EnvBaseURL = "JIRA_BASE_URL"
+	EnvProject = "JIRA_PROJECT"
+	EnvEmail   = "JIRA_EMAIL"
+	EnvToken   = "JIRA_TOKEN"
+)
+
+// DetectJira reads <repoPath>/.kasmos/jira.toml and the JIRA_* environment
+// variables (which win over the file). Jira is considered configured when a
+// base URL is known.
+func DetectJira(repoPath string) (Config, bool) {
+	var cfg Config
+	if data, err := os.ReadFile(filepath.Join(repoPath, projectConfigPath)); err == nil {
+		_, _ = toml.Decode(string(data), &cfg)
+	}
+	if v := strings.TrimSpace(os.Getenv(EnvBaseURL)); v != "" {
+		cfg.BaseURL = v
+	}
+	if v := strings.TrimSpace(os.Getenv(EnvProject)); v != "" {
+		cfg.ProjectKey = v
+	}
+	if v := strings.TrimSpace(os.Getenv(EnvEmail)); v != "" {
+		cfg.Email = v
+	}
+	cfg.BaseURL = strings.TrimRight(strings.TrimSpace(cfg.BaseURL), "/")
+	cfg.ProjectKey = strings.TrimSpace(cfg.ProjectKey)
+	if cfg.BaseURL == "" {
+		return Config{}, false
+	}
+	return cfg, true
+}
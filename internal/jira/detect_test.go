@@ -0,0 +1,61 @@
+package jira_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kastheco/kasmos/internal/jira"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func clearJiraEnv(t *testing.T) {
+	t.Helper()
+	for _, k := range []string{jira.EnvBaseURL, jira.EnvProject, jira.EnvEmail} {
+		t.Setenv(k, "")
+	}
+}
+
+func TestDetectJira_ReadsProjectConfig(t *testing.T) {
+	clearJiraEnv(t)
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".kasmos"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".kasmos", "jira.toml"), []byte(`base_url = "https://acme.atlassian.net/"
+project_key = "WID"
+email = "me@acme.io"
+`), 0o644))
+
+	cfg, found := jira.DetectJira(dir)
+	require.True(t, found)
+	assert.Equal(t, jira.Config{BaseURL: "https://acme.atlassian.net", ProjectKey: "WID", Email: "me@acme.io"}, cfg)
+}
+
+func TestDetectJira_EnvOverridesFile(t *testing.T) {
+	clearJiraEnv(t)
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".kasmos"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".kasmos", "jira.toml"),
+		[]byte("base_url = \"https://file.example\"\nproject_key = \"FILE\"\n"), 0o644))
+	t.Setenv(jira.EnvProject, "ENV")
+
+	cfg, found := jira.DetectJira(dir)
+	require.True(t, found)
+	assert.Equal(t, "https://file.example", cfg.BaseURL)
+	assert.Equal(t, "ENV", cfg.ProjectKey)
+}
+
+func TestDetectJira_EnvOnly(t *testing.T) {
+	clearJiraEnv(t)
+	t.Setenv(jira.EnvBaseURL, "https://jira.internal")
+
+	cfg, found := jira.DetectJira(t.TempDir())
+	require.True(t, found)
+	assert.Equal(t, "https://jira.internal", cfg.BaseURL)
+}
+
+func TestDetectJira_NotConfigured(t *testing.T) {
+	clearJiraEnv(t)
+	_, found := jira.DetectJira(t.TempDir())
+	assert.False(t, found)
+}
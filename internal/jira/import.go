@@ -0,0 +1,214 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// searchLimit caps how many issues a search returns.
+const searchLimit = 20
+
+// Importer searches and fetches Jira issues via the REST v3 API.
+type Importer struct {
+	cfg    Config
+	token  string
+	client *http.Client
+}
+
+// NewImporter creates an Importer for the Jira site in cfg. token is a Jira
+// Cloud API token (used with cfg.Email) or a Data Center personal access token.
+func NewImporter(cfg Config, token string) *Importer {
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	return &Importer{
+		cfg:    cfg,
+		token:  token,
+		client: &http.Client{Timeout: 20 * time.Second},
+	}
+}
+
+// apiStatus is the status object embedded in issue fields.
+type apiStatus struct {
+	Name           string `json:"name"`
+	StatusCategory struct {
+		Key string `json:"key"`
+	} `json:"statusCategory"`
+}
+
+// apiIssue is the subset of the Jira issue payload the importer reads.
+type apiIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary     string          `json:"summary"`
+		Description json.RawMessage `json:"description"`
+		Status      apiStatus       `json:"status"`
+		Priority    *struct {
+			Name string `json:"name"`
+		} `json:"priority"`
+		IssueType struct {
+			Name string `json:"name"`
+		} `json:"issuetype"`
+		Labels   []string `json:"labels"`
+		Subtasks []struct {
+			Key    string `json:"key"`
+			Fields struct {
+				Summary string    `json:"summary"`
+				Status  apiStatus `json:"status"`
+			} `json:"fields"`
+		} `json:"subtasks"`
+	} `json:"fields"`
+}
+
+// issueKeyRe matches a bare issue key ("PROJ-123") or a /browse/ URL.
+var issueKeyRe = regexp.MustCompile(`^(?:([A-Za-z][A-Za-z0-9_]*-\d+)|https?://\S+/browse/([A-Za-z][A-Za-z0-9_]*-\d+)/?)$`)
+
+// jqlOperatorRe detects input that is already JQL rather than free text.
+var jqlOperatorRe = regexp.MustCompile(`(?i)(!=|=|~|\s(in|is|was|order\s+by)\s)`)
+
+// BuildJQL turns user input into a JQL query. Input that already contains a
+// JQL operator is used verbatim; free text becomes a text search scoped to
+// projectKey (when set), most recently updated first.
+func BuildJQL(projectKey, query string) string {
+	query = strings.TrimSpace(query)
+	if jqlOperatorRe.MatchString(query) {
+		return query
+	}
+	jql := fmt.Sprintf("text ~ %s", strconv.Quote(query))
+	if projectKey != "" {
+		jql = fmt.Sprintf("project = %s AND %s", strconv.Quote(projectKey), jql)
+	}
+	return jql + " ORDER BY updated DESC"
+}
+
+// Search finds issues matching jql. Free text is wrapped by BuildJQL, and a
+// bare issue key ("PROJ-42") or browse URL resolves directly to that issue.
+func (im *Importer) Search(jql string) ([]SearchResult, error) {
+	jql = strings.TrimSpace(jql)
+	if m := issueKeyRe.FindStringSubmatch(jql); m != nil {
+		key := m[1]
+		if key == "" {
+			key = m[2]
+		}
+		task, err := im.FetchTask(strings.ToUpper(key))
+		if err != nil {
+			return nil, err
+		}
+		return []SearchResult{{Key: task.Key, Summary: task.Summary, Status: task.Status, Type: task.Type, URL: task.URL}}, nil
+	}
+
+	params := url.Values{}
+	params.Set("jql", BuildJQL(im.cfg.ProjectKey, jql))
+	params.Set("maxResults", strconv.Itoa(searchLimit))
+	params.Set("fields", "summary,status,issuetype")
+
+	var resp struct {
+		Issues []apiIssue `json:"issues"`
+	}
+	if err := im.get("/rest/api/3/search/jql?"+params.Encode(), &resp); err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+	results := make([]SearchResult, 0, len(resp.Issues))
+	for _, it := range resp.Issues {
+		results = append(results, SearchResult{
+			Key:     it.Key,
+			Summary: it.Fields.Summary,
+			Status:  it.Fields.Status.Name,
+			Type:    it.Fields.IssueType.Name,
+			URL:     im.browseURL(it.Key),
+		})
+	}
+	return results, nil
+}
+
+// FetchTask gets full details for a Jira issue by key, including sub-tasks.
+func (im *Importer) FetchTask(issueKey string) (*Task, error) {
+	params := url.Values{}
+	params.Set("fields", "summary,description,status,priority,issuetype,labels,subtasks")
+
+	var issue apiIssue
+	path := "/rest/api/3/issue/" + url.PathEscape(issueKey) + "?" + params.Encode()
+	if err := im.get(path, &issue); err != nil {
+		return nil, fmt.Errorf("fetch issue %s: %w", issueKey, err)
+	}
+
+	f := issue.Fields
+	task := &Task{
+		Key:         issue.Key,
+		Summary:     f.Summary,
+		Description: ADFToText(f.Description),
+		Status:      f.Status.Name,
+		Type:        f.IssueType.Name,
+		URL:         im.browseURL(issue.Key),
+		Labels:      f.Labels,
+	}
+	if f.Priority != nil {
+		task.Priority = f.Priority.Name
+	}
+	for _, st := range f.Subtasks {
+		task.Subtasks = append(task.Subtasks, Subtask{
+			Key:     st.Key,
+			Summary: st.Fields.Summary,
+			Status:  st.Fields.Status.Name,
+			Done:    st.Fields.Status.StatusCategory.Key == "done",
+		})
+	}
+	return task, nil
+}
+
+// browseURL returns the web URL for an issue key.
+func (im *Importer) browseURL(key string) string {
+	return im.cfg.BaseURL + "/browse/" + key
+}
+
+// get issues a GET against the API and decodes the JSON response into out.
+func (im *Importer) get(path string, out interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, im.cfg.BaseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if im.token != "" {
+		if im.cfg.Email != "" {
+			req.SetBasicAuth(im.cfg.Email, im.token)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+im.token)
+		}
+	}
+
+	resp, err := im.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			ErrorMessages []string          `json:"errorMessages"`
+			Errors        map[string]string `json:"errors"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil {
+			msgs := apiErr.ErrorMessages
+			for field, msg := range apiErr.Errors {
+				msgs = append(msgs, field+": "+msg)
+			}
+			if len(msgs) > 0 {
+				return fmt.Errorf("jira api %s: %s", resp.Status, strings.Join(msgs, "; "))
+			}
+		}
+		return fmt.Errorf("jira api %s", resp.Status)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("parse response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package jira_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kastheco/kasmos/internal/jira"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const issueJSON = `{
+	"key": "WID-42",
+	"fields": {
+		"summary": "Add dark mode",
+		"description": {"type": "doc", "version": 1, "content": [
+			{"type": "paragraph", "content": [{"type": "text", "text": "We need dark mode."}]}
+		]},
+		"status": {"name": "In Progress", "statusCategory": {"key": "indeterminate"}},
+		"priority": {"name": "High"},
+		"issuetype": {"name": "Story"},
+		"labels": ["ui", "theme"],
+		"subtasks": [
+			{"key": "WID-43", "fields": {"summary": "add palette", "status": {"name": "To Do", "statusCategory": {"key": "new"}}}},
+			{"key": "WID-44", "fields": {"summary": "pick colors", "status": {"name": "Done", "statusCategory": {"key": "done"}}}}
+		]
+	}
+}`
+
+func newTestImporter(t *testing.T, cfg jira.Config, handler http.HandlerFunc) *jira.Importer {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	cfg.BaseURL = srv.URL
+	return jira.NewImporter(cfg, "secret")
+}
+
+func TestImporter_Search(t *testing.T) {
+	var gotJQL, gotAuth string
+	im := newTestImporter(t, jira.Config{ProjectKey: "WID"}, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/3/search/jql", r.URL.Path)
+		gotJQL = r.URL.Query().Get("jql")
+		gotAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"issues":[` + issueJSON + `]}`))
+	})
+
+	results, err := im.Search("dark mode")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "WID-42", results[0].Key)
+	assert.Equal(t, "Add dark mode", results[0].Summary)
+	assert.Equal(t, "In Progress", results[0].Status)
+	assert.Equal(t, "Story", results[0].Type)
+	assert.Contains(t, results[0].URL, "/browse/WID-42")
+	assert.Equal(t, `project = "WID" AND text ~ "dark mode" ORDER BY updated DESC`, gotJQL)
+	assert.Equal(t, "Bearer secret", gotAuth)
+}
+
+func TestImporter_SearchRawJQL(t *testing.T) {
+	var gotJQL string
+	im := newTestImporter(t, jira.Config{ProjectKey: "WID"}, func(w http.ResponseWriter, r *http.Request) {
+		gotJQL = r.URL.Query().Get("jql")
+		_, _ = w.Write([]byte(`{"issues":[]}`))
+	})
+
+	results, err := im.Search("assignee = currentUser() AND status != Done")
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	assert.Equal(t, "assignee = currentUser() AND status != Done", gotJQL)
+}
+
+func TestImporter_SearchByIssueKey(t *testing.T) {
+	for _, query := range []string{"WID-42", "wid-42", "https://acme.atlassian.net/browse/WID-42"} {
+		t.Run(query, func(t *testing.T) {
+			im := newTestImporter(t, jira.Config{}, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/rest/api/3/issue/WID-42", r.URL.Path)
+				_, _ = w.Write([]byte(issueJSON))
+			})
+			results, err := im.Search(query)
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+			assert.Equal(t, "WID-42", results[0].Key)
+		})
+	}
+}
+
+func TestImporter_FetchTask(t *testing.T) {
+	var gotAuth string
+	im := newTestImporter(t, jira.Config{Email: "me@acme.io"}, func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(issueJSON))
+	})
+
+	task, err := im.FetchTask("WID-42")
+	require.NoError(t, err)
+	assert.Equal(t, "WID-42", task.Key)
+	assert.Equal(t, "Add dark mode", task.Summary)
+	assert.Equal(t, "We need dark mode.", task.Description)
+	assert.Equal(t, "High", task.Priority)
+	assert.Equal(t, []string{"ui", "theme"}, task.Labels)
+	assert.Equal(t, []jira.Subtask{
+		{Key: "WID-43", Summary: "add palette", Status: "To Do"},
+		{Key: "WID-44", Summary: "pick colors", Status: "Done", Done: true},
+	}, task.Subtasks)
+	assert.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte("me@acme.io:secret")), gotAuth)
+}
+
+func TestImporter_APIError(t *testing.T) {
+	im := newTestImporter(t, jira.Config{}, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errorMessages":["Issue does not exist or you do not have permission to see it."],"errors":{}}`))
+	})
+
+	_, err := im.FetchTask("WID-7")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Issue does not exist")
+}
+
+func TestADFToText(t *testing.T) {
+	doc := `{"type":"doc","version":1,"content":[
+		{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"Context"}]},
+		{"type":"paragraph","content":[{"type":"text","text":"line one"},{"type":"hardBreak"},{"type":"text","text":"line two"}]},
+		{"type":"bulletList","content":[
+			{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"first"}]}]},
+			{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"second"}]}]}
+		]},
+		{"type":"codeBlock","content":[{"type":"text","text":"go test ./..."}]}
+	]}`
+
+	got := jira.ADFToText(json.RawMessage(doc))
+	assert.Equal(t, "## Context\n\nline one\nline two\n\n- first\n- second\n\n```\ngo test ./...\n```", got)
+
+	assert.Equal(t, "plain text", jira.ADFToText(json.RawMessage(`"plain text"`)))
+	assert.Equal(t, "", jira.ADFToText(json.RawMessage(`null`)))
+	assert.Equal(t, "", jira.ADFToText(nil))
+}
+
+func TestBuildJQL(t *testing.T) {
+	assert.Equal(t, `text ~ "crash" ORDER BY updated DESC`, jira.BuildJQL("", "crash"))
+	assert.Equal(t, `project = "WID" AND text ~ "say \"hi\"" ORDER BY updated DESC`, jira.BuildJQL("WID", `say "hi"`))
+	assert.Equal(t, "project = WID ORDER BY created", jira.BuildJQL("OTHER", "project = WID ORDER BY created"))
+	assert.Equal(t, "labels in (ui, ux)", jira.BuildJQL("WID", "labels in (ui, ux)"))
+}
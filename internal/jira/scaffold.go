@@ -0,0 +1,71 @@
+package jira
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ScaffoldPlan generates a plan markdown from a Jira issue. The description is
+// embedded verbatim and sub-tasks are listed as wave task candidates for the
+// planner.
+func ScaffoldPlan(task Task) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", task.Summary)
+	fmt.Fprintf(&b, "**Goal:** %s\n\n", task.Summary)
+
+	if task.Key != "" {
+		fmt.Fprintf(&b, "**Source:** Jira %s", task.Key)
+		if task.URL != "" {
+			fmt.Fprintf(&b, " (%s)", task.URL)
+		}
+		b.WriteString("\n\n")
+	}
+
+	if task.Type != "" {
+		fmt.Fprintf(&b, "**Issue Type:** %s\n\n", task.Type)
+	}
+
+	if task.Status != "" {
+		fmt.Fprintf(&b, "**Jira Status:** %s\n\n", task.Status)
+	}
+
+	if task.Priority != "" {
+		fmt.Fprintf(&b, "**Priority:** %s\n\n", task.Priority)
+	}
+
+	if len(task.Labels) > 0 {
+		fmt.Fprintf(&b, "**Labels:** %s\n\n", strings.Join(task.Labels, ", "))
+	}
+
+	if desc := strings.TrimSpace(task.Description); desc != "" {
+		b.WriteString("## Reference: Jira Description\n\n")
+		b.WriteString(desc)
+		b.WriteString("\n\n")
+	}
+
+	if len(task.Subtasks) > 0 {
+		b.WriteString("## Reference: Task Candidates\n\n")
+		for _, st := range task.Subtasks {
+			checkbox := "- [ ] "
+			if st.Done {
+				checkbox = "- [x] "
+			}
+			fmt.Fprintf(&b, "%s%s (%s)\n", checkbox, st.Summary, st.Key)
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// ScaffoldFilename generates a plan filename from an issue summary.
+func ScaffoldFilename(name string) string {
+	slug := strings.ToLower(strings.TrimSpace(name))
+	slug = nonAlphanumeric.ReplaceAllString(slug, "-")
+	slug = strings.Trim(slug, "-")
+	return slug
+}
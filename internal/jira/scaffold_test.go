@@ -0,0 +1,55 @@
+package jira_test
+
+import (
+	"testing"
+
+	"github.com/kastheco/kasmos/internal/jira"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScaffoldPlan_EmbedsDescriptionAndSubtasks(t *testing.T) {
+	task := jira.Task{
+		Key:         "WID-42",
+		Summary:     "Add dark mode",
+		Description: "We need dark mode.",
+		Status:      "In Progress",
+		Priority:    "High",
+		Type:        "Story",
+		URL:         "https://acme.atlassian.net/browse/WID-42",
+		Labels:      []string{"ui", "theme"},
+		Subtasks: []jira.Subtask{
+			{Key: "WID-43", Summary: "add palette"},
+			{Key: "WID-44", Summary: "pick colors", Done: true},
+		},
+	}
+
+	md := jira.ScaffoldPlan(task)
+	assert.Contains(t, md, "# Add dark mode")
+	assert.Contains(t, md, "**Goal:** Add dark mode")
+	assert.Contains(t, md, "**Source:** Jira WID-42 (https://acme.atlassian.net/browse/WID-42)")
+	assert.Contains(t, md, "**Issue Type:** Story")
+	assert.Contains(t, md, "**Jira Status:** In Progress")
+	assert.Contains(t, md, "**Priority:** High")
+	assert.Contains(t, md, "**Labels:** ui, theme")
+	assert.Contains(t, md, "## Reference: Jira Description\n\nWe need dark mode.")
+	assert.Contains(t, md, "## Reference: Task Candidates")
+	assert.Contains(t, md, "- [ ] add palette (WID-43)\n- [x] pick colors (WID-44)")
+}
+
+func TestScaffoldPlan_MinimalIssue(t *testing.T) {
+	md := jira.ScaffoldPlan(jira.Task{Summary: "Bare"})
+	assert.Contains(t, md, "# Bare")
+	assert.NotContains(t, md, "## Reference")
+	assert.NotContains(t, md, "**Source:**")
+}
+
+func TestScaffoldFilename(t *testing.T) {
+	tests := map[string]string{
+		"Add Dark Mode":          "add-dark-mode",
+		"API v2 — New Endpoints": "api-v2-new-endpoints",
+		"  spaces & symbols!!! ": "spaces-symbols",
+	}
+	for input, want := range tests {
+		assert.Equal(t, want, jira.ScaffoldFilename(input), "input: %q", input)
+	}
+}
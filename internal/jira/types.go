@@ -0,0 +1,40 @@
+package jira
+
+// Config identifies a Jira site and the project to import from.
+type Config struct {
+	BaseURL    string `toml:"base_url"`
+	ProjectKey string `toml:"project_key"`
+	// Email enables Jira Cloud basic auth (email + API token). When empty the
+	// token is sent as a bearer token (Data Center personal access tokens).
+	Email string `toml:"email"`
+}
+
+// SearchResult is a Jira issue from search results.
+type SearchResult struct {
+	Key     string `json:"key"`
+	Summary string `json:"summary"`
+	Status  string `json:"status"`
+	Type    string `json:"type"`
+	URL     string `json:"url"`
+}
+
+// Task is a full Jira issue with details.
+type Task struct {
+	Key         string    `json:"key"`
+	Summary     string    `json:"summary"`
+	Description string    `json:"description"`
+	Status      string    `json:"status"`
+	Priority    string    `json:"priority"`
+	Type        string    `json:"type"`
+	URL         string    `json:"url"`
+	Labels      []string  `json:"labels"`
+	Subtasks    []Subtask `json:"subtasks"`
+}
+
+// Subtask is a Jira sub-task reference.
+type Subtask struct {
+	Key     string `json:"key"`
+	Summary string `json:"summary"`
+	Status  string `json:"status"`
+	Done    bool   `json:"done"`
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 )
 
 // Client is a minimal MCP client that supports initialize, tools/list, and tools/call.
@@ -13,26 +14,77 @@ type Client struct {
 	nextID    int
 	mu        sync.Mutex
 	tools     []Tool // cached after ListTools
+
+	// Retry policy for transport errors (see WithRetry). maxAttempts <= 1
+	// disables retries.
+	maxAttempts int
+	baseDelay   time.Duration
+	// reconnect re-establishes the transport before a retry (see WithReconnect).
+	reconnect   func() (Transport, error)
+	initialized bool
+}
+
+// ClientOption configures optional Client behavior.
+type ClientOption func(*Client)
+
+// WithRetry retries calls that fail with a transport error (not a JSON-RPC
+// error from the server) up to maxAttempts total attempts, sleeping
+// baseDelay, 2*baseDelay, 4*baseDelay, ... between attempts.
+func WithRetry(maxAttempts int, baseDelay time.Duration) ClientOption {
+	return func(c *Client) {
+		c.maxAttempts = maxAttempts
+		c.baseDelay = baseDelay
+	}
+}
+
+// WithReconnect sets the function used to open a fresh transport before each
+// retry. When the client was initialized, the MCP handshake is replayed on the
+// new transport. Without it, retries reuse the existing transport.
+func WithReconnect(dial func() (Transport, error)) ClientOption {
+	return func(c *Client) { c.reconnect = dial }
 }
 
 // NewClient creates a Client with the given transport.
-func NewClient(t Transport) (*Client, error) {
+func NewClient(t Transport, opts ...ClientOption) (*Client, error) {
 	if t == nil {
 		return nil, fmt.Errorf("transport required")
 	}
-	return &Client{transport: t, nextID: 1}, nil
+	c := &Client{transport: t, nextID: 1}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
 }
 
 // Initialize sends the MCP initialize handshake and the required
 // notifications/initialized follow-up per the MCP specification.
 func (c *Client) Initialize() error {
-	resp, err := c.call("initialize", map[string]any{
+	err := c.withRetry(func() error {
+		return c.handshake(c.currentTransport())
+	})
+	if te, ok := err.(transportError); ok {
+		return te.err
+	}
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.initialized = true
+	c.mu.Unlock()
+	return nil
+}
+
+// handshake runs initialize + notifications/initialized on t without retries.
+// Transport failures are wrapped in transportError so withRetry can tell them
+// apart from protocol errors.
+func (c *Client) handshake(t Transport) error {
+	resp, err := t.Send(c.request("initialize", map[string]any{
 		"protocolVersion": "2024-11-05",
 		"capabilities":    map[string]any{},
 		"clientInfo":      map[string]string{"name": "kasmos", "version": "0.1.0"},
-	})
+	}))
 	if err != nil {
-		return fmt.Errorf("initialize: %w", err)
+		return transportError{fmt.Errorf("initialize: %w", err)}
 	}
 	if resp.Error != nil {
 		return resp.Error
@@ -40,16 +92,15 @@ func (c *Client) Initialize() error {
 
 	// Per the MCP spec, the client MUST send notifications/initialized
 	// after receiving the initialize response.
-	if err := c.SendNotification("notifications/initialized", nil); err != nil {
-		return fmt.Errorf("initialized notification: %w", err)
+	if _, err := t.Send(JSONRPCRequest{JSONRPC: "2.0", Method: "notifications/initialized"}); err != nil {
+		return transportError{fmt.Errorf("initialized notification: %w", err)}
 	}
-
 	return nil
 }
 
 // SendNotification sends a JSON-RPC notification (no id, no response expected).
 func (c *Client) SendNotification(method string, params any) error {
-	_, err := c.transport.Send(JSONRPCRequest{
+	_, err := c.currentTransport().Send(JSONRPCRequest{
 		JSONRPC: "2.0",
 		ID:      0,
 		Method:  method,
@@ -112,18 +163,95 @@ func (c *Client) FindTool(substring string) (Tool, bool) {
 
 // Close shuts down the transport.
 func (c *Client) Close() error {
-	return c.transport.Close()
+	return c.currentTransport().Close()
 }
 
+// call sends a request, retrying transport failures per the retry policy.
+// JSON-RPC errors in the response are returned as-is and never retried.
 func (c *Client) call(method string, params any) (JSONRPCResponse, error) {
+	var resp JSONRPCResponse
+	err := c.withRetry(func() error {
+		var sendErr error
+		resp, sendErr = c.currentTransport().Send(c.request(method, params))
+		if sendErr != nil {
+			return transportError{sendErr}
+		}
+		return nil
+	})
+	if te, ok := err.(transportError); ok {
+		err = te.err
+	}
+	return resp, err
+}
+
+func (c *Client) request(method string, params any) JSONRPCRequest {
 	c.mu.Lock()
 	id := c.nextID
 	c.nextID++
 	c.mu.Unlock()
-	return c.transport.Send(JSONRPCRequest{
+	return JSONRPCRequest{
 		JSONRPC: "2.0",
 		ID:      id,
 		Method:  method,
 		Params:  params,
-	})
+	}
+}
+
+func (c *Client) currentTransport() Transport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.transport
+}
+
+// transportError marks a failure to exchange a message with the server, as
+// opposed to an error reported by the server itself.
+type transportError struct{ err error }
+
+func (e transportError) Error() string { return e.err.Error() }
+func (e transportError) Unwrap() error { return e.err }
+
+// withRetry runs fn, retrying with exponential backoff while it fails with a
+// transportError. Before each retry the transport is re-established when a
+// reconnect function is configured. Returns the last error.
+func (c *Client) withRetry(fn func() error) error {
+	err := fn()
+	for attempt := 1; attempt < c.maxAttempts; attempt++ {
+		if _, ok := err.(transportError); !ok {
+			return err
+		}
+		time.Sleep(c.baseDelay << (attempt - 1))
+		if rerr := c.reestablish(); rerr != nil {
+			err = transportError{fmt.Errorf("reconnect: %w", rerr)}
+			continue
+		}
+		err = fn()
+	}
+	return err
+}
+
+// reestablish swaps in a fresh transport from the reconnect function and
+// replays the MCP handshake if the client had been initialized.
+func (c *Client) reestablish() error {
+	if c.reconnect == nil {
+		return nil
+	}
+	t, err := c.reconnect()
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	initialized := c.initialized
+	c.mu.Unlock()
+	if initialized {
+		if err := c.handshake(t); err != nil {
+			_ = t.Close()
+			return err
+		}
+	}
+	c.mu.Lock()
+	old := c.transport
+	c.transport = t
+	c.mu.Unlock()
+	_ = old.Close()
+	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/kastheco/kasmos/internal/mcpclient"
 	"github.com/stretchr/testify/assert"
@@ -157,3 +158,97 @@ func TestJSONRPCError_Error(t *testing.T) {
 	e := &mcpclient.JSONRPCError{Code: -32600, Message: "invalid request"}
 	assert.Equal(t, "invalid request", e.Error())
 }
+
+// flakyTransport fails the first failures Send calls with a transport error,
+// then answers from the embedded mockTransport.
+type flakyTransport struct {
+	mockTransport
+	failures int
+	calls    int
+	methods  []string
+}
+
+func (f *flakyTransport) Send(req mcpclient.JSONRPCRequest) (mcpclient.JSONRPCResponse, error) {
+	f.calls++
+	f.methods = append(f.methods, req.Method)
+	if f.calls <= f.failures {
+		return mcpclient.JSONRPCResponse{}, fmt.Errorf("connection reset")
+	}
+	return f.mockTransport.Send(req)
+}
+
+func toolResponses() map[string]mcpclient.JSONRPCResponse {
+	return map[string]mcpclient.JSONRPCResponse{
+		"initialize":                {Result: json.RawMessage(`{"protocolVersion":"2024-11-05"}`)},
+		"notifications/initialized": {JSONRPC: "2.0"},
+		"tools/call":                {Result: json.RawMessage(`{"content":[{"type":"text","text":"ok"}]}`)},
+	}
+}
+
+func TestClient_RetryRecoversFromTransportErrors(t *testing.T) {
+	ft := &flakyTransport{mockTransport: mockTransport{responses: toolResponses()}, failures: 2}
+	c, err := mcpclient.NewClient(ft, mcpclient.WithRetry(3, time.Millisecond))
+	require.NoError(t, err)
+
+	result, err := c.CallTool("search", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", result.Content[0].Text)
+	assert.Equal(t, 3, ft.calls)
+}
+
+func TestClient_RetryGivesUpAfterMaxAttempts(t *testing.T) {
+	ft := &flakyTransport{mockTransport: mockTransport{responses: toolResponses()}, failures: 5}
+	c, err := mcpclient.NewClient(ft, mcpclient.WithRetry(3, time.Millisecond))
+	require.NoError(t, err)
+
+	_, err = c.CallTool("search", nil)
+	assert.ErrorContains(t, err, "connection reset")
+	assert.Equal(t, 3, ft.calls)
+}
+
+func TestClient_NoRetryWithoutPolicy(t *testing.T) {
+	ft := &flakyTransport{mockTransport: mockTransport{responses: toolResponses()}, failures: 1}
+	c, err := mcpclient.NewClient(ft)
+	require.NoError(t, err)
+
+	_, err = c.CallTool("search", nil)
+	assert.Error(t, err)
+	assert.Equal(t, 1, ft.calls)
+}
+
+func TestClient_RetryIgnoresProtocolErrors(t *testing.T) {
+	responses := toolResponses()
+	responses["tools/call"] = mcpclient.JSONRPCResponse{Error: &mcpclient.JSONRPCError{Code: -32602, Message: "invalid params"}}
+	ft := &flakyTransport{mockTransport: mockTransport{responses: responses}}
+	c, err := mcpclient.NewClient(ft, mcpclient.WithRetry(3, time.Millisecond))
+	require.NoError(t, err)
+
+	_, err = c.CallTool("search", nil)
+	assert.ErrorContains(t, err, "invalid params")
+	assert.Equal(t, 1, ft.calls, "protocol errors must not be retried")
+}
+
+func TestClient_RetryReconnectsAndReplaysHandshake(t *testing.T) {
+	first := &flakyTransport{mockTransport: mockTransport{responses: toolResponses()}}
+	var dials []*flakyTransport
+	c, err := mcpclient.NewClient(first,
+		mcpclient.WithRetry(3, time.Millisecond),
+		mcpclient.WithReconnect(func() (mcpclient.Transport, error) {
+			ft := &flakyTransport{mockTransport: mockTransport{responses: toolResponses()}}
+			dials = append(dials, ft)
+			return ft, nil
+		}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, c.Initialize())
+
+	// The server drops: every further call on the first transport fails.
+	first.failures = 1 << 30
+
+	result, err := c.CallTool("search", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", result.Content[0].Text)
+	require.Len(t, dials, 1)
+	assert.Equal(t, []string{"initialize", "notifications/initialized", "tools/call"}, dials[0].methods)
+	assert.True(t, first.closed, "replaced transport must be closed")
+}
@@ -0,0 +1,269 @@
+package mcpclient
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultSSEResponseTimeout bounds how long Send waits for a response event.
+const defaultSSEResponseTimeout = 60 * time.Second
+
+// SSETransport speaks JSON-RPC over the HTTP+SSE MCP transport: the client
+// holds a GET text/event-stream open, the server announces a POST endpoint in
+// an "endpoint" event, requests are POSTed there, and responses arrive as
+// "message" events on the stream.
+type SSETransport struct {
+	url     string
+	token   string
+	http    *http.Client
+	timeout time.Duration
+
+	mu       sync.Mutex
+	endpoint string
+	stream   io.ReadCloser
+	pending  map[int]chan JSONRPCResponse
+	err      error // set once the event stream ends
+	done     chan struct{}
+}
+
+// NewSSETransport creates an SSE transport with a bearer token.
+// Pass an empty token to skip authorization headers. The event stream is
+// opened lazily on the first Send.
+func NewSSETransport(url, token string) *SSETransport {
+	return &SSETransport{
+		url:     url,
+		token:   token,
+		http:    &http.Client{},
+		timeout: defaultSSEResponseTimeout,
+		pending: make(map[int]chan JSONRPCResponse),
+	}
+}
+
+// Send posts a JSON-RPC request to the session endpoint and waits for the
+// matching response on the event stream. Notifications return immediately.
+func (t *SSETransport) Send(req JSONRPCRequest) (JSONRPCResponse, error) {
+	if err := t.connect(); err != nil {
+		return JSONRPCResponse{}, err
+	}
+
+	notification := strings.HasPrefix(req.Method, "notifications/")
+	var ch chan JSONRPCResponse
+	if !notification {
+		ch = make(chan JSONRPCResponse, 1)
+		t.mu.Lock()
+		t.pending[req.ID] = ch
+		t.mu.Unlock()
+		defer func() {
+			t.mu.Lock()
+			delete(t.pending, req.ID)
+			t.mu.Unlock()
+		}()
+	}
+
+	if err := t.post(req); err != nil {
+		return JSONRPCResponse{}, err
+	}
+	if notification {
+		return JSONRPCResponse{JSONRPC: "2.0", ID: req.ID}, nil
+	}
+
+	t.mu.Lock()
+	done := t.done
+	t.mu.Unlock()
+
+	timer := time.NewTimer(t.timeout)
+	defer timer.Stop()
+	select {
+	case resp := <-ch:
+		return resp, nil
+	case <-done:
+		t.mu.Lock()
+		err := t.err
+		t.mu.Unlock()
+		return JSONRPCResponse{}, fmt.Errorf("sse stream closed: %w", err)
+	case <-timer.C:
+		return JSONRPCResponse{}, fmt.Errorf("sse: no response for request %d after %s", req.ID, t.timeout)
+	}
+}
+
+// connect opens the event stream and waits for the endpoint event. It is a
+// no-op while a stream is live; after the stream ends it reconnects.
+func (t *SSETransport) connect() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stream != nil {
+		if t.err == nil {
+			return nil
+		}
+		_ = t.stream.Close()
+		t.stream = nil
+	}
+
+	httpReq, err := http.NewRequest("GET", t.url, nil)
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+	httpReq.Header.Set("Accept", "text/event-stream")
+	if t.token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+t.token)
+	}
+
+	httpResp, err := t.http.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("sse connect: %w", err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(httpResp.Body)
+		httpResp.Body.Close()
+		return fmt.Errorf("sse connect: http %d: %s", httpResp.StatusCode, string(respBody))
+	}
+
+	reader := bufio.NewReader(httpResp.Body)
+	event, data, err := readSSEEvent(reader)
+	if err != nil {
+		httpResp.Body.Close()
+		return fmt.Errorf("sse connect: %w", err)
+	}
+	if event != "endpoint" {
+		httpResp.Body.Close()
+		return fmt.Errorf("sse connect: expected endpoint event, got %q", event)
+	}
+	endpoint, err := resolveSSEEndpoint(t.url, data)
+	if err != nil {
+		httpResp.Body.Close()
+		return fmt.Errorf("sse connect: %w", err)
+	}
+
+	t.endpoint = endpoint
+	t.stream = httpResp.Body
+	t.err = nil
+	t.done = make(chan struct{})
+	go t.readLoop(reader, t.done)
+	return nil
+}
+
+// readLoop dispatches "message" events to the pending request with the
+// matching ID until the stream ends.
+func (t *SSETransport) readLoop(reader *bufio.Reader, done chan struct{}) {
+	for {
+		event, data, err := readSSEEvent(reader)
+		if err != nil {
+			t.mu.Lock()
+			t.err = err
+			t.mu.Unlock()
+			close(done)
+			return
+		}
+		if event != "message" && event != "" {
+			continue
+		}
+		var resp JSONRPCResponse
+		if err := json.Unmarshal([]byte(data), &resp); err != nil {
+			continue
+		}
+		t.mu.Lock()
+		ch, ok := t.pending[resp.ID]
+		t.mu.Unlock()
+		if ok {
+			select {
+			case ch <- resp:
+			default: // duplicate response; the first one wins
+			}
+		}
+	}
+}
+
+func (t *SSETransport) post(req JSONRPCRequest) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	t.mu.Lock()
+	endpoint := t.endpoint
+	t.mu.Unlock()
+
+	httpReq, err := http.NewRequest("POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if t.token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+t.token)
+	}
+
+	httpResp, err := t.http.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("http post: %w", err)
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusAccepted {
+		respBody, _ := io.ReadAll(httpResp.Body)
+		return fmt.Errorf("http %d: %s", httpResp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+// readSSEEvent reads one event (terminated by a blank line) and returns its
+// type and joined data lines. Comment lines and events without data are skipped.
+func readSSEEvent(r *bufio.Reader) (string, string, error) {
+	var event string
+	var data []string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			if err == io.EOF && len(data) > 0 && strings.TrimSpace(line) == "" {
+				return event, strings.Join(data, "\n"), nil
+			}
+			return "", "", err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "":
+			if len(data) > 0 {
+				return event, strings.Join(data, "\n"), nil
+			}
+			event = ""
+		case strings.HasPrefix(line, ":"):
+			// comment / keep-alive
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+}
+
+// resolveSSEEndpoint resolves the (usually relative) endpoint announced by the
+// server against the stream URL.
+func resolveSSEEndpoint(base, endpoint string) (string, error) {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("parse url: %w", err)
+	}
+	ref, err := url.Parse(strings.TrimSpace(endpoint))
+	if err != nil {
+		return "", fmt.Errorf("parse endpoint: %w", err)
+	}
+	return baseURL.ResolveReference(ref).String(), nil
+}
+
+// Close terminates the event stream.
+func (t *SSETransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stream == nil {
+		return nil
+	}
+	err := t.stream.Close()
+	t.stream = nil
+	return err
+}
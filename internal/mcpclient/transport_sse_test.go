@@ -0,0 +1,147 @@
+package mcpclient_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/kastheco/kasmos/internal/mcpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sseTestServer is a minimal HTTP+SSE MCP server: GET /sse opens the stream
+// and announces /messages; POST /messages answers on the stream.
+type sseTestServer struct {
+	t        *testing.T
+	mu       sync.Mutex
+	events   chan string
+	posted   []mcpclient.JSONRPCRequest
+	authSeen string
+}
+
+func newSSETestServer(t *testing.T) (*sseTestServer, *httptest.Server) {
+	s := &sseTestServer{t: t, events: make(chan string, 16)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sse", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.authSeen = r.Header.Get("Authorization")
+		s.mu.Unlock()
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher := w.(http.Flusher)
+		fmt.Fprint(w, ": keep-alive\n\nevent: endpoint\ndata: /messages?sessionId=abc\n\n")
+		flusher.Flush()
+		for {
+			select {
+			case ev := <-s.events:
+				fmt.Fprint(w, ev)
+				flusher.Flush()
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})
+	mux.HandleFunc("/messages", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "abc", r.URL.Query().Get("sessionId"))
+		var req mcpclient.JSONRPCRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		s.mu.Lock()
+		s.posted = append(s.posted, req)
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+		if req.Method == "notifications/initialized" {
+			return
+		}
+		var result string
+		switch req.Method {
+		case "initialize":
+			result = `{"protocolVersion":"2024-11-05"}`
+		case "tools/list":
+			result = `{"tools":[{"name":"clickup_search"}]}`
+		default:
+			result = `{"content":[{"type":"text","text":"ok"}]}`
+		}
+		s.events <- fmt.Sprintf("event: message\ndata: {\"jsonrpc\":\"2.0\",\"id\":%d,\"result\":%s}\n\n", req.ID, result)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return s, srv
+}
+
+func TestSSETransport_ClientRoundTrip(t *testing.T) {
+	s, srv := newSSETestServer(t)
+
+	tr := mcpclient.NewSSETransport(srv.URL+"/sse", "tok")
+	client, err := mcpclient.NewClient(tr)
+	require.NoError(t, err)
+	defer client.Close()
+
+	require.NoError(t, client.Initialize())
+	tools, err := client.ListTools()
+	require.NoError(t, err)
+	require.Len(t, tools, 1)
+	assert.Equal(t, "clickup_search", tools[0].Name)
+
+	result, err := client.CallTool("clickup_search", map[string]any{"keywords": "x"})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	assert.Equal(t, "ok", result.Content[0].Text)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	assert.Equal(t, "Bearer tok", s.authSeen)
+	methods := make([]string, len(s.posted))
+	for i, req := range s.posted {
+		methods[i] = req.Method
+	}
+	assert.Equal(t, []string{"initialize", "notifications/initialized", "tools/list", "tools/call"}, methods)
+}
+
+func TestSSETransport_IgnoresUnmatchedEvents(t *testing.T) {
+	s, srv := newSSETestServer(t)
+	tr := mcpclient.NewSSETransport(srv.URL+"/sse", "")
+	defer tr.Close()
+
+	// An unsolicited multi-line event for an unknown ID must not be delivered to request 5.
+	go func() {
+		s.events <- "event: message\ndata: {\"jsonrpc\":\"2.0\",\ndata: \"id\":99,\"result\":{}}\n\n"
+	}()
+	resp, err := tr.Send(mcpclient.JSONRPCRequest{JSONRPC: "2.0", ID: 5, Method: "tools/list"})
+	require.NoError(t, err)
+	assert.Equal(t, 5, resp.ID)
+}
+
+func TestSSETransport_ConnectErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	tr := mcpclient.NewSSETransport(srv.URL, "bad")
+	_, err := tr.Send(mcpclient.JSONRPCRequest{JSONRPC: "2.0", ID: 1, Method: "initialize"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+}
+
+func TestSSETransport_StreamClosedFailsPending(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sse", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: endpoint\ndata: /messages\n\n")
+		w.(http.Flusher).Flush()
+		// Return immediately: the stream ends before any response is sent.
+	})
+	mux.HandleFunc("/messages", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	tr := mcpclient.NewSSETransport(srv.URL+"/sse", "")
+	_, err := tr.Send(mcpclient.JSONRPCRequest{JSONRPC: "2.0", ID: 1, Method: "tools/list"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sse stream closed")
+}
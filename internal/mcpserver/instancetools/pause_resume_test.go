@@ -37,8 +37,12 @@ func (m *mockStateManager) DeleteAllInstances() error {
 	return nil
 }
 
-func (m *mockStateManager) GetHelpScreensSeen() uint32        { return 0 }
-func (m *mockStateManager) SetHelpScreensSeen(_ uint32) error { return nil }
+func (m *mockStateManager) GetHelpScreensSeen() uint32         { return 0 }
+func (m *mockStateManager) SetHelpScreensSeen(_ uint32) error  { return nil }
+func (m *mockStateManager) GetNavGrouping() string             { return "" }
+func (m *mockStateManager) SetNavGrouping(_ string) error      { return nil }
+func (m *mockStateManager) GetPromptHistory(_ string) []string { return nil }
+func (m *mockStateManager) AddPromptHistory(_, _ string) error { return nil }
 
 // seedMutable returns a StateLoader backed by an in-memory mockStateManager.
 // Unlike seedInstances, mutations via SaveInstances are visible on subsequent
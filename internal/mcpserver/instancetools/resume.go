@@ -17,7 +17,7 @@ func init() {
 
 // buildResumeProgram reconstructs the tmux program command string for a resumed
 // instance. It mirrors the env-var and flag injection performed by
-// cmd.buildResumeCommand and session/tmux.TmuxSession.Start so that the resumed
+// session/tmux.TmuxSession.Start so that the resumed
 // agent is indistinguishable from a freshly started one.
 func buildResumeProgram(rec instanceRecord, worktreePath string) string {
 	program := rec.Program
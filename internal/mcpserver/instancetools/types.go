@@ -13,6 +13,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/kastheco/kasmos/config"
@@ -140,13 +141,17 @@ func statusLabel(s instanceStatus) string {
 // whiteSpaceRe matches one or more whitespace characters for session name sanitisation.
 var whiteSpaceRe = regexp.MustCompile(`\s+`)
 
-// kasTmuxName converts a human-readable instance title to the kas_-prefixed tmux
+// tmuxPrefix returns the configured tmux session prefix (tmux_prefix in
+// config.toml, default "kas_"). Resolved once per process.
+var tmuxPrefix = sync.OnceValue(config.ResolveTmuxPrefix)
+
+// kasTmuxName converts a human-readable instance title to the prefixed tmux
 // session name used by the session package. It replicates toKasTmuxName from
 // session/tmux without importing that package (which would create a cycle).
 func kasTmuxName(title string) string {
 	name := whiteSpaceRe.ReplaceAllString(title, "")
 	name = strings.ReplaceAll(name, ".", "_")
-	return "kas_" + name
+	return tmuxPrefix() + name
 }
 
 // loadRecords reads and parses the raw instance JSON from the state loader.
@@ -10,7 +10,7 @@ const (
 	KeyUp KeyName = iota
 	KeyDown
 	KeyEnter
-	KeyKill  // k — soft kill: terminates tmux session, keeps instance in list
+	KeyKill  // k (x in vim mode) — soft kill: terminates tmux session, keeps instance in list
 	KeyAbort // K — full abort: kills tmux, removes worktree, removes from list
 	KeyQuit
 	KeyReview
@@ -62,6 +62,44 @@ const (
 	KeyAuditToggle // L - toggle audit log pane visibility
 	KeyAuditCursor // A - enter audit log cursor mode (navigate log lines)
 	KeyBrowser     // b - open the admin plan browser
+
+	KeyAttachReadonly // O - attach to the selected instance read-only
+
+	KeyToggleDraft // ctrl+d - toggle draft mode while creating a PR
+
+	KeyExportDiff // D - export the selected instance's diff as a .patch file
+
+	KeyCopyPlan // Y - copy the selected plan's markdown to the clipboard
+
+	KeyCopyBranch // B - copy the selected instance's or plan's branch name to the clipboard
+
+	KeyBroadcastPrompt // I - send a prompt to every running session of the selected plan
+
+	KeyGlobalSearch // F - search the markdown content of every plan
+
+	KeyEditPlan // E - open the selected plan in $EDITOR
+
+	KeyQuitAndKill // Q - stop every session and remove worktrees, then quit
+
+	KeyMoveUp   // shift+up - move the selected instance up within its group
+	KeyMoveDown // shift+down - move the selected instance down within its group
+
+	KeyUndo // u - restore the last instance removed from the list
+
+	KeyNextNotification // ] - jump to the next instance needing attention
+
+	KeyToggleFollow // f - keep the preview scrolled to the newest output
+
+	KeyToggleCollapseAll // z - collapse every topic and plan group, or expand them all
+
+	KeyOverview // V - show plan progress and running agents across every known repo
+
+	KeyPauseAll  // C - pause every running session in the active repo
+	KeyResumeAll // R - resume every paused session in the active repo
+
+	KeySettings // , - edit the common config settings
+
+	KeyToggleGrouping // G - switch the sidebar between the plan tree and agent-type sections
 )
 
 // Backward-compatible aliases; prefer KeyInfoTab/KeyTabInfo.
@@ -77,6 +115,10 @@ var GlobalKeyStringsMap = map[string]KeyName{
 	"N":          KeyPrompt,
 	"enter":      KeyEnter,
 	"o":          KeyEnter,
+	"O":          KeyAttachReadonly,
+	"D":          KeyExportDiff,
+	"Y":          KeyCopyPlan,
+	"B":          KeyCopyBranch,
 	"n":          KeyNewPlan,
 	"k":          KeyKill,
 	"K":          KeyAbort,
@@ -91,6 +133,21 @@ var GlobalKeyStringsMap = map[string]KeyName{
 	"right":      KeyArrowRight,
 	"P":          KeyCreatePR,
 	"i":          KeySendPrompt,
+	"I":          KeyBroadcastPrompt,
+	"F":          KeyGlobalSearch,
+	"E":          KeyEditPlan,
+	"Q":          KeyQuitAndKill,
+	"shift+up":   KeyMoveUp,
+	"shift+down": KeyMoveDown,
+	"u":          KeyUndo,
+	"]":          KeyNextNotification,
+	"f":          KeyToggleFollow,
+	"z":          KeyToggleCollapseAll,
+	"V":          KeyOverview,
+	"C":          KeyPauseAll,
+	"R":          KeyResumeAll,
+	",":          KeySettings,
+	"G":          KeyToggleGrouping,
 	"y":          KeySendYes,
 	" ":          KeySpace,
 	"space":      KeySpace, // msg.String() returns "space" for tea.KeySpace with Text=" "
@@ -111,6 +168,49 @@ var GlobalKeyStringsMap = map[string]KeyName{
 	"#":          KeyTabInfo,
 }
 
+// VimKeyStringsMap overlays GlobalKeyStringsMap when vim mode is enabled,
+// mapping h/j/k/l onto the arrow-key navigation actions. Kill moves from k
+// to x, vim's delete.
+var VimKeyStringsMap = map[string]KeyName{
+	"h": KeyArrowLeft,
+	"j": KeyDown,
+	"k": KeyUp,
+	"l": KeyArrowRight,
+	"x": KeyKill,
+}
+
+// VimKeyBindings overrides GlobalkeyBindings in vim mode for actions whose
+// key VimKeyStringsMap moves.
+var VimKeyBindings = map[KeyName]key.Binding{
+	KeyKill: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "kill"),
+	),
+}
+
+// Binding returns the key binding for name, consulting VimKeyBindings first
+// when vim is set.
+func Binding(name KeyName, vim bool) key.Binding {
+	if vim {
+		if b, ok := VimKeyBindings[name]; ok {
+			return b
+		}
+	}
+	return GlobalkeyBindings[name]
+}
+
+// Lookup resolves a key string to its KeyName, consulting VimKeyStringsMap
+// first when vim is set.
+func Lookup(s string, vim bool) (KeyName, bool) {
+	if vim {
+		if name, ok := VimKeyStringsMap[s]; ok {
+			return name, true
+		}
+	}
+	name, ok := GlobalKeyStringsMap[s]
+	return name, ok
+}
+
 // GlobalkeyBindings is a global, immutable map of KeyName tot keybinding.
 var GlobalkeyBindings = map[KeyName]key.Binding{
 	KeyUp: key.NewBinding(
@@ -125,6 +225,10 @@ var GlobalkeyBindings = map[KeyName]key.Binding{
 		key.WithKeys("enter", "o"),
 		key.WithHelp("↵/o", "select"),
 	),
+	KeyAttachReadonly: key.NewBinding(
+		key.WithKeys("O"),
+		key.WithHelp("O", "attach read-only"),
+	),
 	KeyKill: key.NewBinding(
 		key.WithKeys("k"),
 		key.WithHelp("k", "kill"),
@@ -241,6 +345,82 @@ var GlobalkeyBindings = map[KeyName]key.Binding{
 		key.WithKeys("ctrl+enter"),
 		key.WithHelp("ctrl+↵", "submit + exit"),
 	),
+	KeyExportDiff: key.NewBinding(
+		key.WithKeys("D"),
+		key.WithHelp("D", "export diff"),
+	),
+	KeyCopyPlan: key.NewBinding(
+		key.WithKeys("Y"),
+		key.WithHelp("Y", "copy plan"),
+	),
+	KeyCopyBranch: key.NewBinding(
+		key.WithKeys("B"),
+		key.WithHelp("B", "copy branch name"),
+	),
+	KeyBroadcastPrompt: key.NewBinding(
+		key.WithKeys("I"),
+		key.WithHelp("I", "broadcast prompt"),
+	),
+	KeyGlobalSearch: key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "search plan contents"),
+	),
+	KeyEditPlan: key.NewBinding(
+		key.WithKeys("E"),
+		key.WithHelp("E", "edit plan"),
+	),
+	KeyQuitAndKill: key.NewBinding(
+		key.WithKeys("Q"),
+		key.WithHelp("Q", "quit and stop sessions"),
+	),
+	KeyMoveUp: key.NewBinding(
+		key.WithKeys("shift+up"),
+		key.WithHelp("shift+↑", "move up"),
+	),
+	KeyMoveDown: key.NewBinding(
+		key.WithKeys("shift+down"),
+		key.WithHelp("shift+↓", "move down"),
+	),
+	KeyUndo: key.NewBinding(
+		key.WithKeys("u"),
+		key.WithHelp("u", "undo remove"),
+	),
+	KeyNextNotification: key.NewBinding(
+		key.WithKeys("]"),
+		key.WithHelp("]", "next notification"),
+	),
+	KeyToggleFollow: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "follow output"),
+	),
+	KeyToggleCollapseAll: key.NewBinding(
+		key.WithKeys("z"),
+		key.WithHelp("z", "collapse/expand all"),
+	),
+	KeyOverview: key.NewBinding(
+		key.WithKeys("V"),
+		key.WithHelp("V", "overview"),
+	),
+	KeyPauseAll: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "pause all"),
+	),
+	KeyResumeAll: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "resume all"),
+	),
+	KeySettings: key.NewBinding(
+		key.WithKeys(","),
+		key.WithHelp(",", "settings"),
+	),
+	KeyToggleGrouping: key.NewBinding(
+		key.WithKeys("G"),
+		key.WithHelp("G", "group by agent type"),
+	),
+	KeyToggleDraft: key.NewBinding(
+		key.WithKeys("ctrl+d"),
+		key.WithHelp("ctrl+d", "toggle draft PR"),
+	),
 
 	KeySpaceExpand: key.NewBinding(
 		key.WithKeys(" "),
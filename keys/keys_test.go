@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGlobalKeyStringsMap_ViewPlanHasPAlias(t *testing.T) {
@@ -48,3 +49,58 @@ func TestGlobalKeyBindings_YesLabel(t *testing.T) {
 		t.Fatalf("KeySendYes help desc = %q, want %q", got, "yes")
 	}
 }
+
+func TestAttachReadonlyKeyInGlobalMap(t *testing.T) {
+	assert.Equal(t, KeyAttachReadonly, GlobalKeyStringsMap["O"])
+	assert.Equal(t, KeyEnter, GlobalKeyStringsMap["o"], "lowercase o must stay a regular attach")
+	assert.Equal(t, "attach read-only", GlobalkeyBindings[KeyAttachReadonly].Help().Desc)
+}
+
+func TestToggleDraftKeyNotInGlobalMap(t *testing.T) {
+	_, ok := GlobalKeyStringsMap["ctrl+d"]
+	assert.False(t, ok, "ctrl+d is only handled inside the PR overlays")
+	assert.Equal(t, []string{"ctrl+d"}, GlobalkeyBindings[KeyToggleDraft].Keys())
+}
+
+func TestLookup_VimMode(t *testing.T) {
+	name, ok := Lookup("k", false)
+	require.True(t, ok)
+	assert.Equal(t, KeyKill, name)
+	_, ok = Lookup("j", false)
+	assert.False(t, ok)
+
+	for s, want := range map[string]KeyName{"h": KeyArrowLeft, "j": KeyDown, "k": KeyUp, "l": KeyArrowRight} {
+		name, ok := Lookup(s, true)
+		require.True(t, ok, s)
+		assert.Equal(t, want, name, s)
+	}
+	name, ok = Lookup("i", true)
+	require.True(t, ok)
+	assert.Equal(t, KeySendPrompt, name)
+
+	name, ok = Lookup("x", true)
+	require.True(t, ok)
+	assert.Equal(t, KeyKill, name, "vim mode moves kill to x")
+	assert.Equal(t, "x", Binding(KeyKill, true).Help().Key)
+	assert.Equal(t, "k", Binding(KeyKill, false).Help().Key)
+	assert.Equal(t, GlobalkeyBindings[KeyQuit].Help(), Binding(KeyQuit, true).Help())
+}
+
+func TestExportDiffKeyInGlobalMap(t *testing.T) {
+	assert.Equal(t, KeyExportDiff, GlobalKeyStringsMap["D"])
+	assert.Equal(t, "export diff", GlobalkeyBindings[KeyExportDiff].Help().Desc)
+	assert.Equal(t, KeyBroadcastPrompt, GlobalKeyStringsMap["I"])
+	assert.Equal(t, "broadcast prompt", GlobalkeyBindings[KeyBroadcastPrompt].Help().Desc)
+	assert.Equal(t, KeyGlobalSearch, GlobalKeyStringsMap["F"])
+	assert.Equal(t, "search plan contents", GlobalkeyBindings[KeyGlobalSearch].Help().Desc)
+	assert.Equal(t, KeyEditPlan, GlobalKeyStringsMap["E"])
+	assert.Equal(t, "edit plan", GlobalkeyBindings[KeyEditPlan].Help().Desc)
+	assert.Equal(t, KeyQuitAndKill, GlobalKeyStringsMap["Q"])
+	assert.Equal(t, KeyMoveUp, GlobalKeyStringsMap["shift+up"])
+	assert.Equal(t, KeyMoveDown, GlobalKeyStringsMap["shift+down"])
+	assert.Equal(t, KeyUndo, GlobalKeyStringsMap["u"])
+	assert.Equal(t, KeyNextNotification, GlobalKeyStringsMap["]"])
+	assert.Equal(t, KeyToggleFollow, GlobalKeyStringsMap["f"])
+	assert.Equal(t, KeyToggleCollapseAll, GlobalKeyStringsMap["z"])
+	assert.Equal(t, KeyOverview, GlobalKeyStringsMap["V"])
+}
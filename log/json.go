@@ -0,0 +1,53 @@
+package log
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// jsonLine is one line of JSON-formatted log output.
+type jsonLine struct {
+	TS     string `json:"ts"`
+	Level  string `json:"level"`
+	Msg    string `json:"msg"`
+	Caller string `json:"caller,omitempty"`
+	Daemon bool   `json:"daemon,omitempty"`
+}
+
+// jsonMu serialises writes from the three loggers sharing the log file.
+var jsonMu sync.Mutex
+
+// jsonWriter re-encodes the lines of a log.Logger using log.Lshortfile and no
+// prefix as JSON objects, one per line.
+type jsonWriter struct {
+	w      io.Writer
+	level  string
+	daemon bool
+}
+
+func (j *jsonWriter) Write(p []byte) (int, error) {
+	msg := strings.TrimSuffix(string(p), "\n")
+	line := jsonLine{
+		TS:     time.Now().UTC().Format(time.RFC3339Nano),
+		Level:  j.level,
+		Msg:    msg,
+		Daemon: j.daemon,
+	}
+	// log.Lshortfile renders "file.go:123: message".
+	if caller, rest, ok := strings.Cut(msg, ": "); ok && strings.Contains(caller, ".go:") {
+		line.Caller, line.Msg = caller, rest
+	}
+	b, err := json.Marshal(line)
+	if err != nil {
+		return 0, err
+	}
+	jsonMu.Lock()
+	defer jsonMu.Unlock()
+	if _, err := j.w.Write(append(b, '\n')); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
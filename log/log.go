@@ -21,6 +21,24 @@ var logFileName = filepath.Join(os.TempDir(), "kas.log")
 
 var globalLogFile *os.File
 
+// Log line formats accepted by SetFormat.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// logFormat is the format Initialize sets up; see SetFormat.
+var logFormat = FormatText
+
+// SetFormat selects the log line format for the next Initialize call.
+// Unknown formats fall back to FormatText.
+func SetFormat(format string) {
+	if format != FormatJSON {
+		format = FormatText
+	}
+	logFormat = format
+}
+
 // Initialize should be called once at the beginning of the program to set up logging.
 // defer Close() after calling this function. It sets the go log output to the file in
 // the os temp directory.
@@ -39,17 +57,28 @@ func Initialize(daemon bool, telemetryEnabled ...bool) {
 		fmtS = "[DAEMON] %s"
 	}
 
-	useTelemetry := len(telemetryEnabled) > 0 && telemetryEnabled[0]
 	var infoW, warnW, errW io.Writer = f, f, f
+	infoP, warnP, errP := fmt.Sprintf(fmtS, "INFO:"), fmt.Sprintf(fmtS, "WARNING:"), fmt.Sprintf(fmtS, "ERROR:")
+	flags := log.Ldate | log.Ltime | log.Lshortfile
+	if logFormat == FormatJSON {
+		// The JSON writers add the timestamp and level themselves.
+		infoW = &jsonWriter{w: f, level: "info", daemon: daemon}
+		warnW = &jsonWriter{w: f, level: "warning", daemon: daemon}
+		errW = &jsonWriter{w: f, level: "error", daemon: daemon}
+		infoP, warnP, errP = "", "", ""
+		flags = log.Lshortfile
+	}
+
+	useTelemetry := len(telemetryEnabled) > 0 && telemetryEnabled[0]
 	if useTelemetry && sentrypkg.IsEnabled() {
-		infoW = sentrypkg.NewWriter(f, sentrypkg.LevelInfo)
-		warnW = sentrypkg.NewWriter(f, sentrypkg.LevelWarning)
-		errW = sentrypkg.NewWriter(f, sentrypkg.LevelError)
+		infoW = sentrypkg.NewWriter(infoW, sentrypkg.LevelInfo)
+		warnW = sentrypkg.NewWriter(warnW, sentrypkg.LevelWarning)
+		errW = sentrypkg.NewWriter(errW, sentrypkg.LevelError)
 	}
 
-	InfoLog = log.New(infoW, fmt.Sprintf(fmtS, "INFO:"), log.Ldate|log.Ltime|log.Lshortfile)
-	WarningLog = log.New(warnW, fmt.Sprintf(fmtS, "WARNING:"), log.Ldate|log.Ltime|log.Lshortfile)
-	ErrorLog = log.New(errW, fmt.Sprintf(fmtS, "ERROR:"), log.Ldate|log.Ltime|log.Lshortfile)
+	InfoLog = log.New(infoW, infoP, flags)
+	WarningLog = log.New(warnW, warnP, flags)
+	ErrorLog = log.New(errW, errP, flags)
 
 	globalLogFile = f
 }
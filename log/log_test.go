@@ -0,0 +1,51 @@
+package log
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitialize_JSONFormat(t *testing.T) {
+	origFile := logFileName
+	logFileName = filepath.Join(t.TempDir(), "kas.log")
+	t.Cleanup(func() {
+		logFileName = origFile
+		SetFormat(FormatText)
+	})
+
+	SetFormat(FormatJSON)
+	Initialize(true)
+	InfoLog.Printf("started %d agents", 3)
+	ErrorLog.Print("tmux: session not found")
+	Close()
+
+	raw, err := os.ReadFile(logFileName)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(raw)), "\n")
+	require.Len(t, lines, 2)
+
+	var info, errLine map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &info))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &errLine))
+
+	assert.Equal(t, "info", info["level"])
+	assert.Equal(t, "started 3 agents", info["msg"])
+	assert.NotEmpty(t, info["ts"])
+	assert.Contains(t, info["caller"], "log_test.go:")
+	assert.Equal(t, true, info["daemon"])
+
+	assert.Equal(t, "error", errLine["level"])
+	assert.Equal(t, "tmux: session not found", errLine["msg"], "colons in the message are kept")
+}
+
+func TestSetFormat_UnknownFallsBackToText(t *testing.T) {
+	t.Cleanup(func() { SetFormat(FormatText) })
+	SetFormat("yaml")
+	assert.Equal(t, FormatText, logFormat)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/kastheco/kasmos/app"
 	cmd2 "github.com/kastheco/kasmos/cmd"
 	"github.com/kastheco/kasmos/config"
+	"github.com/kastheco/kasmos/config/taskfsm"
 	"github.com/kastheco/kasmos/daemon"
 	initcmd "github.com/kastheco/kasmos/internal/initcmd"
 	sentrypkg "github.com/kastheco/kasmos/internal/sentry"
@@ -20,6 +21,7 @@ import (
 	"github.com/kastheco/kasmos/session"
 	"github.com/kastheco/kasmos/session/git"
 	"github.com/kastheco/kasmos/session/tmux"
+	"github.com/kastheco/kasmos/ui"
 	"github.com/spf13/cobra"
 )
 
@@ -27,6 +29,7 @@ var (
 	version              = "2.0.0-alpha"
 	commitHash           = ""
 	programFlag          string
+	reviewerProgramFlag  string
 	autoYesFlag          bool
 	daemonFlag           bool
 	daemonForegroundFlag bool
@@ -45,9 +48,13 @@ var (
 			defer sentrypkg.Flush()
 			defer sentrypkg.RecoverPanic()
 
+			log.SetFormat(cfg.LogFormat)
 			log.Initialize(daemonFlag, cfg.IsTelemetryEnabled())
 			defer log.Close()
 
+			// Scope session naming and discovery to this checkout's prefix.
+			tmux.SetPrefix(cfg.TmuxPrefix)
+
 			// New multi-repo daemon foreground mode: started by `kas daemon start --foreground`
 			// which re-execs the binary with this hidden flag.
 			if daemonForegroundFlag {
@@ -68,6 +75,7 @@ var (
 
 			if daemonFlag {
 				session.NotificationsEnabled = cfg.AreNotificationsEnabled()
+				session.SetNotifiers(session.NotifiersFromConfig(cfg)...)
 				if err := daemon.RunDaemon(cfg); err != nil {
 					log.ErrorLog.Printf("failed to start daemon: %v", err)
 					return err
@@ -86,6 +94,9 @@ var (
 			}
 
 			session.NotificationsEnabled = cfg.AreNotificationsEnabled()
+			session.SetNotifiers(session.NotifiersFromConfig(cfg)...)
+			taskfsm.DefaultNotifyFunc = session.SendNotification
+			ui.ApplyTheme(ui.ResolveTheme(cfg.Theme))
 
 			// Program flag overrides config
 			program := cfg.DefaultProgram
@@ -112,7 +123,7 @@ var (
 				log.ErrorLog.Printf("failed to stop daemon: %v", err)
 			}
 
-			return app.Run(ctx, program, autoYes, versionString())
+			return app.Run(ctx, program, reviewerProgramFlag, autoYes, versionString())
 		},
 	}
 
@@ -133,6 +144,7 @@ var (
 			}
 			fmt.Println("Storage has been reset successfully")
 
+			tmux.SetPrefix(config.LoadConfig().TmuxPrefix)
 			if err := tmux.CleanupSessions(cmd2.MakeExecutor()); err != nil {
 				return fmt.Errorf("failed to cleanup tmux sessions: %w", err)
 			}
@@ -200,9 +212,37 @@ func versionString() string {
 	return v + "-" + short
 }
 
+// resumeInstance backs cmd.ResumeInstance: it restores a paused instance the
+// way the TUI does, so plan agents rejoin their plan's shared worktree.
+func resumeInstance(raw json.RawMessage) (string, error) {
+	var data session.InstanceData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return "", fmt.Errorf("parse instance: %w", err)
+	}
+	if data.Worktree.WorktreePath == "" {
+		// `kas instance kill` clears the path; plan agents live in the plan's
+		// worktree, anything else gets the instance path back as before.
+		data.Worktree.WorktreePath = data.Path
+		if data.TaskFile != "" && !data.SoloAgent {
+			data.Worktree.WorktreePath = git.TaskWorktreePath(data.Worktree.RepoPath, data.Worktree.BranchName)
+		}
+	}
+	inst, err := session.FromInstanceData(data)
+	if err != nil {
+		return "", fmt.Errorf("restore instance: %w", err)
+	}
+	if err := inst.Resume(); err != nil {
+		return "", err
+	}
+	return inst.GetWorktreePath(), nil
+}
+
 func init() {
+	cmd2.ResumeInstance = resumeInstance
 	rootCmd.Flags().StringVarP(&programFlag, "program", "p", "",
 		"Program to run in new instances (e.g. 'aider --model ollama_chat/gemma3:1b')")
+	rootCmd.Flags().StringVar(&reviewerProgramFlag, "reviewer-program", "",
+		"Program to run for reviewer agents, overriding the quality_review profile")
 	rootCmd.Flags().BoolVarP(&autoYesFlag, "autoyes", "y", false,
 		"[experimental] If enabled, all instances will automatically accept prompts")
 	rootCmd.Flags().BoolVar(&daemonFlag, "daemon", false, "Run a program that loads all sessions"+
@@ -265,11 +305,14 @@ func init() {
 	rootCmd.AddCommand(cmd2.NewDaemonCmd())
 	rootCmd.AddCommand(cmd2.NewMonitorCmd())
 	rootCmd.AddCommand(cmd2.NewStatusCmd())
+	rootCmd.AddCommand(cmd2.NewConfigCmd())
+	rootCmd.AddCommand(cmd2.NewResumeCmd())
+	rootCmd.AddCommand(cmd2.NewPermissionsCmd())
 }
 
 func main() {
 	if err := rootCmd.Execute(); err != nil {
-		if errors.Is(err, errUnhealthy) {
+		if errors.Is(err, errUnhealthy) || errors.Is(err, cmd2.ErrConfigInvalid) {
 			os.Exit(1)
 		}
 		fmt.Println(err)
@@ -2,6 +2,7 @@ package orchestration
 
 import (
 	"sort"
+	"time"
 
 	"github.com/kastheco/kasmos/config/taskparser"
 	"github.com/kastheco/kasmos/config/taskstore"
@@ -34,11 +35,21 @@ type WaveOrchestrator struct {
 	plan              *taskparser.Plan
 	store             taskstore.Store
 	project           string
+	stateDir          string // directory for SaveWaveState snapshots; empty disables
 	architectMeta     *ArchitectMeta
 	state             WaveState
 	currentWave       int                // 0-indexed into plan.Waves
 	taskStates        map[int]taskStatus // task number → status
+	taskStartedAt     map[int]time.Time  // task number → time it was last spawned
+	timedOut          map[int]bool       // task numbers failed by TaskTimeout
 	waitingForConfirm bool               // true once we've shown the wave-complete dialog
+
+	// MaxConcurrentTasks caps how many tasks of a wave run at once. Tasks beyond
+	// the cap stay pending until a running task resolves. 0 means unlimited.
+	MaxConcurrentTasks int
+	// TaskTimeout is how long a task may run before TimedOutTasks reports it as
+	// hung. 0 disables the timeout.
+	TaskTimeout time.Duration
 }
 
 // FileConflict represents a file modified by multiple tasks in the same wave.
@@ -64,10 +75,12 @@ func ShouldBlueprintSkip(plan *taskparser.Plan, threshold int) bool {
 // NewWaveOrchestrator creates an orchestrator for the given plan.
 func NewWaveOrchestrator(planFile string, plan *taskparser.Plan) *WaveOrchestrator {
 	return &WaveOrchestrator{
-		taskFile:   planFile,
-		plan:       plan,
-		state:      WaveStateIdle,
-		taskStates: make(map[int]taskStatus),
+		taskFile:      planFile,
+		plan:          plan,
+		state:         WaveStateIdle,
+		taskStates:    make(map[int]taskStatus),
+		taskStartedAt: make(map[int]time.Time),
+		timedOut:      make(map[int]bool),
 	}
 }
 
@@ -111,6 +124,15 @@ func (o *WaveOrchestrator) CurrentWaveTasks() []taskparser.Task {
 	return o.plan.Waves[o.currentWave].Tasks
 }
 
+// CurrentWaveModel returns the model override declared for the current wave,
+// or "" when the wave inherits the coder profile default.
+func (o *WaveOrchestrator) CurrentWaveModel() string {
+	if o.currentWave >= len(o.plan.Waves) {
+		return ""
+	}
+	return o.plan.Waves[o.currentWave].Model
+}
+
 // Plan returns the current plan held by the orchestrator.
 func (o *WaveOrchestrator) Plan() *taskparser.Plan {
 	return o.plan
@@ -122,10 +144,19 @@ func (o *WaveOrchestrator) SetStore(store taskstore.Store, project string) {
 	o.project = project
 }
 
+// SetStateDir enables best-effort snapshotting of the orchestrator state to
+// dir/<plan-file>.json after every mutation, so LoadWaveState can restore it
+// exactly after a restart.
+func (o *WaveOrchestrator) SetStateDir(dir string) {
+	o.stateDir = dir
+	o.persistWaveState()
+}
+
 // SetElaborating puts the orchestrator into the elaborating state.
 // StartNextWave is blocked until UpdatePlan is called.
 func (o *WaveOrchestrator) SetElaborating() {
 	o.state = WaveStateElaborating
+	o.persistWaveState()
 }
 
 // UpdatePlan replaces the plan with an elaborated version and resets the
@@ -136,6 +167,9 @@ func (o *WaveOrchestrator) UpdatePlan(plan *taskparser.Plan) {
 	o.state = WaveStateIdle
 	o.currentWave = 0
 	o.taskStates = make(map[int]taskStatus)
+	o.taskStartedAt = make(map[int]time.Time)
+	o.timedOut = make(map[int]bool)
+	o.persistWaveState()
 }
 
 // StartNextWave advances to the next wave and returns its tasks.
@@ -153,28 +187,77 @@ func (o *WaveOrchestrator) StartNextWave() []taskparser.Task {
 	}
 	if o.currentWave >= len(o.plan.Waves) {
 		o.state = WaveStateAllComplete
+		o.persistWaveState()
 		return nil
 	}
 
 	o.state = WaveStateRunning
-	tasks := o.plan.Waves[o.currentWave].Tasks
-	for _, t := range tasks {
+	for _, t := range o.plan.Waves[o.currentWave].Tasks {
+		o.taskStates[t.Number] = taskPending
+		o.persistTaskStatus(t.Number, taskstore.SubtaskStatusPending)
+	}
+	return o.DequeueTasks()
+}
+
+// DequeueTasks promotes pending tasks in the current wave to running, up to
+// MaxConcurrentTasks running at once, and returns the promoted tasks in plan
+// order. A task is only promoted once every task in its DependsOn list has
+// completed; tasks whose dependencies failed are marked failed instead.
+// Returns nil when nothing is eligible or no slots are free.
+func (o *WaveOrchestrator) DequeueTasks() []taskparser.Task {
+	if o.state != WaveStateRunning || o.currentWave >= len(o.plan.Waves) {
+		return nil
+	}
+	defer o.persistWaveState()
+	o.failBlockedTasks()
+	if o.state != WaveStateRunning {
+		return nil
+	}
+	slots := -1
+	if o.MaxConcurrentTasks > 0 {
+		slots = o.MaxConcurrentTasks - o.countCurrentWaveByStatus(taskRunning)
+		if slots <= 0 {
+			return nil
+		}
+	}
+	var tasks []taskparser.Task
+	for _, t := range o.plan.Waves[o.currentWave].Tasks {
+		if slots == 0 {
+			break
+		}
+		if o.taskStates[t.Number] != taskPending || !o.dependenciesMet(t) {
+			continue
+		}
 		o.taskStates[t.Number] = taskRunning
+		o.taskStartedAt[t.Number] = time.Now()
 		o.persistTaskStatus(t.Number, taskstore.SubtaskStatusRunning)
+		tasks = append(tasks, t)
+		slots--
 	}
 	return tasks
 }
 
-// MarkTaskComplete marks a task as successfully completed.
+// QueuedTaskCount returns the number of tasks in the current wave waiting for
+// a free concurrency slot.
+func (o *WaveOrchestrator) QueuedTaskCount() int {
+	if o.state != WaveStateRunning {
+		return 0
+	}
+	return o.countCurrentWaveByStatus(taskPending)
+}
+
+// MarkTaskComplete marks a running or queued task as successfully completed.
 // If all tasks in the current wave are done, transitions state.
 // Idempotent: calling again on an already-resolved task is a no-op.
 func (o *WaveOrchestrator) MarkTaskComplete(taskNumber int) {
-	if o.taskStates[taskNumber] != taskRunning {
+	s, ok := o.taskStates[taskNumber]
+	if !ok || (s != taskRunning && s != taskPending) {
 		return
 	}
 	o.taskStates[taskNumber] = taskComplete
 	o.persistTaskStatus(taskNumber, taskstore.SubtaskStatusComplete)
 	o.checkWaveComplete()
+	o.persistWaveState()
 }
 
 // MarkTaskFailed marks a task as failed.
@@ -186,7 +269,56 @@ func (o *WaveOrchestrator) MarkTaskFailed(taskNumber int) {
 	}
 	o.taskStates[taskNumber] = taskFailed
 	o.persistTaskStatus(taskNumber, taskstore.SubtaskStatusFailed)
+	o.failBlockedTasks()
 	o.checkWaveComplete()
+	o.persistWaveState()
+}
+
+// TimedOutTasks returns the numbers of running tasks in the current wave that
+// were spawned more than TaskTimeout before now. Returns nil when the timeout
+// is disabled.
+func (o *WaveOrchestrator) TimedOutTasks(now time.Time) []int {
+	if o.TaskTimeout <= 0 || o.currentWave >= len(o.plan.Waves) {
+		return nil
+	}
+	var hung []int
+	for _, t := range o.plan.Waves[o.currentWave].Tasks {
+		if o.taskStates[t.Number] != taskRunning {
+			continue
+		}
+		startedAt, ok := o.taskStartedAt[t.Number]
+		if ok && now.Sub(startedAt) > o.TaskTimeout {
+			hung = append(hung, t.Number)
+		}
+	}
+	return hung
+}
+
+// MarkTaskTimedOut marks a running task as failed because it exceeded
+// TaskTimeout. The task is remembered so RetryTimedOutTasks can retry it alone.
+func (o *WaveOrchestrator) MarkTaskTimedOut(taskNumber int) {
+	if o.taskStates[taskNumber] != taskRunning {
+		return
+	}
+	o.timedOut[taskNumber] = true
+	o.MarkTaskFailed(taskNumber)
+}
+
+// IsTaskTimedOut returns true if the given task failed by exceeding TaskTimeout.
+func (o *WaveOrchestrator) IsTaskTimedOut(taskNumber int) bool {
+	return o.taskStates[taskNumber] == taskFailed && o.timedOut[taskNumber]
+}
+
+// TimedOutTaskCount returns the number of tasks in the current wave that
+// failed by exceeding TaskTimeout.
+func (o *WaveOrchestrator) TimedOutTaskCount() int {
+	count := 0
+	for _, t := range o.CurrentWaveTasks() {
+		if o.IsTaskTimedOut(t.Number) {
+			count++
+		}
+	}
+	return count
 }
 
 // NeedsConfirm returns true if the wave just completed and the user hasn't
@@ -206,26 +338,70 @@ func (o *WaveOrchestrator) ResetConfirm() {
 	o.waitingForConfirm = false
 }
 
-// RetryFailedTasks transitions all failed tasks in the current wave back to running
-// and sets the orchestrator state to WaveStateRunning. Returns the tasks that were
-// retried (previously failed). Returns nil if there are no failed tasks to retry.
+// RetryFailedTasks requeues all failed tasks in the current wave and sets the
+// orchestrator state to WaveStateRunning. Returns the tasks that start running
+// immediately; the rest stay queued behind MaxConcurrentTasks and are returned
+// by later DequeueTasks calls. Returns nil if there are no failed tasks to retry.
 func (o *WaveOrchestrator) RetryFailedTasks() []taskparser.Task {
+	return o.retryTasks(func(t taskparser.Task) bool {
+		return o.taskStates[t.Number] == taskFailed
+	})
+}
+
+// RetryTimedOutTasks requeues only the tasks in the current wave that failed by
+// exceeding TaskTimeout, along with tasks that failed because they depend on
+// one of them. Other failures are left untouched. Returns the tasks that start
+// running immediately, or nil if no task timed out.
+func (o *WaveOrchestrator) RetryTimedOutTasks() []taskparser.Task {
+	retry := make(map[int]bool)
+	for changed := true; changed; {
+		changed = false
+		for _, t := range o.CurrentWaveTasks() {
+			if retry[t.Number] || o.taskStates[t.Number] != taskFailed {
+				continue
+			}
+			if o.timedOut[t.Number] || dependsOnAny(t, retry) {
+				retry[t.Number] = true
+				changed = true
+			}
+		}
+	}
+	return o.retryTasks(func(t taskparser.Task) bool { return retry[t.Number] })
+}
+
+// RetryTask requeues a single failed task in the current wave, leaving every
+// other task's state untouched (tasks that failed because they depend on it
+// stay failed). Returns the task if it starts running immediately, or nil if
+// it is not a failed task of the current wave or is queued behind
+// MaxConcurrentTasks.
+func (o *WaveOrchestrator) RetryTask(taskNumber int) []taskparser.Task {
+	return o.retryTasks(func(t taskparser.Task) bool {
+		return t.Number == taskNumber && o.taskStates[t.Number] == taskFailed
+	})
+}
+
+// retryTasks requeues the current-wave tasks selected by want and dequeues as
+// many as the concurrency limit allows.
+func (o *WaveOrchestrator) retryTasks(want func(taskparser.Task) bool) []taskparser.Task {
 	if o.currentWave >= len(o.plan.Waves) {
 		return nil
 	}
-	var tasks []taskparser.Task
+	requeued := 0
 	for _, t := range o.plan.Waves[o.currentWave].Tasks {
-		if o.taskStates[t.Number] == taskFailed {
-			o.taskStates[t.Number] = taskRunning
-			o.persistTaskStatus(t.Number, taskstore.SubtaskStatusRunning)
-			tasks = append(tasks, t)
+		if !want(t) {
+			continue
 		}
+		o.taskStates[t.Number] = taskPending
+		delete(o.timedOut, t.Number)
+		o.persistTaskStatus(t.Number, taskstore.SubtaskStatusPending)
+		requeued++
 	}
-	if len(tasks) > 0 {
-		o.state = WaveStateRunning
-		o.waitingForConfirm = false
+	if requeued == 0 {
+		return nil
 	}
-	return tasks
+	o.state = WaveStateRunning
+	o.waitingForConfirm = false
+	return o.DequeueTasks()
 }
 
 // IsCurrentWaveComplete returns true if all tasks in the current wave have resolved.
@@ -244,7 +420,7 @@ func (o *WaveOrchestrator) FailedTaskCount() int {
 }
 
 // IsTaskRunning returns true if the given task number is currently in the running state.
-// Used to gate the "Mark complete" context menu action.
+// Used to gate the "mark task complete" context menu action.
 func (o *WaveOrchestrator) IsTaskRunning(taskNumber int) bool {
 	return o.taskStates[taskNumber] == taskRunning
 }
@@ -427,6 +603,76 @@ func (o *WaveOrchestrator) checkWaveComplete() {
 	}
 }
 
+// dependenciesMet reports whether every dependency of t has completed.
+// Dependencies with no recorded state (unknown task numbers) are ignored.
+func (o *WaveOrchestrator) dependenciesMet(t taskparser.Task) bool {
+	for _, dep := range t.DependsOn {
+		if s, ok := o.taskStates[dep]; ok && s != taskComplete {
+			return false
+		}
+	}
+	return true
+}
+
+// dependsOnAny reports whether t depends on any task number in set.
+func dependsOnAny(t taskparser.Task, set map[int]bool) bool {
+	for _, dep := range t.DependsOn {
+		if set[dep] {
+			return true
+		}
+	}
+	return false
+}
+
+// failBlockedTasks marks pending tasks in the current wave as failed when they
+// can never start: a dependency failed (applied transitively), or nothing is
+// running and every remaining pending task waits on another pending task
+// (a dependency cycle).
+func (o *WaveOrchestrator) failBlockedTasks() {
+	if o.currentWave >= len(o.plan.Waves) {
+		return
+	}
+	tasks := o.plan.Waves[o.currentWave].Tasks
+	for changed := true; changed; {
+		changed = false
+		for _, t := range tasks {
+			if o.taskStates[t.Number] != taskPending {
+				continue
+			}
+			for _, dep := range t.DependsOn {
+				if s, ok := o.taskStates[dep]; ok && s == taskFailed {
+					o.taskStates[t.Number] = taskFailed
+					o.persistTaskStatus(t.Number, taskstore.SubtaskStatusFailed)
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	if o.countCurrentWaveByStatus(taskRunning) > 0 {
+		return
+	}
+	var pending []taskparser.Task
+	for _, t := range tasks {
+		if o.taskStates[t.Number] != taskPending {
+			continue
+		}
+		if o.dependenciesMet(t) {
+			return // at least one task can still make progress
+		}
+		pending = append(pending, t)
+	}
+	if len(pending) == 0 {
+		return
+	}
+	for _, t := range pending {
+		o.taskStates[t.Number] = taskFailed
+		o.persistTaskStatus(t.Number, taskstore.SubtaskStatusFailed)
+	}
+	o.checkWaveComplete()
+}
+
 func (o *WaveOrchestrator) countCurrentWaveByStatus(s taskStatus) int {
 	if o.currentWave >= len(o.plan.Waves) {
 		return 0
@@ -446,3 +692,10 @@ func (o *WaveOrchestrator) persistTaskStatus(taskNumber int, status taskstore.Su
 	}
 	_ = o.store.UpdateSubtaskStatus(o.project, o.taskFile, taskNumber, status)
 }
+
+func (o *WaveOrchestrator) persistWaveState() {
+	if o.stateDir == "" {
+		return
+	}
+	_ = SaveWaveState(o.stateDir, o)
+}
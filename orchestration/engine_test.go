@@ -3,6 +3,7 @@ package orchestration
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/kastheco/kasmos/config/taskparser"
 	"github.com/kastheco/kasmos/config/taskstore"
@@ -286,6 +287,307 @@ func TestWaveOrchestrator_RetryFailedTasksRestoresRunning(t *testing.T) {
 	assert.Equal(t, 0, orch.FailedTaskCount(), "no more failures after retry completes")
 }
 
+func TestWaveOrchestrator_MaxConcurrentTasksQueuesOverflow(t *testing.T) {
+	plan := &taskparser.Plan{
+		Waves: []taskparser.Wave{
+			{Number: 1, Tasks: []taskparser.Task{
+				{Number: 1}, {Number: 2}, {Number: 3}, {Number: 4}, {Number: 5},
+			}},
+		},
+	}
+
+	orch := NewWaveOrchestrator("plan", plan)
+	orch.MaxConcurrentTasks = 2
+
+	started := orch.StartNextWave()
+	require.Len(t, started, 2)
+	assert.Equal(t, 1, started[0].Number)
+	assert.Equal(t, 2, started[1].Number)
+	assert.Equal(t, 3, orch.QueuedTaskCount())
+	assert.False(t, orch.IsTaskRunning(3), "queued task must not be running")
+
+	// No free slot yet — nothing to dequeue.
+	assert.Empty(t, orch.DequeueTasks())
+
+	// T1 completes — exactly one slot frees up.
+	orch.MarkTaskComplete(1)
+	next := orch.DequeueTasks()
+	require.Len(t, next, 1)
+	assert.Equal(t, 3, next[0].Number)
+	assert.Equal(t, 2, orch.QueuedTaskCount())
+
+	// Wave is not complete while tasks are still queued.
+	orch.MarkTaskComplete(2)
+	orch.MarkTaskFailed(3)
+	assert.Equal(t, WaveStateRunning, orch.State())
+
+	next = orch.DequeueTasks()
+	require.Len(t, next, 2)
+	assert.Equal(t, 4, next[0].Number)
+	assert.Equal(t, 5, next[1].Number)
+	assert.Equal(t, 0, orch.QueuedTaskCount())
+
+	orch.MarkTaskComplete(4)
+	orch.MarkTaskComplete(5)
+	assert.Equal(t, WaveStateAllComplete, orch.State())
+}
+
+func TestWaveOrchestrator_RetryFailedTasksRespectsConcurrencyLimit(t *testing.T) {
+	plan := &taskparser.Plan{
+		Waves: []taskparser.Wave{
+			{Number: 1, Tasks: []taskparser.Task{{Number: 1}, {Number: 2}, {Number: 3}}},
+			{Number: 2, Tasks: []taskparser.Task{{Number: 4}}},
+		},
+	}
+
+	orch := NewWaveOrchestrator("plan", plan)
+	orch.StartNextWave()
+	orch.MarkTaskFailed(1)
+	orch.MarkTaskFailed(2)
+	orch.MarkTaskFailed(3)
+	require.Equal(t, WaveStateWaveComplete, orch.State())
+
+	orch.MaxConcurrentTasks = 1
+	retried := orch.RetryFailedTasks()
+	require.Len(t, retried, 1)
+	assert.Equal(t, 1, retried[0].Number)
+	assert.Equal(t, 2, orch.QueuedTaskCount())
+	assert.Equal(t, WaveStateRunning, orch.State())
+
+	orch.MarkTaskComplete(1)
+	next := orch.DequeueTasks()
+	require.Len(t, next, 1)
+	assert.Equal(t, 2, next[0].Number)
+}
+
+// diamondPlan returns a single-wave plan shaped 1 → {2, 3} → 4.
+func diamondPlan() *taskparser.Plan {
+	return &taskparser.Plan{
+		Waves: []taskparser.Wave{
+			{Number: 1, Tasks: []taskparser.Task{
+				{Number: 1, Title: "Root"},
+				{Number: 2, Title: "Left", DependsOn: []int{1}},
+				{Number: 3, Title: "Right", DependsOn: []int{1}},
+				{Number: 4, Title: "Join", DependsOn: []int{2, 3}},
+			}},
+		},
+	}
+}
+
+func TestWaveOrchestrator_DependenciesDiamond(t *testing.T) {
+	orch := NewWaveOrchestrator("plan", diamondPlan())
+
+	started := orch.StartNextWave()
+	require.Len(t, started, 1, "only the root has no dependencies")
+	assert.Equal(t, 1, started[0].Number)
+	assert.Equal(t, 3, orch.QueuedTaskCount())
+
+	orch.MarkTaskComplete(1)
+	next := orch.DequeueTasks()
+	require.Len(t, next, 2, "both branches become eligible once the root completes")
+	assert.Equal(t, 2, next[0].Number)
+	assert.Equal(t, 3, next[1].Number)
+
+	orch.MarkTaskComplete(2)
+	assert.Empty(t, orch.DequeueTasks(), "join must wait for both branches")
+
+	orch.MarkTaskComplete(3)
+	next = orch.DequeueTasks()
+	require.Len(t, next, 1)
+	assert.Equal(t, 4, next[0].Number)
+
+	orch.MarkTaskComplete(4)
+	assert.Equal(t, WaveStateAllComplete, orch.State())
+}
+
+func TestWaveOrchestrator_DependenciesDiamondRespectsConcurrencyLimit(t *testing.T) {
+	orch := NewWaveOrchestrator("plan", diamondPlan())
+	orch.MaxConcurrentTasks = 1
+
+	require.Len(t, orch.StartNextWave(), 1)
+	orch.MarkTaskComplete(1)
+
+	next := orch.DequeueTasks()
+	require.Len(t, next, 1)
+	assert.Equal(t, 2, next[0].Number)
+	assert.Equal(t, 2, orch.QueuedTaskCount())
+}
+
+func TestWaveOrchestrator_DependencyFailurePropagates(t *testing.T) {
+	orch := NewWaveOrchestrator("plan", diamondPlan())
+	orch.StartNextWave()
+
+	orch.MarkTaskComplete(1)
+	require.Len(t, orch.DequeueTasks(), 2)
+
+	// Left branch fails — join is failed transitively without ever starting.
+	orch.MarkTaskFailed(2)
+	assert.True(t, orch.IsTaskFailed(4), "dependent of a failed task must be failed")
+	assert.True(t, orch.IsTaskRunning(3), "independent branch keeps running")
+	assert.Equal(t, WaveStateRunning, orch.State())
+
+	orch.MarkTaskComplete(3)
+	assert.Equal(t, WaveStateAllComplete, orch.State())
+	assert.Equal(t, 2, orch.FailedTaskCount())
+	assert.Equal(t, 2, orch.CompletedTaskCount())
+}
+
+func TestWaveOrchestrator_DependencyFailureIsTransitive(t *testing.T) {
+	orch := NewWaveOrchestrator("plan", diamondPlan())
+	orch.StartNextWave()
+
+	// Root fails — every other task depends on it directly or indirectly.
+	orch.MarkTaskFailed(1)
+	for _, n := range []int{2, 3, 4} {
+		assert.True(t, orch.IsTaskFailed(n), "task %d must fail transitively", n)
+	}
+	assert.Equal(t, WaveStateAllComplete, orch.State())
+
+	// Retrying requeues the whole chain, starting from the root again.
+	retried := orch.RetryFailedTasks()
+	require.Len(t, retried, 1)
+	assert.Equal(t, 1, retried[0].Number)
+	assert.Equal(t, 3, orch.QueuedTaskCount())
+}
+
+func TestWaveOrchestrator_DependencyCycleFailsTasks(t *testing.T) {
+	plan := &taskparser.Plan{
+		Waves: []taskparser.Wave{
+			{Number: 1, Tasks: []taskparser.Task{
+				{Number: 1, DependsOn: []int{2}},
+				{Number: 2, DependsOn: []int{1}},
+			}},
+		},
+	}
+	orch := NewWaveOrchestrator("plan", plan)
+
+	assert.Empty(t, orch.StartNextWave())
+	assert.True(t, orch.IsTaskFailed(1))
+	assert.True(t, orch.IsTaskFailed(2))
+	assert.Equal(t, WaveStateAllComplete, orch.State())
+}
+
+func TestWaveOrchestrator_DependencyOnEarlierWave(t *testing.T) {
+	plan := &taskparser.Plan{
+		Waves: []taskparser.Wave{
+			{Number: 1, Tasks: []taskparser.Task{{Number: 1}}},
+			{Number: 2, Tasks: []taskparser.Task{{Number: 2, DependsOn: []int{1, 99}}}},
+		},
+	}
+	orch := NewWaveOrchestrator("plan", plan)
+	orch.StartNextWave()
+	orch.MarkTaskComplete(1)
+
+	// Completed earlier-wave tasks and unknown task numbers don't block.
+	started := orch.StartNextWave()
+	require.Len(t, started, 1)
+	assert.Equal(t, 2, started[0].Number)
+}
+
+func TestWaveOrchestrator_TaskTimeout(t *testing.T) {
+	plan := &taskparser.Plan{
+		Waves: []taskparser.Wave{
+			{Number: 1, Tasks: []taskparser.Task{{Number: 1}, {Number: 2}}},
+			{Number: 2, Tasks: []taskparser.Task{{Number: 3}}},
+		},
+	}
+	orch := NewWaveOrchestrator("plan", plan)
+	orch.TaskTimeout = 30 * time.Minute
+	orch.StartNextWave()
+
+	now := time.Now()
+	assert.Empty(t, orch.TimedOutTasks(now), "fresh tasks must not time out")
+	assert.Empty(t, orch.TimedOutTasks(now.Add(29*time.Minute)))
+
+	orch.MarkTaskComplete(2)
+
+	// Fast-forward past the timeout — only the still-running task is reported.
+	hung := orch.TimedOutTasks(now.Add(31 * time.Minute))
+	require.Equal(t, []int{1}, hung)
+
+	orch.MarkTaskTimedOut(1)
+	assert.True(t, orch.IsTaskFailed(1))
+	assert.True(t, orch.IsTaskTimedOut(1))
+	assert.False(t, orch.IsTaskTimedOut(2))
+	assert.Equal(t, 1, orch.TimedOutTaskCount())
+	assert.Equal(t, WaveStateWaveComplete, orch.State())
+	assert.Empty(t, orch.TimedOutTasks(now.Add(31*time.Minute)), "failed tasks are not reported again")
+}
+
+func TestWaveOrchestrator_TaskTimeoutDisabled(t *testing.T) {
+	plan := &taskparser.Plan{Waves: []taskparser.Wave{
+		{Number: 1, Tasks: []taskparser.Task{{Number: 1}}},
+	}}
+	orch := NewWaveOrchestrator("plan", plan)
+	orch.StartNextWave()
+
+	assert.Empty(t, orch.TimedOutTasks(time.Now().Add(24*time.Hour)))
+}
+
+func TestWaveOrchestrator_RetryTimedOutTasksOnly(t *testing.T) {
+	plan := &taskparser.Plan{
+		Waves: []taskparser.Wave{
+			{Number: 1, Tasks: []taskparser.Task{
+				{Number: 1},
+				{Number: 2},
+				{Number: 3, DependsOn: []int{1}},
+			}},
+		},
+	}
+	orch := NewWaveOrchestrator("plan", plan)
+	orch.TaskTimeout = time.Minute
+	orch.StartNextWave()
+
+	orch.MarkTaskFailed(2) // ordinary failure
+	for _, n := range orch.TimedOutTasks(time.Now().Add(2 * time.Minute)) {
+		orch.MarkTaskTimedOut(n)
+	}
+	require.True(t, orch.IsTaskTimedOut(1))
+	require.True(t, orch.IsTaskFailed(3), "dependent of timed-out task fails transitively")
+	require.Equal(t, WaveStateAllComplete, orch.State())
+
+	retried := orch.RetryTimedOutTasks()
+	require.Len(t, retried, 1)
+	assert.Equal(t, 1, retried[0].Number)
+	assert.False(t, orch.IsTaskTimedOut(1), "retry clears the timed-out flag")
+	assert.True(t, orch.IsTaskFailed(2), "ordinary failures are not retried")
+	assert.Equal(t, 1, orch.QueuedTaskCount(), "dependent is requeued behind the retried task")
+}
+
+func TestWaveOrchestrator_RetryTaskResetsOnlyTargetedTask(t *testing.T) {
+	plan := &taskparser.Plan{
+		Waves: []taskparser.Wave{
+			{Number: 1, Tasks: []taskparser.Task{
+				{Number: 1},
+				{Number: 2},
+				{Number: 3},
+				{Number: 4, DependsOn: []int{3}},
+			}},
+		},
+	}
+	orch := NewWaveOrchestrator("plan", plan)
+	orch.StartNextWave()
+	orch.MarkTaskFailed(1)
+	orch.MarkTaskComplete(2)
+	orch.MarkTaskFailed(3)
+	require.True(t, orch.IsTaskFailed(4), "dependent of task 3 fails transitively")
+	require.Equal(t, WaveStateAllComplete, orch.State())
+
+	assert.Nil(t, orch.RetryTask(2), "completed tasks are not retried")
+	assert.Nil(t, orch.RetryTask(9), "unknown tasks are not retried")
+	require.Equal(t, WaveStateAllComplete, orch.State())
+
+	retried := orch.RetryTask(3)
+	require.Len(t, retried, 1)
+	assert.Equal(t, 3, retried[0].Number)
+	assert.Equal(t, WaveStateRunning, orch.State())
+	assert.True(t, orch.IsTaskRunning(3))
+	assert.True(t, orch.IsTaskFailed(1), "other failures are untouched")
+	assert.True(t, orch.IsTaskComplete(2))
+	assert.True(t, orch.IsTaskFailed(4), "dependents are not retried")
+	assert.Equal(t, 2, orch.FailedTaskCount(), "task 3 no longer counts as failed")
+}
+
 func TestRestoreToWave(t *testing.T) {
 	plan := &taskparser.Plan{
 		Waves: []taskparser.Wave{
@@ -0,0 +1,140 @@
+package orchestration
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kastheco/kasmos/config/taskparser"
+)
+
+// waveStateFile is the on-disk snapshot of a WaveOrchestrator, written to
+// <dir>/<plan-file>.json after every state mutation.
+type waveStateFile struct {
+	TaskFile    string                   `json:"task_file"`
+	State       WaveState                `json:"state"`
+	CurrentWave int                      `json:"current_wave"`
+	Tasks       map[int]waveStateTaskRec `json:"tasks"`
+}
+
+// waveStateTaskRec is the persisted state of a single task.
+type waveStateTaskRec struct {
+	Status    string    `json:"status"`
+	StartedAt time.Time `json:"started_at,omitempty"`
+	TimedOut  bool      `json:"timed_out,omitempty"`
+}
+
+var taskStatusNames = map[taskStatus]string{
+	taskPending:  "pending",
+	taskRunning:  "running",
+	taskComplete: "complete",
+	taskFailed:   "failed",
+}
+
+func waveStateFilename(planFile string) string {
+	return planFile + ".json"
+}
+
+// SaveWaveState writes a snapshot of orch to dir/<plan-file>.json, creating dir
+// with mode 0755 if it does not exist.
+func SaveWaveState(dir string, orch *WaveOrchestrator) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	snapshot := waveStateFile{
+		TaskFile:    orch.taskFile,
+		State:       orch.state,
+		CurrentWave: orch.currentWave,
+		Tasks:       make(map[int]waveStateTaskRec, len(orch.taskStates)),
+	}
+	for num, status := range orch.taskStates {
+		snapshot.Tasks[num] = waveStateTaskRec{
+			Status:    taskStatusNames[status],
+			StartedAt: orch.taskStartedAt[num],
+			TimedOut:  orch.timedOut[num],
+		}
+	}
+
+	encoded, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	encoded = append(encoded, '\n')
+
+	// Write to a temp file and rename so a crash mid-write never leaves a
+	// truncated snapshot behind.
+	filename := filepath.Join(dir, waveStateFilename(orch.taskFile))
+	tmp := filename + ".tmp"
+	if err := os.WriteFile(tmp, encoded, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filename)
+}
+
+// LoadWaveState reads the snapshot for planFile from dir and rebuilds an
+// orchestrator for plan with the exact task states it was saved with.
+// Returns (nil, nil) when no snapshot exists. Returns an error when the
+// snapshot is unreadable or does not fit the plan (e.g. the plan was edited).
+// The returned orchestrator persists further mutations back to dir.
+func LoadWaveState(dir, planFile string, plan *taskparser.Plan) (*WaveOrchestrator, error) {
+	filename := filepath.Join(dir, waveStateFilename(planFile))
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read wave state: %w", err)
+	}
+
+	var snapshot waveStateFile
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("read wave state: %w", err)
+	}
+	if snapshot.CurrentWave < 0 || snapshot.CurrentWave >= len(plan.Waves) {
+		return nil, fmt.Errorf("read wave state: wave index %d out of range for %d-wave plan",
+			snapshot.CurrentWave, len(plan.Waves))
+	}
+
+	statusByName := make(map[string]taskStatus, len(taskStatusNames))
+	for status, name := range taskStatusNames {
+		statusByName[name] = status
+	}
+
+	orch := NewWaveOrchestrator(planFile, plan)
+	orch.state = snapshot.State
+	orch.currentWave = snapshot.CurrentWave
+	for num, rec := range snapshot.Tasks {
+		status, ok := statusByName[rec.Status]
+		if !ok {
+			return nil, fmt.Errorf("read wave state: task %d has unknown status %q", num, rec.Status)
+		}
+		orch.taskStates[num] = status
+		if !rec.StartedAt.IsZero() {
+			orch.taskStartedAt[num] = rec.StartedAt
+		}
+		if rec.TimedOut {
+			orch.timedOut[num] = true
+		}
+	}
+	for _, t := range plan.Waves[snapshot.CurrentWave].Tasks {
+		if _, ok := orch.taskStates[t.Number]; !ok {
+			return nil, fmt.Errorf("read wave state: task %d missing from snapshot", t.Number)
+		}
+	}
+	orch.stateDir = dir
+	return orch, nil
+}
+
+// RemoveWaveState deletes the snapshot for planFile from dir.
+// A missing snapshot is not an error.
+func RemoveWaveState(dir, planFile string) error {
+	err := os.Remove(filepath.Join(dir, waveStateFilename(planFile)))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
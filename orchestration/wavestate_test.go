@@ -0,0 +1,122 @@
+package orchestration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kastheco/kasmos/config/taskparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func waveStatePlan() *taskparser.Plan {
+	return &taskparser.Plan{
+		Waves: []taskparser.Wave{
+			{Number: 1, Tasks: []taskparser.Task{
+				{Number: 1, Title: "First"},
+				{Number: 2, Title: "Second"},
+				{Number: 3, Title: "Third"},
+				{Number: 4, Title: "Fourth"},
+			}},
+			{Number: 2, Tasks: []taskparser.Task{
+				{Number: 5, Title: "Fifth"},
+			}},
+		},
+	}
+}
+
+func TestWaveState_SavedOnMutationAndRestored(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), ".waves")
+	plan := waveStatePlan()
+
+	orch := NewWaveOrchestrator("plan", plan)
+	orch.SetStateDir(dir)
+	orch.MaxConcurrentTasks = 3
+	orch.TaskTimeout = time.Minute
+
+	started := time.Now().Add(-2 * time.Minute)
+	orch.StartNextWave()
+	for _, num := range []int{1, 2, 3} {
+		orch.taskStartedAt[num] = started
+	}
+	orch.MarkTaskComplete(1)
+	orch.MarkTaskFailed(2)
+	orch.MarkTaskTimedOut(3)
+
+	_, err := os.Stat(filepath.Join(dir, "plan.json"))
+	require.NoError(t, err, "mutations must write the snapshot")
+
+	restored, err := LoadWaveState(dir, "plan", plan)
+	require.NoError(t, err)
+	require.NotNil(t, restored)
+
+	assert.Equal(t, WaveStateRunning, restored.State())
+	assert.Equal(t, 1, restored.CurrentWaveNumber())
+	assert.True(t, restored.IsTaskComplete(1))
+	assert.True(t, restored.IsTaskFailed(2))
+	assert.True(t, restored.IsTaskFailed(3))
+	assert.True(t, restored.IsTaskTimedOut(3))
+	assert.False(t, restored.IsTaskTimedOut(2))
+	assert.Equal(t, taskPending, restored.taskStates[4], "queued task must stay pending, not be treated as failed")
+	assert.True(t, restored.taskStartedAt[1].Equal(started))
+}
+
+func TestWaveState_RestoredOrchestratorKeepsPersisting(t *testing.T) {
+	dir := t.TempDir()
+	plan := waveStatePlan()
+
+	orch := NewWaveOrchestrator("plan", plan)
+	orch.SetStateDir(dir)
+	orch.StartNextWave()
+
+	restored, err := LoadWaveState(dir, "plan", plan)
+	require.NoError(t, err)
+	for _, num := range []int{1, 2, 3, 4} {
+		restored.MarkTaskComplete(num)
+	}
+	require.Equal(t, WaveStateWaveComplete, restored.State())
+
+	again, err := LoadWaveState(dir, "plan", plan)
+	require.NoError(t, err)
+	assert.Equal(t, WaveStateWaveComplete, again.State())
+	assert.Equal(t, 4, again.CompletedTaskCount())
+}
+
+func TestLoadWaveState_MissingSnapshot(t *testing.T) {
+	orch, err := LoadWaveState(t.TempDir(), "plan", waveStatePlan())
+	require.NoError(t, err)
+	assert.Nil(t, orch)
+}
+
+func TestLoadWaveState_RejectsSnapshotForEditedPlan(t *testing.T) {
+	dir := t.TempDir()
+	plan := waveStatePlan()
+
+	orch := NewWaveOrchestrator("plan", plan)
+	orch.SetStateDir(dir)
+	orch.StartNextWave()
+
+	edited := waveStatePlan()
+	edited.Waves[0].Tasks = append(edited.Waves[0].Tasks, taskparser.Task{Number: 6, Title: "New"})
+	_, err := LoadWaveState(dir, "plan", edited)
+	assert.Error(t, err)
+
+	shrunk := &taskparser.Plan{Waves: []taskparser.Wave{}}
+	_, err = LoadWaveState(dir, "plan", shrunk)
+	assert.Error(t, err)
+}
+
+func TestRemoveWaveState(t *testing.T) {
+	dir := t.TempDir()
+	orch := NewWaveOrchestrator("plan", waveStatePlan())
+	orch.SetStateDir(dir)
+	orch.StartNextWave()
+
+	require.NoError(t, RemoveWaveState(dir, "plan"))
+	_, err := os.Stat(filepath.Join(dir, "plan.json"))
+	assert.True(t, os.IsNotExist(err))
+
+	assert.NoError(t, RemoveWaveState(dir, "plan"), "removing a missing snapshot is not an error")
+}
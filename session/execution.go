@@ -45,6 +45,7 @@ type ExecutionSession interface {
 
 	// Attach/Detach
 	Attach() (chan struct{}, error)
+	AttachReadonly() (chan struct{}, error)
 	DetachSafely() error
 	SetDetachedSize(width, height int) error
 
@@ -66,6 +67,18 @@ type progressReporter interface {
 	SetProgressFunc(fn func(int, string))
 }
 
+// recorder is optionally implemented by session types that can record the
+// agent session to a file. Headless sessions already log to disk and skip it.
+type recorder interface {
+	SetRecordingPath(path string)
+}
+
+// containerRunner is optionally implemented by session types that can run the
+// agent program inside a container.
+type containerRunner interface {
+	SetContainer(c tmux.Container)
+}
+
 // NormalizeExecutionMode returns ExecutionModeHeadless when mode is
 // ExecutionModeHeadless (after trimming whitespace), and ExecutionModeTmux for
 // all other values including "".
@@ -125,7 +138,10 @@ func (w *tmuxExecutionSession) GetPanePID() (int, error) { return w.s.GetPanePID
 
 // Attach/Detach
 func (w *tmuxExecutionSession) Attach() (chan struct{}, error) { return w.s.Attach() }
-func (w *tmuxExecutionSession) DetachSafely() error            { return w.s.DetachSafely() }
+func (w *tmuxExecutionSession) AttachReadonly() (chan struct{}, error) {
+	return w.s.AttachReadonly()
+}
+func (w *tmuxExecutionSession) DetachSafely() error { return w.s.DetachSafely() }
 func (w *tmuxExecutionSession) SetDetachedSize(width, height int) error {
 	return w.s.SetDetachedSize(width, height)
 }
@@ -144,6 +160,12 @@ func (w *tmuxExecutionSession) SetTitleFunc(fn func(workDir string, beforeStart
 	w.s.SetTitleFunc(fn)
 }
 
+// SetRecordingPath implements recorder.
+func (w *tmuxExecutionSession) SetRecordingPath(path string) { w.s.SetRecordingPath(path) }
+
+// SetContainer implements containerRunner.
+func (w *tmuxExecutionSession) SetContainer(c tmux.Container) { w.s.SetContainer(c) }
+
 // SetProgressFunc implements progressReporter, allowing the instance layer to
 // inject a progress hook without knowing the concrete TmuxSession type.
 func (w *tmuxExecutionSession) SetProgressFunc(fn func(int, string)) {
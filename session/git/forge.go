@@ -0,0 +1,108 @@
+package git
+
+import (
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strings"
+)
+
+// ForgeType identifies the code-hosting service behind a repository's origin.
+type ForgeType string
+
+const (
+	// ForgeGitHub is the default forge; reviews are opened with `gh`.
+	ForgeGitHub ForgeType = "github"
+	// ForgeGitLab opens merge requests with `glab`.
+	ForgeGitLab ForgeType = "gitlab"
+)
+
+// DetectForge returns the forge for a git remote URL. HTTPS, ssh:// and
+// scp-style (git@host:org/repo.git) URLs are understood. Any host whose name
+// contains "gitlab" (gitlab.com or a self-hosted gitlab.example.com) is
+// GitLab; everything else is treated as GitHub.
+func DetectForge(remoteURL string) ForgeType {
+	if strings.Contains(strings.ToLower(remoteHost(remoteURL)), "gitlab") {
+		return ForgeGitLab
+	}
+	return ForgeGitHub
+}
+
+// remoteHost extracts the host name from a git remote URL, or "" when the URL
+// cannot be parsed.
+func remoteHost(remoteURL string) string {
+	remoteURL = strings.TrimSpace(remoteURL)
+	if strings.Contains(remoteURL, "://") {
+		u, err := url.Parse(remoteURL)
+		if err != nil {
+			return ""
+		}
+		return u.Hostname()
+	}
+	// scp-like syntax: [user@]host:path
+	host, _, ok := strings.Cut(remoteURL, ":")
+	if !ok {
+		return ""
+	}
+	if _, after, found := strings.Cut(host, "@"); found {
+		host = after
+	}
+	return host
+}
+
+// Forge detects the forge from the repository's origin remote. It falls back
+// to GitHub when origin is missing or unreadable.
+func (g *GitWorktree) Forge() ForgeType {
+	out, err := g.runGitCommand(g.repoPath, "remote", "get-url", "origin")
+	if err != nil {
+		return ForgeGitHub
+	}
+	return DetectForge(out)
+}
+
+// CreateReview pushes the branch and opens a review request on whichever
+// forge hosts origin: a pull request on GitHub or a merge request on GitLab.
+// It returns the review's URL, or "" when the forge CLI did not print one.
+func (g *GitWorktree) CreateReview(title, body, commitMsg string) (string, error) {
+	return g.CreateReviewWithOptions(title, body, commitMsg, PROptions{})
+}
+
+// CreateReviewWithOptions is CreateReview with explicit options (e.g. draft).
+func (g *GitWorktree) CreateReviewWithOptions(title, body, commitMsg string, opts PROptions) (string, error) {
+	if g.Forge() == ForgeGitLab {
+		return g.CreateMergeRequest(title, body, commitMsg, opts)
+	}
+	return g.CreatePRWithOptions(title, body, commitMsg, opts)
+}
+
+// CreateMergeRequest pushes the current branch and opens a merge request on
+// GitLab with `glab mr create`, returning its URL. If the MR already exists
+// the existing MR's URL is returned instead.
+func (g *GitWorktree) CreateMergeRequest(title, body, commitMsg string, opts PROptions) (string, error) {
+	if err := checkGLabCLI(); err != nil {
+		return "", err
+	}
+	if err := g.CommitChanges(commitMsg); err != nil {
+		return "", fmt.Errorf("failed to push changes: %w", err)
+	}
+	if err := g.Push(false); err != nil {
+		return "", fmt.Errorf("failed to push changes: %w", err)
+	}
+
+	mrCmd := exec.Command("glab", mrCreateArgs(title, body, g.branchName, opts)...)
+	mrCmd.Dir = g.worktreePath
+	out, err := mrCmd.CombinedOutput()
+	if err != nil && !strings.Contains(string(out), "already exists") {
+		return "", fmt.Errorf("failed to create MR: %s (%w)", out, err)
+	}
+	return parseReviewURL(string(out)), nil
+}
+
+// mrCreateArgs builds the `glab mr create` arguments for the given options.
+func mrCreateArgs(title, body, branch string, opts PROptions) []string {
+	args := []string{"mr", "create", "--title", title, "--description", body, "--source-branch", branch, "--yes"}
+	if opts.Draft {
+		args = append(args, "--draft")
+	}
+	return args
+}
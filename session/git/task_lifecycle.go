@@ -5,11 +5,14 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/kastheco/kasmos/config/taskstate"
 )
 
-// TaskBranchFromFile derives the git branch name from a plan filename.
+// TaskBranchFromFile derives the default git branch name from a plan
+// filename. It backfills entries stored without a branch; new plans use
+// RenderTaskBranch with the configured template.
 // "auth-refactor" → "plan/auth-refactor"
 func TaskBranchFromFile(planFile string) string {
 	name := taskstate.DisplayName(planFile)
@@ -20,6 +23,69 @@ func TaskBranchFromFile(planFile string) string {
 	return "plan/" + name
 }
 
+// DefaultTaskBranchTemplate is the branch naming template used when none is
+// configured.
+const DefaultTaskBranchTemplate = "plan/{slug}"
+
+// RenderTaskBranch renders a branch naming template for a new plan. The
+// tokens {slug}, {user} and {date} expand to the plan name, user and
+// now (YYYY-MM-DD); the result is sanitized the same way session branches
+// are. An empty template uses DefaultTaskBranchTemplate. Returns an error when
+// the template lacks {slug} or does not render to a legal git branch name.
+// "feature/{user}/{slug}", "auth-refactor", "Jane" → "feature/jane/auth-refactor"
+func RenderTaskBranch(template, planFile, user string, now time.Time) (string, error) {
+	if strings.TrimSpace(template) == "" {
+		template = DefaultTaskBranchTemplate
+	}
+	if !strings.Contains(template, "{slug}") {
+		return "", fmt.Errorf("branch template %q must contain {slug}", template)
+	}
+	slug := sanitizeBranchName(taskstate.DisplayName(planFile))
+	if slug == "" {
+		slug = "plan"
+	}
+	user = sanitizeBranchName(strings.ReplaceAll(user, "/", "-"))
+	if user == "" {
+		user = "user"
+	}
+	branch := strings.NewReplacer(
+		"{slug}", slug,
+		"{user}", user,
+		"{date}", now.Format("2006-01-02"),
+	).Replace(template)
+	branch = sanitizeBranchName(branch)
+	if err := validateBranchName(branch); err != nil {
+		return "", fmt.Errorf("branch template %q: %w", template, err)
+	}
+	return branch, nil
+}
+
+// validateBranchName applies the git check-ref-format rules that survive
+// sanitizeBranchName: no empty or dot-leading components, no "..", and no
+// ".lock" or "." suffix.
+func validateBranchName(branch string) error {
+	if branch == "" {
+		return fmt.Errorf("renders to an empty branch name")
+	}
+	if strings.Contains(branch, "..") {
+		return fmt.Errorf("branch %q contains \"..\"", branch)
+	}
+	if strings.HasSuffix(branch, ".") {
+		return fmt.Errorf("branch %q ends with \".\"", branch)
+	}
+	for _, part := range strings.Split(branch, "/") {
+		switch {
+		case part == "":
+			return fmt.Errorf("branch %q has an empty path component", branch)
+		case strings.HasPrefix(part, "."):
+			return fmt.Errorf("branch %q has a component starting with \".\"", branch)
+		case strings.HasSuffix(part, ".lock"):
+			return fmt.Errorf("branch %q has a component ending with \".lock\"", branch)
+		}
+	}
+	return nil
+}
+
 // TaskWorktreePath returns the worktree path for a plan branch.
 // The branch separator "/" is replaced with "-" to form a valid directory name.
 func TaskWorktreePath(repoPath, branch string) string {
@@ -39,6 +105,17 @@ func NewSharedTaskWorktree(repoPath, branch string) *GitWorktree {
 	)
 }
 
+// NewSharedBranchWorktree returns a GitWorktree for the worktree that already
+// has branch checked out, so another session can join it. It returns nil when
+// the branch has no worktree yet.
+func NewSharedBranchWorktree(repoPath, branch string) (*GitWorktree, error) {
+	path, err := FindBranchWorktree(repoPath, branch)
+	if err != nil || path == "" {
+		return nil, err
+	}
+	return NewGitWorktreeFromStorage(repoPath, path, "branch-shared", branch, ""), nil
+}
+
 // EnsureTaskBranch creates the plan branch off the current HEAD if it doesn't
 // already exist. It is idempotent.
 func EnsureTaskBranch(repoPath, branch string) error {
@@ -52,6 +129,62 @@ func EnsureTaskBranch(repoPath, branch string) error {
 	return nil
 }
 
+// RenameTaskBranch renames a plan branch with `git branch -m` and moves its
+// shared plan worktree, if any, to the path for the new name. It refuses when
+// the branch is checked out anywhere other than that shared worktree (the
+// repo's own checkout or another session's worktree), since that checkout
+// would be left pointing at a renamed branch behind its owner's back. If the
+// worktree cannot be moved, the branch is renamed back before returning.
+func RenameTaskBranch(repoPath, oldBranch, newBranch string) error {
+	if err := validateBranchName(newBranch); err != nil {
+		return fmt.Errorf("rename branch %s: %w", oldBranch, err)
+	}
+	if oldBranch == newBranch {
+		return nil
+	}
+	gt := &GitWorktree{repoPath: repoPath, worktreePath: repoPath}
+	if _, err := gt.runGitCommand(repoPath, "rev-parse", "--verify", "refs/heads/"+newBranch); err == nil {
+		return fmt.Errorf("rename branch %s: branch %s already exists", oldBranch, newBranch)
+	}
+
+	oldPath := TaskWorktreePath(repoPath, oldBranch)
+	checkedOut, err := FindBranchWorktree(repoPath, oldBranch)
+	if err != nil {
+		return fmt.Errorf("rename branch %s: %w", oldBranch, err)
+	}
+	if checkedOut != "" && !sameWorktreePath(checkedOut, oldPath) {
+		return fmt.Errorf("branch %s is checked out in %s", oldBranch, checkedOut)
+	}
+
+	if _, err := gt.runGitCommand(repoPath, "branch", "-m", oldBranch, newBranch); err != nil {
+		return fmt.Errorf("rename branch %s to %s: %w", oldBranch, newBranch, err)
+	}
+	if checkedOut == "" {
+		return nil
+	}
+	newPath := TaskWorktreePath(repoPath, newBranch)
+	if _, err := gt.runGitCommand(repoPath, "worktree", "move", checkedOut, newPath); err != nil {
+		// Undo the rename so the plan keeps a branch that matches its worktree.
+		if _, undoErr := gt.runGitCommand(repoPath, "branch", "-m", newBranch, oldBranch); undoErr != nil {
+			return fmt.Errorf("move worktree for %s: %w (branch left renamed: %v)", newBranch, err, undoErr)
+		}
+		return fmt.Errorf("move worktree for %s: %w", newBranch, err)
+	}
+	return nil
+}
+
+// sameWorktreePath reports whether two worktree paths name the same
+// directory, resolving symlinks such as macOS's /var -> /private/var.
+func sameWorktreePath(a, b string) bool {
+	if ra, err := filepath.EvalSymlinks(a); err == nil {
+		a = ra
+	}
+	if rb, err := filepath.EvalSymlinks(b); err == nil {
+		b = rb
+	}
+	return filepath.Clean(a) == filepath.Clean(b)
+}
+
 // MergeTaskBranch merges the plan branch into the current branch (typically main),
 // removes the worktree, and deletes the plan branch.
 func MergeTaskBranch(repoPath, branch string) error {
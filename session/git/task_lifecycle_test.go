@@ -4,7 +4,9 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -18,6 +20,49 @@ func TestTaskBranchFromFile(t *testing.T) {
 	}
 }
 
+func TestRenderTaskBranch(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{name: "empty uses default", template: "", want: "plan/auth-refactor"},
+		{name: "user and slug", template: "feature/{user}/{slug}", want: "feature/jane/auth-refactor"},
+		{name: "date", template: "{date}-{slug}", want: "2026-03-04-auth-refactor"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderTaskBranch(tt.template, "auth-refactor", "Jane", now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRenderTaskBranch_SanitizesIllegalCharacters(t *testing.T) {
+	got, err := RenderTaskBranch("Feature/{user}/{slug}~", "Auth Refactor: v2?", "Jane Doe^", time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, "feature/jane-doe/auth-refactor-v2", got)
+
+	got, err = RenderTaskBranch("feature/{user}/{slug}", "auth", "", time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, "feature/user/auth", got, "a missing user still yields a legal branch")
+}
+
+func TestRenderTaskBranch_RejectsIllegalTemplates(t *testing.T) {
+	for _, template := range []string{
+		"feature/{user}",
+		"feature//{slug}",
+		"feature/../{slug}",
+		".hidden/{slug}",
+		"{slug}/x.lock",
+	} {
+		_, err := RenderTaskBranch(template, "auth", "jane", time.Time{})
+		assert.Error(t, err, template)
+	}
+}
+
 func TestTaskWorktreePath(t *testing.T) {
 	repo := "/tmp/repo"
 	branch := "plan/auth-refactor"
@@ -102,6 +147,76 @@ func TestPreflightMergeTaskBranch_AllowsUnrelatedDirtyPaths(t *testing.T) {
 	require.NoError(t, PreflightMergeTaskBranch(repo, "plan/test-merge"))
 }
 
+func TestRenameTaskBranch_RenamesBranchAndMovesWorktree(t *testing.T) {
+	repo := initTestRepo(t)
+	require.NoError(t, EnsureTaskBranch(repo, "plan/old-name"))
+	gt := NewSharedTaskWorktree(repo, "plan/old-name")
+	require.NoError(t, gt.Setup())
+	t.Cleanup(func() { _ = gt.Cleanup() })
+
+	require.NoError(t, RenameTaskBranch(repo, "plan/old-name", "plan/new-name"))
+
+	out, err := exec.Command("git", "-C", repo, "branch", "--list", "plan/*").CombinedOutput()
+	require.NoError(t, err, string(out))
+	assert.Contains(t, string(out), "plan/new-name")
+	assert.NotContains(t, string(out), "plan/old-name")
+
+	path, err := FindBranchWorktree(repo, "plan/new-name")
+	require.NoError(t, err)
+	assert.True(t, sameWorktreePath(path, TaskWorktreePath(repo, "plan/new-name")),
+		"worktree should move to the new branch's path, got %s", path)
+	assert.NoDirExists(t, TaskWorktreePath(repo, "plan/old-name"))
+}
+
+func TestRenameTaskBranch_RestoresBranchWhenMoveFails(t *testing.T) {
+	repo := initTestRepo(t)
+	require.NoError(t, EnsureTaskBranch(repo, "plan/old-name"))
+	gt := NewSharedTaskWorktree(repo, "plan/old-name")
+	require.NoError(t, gt.Setup())
+	t.Cleanup(func() { _ = gt.Cleanup() })
+
+	// Occupy the destination with a file so `git worktree move` fails.
+	require.NoError(t, os.WriteFile(TaskWorktreePath(repo, "plan/new-name"), []byte("taken\n"), 0o644))
+
+	err := RenameTaskBranch(repo, "plan/old-name", "plan/new-name")
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "move worktree")
+
+	out, err := exec.Command("git", "-C", repo, "branch", "--list", "plan/*").CombinedOutput()
+	require.NoError(t, err, string(out))
+	assert.Contains(t, string(out), "plan/old-name", "the rename must be undone")
+	assert.NotContains(t, string(out), "plan/new-name")
+
+	path, err := FindBranchWorktree(repo, "plan/old-name")
+	require.NoError(t, err)
+	assert.True(t, sameWorktreePath(path, gt.GetWorktreePath()), "worktree stays on the old branch, got %s", path)
+}
+
+func TestRenameTaskBranch_RefusesWhenCheckedOutElsewhere(t *testing.T) {
+	repo := initTestRepo(t)
+	cmd := exec.Command("git", "-C", repo, "checkout", "-b", "plan/busy")
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	err = RenameTaskBranch(repo, "plan/busy", "plan/renamed")
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "checked out")
+
+	out, err = exec.Command("git", "-C", repo, "branch", "--show-current").CombinedOutput()
+	require.NoError(t, err, string(out))
+	assert.Equal(t, "plan/busy", strings.TrimSpace(string(out)), "branch must be left untouched")
+}
+
+func TestRenameTaskBranch_RejectsExistingTarget(t *testing.T) {
+	repo := initTestRepo(t)
+	require.NoError(t, EnsureTaskBranch(repo, "plan/a"))
+	require.NoError(t, EnsureTaskBranch(repo, "plan/b"))
+
+	err := RenameTaskBranch(repo, "plan/a", "plan/b")
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "already exists")
+}
+
 func initTestRepo(t *testing.T) string {
 	t.Helper()
 
@@ -42,6 +42,20 @@ func checkGHCLI() error {
 	return nil
 }
 
+// checkGLabCLI checks if the GitLab CLI is installed and configured
+func checkGLabCLI() error {
+	if _, err := exec.LookPath("glab"); err != nil {
+		return fmt.Errorf("GitLab CLI (glab) is not installed. Please install it first")
+	}
+
+	cmd := exec.Command("glab", "auth", "status")
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("GitLab CLI is not configured. Please run 'glab auth login' first")
+	}
+
+	return nil
+}
+
 // IsGitRepo checks if the given path is within a git repository
 func IsGitRepo(path string) bool {
 	_, err := findGitRepoRoot(path)
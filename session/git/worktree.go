@@ -25,6 +25,9 @@ type GitWorktree struct {
 	sessionName   string
 	branchName    string
 	baseCommitSHA string
+	// forcePush is set by SquashAll after it rewrites branch history so the
+	// next Push replaces the remote branch with --force-with-lease.
+	forcePush bool
 }
 
 // NewGitWorktreeFromStorage constructs a GitWorktree directly from persisted
@@ -3,7 +3,10 @@ package git
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/kastheco/kasmos/log"
@@ -118,10 +121,18 @@ func (g *GitWorktree) PushChanges(commitMessage string, open bool) error {
 // Push pushes the current branch to origin without committing first.
 // If open is true it attempts to open the remote branch URL; any error from
 // that step is logged but not returned.
+//
+// After SquashAll has rewritten the branch the push uses --force-with-lease so
+// previously pushed WIP commits are replaced.
 func (g *GitWorktree) Push(open bool) error {
-	if _, err := g.runGitCommand(g.worktreePath, "push", "-u", "origin", g.branchName); err != nil {
+	args := []string{"push", "-u", "origin", g.branchName}
+	if g.forcePush {
+		args = append(args, "--force-with-lease")
+	}
+	if _, err := g.runGitCommand(g.worktreePath, args...); err != nil {
 		return fmt.Errorf("failed to push branch %s: %w", g.branchName, err)
 	}
+	g.forcePush = false
 	if open {
 		if err := g.OpenBranchURL(); err != nil {
 			log.ErrorLog.Printf("failed to open branch URL: %v", err)
@@ -168,32 +179,147 @@ func (g *GitWorktree) GeneratePRBody() (string, error) {
 	return strings.Join(sections, "\n\n"), nil
 }
 
-// CreatePR pushes the current branch and opens a pull request on GitHub.
-// If the PR already exists it opens the existing one in the browser instead.
-func (g *GitWorktree) CreatePR(title, body, commitMsg string) error {
+// PROptions customises pull request creation.
+type PROptions struct {
+	// Draft opens the pull request as a draft.
+	Draft bool
+}
+
+// CreatePR pushes the current branch and opens a ready-for-review pull request
+// on GitHub, returning its URL. If the PR already exists the existing PR's URL
+// is returned instead. The URL is "" when gh did not print one.
+func (g *GitWorktree) CreatePR(title, body, commitMsg string) (string, error) {
+	return g.CreatePRWithOptions(title, body, commitMsg, PROptions{})
+}
+
+// CreatePRWithOptions is CreatePR with explicit options (e.g. draft).
+func (g *GitWorktree) CreatePRWithOptions(title, body, commitMsg string, opts PROptions) (string, error) {
 	if err := g.PushChanges(commitMsg, false); err != nil {
-		return fmt.Errorf("failed to push changes: %w", err)
+		return "", fmt.Errorf("failed to push changes: %w", err)
 	}
 
-	prCmd := exec.Command("gh", "pr", "create", "--title", title, "--body", body, "--head", g.branchName)
+	prCmd := exec.Command("gh", prCreateArgs(title, body, g.branchName, opts)...)
 	prCmd.Dir = g.worktreePath
 	out, err := prCmd.CombinedOutput()
+	if err != nil && !strings.Contains(string(out), "already exists") {
+		return "", fmt.Errorf("failed to create PR: %s (%w)", out, err)
+	}
+	return parseReviewURL(string(out)), nil
+}
+
+// parseReviewURL returns the last http(s) URL in the output of `gh pr create`
+// or `glab mr create`, which both end by printing the new (or existing)
+// review's URL, or "" when there is none.
+func parseReviewURL(out string) string {
+	fields := strings.Fields(out)
+	for i := len(fields) - 1; i >= 0; i-- {
+		if f := fields[i]; strings.HasPrefix(f, "https://") || strings.HasPrefix(f, "http://") {
+			return strings.TrimRight(f, ".,:;)")
+		}
+	}
+	return ""
+}
+
+// SquashAll collapses every commit on the branch since its fork point from
+// main into a single commit with the given message. Uncommitted changes are
+// included. The fork point is the merge base of the branch and the default
+// branch, falling back to baseCommitSHA. It is a no-op when the
+// branch has no commits or changes of its own.
+func (g *GitWorktree) SquashAll(message string) error {
+	if strings.TrimSpace(message) == "" {
+		return fmt.Errorf("squash commit message cannot be empty")
+	}
+	base, err := g.forkPoint()
+	if err != nil {
+		return err
+	}
+	if err := g.CommitChanges(message); err != nil {
+		return err
+	}
+	count, err := g.runGitCommand(g.worktreePath, "rev-list", "--count", base+"..HEAD")
 	if err != nil {
-		if strings.Contains(string(out), "already exists") {
-			viewCmd := exec.Command("gh", "pr", "view", "--web", g.branchName)
-			viewCmd.Dir = g.worktreePath
-			_ = viewCmd.Run()
-			return nil
+		return fmt.Errorf("failed to count branch commits: %w", err)
+	}
+	if strings.TrimSpace(count) == "0" {
+		return nil
+	}
+	if _, err := g.runGitCommand(g.worktreePath, "reset", "--soft", base); err != nil {
+		return fmt.Errorf("failed to reset to fork point: %w", err)
+	}
+	if _, err := g.runGitCommand(g.worktreePath, "commit", "-m", message, "--no-verify"); err != nil {
+		return fmt.Errorf("failed to create squash commit: %w", err)
+	}
+	g.forcePush = true
+	return nil
+}
+
+// forkPoint returns the commit the branch was forked from on the default
+// branch. When no default branch resolves, or the merge base is the branch
+// tip (nothing of its own relative to it), baseCommitSHA is used instead.
+func (g *GitWorktree) forkPoint() (string, error) {
+	if main := g.defaultBranch(); main != "" {
+		if out, err := g.runGitCommand(g.repoPath, "merge-base", main, g.branchName); err == nil {
+			sha := strings.TrimSpace(out)
+			tip, tipErr := g.runGitCommand(g.repoPath, "rev-parse", g.branchName)
+			if sha != "" && (tipErr != nil || strings.TrimSpace(tip) != sha || g.baseCommitSHA == "") {
+				return sha, nil
+			}
+		}
+	}
+	if g.baseCommitSHA != "" {
+		return g.baseCommitSHA, nil
+	}
+	return "", fmt.Errorf("cannot determine fork point for branch %s", g.branchName)
+}
+
+// defaultBranch returns the repository's default branch: what origin/HEAD
+// points at, else the first of main, master, origin/main and origin/master
+// that exists. Returns "" when none does.
+func (g *GitWorktree) defaultBranch() string {
+	if out, err := g.runGitCommand(g.repoPath, "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD"); err == nil {
+		if ref := strings.TrimSpace(out); ref != "" {
+			return ref
+		}
+	}
+	for _, ref := range []string{"main", "master", "origin/main", "origin/master"} {
+		if _, err := g.runGitCommand(g.repoPath, "rev-parse", "--verify", "--quiet", ref+"^{commit}"); err == nil {
+			return ref
 		}
-		return fmt.Errorf("failed to create PR: %s (%w)", out, err)
 	}
+	return ""
+}
 
-	viewCmd := exec.Command("gh", "pr", "view", "--web", g.branchName)
-	viewCmd.Dir = g.worktreePath
-	_ = viewCmd.Run()
+// WriteDiff writes the branch's changes as a unified diff (suitable for
+// `git apply`) to path. The diff runs from baseCommitSHA, or HEAD when no base
+// is recorded, to the working tree, so committed and uncommitted changes to
+// tracked files are both included. Untracked files are not.
+func (g *GitWorktree) WriteDiff(path string) error {
+	base := g.GetBaseCommitSHA()
+	if base == "" {
+		base = "HEAD"
+	}
+	out, err := g.runGitCommand(g.worktreePath, "diff", "--binary", base)
+	if err != nil {
+		return fmt.Errorf("failed to compute diff: %w", err)
+	}
+	if strings.TrimSpace(out) == "" {
+		return fmt.Errorf("no changes to export")
+	}
+	if err := os.WriteFile(path, []byte(out), 0o644); err != nil {
+		return fmt.Errorf("failed to write diff: %w", err)
+	}
 	return nil
 }
 
+// prCreateArgs builds the `gh pr create` arguments for the given options.
+func prCreateArgs(title, body, branch string, opts PROptions) []string {
+	args := []string{"pr", "create", "--title", title, "--body", body, "--head", branch}
+	if opts.Draft {
+		args = append(args, "--draft")
+	}
+	return args
+}
+
 // CommitChanges stages all changes and creates a commit with the given message.
 // It is a no-op when the worktree is clean.
 func (g *GitWorktree) CommitChanges(commitMessage string) error {
@@ -224,6 +350,150 @@ func (g *GitWorktree) IsDirty() (bool, error) {
 	return len(out) > 0, nil
 }
 
+// RebaseOntoMain fetches main from origin and rebases the worktree's branch
+// onto it, stashing uncommitted changes around the rebase. When the rebase
+// stops on conflicts it is aborted, leaving the branch as it was, and the
+// returned error lists the conflicting files.
+func (g *GitWorktree) RebaseOntoMain() error {
+	if _, err := g.runGitCommand(g.worktreePath, "fetch", "origin", "main"); err != nil {
+		return fmt.Errorf("failed to fetch origin main: %w", err)
+	}
+	if _, err := g.runGitCommand(g.worktreePath, "rebase", "--autostash", "origin/main"); err != nil {
+		_, files, _ := g.HasConflicts()
+		if _, abortErr := g.runGitCommand(g.worktreePath, "rebase", "--abort"); abortErr != nil {
+			return fmt.Errorf("failed to abort rebase of %s: %w", g.branchName, abortErr)
+		}
+		if len(files) > 0 {
+			return fmt.Errorf("rebase of %s onto origin/main aborted, conflicts in: %s",
+				g.branchName, strings.Join(files, ", "))
+		}
+		return fmt.Errorf("failed to rebase %s onto origin/main: %w", g.branchName, err)
+	}
+	return nil
+}
+
+// HasConflicts reports whether the worktree has unmerged paths, as left by a
+// rebase or merge that stopped on conflicts, and lists them.
+func (g *GitWorktree) HasConflicts() (bool, []string, error) {
+	out, err := g.runGitCommand(g.worktreePath, "diff", "--name-only", "--diff-filter=U")
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to check for conflicts: %w", err)
+	}
+	var files []string
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return len(files) > 0, files, nil
+}
+
+// HeadSHA returns the commit the worktree's HEAD points at.
+func (g *GitWorktree) HeadSHA() (string, error) {
+	out, err := g.runGitCommand(g.worktreePath, "rev-parse", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to read HEAD: %w", err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// IsDetached reports whether the worktree's HEAD is detached, i.e. points at
+// a commit rather than a branch. A rebase in progress detaches HEAD on
+// purpose, so it is not reported.
+func (g *GitWorktree) IsDetached() (bool, error) {
+	out, err := g.runGitCommand(g.worktreePath, "branch", "--show-current")
+	if err != nil {
+		return false, fmt.Errorf("failed to get current branch: %w", err)
+	}
+	if strings.TrimSpace(out) != "" {
+		return false, nil
+	}
+	rebasing, err := g.RebaseInProgress()
+	if err != nil {
+		return false, err
+	}
+	return !rebasing, nil
+}
+
+// RebaseInProgress reports whether the worktree is in the middle of a rebase,
+// i.e. its git dir holds rebase-merge or rebase-apply.
+func (g *GitWorktree) RebaseInProgress() (bool, error) {
+	for _, name := range []string{"rebase-merge", "rebase-apply"} {
+		out, err := g.runGitCommand(g.worktreePath, "rev-parse", "--git-path", name)
+		if err != nil {
+			return false, fmt.Errorf("failed to resolve %s: %w", name, err)
+		}
+		path := strings.TrimSpace(out)
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(g.worktreePath, path)
+		}
+		if _, err := os.Stat(path); err == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// OrphanedCommits counts the commits on the detached HEAD that Reattach would
+// leave behind. It is zero when HEAD descends from the branch, because
+// Reattach then fast-forwards the branch onto them.
+func (g *GitWorktree) OrphanedCommits() (int, error) {
+	if g.headDescendsFromBranch() {
+		return 0, nil
+	}
+	out, err := g.runGitCommand(g.worktreePath, "rev-list", "--count", g.branchName+"..HEAD")
+	if err != nil {
+		return 0, fmt.Errorf("failed to count commits on HEAD: %w", err)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(out))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse commit count %q: %w", strings.TrimSpace(out), err)
+	}
+	return n, nil
+}
+
+// Reattach checks the worktree's configured branch back out, ending a
+// detached HEAD. When HEAD descends from the branch, the branch is first
+// fast-forwarded to HEAD so commits made while detached stay on it. Otherwise
+// Reattach refuses if that would orphan commits (see OrphanedCommits), unless
+// force is set. It always refuses while a rebase is in progress.
+func (g *GitWorktree) Reattach(force bool) error {
+	if g.branchName == "" {
+		return fmt.Errorf("worktree has no branch to reattach to")
+	}
+	rebasing, err := g.RebaseInProgress()
+	if err != nil {
+		return err
+	}
+	if rebasing {
+		return fmt.Errorf("a rebase is in progress; finish or abort it before reattaching to %s", g.branchName)
+	}
+	if g.headDescendsFromBranch() {
+		if _, err := g.runGitCommand(g.worktreePath, "branch", "-f", g.branchName, "HEAD"); err != nil {
+			return fmt.Errorf("failed to fast-forward %s: %w", g.branchName, err)
+		}
+	} else if !force {
+		n, err := g.OrphanedCommits()
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			return fmt.Errorf("%d commit(s) on the detached HEAD are not on %s and would be left behind", n, g.branchName)
+		}
+	}
+	if _, err := g.runGitCommand(g.worktreePath, "checkout", g.branchName); err != nil {
+		return fmt.Errorf("failed to check out %s: %w", g.branchName, err)
+	}
+	return nil
+}
+
+// headDescendsFromBranch reports whether the configured branch is an ancestor
+// of (or equal to) the worktree's HEAD.
+func (g *GitWorktree) headDescendsFromBranch() bool {
+	_, err := g.runGitCommand(g.worktreePath, "merge-base", "--is-ancestor", g.branchName, "HEAD")
+	return err == nil
+}
+
 // IsBranchCheckedOut reports whether the configured branch is the currently
 // checked-out branch in the repository (not in the worktree).
 func (g *GitWorktree) IsBranchCheckedOut() (bool, error) {
@@ -1,6 +1,11 @@
 package git
 
 import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -59,3 +64,362 @@ func TestParsePRViewJSON_MalformedJSON(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "parse pr view json")
 }
+
+func TestSquashAll_CollapsesBranchCommits(t *testing.T) {
+	repo := initTestRepo(t)
+	git := func(dir string, args ...string) string {
+		t.Helper()
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		require.NoErrorf(t, err, "git %v failed: %s", args, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	forkPoint := git(repo, "rev-parse", "HEAD")
+	git(repo, "branch", "plan/squash")
+
+	gt := NewSharedTaskWorktree(repo, "plan/squash")
+	require.NoError(t, gt.Setup())
+	t.Cleanup(func() { _ = gt.Cleanup() })
+	wt := gt.GetWorktreePath()
+
+	for i := 1; i <= 3; i++ {
+		name := fmt.Sprintf("file%d.txt", i)
+		require.NoError(t, os.WriteFile(filepath.Join(wt, name), []byte("wip\n"), 0o644))
+		git(wt, "add", name)
+		git(wt, "commit", "-m", "wip "+name)
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(wt, "dirty.txt"), []byte("uncommitted\n"), 0o644))
+
+	// main moves on after the fork; its commit must not be pulled into the squash.
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "main.txt"), []byte("main\n"), 0o644))
+	git(repo, "add", "main.txt")
+	git(repo, "commit", "-m", "main moves on")
+
+	require.NoError(t, gt.SquashAll("feat: single clean commit"))
+
+	assert.Equal(t, "1", git(wt, "rev-list", "--count", forkPoint+"..HEAD"))
+	assert.Equal(t, forkPoint, git(wt, "rev-parse", "HEAD~1"))
+	assert.Equal(t, "feat: single clean commit", git(wt, "log", "-1", "--format=%s"))
+	files := git(wt, "show", "--name-only", "--format=", "HEAD")
+	for _, f := range []string{"file1.txt", "file2.txt", "file3.txt", "dirty.txt"} {
+		assert.Contains(t, files, f)
+	}
+	assert.NotContains(t, files, "main.txt")
+	assert.True(t, gt.forcePush, "rewritten history must be force-pushed")
+}
+
+func TestSquashAll_ForksFromDefaultBranchNotHEAD(t *testing.T) {
+	repo := initTestRepo(t)
+	git := func(dir string, args ...string) string {
+		t.Helper()
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		require.NoErrorf(t, err, "git %v failed: %s", args, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	git(repo, "branch", "-M", "main")
+	git(repo, "branch", "old")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "main.txt"), []byte("main\n"), 0o644))
+	git(repo, "add", "main.txt")
+	git(repo, "commit", "-m", "main moves on")
+	forkPoint := git(repo, "rev-parse", "HEAD")
+	git(repo, "branch", "plan/fork")
+	// The main checkout sits on an older branch; its HEAD is not the fork point.
+	git(repo, "checkout", "old")
+
+	gt := NewSharedTaskWorktree(repo, "plan/fork")
+	require.NoError(t, gt.Setup())
+	t.Cleanup(func() { _ = gt.Cleanup() })
+	wt := gt.GetWorktreePath()
+	for i := 1; i <= 2; i++ {
+		name := fmt.Sprintf("file%d.txt", i)
+		require.NoError(t, os.WriteFile(filepath.Join(wt, name), []byte("wip\n"), 0o644))
+		git(wt, "add", name)
+		git(wt, "commit", "-m", "wip "+name)
+	}
+
+	require.NoError(t, gt.SquashAll("feat: squashed"))
+	assert.Equal(t, forkPoint, git(wt, "rev-parse", "HEAD~1"))
+	assert.NotContains(t, git(wt, "show", "--name-only", "--format=", "HEAD"), "main.txt")
+}
+
+func TestSquashAll_NoBranchCommitsIsNoop(t *testing.T) {
+	repo := initTestRepo(t)
+	require.NoError(t, exec.Command("git", "-C", repo, "branch", "plan/empty").Run())
+
+	gt := NewSharedTaskWorktree(repo, "plan/empty")
+	require.NoError(t, gt.Setup())
+	t.Cleanup(func() { _ = gt.Cleanup() })
+
+	require.NoError(t, gt.SquashAll("nothing"))
+	assert.False(t, gt.forcePush)
+	assert.Error(t, gt.SquashAll("  "), "empty message is rejected")
+}
+
+func TestParseReviewURL(t *testing.T) {
+	ghCreate := "\nCreating pull request for plan/x into main in kastheco/kasmos\n\nhttps://github.com/kastheco/kasmos/pull/42\n"
+	assert.Equal(t, "https://github.com/kastheco/kasmos/pull/42", parseReviewURL(ghCreate))
+
+	ghExists := "a pull request for branch \"plan/x\" into branch \"main\" already exists:\nhttps://github.com/kastheco/kasmos/pull/7\n"
+	assert.Equal(t, "https://github.com/kastheco/kasmos/pull/7", parseReviewURL(ghExists))
+
+	glab := "Creating merge request for plan/x into main in group/proj\n\n!12 add thing (plan/x)\n https://gitlab.com/group/proj/-/merge_requests/12\n"
+	assert.Equal(t, "https://gitlab.com/group/proj/-/merge_requests/12", parseReviewURL(glab))
+
+	assert.Empty(t, parseReviewURL("Warning: 2 uncommitted changes\n"))
+}
+
+func TestPRCreateArgs_Draft(t *testing.T) {
+	args := prCreateArgs("title", "body", "plan/x", PROptions{Draft: true})
+	assert.Equal(t, []string{"pr", "create", "--title", "title", "--body", "body", "--head", "plan/x", "--draft"}, args)
+	assert.NotContains(t, prCreateArgs("title", "body", "plan/x", PROptions{}), "--draft")
+}
+
+func TestWriteDiff_WritesApplicablePatch(t *testing.T) {
+	repo := initTestRepo(t)
+	require.NoError(t, exec.Command("git", "-C", repo, "branch", "plan/diff").Run())
+
+	gt := NewSharedTaskWorktree(repo, "plan/diff")
+	require.NoError(t, gt.Setup())
+	t.Cleanup(func() { _ = gt.Cleanup() })
+	wt := gt.GetWorktreePath()
+
+	patchPath := filepath.Join(t.TempDir(), "out.patch")
+	assert.Error(t, gt.WriteDiff(patchPath), "no changes yet")
+
+	require.NoError(t, os.WriteFile(filepath.Join(wt, "README.md"), []byte("changed\n"), 0o644))
+	require.NoError(t, gt.WriteDiff(patchPath))
+
+	data, err := os.ReadFile(patchPath)
+	require.NoError(t, err)
+	patch := string(data)
+	assert.True(t, strings.HasPrefix(patch, "diff --git a/README.md b/README.md"))
+	assert.Contains(t, patch, "--- a/README.md")
+	assert.Contains(t, patch, "+++ b/README.md")
+	assert.Contains(t, patch, "+changed")
+
+	// The patch must apply cleanly to the untouched main checkout.
+	out, err := exec.Command("git", "-C", repo, "apply", "--check", patchPath).CombinedOutput()
+	assert.NoErrorf(t, err, "git apply --check: %s", out)
+}
+
+func TestIsDirty_DetectsUncommittedChanges(t *testing.T) {
+	repo := initTestRepo(t)
+	require.NoError(t, exec.Command("git", "-C", repo, "branch", "plan/dirty").Run())
+
+	gt := NewSharedTaskWorktree(repo, "plan/dirty")
+	require.NoError(t, gt.Setup())
+	t.Cleanup(func() { _ = gt.Cleanup() })
+	wt := gt.GetWorktreePath()
+
+	dirty, err := gt.IsDirty()
+	require.NoError(t, err)
+	assert.False(t, dirty, "fresh worktree is clean")
+
+	require.NoError(t, os.WriteFile(filepath.Join(wt, "new.txt"), []byte("wip\n"), 0o644))
+	dirty, err = gt.IsDirty()
+	require.NoError(t, err)
+	assert.True(t, dirty, "untracked file counts as uncommitted")
+
+	require.NoError(t, os.Remove(filepath.Join(wt, "new.txt")))
+	require.NoError(t, os.WriteFile(filepath.Join(wt, "README.md"), []byte("edited\n"), 0o644))
+	dirty, err = gt.IsDirty()
+	require.NoError(t, err)
+	assert.True(t, dirty, "modified tracked file counts as uncommitted")
+}
+
+func TestHasConflicts_DetectsUnmergedPaths(t *testing.T) {
+	repo := initTestRepo(t)
+	git := func(dir string, args ...string) string {
+		t.Helper()
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		require.NoErrorf(t, err, "git %v failed: %s", args, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	git(repo, "branch", "plan/conflict")
+
+	gt := NewSharedTaskWorktree(repo, "plan/conflict")
+	require.NoError(t, gt.Setup())
+	t.Cleanup(func() { _ = gt.Cleanup() })
+	wt := gt.GetWorktreePath()
+
+	has, files, err := gt.HasConflicts()
+	require.NoError(t, err)
+	assert.False(t, has)
+	assert.Empty(t, files)
+
+	require.NoError(t, os.WriteFile(filepath.Join(wt, "README.md"), []byte("branch\n"), 0o644))
+	git(wt, "commit", "-am", "branch edit")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "README.md"), []byte("main\n"), 0o644))
+	git(repo, "commit", "-am", "main edit")
+	main := git(repo, "rev-parse", "HEAD")
+
+	out, err := exec.Command("git", "-C", wt, "merge", main).CombinedOutput()
+	require.Error(t, err, "merge should stop on a conflict: %s", string(out))
+
+	has, files, err = gt.HasConflicts()
+	require.NoError(t, err)
+	assert.True(t, has)
+	assert.Equal(t, []string{"README.md"}, files)
+}
+
+func TestIsDetached_DetectsDetachedHead(t *testing.T) {
+	repo := initTestRepo(t)
+	git := func(dir string, args ...string) string {
+		t.Helper()
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		require.NoErrorf(t, err, "git %v failed: %s", args, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	git(repo, "branch", "plan/detached")
+
+	gt := NewSharedTaskWorktree(repo, "plan/detached")
+	require.NoError(t, gt.Setup())
+	t.Cleanup(func() { _ = gt.Cleanup() })
+	wt := gt.GetWorktreePath()
+
+	detached, err := gt.IsDetached()
+	require.NoError(t, err)
+	assert.False(t, detached)
+
+	git(wt, "checkout", "--detach", "HEAD")
+	detached, err = gt.IsDetached()
+	require.NoError(t, err)
+	assert.True(t, detached)
+
+	require.NoError(t, gt.Reattach(false))
+	detached, err = gt.IsDetached()
+	require.NoError(t, err)
+	assert.False(t, detached)
+	assert.Equal(t, "plan/detached", git(wt, "branch", "--show-current"))
+}
+
+func TestReattach_KeepsOrRefusesDetachedCommits(t *testing.T) {
+	repo := initTestRepo(t)
+	git := func(dir string, args ...string) string {
+		t.Helper()
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		require.NoErrorf(t, err, "git %v failed: %s", args, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	git(repo, "branch", "plan/detached")
+
+	gt := NewSharedTaskWorktree(repo, "plan/detached")
+	require.NoError(t, gt.Setup())
+	t.Cleanup(func() { _ = gt.Cleanup() })
+	wt := gt.GetWorktreePath()
+
+	// Commits on a HEAD that descends from the branch are fast-forwarded.
+	git(wt, "checkout", "--detach", "HEAD")
+	git(wt, "commit", "--allow-empty", "-m", "detached work")
+	head := git(wt, "rev-parse", "HEAD")
+	n, err := gt.OrphanedCommits()
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	require.NoError(t, gt.Reattach(false))
+	assert.Equal(t, "plan/detached", git(wt, "branch", "--show-current"))
+	assert.Equal(t, head, git(wt, "rev-parse", "plan/detached"))
+
+	// Commits on a HEAD that diverged from the branch are only dropped on force.
+	git(wt, "checkout", "--detach", "HEAD~1")
+	git(wt, "commit", "--allow-empty", "-m", "diverged work")
+	n, err = gt.OrphanedCommits()
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	require.Error(t, gt.Reattach(false))
+	assert.Empty(t, git(wt, "branch", "--show-current"))
+	require.NoError(t, gt.Reattach(true))
+	assert.Equal(t, head, git(wt, "rev-parse", "HEAD"))
+}
+
+func TestIsDetached_IgnoresRebaseInProgress(t *testing.T) {
+	repo := initTestRepo(t)
+	git := func(dir string, args ...string) string {
+		t.Helper()
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		require.NoErrorf(t, err, "git %v failed: %s", args, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	git(repo, "branch", "plan/rebasing")
+
+	gt := NewSharedTaskWorktree(repo, "plan/rebasing")
+	require.NoError(t, gt.Setup())
+	t.Cleanup(func() { _ = gt.Cleanup() })
+	wt := gt.GetWorktreePath()
+
+	git(wt, "checkout", "--detach", "HEAD")
+	rebaseDir := git(wt, "rev-parse", "--path-format=absolute", "--git-path", "rebase-merge")
+	require.NoError(t, os.MkdirAll(rebaseDir, 0o755))
+
+	detached, err := gt.IsDetached()
+	require.NoError(t, err)
+	assert.False(t, detached)
+	require.Error(t, gt.Reattach(true))
+}
+
+// initTestRepoWithOrigin returns a repo whose origin is a bare clone, plus a
+// helper to run git commands in either.
+func initTestRepoWithOrigin(t *testing.T) (string, func(dir string, args ...string) string) {
+	t.Helper()
+	repo := initTestRepo(t)
+	git := func(dir string, args ...string) string {
+		t.Helper()
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		require.NoErrorf(t, err, "git %v failed: %s", args, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	git(repo, "branch", "-M", "main")
+	origin := filepath.Join(t.TempDir(), "origin.git")
+	git(repo, "clone", "--bare", repo, origin)
+	git(repo, "remote", "add", "origin", origin)
+	return repo, git
+}
+
+func TestRebaseOntoMain_Clean(t *testing.T) {
+	repo, git := initTestRepoWithOrigin(t)
+	git(repo, "branch", "plan/rebase")
+
+	gt := NewSharedTaskWorktree(repo, "plan/rebase")
+	require.NoError(t, gt.Setup())
+	t.Cleanup(func() { _ = gt.Cleanup() })
+	wt := gt.GetWorktreePath()
+
+	require.NoError(t, os.WriteFile(filepath.Join(wt, "feature.txt"), []byte("feature\n"), 0o644))
+	git(wt, "add", "feature.txt")
+	git(wt, "commit", "-m", "feature")
+
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "main.txt"), []byte("main\n"), 0o644))
+	git(repo, "add", "main.txt")
+	git(repo, "commit", "-m", "main moves on")
+	git(repo, "push", "origin", "main")
+
+	require.NoError(t, gt.RebaseOntoMain())
+	assert.Equal(t, git(repo, "rev-parse", "main"), git(wt, "rev-parse", "HEAD~1"))
+	assert.FileExists(t, filepath.Join(wt, "main.txt"))
+	assert.FileExists(t, filepath.Join(wt, "feature.txt"))
+}
+
+func TestRebaseOntoMain_ConflictAborts(t *testing.T) {
+	repo, git := initTestRepoWithOrigin(t)
+	git(repo, "branch", "plan/rebase")
+
+	gt := NewSharedTaskWorktree(repo, "plan/rebase")
+	require.NoError(t, gt.Setup())
+	t.Cleanup(func() { _ = gt.Cleanup() })
+	wt := gt.GetWorktreePath()
+
+	require.NoError(t, os.WriteFile(filepath.Join(wt, "README.md"), []byte("branch\n"), 0o644))
+	git(wt, "commit", "-am", "branch edit")
+	before := git(wt, "rev-parse", "HEAD")
+
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "README.md"), []byte("main\n"), 0o644))
+	git(repo, "commit", "-am", "main edit")
+	git(repo, "push", "origin", "main")
+
+	err := gt.RebaseOntoMain()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "README.md")
+	assert.Equal(t, before, git(wt, "rev-parse", "HEAD"), "aborted rebase leaves the branch untouched")
+
+	has, _, err := gt.HasConflicts()
+	require.NoError(t, err)
+	assert.False(t, has)
+}
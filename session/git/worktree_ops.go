@@ -250,20 +250,7 @@ func CleanupWorktrees(repoPath string) error {
 		return fmt.Errorf("failed to list worktrees: %w", err)
 	}
 
-	pathToBranch := make(map[string]string)
-	var currentPath string
-	for _, line := range strings.Split(listOut, "\n") {
-		switch {
-		case strings.HasPrefix(line, "worktree "):
-			currentPath = strings.TrimPrefix(line, "worktree ")
-		case strings.HasPrefix(line, "branch "):
-			branch := strings.TrimPrefix(line, "branch ")
-			branch = strings.TrimPrefix(branch, "refs/heads/")
-			if currentPath != "" {
-				pathToBranch[currentPath] = branch
-			}
-		}
-	}
+	pathToBranch := parseWorktreeList(listOut)
 
 	for _, entry := range entries {
 		if !entry.IsDir() {
@@ -296,3 +283,39 @@ func CleanupWorktrees(repoPath string) error {
 
 	return nil
 }
+
+// parseWorktreeList maps each worktree path in `git worktree list --porcelain`
+// output to its checked-out branch. Detached worktrees are omitted.
+func parseWorktreeList(out string) map[string]string {
+	pathToBranch := make(map[string]string)
+	var currentPath string
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case strings.HasPrefix(line, "worktree "):
+			currentPath = strings.TrimPrefix(line, "worktree ")
+		case strings.HasPrefix(line, "branch "):
+			branch := strings.TrimPrefix(line, "branch ")
+			branch = strings.TrimPrefix(branch, "refs/heads/")
+			if currentPath != "" {
+				pathToBranch[currentPath] = branch
+			}
+		}
+	}
+	return pathToBranch
+}
+
+// FindBranchWorktree returns the path of the worktree that has branch checked
+// out, or "" when no worktree of repoPath is on that branch.
+func FindBranchWorktree(repoPath, branch string) (string, error) {
+	out, err := exec.Command("git", "-C", repoPath, "worktree", "list", "--porcelain").CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to list worktrees: %s (%w)", out, err)
+	}
+	branch = strings.TrimPrefix(branch, "refs/heads/")
+	for path, b := range parseWorktreeList(string(out)) {
+		if b == branch {
+			return path, nil
+		}
+	}
+	return "", nil
+}
@@ -139,3 +139,37 @@ func TestCleanupWorktrees_RemovesWorktreeAndBranch(t *testing.T) {
 	require.NoError(t, err)
 	assert.Empty(t, strings.TrimSpace(string(out)), "branch should be deleted")
 }
+
+func TestFindBranchWorktree(t *testing.T) {
+	repo := initCleanupTestRepo(t)
+	wtPath := filepath.Join(t.TempDir(), "feature-wt")
+	out, err := exec.Command("git", "-C", repo, "worktree", "add", "-b", "feature/login", wtPath).CombinedOutput()
+	require.NoError(t, err, "git worktree add: %s", out)
+
+	got, err := FindBranchWorktree(repo, "feature/login")
+	require.NoError(t, err)
+	resolved, _ := filepath.EvalSymlinks(wtPath)
+	gotResolved, _ := filepath.EvalSymlinks(got)
+	assert.Equal(t, resolved, gotResolved)
+
+	got, err = FindBranchWorktree(repo, "feature/missing")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestNewSharedBranchWorktree(t *testing.T) {
+	repo := initCleanupTestRepo(t)
+	wtPath := filepath.Join(t.TempDir(), "shared-wt")
+	out, err := exec.Command("git", "-C", repo, "worktree", "add", "-b", "feature/shared", wtPath).CombinedOutput()
+	require.NoError(t, err, "git worktree add: %s", out)
+
+	wt, err := NewSharedBranchWorktree(repo, "feature/shared")
+	require.NoError(t, err)
+	require.NotNil(t, wt, "existing worktree should be joined")
+	assert.Equal(t, "feature/shared", wt.GetBranchName())
+	assert.Equal(t, filepath.Base(wtPath), filepath.Base(wt.GetWorktreePath()))
+
+	wt, err = NewSharedBranchWorktree(repo, "feature/none")
+	require.NoError(t, err)
+	assert.Nil(t, wt, "no worktree means a new one must be created")
+}
@@ -267,6 +267,11 @@ func (s *Session) Attach() (chan struct{}, error) {
 	return nil, ErrInteractiveOnly
 }
 
+// AttachReadonly returns ErrInteractiveOnly.
+func (s *Session) AttachReadonly() (chan struct{}, error) {
+	return nil, ErrInteractiveOnly
+}
+
 // SendKeys returns ErrInteractiveOnly.
 func (s *Session) SendKeys(_ string) error { return ErrInteractiveOnly }
 
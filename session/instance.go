@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/kastheco/kasmos/config"
 	"github.com/kastheco/kasmos/session/git"
 )
 
@@ -55,6 +56,9 @@ type Instance struct {
 	UpdatedAt time.Time
 	// AutoYes causes the instance to auto-confirm prompts.
 	AutoYes bool
+	// AutoYesPatterns, when non-empty, limits AutoYes to permission prompts
+	// matching one of these patterns (see ShouldAutoYes).
+	AutoYesPatterns []string
 	// SkipPermissions enables the --dangerously-skip-permissions flag for Claude.
 	SkipPermissions bool
 	// TaskFile is the plan file this instance is implementing (empty for ad-hoc sessions).
@@ -80,6 +84,9 @@ type Instance struct {
 	Exited bool
 	// QueuedPrompt is delivered to the session on first transition to Ready. Cleared after delivery.
 	QueuedPrompt string
+	// LastPrompt is the most recent prompt SendPrompt delivered. It is kept
+	// across restarts so it can be requeued after the session is resumed.
+	LastPrompt string
 
 	// sharedWorktree indicates the instance shares a topic worktree and should not clean it up.
 	sharedWorktree bool
@@ -95,6 +102,9 @@ type Instance struct {
 
 	// LastActiveAt records the most recent time the instance entered Running or Loading state.
 	LastActiveAt time.Time
+	// IdleSince is when the instance last became Ready; zero while it is in
+	// any other status (ephemeral, not persisted).
+	IdleSince time.Time
 
 	// PromptDetected is true when the agent program is waiting for user input.
 	// Persists across status transitions to prevent UI flicker.
@@ -107,6 +117,21 @@ type Instance struct {
 	// ReviewCycle is the 1-indexed count of review/fix cycles for this instance (0 = not a cycle instance).
 	ReviewCycle int
 
+	// RecordSessions records the agent session when it is first started (not persisted).
+	RecordSessions bool
+	// Container is the container the agent program runs in. It is re-applied
+	// whenever the session is started again, so resumed agents stay sandboxed.
+	Container config.ContainerConfig
+	// RecordingPath is the .cast or .log file the session is recorded to ("" = not recorded).
+	RecordingPath string
+	// OutputLogPath is the rolling transcript of the agent's output ("" = not logged).
+	OutputLogPath string
+	// outputLog tracks the last capture written to OutputLogPath.
+	outputLog *outputLog
+	// SortIndex is the user-chosen position within its nav group (1-indexed).
+	// 0 means unordered; such instances sort after ordered ones.
+	SortIndex int
+
 	// HasWorked is true once the agent produces at least one content update after receiving its task.
 	// Prevents permission prompts or early returns from prematurely completing a wave.
 	HasWorked bool
@@ -115,6 +140,24 @@ type Instance struct {
 	CPUPercent float64
 	// MemMB is the last sampled memory usage of the agent process in megabytes.
 	MemMB float64
+	// TokensUsed and CostUSD are the last token count and cost the agent
+	// reported in its pane (ephemeral, not persisted; 0 = unknown).
+	TokensUsed int
+	CostUSD    float64
+	// ResourceBreachTicks counts consecutive metadata ticks with CPU or memory
+	// above the configured alert thresholds (ephemeral, not persisted).
+	ResourceBreachTicks int
+	// ResourceAlerted is true once the current breach has been reported, so
+	// the alert fires once per breach rather than every tick.
+	ResourceAlerted bool
+
+	// HasConflicts is true when the worktree has unmerged paths from a stalled merge or rebase.
+	HasConflicts bool
+	// ConflictFiles lists the unmerged paths when HasConflicts is set.
+	ConflictFiles []string
+	// DetachedHead is true when the worktree's HEAD no longer points at the
+	// instance's branch (ephemeral, refreshed every metadata tick).
+	DetachedHead bool
 
 	// LastActivity is the most recently detected agent activity event (ephemeral, not persisted).
 	LastActivity *Activity
@@ -157,7 +200,15 @@ func (i *Instance) ToInstanceData() InstanceData {
 		ImplementationComplete: i.ImplementationComplete,
 		SoloAgent:              i.SoloAgent,
 		QueuedPrompt:           i.QueuedPrompt,
+		LastPrompt:             i.LastPrompt,
 		ReviewCycle:            i.ReviewCycle,
+		RecordingPath:          i.RecordingPath,
+		OutputLogPath:          i.OutputLogPath,
+		SortIndex:              i.SortIndex,
+	}
+	if i.Container.Enabled() {
+		c := i.Container
+		data.Container = &c
 	}
 
 	if i.gitWorktree != nil {
@@ -202,7 +253,11 @@ func FromInstanceData(data InstanceData) (*Instance, error) {
 		ImplementationComplete: data.ImplementationComplete,
 		SoloAgent:              data.SoloAgent,
 		QueuedPrompt:           data.QueuedPrompt,
+		LastPrompt:             data.LastPrompt,
 		ReviewCycle:            data.ReviewCycle,
+		RecordingPath:          data.RecordingPath,
+		OutputLogPath:          data.OutputLogPath,
+		SortIndex:              data.SortIndex,
 		gitWorktree: git.NewGitWorktreeFromStorage(
 			data.Worktree.RepoPath,
 			data.Worktree.WorktreePath,
@@ -211,6 +266,15 @@ func FromInstanceData(data InstanceData) (*Instance, error) {
 			data.Worktree.BaseCommitSHA,
 		),
 	}
+	if data.Container != nil {
+		instance.Container = *data.Container
+	}
+	// Plan agents share the plan's worktree; the flag itself is not persisted,
+	// but the worktree sits at the plan's conventional path.
+	if data.Worktree.RepoPath != "" && data.Worktree.BranchName != "" &&
+		data.Worktree.WorktreePath == git.TaskWorktreePath(data.Worktree.RepoPath, data.Worktree.BranchName) {
+		instance.sharedWorktree = true
+	}
 
 	if instance.Paused() {
 		// Paused instances keep the session struct ready but do not reattach.
@@ -255,6 +319,8 @@ type InstanceOptions struct {
 	ExecutionMode ExecutionMode
 	// AutoYes enables automatic confirmation of agent prompts.
 	AutoYes bool
+	// AutoYesPatterns restricts AutoYes to prompts matching these patterns.
+	AutoYesPatterns []string
 	// SkipPermissions enables --dangerously-skip-permissions for Claude.
 	SkipPermissions bool
 	// TaskFile binds this instance to a plan from plan-state.
@@ -269,6 +335,10 @@ type InstanceOptions struct {
 	PeerCount int
 	// ReviewCycle is the 1-indexed review/fix cycle number (0 = not a cycle instance).
 	ReviewCycle int
+	// RecordSessions records the agent session to ~/.kasmos/recordings on start.
+	RecordSessions bool
+	// Container runs the agent inside a docker image or devcontainer.
+	Container config.ContainerConfig
 }
 
 // NewInstance constructs a new unstarted Instance from the given options.
@@ -292,6 +362,7 @@ func NewInstance(opts InstanceOptions) (*Instance, error) {
 		CreatedAt:       now,
 		UpdatedAt:       now,
 		AutoYes:         opts.AutoYes,
+		AutoYesPatterns: opts.AutoYesPatterns,
 		SkipPermissions: opts.SkipPermissions,
 		TaskFile:        opts.TaskFile,
 		AgentType:       opts.AgentType,
@@ -299,6 +370,8 @@ func NewInstance(opts InstanceOptions) (*Instance, error) {
 		WaveNumber:      opts.WaveNumber,
 		PeerCount:       opts.PeerCount,
 		ReviewCycle:     opts.ReviewCycle,
+		RecordSessions:  opts.RecordSessions,
+		Container:       opts.Container,
 	}, nil
 }
 
@@ -355,9 +428,26 @@ func (i *Instance) SetStatus(status Status) {
 		i.AwaitingWork = false
 	}
 
+	// A restored instance is already Ready, so start its idle clock on the
+	// first Ready report too.
+	if status != Ready {
+		i.IdleSince = time.Time{}
+	} else if i.Status != Ready || i.IdleSince.IsZero() {
+		i.IdleSince = time.Now()
+	}
+
 	i.Status = status
 }
 
+// IdleFor reports how long the instance has been Ready as of now, or 0 when
+// it is not Ready.
+func (i *Instance) IdleFor(now time.Time) time.Duration {
+	if i.Status != Ready || i.IdleSince.IsZero() {
+		return 0
+	}
+	return now.Sub(i.IdleSince)
+}
+
 // setLoadingProgress updates the loading stage and message shown during startup.
 func (i *Instance) setLoadingProgress(stage int, message string) {
 	i.LoadingStage = stage
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
@@ -32,6 +34,7 @@ func (i *Instance) prepareExecutionSession() ExecutionSession {
 func (i *Instance) transferPromptToCli() {
 	if i.QueuedPrompt != "" && programSupportsCliPrompt(i.Program) {
 		i.executionSession.SetInitialPrompt(i.QueuedPrompt)
+		i.LastPrompt = i.QueuedPrompt
 		i.QueuedPrompt = ""
 	}
 }
@@ -78,6 +81,65 @@ func (i *Instance) configureSessionTitle() {
 	})
 }
 
+// recordingDir resolves the directory session recordings are written to.
+// Tests override it.
+var recordingDir = func() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".kasmos", "recordings"), nil
+}
+
+// recordingFileNameRe matches characters that are unsafe in recording file names.
+var recordingFileNameRe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// configureContainer makes the session run the agent inside the configured
+// container. The repo is mounted alongside the worktree so git still resolves
+// the worktree's .git file.
+func (i *Instance) configureContainer() {
+	if !i.Container.Enabled() {
+		return
+	}
+	if cr, ok := i.executionSession.(containerRunner); ok {
+		cr.SetContainer(tmux.Container{
+			Image:        i.Container.Image,
+			Devcontainer: i.Container.Devcontainer,
+			Mounts:       []string{i.Path},
+		})
+	}
+}
+
+// configureRecording picks a recording file for a freshly started session when
+// RecordSessions is set: a .cast file when asciinema is installed, otherwise a
+// .log file that receives capture-pane snapshots. Recording is best-effort;
+// failures are logged and the session starts unrecorded.
+func (i *Instance) configureRecording() {
+	if !i.RecordSessions {
+		return
+	}
+	rec, ok := i.executionSession.(recorder)
+	if !ok {
+		return
+	}
+	dir, err := recordingDir()
+	if err != nil {
+		log.WarningLog.Printf("session recording disabled for %q: %v", i.Title, err)
+		return
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		log.WarningLog.Printf("session recording disabled for %q: %v", i.Title, err)
+		return
+	}
+	ext := ".log"
+	if tmux.AsciinemaAvailable() {
+		ext = ".cast"
+	}
+	name := recordingFileNameRe.ReplaceAllString(i.Title, "_")
+	i.RecordingPath = filepath.Join(dir, fmt.Sprintf("%s-%s%s", name, time.Now().Format("20060102-150405"), ext))
+	rec.SetRecordingPath(i.RecordingPath)
+}
+
 func dirtyWorktreeContext(worktreePath string) string {
 	if strings.TrimSpace(worktreePath) == "" {
 		return ""
@@ -130,6 +192,11 @@ func (i *Instance) Start(firstTimeSetup bool) error {
 	i.executionSession.SetAgentType(i.AgentType)
 	i.setExecutionTaskEnv()
 	i.configureSessionTitle()
+	i.configureContainer()
+	if firstTimeSetup {
+		i.configureRecording()
+	}
+	i.configureOutputLog()
 
 	// Offset internal progress stages so they map to the overall loading bar.
 	stageBase := 3
@@ -205,6 +272,9 @@ func (i *Instance) StartOnMainBranch() error {
 	i.executionSession.SetAgentType(i.AgentType)
 	i.setExecutionTaskEnv()
 	i.configureSessionTitle()
+	i.configureContainer()
+	i.configureRecording()
+	i.configureOutputLog()
 	i.setProgressFunc(func(stage int, desc string) {
 		i.setLoadingProgress(1+stage, desc)
 	})
@@ -246,6 +316,9 @@ func (i *Instance) StartOnBranch(branch string) error {
 	i.executionSession.SetAgentType(i.AgentType)
 	i.setExecutionTaskEnv()
 	i.configureSessionTitle()
+	i.configureContainer()
+	i.configureRecording()
+	i.configureOutputLog()
 	i.setProgressFunc(func(stage int, desc string) {
 		i.setLoadingProgress(3+stage, desc)
 	})
@@ -307,6 +380,9 @@ func (i *Instance) StartInSharedWorktree(worktree *git.GitWorktree, branch strin
 	i.executionSession.SetAgentType(i.AgentType)
 	i.setExecutionTaskEnv()
 	i.configureSessionTitle()
+	i.configureContainer()
+	i.configureRecording()
+	i.configureOutputLog()
 	i.setProgressFunc(func(stage int, desc string) {
 		i.setLoadingProgress(1+stage, desc)
 	})
@@ -369,9 +445,56 @@ func (i *Instance) StopTmux() {
 	}
 }
 
+// RemoveWorktree removes the instance's own git worktree, preserving the
+// branch, without touching the execution session. It is meant for instances
+// whose session is already gone. Shared and main-branch worktrees are left
+// alone, as is a worktree with uncommitted changes.
+func (i *Instance) RemoveWorktree() error {
+	if !i.started || i.sharedWorktree || i.gitWorktree == nil {
+		return nil
+	}
+	path := i.gitWorktree.GetWorktreePath()
+	if path == "" || path == i.gitWorktree.GetRepoPath() {
+		return nil
+	}
+	if _, err := os.Stat(path); err != nil {
+		return nil
+	}
+	dirty, err := i.gitWorktree.IsDirty()
+	if err != nil {
+		return fmt.Errorf("failed to check if worktree is dirty: %w", err)
+	}
+	if dirty {
+		return fmt.Errorf("kept worktree with uncommitted changes%s", dirtyWorktreeContext(path))
+	}
+	if err := i.gitWorktree.Remove(); err != nil {
+		return fmt.Errorf("failed to remove git worktree: %w", err)
+	}
+	if err := i.gitWorktree.Prune(); err != nil {
+		return fmt.Errorf("failed to prune git worktrees: %w", err)
+	}
+	return nil
+}
+
 // Pause detaches from the session and removes the git worktree, preserving
-// the branch for a later Resume.
+// the branch for a later Resume. The branch name is copied to the clipboard.
 func (i *Instance) Pause() error {
+	if err := i.pause(); err != nil {
+		return err
+	}
+	if i.gitWorktree != nil {
+		_ = clipboard.WriteAll(i.gitWorktree.GetBranchName())
+	}
+	return nil
+}
+
+// PauseQuietly is Pause without the clipboard copy, for pauses the user did
+// not ask for (e.g. idle auto-pause) that must not clobber their clipboard.
+func (i *Instance) PauseQuietly() error {
+	return i.pause()
+}
+
+func (i *Instance) pause() error {
 	if !i.started {
 		return fmt.Errorf("cannot pause instance that has not been started")
 	}
@@ -417,9 +540,6 @@ func (i *Instance) Pause() error {
 	}
 
 	i.SetStatus(Paused)
-	if i.gitWorktree != nil {
-		_ = clipboard.WriteAll(i.gitWorktree.GetBranchName())
-	}
 	return nil
 }
 
@@ -472,6 +592,7 @@ func (i *Instance) Restart() error {
 	i.executionSession.SetAgentType(i.AgentType)
 	i.setExecutionTaskEnv()
 	i.configureSessionTitle()
+	i.configureContainer()
 
 	workDir := i.Path
 	if i.gitWorktree != nil {
@@ -514,12 +635,17 @@ func (i *Instance) Resume() error {
 		return fmt.Errorf("cannot resume: branch is checked out, please switch to a different branch")
 	}
 
-	if err := i.gitWorktree.Setup(); err != nil {
-		log.ErrorLog.Print(err)
-		return fmt.Errorf("failed to setup git worktree: %w", err)
+	// Pausing a plan agent leaves the plan's shared worktree in place, and
+	// recreating it would discard its peers' work, so rejoin it when present.
+	if !i.sharedWorktree || !i.sharedWorktreeExists() {
+		if err := i.gitWorktree.Setup(); err != nil {
+			log.ErrorLog.Print(err)
+			return fmt.Errorf("failed to setup git worktree: %w", err)
+		}
 	}
 
 	worktreePath := i.gitWorktree.GetWorktreePath()
+	i.configureContainer()
 
 	if i.executionSession.DoesSessionExist() {
 		if restoreErr := i.executionSession.Restore(); restoreErr != nil {
@@ -527,7 +653,7 @@ func (i *Instance) Resume() error {
 			// Fall back to a fresh session start.
 			if startErr := i.executionSession.Start(worktreePath); startErr != nil {
 				log.ErrorLog.Print(startErr)
-				if cleanupErr := i.gitWorktree.Cleanup(); cleanupErr != nil {
+				if cleanupErr := i.cleanupResumedWorktree(); cleanupErr != nil {
 					startErr = fmt.Errorf("%v (cleanup: %v)", startErr, cleanupErr)
 					log.ErrorLog.Print(startErr)
 				}
@@ -537,7 +663,7 @@ func (i *Instance) Resume() error {
 	} else {
 		if err := i.executionSession.Start(worktreePath); err != nil {
 			log.ErrorLog.Print(err)
-			if cleanupErr := i.gitWorktree.Cleanup(); cleanupErr != nil {
+			if cleanupErr := i.cleanupResumedWorktree(); cleanupErr != nil {
 				err = fmt.Errorf("%v (cleanup: %v)", err, cleanupErr)
 				log.ErrorLog.Print(err)
 			}
@@ -548,3 +674,22 @@ func (i *Instance) Resume() error {
 	i.SetStatus(Running)
 	return nil
 }
+
+// sharedWorktreeExists reports whether the shared worktree is still checked
+// out on the instance's branch at its recorded path.
+func (i *Instance) sharedWorktreeExists() bool {
+	path, err := git.FindBranchWorktree(i.gitWorktree.GetRepoPath(), i.gitWorktree.GetBranchName())
+	if err != nil || path == "" {
+		return false
+	}
+	return filepath.Clean(path) == filepath.Clean(i.gitWorktree.GetWorktreePath())
+}
+
+// cleanupResumedWorktree undoes Resume's worktree setup after the session
+// failed to start. A shared worktree belongs to the plan and is left alone.
+func (i *Instance) cleanupResumedWorktree() error {
+	if i.sharedWorktree {
+		return nil
+	}
+	return i.gitWorktree.Cleanup()
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/kastheco/kasmos/cmd/cmd_test"
+	"github.com/kastheco/kasmos/session/git"
 	"github.com/kastheco/kasmos/session/tmux"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -68,6 +69,31 @@ func TestStartTransfersQueuedPromptForOpenCode(t *testing.T) {
 
 	// QueuedPrompt should be cleared (transferred to initialPrompt).
 	assert.Empty(t, inst.QueuedPrompt)
+	assert.Equal(t, "Plan auth.", inst.LastPrompt, "the CLI prompt counts as sent")
+}
+
+func TestSendPrompt_RecordsLastPrompt(t *testing.T) {
+	cmdExec := cmd_test.MockCmdExec{
+		RunFunc:    func(cmd *exec.Cmd) error { return nil },
+		OutputFunc: func(cmd *exec.Cmd) ([]byte, error) { return nil, nil },
+	}
+	inst := &Instance{Title: "test-last-prompt", Program: "claude"}
+	inst.MarkStartedForTest()
+	inst.SetTmuxSession(tmux.NewTmuxSessionWithDeps("test-last-prompt", "claude", false, &testPtyFactory{}, cmdExec))
+
+	require.NoError(t, inst.SendPrompt("fix the tests"))
+	assert.Equal(t, "fix the tests", inst.LastPrompt)
+
+	data := inst.ToInstanceData()
+	assert.Equal(t, "fix the tests", data.LastPrompt)
+	restored, err := FromInstanceData(InstanceData{Title: data.Title, Status: Paused, LastPrompt: data.LastPrompt})
+	require.NoError(t, err)
+	assert.Equal(t, "fix the tests", restored.LastPrompt)
+
+	inst.SetStatus(Paused)
+	inst.executionSession = nil
+	require.Error(t, inst.SendPrompt("lost"))
+	assert.Equal(t, "fix the tests", inst.LastPrompt, "a failed send leaves the last prompt alone")
 }
 
 func TestStartKeepsQueuedPromptForAider(t *testing.T) {
@@ -342,3 +368,101 @@ func TestInstance_AttachReturnsErrorForHeadlessExecution(t *testing.T) {
 	// The error originates from the headless session, which reports interactive-only.
 	assert.Contains(t, err.Error(), "interactive")
 }
+
+func TestStartOnMainBranch_RecordSessionsSetsRecordingPath(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "recordings")
+	orig := recordingDir
+	recordingDir = func() (string, error) { return dir, nil }
+	t.Cleanup(func() { recordingDir = orig })
+
+	cmdExec := cmd_test.MockCmdExec{
+		RunFunc:    func(cmd *exec.Cmd) error { return nil },
+		OutputFunc: func(cmd *exec.Cmd) ([]byte, error) { return []byte(""), nil },
+	}
+	es := newMockTmuxSession("rec test", "bash", &testPtyFactory{}, cmdExec)
+	inst := &Instance{
+		Title:            "rec test",
+		Path:             t.TempDir(),
+		Program:          "bash",
+		RecordSessions:   true,
+		executionSession: es,
+	}
+
+	require.NoError(t, inst.StartOnMainBranch())
+	t.Cleanup(func() { _ = es.Close() })
+
+	require.NotEmpty(t, inst.RecordingPath)
+	assert.Equal(t, dir, filepath.Dir(inst.RecordingPath))
+	assert.Contains(t, filepath.Base(inst.RecordingPath), "rec_test-", "unsafe title characters are replaced")
+	wantExt := ".log"
+	if tmux.AsciinemaAvailable() {
+		wantExt = ".cast"
+	}
+	assert.Equal(t, wantExt, filepath.Ext(inst.RecordingPath))
+	assert.Equal(t, inst.RecordingPath, es.s.RecordingPath())
+
+	info, err := os.Stat(dir)
+	require.NoError(t, err, "recording directory must be created")
+	assert.True(t, info.IsDir())
+
+	assert.Equal(t, inst.RecordingPath, inst.ToInstanceData().RecordingPath)
+}
+
+func TestStartOnMainBranch_NoRecordingByDefault(t *testing.T) {
+	cmdExec := cmd_test.MockCmdExec{
+		RunFunc:    func(cmd *exec.Cmd) error { return nil },
+		OutputFunc: func(cmd *exec.Cmd) ([]byte, error) { return []byte(""), nil },
+	}
+	inst := &Instance{
+		Title:            "no-rec",
+		Path:             t.TempDir(),
+		Program:          "bash",
+		executionSession: newMockTmuxSession("no-rec", "bash", &testPtyFactory{}, cmdExec),
+	}
+
+	require.NoError(t, inst.StartOnMainBranch())
+	assert.Empty(t, inst.RecordingPath)
+}
+
+func TestResume_RejoinsSharedPlanWorktree(t *testing.T) {
+	repoPath := setupGitRepo(t)
+	branch := "plan/auth"
+	require.NoError(t, git.EnsureTaskBranch(repoPath, branch))
+	shared := git.NewSharedTaskWorktree(repoPath, branch)
+	require.NoError(t, shared.Setup())
+	t.Cleanup(func() { _ = shared.Cleanup() })
+
+	// A peer's uncommitted work must survive the resume.
+	peerFile := filepath.Join(shared.GetWorktreePath(), "peer.txt")
+	require.NoError(t, os.WriteFile(peerFile, []byte("in progress\n"), 0644))
+
+	inst, err := FromInstanceData(InstanceData{
+		Title:    "auth-coder",
+		Path:     repoPath,
+		Program:  "opencode",
+		Status:   Paused,
+		TaskFile: "auth.md",
+		Worktree: GitWorktreeData{
+			RepoPath:     repoPath,
+			WorktreePath: shared.GetWorktreePath(),
+			SessionName:  "auth-coder",
+			BranchName:   branch,
+		},
+	})
+	require.NoError(t, err)
+
+	cmdExec := cmd_test.MockCmdExec{
+		RunFunc:    func(cmd *exec.Cmd) error { return nil },
+		OutputFunc: func(cmd *exec.Cmd) ([]byte, error) { return []byte(""), nil },
+	}
+	inst.executionSession = newMockTmuxSession(inst.Title, inst.Program, &testPtyFactory{}, cmdExec)
+
+	require.NoError(t, inst.Resume())
+	assert.Equal(t, Running, inst.Status)
+	assert.Equal(t, shared.GetWorktreePath(), inst.GetWorktreePath())
+	assert.FileExists(t, peerFile, "the shared worktree is rejoined, not recreated")
+
+	// Killing the agent leaves the plan's worktree to the plan.
+	require.NoError(t, inst.Kill())
+	assert.DirExists(t, shared.GetWorktreePath())
+}
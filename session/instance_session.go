@@ -55,6 +55,20 @@ func (i *Instance) TapEnter() {
 	}
 }
 
+// ShouldAutoYes reports whether a detected prompt may be confirmed with
+// TapEnter. Without AutoYesPatterns every prompt qualifies; with them only a
+// parsed permission prompt matching one of the patterns does, so anything
+// else keeps blocking until the user answers it.
+func (i *Instance) ShouldAutoYes(prompt *PermissionPrompt) bool {
+	if !i.AutoYes {
+		return false
+	}
+	if len(i.AutoYesPatterns) == 0 {
+		return true
+	}
+	return prompt.MatchesAny(i.AutoYesPatterns)
+}
+
 // Attach connects the caller to the instance's execution session.
 // Returns an error if the instance has not been started.
 // Returns ErrInteractiveOnly for headless instances.
@@ -65,6 +79,17 @@ func (i *Instance) Attach() (chan struct{}, error) {
 	return i.executionSession.Attach()
 }
 
+// AttachReadonly connects the caller to the instance's execution session
+// without forwarding keystrokes to it.
+// Returns an error if the instance has not been started.
+// Returns ErrInteractiveOnly for headless instances.
+func (i *Instance) AttachReadonly() (chan struct{}, error) {
+	if !i.started {
+		return nil, fmt.Errorf("cannot attach instance that has not been started")
+	}
+	return i.executionSession.AttachReadonly()
+}
+
 // SetPreviewSize resizes the detached pane to the given dimensions.
 // Returns an error if the instance is not started or is paused.
 func (i *Instance) SetPreviewSize(width, height int) error {
@@ -105,6 +130,7 @@ func (i *Instance) SendPrompt(prompt string) error {
 	if err := i.executionSession.TapEnter(); err != nil {
 		return fmt.Errorf("error tapping enter: %w", err)
 	}
+	i.LastPrompt = prompt
 	return nil
 }
 
@@ -154,6 +180,18 @@ type InstanceMetadata struct {
 	// TmuxAlive reflects the result of session liveness check (used by the reviewer completion check).
 	TmuxAlive        bool
 	PermissionPrompt *PermissionPrompt
+	// Usage is the agent's self-reported token/cost figures; zero when not shown.
+	Usage TokenUsage
+	// ConflictsChecked is true when the worktree was probed for merge conflicts.
+	ConflictsChecked bool
+	HasConflicts     bool
+	ConflictFiles    []string
+	// HeadSHA is the worktree's HEAD commit for coder agents on their own
+	// feature branch worktree; empty otherwise.
+	HeadSHA string
+	// DetachedChecked is true when the worktree was probed for a detached HEAD.
+	DetachedChecked bool
+	Detached        bool
 }
 
 // CollectMetadata gathers all per-tick data for this instance via subprocess calls.
@@ -168,9 +206,15 @@ func (i *Instance) CollectMetadata() InstanceMetadata {
 	// Single capture call shared by hash check, activity parsing, and preview.
 	m.Updated, m.HasPrompt, m.Content, m.ContentCaptured = i.executionSession.HasUpdatedWithContent()
 
-	// Permission prompt detection — only meaningful when content was actually captured.
+	// Permission prompt and usage detection — only meaningful when content was actually captured.
 	if m.ContentCaptured && m.Content != "" {
 		m.PermissionPrompt = ParsePermissionPrompt(m.Content, i.Program)
+		m.Usage = ParseTokenUsage(m.Content, i.Program)
+	}
+
+	// Append the new part of the capture to the instance's output log.
+	if m.ContentCaptured && m.Updated {
+		i.appendOutputLog(m.Content)
 	}
 
 	// Resource usage via pgrep + ps.
@@ -179,9 +223,46 @@ func (i *Instance) CollectMetadata() InstanceMetadata {
 	// Session liveness check for the reviewer completion logic.
 	m.TmuxAlive = i.TmuxAlive()
 
+	// Detached HEAD detection — for any agent in its own branch worktree.
+	if i.hasBranchWorktree() {
+		if detached, err := i.gitWorktree.IsDetached(); err == nil {
+			m.DetachedChecked, m.Detached = true, detached
+		}
+	}
+
+	// Merge conflict detection — only for agents working on a feature branch worktree.
+	if i.checksConflicts() {
+		has, files, err := i.gitWorktree.HasConflicts()
+		if err == nil {
+			m.ConflictsChecked, m.HasConflicts, m.ConflictFiles = true, has, files
+		}
+		if i.AgentType == AgentTypeCoder {
+			m.HeadSHA, _ = i.gitWorktree.HeadSHA()
+		}
+	}
+
 	return m
 }
 
+// checksConflicts reports whether the metadata tick should probe this
+// instance's worktree for unmerged paths: coder and reviewer agents running in
+// their own feature branch worktree.
+func (i *Instance) checksConflicts() bool {
+	if i.AgentType != AgentTypeCoder && i.AgentType != AgentTypeReviewer {
+		return false
+	}
+	return i.hasBranchWorktree()
+}
+
+// hasBranchWorktree reports whether the instance runs in a worktree of its
+// own (not the main checkout) with a branch it is meant to stay on.
+func (i *Instance) hasBranchWorktree() bool {
+	if i.gitWorktree == nil || i.gitWorktree.GetBranchName() == "" {
+		return false
+	}
+	return i.gitWorktree.GetWorktreePath() != i.gitWorktree.GetRepoPath()
+}
+
 // collectResourceUsage samples CPU and RSS memory for the agent process via pgrep and ps.
 // Returns (cpu%, memMB, ok). Safe to call from a goroutine.
 func (i *Instance) collectResourceUsage() (float64, float64, bool) {
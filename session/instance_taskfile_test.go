@@ -3,6 +3,7 @@ package session
 import (
 	"testing"
 
+	"github.com/kastheco/kasmos/config"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -231,3 +232,34 @@ func TestInstanceData_RoundTripExecutionMode(t *testing.T) {
 		})
 	}
 }
+
+// TestInstanceData_RoundTripContainer verifies a paused instance keeps its
+// container across a restart, so resuming it does not drop the sandbox.
+func TestInstanceData_RoundTripContainer(t *testing.T) {
+	inst, err := NewInstance(InstanceOptions{
+		Title:     "boxed",
+		Path:      "/tmp/repo",
+		Program:   "claude",
+		Container: config.ContainerConfig{Image: "kas-agent:latest"},
+	})
+	if err != nil {
+		t.Fatalf("NewInstance() error = %v", err)
+	}
+	inst.Status = Paused
+
+	data := inst.ToInstanceData()
+	if data.Container == nil || data.Container.Image != "kas-agent:latest" {
+		t.Fatalf("ToInstanceData Container = %+v, want image kas-agent:latest", data.Container)
+	}
+	restored, err := FromInstanceData(data)
+	if err != nil {
+		t.Fatalf("FromInstanceData() error = %v", err)
+	}
+	assert.Equal(t, "kas-agent:latest", restored.Container.Image)
+
+	host, err := NewInstance(InstanceOptions{Title: "host", Path: "/tmp/repo", Program: "claude"})
+	if err != nil {
+		t.Fatalf("NewInstance() error = %v", err)
+	}
+	assert.Nil(t, host.ToInstanceData().Container, "host instances persist no container")
+}
@@ -2,6 +2,7 @@ package session
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -16,3 +17,23 @@ func TestNewInstance_SoloAgentDefaultsFalse(t *testing.T) {
 	require.NoError(t, err)
 	assert.False(t, inst.SoloAgent, "SoloAgent must default to false")
 }
+
+func TestIdleFor_CountsFromBecomingReady(t *testing.T) {
+	inst, err := NewInstance(InstanceOptions{Title: "idle", Path: t.TempDir(), Program: "claude"})
+	require.NoError(t, err)
+	now := time.Now()
+
+	inst.SetStatus(Running)
+	assert.Zero(t, inst.IdleFor(now.Add(time.Hour)), "running instances are never idle")
+
+	inst.SetStatus(Ready)
+	start := inst.IdleSince
+	require.False(t, start.IsZero())
+	inst.SetStatus(Ready) // repeated Ready reports keep the original start
+	assert.Equal(t, start, inst.IdleSince)
+	assert.Equal(t, 10*time.Minute, inst.IdleFor(start.Add(10*time.Minute)))
+
+	inst.SetStatus(Running)
+	assert.True(t, inst.IdleSince.IsZero(), "activity resets the idle clock")
+	assert.Zero(t, inst.IdleFor(start.Add(10*time.Minute)))
+}
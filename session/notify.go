@@ -1,18 +1,86 @@
 package session
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"os/exec"
 	"runtime"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/kastheco/kasmos/config"
+	"github.com/kastheco/kasmos/log"
 )
 
 // NotificationsEnabled controls whether desktop notifications are sent.
 // Set from config at startup.
 var NotificationsEnabled = true
 
-// SendNotification fires a desktop notification. The underlying command is
-// started but not awaited — callers do not block on OS notification delivery.
+// Notifier delivers a short notification to the user. Implementations must not
+// block the caller on delivery.
+type Notifier interface {
+	Notify(title, body string)
+}
+
+var (
+	notifiersMu sync.RWMutex
+	notifiers   = []Notifier{DesktopNotifier{}}
+)
+
+// SetNotifiers replaces the backends SendNotification routes through.
+func SetNotifiers(ns ...Notifier) {
+	notifiersMu.Lock()
+	defer notifiersMu.Unlock()
+	notifiers = ns
+}
+
+// NotifiersFromConfig builds the backends named in cfg.Notifiers. An empty
+// list means desktop only. Unknown names, and "slack" without a webhook URL,
+// are logged and skipped.
+func NotifiersFromConfig(cfg *config.Config) []Notifier {
+	names := cfg.Notifiers
+	if len(names) == 0 {
+		names = []string{config.NotifierDesktop}
+	}
+	var ns []Notifier
+	for _, name := range names {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case config.NotifierDesktop:
+			ns = append(ns, DesktopNotifier{})
+		case config.NotifierSlack:
+			if cfg.SlackWebhookURL == "" {
+				log.WarningLog.Printf("notifier %q needs slack_webhook_url; skipping", name)
+				continue
+			}
+			ns = append(ns, NewSlackNotifier(cfg.SlackWebhookURL))
+		default:
+			log.WarningLog.Printf("unknown notifier %q; skipping", name)
+		}
+	}
+	return ns
+}
+
+// SendNotification fans a notification out to every configured backend.
+// Delivery is fire-and-forget — callers do not block on it.
 func SendNotification(title, body string) {
+	notifiersMu.RLock()
+	ns := notifiers
+	notifiersMu.RUnlock()
+	for _, n := range ns {
+		n.Notify(title, body)
+	}
+}
+
+// DesktopNotifier fires an OS notification via osascript (macOS) or
+// notify-send (Linux). It honours NotificationsEnabled. The underlying command
+// is started but not awaited.
+type DesktopNotifier struct{}
+
+// Notify implements Notifier.
+func (DesktopNotifier) Notify(title, body string) {
 	if !NotificationsEnabled {
 		return
 	}
@@ -24,6 +92,52 @@ func SendNotification(title, body string) {
 	}
 }
 
+// SlackNotifier posts notifications to a Slack incoming webhook.
+type SlackNotifier struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// NewSlackNotifier creates a SlackNotifier for the given incoming-webhook URL.
+func NewSlackNotifier(webhookURL string) *SlackNotifier {
+	return &SlackNotifier{
+		webhookURL: webhookURL,
+		client:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// slackPayload is the incoming-webhook message body.
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+// Notify implements Notifier. The webhook is posted from a goroutine; failures
+// are logged.
+func (s *SlackNotifier) Notify(title, body string) {
+	go func() {
+		if err := s.post(title, body); err != nil {
+			log.WarningLog.Printf("slack notification failed: %v", err)
+		}
+	}()
+}
+
+// post sends a single webhook request, with the title in bold on the first line.
+func (s *SlackNotifier) post(title, body string) error {
+	data, err := json.Marshal(slackPayload{Text: "*" + title + "*\n" + body})
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Post(s.webhookURL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("slack webhook: %s", resp.Status)
+	}
+	return nil
+}
+
 // sendDarwin delivers a notification via osascript on macOS.
 func sendDarwin(title, body string) {
 	script := `display notification "` + escapeAppleScript(body) +
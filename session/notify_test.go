@@ -1,9 +1,16 @@
 package session
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/kastheco/kasmos/config"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEscapeAppleScript(t *testing.T) {
@@ -71,3 +78,68 @@ func TestSendNotificationEnabled(t *testing.T) {
 		SendNotification("klique", "agent finished")
 	})
 }
+
+func TestSlackNotifier_Payload(t *testing.T) {
+	got := make(chan map[string]any, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var payload map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		got <- payload
+	}))
+	defer srv.Close()
+
+	cfg := &config.Config{Notifiers: []string{"slack"}, SlackWebhookURL: srv.URL}
+	ns := NotifiersFromConfig(cfg)
+	require.Len(t, ns, 1)
+	require.IsType(t, &SlackNotifier{}, ns[0])
+
+	ns[0].Notify("kas", "plan complete: auth")
+	select {
+	case payload := <-got:
+		assert.Equal(t, map[string]any{"text": "*kas*\nplan complete: auth"}, payload)
+	case <-time.After(5 * time.Second):
+		t.Fatal("slack webhook was not called")
+	}
+}
+
+func TestSlackNotifier_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	err := NewSlackNotifier(srv.URL).post("kas", "body")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "403")
+}
+
+func TestNotifiersFromConfig_DesktopOnlySkipsNetwork(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+	}))
+	defer srv.Close()
+
+	origEnabled := NotificationsEnabled
+	NotificationsEnabled = false
+	defer func() { NotificationsEnabled = origEnabled }()
+	defer SetNotifiers(DesktopNotifier{})
+
+	for _, names := range [][]string{nil, {"desktop"}} {
+		ns := NotifiersFromConfig(&config.Config{Notifiers: names, SlackWebhookURL: srv.URL})
+		require.Len(t, ns, 1)
+		assert.IsType(t, DesktopNotifier{}, ns[0])
+		SetNotifiers(ns...)
+		SendNotification("kas", "agent finished")
+	}
+	time.Sleep(50 * time.Millisecond)
+	assert.Zero(t, hits.Load())
+}
+
+func TestNotifiersFromConfig_SkipsInvalid(t *testing.T) {
+	ns := NotifiersFromConfig(&config.Config{Notifiers: []string{"slack", "pager", "Desktop"}})
+	require.Len(t, ns, 1)
+	assert.IsType(t, DesktopNotifier{}, ns[0])
+}
@@ -0,0 +1,123 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/kastheco/kasmos/log"
+)
+
+// outputLogDir resolves the directory per-instance output logs are written to.
+// Tests override it.
+var outputLogDir = func() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".kasmos", "logs"), nil
+}
+
+// maxOutputLogBytes is the size at which an output log is rotated to
+// <title>.log.1, replacing any previous rotation. Tests override it.
+var maxOutputLogBytes int64 = 5 << 20
+
+// outputLog appends the new lines of successive pane captures to a log file,
+// so the file holds the agent transcript rather than repeated screens.
+type outputLog struct {
+	path string
+	// last is the previous capture, ANSI-stripped, used to compute the delta.
+	last string
+}
+
+// configureOutputLog picks the output log file for the instance when it has
+// none yet. Restored instances keep the path they were started with.
+func (i *Instance) configureOutputLog() {
+	if i.OutputLogPath != "" {
+		return
+	}
+	dir, err := outputLogDir()
+	if err != nil {
+		log.WarningLog.Printf("output log disabled for %q: %v", i.Title, err)
+		return
+	}
+	name := recordingFileNameRe.ReplaceAllString(i.Title, "_")
+	i.OutputLogPath = filepath.Join(dir, name+".log")
+}
+
+// appendOutputLog logs the part of content not already logged by the previous
+// capture. Logging is best-effort; failures are logged and otherwise ignored.
+func (i *Instance) appendOutputLog(content string) {
+	if i.OutputLogPath == "" {
+		return
+	}
+	if i.outputLog == nil || i.outputLog.path != i.OutputLogPath {
+		i.outputLog = &outputLog{path: i.OutputLogPath}
+	}
+	if err := i.outputLog.append(content); err != nil {
+		log.WarningLog.Printf("output log for %q: %v", i.Title, err)
+	}
+}
+
+// append writes the delta between the previous capture and content.
+func (l *outputLog) append(content string) error {
+	content = strings.TrimRight(ansi.Strip(content), "\n ")
+	delta := outputDelta(l.last, content)
+	l.last = content
+	if delta == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return err
+	}
+	if info, err := os.Stat(l.path); err == nil && info.Size()+int64(len(delta)) > maxOutputLogBytes {
+		if err := os.Rename(l.path, l.path+".1"); err != nil {
+			return err
+		}
+	}
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.WriteString(delta + "\n")
+	return err
+}
+
+// outputDelta returns the lines of cur that were not already shown by prev.
+// A capture is a screenful, so new output either scrolls the old lines up
+// (a suffix of prev reappears as a prefix of cur) or is drawn below or over
+// the tail of an unscrolled screen (prev and cur share a prefix). The longer
+// of the two overlaps is dropped; with no overlap all of cur is new.
+func outputDelta(prev, cur string) string {
+	if cur == prev || cur == "" {
+		return ""
+	}
+	if prev == "" {
+		return cur
+	}
+	p := strings.Split(prev, "\n")
+	c := strings.Split(cur, "\n")
+
+	skip := 0
+	for skip < len(p) && skip < len(c) && p[skip] == c[skip] {
+		skip++
+	}
+	for k := min(len(p), len(c)); k > skip; k-- {
+		if equalLines(p[len(p)-k:], c[:k]) {
+			skip = k
+			break
+		}
+	}
+	return strings.Join(c[skip:], "\n")
+}
+
+func equalLines(a, b []string) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
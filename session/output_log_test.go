@@ -0,0 +1,71 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputDelta(t *testing.T) {
+	tests := []struct {
+		name, prev, cur, want string
+	}{
+		{"first capture", "", "a\nb", "a\nb"},
+		{"unchanged", "a\nb", "a\nb", ""},
+		{"scrolled", "a\nb\nc", "b\nc\nd\ne", "d\ne"},
+		{"appended below", "a\nb", "a\nb\nc", "c"},
+		{"tail redrawn", "a\nb\nworking.", "a\nb\nworking..", "working.."},
+		{"no overlap", "a\nb", "x\ny", "x\ny"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, outputDelta(tt.prev, tt.cur))
+		})
+	}
+}
+
+func TestOutputLog_AppendsOnlyNewLinesFromOverlappingCaptures(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "agent.log")
+	l := &outputLog{path: path}
+
+	require.NoError(t, l.append("\x1b[32m$ make\x1b[0m\ncompiling a\ncompiling b\n"))
+	require.NoError(t, l.append("compiling a\ncompiling b\ncompiling c\ndone\n"))
+	require.NoError(t, l.append("compiling a\ncompiling b\ncompiling c\ndone\n"))
+
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "$ make\ncompiling a\ncompiling b\ncompiling c\ndone\n", string(got))
+}
+
+func TestOutputLog_RotatesWhenFull(t *testing.T) {
+	orig := maxOutputLogBytes
+	maxOutputLogBytes = 16
+	t.Cleanup(func() { maxOutputLogBytes = orig })
+
+	path := filepath.Join(t.TempDir(), "agent.log")
+	l := &outputLog{path: path}
+	require.NoError(t, l.append("0123456789"))
+	require.NoError(t, l.append("abcdefghij"))
+
+	rotated, err := os.ReadFile(path + ".1")
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789\n", string(rotated))
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "abcdefghij\n", string(current))
+}
+
+func TestConfigureOutputLog_KeepsRestoredPath(t *testing.T) {
+	inst := &Instance{Title: "fix: login/bug"}
+	inst.configureOutputLog()
+	dir, err := outputLogDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "fix_login_bug.log"), inst.OutputLogPath)
+
+	restored := &Instance{Title: "fix: login/bug", OutputLogPath: "/tmp/previous.log"}
+	restored.configureOutputLog()
+	assert.Equal(t, "/tmp/previous.log", restored.OutputLogPath)
+}
@@ -1,6 +1,7 @@
 package session
 
 import (
+	"path"
 	"strings"
 
 	"github.com/charmbracelet/x/ansi"
@@ -99,3 +100,28 @@ func ParsePermissionPrompt(content string, program string) *PermissionPrompt {
 
 	return prompt
 }
+
+// MatchesAny reports whether the prompt matches one of patterns. An entry
+// matches when it is a glob (path.Match syntax) matching the prompt's Pattern,
+// or a case-insensitive substring of its Description.
+func (p *PermissionPrompt) MatchesAny(patterns []string) bool {
+	if p == nil {
+		return false
+	}
+	desc := strings.ToLower(p.Description)
+	for _, pat := range patterns {
+		pat = strings.TrimSpace(pat)
+		if pat == "" {
+			continue
+		}
+		if p.Pattern != "" {
+			if ok, _ := path.Match(pat, p.Pattern); ok {
+				return true
+			}
+		}
+		if strings.Contains(desc, strings.ToLower(pat)) {
+			return true
+		}
+	}
+	return false
+}
@@ -68,3 +68,31 @@ stateDefault and opens the modal again.`
 	result := ParsePermissionPrompt(content, "opencode")
 	assert.Nil(t, result, "should not match conversation text without dialog buttons")
 }
+
+func TestPermissionPrompt_MatchesAny(t *testing.T) {
+	prompt := &PermissionPrompt{Description: "Access external directory /tmp", Pattern: "/tmp/*"}
+
+	assert.True(t, prompt.MatchesAny([]string{"/tmp/*"}), "glob matches the pattern")
+	assert.True(t, prompt.MatchesAny([]string{"/*/*"}), "wildcards match within path segments")
+	assert.True(t, prompt.MatchesAny([]string{"EXTERNAL directory"}), "description substring, case-insensitive")
+	assert.False(t, prompt.MatchesAny([]string{"/opt/*", "write file"}))
+	assert.False(t, prompt.MatchesAny([]string{"", "  "}), "blank entries never match")
+	assert.False(t, (*PermissionPrompt)(nil).MatchesAny([]string{"/tmp/*"}))
+}
+
+func TestInstance_ShouldAutoYes(t *testing.T) {
+	matching := &PermissionPrompt{Description: "Access external directory /tmp", Pattern: "/tmp/*"}
+	other := &PermissionPrompt{Description: "Run rm -rf /", Pattern: "rm *"}
+
+	inst := &Instance{}
+	assert.False(t, inst.ShouldAutoYes(matching), "auto-yes off approves nothing")
+
+	inst.AutoYes = true
+	assert.True(t, inst.ShouldAutoYes(other), "no allowlist approves everything")
+	assert.True(t, inst.ShouldAutoYes(nil), "no allowlist approves unparsed prompts")
+
+	inst.AutoYesPatterns = []string{"/tmp/*"}
+	assert.True(t, inst.ShouldAutoYes(matching))
+	assert.False(t, inst.ShouldAutoYes(other), "non-matching prompt stays blocked")
+	assert.False(t, inst.ShouldAutoYes(nil), "unparsed prompt stays blocked")
+}
@@ -0,0 +1,116 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// snapshotDir returns the directory holding named instance-list snapshots.
+// Tests override it.
+var snapshotDir = func() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".kasmos", "snapshots"), nil
+}
+
+// SnapshotPath returns the file a snapshot called name is stored in.
+// Names must be non-empty and must not contain path separators.
+func SnapshotPath(name string) (string, error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("invalid snapshot name %q", name)
+	}
+	dir, err := snapshotDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve snapshot dir: %w", err)
+	}
+	return filepath.Join(dir, name+".json"), nil
+}
+
+// SnapshotRestore reports what became of each instance restored from a
+// snapshot, by title.
+type SnapshotRestore struct {
+	// Live instances still have a running session and are re-adopted.
+	Live []string
+	// Paused instances come back paused, as saved.
+	Paused []string
+	// Exited instances lost their session and come back as exited.
+	Exited []string
+}
+
+// SaveSnapshot writes the persisted instance list (titles, plans, branches,
+// agent types and worktrees — no live session state) to the snapshot called
+// name, replacing any previous one. Returns the number of instances saved.
+func (s *Storage) SaveSnapshot(name string) (int, error) {
+	path, err := SnapshotPath(name)
+	if err != nil {
+		return 0, err
+	}
+	var records []InstanceData
+	if err := json.Unmarshal(s.state.GetInstances(), &records); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal instances: %w", err)
+	}
+	if records == nil {
+		records = []InstanceData{}
+	}
+	raw, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return 0, fmt.Errorf("create snapshot dir: %w", err)
+	}
+	if err := os.WriteFile(path, raw, 0o644); err != nil {
+		return 0, fmt.Errorf("write snapshot: %w", err)
+	}
+	return len(records), nil
+}
+
+// RestoreSnapshot replaces the persisted instance list with the snapshot
+// called name. Instances whose session is still alive are re-adopted on the
+// next load; the others load as exited (see FromInstanceData).
+func (s *Storage) RestoreSnapshot(name string) (SnapshotRestore, error) {
+	var result SnapshotRestore
+	path, err := SnapshotPath(name)
+	if err != nil {
+		return result, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return result, fmt.Errorf("snapshot not found: %s", name)
+		}
+		return result, fmt.Errorf("read snapshot: %w", err)
+	}
+	var records []InstanceData
+	if err := json.Unmarshal(data, &records); err != nil {
+		return result, fmt.Errorf("parse snapshot %s: %w", name, err)
+	}
+
+	for _, rec := range records {
+		switch {
+		case rec.Status == Paused:
+			result.Paused = append(result.Paused, rec.Title)
+		case NewExecutionSession(NormalizeExecutionMode(rec.ExecutionMode), rec.Title, rec.Program, rec.SkipPermissions).DoesSessionExist():
+			result.Live = append(result.Live, rec.Title)
+		default:
+			result.Exited = append(result.Exited, rec.Title)
+		}
+	}
+
+	if records == nil {
+		records = []InstanceData{}
+	}
+	raw, err := json.Marshal(records)
+	if err != nil {
+		return result, fmt.Errorf("failed to marshal instances: %w", err)
+	}
+	if err := s.state.SaveInstances(raw); err != nil {
+		return result, err
+	}
+	return result, nil
+}
@@ -0,0 +1,81 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot_SaveRestoreRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	orig := snapshotDir
+	snapshotDir = func() (string, error) { return dir, nil }
+	t.Cleanup(func() { snapshotDir = orig })
+
+	nonce := time.Now().UnixNano()
+	records := []InstanceData{
+		{
+			Title:     fmt.Sprintf("coder-%d", nonce),
+			Path:      "/repo",
+			Branch:    "plan/auth",
+			Status:    Ready,
+			Program:   "opencode",
+			TaskFile:  "auth",
+			AgentType: "coder",
+			Worktree:  GitWorktreeData{RepoPath: "/repo", WorktreePath: "/repo/.worktrees/auth", BranchName: "plan/auth"},
+		},
+		{
+			Title:     fmt.Sprintf("reviewer-%d", nonce),
+			Path:      "/repo",
+			Branch:    "plan/docs",
+			Status:    Paused,
+			Program:   "claude",
+			TaskFile:  "docs",
+			AgentType: "reviewer",
+		},
+	}
+	raw, err := json.Marshal(records)
+	require.NoError(t, err)
+	storage, err := NewStorage(&mockStateManager{instances: raw})
+	require.NoError(t, err)
+
+	n, err := storage.SaveSnapshot("before-merge")
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.FileExists(t, filepath.Join(dir, "before-merge.json"))
+
+	// Restore into a state whose instance list has since changed.
+	state := &mockStateManager{}
+	target, err := NewStorage(state)
+	require.NoError(t, err)
+	result, err := target.RestoreSnapshot("before-merge")
+	require.NoError(t, err)
+	assert.Empty(t, result.Live)
+	assert.Equal(t, []string{records[0].Title}, result.Exited, "no tmux session is alive for the coder")
+	assert.Equal(t, []string{records[1].Title}, result.Paused)
+
+	var restored []InstanceData
+	require.NoError(t, json.Unmarshal(state.GetInstances(), &restored))
+	assert.Equal(t, records, restored)
+}
+
+func TestSnapshot_RejectsBadNamesAndMissingSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	orig := snapshotDir
+	snapshotDir = func() (string, error) { return dir, nil }
+	t.Cleanup(func() { snapshotDir = orig })
+
+	storage, err := NewStorage(&mockStateManager{})
+	require.NoError(t, err)
+	for _, name := range []string{"", "..", "a/b"} {
+		_, err := storage.SaveSnapshot(name)
+		assert.Error(t, err, name)
+	}
+	_, err = storage.RestoreSnapshot("nope")
+	assert.ErrorContains(t, err, "snapshot not found: nope")
+}
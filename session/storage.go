@@ -35,7 +35,14 @@ type InstanceData struct {
 	ImplementationComplete bool   `json:"implementation_complete,omitempty"`
 	SoloAgent              bool   `json:"solo_agent,omitempty"`
 	QueuedPrompt           string `json:"queued_prompt,omitempty"`
+	LastPrompt             string `json:"last_prompt,omitempty"`
 	ReviewCycle            int    `json:"review_cycle,omitempty"`
+	RecordingPath          string `json:"recording_path,omitempty"`
+	OutputLogPath          string `json:"output_log_path,omitempty"`
+	SortIndex              int    `json:"sort_index,omitempty"`
+
+	// Container is the container the agent runs in; nil runs it on the host.
+	Container *config.ContainerConfig `json:"container,omitempty"`
 
 	Worktree GitWorktreeData `json:"worktree"`
 }
@@ -41,6 +41,14 @@ func (m *mockStateManager) SetHelpScreensSeen(seen uint32) error {
 	return nil
 }
 
+func (m *mockStateManager) GetNavGrouping() string { return "" }
+
+func (m *mockStateManager) SetNavGrouping(string) error { return nil }
+
+func (m *mockStateManager) GetPromptHistory(string) []string { return nil }
+
+func (m *mockStateManager) AddPromptHistory(string, string) error { return nil }
+
 func TestLoadInstances_DropsStaleWaveInstancesWithoutTmuxSession(t *testing.T) {
 	repoDir := t.TempDir()
 	nonce := time.Now().UnixNano()
@@ -9,7 +9,14 @@ import (
 
 func TestMain(m *testing.M) {
 	log.Initialize(false)
+	// Keep output logs written by instances under test out of the real home.
+	logDir, err := os.MkdirTemp("", "kasmos-output-logs")
+	if err != nil {
+		panic(err)
+	}
+	outputLogDir = func() (string, error) { return logDir, nil }
 	code := m.Run()
+	_ = os.RemoveAll(logDir)
 	log.Close()
 	os.Exit(code)
 }
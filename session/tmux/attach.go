@@ -2,6 +2,7 @@ package tmux
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"os"
 	"os/exec"
@@ -31,6 +32,36 @@ var (
 //
 // Returns a channel that is closed when Detach completes.
 func (t *TmuxSession) Attach() (chan struct{}, error) {
+	return t.attach()
+}
+
+// AttachReadonly is like Attach but connects through a read-only tmux client
+// (`tmux attach-session -r`), so keystrokes never reach the agent's pane. The
+// background PTY is swapped for the read-only client; Detach restores it.
+func (t *TmuxSession) AttachReadonly() (chan struct{}, error) {
+	ptmx, err := t.ptyFactory.Start(exec.Command("tmux", "attach-session", "-r", "-t", t.sanitizedName))
+	if err != nil {
+		return nil, fmt.Errorf("error opening read-only PTY: %w", err)
+	}
+	if t.ptmx != nil {
+		_ = t.ptmx.Close()
+	}
+	t.ptmx = ptmx
+
+	ch, err := t.attach()
+	if err != nil {
+		_ = t.ptmx.Close()
+		t.ptmx = nil
+		if restoreErr := t.Restore(); restoreErr != nil {
+			log.ErrorLog.Printf("AttachReadonly: error restoring background PTY: %v", restoreErr)
+		}
+		return nil, err
+	}
+	return ch, nil
+}
+
+// attach wires the calling terminal to the current PTY. See Attach.
+func (t *TmuxSession) attach() (chan struct{}, error) {
 	// Detect and disable outer tmux mouse so the inner session gets raw events.
 	outer := outerTmuxSession()
 	t.outerMouseWasEnabled = outerMouseEnabled(outer)
@@ -12,6 +12,20 @@ type Attacher interface {
 	Attach() (chan struct{}, error)
 }
 
+// ReadonlyAttacher is satisfied by any type that can attach without
+// forwarding input to the session. *session.Instance satisfies this interface
+// via its AttachReadonly method.
+type ReadonlyAttacher interface {
+	AttachReadonly() (chan struct{}, error)
+}
+
+// readonlyAttacher adapts a ReadonlyAttacher to the Attacher interface.
+type readonlyAttacher struct {
+	target ReadonlyAttacher
+}
+
+func (r readonlyAttacher) Attach() (chan struct{}, error) { return r.target.AttachReadonly() }
+
 // AttachExecCommand wraps an Attacher so it can be passed to tea.Exec,
 // allowing bubbletea to properly release the terminal before attach and
 // restore it after detach — preventing stdin contention between bubbletea's
@@ -25,6 +39,15 @@ func NewAttachExecCommand(target Attacher) *AttachExecCommand {
 	return &AttachExecCommand{target: target}
 }
 
+// NewReadonlyAttachExecCommand returns an AttachExecCommand that delegates to
+// target's AttachReadonly.
+func NewReadonlyAttachExecCommand(target ReadonlyAttacher) *AttachExecCommand {
+	if target == nil {
+		return &AttachExecCommand{}
+	}
+	return &AttachExecCommand{target: readonlyAttacher{target: target}}
+}
+
 // Run implements tea.ExecCommand. It calls Attach on the target exactly once,
 // returns any attach error, then blocks until the detach channel is closed.
 func (c *AttachExecCommand) Run() error {
@@ -66,3 +66,35 @@ func TestAttachExecCommand_SetStdinStdoutStderr_NoPanic(t *testing.T) {
 	cmd.SetStdout(nil)
 	cmd.SetStderr(nil)
 }
+
+type stubReadonlyAttacher struct {
+	attach   func() (chan struct{}, error)
+	readonly func() (chan struct{}, error)
+}
+
+func (s stubReadonlyAttacher) Attach() (chan struct{}, error)         { return s.attach() }
+func (s stubReadonlyAttacher) AttachReadonly() (chan struct{}, error) { return s.readonly() }
+
+func TestReadonlyAttachExecCommand_UsesAttachReadonly(t *testing.T) {
+	detachCh := make(chan struct{})
+	close(detachCh)
+	var readonlyCalled atomic.Bool
+
+	cmd := NewReadonlyAttachExecCommand(stubReadonlyAttacher{
+		attach: func() (chan struct{}, error) {
+			t.Fatal("read-only exec command must not call Attach")
+			return nil, nil
+		},
+		readonly: func() (chan struct{}, error) {
+			readonlyCalled.Store(true)
+			return detachCh, nil
+		},
+	})
+
+	require.NoError(t, cmd.Run())
+	require.True(t, readonlyCalled.Load())
+}
+
+func TestReadonlyAttachExecCommand_NilTarget(t *testing.T) {
+	require.Error(t, NewReadonlyAttachExecCommand(nil).Run())
+}
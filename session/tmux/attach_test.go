@@ -41,6 +41,29 @@ func TestDetachSafely_IsIdempotent(t *testing.T) {
 	assert.NoError(t, s.DetachSafely())
 }
 
+func TestAttachReadonly_UsesReadonlyClient(t *testing.T) {
+	ptyFactory := NewMockPtyFactory(t)
+	cmdExec := cmd_test.MockCmdExec{
+		RunFunc:    func(cmd *exec.Cmd) error { return nil },
+		OutputFunc: func(cmd *exec.Cmd) ([]byte, error) { return []byte(""), nil },
+	}
+	s := NewTmuxSessionWithDeps("test-ro", "opencode", false, ptyFactory, cmdExec)
+	require.NoError(t, s.Restore())
+
+	ch, err := s.AttachReadonly()
+	require.NoError(t, err)
+	require.NotNil(t, ch)
+
+	require.Len(t, ptyFactory.cmds, 2)
+	assert.Equal(t, []string{"tmux", "attach-session", "-r", "-t", s.sanitizedName}, ptyFactory.cmds[1].Args)
+
+	s.Detach()
+	<-ch
+	// Detach restores the regular (read-write) background client.
+	require.Len(t, ptyFactory.cmds, 3)
+	assert.NotContains(t, ptyFactory.cmds[2].Args, "-r")
+}
+
 func TestUpdateWindowSize_NilPTY(t *testing.T) {
 	s := NewTmuxSessionWithDeps("test-winsize", "opencode", false, NewMockPtyFactory(t), cmd_test.NewMockExecutor())
 	// ptmx is nil — updateWindowSize should be a no-op.
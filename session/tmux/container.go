@@ -0,0 +1,67 @@
+package tmux
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Container describes the container a session's program runs in. The zero
+// value runs the program directly in the tmux pane.
+type Container struct {
+	// Image is the docker image to `docker run` the program in.
+	Image string
+	// Devcontainer runs the program with `devcontainer exec` when the work
+	// directory has a devcontainer configuration; otherwise Image applies.
+	Devcontainer bool
+	// Mounts are extra host paths bind-mounted at the same path, alongside the
+	// work directory (e.g. the repo a worktree's .git file points into).
+	Mounts []string
+}
+
+// SetContainer makes the next Start run the program inside c.
+func (t *TmuxSession) SetContainer(c Container) {
+	t.container = c
+}
+
+// hasDevcontainerConfig reports whether dir has a devcontainer configuration.
+func hasDevcontainerConfig(dir string) bool {
+	for _, p := range []string{
+		filepath.Join(dir, ".devcontainer", "devcontainer.json"),
+		filepath.Join(dir, ".devcontainer.json"),
+	} {
+		if _, err := os.Stat(p); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapForContainer returns program wrapped in a container exec against
+// workDir when a container is configured; otherwise program is returned
+// unchanged. Paths are mounted at their host locations so the absolute paths
+// kasmos hands the agent (prompt files, logs) resolve inside the container.
+func (t *TmuxSession) wrapForContainer(program, workDir string) string {
+	c := t.container
+	inner := "sh -c " + shellEscapeSingleQuote(program)
+	if c.Devcontainer && hasDevcontainerConfig(workDir) {
+		folder := shellEscapeSingleQuote(workDir)
+		return fmt.Sprintf("devcontainer up --workspace-folder %s >/dev/null && devcontainer exec --workspace-folder %s %s",
+			folder, folder, inner)
+	}
+	if c.Image == "" {
+		return program
+	}
+	args := []string{"docker run --rm -it"}
+	seen := map[string]bool{}
+	for _, p := range append([]string{workDir}, c.Mounts...) {
+		if p == "" || seen[p] {
+			continue
+		}
+		seen[p] = true
+		args = append(args, "-v", shellEscapeSingleQuote(p+":"+p))
+	}
+	args = append(args, "-w", shellEscapeSingleQuote(workDir), shellEscapeSingleQuote(c.Image), inner)
+	return strings.Join(args, " ")
+}
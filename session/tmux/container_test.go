@@ -0,0 +1,36 @@
+package tmux
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapForContainer(t *testing.T) {
+	s := newTmuxSession("box", "claude", false, NewMockPtyFactory(t), nil)
+	assert.Equal(t, "KASMOS_MANAGED=1 claude", s.wrapForContainer("KASMOS_MANAGED=1 claude", "/wt"),
+		"no container leaves the program unchanged")
+
+	s.SetContainer(Container{Image: "ghcr.io/acme/dev", Mounts: []string{"/repo", "/wt"}})
+	assert.Equal(t,
+		`docker run --rm -it -v '/wt:/wt' -v '/repo:/repo' -w '/wt' 'ghcr.io/acme/dev' sh -c 'KASMOS_MANAGED=1 claude --prompt '\''hi'\'''`,
+		s.wrapForContainer("KASMOS_MANAGED=1 claude --prompt 'hi'", "/wt"))
+
+	// Devcontainer mode without a devcontainer config falls back to the image,
+	// or to the host when no image is set.
+	plain := t.TempDir()
+	s.SetContainer(Container{Devcontainer: true, Image: "ghcr.io/acme/dev"})
+	assert.Contains(t, s.wrapForContainer("claude", plain), "docker run --rm -it")
+	s.SetContainer(Container{Devcontainer: true})
+	assert.Equal(t, "claude", s.wrapForContainer("claude", plain))
+
+	dev := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dev, ".devcontainer"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dev, ".devcontainer", "devcontainer.json"), []byte("{}"), 0o644))
+	assert.Equal(t,
+		"devcontainer up --workspace-folder '"+dev+"' >/dev/null && devcontainer exec --workspace-folder '"+dev+"' sh -c 'claude'",
+		s.wrapForContainer("claude", dev))
+}
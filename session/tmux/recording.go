@@ -0,0 +1,104 @@
+package tmux
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/kastheco/kasmos/log"
+)
+
+// RecordingSnapshotInterval is how often the capture-pane fallback appends a
+// snapshot to a .log recording when asciinema is not installed.
+const RecordingSnapshotInterval = 5 * time.Second
+
+// asciinemaLookPath resolves the asciinema binary. Tests override it.
+var asciinemaLookPath = exec.LookPath
+
+// AsciinemaAvailable reports whether the asciinema binary is on PATH.
+func AsciinemaAvailable() bool {
+	_, err := asciinemaLookPath("asciinema")
+	return err == nil
+}
+
+// SetRecordingPath enables session recording for the next Start. A path ending
+// in ".cast" wraps the program with `asciinema rec`; any other path receives
+// periodic capture-pane snapshots instead.
+func (t *TmuxSession) SetRecordingPath(path string) {
+	t.recordingPath = path
+}
+
+// RecordingPath returns the file this session records to, or "" when
+// recording is disabled.
+func (t *TmuxSession) RecordingPath() string {
+	return t.recordingPath
+}
+
+// isCastRecording reports whether the session records through asciinema.
+func (t *TmuxSession) isCastRecording() bool {
+	return strings.HasSuffix(t.recordingPath, ".cast")
+}
+
+// wrapForRecording returns program wrapped in `asciinema rec` when the session
+// records to a cast file; otherwise program is returned unchanged.
+func (t *TmuxSession) wrapForRecording(program string) string {
+	if !t.isCastRecording() {
+		return program
+	}
+	return fmt.Sprintf("asciinema rec -q --overwrite -c %s %s",
+		shellEscapeSingleQuote(program), shellEscapeSingleQuote(t.recordingPath))
+}
+
+// startSnapshotRecording launches the capture-pane fallback recorder. Each
+// tick appends the pane content to the recording file when it has changed
+// since the previous snapshot. The loop stops when Close is called.
+func (t *TmuxSession) startSnapshotRecording() {
+	if t.recordingPath == "" || t.isCastRecording() || t.recordingStop != nil {
+		return
+	}
+	stop := make(chan struct{})
+	t.recordingStop = stop
+	path := t.recordingPath
+
+	go func() {
+		ticker := time.NewTicker(RecordingSnapshotInterval)
+		defer ticker.Stop()
+		var last string
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			content, err := t.CapturePaneContent()
+			if err != nil || content == last {
+				continue
+			}
+			last = content
+			if err := appendSnapshot(path, content); err != nil {
+				log.WarningLog.Printf("recording snapshot for %s: %v", t.sanitizedName, err)
+			}
+		}
+	}()
+}
+
+// stopSnapshotRecording stops the capture-pane fallback recorder, if running.
+func (t *TmuxSession) stopSnapshotRecording() {
+	if t.recordingStop != nil {
+		close(t.recordingStop)
+		t.recordingStop = nil
+	}
+}
+
+// appendSnapshot appends one timestamped pane snapshot to the log at path.
+func appendSnapshot(path, content string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = fmt.Fprintf(f, "--- %s ---\n%s\n", time.Now().Format(time.RFC3339), content)
+	return err
+}
@@ -0,0 +1,47 @@
+package tmux
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsciinemaAvailable(t *testing.T) {
+	orig := asciinemaLookPath
+	t.Cleanup(func() { asciinemaLookPath = orig })
+
+	asciinemaLookPath = func(string) (string, error) { return "/usr/bin/asciinema", nil }
+	assert.True(t, AsciinemaAvailable())
+
+	asciinemaLookPath = func(string) (string, error) { return "", errors.New("not found") }
+	assert.False(t, AsciinemaAvailable())
+}
+
+func TestWrapForRecording(t *testing.T) {
+	s := newTmuxSession("rec", "claude", false, NewMockPtyFactory(t), nil)
+	assert.Equal(t, "claude", s.wrapForRecording("claude"), "no recording path leaves the program unchanged")
+
+	s.SetRecordingPath("/tmp/rec.log")
+	assert.Equal(t, "claude", s.wrapForRecording("claude"), "snapshot recordings do not wrap the program")
+
+	s.SetRecordingPath("/tmp/it's.cast")
+	assert.Equal(t, `asciinema rec -q --overwrite -c 'KASMOS_MANAGED=1 claude' '/tmp/it'\''s.cast'`,
+		s.wrapForRecording("KASMOS_MANAGED=1 claude"))
+}
+
+func TestAppendSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rec.log")
+	require.NoError(t, appendSnapshot(path, "first"))
+	require.NoError(t, appendSnapshot(path, "second"))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "first\n")
+	assert.Contains(t, string(data), "second\n")
+	assert.Equal(t, 2, strings.Count(string(data), "--- "), "each snapshot gets a timestamp header")
+}
@@ -27,6 +27,7 @@ type Session interface {
 
 	// Attach/Detach
 	Attach() (chan struct{}, error)
+	AttachReadonly() (chan struct{}, error)
 	Detach()
 	DetachSafely() error
 	SetDetachedSize(width, height int) error
@@ -67,6 +67,14 @@ type TmuxSession struct {
 	// It is injected by the instance layer to avoid a direct dependency from tmux → opencodesession.
 	// Called as a goroutine (best-effort, non-blocking) after session startup.
 	titleFunc func(workDir string, beforeStart time.Time, title string)
+	// recordingPath, when non-empty, is the file the session is recorded to.
+	// See SetRecordingPath.
+	recordingPath string
+	// recordingStop stops the capture-pane snapshot recorder; nil when idle.
+	recordingStop chan struct{}
+	// container, when configured, is the container the program runs in.
+	// See SetContainer.
+	container Container
 
 	// Initialized by Start or Restore
 	//
@@ -92,20 +100,46 @@ type TmuxSession struct {
 	rawInputState        *term.State
 }
 
-// TmuxPrefix is the prefix added to all kas-managed tmux session names.
-const TmuxPrefix = "kas_"
+// DefaultTmuxPrefix is the prefix added to kas-managed tmux session names when
+// no tmux_prefix is configured.
+const DefaultTmuxPrefix = "kas_"
+
+// tmuxPrefix is the active session name prefix. It scopes session naming,
+// discovery, counting and cleanup so that separate kasmos checkouts sharing a
+// tmux server do not see each other's sessions. Set once at startup via SetPrefix.
+var tmuxPrefix = DefaultTmuxPrefix
+
+// Prefix returns the active tmux session name prefix.
+func Prefix() string {
+	return tmuxPrefix
+}
+
+// SetPrefix replaces the active tmux session name prefix. An empty prefix
+// selects DefaultTmuxPrefix. Returns a function that restores the previous
+// prefix (useful in tests).
+func SetPrefix(prefix string) func() {
+	if prefix == "" {
+		prefix = DefaultTmuxPrefix
+	}
+	prev := tmuxPrefix
+	tmuxPrefix = prefix
+	return func() { tmuxPrefix = prev }
+}
 
 var whiteSpaceRegex = regexp.MustCompile(`\s+`)
 
-// cleanupSessionsRe matches current kas_ sessions and legacy klique_/hivemind_ sessions.
-var cleanupSessionsRe = regexp.MustCompile(`(?:kas_|klique_|hivemind_).*:`)
+// cleanupSessionsRe matches sessions with the given prefix and legacy
+// klique_/hivemind_ sessions at the start of a `tmux ls` line.
+func cleanupSessionsRe(prefix string) *regexp.Regexp {
+	return regexp.MustCompile(`(?m)^(?:` + regexp.QuoteMeta(prefix) + `|klique_|hivemind_)[^:]*:`)
+}
 
-// toKasTmuxName converts a human-readable name to a kas_-prefixed tmux session name.
+// toKasTmuxName converts a human-readable name to a prefixed tmux session name.
 // Whitespace is removed and dots are replaced with underscores (tmux does this natively).
 func toKasTmuxName(str string) string {
 	str = whiteSpaceRegex.ReplaceAllString(str, "")
 	str = strings.ReplaceAll(str, ".", "_") // tmux replaces all . with _
-	return fmt.Sprintf("%s%s", TmuxPrefix, str)
+	return fmt.Sprintf("%s%s", tmuxPrefix, str)
 }
 
 // ToKasTmuxNamePublic is the exported version of toKasTmuxName for use by the app layer.
@@ -274,6 +308,9 @@ func (t *TmuxSession) Start(workDir string) error {
 			t.taskNumber, t.waveNumber, t.peerCount, program)
 	}
 
+	program = t.wrapForContainer(program, workDir)
+	program = t.wrapForRecording(program)
+
 	t.reportProgress(1, "Creating tmux session...")
 
 	// Record the timestamp immediately before launching the tmux session.
@@ -354,6 +391,8 @@ func (t *TmuxSession) Start(workDir string) error {
 		return fmt.Errorf("error restoring tmux session: %w", err)
 	}
 
+	t.startSnapshotRecording()
+
 	if isClaudeProgram(t.program) || isAiderProgram(t.program) || isGeminiProgram(t.program) || isOpenCodeProgram(t.program) {
 		t.reportProgress(4, "Waiting for program to start...")
 
@@ -455,6 +494,8 @@ func outerMouseEnabled(session string) bool {
 func (t *TmuxSession) Close() error {
 	var errs []error
 
+	t.stopSnapshotRecording()
+
 	if t.ptmx != nil {
 		if err := t.ptmx.Close(); err != nil {
 			errs = append(errs, fmt.Errorf("error closing PTY: %w", err))
@@ -482,7 +523,7 @@ func (t *TmuxSession) DoesSessionExist() bool {
 	return t.cmdExec.Run(existsCmd) == nil
 }
 
-// CleanupSessions kills all tmux sessions that start with the kas prefix.
+// CleanupSessions kills all tmux sessions that start with the active prefix.
 // Also cleans up legacy "hivemind_" and "klique_" sessions from before the rename.
 func CleanupSessions(cmdExec cmd.Executor) error {
 	// First try to list sessions.
@@ -498,7 +539,7 @@ func CleanupSessions(cmdExec cmd.Executor) error {
 		return fmt.Errorf("failed to list tmux sessions: %v", err)
 	}
 
-	matches := cleanupSessionsRe.FindAllString(string(output), -1)
+	matches := cleanupSessionsRe(tmuxPrefix).FindAllString(string(output), -1)
 	for i, match := range matches {
 		matches[i] = match[:strings.Index(match, ":")]
 	}
@@ -515,7 +556,7 @@ func CleanupSessions(cmdExec cmd.Executor) error {
 // OrphanSession represents a kas_ tmux session not tracked by any kasmos Instance.
 type OrphanSession struct {
 	Name     string    // raw tmux session name, e.g. "kas_auth-refactor-implement"
-	Title    string    // human name with "kas_" prefix stripped
+	Title    string    // human name with the session prefix stripped
 	Created  time.Time // session creation time
 	Windows  int       // window count
 	Attached bool      // whether another client is attached
@@ -523,7 +564,7 @@ type OrphanSession struct {
 	Height   int       // pane rows
 }
 
-// DiscoverOrphans lists kas_-prefixed tmux sessions that are NOT in knownNames.
+// DiscoverOrphans lists prefixed tmux sessions that are NOT in knownNames.
 // knownNames should contain the sanitized tmux names of all current Instances.
 func DiscoverOrphans(cmdExec cmd.Executor, knownNames []string) ([]OrphanSession, error) {
 	lsCmd := exec.Command("tmux", "ls", "-F",
@@ -551,7 +592,7 @@ func DiscoverOrphans(cmdExec cmd.Executor, knownNames []string) ([]OrphanSession
 			continue
 		}
 		name := parts[0]
-		if !strings.HasPrefix(name, TmuxPrefix) {
+		if !strings.HasPrefix(name, tmuxPrefix) {
 			continue
 		}
 		if known[name] {
@@ -567,7 +608,7 @@ func DiscoverOrphans(cmdExec cmd.Executor, knownNames []string) ([]OrphanSession
 		width, _ := strconv.Atoi(parts[4])
 		height, _ := strconv.Atoi(parts[5])
 
-		title := strings.TrimPrefix(name, TmuxPrefix)
+		title := strings.TrimPrefix(name, tmuxPrefix)
 		orphans = append(orphans, OrphanSession{
 			Name:     name,
 			Title:    title,
@@ -584,7 +625,7 @@ func DiscoverOrphans(cmdExec cmd.Executor, knownNames []string) ([]OrphanSession
 // SessionInfo represents any kas_ tmux session (managed or orphaned).
 type SessionInfo struct {
 	Name     string    // raw tmux session name, e.g. "kas_auth-refactor-implement"
-	Title    string    // human name with "kas_" prefix stripped
+	Title    string    // human name with the session prefix stripped
 	Created  time.Time // session creation time
 	Windows  int       // window count
 	Attached bool      // whether another client is attached
@@ -593,7 +634,7 @@ type SessionInfo struct {
 	Managed  bool      // true if matched a known instance name
 }
 
-// DiscoverAll lists all kas_-prefixed tmux sessions, marking each as Managed
+// DiscoverAll lists all prefixed tmux sessions, marking each as Managed
 // if its name appears in knownNames. knownNames should contain the sanitized
 // tmux names of all current Instances (e.g. from ToKasTmuxNamePublic).
 func DiscoverAll(cmdExec cmd.Executor, knownNames []string) ([]SessionInfo, error) {
@@ -622,7 +663,7 @@ func DiscoverAll(cmdExec cmd.Executor, knownNames []string) ([]SessionInfo, erro
 			continue
 		}
 		name := parts[0]
-		if !strings.HasPrefix(name, TmuxPrefix) {
+		if !strings.HasPrefix(name, tmuxPrefix) {
 			continue
 		}
 
@@ -637,7 +678,7 @@ func DiscoverAll(cmdExec cmd.Executor, knownNames []string) ([]SessionInfo, erro
 
 		sessions = append(sessions, SessionInfo{
 			Name:     name,
-			Title:    strings.TrimPrefix(name, TmuxPrefix),
+			Title:    strings.TrimPrefix(name, tmuxPrefix),
 			Created:  created,
 			Windows:  windows,
 			Attached: attached,
@@ -676,7 +717,7 @@ func parseClientCount(s string) int {
 	return n
 }
 
-// CountKasSessions returns the number of kas_-prefixed tmux sessions.
+// CountKasSessions returns the number of prefixed tmux sessions.
 // Returns 0 if no tmux server is running or on any error.
 func CountKasSessions(cmdExec cmd.Executor) int {
 	lsCmd := exec.Command("tmux", "ls")
@@ -686,7 +727,7 @@ func CountKasSessions(cmdExec cmd.Executor) int {
 	}
 	count := 0
 	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
-		if strings.HasPrefix(line, TmuxPrefix) {
+		if strings.HasPrefix(line, tmuxPrefix) {
 			count++
 		}
 	}
@@ -119,6 +119,45 @@ func TestCountKasSessions_Simple(t *testing.T) {
 	assert.Equal(t, 2, CountKasSessions(cmdExec))
 }
 
+func TestConfiguredPrefix_ScopesDiscovery(t *testing.T) {
+	restore := SetPrefix("work_")
+	t.Cleanup(restore)
+
+	ls := "work_foo|1740000000|1|0|80|24\nkas_other|1740000000|1|0|80|24\nwork_bar|1740000000|1|1|80|24\n"
+	cmdExec := cmd_test.NewMockExecutor()
+	cmdExec.OutputFunc = func(cmd *exec.Cmd) ([]byte, error) { return []byte(ls), nil }
+
+	assert.Equal(t, "work_my-task", ToKasTmuxNamePublic("my-task"))
+
+	sessions, err := DiscoverAll(cmdExec, []string{"work_foo"})
+	require.NoError(t, err)
+	require.Len(t, sessions, 2, "sessions from another checkout's prefix must be ignored")
+	assert.Equal(t, "foo", sessions[0].Title)
+	assert.True(t, sessions[0].Managed)
+	assert.Equal(t, "bar", sessions[1].Title)
+
+	cmdExec.OutputFunc = func(cmd *exec.Cmd) ([]byte, error) {
+		return []byte("work_foo: 1 windows\nkas_other: 1 windows\nkas_work_x: 1 windows\n"), nil
+	}
+	assert.Equal(t, 1, CountKasSessions(cmdExec))
+
+	var killed []string
+	cmdExec.RunFunc = func(cmd *exec.Cmd) error {
+		if len(cmd.Args) >= 4 && cmd.Args[1] == "kill-session" {
+			killed = append(killed, cmd.Args[3])
+		}
+		return nil
+	}
+	require.NoError(t, CleanupSessions(cmdExec))
+	assert.Equal(t, []string{"work_foo"}, killed)
+}
+
+func TestSetPrefix_EmptyUsesDefault(t *testing.T) {
+	restore := SetPrefix("")
+	t.Cleanup(restore)
+	assert.Equal(t, DefaultTmuxPrefix, Prefix())
+}
+
 func TestStart_CreatesAndRestoresSession(t *testing.T) {
 	ptyFactory := NewMockPtyFactory(t)
 	created := false
@@ -52,10 +52,10 @@ func NewMockPtyFactory(t *testing.T) *MockPtyFactory {
 
 func TestSanitizeName(t *testing.T) {
 	session := NewTmuxSession("asdf", "program", false)
-	require.Equal(t, TmuxPrefix+"asdf", session.sanitizedName)
+	require.Equal(t, DefaultTmuxPrefix+"asdf", session.sanitizedName)
 
 	session = NewTmuxSession("a sd f . . asdf", "program", false)
-	require.Equal(t, TmuxPrefix+"asdf__asdf", session.sanitizedName)
+	require.Equal(t, DefaultTmuxPrefix+"asdf__asdf", session.sanitizedName)
 }
 
 func TestStartTmuxSession(t *testing.T) {
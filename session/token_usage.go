@@ -0,0 +1,75 @@
+package session
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// TokenUsage is the cumulative token count and cost an agent reports for its
+// session. The zero value means no figures were found.
+type TokenUsage struct {
+	Tokens  int
+	CostUSD float64
+}
+
+// tokenUsagePatterns match the usage figures opencode renders in its session
+// header and status line. Each pattern captures the token count then the cost.
+// Several layouts are accepted because the format has changed between releases:
+//
+//	Context: 6.2K, Cost: $0.01
+//	14,210  7% ($0.05)
+//	12.5K tokens · $0.42
+var tokenUsagePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)context:\s*([\d.,]+[km]?)\s*,?\s*cost:\s*\$([\d.]+)`),
+	regexp.MustCompile(`(?i)([\d.,]+[km]?)\s+\d+%\s*\(\$([\d.]+)\)`),
+	regexp.MustCompile(`(?i)([\d.,]+[km]?)\s+tokens?\b.*?\$([\d.]+)`),
+}
+
+// ParseTokenUsage scans pane content for opencode's token/cost figures.
+// The bottom-most matching line wins. Returns the zero value when the program
+// is not opencode or nothing matches, so format changes degrade to "unknown"
+// rather than to wrong numbers.
+func ParseTokenUsage(content string, program string) TokenUsage {
+	if !strings.Contains(strings.ToLower(program), "opencode") {
+		return TokenUsage{}
+	}
+	lines := strings.Split(ansi.Strip(content), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		for _, re := range tokenUsagePatterns {
+			m := re.FindStringSubmatch(lines[i])
+			if m == nil {
+				continue
+			}
+			tokens, ok := parseTokenCount(m[1])
+			if !ok {
+				continue
+			}
+			cost, err := strconv.ParseFloat(strings.TrimSuffix(m[2], "."), 64)
+			if err != nil {
+				continue
+			}
+			return TokenUsage{Tokens: tokens, CostUSD: cost}
+		}
+	}
+	return TokenUsage{}
+}
+
+// parseTokenCount parses counts like "14,210", "6.2K" and "1.5M".
+func parseTokenCount(s string) (int, bool) {
+	s = strings.ToLower(strings.ReplaceAll(s, ",", ""))
+	mult := 1.0
+	switch {
+	case strings.HasSuffix(s, "k"):
+		mult, s = 1e3, strings.TrimSuffix(s, "k")
+	case strings.HasSuffix(s, "m"):
+		mult, s = 1e6, strings.TrimSuffix(s, "m")
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return int(n*mult + 0.5), true
+}
@@ -0,0 +1,68 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTokenUsage(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		program string
+		want    TokenUsage
+	}{
+		{
+			name:    "context and cost status line",
+			content: "  > build the thing\n\n  Context: 6.2K, Cost: $0.01   ctrl+? help",
+			program: "opencode",
+			want:    TokenUsage{Tokens: 6200, CostUSD: 0.01},
+		},
+		{
+			name:    "header with percentage and cost",
+			content: "┃  # Add auth middleware   14,210  7% ($0.05)  v0.5.1\n┃\n┃  working...",
+			program: "/usr/local/bin/opencode --agent coder",
+			want:    TokenUsage{Tokens: 14210, CostUSD: 0.05},
+		},
+		{
+			name:    "tokens and cost",
+			content: "12.5K tokens · $0.42",
+			program: "opencode",
+			want:    TokenUsage{Tokens: 12500, CostUSD: 0.42},
+		},
+		{
+			name:    "ansi styling is stripped",
+			content: "\x1b[38;5;245m1.5M\x1b[0m  61% \x1b[1m($12.80)\x1b[0m",
+			program: "opencode",
+			want:    TokenUsage{Tokens: 1500000, CostUSD: 12.80},
+		},
+		{
+			name:    "bottom-most line wins",
+			content: "Context: 1K, Cost: $0.01\n...\nContext: 2K, Cost: $0.02",
+			program: "opencode",
+			want:    TokenUsage{Tokens: 2000, CostUSD: 0.02},
+		},
+		{
+			name:    "unknown format returns zero",
+			content: "Session usage: lots of words, some money",
+			program: "opencode",
+		},
+		{
+			name:    "other programs are ignored",
+			content: "Context: 6.2K, Cost: $0.01",
+			program: "claude",
+		},
+		{
+			name:    "empty content",
+			program: "opencode",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseTokenUsage(tt.content, tt.program)
+			assert.Equal(t, tt.want.Tokens, got.Tokens)
+			assert.InDelta(t, tt.want.CostUSD, got.CostUSD, 1e-9)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/kastheco/kasmos/config"
+	"github.com/kastheco/kasmos/log"
+	"github.com/kastheco/kasmos/session"
+	"github.com/kastheco/kasmos/session/tmux"
+	"github.com/spf13/cobra"
+)
+
+func newSnapshotCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Save and restore the instance list (~/.kasmos/snapshots)",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "save <name>",
+		Short: "Save the current instance list as a named snapshot",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log.Initialize(false)
+			defer log.Close()
+			return runSnapshotSave(cmd.OutOrStdout(), config.LoadState(), args[0])
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "restore <name>",
+		Short: "Replace the instance list with a named snapshot (run while kas is closed)",
+		Long: `Replaces the stored instance list with a named snapshot. Instances whose
+tmux session is still alive are re-adopted the next time kas starts; the
+others come back as exited. Paused instances stay paused.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log.Initialize(false)
+			defer log.Close()
+			tmux.SetPrefix(config.LoadConfig().TmuxPrefix)
+			return runSnapshotRestore(cmd.OutOrStdout(), config.LoadState(), args[0])
+		},
+	})
+
+	return cmd
+}
+
+func runSnapshotSave(out io.Writer, state config.StateManager, name string) error {
+	storage, err := session.NewStorage(state)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	n, err := storage.SaveSnapshot(name)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "saved %d instance(s) to snapshot '%s'\n", n, name)
+	return nil
+}
+
+func runSnapshotRestore(out io.Writer, state config.StateManager, name string) error {
+	storage, err := session.NewStorage(state)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	result, err := storage.RestoreSnapshot(name)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "restored snapshot '%s': %d live, %d paused, %d exited\n",
+		name, len(result.Live), len(result.Paused), len(result.Exited))
+	for _, title := range result.Live {
+		fmt.Fprintf(out, "  %s → re-adopted\n", title)
+	}
+	for _, title := range result.Paused {
+		fmt.Fprintf(out, "  %s → paused\n", title)
+	}
+	for _, title := range result.Exited {
+		fmt.Fprintf(out, "  %s → exited (session gone)\n", title)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(newSnapshotCmd())
+}
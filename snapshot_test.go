@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kastheco/kasmos/config"
+	"github.com/kastheco/kasmos/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotCmd_SaveAndRestore(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	// Keep the state file written on restore out of this repo's .kasmos.
+	t.Chdir(t.TempDir())
+
+	title := fmt.Sprintf("coder-%d", time.Now().UnixNano())
+	raw, err := json.Marshal([]session.InstanceData{
+		{Title: title, Branch: "plan/auth", Status: session.Ready, Program: "opencode", TaskFile: "auth", AgentType: "coder"},
+	})
+	require.NoError(t, err)
+	state := config.DefaultState()
+	state.InstancesData = raw
+
+	var out bytes.Buffer
+	require.NoError(t, runSnapshotSave(&out, state, "risky"))
+	assert.Equal(t, "saved 1 instance(s) to snapshot 'risky'\n", out.String())
+	assert.FileExists(t, filepath.Join(home, ".kasmos", "snapshots", "risky.json"))
+
+	restored := config.DefaultState()
+	out.Reset()
+	require.NoError(t, runSnapshotRestore(&out, restored, "risky"))
+	assert.Contains(t, out.String(), "restored snapshot 'risky': 0 live, 0 paused, 1 exited")
+	assert.Contains(t, out.String(), title+" → exited (session gone)")
+	assert.JSONEq(t, string(raw), string(restored.GetInstances()))
+}
@@ -6,6 +6,7 @@ import (
 
 	"charm.land/bubbles/v2/viewport"
 	"charm.land/lipgloss/v2"
+	"github.com/kastheco/kasmos/config/auditlog"
 	"github.com/kastheco/kasmos/ui/overlay"
 	"github.com/muesli/reflow/wordwrap"
 )
@@ -272,19 +273,33 @@ func (p *AuditPane) ContentLines() int { return p.bodyLines }
 // ToggleVisible flips the visibility flag.
 func (p *AuditPane) ToggleVisible() { p.visible = !p.visible }
 
-// Audit-pane styles — Rosé Pine Moon palette.
+// Audit-pane styles, built from the active theme by buildAuditStyles.
 var (
-	auditDividerStyle  = lipgloss.NewStyle().Foreground(ColorSubtle)
-	auditMinuteStyle   = lipgloss.NewStyle().Foreground(ColorOverlay)
-	auditMsgStyle      = lipgloss.NewStyle().Foreground(ColorSubtle)
-	auditWarnMsgStyle  = lipgloss.NewStyle().Foreground(ColorGold)
-	auditErrMsgStyle   = lipgloss.NewStyle().Foreground(ColorLove)
-	auditEmptyStyle    = lipgloss.NewStyle().Foreground(ColorMuted)
+	auditDividerStyle  lipgloss.Style
+	auditMinuteStyle   lipgloss.Style
+	auditMsgStyle      lipgloss.Style
+	auditWarnMsgStyle  lipgloss.Style
+	auditErrMsgStyle   lipgloss.Style
+	auditEmptyStyle    lipgloss.Style
 	auditRowPad        = lipgloss.NewStyle().PaddingLeft(1)
-	auditSelectedStyle = lipgloss.NewStyle().Foreground(ColorText).Background(ColorOverlay)
-	auditActionable    = lipgloss.NewStyle().Foreground(ColorIris) // indicator for actionable events
+	auditSelectedStyle lipgloss.Style
+	auditActionable    lipgloss.Style // indicator for actionable events
 )
 
+func init() { OnThemeChange(buildAuditStyles) }
+
+// buildAuditStyles derives the audit-pane styles from the active theme.
+func buildAuditStyles() {
+	auditDividerStyle = lipgloss.NewStyle().Foreground(ColorSubtle)
+	auditMinuteStyle = lipgloss.NewStyle().Foreground(ColorOverlay)
+	auditMsgStyle = lipgloss.NewStyle().Foreground(ColorSubtle)
+	auditWarnMsgStyle = lipgloss.NewStyle().Foreground(ColorGold)
+	auditErrMsgStyle = lipgloss.NewStyle().Foreground(ColorLove)
+	auditEmptyStyle = lipgloss.NewStyle().Foreground(ColorMuted)
+	auditSelectedStyle = lipgloss.NewStyle().Foreground(ColorText).Background(ColorOverlay)
+	auditActionable = lipgloss.NewStyle().Foreground(ColorIris)
+}
+
 // String returns the full rendered output: header divider + viewport body.
 func (p *AuditPane) String() string {
 	return lipgloss.JoinVertical(lipgloss.Left, p.renderHeader(), p.viewport.View())
@@ -405,48 +420,24 @@ func (p *AuditPane) renderBody() string {
 // EventKindIcon maps an event kind string to a display glyph and colour.
 // Used by the app layer when constructing AuditEventDisplay values.
 func EventKindIcon(kind string) (icon string, clr color.Color) {
+	return auditlog.EventKind(kind).Icon(), eventKindColor(kind)
+}
+
+// eventKindColor maps an event kind string to its display colour.
+func eventKindColor(kind string) color.Color {
 	switch kind {
-	case "agent_spawned":
-		return "◆", ColorFoam
-	case "agent_finished":
-		return "✓", ColorGold
-	case "agent_killed":
-		return "✕", ColorLove
-	case "agent_paused":
-		return "⏸", ColorMuted
-	case "agent_resumed":
-		return "▶", ColorFoam
-	case "plan_transition":
-		return "⟳", ColorIris
-	case "plan_created":
-		return "✦", ColorFoam
-	case "plan_merged":
-		return "⇒", ColorGold
-	case "plan_cancelled":
-		return "✕", ColorLove
-	case "wave_started":
-		return "↯", ColorGold
-	case "wave_completed":
-		return "↯", ColorFoam
-	case "wave_failed":
-		return "↯", ColorLove
-	case "prompt_sent":
-		return "→", ColorFoam
-	case "git_push":
-		return "↑", ColorFoam
-	case "pr_created":
-		return "⎇", ColorIris
-	case "permission_detected":
-		return "!", ColorGold
-	case "permission_answered":
-		return "✓", ColorGold
-	case "session_started":
-		return "▶", ColorFoam
-	case "session_stopped":
-		return "■", ColorMuted
-	case "fsm_error", "error":
-		return "!", ColorLove
+	case "agent_spawned", "agent_resumed", "plan_created", "plan_reopened", "wave_completed",
+		"prompt_sent", "git_push", "session_started":
+		return ColorFoam
+	case "agent_finished", "plan_merged", "wave_started",
+		"permission_detected", "permission_answered":
+		return ColorGold
+	case "agent_killed", "plan_cancelled", "wave_failed", "fsm_error", "error",
+		"review_cycle_limit":
+		return ColorLove
+	case "plan_transition", "pr_created":
+		return ColorIris
 	default:
-		return "·", ColorMuted
+		return ColorMuted
 	}
 }
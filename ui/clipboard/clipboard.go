@@ -0,0 +1,57 @@
+// Package clipboard copies text to the system clipboard. It prefers OSC 52
+// escape sequences, which the terminal handles and which therefore work over
+// SSH, and falls back to the native clipboard tools (pbcopy, xclip, xsel,
+// wl-copy) for terminals or payloads OSC 52 can't carry.
+package clipboard
+
+import (
+	"encoding/base64"
+	"os"
+
+	native "github.com/atotto/clipboard"
+)
+
+// MaxOSC52Bytes caps the base64-encoded payload sent over OSC 52. Many
+// terminals silently drop larger sequences, so bigger payloads use the native
+// clipboard only.
+const MaxOSC52Bytes = 100_000
+
+// osc52ChunkSize bounds each piece of the escape sequence so large payloads are
+// written to the terminal incrementally rather than in one huge write.
+const osc52ChunkSize = 4096
+
+// InTmux reports whether the process runs inside tmux, where OSC 52 must be
+// wrapped in a DCS passthrough to reach the outer terminal.
+func InTmux() bool {
+	return os.Getenv("TMUX") != ""
+}
+
+// OSC52Chunks returns the OSC 52 sequence that sets the clipboard to text,
+// split into pieces of at most osc52ChunkSize payload bytes. Writing the
+// pieces back to back produces one complete sequence. When tmux is true the
+// sequence is wrapped in tmux's DCS passthrough. It returns nil when the
+// encoded payload exceeds MaxOSC52Bytes.
+func OSC52Chunks(text string, tmux bool) []string {
+	payload := base64.StdEncoding.EncodeToString([]byte(text))
+	if len(payload) > MaxOSC52Bytes {
+		return nil
+	}
+
+	prefix, suffix := "\x1b]52;c;", "\x07"
+	if tmux {
+		prefix, suffix = "\x1bPtmux;\x1b\x1b]52;c;", "\x07\x1b\\"
+	}
+
+	chunks := []string{prefix}
+	for len(payload) > 0 {
+		n := min(osc52ChunkSize, len(payload))
+		chunks = append(chunks, payload[:n])
+		payload = payload[n:]
+	}
+	return append(chunks, suffix)
+}
+
+// WriteNative copies text with the platform clipboard tool.
+func WriteNative(text string) error {
+	return native.WriteAll(text)
+}
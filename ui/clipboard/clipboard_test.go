@@ -0,0 +1,37 @@
+package clipboard
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOSC52Chunks_EncodesPayload(t *testing.T) {
+	chunks := OSC52Chunks("# plan\nhello", false)
+	assert.Equal(t, []string{"\x1b]52;c;", "IyBwbGFuCmhlbGxv", "\x07"}, chunks)
+}
+
+func TestOSC52Chunks_TmuxPassthrough(t *testing.T) {
+	seq := strings.Join(OSC52Chunks("hi", true), "")
+	assert.Equal(t, "\x1bPtmux;\x1b\x1b]52;c;aGk=\x07\x1b\\", seq)
+}
+
+func TestOSC52Chunks_SplitsLargePayloads(t *testing.T) {
+	text := strings.Repeat("x", 10_000)
+	chunks := OSC52Chunks(text, false)
+	require.Greater(t, len(chunks), 3, "payload must be split")
+	for _, c := range chunks[1 : len(chunks)-1] {
+		assert.LessOrEqual(t, len(c), osc52ChunkSize)
+	}
+	payload := strings.Join(chunks[1:len(chunks)-1], "")
+	decoded, err := base64.StdEncoding.DecodeString(payload)
+	require.NoError(t, err)
+	assert.Equal(t, text, string(decoded))
+}
+
+func TestOSC52Chunks_TooLargeReturnsNil(t *testing.T) {
+	assert.Nil(t, OSC52Chunks(strings.Repeat("x", MaxOSC52Bytes), false))
+}
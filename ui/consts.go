@@ -23,7 +23,13 @@ var blockPeriod = [6]string{
 
 // bannerFrames holds the precomputed gradient-rendered banner strings for each animation frame.
 // Frames progress: base → one period → two periods → three periods (then cycle).
-var bannerFrames = func() []string {
+// Rebuilt whenever the theme (and so the gradient) changes.
+var bannerFrames []string
+
+func init() { OnThemeChange(func() { bannerFrames = buildBannerFrames() }) }
+
+// buildBannerFrames renders every banner frame with the active gradient.
+func buildBannerFrames() []string {
 	base := strings.Split(fallbackBannerRaw, "\n")
 
 	type glyph = [6]string
@@ -46,7 +52,7 @@ var bannerFrames = func() []string {
 		frames[i] = GradientText(strings.Join(lines, "\n"), GradientStart, GradientEnd)
 	}
 	return frames
-}()
+}
 
 // FallBackText returns the precomputed gradient banner string for the given animation tick.
 // The frame index wraps around automatically.
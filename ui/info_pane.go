@@ -10,18 +10,35 @@ import (
 
 	"charm.land/bubbles/v2/viewport"
 	"charm.land/lipgloss/v2"
+	"github.com/kastheco/kasmos/session"
 	zone "github.com/lrstanley/bubblezone/v2"
 )
 
 var (
+	infoSectionStyle lipgloss.Style
+	infoDividerStyle lipgloss.Style
+	infoLabelStyle   lipgloss.Style
+	infoValueStyle   lipgloss.Style
+)
+
+func init() { OnThemeChange(buildInfoStyles) }
+
+// buildInfoStyles derives the info-pane styles from the active theme.
+func buildInfoStyles() {
 	infoSectionStyle = lipgloss.NewStyle().Foreground(ColorFoam).Bold(true)
 	infoDividerStyle = lipgloss.NewStyle().Foreground(ColorOverlay)
-	infoLabelStyle   = lipgloss.NewStyle().Foreground(ColorMuted).Width(20)
-	infoValueStyle   = lipgloss.NewStyle().Foreground(ColorText)
-)
+	infoLabelStyle = lipgloss.NewStyle().Foreground(ColorMuted).Width(20)
+	infoValueStyle = lipgloss.NewStyle().Foreground(ColorText)
+}
 
 // InfoData carries display data for the info pane.
 // Populated by the app layer from instance + plan + wave state.
+// StatusChange is one entry of a plan's status timeline.
+type StatusChange struct {
+	Status string
+	At     time.Time
+}
+
 type InfoData struct {
 	// Instance fields
 	Title   string
@@ -30,6 +47,10 @@ type InfoData struct {
 	Path    string
 	Created string
 	Status  string
+	// Recording is the session recording file ("" when not recorded).
+	Recording string
+	// OutputLog is the rolling agent output log ("" when not logged).
+	OutputLog string
 
 	// Plan fields (empty when no plan is associated)
 	PlanName        string
@@ -43,6 +64,8 @@ type InfoData struct {
 	ImplementingAt  time.Time
 	ReviewingAt     time.Time
 	DoneAt          time.Time
+	// PlanHistory is the plan's status timeline, oldest first.
+	PlanHistory []StatusChange
 
 	// Plan summary fields (rendered when plan header row is selected)
 	PlanInstanceCount int
@@ -58,6 +81,22 @@ type InfoData struct {
 	// Resource utilisation
 	CPUPercent float64
 	MemMB      float64
+	// CPUOverThreshold / MemOverThreshold flag usage above the configured alert thresholds.
+	CPUOverThreshold bool
+	MemOverThreshold bool
+
+	// Token usage reported by the agent (0 = unknown), and its sum across
+	// the plan's instances.
+	TokensUsed     int
+	CostUSD        float64
+	PlanTokensUsed int
+	PlanCostUSD    float64
+
+	// Merge conflicts left in the worktree by a stalled merge or rebase
+	HasConflicts  bool
+	ConflictFiles []string
+	// Detached is true when the worktree's HEAD has left the instance's branch
+	Detached bool
 
 	// Wave / task context (zero values mean no wave info)
 	AgentType  string
@@ -163,6 +202,33 @@ func statusColor(status string) color.Color {
 	}
 }
 
+// AgentTypeColor returns the accent color for an agent role, or ColorText for
+// an unknown or empty role.
+func AgentTypeColor(agentType string) color.Color {
+	if c, ok := agentTypeColor(agentType); ok {
+		return c
+	}
+	return ColorText
+}
+
+// agentTypeColor maps the known agent roles to distinct theme accents.
+func agentTypeColor(agentType string) (color.Color, bool) {
+	switch agentType {
+	case session.AgentTypePlanner:
+		return ColorIris, true
+	case session.AgentTypeCoder:
+		return ColorFoam, true
+	case session.AgentTypeReviewer:
+		return ColorGold, true
+	case session.AgentTypeFixer:
+		return ColorRose, true
+	case session.AgentTypeElaborator:
+		return ColorPine, true
+	default:
+		return nil, false
+	}
+}
+
 // renderRow renders a single label+value row.
 func (p *InfoPane) renderRow(label, value string) string {
 	valW := p.width - lipgloss.Width(infoLabelStyle.Render(label))
@@ -177,13 +243,47 @@ func (p *InfoPane) renderRow(label, value string) string {
 
 // renderStatusRow renders a label+value row where the value is coloured by status.
 func (p *InfoPane) renderStatusRow(label, value string) string {
+	return p.renderColoredRow(label, value, statusColor(value))
+}
+
+// renderColoredRow renders a label+value row with the value in fg.
+func (p *InfoPane) renderColoredRow(label, value string, fg color.Color) string {
+	valW := p.width - lipgloss.Width(infoLabelStyle.Render(label))
+	if valW < 10 {
+		valW = 10
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		infoLabelStyle.Render(label),
+		lipgloss.NewStyle().Foreground(fg).Width(valW).Render(value),
+	)
+}
+
+// renderResourceRow renders a resource usage row, coloured red when over its
+// alert threshold.
+func (p *InfoPane) renderResourceRow(label, value string, over bool) string {
+	if !over {
+		return p.renderRow(label, value)
+	}
 	valW := p.width - lipgloss.Width(infoLabelStyle.Render(label))
 	if valW < 10 {
 		valW = 10
 	}
 	return lipgloss.JoinHorizontal(lipgloss.Top,
 		infoLabelStyle.Render(label),
-		lipgloss.NewStyle().Foreground(statusColor(value)).Width(valW).Render(value),
+		lipgloss.NewStyle().Foreground(ColorLove).Width(valW).Render(value),
+	)
+}
+
+// renderConflictRow renders the merge conflict warning with the unmerged files.
+func (p *InfoPane) renderConflictRow() string {
+	valW := p.width - lipgloss.Width(infoLabelStyle.Render("conflicts"))
+	if valW < 10 {
+		valW = 10
+	}
+	value := "⚠ " + strings.Join(p.data.ConflictFiles, ", ")
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		infoLabelStyle.Render("conflicts"),
+		lipgloss.NewStyle().Foreground(ColorLove).Width(valW).Render(value),
 	)
 }
 
@@ -202,6 +302,24 @@ func statusToGlyph(status string) (string, color.Color) {
 	}
 }
 
+// FormatTokenUsage renders a token count and cost as "12.5K tok · $0.42",
+// omitting whichever figure is zero. Returns "" when both are zero.
+func FormatTokenUsage(tokens int, costUSD float64) string {
+	var parts []string
+	switch {
+	case tokens >= 1_000_000:
+		parts = append(parts, fmt.Sprintf("%.1fM tok", float64(tokens)/1e6))
+	case tokens >= 1_000:
+		parts = append(parts, fmt.Sprintf("%.1fK tok", float64(tokens)/1e3))
+	case tokens > 0:
+		parts = append(parts, fmt.Sprintf("%d tok", tokens))
+	}
+	if costUSD > 0 {
+		parts = append(parts, fmt.Sprintf("$%.2f", costUSD))
+	}
+	return strings.Join(parts, " · ")
+}
+
 func formatPhaseTime(ts time.Time) string {
 	if ts.IsZero() {
 		return "—"
@@ -285,6 +403,19 @@ func (p *InfoPane) renderLifecycleSection() string {
 	return strings.Join(rows, "\n")
 }
 
+// renderHistorySection renders the plan's status timeline, newest first.
+func (p *InfoPane) renderHistorySection() string {
+	rows := []string{
+		infoSectionStyle.Render("history"),
+		p.renderDivider(),
+	}
+	for i := len(p.data.PlanHistory) - 1; i >= 0; i-- {
+		change := p.data.PlanHistory[i]
+		rows = append(rows, p.renderRow(change.Status, formatPhaseTime(change.At.Local())))
+	}
+	return strings.Join(rows, "\n")
+}
+
 func asciiProgressBar(total, done int) string {
 	barWidth := 10
 	if total <= 0 {
@@ -348,6 +479,9 @@ func (p *InfoPane) renderPlanSection() string {
 	if p.data.PlanCreated != "" {
 		rows = append(rows, p.renderRow("created", p.data.PlanCreated))
 	}
+	if usage := FormatTokenUsage(p.data.PlanTokensUsed, p.data.PlanCostUSD); usage != "" {
+		rows = append(rows, p.renderRow("usage", usage))
+	}
 	return strings.Join(rows, "\n")
 }
 
@@ -361,7 +495,7 @@ func (p *InfoPane) renderInstanceSection() string {
 		rows = append(rows, p.renderRow("title", p.data.Title))
 	}
 	if p.data.AgentType != "" {
-		rows = append(rows, p.renderRow("role", p.data.AgentType))
+		rows = append(rows, p.renderColoredRow("role", p.data.AgentType, AgentTypeColor(p.data.AgentType)))
 	}
 	if p.data.Program != "" {
 		rows = append(rows, p.renderRow("program", p.data.Program))
@@ -372,12 +506,24 @@ func (p *InfoPane) renderInstanceSection() string {
 	if p.data.Branch != "" {
 		rows = append(rows, p.renderRow("branch", p.data.Branch))
 	}
+	if p.data.Detached {
+		rows = append(rows, p.renderColoredRow("head", "⚠ detached, not on branch", ColorGold))
+	}
+	if p.data.HasConflicts {
+		rows = append(rows, p.renderConflictRow())
+	}
 	if p.data.Path != "" {
 		rows = append(rows, p.renderRow("path", p.data.Path))
 	}
 	if p.data.Created != "" {
 		rows = append(rows, p.renderRow("created", p.data.Created))
 	}
+	if p.data.Recording != "" {
+		rows = append(rows, p.renderRow("recording", p.data.Recording))
+	}
+	if p.data.OutputLog != "" {
+		rows = append(rows, p.renderRow("log", p.data.OutputLog))
+	}
 	if p.data.PlanGoal != "" {
 		rows = append(rows, p.renderRow("goal", p.data.PlanGoal))
 	}
@@ -392,8 +538,11 @@ func (p *InfoPane) renderInstanceSection() string {
 		rows = append(rows, p.renderRow("task", taskText))
 	}
 	if p.data.CPUPercent > 0 || p.data.MemMB > 0 {
-		rows = append(rows, p.renderRow("cpu", fmt.Sprintf("%.0f%%", math.Round(p.data.CPUPercent))))
-		rows = append(rows, p.renderRow("memory", fmt.Sprintf("%.0fM", p.data.MemMB)))
+		rows = append(rows, p.renderResourceRow("cpu", fmt.Sprintf("%.0f%%", math.Round(p.data.CPUPercent)), p.data.CPUOverThreshold))
+		rows = append(rows, p.renderResourceRow("memory", fmt.Sprintf("%.0fM", p.data.MemMB), p.data.MemOverThreshold))
+	}
+	if usage := FormatTokenUsage(p.data.TokensUsed, p.data.CostUSD); usage != "" {
+		rows = append(rows, p.renderRow("usage", usage))
 	}
 	return strings.Join(rows, "\n")
 }
@@ -423,6 +572,9 @@ func (p *InfoPane) renderPlanSummary() string {
 	rows := []string{p.renderPlanSection()}
 	rows = append(rows, p.renderGoalSection())
 	rows = append(rows, p.renderLifecycleSection())
+	if len(p.data.PlanHistory) > 0 {
+		rows = append(rows, p.renderHistorySection())
+	}
 	rows = append(rows, p.renderProgressSection())
 	if p.data.ReviewOutcome != "" {
 		rows = append(rows, p.renderReviewSection())
@@ -475,7 +627,17 @@ func (p *InfoPane) renderWaveSection() string {
 			glyph, col = "○", ColorMuted
 		}
 		icon := lipgloss.NewStyle().Foreground(col).Render(glyph)
-		rows = append(rows, infoLabelStyle.Render(fmt.Sprintf("task %d", t.Number))+icon+" "+t.State)
+		row := infoLabelStyle.Render(fmt.Sprintf("task %d", t.Number)) + icon + " " + t.State
+		if t.State == "failed" && p.data.IsPlanHeaderSelected {
+			// From the plan header, failed tasks can be retried on their own by
+			// clicking the row or, for single-digit task numbers, pressing the number.
+			hint := "click to retry"
+			if t.Number >= 1 && t.Number <= 9 {
+				hint = fmt.Sprintf("%d or click to retry", t.Number)
+			}
+			row = zone.Mark(WaveTaskZoneID(t.Number), row+infoValueStyle.Render(" · "+hint))
+		}
+		rows = append(rows, row)
 	}
 	return strings.Join(rows, "\n")
 }
@@ -1,11 +1,14 @@
 package ui
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"testing"
 
 	"charm.land/lipgloss/v2"
+	"github.com/kastheco/kasmos/session"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -38,6 +41,55 @@ func TestInfoPane_AdHocInstance(t *testing.T) {
 	assert.NotContains(t, output, "plan")
 }
 
+func TestInfoPane_RecordingRow(t *testing.T) {
+	p := NewInfoPane()
+	p.SetSize(100, 24)
+	p.SetData(InfoData{HasInstance: true, Title: "fix", Recording: "/home/kas/.kasmos/recordings/fix.cast"})
+	output := p.String()
+	assert.Contains(t, output, "recording")
+	assert.Contains(t, output, "fix.cast")
+
+	p.SetData(InfoData{HasInstance: true, Title: "fix"})
+	assert.NotContains(t, p.String(), "recording")
+}
+
+func TestInfoPane_UsageRows(t *testing.T) {
+	p := NewInfoPane()
+	p.SetSize(100, 40)
+	p.SetData(InfoData{
+		HasInstance: true, HasPlan: true, Title: "coder", PlanName: "auth",
+		TokensUsed: 6200, CostUSD: 0.01, PlanTokensUsed: 20700, PlanCostUSD: 0.43,
+	})
+	output := p.String()
+	assert.Contains(t, output, "6.2K tok · $0.01")
+	assert.Contains(t, output, "20.7K tok · $0.43")
+
+	p.SetData(InfoData{HasInstance: true, Title: "coder"})
+	assert.NotContains(t, p.String(), "usage")
+}
+
+func TestInfoPane_ConflictRow(t *testing.T) {
+	p := NewInfoPane()
+	p.SetSize(100, 24)
+	p.SetData(InfoData{HasInstance: true, Title: "fix", HasConflicts: true, ConflictFiles: []string{"a.go", "b.go"}})
+	output := p.String()
+	assert.Contains(t, output, "conflicts")
+	assert.Contains(t, output, "a.go, b.go")
+
+	p.SetData(InfoData{HasInstance: true, Title: "fix"})
+	assert.NotContains(t, p.String(), "conflicts")
+}
+
+func TestInfoPane_DetachedRow(t *testing.T) {
+	p := NewInfoPane()
+	p.SetSize(100, 24)
+	p.SetData(InfoData{HasInstance: true, Title: "fix", Branch: "plan/fix", Detached: true})
+	assert.Contains(t, p.String(), "detached")
+
+	p.SetData(InfoData{HasInstance: true, Title: "fix", Branch: "plan/fix"})
+	assert.NotContains(t, p.String(), "detached")
+}
+
 func TestInfoPane_PlanBoundInstance(t *testing.T) {
 	p := NewInfoPane()
 	p.SetSize(80, 24)
@@ -90,6 +142,31 @@ func TestInfoPane_WaveProgress(t *testing.T) {
 	assert.Contains(t, output, "○")
 }
 
+func TestInfoPane_WaveProgressRetryHint(t *testing.T) {
+	p := NewInfoPane()
+	p.SetSize(80, 40)
+	data := InfoData{
+		IsPlanHeaderSelected: true,
+		PlanName:             "test-plan",
+		PlanStatus:           "implementing",
+		WaveTasks: []WaveTaskInfo{
+			{Number: 3, State: "failed"},
+			{Number: 12, State: "failed"},
+			{Number: 4, State: "complete"},
+		},
+	}
+	p.SetData(data)
+	output := p.String()
+	assert.Contains(t, output, "3 or click to retry")
+	assert.Contains(t, output, "· click to retry", "task numbers above 9 have no key")
+
+	data.IsPlanHeaderSelected = false
+	data.HasInstance = true
+	data.HasPlan = true
+	p.SetData(data)
+	assert.NotContains(t, p.String(), "to retry", "retry is offered from the plan header only")
+}
+
 func TestInfoPane_Scrolling(t *testing.T) {
 	p := NewInfoPane()
 	p.SetSize(80, 5)
@@ -138,6 +215,30 @@ func TestInfoPane_PlanSummary(t *testing.T) {
 	assert.Contains(t, output, "view plan doc")
 }
 
+func TestInfoPane_PlanHistory(t *testing.T) {
+	pane := NewInfoPane()
+	pane.SetSize(70, 60)
+	pane.SetData(InfoData{IsPlanHeaderSelected: true, PlanName: "p", PlanStatus: "ready"})
+	assert.NotContains(t, pane.String(), "history")
+
+	planned := time.Date(2025, 3, 1, 9, 0, 0, 0, time.Local)
+	pane.SetData(InfoData{
+		IsPlanHeaderSelected: true,
+		PlanName:             "p",
+		PlanStatus:           "ready",
+		PlanHistory: []StatusChange{
+			{Status: "planning", At: planned},
+			{Status: "ready", At: planned.Add(time.Hour)},
+		},
+	})
+	output := pane.String()
+	assert.Contains(t, output, "history")
+	assert.Contains(t, output, "2025-03-01 09:00")
+	assert.Contains(t, output, "2025-03-01 10:00")
+	// Newest first.
+	assert.Less(t, strings.Index(output, "2025-03-01 10:00"), strings.Index(output, "2025-03-01 09:00"))
+}
+
 func TestInfoPane_PlanSummaryWithGoalAndLifecycle(t *testing.T) {
 	pane := NewInfoPane()
 	pane.SetSize(70, 40)
@@ -283,3 +384,35 @@ func TestRenderCompact_ShowsInstanceTitle(t *testing.T) {
 	compact := p.RenderCompact(80)
 	assert.NotEmpty(t, compact)
 }
+
+func TestInfoPane_ResourceRowOverThreshold(t *testing.T) {
+	p := NewInfoPane()
+	p.SetSize(100, 24)
+	p.SetData(InfoData{HasInstance: true, Title: "fix", CPUPercent: 250, MemMB: 512, CPUOverThreshold: true})
+	output := p.String()
+	assert.Contains(t, output, "250%")
+	assert.Contains(t, output, "512M")
+}
+
+func TestAgentTypeColor_DistinctPerRole(t *testing.T) {
+	roles := []string{
+		session.AgentTypePlanner,
+		session.AgentTypeCoder,
+		session.AgentTypeReviewer,
+		session.AgentTypeFixer,
+		session.AgentTypeElaborator,
+	}
+	seen := map[string]string{}
+	for _, role := range roles {
+		c := AgentTypeColor(role)
+		assert.NotEqual(t, ColorText, c, "%s should have an accent color", role)
+		key := fmt.Sprint(c)
+		if prev, dup := seen[key]; dup {
+			t.Errorf("%s and %s share the color %s", prev, role, key)
+		}
+		seen[key] = role
+	}
+
+	assert.Equal(t, ColorText, AgentTypeColor(""))
+	assert.Equal(t, ColorText, AgentTypeColor("custom"))
+}
@@ -11,11 +11,11 @@ import (
 )
 
 // Style definitions for the bottom keybind bar.
-var keyStyle = lipgloss.NewStyle().Foreground(ColorSubtle)
-var descStyle = lipgloss.NewStyle().Foreground(ColorMuted)
-var sepStyle = lipgloss.NewStyle().Foreground(ColorOverlay)
-var actionGroupStyle = lipgloss.NewStyle().Foreground(ColorRose)
-var menuStyle = lipgloss.NewStyle().Foreground(ColorFoam)
+var keyStyle lipgloss.Style
+var descStyle lipgloss.Style
+var sepStyle lipgloss.Style
+var actionGroupStyle lipgloss.Style
+var menuStyle lipgloss.Style
 
 // Separator tokens inserted between keybind items.
 var separator = " • "
@@ -54,6 +54,7 @@ type Menu struct {
 	keyDown            keys.KeyName
 	systemGroupSize    int
 	tmuxSessionCount   int
+	vimMode            bool
 }
 
 // Pre-built option slices for each menu state.
@@ -76,10 +77,25 @@ const (
 var focusModeFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
 
 // Styles specific to focus / interactive mode overlay.
-var focusDotStyle = lipgloss.NewStyle().Foreground(ColorLove).Bold(true)
-var focusLabelStyle = lipgloss.NewStyle().Foreground(ColorLove).Bold(true)
-var focusHintKeyStyle = lipgloss.NewStyle().Foreground(ColorSubtle)
-var focusHintDescStyle = lipgloss.NewStyle().Foreground(ColorMuted)
+var focusDotStyle lipgloss.Style
+var focusLabelStyle lipgloss.Style
+var focusHintKeyStyle lipgloss.Style
+var focusHintDescStyle lipgloss.Style
+
+func init() { OnThemeChange(buildMenuStyles) }
+
+// buildMenuStyles derives the keybind-bar and focus-mode styles from the active theme.
+func buildMenuStyles() {
+	keyStyle = lipgloss.NewStyle().Foreground(ColorSubtle)
+	descStyle = lipgloss.NewStyle().Foreground(ColorMuted)
+	sepStyle = lipgloss.NewStyle().Foreground(ColorOverlay)
+	actionGroupStyle = lipgloss.NewStyle().Foreground(ColorRose)
+	menuStyle = lipgloss.NewStyle().Foreground(ColorFoam)
+	focusDotStyle = lipgloss.NewStyle().Foreground(ColorLove).Bold(true)
+	focusLabelStyle = lipgloss.NewStyle().Foreground(ColorLove).Bold(true)
+	focusHintKeyStyle = lipgloss.NewStyle().Foreground(ColorSubtle)
+	focusHintDescStyle = lipgloss.NewStyle().Foreground(ColorMuted)
+}
 
 // NewMenu constructs a Menu in the StateEmpty state with sensible defaults.
 func NewMenu() *Menu {
@@ -92,6 +108,11 @@ func NewMenu() *Menu {
 	return m
 }
 
+// SetVimMode makes the menu show the vim-mode keys (e.g. x for kill).
+func (m *Menu) SetVimMode(vim bool) {
+	m.vimMode = vim
+}
+
 // SetTmuxSessionCount sets the number of active tmux sessions shown right-aligned.
 func (m *Menu) SetTmuxSessionCount(count int) {
 	m.tmuxSessionCount = count
@@ -343,7 +364,7 @@ func (m *Menu) buildOptionText() string {
 
 	var sb strings.Builder
 	for i, k := range m.options {
-		binding := keys.GlobalkeyBindings[k]
+		binding := keys.Binding(k, m.vimMode)
 		h := binding.Help()
 		label, desc := h.Key, h.Desc
 		if k == keys.KeySpaceExpand {
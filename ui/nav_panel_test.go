@@ -90,6 +90,86 @@ func TestRebuildRows_SoloInstances(t *testing.T) {
 	assert.Equal(t, navRowInstance, n.rows[2].Kind)
 }
 
+func TestMoveSelectedInstance_ReordersWithinGroup(t *testing.T) {
+	n := newTestPanel()
+	plans := []PlanDisplay{{Filename: "plan"}}
+	instances := []*session.Instance{
+		makeInst("a", "plan", session.Running),
+		makeInst("b", "plan", session.Running),
+		makeInst("c", "plan", session.Running),
+		makeInst("solo", "", session.Running),
+	}
+	n.SetData(plans, instances, nil, nil, map[string]TopicStatus{"plan": {HasRunning: true}})
+	labels := func() []string {
+		var out []string
+		for _, r := range n.rows {
+			if r.Kind == navRowInstance {
+				out = append(out, r.Label)
+			}
+		}
+		return out
+	}
+	require.Equal(t, []string{"a", "b", "c", "solo"}, labels())
+
+	require.True(t, n.SelectByID("inst:a"))
+	assert.False(t, n.MoveSelectedInstance(-1), "top of group is a no-op")
+	assert.Equal(t, 0, instances[0].SortIndex, "no-op leaves sort indexes alone")
+
+	assert.True(t, n.MoveSelectedInstance(1))
+	assert.Equal(t, []string{"b", "a", "c", "solo"}, labels())
+	assert.Equal(t, "inst:a", n.GetSelectedID(), "selection follows the moved instance")
+	assert.Equal(t, []int{2, 1, 3}, []int{instances[0].SortIndex, instances[1].SortIndex, instances[2].SortIndex})
+
+	assert.True(t, n.MoveSelectedInstance(1))
+	assert.False(t, n.MoveSelectedInstance(1), "bottom of group is a no-op; solo agents are a separate group")
+	assert.Equal(t, []string{"b", "c", "a", "solo"}, labels())
+
+	require.True(t, n.SelectByID("inst:solo"))
+	assert.False(t, n.MoveSelectedInstance(-1), "single-instance group cannot move")
+}
+
+func TestCycleNextNotified_WrapsAmongFlaggedInstances(t *testing.T) {
+	n := newTestPanel()
+	instances := []*session.Instance{
+		makeInst("a", "", session.Ready),
+		makeInst("b", "", session.Ready),
+		makeInst("c", "", session.Running),
+		makeInst("d", "", session.Ready),
+	}
+	instances[1].Notified = true
+	instances[3].Notified = true
+	n.SetData(nil, instances, nil, nil, nil)
+	require.True(t, n.SelectByID("inst:a"))
+
+	require.True(t, n.CycleNextNotified())
+	assert.Equal(t, "inst:b", n.GetSelectedID())
+	require.True(t, n.CycleNextNotified())
+	assert.Equal(t, "inst:d", n.GetSelectedID(), "unflagged instances are skipped")
+	require.True(t, n.CycleNextNotified())
+	assert.Equal(t, "inst:b", n.GetSelectedID(), "cycling wraps past the end")
+}
+
+func TestCycleNextNotified_NoopWhenNoneFlagged(t *testing.T) {
+	n := newTestPanel()
+	instances := []*session.Instance{makeInst("a", "", session.Ready), makeInst("b", "", session.Ready)}
+	n.SetData(nil, instances, nil, nil, nil)
+	require.True(t, n.SelectByID("inst:b"))
+
+	assert.False(t, n.CycleNextNotified())
+	assert.Equal(t, "inst:b", n.GetSelectedID())
+
+	instances[1].Notified = true
+	assert.False(t, n.CycleNextNotified(), "the selected instance is the only one flagged")
+}
+
+func TestSortNavInstances_UnorderedAfterOrdered(t *testing.T) {
+	pinned := makeInst("pinned", "plan", session.Ready)
+	pinned.SortIndex = 1
+	list := []*session.Instance{makeInst("new", "plan", session.Running), pinned}
+	sortNavInstances(list)
+	assert.Equal(t, "pinned", list[0].Title)
+}
+
 func TestRebuildRows_MixedPlanAndSolo(t *testing.T) {
 	n := newTestPanel()
 	plans := []PlanDisplay{{Filename: "plan"}}
@@ -309,6 +389,115 @@ func TestSortOrder_NotificationsFirst(t *testing.T) {
 	assert.Equal(t, "running", n.rows[0].TaskFile)
 }
 
+func TestSortOrder_PriorityFirst(t *testing.T) {
+	n := newTestPanel()
+	plans := []PlanDisplay{
+		{Filename: "zeta"},
+		{Filename: "alpha", Priority: 3},
+		{Filename: "mid", Priority: 1},
+		{Filename: "beta", Priority: 3},
+	}
+	n.SetData(plans, nil, nil, nil, nil)
+
+	var got []string
+	for _, row := range n.rows {
+		got = append(got, row.TaskFile)
+	}
+	// Priority descending, ties broken by the usual name-descending order.
+	assert.Equal(t, []string{"beta", "alpha", "mid", "zeta"}, got)
+	assert.Equal(t, 3, n.rows[0].Priority)
+}
+
+func TestSortOrder_PriorityWithinTopic(t *testing.T) {
+	n := newTestPanel()
+	topics := []TopicDisplay{{Name: "ui", Plans: []PlanDisplay{
+		{Filename: "a", Topic: "ui"},
+		{Filename: "b", Topic: "ui", Priority: 2},
+		{Filename: "c", Topic: "ui"},
+	}}}
+	n.SetTopicsAndPlans(topics, nil, nil)
+
+	require.Len(t, n.rows, 4)
+	assert.Equal(t, navRowTopicHeader, n.rows[0].Kind)
+	assert.Equal(t, []string{"b", "a", "c"}, []string{n.rows[1].TaskFile, n.rows[2].TaskFile, n.rows[3].TaskFile})
+}
+
+func TestSortOrder_PinnedFirst(t *testing.T) {
+	n := newTestPanel()
+	plans := []PlanDisplay{
+		{Filename: "zeta"},
+		{Filename: "alpha", Pinned: true},
+		{Filename: "mid", Priority: 3},
+	}
+	n.SetData(plans, nil, nil, nil, nil)
+
+	var got []string
+	for _, row := range n.rows {
+		got = append(got, row.TaskFile)
+	}
+	assert.Equal(t, []string{"alpha", "mid", "zeta"}, got)
+	assert.True(t, n.rows[0].Pinned)
+	assert.Contains(t, n.renderNavRow(n.rows[0], 40), navPinnedGlyph)
+	assert.NotContains(t, n.renderNavRow(n.rows[1], 40), navPinnedGlyph)
+
+	// Unpinning restores the default priority/name order.
+	plans[1].Pinned = false
+	n.SetData(plans, nil, nil, nil, nil)
+	got = got[:0]
+	for _, row := range n.rows {
+		got = append(got, row.TaskFile)
+	}
+	assert.Equal(t, []string{"mid", "zeta", "alpha"}, got)
+}
+
+func TestSortOrder_PinnedTopicFirst(t *testing.T) {
+	n := newTestPanel()
+	topics := []TopicDisplay{
+		{Name: "api", Plans: []PlanDisplay{{Filename: "a1", Topic: "api"}}},
+		{Name: "zed", Plans: []PlanDisplay{
+			{Filename: "z1", Topic: "zed"},
+			{Filename: "z2", Topic: "zed", Pinned: true},
+		}},
+	}
+	n.SetTopicsAndPlans(topics, []PlanDisplay{{Filename: "loose"}}, nil)
+
+	var got []string
+	for _, row := range n.rows {
+		got = append(got, row.ID)
+	}
+	assert.Equal(t, []string{
+		SidebarTopicPrefix + "zed", SidebarPlanPrefix + "z2", SidebarPlanPrefix + "z1",
+		SidebarTopicPrefix + "api", SidebarPlanPrefix + "a1",
+		SidebarPlanPrefix + "loose",
+	}, got)
+
+	topics[1].Plans[1].Pinned = false
+	n.SetTopicsAndPlans(topics, []PlanDisplay{{Filename: "loose"}}, nil)
+	assert.Equal(t, SidebarTopicPrefix+"api", n.rows[0].ID)
+}
+
+func TestNavPriorityBadge(t *testing.T) {
+	assert.Empty(t, navPriorityBadge(0))
+	assert.Contains(t, navPriorityBadge(1), "!")
+	assert.Contains(t, navPriorityBadge(2), "!!")
+	assert.Contains(t, navPriorityBadge(3), "!!!")
+}
+
+func TestBlockedPlanShowsLockGlyph(t *testing.T) {
+	n := newTestPanel()
+	n.SetData([]PlanDisplay{{Filename: "blocked", Blocked: true}, {Filename: "free"}}, nil, nil, nil, nil)
+
+	for _, row := range n.rows {
+		rendered := n.renderNavRow(row, 40)
+		if row.TaskFile == "blocked" {
+			assert.True(t, row.Blocked)
+			assert.Contains(t, rendered, navBlockedGlyph)
+		} else {
+			assert.NotContains(t, rendered, navBlockedGlyph)
+		}
+	}
+}
+
 func TestSortOrder_InstancesWithinPlan(t *testing.T) {
 	n := newTestPanel()
 	plans := []PlanDisplay{{Filename: "plan"}}
@@ -578,6 +767,45 @@ func TestSelectByID(t *testing.T) {
 	assert.Equal(t, "b", n.GetSelectedPlanFile())
 }
 
+func TestSelectPlan_ExpandsHistoryAndTopic(t *testing.T) {
+	n := newTestPanel()
+	plans := []PlanDisplay{{Filename: "a", Topic: "infra"}}
+	history := []PlanDisplay{{Filename: "old", Status: "done"}}
+	n.SetData(plans, nil, history, nil, nil)
+
+	assert.False(t, n.SelectByID(SidebarPlanPrefix+"old"), "history starts collapsed")
+	assert.True(t, n.SelectPlan("old"))
+	assert.Equal(t, "old", n.GetSelectedPlanFile())
+
+	n.collapsed[SidebarTopicPrefix+"infra"] = true
+	n.rebuildRows()
+	assert.True(t, n.SelectPlan("a"))
+	assert.Equal(t, "a", n.GetSelectedPlanFile())
+	assert.False(t, n.SelectPlan("missing"))
+}
+
+func TestSetAllExpanded_TogglesGroupsAndKeepsSelectionVisible(t *testing.T) {
+	n := newTestPanel()
+	topics := []TopicDisplay{{Name: "infra", Plans: []PlanDisplay{{Filename: "a", Topic: "infra"}}}}
+	n.SetTopicsAndPlans(topics, []PlanDisplay{{Filename: "b"}}, nil)
+	coder := makeInst("b-coder", "b", session.Running)
+	n.AddInstance(coder)
+	require.True(t, n.SelectInstance(coder))
+
+	n.SetAllExpanded(false)
+	assert.False(t, n.AnyExpanded())
+	assert.False(t, n.SelectByID(SidebarPlanPrefix+"a"), "topic is collapsed")
+	assert.Equal(t, SidebarPlanPrefix+"b", n.GetSelectedID(), "hidden instance selects its plan header")
+
+	n.SetAllExpanded(true)
+	assert.True(t, n.AnyExpanded())
+	require.True(t, n.SelectInstance(coder), "every group is expanded again")
+	require.True(t, n.SelectByID(SidebarPlanPrefix+"a"))
+
+	n.SetAllExpanded(false)
+	assert.Equal(t, SidebarTopicPrefix+"infra", n.GetSelectedID(), "hidden plan selects its topic header")
+}
+
 func TestSelectInstance(t *testing.T) {
 	n := newTestPanel()
 	inst1 := makeInst("s1", "", session.Running)
@@ -998,3 +1226,120 @@ func TestString_PlanningStatusPlanAppearsInActiveSection(t *testing.T) {
 	// not dropped to the idle "plans" section.
 	assert.Contains(t, output, "active", "planning status plan should appear in active section")
 }
+
+func TestRebuildRows_GitHubAvailable(t *testing.T) {
+	n := newTestPanel()
+	n.SetClickUpAvailable(true)
+	n.SetGitHubAvailable(true)
+	require.Len(t, n.rows, 2)
+	assert.Equal(t, SidebarImportClickUp, n.rows[0].ID)
+	assert.Equal(t, navRowImportAction, n.rows[1].Kind)
+	assert.Equal(t, SidebarImportGitHub, n.rows[1].ID)
+	assert.Equal(t, "+ import from github", n.rows[1].Label)
+}
+
+func TestRebuildRows_JiraAvailable(t *testing.T) {
+	n := newTestPanel()
+	n.SetGitHubAvailable(true)
+	n.SetJiraAvailable(true)
+	require.Len(t, n.rows, 2)
+	assert.Equal(t, SidebarImportGitHub, n.rows[0].ID)
+	assert.Equal(t, navRowImportAction, n.rows[1].Kind)
+	assert.Equal(t, SidebarImportJira, n.rows[1].ID)
+	assert.Equal(t, "+ import from jira", n.rows[1].Label)
+}
+
+func newRegexSearchPanel(t *testing.T) *NavigationPanel {
+	t.Helper()
+	n := newTestPanel()
+	n.SetSize(80, 40)
+	plans := []PlanDisplay{
+		{Filename: "auth-v2-rewrite"},
+		{Filename: "auth-legacy"},
+		{Filename: "billing-plan"},
+		{Filename: "c++-port"},
+	}
+	n.SetData(plans, nil, nil, nil, nil)
+	n.ActivateSearch()
+	return n
+}
+
+func TestSearch_RegexFiltersRows(t *testing.T) {
+	n := newRegexSearchPanel(t)
+	n.SetSearchQuery("/auth.*v2")
+	assert.True(t, n.IsRegexSearch())
+	assert.False(t, n.IsSearchRegexInvalid())
+	output := n.String()
+	assert.Contains(t, output, "auth-v2-rewrite")
+	assert.NotContains(t, output, "auth-legacy")
+	assert.NotContains(t, output, "billing")
+}
+
+func TestSearch_RegexIsCaseInsensitive(t *testing.T) {
+	n := newRegexSearchPanel(t)
+	n.SetSearchQuery("/^BILLING")
+	output := n.String()
+	assert.Contains(t, output, "billing-plan")
+	assert.NotContains(t, output, "auth")
+}
+
+func TestSearch_InvalidRegexFallsBackToSubstring(t *testing.T) {
+	n := newRegexSearchPanel(t)
+	n.SetSearchQuery("/c++")
+	assert.True(t, n.IsSearchRegexInvalid())
+	output := n.String()
+	assert.Contains(t, output, "invalid regex")
+	assert.Contains(t, output, "c++-port", "invalid pattern is matched as a substring")
+	assert.NotContains(t, output, "billing")
+
+	n.SetSearchQuery("/legacy")
+	assert.False(t, n.IsSearchRegexInvalid())
+	assert.Contains(t, n.String(), "auth-legacy")
+}
+
+func TestRebuildRows_GroupByAgentType(t *testing.T) {
+	n := newTestPanel()
+	mk := func(title, planFile, agentType string) *session.Instance {
+		inst := makeInst(title, planFile, session.Running)
+		inst.AgentType = agentType
+		inst.MarkStartedForTest()
+		return inst
+	}
+	unstarted := makeInst("loading", "plan-a", session.Loading)
+	unstarted.AgentType = session.AgentTypeCoder
+	instances := []*session.Instance{
+		mk("review-a", "plan-a", session.AgentTypeReviewer),
+		mk("coder-a", "plan-a", session.AgentTypeCoder),
+		mk("solo", "", ""),
+		mk("coder-b", "plan-b", session.AgentTypeCoder),
+		mk("custom", "plan-b", "auditor"),
+		unstarted,
+	}
+	n.SetData([]PlanDisplay{{Filename: "plan-a"}, {Filename: "plan-b"}}, instances, nil, nil, nil)
+	require.Equal(t, NavGroupByAgentType, n.ToggleGrouping())
+
+	var got []string
+	for _, row := range n.rows {
+		if row.Kind == navRowSoloHeader {
+			got = append(got, "--"+row.Label)
+		} else {
+			require.Equal(t, navRowInstance, row.Kind)
+			got = append(got, row.Label)
+		}
+	}
+	assert.ElementsMatch(t, []string{"coder-a", "coder-b"}, got[1:3])
+	got[1], got[2] = "coder-a", "coder-b"
+	assert.Equal(t, []string{
+		"--coders", "coder-a", "coder-b",
+		"--reviewers", "review-a",
+		"--auditors", "custom",
+		"--other", "solo",
+	}, got, "unstarted instances are left out")
+	assert.Equal(t, "plan-a", n.rows[4].TaskFile, "instance rows keep their plan")
+
+	assert.Equal(t, "plan-a · review", navAgentTypeTitle(n.rows[4].Instance), "plan-bound rows name their plan")
+	assert.Equal(t, "solo", navAgentTypeTitle(n.rows[8].Instance))
+
+	require.Equal(t, NavGroupByPlan, n.ToggleGrouping())
+	assert.Equal(t, navRowPlanHeader, n.rows[0].Kind, "toggling back restores the plan tree")
+}
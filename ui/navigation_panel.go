@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -20,6 +21,8 @@ const (
 	SidebarTopicPrefix       = "__topic__"
 	SidebarPlanHistoryToggle = "__plan_history_toggle__"
 	SidebarImportClickUp     = "__import_clickup__"
+	SidebarImportGitHub      = "__import_github__"
+	SidebarImportJira        = "__import_jira__"
 )
 
 // PlanDisplay holds display metadata for a single plan entry in the sidebar.
@@ -29,6 +32,9 @@ type PlanDisplay struct {
 	Description string
 	Branch      string
 	Topic       string
+	Priority    int  // 0 (none) to 3 (urgent)
+	Blocked     bool // true while any blocking plan is not done
+	Pinned      bool // true when the user pinned the plan to the top
 }
 
 // TopicStatus captures aggregate run/notification state for a plan.
@@ -43,6 +49,26 @@ type TopicDisplay struct {
 	Plans []PlanDisplay
 }
 
+// NavGrouping selects how the navigation panel groups its rows.
+type NavGrouping string
+
+const (
+	// NavGroupByPlan is the default plan tree: topics, plans and their instances.
+	NavGroupByPlan NavGrouping = ""
+	// NavGroupByAgentType lists started instances in one section per agent type.
+	NavGroupByAgentType NavGrouping = "agent_type"
+)
+
+// navAgentTypeOrder is the section order for NavGroupByAgentType; other
+// agent types follow alphabetically, then instances without one.
+var navAgentTypeOrder = []string{
+	session.AgentTypePlanner,
+	session.AgentTypeElaborator,
+	session.AgentTypeCoder,
+	session.AgentTypeReviewer,
+	session.AgentTypeFixer,
+}
+
 // navRowKind enumerates the distinct row types rendered in the nav panel.
 type navRowKind int
 
@@ -71,30 +97,69 @@ type navRow struct {
 	HasRunning      bool
 	HasNotification bool
 	Indent          int
+	Priority        int
+	Blocked         bool
+	Pinned          bool
 }
 
 // ---------- styles ----------
 
 var (
-	navItemStyle          = lipgloss.NewStyle().Foreground(ColorText).Padding(0, 1)
-	navSelectedRowStyle   = lipgloss.NewStyle().Background(ColorIris).Foreground(ColorBase).Padding(0, 1)
-	navActiveRowStyle     = lipgloss.NewStyle().Background(ColorOverlay).Foreground(ColorText).Padding(0, 1)
-	navSectionDivStyle    = lipgloss.NewStyle().Foreground(ColorMuted).Padding(0, 1)
-	navPlanLabelStyle     = lipgloss.NewStyle().Foreground(ColorText).Bold(true)
+	navItemStyle          lipgloss.Style
+	navSelectedRowStyle   lipgloss.Style
+	navActiveRowStyle     lipgloss.Style
+	navSectionDivStyle    lipgloss.Style
+	navPlanLabelStyle     lipgloss.Style
+	navInstanceLabelStyle lipgloss.Style
+	navRunningIconStyle   lipgloss.Style
+	navReadyIconStyle     lipgloss.Style
+	navNotifyIconStyle    lipgloss.Style
+	navPausedIconStyle    lipgloss.Style
+	navCompletedIconStyle lipgloss.Style
+	navIdleIconStyle      lipgloss.Style
+	navCancelledLblStyle  lipgloss.Style
+	navPriorityLowStyle   lipgloss.Style
+	navPriorityHighStyle  lipgloss.Style
+	navPriorityUrgStyle   lipgloss.Style
+	navBlockedIconStyle   lipgloss.Style
+	navPinnedIconStyle    lipgloss.Style
+	navImportStyle        lipgloss.Style
+	navHistoryDivStyle    lipgloss.Style
+	navLegendLabelStyle   lipgloss.Style
+	navSearchBoxStyle     lipgloss.Style
+	navSearchActiveStyle  lipgloss.Style
+	navSearchInvalidStyle lipgloss.Style
+)
+
+func init() { OnThemeChange(buildNavStyles) }
+
+// buildNavStyles derives the sidebar styles from the active theme.
+func buildNavStyles() {
+	navItemStyle = lipgloss.NewStyle().Foreground(ColorText).Padding(0, 1)
+	navSelectedRowStyle = lipgloss.NewStyle().Background(ColorIris).Foreground(ColorBase).Padding(0, 1)
+	navActiveRowStyle = lipgloss.NewStyle().Background(ColorOverlay).Foreground(ColorText).Padding(0, 1)
+	navSectionDivStyle = lipgloss.NewStyle().Foreground(ColorMuted).Padding(0, 1)
+	navPlanLabelStyle = lipgloss.NewStyle().Foreground(ColorText).Bold(true)
 	navInstanceLabelStyle = lipgloss.NewStyle().Foreground(ColorSubtle)
-	navRunningIconStyle   = lipgloss.NewStyle().Foreground(ColorFoam)
-	navReadyIconStyle     = lipgloss.NewStyle().Foreground(ColorFoam)
-	navNotifyIconStyle    = lipgloss.NewStyle().Foreground(ColorRose)
-	navPausedIconStyle    = lipgloss.NewStyle().Foreground(ColorMuted)
+	navRunningIconStyle = lipgloss.NewStyle().Foreground(ColorFoam)
+	navReadyIconStyle = lipgloss.NewStyle().Foreground(ColorFoam)
+	navNotifyIconStyle = lipgloss.NewStyle().Foreground(ColorRose)
+	navPausedIconStyle = lipgloss.NewStyle().Foreground(ColorMuted)
 	navCompletedIconStyle = lipgloss.NewStyle().Foreground(ColorFoam).Faint(true)
-	navIdleIconStyle      = lipgloss.NewStyle().Foreground(ColorMuted)
-	navCancelledLblStyle  = lipgloss.NewStyle().Foreground(ColorMuted).Strikethrough(true)
-	navImportStyle        = lipgloss.NewStyle().Foreground(ColorFoam).Padding(0, 1)
-	navHistoryDivStyle    = lipgloss.NewStyle().Foreground(ColorMuted)
-	navLegendLabelStyle   = lipgloss.NewStyle().Foreground(ColorMuted)
-	navSearchBoxStyle     = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(ColorOverlay).Padding(0, 1)
-	navSearchActiveStyle  = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(ColorFoam).Padding(0, 1)
-)
+	navIdleIconStyle = lipgloss.NewStyle().Foreground(ColorMuted)
+	navCancelledLblStyle = lipgloss.NewStyle().Foreground(ColorMuted).Strikethrough(true)
+	navPriorityLowStyle = lipgloss.NewStyle().Foreground(ColorSubtle)
+	navPriorityHighStyle = lipgloss.NewStyle().Foreground(ColorGold)
+	navPriorityUrgStyle = lipgloss.NewStyle().Foreground(ColorLove).Bold(true)
+	navBlockedIconStyle = lipgloss.NewStyle().Foreground(ColorGold)
+	navPinnedIconStyle = lipgloss.NewStyle().Foreground(ColorIris)
+	navImportStyle = lipgloss.NewStyle().Foreground(ColorFoam).Padding(0, 1)
+	navHistoryDivStyle = lipgloss.NewStyle().Foreground(ColorMuted)
+	navLegendLabelStyle = lipgloss.NewStyle().Foreground(ColorMuted)
+	navSearchBoxStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(ColorOverlay).Padding(0, 1)
+	navSearchActiveStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(ColorFoam).Padding(0, 1)
+	navSearchInvalidStyle = lipgloss.NewStyle().Foreground(ColorMuted)
+}
 
 // ---------- NavigationPanel ----------
 
@@ -126,7 +191,15 @@ type NavigationPanel struct {
 	historyExpanded bool
 	searchActive    bool
 	searchQuery     string
-	clickUpAvail    bool
+	// searchRe caches the compiled pattern for a "/"-prefixed (regex) query;
+	// searchReSrc is the query it was compiled from. searchRe is nil when the
+	// query is a plain substring or an invalid pattern.
+	searchRe     *regexp.Regexp
+	searchReSrc  string
+	clickUpAvail bool
+	githubAvail  bool
+	jiraAvail    bool
+	grouping     NavGrouping
 
 	// Embedded audit view rendered below the legend.
 	auditView         string
@@ -149,6 +222,29 @@ func NewNavigationPanel(sp *spinner.Model) *NavigationPanel {
 	}
 }
 
+// Grouping returns how the panel currently groups its rows.
+func (n *NavigationPanel) Grouping() NavGrouping { return n.grouping }
+
+// SetGrouping switches between the plan tree and agent-type sections.
+func (n *NavigationPanel) SetGrouping(g NavGrouping) {
+	if g != NavGroupByAgentType {
+		g = NavGroupByPlan
+	}
+	n.grouping = g
+	n.rebuildRows()
+}
+
+// ToggleGrouping flips between NavGroupByPlan and NavGroupByAgentType and
+// returns the new mode.
+func (n *NavigationPanel) ToggleGrouping() NavGrouping {
+	if n.grouping == NavGroupByAgentType {
+		n.SetGrouping(NavGroupByPlan)
+	} else {
+		n.SetGrouping(NavGroupByAgentType)
+	}
+	return n.grouping
+}
+
 // ---------- data setters ----------
 
 // SetData is the primary data update path. It replaces all state and rebuilds rows.
@@ -306,6 +402,11 @@ func (n *NavigationPanel) rebuildRows() {
 		prevID = n.rows[prevIdx].ID
 	}
 
+	if n.grouping == NavGroupByAgentType {
+		n.setRows(n.agentTypeRows(), prevID, prevIdx)
+		return
+	}
+
 	// Partition instances into plan-attached and solo.
 	byPlan := make(map[string][]*session.Instance)
 	var solo []*session.Instance
@@ -317,24 +418,22 @@ func (n *NavigationPanel) rebuildRows() {
 		}
 	}
 
-	// Sort helpers: instances newest-first by CreatedAt, then alpha by title.
-	sortInsts := func(list []*session.Instance) {
-		sort.SliceStable(list, func(i, j int) bool {
-			if !list[i].CreatedAt.Equal(list[j].CreatedAt) {
-				return list[i].CreatedAt.After(list[j].CreatedAt)
-			}
-			return strings.ToLower(list[i].Title) < strings.ToLower(list[j].Title)
-		})
-	}
 	for _, list := range byPlan {
-		sortInsts(list)
+		sortNavInstances(list)
 	}
-	sortInsts(solo)
+	sortNavInstances(solo)
 
-	// Sort plans alphabetically descending (newest date-prefixed names first).
+	// Sort plans pinned first, then by priority (highest first), then
+	// alphabetically descending (newest date-prefixed names first).
 	sorted := append([]PlanDisplay(nil), n.plans...)
 	sort.SliceStable(sorted, func(i, j int) bool {
 		pi, pj := sorted[i], sorted[j]
+		if pi.Pinned != pj.Pinned {
+			return pi.Pinned
+		}
+		if pi.Priority != pj.Priority {
+			return pi.Priority > pj.Priority
+		}
 		return strings.ToLower(taskstate.DisplayName(pi.Filename)) > strings.ToLower(taskstate.DisplayName(pj.Filename))
 	})
 
@@ -356,6 +455,9 @@ func (n *NavigationPanel) rebuildRows() {
 			HasRunning:      hasRunning,
 			HasNotification: hasNotif,
 			Indent:          indent,
+			Priority:        p.Priority,
+			Blocked:         p.Blocked,
+			Pinned:          p.Pinned,
 		})
 		if !collapsed {
 			for _, inst := range insts {
@@ -379,6 +481,20 @@ func (n *NavigationPanel) rebuildRows() {
 			Label: "+ import from clickup",
 		})
 	}
+	if n.githubAvail {
+		rows = append(rows, navRow{
+			Kind:  navRowImportAction,
+			ID:    SidebarImportGitHub,
+			Label: "+ import from github",
+		})
+	}
+	if n.jiraAvail {
+		rows = append(rows, navRow{
+			Kind:  navRowImportAction,
+			ID:    SidebarImportJira,
+			Label: "+ import from jira",
+		})
+	}
 
 	// Dead section: plans with non-running instances or manually inspected.
 	if len(n.deadPlans) > 0 {
@@ -405,10 +521,25 @@ func (n *NavigationPanel) rebuildRows() {
 		}
 	}
 
-	// Sort topics alphabetically for consistent grouping.
+	// Sort topics holding a pinned plan first, then alphabetically for
+	// consistent grouping.
 	sortedTopics := append([]TopicDisplay(nil), n.topics...)
+	inTopic := make(map[string]bool)
+	topicPinned := make(map[string]bool, len(sortedTopics))
+	for _, t := range sortedTopics {
+		for _, p := range t.Plans {
+			inTopic[p.Filename] = true
+			if p.Pinned {
+				topicPinned[t.Name] = true
+			}
+		}
+	}
 	sort.SliceStable(sortedTopics, func(i, j int) bool {
-		return strings.ToLower(sortedTopics[i].Name) < strings.ToLower(sortedTopics[j].Name)
+		ti, tj := sortedTopics[i].Name, sortedTopics[j].Name
+		if topicPinned[ti] != topicPinned[tj] {
+			return topicPinned[ti]
+		}
+		return strings.ToLower(ti) < strings.ToLower(tj)
 	})
 
 	emitPlanGroup := func(plans []PlanDisplay, indent int, emitted map[string]bool) {
@@ -435,6 +566,13 @@ func (n *NavigationPanel) rebuildRows() {
 			if len(planGroup) == 0 {
 				continue
 			}
+			// Pinned, then highest priority first; ties keep the topic's own order.
+			sort.SliceStable(planGroup, func(i, j int) bool {
+				if planGroup[i].Pinned != planGroup[j].Pinned {
+					return planGroup[i].Pinned
+				}
+				return planGroup[i].Priority > planGroup[j].Priority
+			})
 			topicID := SidebarTopicPrefix + t.Name
 			collapsed := n.collapsed[topicID]
 			rows = append(rows, navRow{
@@ -470,7 +608,14 @@ func (n *NavigationPanel) rebuildRows() {
 		}
 	}
 
-	// Idle plans: topic-grouped, then ungrouped.
+	// Idle plans: pinned ungrouped plans, topic-grouped, then ungrouped.
+	var pinnedSolo []PlanDisplay
+	for _, p := range idlePlans {
+		if p.Pinned && !inTopic[p.Filename] {
+			pinnedSolo = append(pinnedSolo, p)
+		}
+	}
+	emitPlanGroup(pinnedSolo, 0, emitted)
 	emitTopicGrouped(idlePlans, emitted)
 	emitPlanGroup(idlePlans, 0, emitted)
 
@@ -504,6 +649,12 @@ func (n *NavigationPanel) rebuildRows() {
 		})
 	}
 
+	n.setRows(rows, prevID, prevIdx)
+}
+
+// setRows installs freshly built rows, restoring the selection by row ID and
+// falling back to the previous position.
+func (n *NavigationPanel) setRows(rows []navRow, prevID string, prevIdx int) {
 	n.rows = rows
 
 	if len(rows) == 0 {
@@ -541,8 +692,79 @@ func (n *NavigationPanel) rebuildRows() {
 	n.clampScroll()
 }
 
+// agentTypeRows builds the NavGroupByAgentType rows: a divider per agent type
+// followed by its started instances.
+func (n *NavigationPanel) agentTypeRows() []navRow {
+	byType := make(map[string][]*session.Instance)
+	for _, inst := range n.instances {
+		if inst.Started() {
+			byType[inst.AgentType] = append(byType[inst.AgentType], inst)
+		}
+	}
+
+	order := make([]string, 0, len(byType))
+	known := make(map[string]bool, len(navAgentTypeOrder))
+	for _, t := range navAgentTypeOrder {
+		known[t] = true
+		if len(byType[t]) > 0 {
+			order = append(order, t)
+		}
+	}
+	var others []string
+	for t := range byType {
+		if !known[t] && t != "" {
+			others = append(others, t)
+		}
+	}
+	sort.Strings(others)
+	order = append(order, others...)
+	if len(byType[""]) > 0 {
+		order = append(order, "")
+	}
+
+	rows := make([]navRow, 0, len(n.instances)+len(order))
+	for _, t := range order {
+		label := "other"
+		if t != "" {
+			label = t + "s"
+		}
+		rows = append(rows, navRow{Kind: navRowSoloHeader, ID: "__agent_type__" + t, Label: label})
+		insts := byType[t]
+		sortNavInstances(insts)
+		for _, inst := range insts {
+			rows = append(rows, navRow{
+				Kind:     navRowInstance,
+				ID:       "inst:" + inst.Title,
+				Label:    inst.Title,
+				TaskFile: inst.TaskFile,
+				Instance: inst,
+			})
+		}
+	}
+	return rows
+}
+
 // ---------- sort key helpers ----------
 
+// sortNavInstances orders a nav group: user-ordered instances (SortIndex > 0)
+// first by SortIndex, then the rest newest-first by CreatedAt, then alpha by
+// title.
+func sortNavInstances(list []*session.Instance) {
+	sort.SliceStable(list, func(i, j int) bool {
+		si, sj := list[i].SortIndex, list[j].SortIndex
+		if si != sj {
+			if si == 0 || sj == 0 {
+				return sj == 0
+			}
+			return si < sj
+		}
+		if !list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return list[i].CreatedAt.After(list[j].CreatedAt)
+		}
+		return strings.ToLower(list[i].Title) < strings.ToLower(list[j].Title)
+	})
+}
+
 // navInstanceSortKey returns the sort priority for an instance within a plan.
 // Lower values sort first: running (0) < notified (1) < paused (2) < done (3).
 func navInstanceSortKey(inst *session.Instance) int {
@@ -584,6 +806,30 @@ func navPlanSortKey(p PlanDisplay, insts []*session.Instance, st TopicStatus) in
 	}
 }
 
+// navBlockedGlyph marks plans waiting on an unfinished blocker.
+const navBlockedGlyph = "\uf023"
+
+// navPinnedGlyph marks plans pinned to the top of the sidebar.
+const navPinnedGlyph = "\uf08d"
+
+// navDetachedGlyph marks instances whose worktree is on a detached HEAD.
+const navDetachedGlyph = "\uf127"
+
+// navPriorityBadge renders a small colored marker for a plan's priority:
+// one "!" per level, empty for no priority.
+func navPriorityBadge(priority int) string {
+	switch {
+	case priority >= 3:
+		return navPriorityUrgStyle.Render("!!!")
+	case priority == 2:
+		return navPriorityHighStyle.Render("!!")
+	case priority == 1:
+		return navPriorityLowStyle.Render("!")
+	default:
+		return ""
+	}
+}
+
 // aggregateNavPlanStatus derives combined running/notification flags from
 // instance state and stored plan status flags.
 func aggregateNavPlanStatus(insts []*session.Instance, st TopicStatus) (hasRunning, hasNotif bool) {
@@ -624,6 +870,8 @@ func (n *NavigationPanel) SetAuditView(view string, contentLines int) {
 func (n *NavigationPanel) SetFocused(focused bool)    { n.focused = focused }
 func (n *NavigationPanel) IsFocused() bool            { return n.focused }
 func (n *NavigationPanel) SetClickUpAvailable(a bool) { n.clickUpAvail = a; n.rebuildRows() }
+func (n *NavigationPanel) SetGitHubAvailable(a bool)  { n.githubAvail = a; n.rebuildRows() }
+func (n *NavigationPanel) SetJiraAvailable(a bool)    { n.jiraAvail = a; n.rebuildRows() }
 
 // availRows returns the number of rows the scroll window can display.
 // Overhead accounts for border (2), search box (3), blank line (1),
@@ -697,13 +945,46 @@ func (n *NavigationPanel) SetSearchQuery(q string) {
 	}
 }
 
+// IsRegexSearch reports whether the current query is a regex search ("/" prefix).
+func (n *NavigationPanel) IsRegexSearch() bool {
+	return strings.HasPrefix(n.searchQuery, "/")
+}
+
+// IsSearchRegexInvalid reports whether a regex search failed to compile and is
+// falling back to a substring match.
+func (n *NavigationPanel) IsSearchRegexInvalid() bool {
+	return n.IsRegexSearch() && len(n.searchQuery) > 1 && n.searchRegexp() == nil
+}
+
+// searchRegexp returns the case-insensitive pattern for a regex query, or nil
+// for substring queries and patterns that do not compile.
+func (n *NavigationPanel) searchRegexp() *regexp.Regexp {
+	if !n.IsRegexSearch() {
+		return nil
+	}
+	if n.searchReSrc != n.searchQuery {
+		n.searchReSrc = n.searchQuery
+		n.searchRe, _ = regexp.Compile("(?i)" + n.searchQuery[1:])
+	}
+	return n.searchRe
+}
+
 // rowMatchesSearch returns true if the row at idx passes the current search filter.
 func (n *NavigationPanel) rowMatchesSearch(idx int) bool {
+	return n.matchesSearch(n.rows[idx])
+}
+
+// matchesSearch returns true if row passes the current search filter. A query
+// starting with "/" is matched as a regexp; when the pattern is invalid the
+// text after the "/" is matched as a plain substring instead.
+func (n *NavigationPanel) matchesSearch(row navRow) bool {
 	if !n.searchActive || n.searchQuery == "" {
 		return true
 	}
-	q := strings.ToLower(n.searchQuery)
-	row := n.rows[idx]
+	if re := n.searchRegexp(); re != nil {
+		return re.MatchString(row.Label) || re.MatchString(row.TaskFile)
+	}
+	q := strings.ToLower(strings.TrimPrefix(n.searchQuery, "/"))
 	return strings.Contains(strings.ToLower(row.Label), q) ||
 		strings.Contains(strings.ToLower(row.TaskFile), q)
 }
@@ -740,6 +1021,48 @@ func (n *NavigationPanel) ToggleSelectedExpand() bool {
 	}
 }
 
+// SetAllExpanded expands or collapses every topic and plan group at once.
+// When collapsing hides the selected row, the nearest visible ancestor (its
+// plan header, else its topic header) is selected instead.
+func (n *NavigationPanel) SetAllExpanded(expanded bool) {
+	var prevID, planFile string
+	if n.selectedIdx >= 0 && n.selectedIdx < len(n.rows) {
+		prevID = n.rows[n.selectedIdx].ID
+		planFile = n.rows[n.selectedIdx].TaskFile
+	}
+	topicID := ""
+	for _, t := range n.topics {
+		n.collapsed[SidebarTopicPrefix+t.Name] = !expanded
+		for _, p := range t.Plans {
+			if p.Filename == planFile {
+				topicID = SidebarTopicPrefix + t.Name
+			}
+		}
+	}
+	for _, p := range n.plans {
+		n.collapsed[p.Filename] = !expanded
+		n.userOverrides[p.Filename] = true
+	}
+	n.rebuildRows()
+
+	if prevID == "" || n.SelectByID(prevID) || planFile == "" {
+		return
+	}
+	if !n.SelectByID(SidebarPlanPrefix + planFile) {
+		n.SelectByID(topicID)
+	}
+}
+
+// AnyExpanded reports whether at least one topic or plan group is expanded.
+func (n *NavigationPanel) AnyExpanded() bool {
+	for _, row := range n.rows {
+		if (row.Kind == navRowPlanHeader || row.Kind == navRowTopicHeader) && !row.Collapsed {
+			return true
+		}
+	}
+	return false
+}
+
 // ---------- navigation ----------
 
 // Up moves the selection up one visible, selectable row.
@@ -835,6 +1158,46 @@ func (n *NavigationPanel) GetSelectedInstance() *session.Instance {
 	return n.rows[n.selectedIdx].Instance
 }
 
+// MoveSelectedInstance moves the selected instance one place up (delta < 0)
+// or down (delta > 0) within its group — the instances of the same plan, or
+// the solo agents — by swapping it with its neighbour. The group's SortIndex
+// values are renumbered to the new order so it survives restarts. Returns
+// false when nothing moved: no instance selected, or already at the edge.
+func (n *NavigationPanel) MoveSelectedInstance(delta int) bool {
+	selected := n.GetSelectedInstance()
+	if selected == nil || delta == 0 {
+		return false
+	}
+	var group []*session.Instance
+	for _, inst := range n.instances {
+		if inst.TaskFile == selected.TaskFile {
+			group = append(group, inst)
+		}
+	}
+	sortNavInstances(group)
+
+	idx := -1
+	for i, inst := range group {
+		if inst == selected {
+			idx = i
+			break
+		}
+	}
+	target := idx + 1
+	if delta < 0 {
+		target = idx - 1
+	}
+	if idx < 0 || target < 0 || target >= len(group) {
+		return false
+	}
+	group[idx], group[target] = group[target], group[idx]
+	for i, inst := range group {
+		inst.SortIndex = i + 1
+	}
+	n.rebuildRows()
+	return true
+}
+
 func (n *NavigationPanel) GetSelectedPlanFile() string {
 	if n.selectedIdx < 0 || n.selectedIdx >= len(n.rows) {
 		return ""
@@ -857,6 +1220,17 @@ func (n *NavigationPanel) IsSelectedHistoryPlan() bool {
 	return n.rows[n.selectedIdx].Kind == navRowHistoryPlan
 }
 
+// GetSelectedTopic returns the topic name when a topic header is selected.
+func (n *NavigationPanel) GetSelectedTopic() string {
+	if n.selectedIdx < 0 || n.selectedIdx >= len(n.rows) {
+		return ""
+	}
+	if row := n.rows[n.selectedIdx]; row.Kind == navRowTopicHeader {
+		return row.Label
+	}
+	return ""
+}
+
 func (n *NavigationPanel) GetSelectedID() string {
 	if n.selectedIdx < 0 || n.selectedIdx >= len(n.rows) {
 		return ""
@@ -887,6 +1261,32 @@ func (n *NavigationPanel) SelectByID(id string) bool {
 	return false
 }
 
+// SelectPlan moves selection to the given plan's header, expanding its topic
+// or the dead/history section when the plan is hidden inside one.
+func (n *NavigationPanel) SelectPlan(planFile string) bool {
+	id := SidebarPlanPrefix + planFile
+	if n.SelectByID(id) {
+		return true
+	}
+	for _, p := range n.plans {
+		if p.Filename == planFile && p.Topic != "" {
+			n.collapsed[SidebarTopicPrefix+p.Topic] = false
+		}
+	}
+	for _, p := range n.historyPlans {
+		if p.Filename == planFile {
+			n.historyExpanded = true
+		}
+	}
+	for _, p := range n.deadPlans {
+		if p.Filename == planFile {
+			n.deadExpanded = true
+		}
+	}
+	n.rebuildRows()
+	return n.SelectByID(id)
+}
+
 // SelectInstance moves selection to the given instance, auto-expanding its plan if needed.
 func (n *NavigationPanel) SelectInstance(inst *session.Instance) bool {
 	for i, row := range n.rows {
@@ -912,6 +1312,41 @@ func (n *NavigationPanel) SelectInstance(inst *session.Instance) bool {
 	return false
 }
 
+// CycleNextNotified selects the next instance with a pending notification in
+// sidebar order, wrapping past the end. Instances hidden under a collapsed plan
+// are included and revealed. Returns false when no other instance is flagged.
+func (n *NavigationPanel) CycleNextNotified() bool {
+	order := make([]*session.Instance, 0, len(n.instances))
+	seen := make(map[*session.Instance]bool, len(n.instances))
+	for _, row := range n.rows {
+		if row.Instance != nil && !seen[row.Instance] {
+			seen[row.Instance] = true
+			order = append(order, row.Instance)
+		}
+	}
+	for _, inst := range n.instances {
+		if !seen[inst] {
+			order = append(order, inst)
+		}
+	}
+
+	current := n.GetSelectedInstance()
+	start := -1
+	for i, inst := range order {
+		if inst == current {
+			start = i
+			break
+		}
+	}
+	for step := 1; step <= len(order); step++ {
+		inst := order[(start+step)%len(order)]
+		if inst.Notified && inst != current {
+			return n.SelectInstance(inst)
+		}
+	}
+	return false
+}
+
 func (n *NavigationPanel) SetSelectedInstance(idx int) {
 	if idx < 0 || idx >= len(n.instances) {
 		return
@@ -1055,6 +1490,15 @@ func (n *NavigationPanel) Attach() (chan struct{}, error) {
 	return inst.Attach()
 }
 
+// AttachReadonly opens a read-only terminal session for the selected instance.
+func (n *NavigationPanel) AttachReadonly() (chan struct{}, error) {
+	inst := n.GetSelectedInstance()
+	if inst == nil {
+		return nil, fmt.Errorf("no instance selected")
+	}
+	return inst.AttachReadonly()
+}
+
 // Clear removes all instances and resets the row list.
 func (n *NavigationPanel) Clear() {
 	n.instances = nil
@@ -1126,6 +1570,18 @@ func navInstanceTitle(inst *session.Instance) string {
 	}
 }
 
+// navAgentTypeTitle is navInstanceTitle for NavGroupByAgentType, where rows
+// sit under their agent type rather than their plan: plan-bound labels such
+// as "review" are prefixed with the plan name.
+// "auth-refactor" reviewer → "auth-refactor · review"
+func navAgentTypeTitle(inst *session.Instance) string {
+	title := navInstanceTitle(inst)
+	if inst.TaskFile == "" || inst.SoloAgent {
+		return title
+	}
+	return taskstate.DisplayName(inst.TaskFile) + " · " + title
+}
+
 // navInstanceStatusIcon returns a styled status glyph for an instance row.
 func (n *NavigationPanel) navInstanceStatusIcon(inst *session.Instance) string {
 	if inst.Exited {
@@ -1202,6 +1658,15 @@ func (n *NavigationPanel) renderNavRow(row navRow, contentWidth int) string {
 			chevron = "▾"
 		}
 		statusIcon := navPlanStatusIcon(row)
+		if badge := navPriorityBadge(row.Priority); badge != "" {
+			statusIcon = badge + " " + statusIcon
+		}
+		if row.Blocked {
+			statusIcon = navBlockedIconStyle.Render(navBlockedGlyph) + " " + statusIcon
+		}
+		if row.Pinned {
+			statusIcon = navPinnedIconStyle.Render(navPinnedGlyph) + " " + statusIcon
+		}
 		statusW := lipgloss.Width(statusIcon)
 		indent := strings.Repeat(" ", row.Indent)
 		indentW := row.Indent
@@ -1236,7 +1701,13 @@ func (n *NavigationPanel) renderNavRow(row navRow, contentWidth int) string {
 		}
 
 		title := navInstanceTitle(inst)
+		if n.grouping == NavGroupByAgentType {
+			title = navAgentTypeTitle(inst)
+		}
 		statusIcon := n.navInstanceStatusIcon(inst)
+		if inst.DetachedHead && !inst.Exited {
+			statusIcon = navBlockedIconStyle.Render(navDetachedGlyph) + " " + statusIcon
+		}
 		statusW := lipgloss.Width(statusIcon)
 
 		indentW := row.Indent + 4
@@ -1261,6 +1732,9 @@ func (n *NavigationPanel) renderNavRow(row navRow, contentWidth int) string {
 			indent = ""
 			lblStyle = navPlanLabelStyle
 		}
+		if c, ok := agentTypeColor(inst.AgentType); ok {
+			lblStyle = lblStyle.Foreground(c)
+		}
 		if inst.Exited {
 			lblStyle = navCancelledLblStyle
 			statusIcon = navCancelledLblStyle.Render("✕")
@@ -1274,7 +1748,7 @@ func (n *NavigationPanel) renderNavRow(row navRow, contentWidth int) string {
 		return indent + lblStyle.Render(title) + strings.Repeat(" ", gap) + " " + statusIcon
 
 	case navRowSoloHeader:
-		return navDividerLine("agents", contentWidth)
+		return navDividerLine(row.Label, contentWidth)
 
 	case navRowTopicHeader:
 		chevron := "▸"
@@ -1392,6 +1866,9 @@ func (n *NavigationPanel) String() string {
 		if text == "" {
 			text = " "
 		}
+		if n.IsSearchRegexInvalid() {
+			text += navSearchInvalidStyle.Render(" (invalid regex)")
+		}
 		searchBox = zone.Mark(ZoneNavSearch, navSearchActiveStyle.Width(searchWidth).Render(text))
 	} else {
 		searchBox = zone.Mark(ZoneNavSearch, navSearchBoxStyle.Width(searchWidth).Render("\uf002 search"))
@@ -1409,12 +1886,8 @@ func (n *NavigationPanel) String() string {
 
 	for i, row := range n.rows {
 		// Apply search filter.
-		if n.searchActive && n.searchQuery != "" {
-			q := strings.ToLower(n.searchQuery)
-			if !strings.Contains(strings.ToLower(row.Label), q) &&
-				!strings.Contains(strings.ToLower(row.TaskFile), q) {
-				continue
-			}
+		if !n.matchesSearch(row) {
+			continue
 		}
 
 		// Track dead section to suppress section dividers inside it.
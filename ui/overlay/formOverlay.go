@@ -15,6 +15,8 @@ type FormOverlay struct {
 	descVal   string
 	branchVal string
 	pathVal   string
+	roleVal   string
+	sharedVal bool
 	title     string
 	submitted bool
 	canceled  bool
@@ -57,12 +59,15 @@ func NewFormOverlay(title string, width int) *FormOverlay {
 	return f
 }
 
-// NewSpawnFormOverlay creates a form overlay with name, branch (optional), and path (optional) inputs.
-func NewSpawnFormOverlay(title string, width int) *FormOverlay {
+// NewSpawnFormOverlay creates a form overlay with name, branch (optional), and
+// path (optional) inputs, plus a toggle for joining the branch's existing worktree.
+// When roles are given, a role picker is added; its first option, the default
+// fixer agent, is selected initially.
+func NewSpawnFormOverlay(title string, width int, roles ...string) *FormOverlay {
 	f := &FormOverlay{
 		title:     title,
 		width:     width,
-		fieldKeys: []string{"name", "branch", "path"},
+		fieldKeys: []string{"name", "branch", "path", "shared"},
 	}
 
 	formWidth := width - 6
@@ -70,22 +75,41 @@ func NewSpawnFormOverlay(title string, width int) *FormOverlay {
 		formWidth = 34
 	}
 
-	f.form = huh.NewForm(
-		huh.NewGroup(
-			huh.NewInput().
-				Key("name").
-				Title("name").
-				Value(&f.nameVal),
-			huh.NewInput().
-				Key("branch").
-				Title("branch (optional)").
-				Value(&f.branchVal),
-			huh.NewInput().
-				Key("path").
-				Title("path (optional)").
-				Value(&f.pathVal),
-		),
-	).
+	fields := []huh.Field{
+		huh.NewInput().
+			Key("name").
+			Title("name").
+			Value(&f.nameVal),
+		huh.NewInput().
+			Key("branch").
+			Title("branch (optional)").
+			Value(&f.branchVal),
+		huh.NewInput().
+			Key("path").
+			Title("path (optional)").
+			Value(&f.pathVal),
+		huh.NewConfirm().
+			Key("shared").
+			Title("join the branch's existing worktree").
+			Affirmative("yes").
+			Negative("no").
+			Value(&f.sharedVal),
+	}
+	if len(roles) > 0 {
+		options := []huh.Option[string]{huh.NewOption("default", "")}
+		for _, role := range roles {
+			options = append(options, huh.NewOption(role, role))
+		}
+		fields = append(fields, huh.NewSelect[string]().
+			Key("role").
+			Title("role (←→)").
+			Inline(true).
+			Options(options...).
+			Value(&f.roleVal))
+		f.fieldKeys = append(f.fieldKeys, "role")
+	}
+
+	f.form = huh.NewForm(huh.NewGroup(fields...)).
 		WithTheme(ThemeRosePine()).
 		WithWidth(formWidth).
 		WithShowHelp(false).
@@ -131,6 +155,17 @@ func (f *FormOverlay) WorkPath() string {
 	return strings.TrimSpace(f.pathVal)
 }
 
+// Role returns the agent role picked in the spawn form, or "" for the default.
+func (f *FormOverlay) Role() string {
+	return f.roleVal
+}
+
+// SharedWorktree reports whether the agent should join an existing worktree
+// for the branch instead of creating a new one.
+func (f *FormOverlay) SharedWorktree() bool {
+	return f.sharedVal
+}
+
 // HandleKey implements Overlay. Processes a key event and returns a Result.
 func (f *FormOverlay) HandleKey(msg tea.KeyPressMsg) Result {
 	switch msg.String() {
@@ -156,7 +156,7 @@ func TestSpawnFormOverlay_EmptyNameDoesNotSubmit(t *testing.T) {
 	assert.False(t, result.Submitted)
 }
 
-func TestSpawnFormOverlay_TabCyclesThreeFields(t *testing.T) {
+func TestSpawnFormOverlay_TabCyclesAllFields(t *testing.T) {
 	f := NewSpawnFormOverlay("spawn agent", 60)
 	for _, r := range "n" {
 		f.HandleKey(tea.KeyPressMsg{Code: r, Text: string(r)})
@@ -174,7 +174,8 @@ func TestSpawnFormOverlay_TabCyclesThreeFields(t *testing.T) {
 		f.HandleKey(tea.KeyPressMsg{Code: r, Text: string(r)})
 	}
 
-	// Tab wraps to name
+	// Tab to the shared-worktree toggle, then wrap to name
+	f.HandleKey(tea.KeyPressMsg{Code: tea.KeyTab})
 	f.HandleKey(tea.KeyPressMsg{Code: tea.KeyTab})
 	for _, r := range "!" {
 		f.HandleKey(tea.KeyPressMsg{Code: r, Text: string(r)})
@@ -185,6 +186,22 @@ func TestSpawnFormOverlay_TabCyclesThreeFields(t *testing.T) {
 	assert.Equal(t, "n!", f.Name())
 	assert.Equal(t, "b", f.Branch())
 	assert.Equal(t, "p", f.WorkPath())
+	assert.False(t, f.SharedWorktree())
+}
+
+func TestSpawnFormOverlay_SharedWorktreeToggle(t *testing.T) {
+	f := NewSpawnFormOverlay("spawn agent", 60)
+	for _, r := range "task" {
+		f.HandleKey(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	for i := 0; i < 3; i++ {
+		f.HandleKey(tea.KeyPressMsg{Code: tea.KeyTab})
+	}
+	f.HandleKey(tea.KeyPressMsg{Code: tea.KeyLeft})
+
+	result := f.HandleKey(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.True(t, result.Submitted)
+	assert.True(t, f.SharedWorktree())
 }
 
 func TestFormOverlay_Render(t *testing.T) {
@@ -216,3 +233,25 @@ func TestFormOverlay_HandleKey_Cancel(t *testing.T) {
 	assert.True(t, result.Dismissed)
 	assert.False(t, result.Submitted)
 }
+
+func TestSpawnFormOverlay_RolePicker(t *testing.T) {
+	f := NewSpawnFormOverlay("spawn agent", 60, "docs_writer", "security_audit")
+	for _, r := range "audit" {
+		f.HandleKey(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	for i := 0; i < 4; i++ {
+		f.HandleKey(tea.KeyPressMsg{Code: tea.KeyTab})
+	}
+	f.HandleKey(tea.KeyPressMsg{Code: tea.KeyRight})
+	f.HandleKey(tea.KeyPressMsg{Code: tea.KeyRight})
+
+	result := f.HandleKey(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.True(t, result.Submitted)
+	assert.Equal(t, "audit", f.Name())
+	assert.Equal(t, "security_audit", f.Role())
+}
+
+func TestSpawnFormOverlay_NoRolesDefaultsRole(t *testing.T) {
+	f := NewSpawnFormOverlay("spawn agent", 60)
+	assert.Equal(t, "", f.Role())
+}
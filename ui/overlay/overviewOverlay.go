@@ -0,0 +1,106 @@
+package overlay
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// OverviewStatusCount is the number of plans in one status.
+type OverviewStatusCount struct {
+	Status string
+	Count  int
+}
+
+// OverviewRepo summarizes one repo in the overview overlay.
+type OverviewRepo struct {
+	Name string
+	Path string
+	// Statuses holds the non-zero plan counts, in display order.
+	Statuses []OverviewStatusCount
+	// Running is the number of running or loading instances in the repo.
+	Running int
+	// Err is set when the repo's plan state could not be loaded.
+	Err string
+}
+
+// OverviewOverlay is a read-only, full-screen summary of plan progress and
+// running agents across every known repo.
+type OverviewOverlay struct {
+	repos         []OverviewRepo
+	width, height int
+	styles        Styles
+}
+
+// NewOverviewOverlay creates an overview of the given repos.
+func NewOverviewOverlay(repos []OverviewRepo) *OverviewOverlay {
+	return &OverviewOverlay{repos: repos, styles: DefaultStyles()}
+}
+
+// HandleKey implements Overlay. Esc closes the overview; other keys are ignored.
+func (o *OverviewOverlay) HandleKey(msg tea.KeyPressMsg) Result {
+	if msg.Code == tea.KeyEscape {
+		return Result{Dismissed: true}
+	}
+	return Result{}
+}
+
+// SetSize implements Overlay. The overview fills the given dimensions.
+func (o *OverviewOverlay) SetSize(w, h int) {
+	o.width = w
+	o.height = h
+}
+
+// View implements Overlay.
+func (o *OverviewOverlay) View() string {
+	var b strings.Builder
+	b.WriteString(o.styles.Title.Render("overview"))
+	b.WriteString("\n")
+	if len(o.repos) == 0 {
+		b.WriteString(o.styles.Muted.Render("no known repos"))
+	}
+	for i, repo := range o.repos {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		b.WriteString(o.renderRepo(repo))
+	}
+	b.WriteString("\n\n")
+	b.WriteString(o.styles.Hint.Render("esc close"))
+
+	style := o.styles.ModalBorder
+	if o.width > 0 {
+		style = style.Width(o.width)
+	}
+	if o.height > 0 {
+		style = style.Height(o.height)
+	}
+	return style.Render(b.String())
+}
+
+// renderRepo renders the name line and counts line of one repo.
+func (o *OverviewOverlay) renderRepo(repo OverviewRepo) string {
+	name := lipgloss.NewStyle().Foreground(colorFoam).Bold(true).Render(repo.Name)
+	header := name + "  " + o.styles.Muted.Render(repo.Path)
+
+	var counts string
+	switch {
+	case repo.Err != "":
+		counts = lipgloss.NewStyle().Foreground(colorLove).Render("error: " + repo.Err)
+	case len(repo.Statuses) == 0:
+		counts = o.styles.Muted.Render("no plans")
+	default:
+		parts := make([]string, 0, len(repo.Statuses))
+		for _, s := range repo.Statuses {
+			parts = append(parts, fmt.Sprintf("%d %s", s.Count, s.Status))
+		}
+		counts = strings.Join(parts, " · ")
+	}
+	running := o.styles.Muted.Render("no agents running")
+	if repo.Running > 0 {
+		running = lipgloss.NewStyle().Foreground(colorGold).Render(fmt.Sprintf("%d running", repo.Running))
+	}
+	return header + "\n  " + counts + "  " + running
+}
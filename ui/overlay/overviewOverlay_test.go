@@ -0,0 +1,37 @@
+package overlay
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverviewOverlay_ClosesOnlyOnEsc(t *testing.T) {
+	var _ Overlay = NewOverviewOverlay(nil)
+	o := NewOverviewOverlay(nil)
+
+	assert.False(t, o.HandleKey(tea.KeyPressMsg{Code: 'x', Text: "x"}).Dismissed)
+	assert.False(t, o.HandleKey(tea.KeyPressMsg{Code: tea.KeyEnter}).Dismissed)
+	assert.Equal(t, Result{Dismissed: true}, o.HandleKey(tea.KeyPressMsg{Code: tea.KeyEscape}))
+}
+
+func TestOverviewOverlay_ViewFillsScreenWithRepoSummaries(t *testing.T) {
+	o := NewOverviewOverlay([]OverviewRepo{
+		{Name: "alpha", Path: "/src/alpha", Statuses: []OverviewStatusCount{{Status: "ready", Count: 2}, {Status: "done", Count: 1}}, Running: 3},
+		{Name: "beta", Path: "/src/beta"},
+		{Name: "gamma", Path: "/src/gamma", Err: "store unavailable"},
+	})
+	o.SetSize(100, 30)
+
+	view := o.View()
+	plain := ansi.Strip(view)
+	assert.Contains(t, plain, "2 ready · 1 done")
+	assert.Contains(t, plain, "3 running")
+	assert.Contains(t, plain, "no plans")
+	assert.Contains(t, plain, "error: store unavailable")
+	assert.Equal(t, 100, lipgloss.Width(view))
+	assert.Equal(t, 30, lipgloss.Height(view))
+}
@@ -17,6 +17,8 @@ type PickerOverlay struct {
 	submitted   bool
 	cancelled   bool
 	allowCustom bool // when true, typing a non-matching query offers "Create: <query>"
+	multiSelect bool // when true, space toggles items and enter submits all checked ones
+	checked     map[string]bool
 }
 
 // NewPickerOverlay creates a picker with a title and list of items.
@@ -41,6 +43,39 @@ func (p *PickerOverlay) SetAllowCustom(allow bool) {
 	p.allowCustom = allow
 }
 
+// SetMultiSelect turns the picker into a checklist: space toggles the
+// highlighted item and enter submits every checked item, newline-separated in
+// Result.Value. Items in preselected start checked.
+func (p *PickerOverlay) SetMultiSelect(preselected []string) {
+	p.multiSelect = true
+	p.checked = make(map[string]bool, len(preselected))
+	for _, item := range preselected {
+		p.checked[item] = true
+	}
+}
+
+// Selected returns the checked items in list order (multi-select mode only).
+func (p *PickerOverlay) Selected() []string {
+	var out []string
+	for _, item := range p.allItems {
+		if p.checked[item] {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// rowLabel returns the text shown for item, with a checkbox in multi-select mode.
+func (p *PickerOverlay) rowLabel(item string) string {
+	if !p.multiSelect {
+		return item
+	}
+	if p.checked[item] {
+		return "[x] " + item
+	}
+	return "[ ] " + item
+}
+
 const customPrefix = "+ Create: "
 
 func (p *PickerOverlay) applyFilter() {
@@ -83,6 +118,12 @@ func (p *PickerOverlay) hasExactMatch() bool {
 // Value returns the selected item, or empty string if cancelled or nothing selected.
 // When a custom "Create: <name>" entry is selected, returns just the name.
 func (p *PickerOverlay) Value() string {
+	if p.multiSelect {
+		if p.cancelled {
+			return ""
+		}
+		return strings.Join(p.Selected(), "\n")
+	}
 	if p.cancelled || len(p.filtered) == 0 {
 		return ""
 	}
@@ -108,6 +149,13 @@ func (p *PickerOverlay) HandleKey(msg tea.KeyPressMsg) Result {
 	case "enter":
 		p.submitted = true
 		return Result{Dismissed: true, Submitted: true, Value: p.Value()}
+	case "space":
+		if p.multiSelect {
+			p.toggleSelected()
+			return Result{}
+		}
+		p.searchQuery += " "
+		p.applyFilter()
 	case "up", "shift+tab":
 		if p.selectedIdx > 0 {
 			p.selectedIdx--
@@ -131,6 +179,15 @@ func (p *PickerOverlay) HandleKey(msg tea.KeyPressMsg) Result {
 	return Result{}
 }
 
+// toggleSelected flips the checked state of the highlighted item.
+func (p *PickerOverlay) toggleSelected() {
+	if len(p.filtered) == 0 {
+		return
+	}
+	item := p.filtered[p.selectedIdx]
+	p.checked[item] = !p.checked[item]
+}
+
 // HandleMouse handles mouse clicks and translates them into picker selection.
 func (p *PickerOverlay) HandleMouse(relX, relY int, button tea.MouseButton) Result {
 	if button != tea.MouseLeft {
@@ -145,12 +202,16 @@ func (p *PickerOverlay) HandleMouse(relX, relY int, button tea.MouseButton) Resu
 
 	line := stripANSI(lines[relY])
 	for i, item := range p.filtered {
-		rowText := "  " + item
+		rowText := "  " + p.rowLabel(item)
 		if i == p.selectedIdx {
-			rowText = "▸ " + item
+			rowText = "▸ " + p.rowLabel(item)
 		}
 		if lineContainsTextBoundary(line, rowText) {
 			p.selectedIdx = i
+			if p.multiSelect {
+				p.toggleSelected()
+				return Result{}
+			}
 			p.submitted = true
 			p.cancelled = false
 			return Result{Dismissed: true, Submitted: true, Value: p.Value()}
@@ -186,15 +247,19 @@ func (p *PickerOverlay) View() string {
 	} else {
 		for i, item := range p.filtered {
 			if i == p.selectedIdx {
-				b.WriteString(st.SelectedItem.Width(innerWidth).Render("▸ " + item))
+				b.WriteString(st.SelectedItem.Width(innerWidth).Render("▸ " + p.rowLabel(item)))
 			} else {
-				b.WriteString(st.Item.Width(innerWidth).Render("  " + item))
+				b.WriteString(st.Item.Width(innerWidth).Render("  " + p.rowLabel(item)))
 			}
 			b.WriteString("\n")
 		}
 	}
 
-	b.WriteString(st.Hint.Render("↑↓ navigate • enter select • esc cancel"))
+	if p.multiSelect {
+		b.WriteString(st.Hint.Render("↑↓ navigate • space toggle • enter confirm • esc cancel"))
+	} else {
+		b.WriteString(st.Hint.Render("↑↓ navigate • enter select • esc cancel"))
+	}
 
 	return st.FloatingBorder.Width(p.width).Render(b.String())
 }
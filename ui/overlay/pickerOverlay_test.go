@@ -103,3 +103,42 @@ func TestPickerOverlay_HandleMouse_PrefixMatchUsesClickedRow(t *testing.T) {
 
 	assert.Equal(t, Result{Dismissed: true, Submitted: true, Value: "alpha"}, result)
 }
+
+func TestPickerOverlay_MultiSelect(t *testing.T) {
+	p := NewPickerOverlay("blockers", []string{"alpha", "beta", "gamma"})
+	p.SetMultiSelect([]string{"gamma"})
+
+	assert.Contains(t, p.View(), "[x] gamma")
+	assert.Contains(t, p.View(), "[ ] alpha")
+
+	// space toggles the highlighted item and keeps the picker open
+	result := p.HandleKey(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	assert.False(t, result.Dismissed)
+	p.HandleKey(tea.KeyPressMsg{Code: tea.KeyDown})
+	p.HandleKey(tea.KeyPressMsg{Code: tea.KeyDown})
+	p.HandleKey(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+
+	result = p.HandleKey(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.True(t, result.Submitted)
+	assert.Equal(t, "alpha", result.Value)
+	assert.Equal(t, []string{"alpha"}, p.Selected())
+}
+
+func TestPickerOverlay_MultiSelectEmptySubmit(t *testing.T) {
+	p := NewPickerOverlay("blockers", []string{"alpha"})
+	p.SetMultiSelect(nil)
+
+	result := p.HandleKey(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.True(t, result.Submitted)
+	assert.Empty(t, result.Value)
+}
+
+func TestPickerOverlay_MultiSelectMouseToggles(t *testing.T) {
+	p := NewPickerOverlay("blockers", []string{"alpha", "beta"})
+	p.SetMultiSelect(nil)
+
+	x, y := pickerMouseTarget(t, p.View(), "beta")
+	result := p.HandleMouse(x, y, tea.MouseLeft)
+	assert.False(t, result.Dismissed)
+	assert.Equal(t, []string{"beta"}, p.Selected())
+}
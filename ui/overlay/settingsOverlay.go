@@ -0,0 +1,152 @@
+package overlay
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/huh/v2"
+)
+
+// Settings holds the values edited by SettingsOverlay.
+type Settings struct {
+	DefaultProgram string
+	AutoYes        bool
+	AnimateBanner  bool
+	Notifications  bool
+	Telemetry      bool
+}
+
+// SettingsOverlay is a form overlay for editing the common config settings.
+type SettingsOverlay struct {
+	form      *huh.Form
+	values    Settings
+	title     string
+	width     int
+	fieldKeys []string
+}
+
+// NewSettingsOverlay creates a settings form pre-filled with current.
+func NewSettingsOverlay(title string, width int, current Settings) *SettingsOverlay {
+	s := &SettingsOverlay{
+		title:     title,
+		width:     width,
+		values:    current,
+		fieldKeys: []string{"program", "autoyes", "banner", "notifications", "telemetry"},
+	}
+
+	formWidth := width - 6
+	if formWidth < 34 {
+		formWidth = 34
+	}
+
+	toggle := func(key, title string, value *bool) *huh.Confirm {
+		return huh.NewConfirm().
+			Key(key).
+			Title(title).
+			Affirmative("on").
+			Negative("off").
+			Value(value)
+	}
+
+	s.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Key("program").
+				Title("default program").
+				Value(&s.values.DefaultProgram),
+			toggle("autoyes", "auto-yes", &s.values.AutoYes),
+			toggle("banner", "animate banner", &s.values.AnimateBanner),
+			toggle("notifications", "desktop notifications", &s.values.Notifications),
+			toggle("telemetry", "telemetry (applies on restart)", &s.values.Telemetry),
+		),
+	).
+		WithTheme(ThemeRosePine()).
+		WithWidth(formWidth).
+		WithShowHelp(false).
+		WithShowErrors(false)
+
+	_ = s.form.Init()
+
+	return s
+}
+
+// Values returns the edited settings.
+func (s *SettingsOverlay) Values() Settings {
+	v := s.values
+	v.DefaultProgram = strings.TrimSpace(v.DefaultProgram)
+	return v
+}
+
+func (s *SettingsOverlay) updateForm(msg tea.Msg) {
+	updated, _ := s.form.Update(msg)
+	if form, ok := updated.(*huh.Form); ok {
+		s.form = form
+	}
+}
+
+func (s *SettingsOverlay) focusedKey() string {
+	field := s.form.GetFocusedField()
+	if field == nil {
+		return ""
+	}
+	return field.GetKey()
+}
+
+// HandleKey implements Overlay. Enter saves; esc discards the edits.
+func (s *SettingsOverlay) HandleKey(msg tea.KeyPressMsg) Result {
+	switch msg.String() {
+	case "esc":
+		return Result{Dismissed: true}
+
+	case "enter":
+		if strings.TrimSpace(s.values.DefaultProgram) == "" {
+			return Result{}
+		}
+		return Result{Dismissed: true, Submitted: true}
+
+	case "tab", "down":
+		if s.focusedKey() == s.fieldKeys[len(s.fieldKeys)-1] {
+			for i := 0; i < len(s.fieldKeys)-1; i++ {
+				s.updateForm(huh.PrevField())
+			}
+			return Result{}
+		}
+		s.updateForm(huh.NextField())
+		return Result{}
+
+	case "shift+tab", "up":
+		if s.focusedKey() == s.fieldKeys[0] {
+			for i := 0; i < len(s.fieldKeys)-1; i++ {
+				s.updateForm(huh.NextField())
+			}
+			return Result{}
+		}
+		s.updateForm(huh.PrevField())
+		return Result{}
+
+	default:
+		s.updateForm(msg)
+		return Result{}
+	}
+}
+
+// View implements Overlay. Returns the rendered overlay string.
+func (s *SettingsOverlay) View() string {
+	w := s.width
+	if w < 40 {
+		w = 40
+	}
+
+	st := DefaultStyles()
+
+	content := st.Title.Render(s.title) + "\n"
+	content += s.form.View() + "\n"
+	content += st.Hint.Render("tab/↑↓ navigate · ←→ toggle · enter save · esc cancel")
+
+	return st.ModalBorder.Width(w).Render(content)
+}
+
+// SetSize implements Overlay. Updates the available width for the overlay.
+func (s *SettingsOverlay) SetSize(w, h int) {
+	s.width = w
+}
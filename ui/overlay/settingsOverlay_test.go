@@ -0,0 +1,44 @@
+package overlay
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettingsOverlay_PrefillsAndSubmits(t *testing.T) {
+	current := Settings{DefaultProgram: "opencode", AnimateBanner: true, Notifications: true, Telemetry: true}
+	s := NewSettingsOverlay("settings", 60, current)
+
+	result := s.HandleKey(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.True(t, result.Dismissed)
+	assert.True(t, result.Submitted)
+	assert.Equal(t, current, s.Values())
+}
+
+func TestSettingsOverlay_TogglesField(t *testing.T) {
+	s := NewSettingsOverlay("settings", 60, Settings{DefaultProgram: "opencode"})
+
+	s.HandleKey(tea.KeyPressMsg{Code: tea.KeyTab})
+	s.HandleKey(tea.KeyPressMsg{Code: tea.KeyLeft})
+
+	s.HandleKey(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.True(t, s.Values().AutoYes)
+	assert.False(t, s.Values().AnimateBanner)
+}
+
+func TestSettingsOverlay_EmptyProgramDoesNotSubmit(t *testing.T) {
+	s := NewSettingsOverlay("settings", 60, Settings{})
+
+	result := s.HandleKey(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.False(t, result.Dismissed)
+}
+
+func TestSettingsOverlay_EscCancels(t *testing.T) {
+	s := NewSettingsOverlay("settings", 60, Settings{DefaultProgram: "opencode"})
+
+	result := s.HandleKey(tea.KeyPressMsg{Code: tea.KeyEscape})
+	assert.True(t, result.Dismissed)
+	assert.False(t, result.Submitted)
+}
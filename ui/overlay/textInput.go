@@ -18,6 +18,16 @@ type TextInputOverlay struct {
 	width, height int
 	multiline     bool
 	styles        Styles
+	// toggleLabel, when non-empty, renders a checkbox flipped with ctrl+s.
+	toggleLabel  string
+	toggleAction string
+	toggled      bool
+	// history holds previously sent values, oldest first, recalled with
+	// up/down. historyIdx is the recalled entry, or len(history) when the
+	// user is editing their own draft.
+	history    []string
+	historyIdx int
+	draft      string
 }
 
 // NewTextInputOverlay creates a new text input overlay with the given title and initial value.
@@ -52,6 +62,55 @@ func (t *TextInputOverlay) SetMultiline(enabled bool) {
 	t.multiline = enabled
 }
 
+// SetToggle adds a checkbox with the given label below the input. ctrl+s flips
+// it; when the overlay is submitted with the checkbox set, Result.Action is action.
+func (t *TextInputOverlay) SetToggle(label, action string, on bool) {
+	t.toggleLabel = label
+	t.toggleAction = action
+	t.toggled = on
+}
+
+// SetHistory enables shell-style recall of entries (oldest first): up on an
+// empty input, or with the cursor at the start, steps back through them and
+// down steps forward again, back to what was typed.
+func (t *TextInputOverlay) SetHistory(entries []string) {
+	t.history = entries
+	t.historyIdx = len(entries)
+}
+
+// recallHistory moves delta entries through the history and loads the entry
+// into the input. It reports whether the key was consumed.
+func (t *TextInputOverlay) recallHistory(delta int) bool {
+	if len(t.history) == 0 || t.FocusIndex != 0 {
+		return false
+	}
+	browsing := t.historyIdx < len(t.history)
+	if delta < 0 && !browsing && t.textarea.Value() != "" && (t.textarea.Line() != 0 || t.textarea.Column() != 0) {
+		return false
+	}
+	if delta > 0 && !browsing {
+		return false
+	}
+	next := t.historyIdx + delta
+	if next < 0 {
+		return true
+	}
+	if !browsing {
+		t.draft = t.textarea.Value()
+	}
+	t.historyIdx = next
+	if next >= len(t.history) {
+		t.historyIdx = len(t.history)
+		t.textarea.SetValue(t.draft)
+	} else {
+		t.textarea.SetValue(t.history[next])
+	}
+	return true
+}
+
+// Toggled reports whether the checkbox added by SetToggle is set.
+func (t *TextInputOverlay) Toggled() bool { return t.toggled }
+
 // SetPlaceholder sets the textarea placeholder text.
 func (t *TextInputOverlay) SetPlaceholder(text string) {
 	t.textarea.Placeholder = text
@@ -74,6 +133,12 @@ func (t *TextInputOverlay) Height() int { return t.height }
 // HandleKey processes a key event and returns the result.
 // Implements the Overlay interface.
 func (t *TextInputOverlay) HandleKey(msg tea.KeyPressMsg) Result {
+	// ctrl+s flips the checkbox when there is one; otherwise it reaches the
+	// textarea like any other key.
+	if msg.String() == "ctrl+s" && t.toggleLabel != "" {
+		t.toggled = !t.toggled
+		return Result{}
+	}
 	switch msg.String() {
 	case "tab", "shift+tab":
 		t.FocusIndex = (t.FocusIndex + 1) % 2
@@ -97,7 +162,20 @@ func (t *TextInputOverlay) HandleKey(msg tea.KeyPressMsg) Result {
 		if t.OnSubmit != nil {
 			t.OnSubmit()
 		}
-		return Result{Dismissed: true, Submitted: true, Value: t.textarea.Value()}
+		result := Result{Dismissed: true, Submitted: true, Value: t.textarea.Value()}
+		if t.toggleLabel != "" && t.toggled {
+			result.Action = t.toggleAction
+		}
+		return result
+	case "up", "down":
+		delta := -1
+		if msg.String() == "down" {
+			delta = 1
+		}
+		if !t.recallHistory(delta) && t.FocusIndex == 0 {
+			t.textarea, _ = t.textarea.Update(msg)
+		}
+		return Result{}
 	case "ctrl+v":
 		if t.FocusIndex == 0 {
 			if text, err := clipboard.ReadAll(); err == nil && text != "" {
@@ -140,6 +218,13 @@ func (t *TextInputOverlay) View() string {
 	if t.multiline {
 		content += "  " + t.styles.Muted.Render("tab → enter submit · esc cancel")
 	}
+	if t.toggleLabel != "" {
+		box := "[ ]"
+		if t.toggled {
+			box = "[x]"
+		}
+		content += "\n" + t.styles.Muted.Render(box+" "+t.toggleLabel+" (ctrl+s)")
+	}
 
 	return style.Render(content)
 }
@@ -70,3 +70,72 @@ func TestTextInputOverlay_HandleKey_Cancel(t *testing.T) {
 	assert.True(t, result.Dismissed)
 	assert.False(t, result.Submitted)
 }
+
+func TestTextInputOverlay_ToggleSetsResultAction(t *testing.T) {
+	ti := NewTextInputOverlay("title", "body")
+	ti.SetToggle("squash commits", "squash", false)
+	assert.Contains(t, ti.View(), "[ ] squash commits")
+
+	ti.HandleKey(tea.KeyPressMsg{Code: 's', Mod: tea.ModCtrl})
+	assert.True(t, ti.Toggled())
+	assert.Contains(t, ti.View(), "[x] squash commits")
+
+	result := ti.HandleKey(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.True(t, result.Submitted)
+	assert.Equal(t, "squash", result.Action)
+	assert.Equal(t, "body", result.Value)
+}
+
+func TestTextInputOverlay_ToggleOffLeavesActionEmpty(t *testing.T) {
+	ti := NewTextInputOverlay("title", "")
+	ti.SetToggle("squash commits", "squash", true)
+	ti.HandleKey(tea.KeyPressMsg{Code: 's', Mod: tea.ModCtrl})
+	result := ti.HandleKey(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.Empty(t, result.Action)
+}
+
+func TestTextInputOverlay_CtrlSWithoutToggleIsNoop(t *testing.T) {
+	ti := NewTextInputOverlay("title", "")
+	ti.HandleKey(tea.KeyPressMsg{Code: 's', Mod: tea.ModCtrl})
+	assert.False(t, ti.Toggled())
+	assert.NotContains(t, ti.View(), "ctrl+s")
+}
+
+func TestTextInputOverlay_HistoryRecall(t *testing.T) {
+	ti := NewTextInputOverlay("enter prompt", "")
+	ti.SetHistory([]string{"oldest", "middle", "newest"})
+	up := tea.KeyPressMsg{Code: tea.KeyUp}
+	down := tea.KeyPressMsg{Code: tea.KeyDown}
+	value := func() string { return ti.HandleKey(tea.KeyPressMsg{Code: tea.KeyEnter}).Value }
+
+	ti.HandleKey(up)
+	assert.Equal(t, "newest", value(), "up recalls the most recent prompt first")
+	ti.HandleKey(up)
+	ti.HandleKey(up)
+	ti.HandleKey(up)
+	assert.Equal(t, "oldest", value(), "recall stops at the oldest prompt")
+	ti.HandleKey(down)
+	assert.Equal(t, "middle", value())
+	ti.HandleKey(down)
+	ti.HandleKey(down)
+	assert.Equal(t, "", value(), "down past the newest restores the draft")
+}
+
+func TestTextInputOverlay_HistoryOnlyFromStartOfInput(t *testing.T) {
+	ti := NewTextInputOverlay("enter prompt", "")
+	ti.SetHistory([]string{"previous"})
+	for _, r := range "draft" {
+		ti.HandleKey(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+
+	ti.HandleKey(tea.KeyPressMsg{Code: tea.KeyUp})
+	assert.Equal(t, "draft", ti.HandleKey(tea.KeyPressMsg{Code: tea.KeyEnter}).Value,
+		"up mid-text does not recall")
+
+	ti.HandleKey(tea.KeyPressMsg{Code: tea.KeyHome})
+	ti.HandleKey(tea.KeyPressMsg{Code: tea.KeyUp})
+	assert.Equal(t, "previous", ti.HandleKey(tea.KeyPressMsg{Code: tea.KeyEnter}).Value,
+		"with the cursor at the start, up recalls")
+	ti.HandleKey(tea.KeyPressMsg{Code: tea.KeyDown})
+	assert.Equal(t, "draft", ti.HandleKey(tea.KeyPressMsg{Code: tea.KeyEnter}).Value)
+}
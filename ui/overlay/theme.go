@@ -1,6 +1,8 @@
 package overlay
 
 import (
+	"image/color"
+
 	"charm.land/huh/v2"
 	"charm.land/lipgloss/v2"
 )
@@ -33,7 +35,7 @@ type Styles struct {
 	FocusedButton lipgloss.Style // focused/active button
 }
 
-// DefaultStyles returns the standard overlay style set using the Rosé Pine Moon palette.
+// DefaultStyles returns the standard overlay style set using the active palette.
 func DefaultStyles() Styles {
 	return Styles{
 		ModalBorder: lipgloss.NewStyle().
@@ -89,27 +91,53 @@ func DefaultStyles() Styles {
 	}
 }
 
-// Rosé Pine Moon palette — mirrors ui/theme.go.
+// Palette is the subset of the app theme the overlays draw with. The ui
+// package pushes the active theme here via SetPalette.
+type Palette struct {
+	Base    color.Color
+	Overlay color.Color
+	Muted   color.Color
+	Subtle  color.Color
+	Text    color.Color
+	Love    color.Color // error, danger
+	Gold    color.Color // warning
+	Foam    color.Color // info, running
+	Iris    color.Color // highlight, primary
+	// Dark is true for dark-background themes.
+	Dark bool
+}
+
+// Active palette; Rosé Pine Moon until SetPalette is called.
 // https://rosepinetheme.com/palette/
 var (
 	// Base tones
-	colorBase    = lipgloss.Color("#232136")
-	colorOverlay = lipgloss.Color("#393552")
-	colorMuted   = lipgloss.Color("#6e6a86")
-	colorSubtle  = lipgloss.Color("#908caa")
-	colorText    = lipgloss.Color("#e0def4")
+	colorBase    color.Color = lipgloss.Color("#232136")
+	colorOverlay color.Color = lipgloss.Color("#393552")
+	colorMuted   color.Color = lipgloss.Color("#6e6a86")
+	colorSubtle  color.Color = lipgloss.Color("#908caa")
+	colorText    color.Color = lipgloss.Color("#e0def4")
 
 	// Semantic colors
-	colorLove = lipgloss.Color("#eb6f92") // error, danger
-	colorGold = lipgloss.Color("#f6c177") // warning
-	colorFoam = lipgloss.Color("#9ccfd8") // info, running
-	colorIris = lipgloss.Color("#c4a7e7") // highlight, primary
+	colorLove color.Color = lipgloss.Color("#eb6f92") // error, danger
+	colorGold color.Color = lipgloss.Color("#f6c177") // warning
+	colorFoam color.Color = lipgloss.Color("#9ccfd8") // info, running
+	colorIris color.Color = lipgloss.Color("#c4a7e7") // highlight, primary
+
+	darkPalette = true
 )
 
+// SetPalette replaces the overlay colors. Styles built afterwards (including
+// DefaultStyles and ThemeRosePine) use the new palette.
+func SetPalette(p Palette) {
+	colorBase, colorOverlay, colorMuted, colorSubtle, colorText = p.Base, p.Overlay, p.Muted, p.Subtle, p.Text
+	colorLove, colorGold, colorFoam, colorIris = p.Love, p.Gold, p.Foam, p.Iris
+	darkPalette = p.Dark
+}
+
 // ThemeRosePine returns a huh theme matching the app's Rose Pine Moon palette.
 func ThemeRosePine() huh.Theme {
 	return huh.ThemeFunc(func(_ bool) *huh.Styles {
-		t := huh.ThemeBase(true)
+		t := huh.ThemeBase(darkPalette)
 
 		t.Focused.Base = t.Focused.Base.BorderForeground(colorIris)
 		t.Focused.Card = t.Focused.Base
@@ -215,7 +215,8 @@ func (b *TmuxBrowserOverlay) SetSize(width, height int) {
 //
 // "kill" returns Result{Action: "kill"} without Dismissed so the browser stays
 // open and the user can kill multiple sessions. The app layer must handle
-// non-dismissed action results. "adopt" and "attach" do dismiss the overlay.
+// non-dismissed action results. "adopt", "attach" and "attach_readonly" do
+// dismiss the overlay.
 func (b *TmuxBrowserOverlay) HandleKey(msg tea.KeyPressMsg) Result {
 	switch msg.Code {
 	case tea.KeyEscape:
@@ -270,6 +271,11 @@ func (b *TmuxBrowserOverlay) HandleKey(msg tea.KeyPressMsg) Result {
 						return Result{Dismissed: true, Action: "attach"}
 					}
 					return Result{}
+				case "O":
+					if len(b.filtered) > 0 {
+						return Result{Dismissed: true, Action: "attach_readonly"}
+					}
+					return Result{}
 				}
 			}
 			// All other runes type into search
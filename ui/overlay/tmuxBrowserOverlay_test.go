@@ -77,6 +77,7 @@ func TestTmuxBrowserOverlay_Actions(t *testing.T) {
 		{"k kills when search empty", tea.KeyPressMsg{Code: 'k', Text: "k"}, false, "kill"},
 		{"a adopts when search empty", tea.KeyPressMsg{Code: 'a', Text: "a"}, true, "adopt"},
 		{"o attaches when search empty", tea.KeyPressMsg{Code: 'o', Text: "o"}, true, "attach"},
+		{"O attaches read-only when search empty", tea.KeyPressMsg{Code: 'O', Text: "O"}, true, "attach_readonly"},
 	}
 
 	for _, tt := range tests {
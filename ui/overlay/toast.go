@@ -39,6 +39,8 @@ const (
 	InfoDismissAfter    = 3 * time.Second
 	SuccessDismissAfter = 3 * time.Second
 	ErrorDismissAfter   = 5 * time.Second
+	// ActionDismissAfter keeps toasts that offer a keybind up long enough to use it.
+	ActionDismissAfter = 10 * time.Second
 
 	MinToastWidth = 30
 	MaxToastWidth = 60
@@ -146,6 +148,28 @@ func (tm *ToastManager) Resolve(id string, typ ToastType, msg string) {
 	}
 }
 
+// SetDismissAfter changes how long the toast with the given ID stays visible,
+// counted from when it became visible. No-op for unknown IDs.
+func (tm *ToastManager) SetDismissAfter(id string, d time.Duration) {
+	for _, t := range tm.toasts {
+		if t.ID == id {
+			t.Duration = d
+			return
+		}
+	}
+}
+
+// IsShowing reports whether the toast with the given ID is still on screen
+// and not yet sliding out.
+func (tm *ToastManager) IsShowing(id string) bool {
+	for _, t := range tm.toasts {
+		if t.ID == id {
+			return t.Phase == PhaseSlidingIn || t.Phase == PhaseVisible
+		}
+	}
+	return false
+}
+
 // HasActiveToasts returns true if there are any toasts that have not completed
 // their animation cycle.
 func (tm *ToastManager) HasActiveToasts() bool {
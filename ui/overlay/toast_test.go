@@ -208,6 +208,26 @@ func TestToastResolveUpdatesTypeAndMessage(t *testing.T) {
 	assert.Equal(t, PhaseVisible, tm.toasts[0].Phase, "phase should be PhaseVisible after resolve")
 }
 
+func TestToastSetDismissAfterAndIsShowing(t *testing.T) {
+	s := spinner.New()
+	tm := NewToastManager(&s)
+
+	id := tm.Loading("creating PR...")
+	tm.Resolve(id, ToastSuccess, "PR created")
+	tm.SetDismissAfter(id, ActionDismissAfter)
+	assert.True(t, tm.IsShowing(id))
+
+	// Past the default success duration the toast is still up.
+	tm.toasts[0].PhaseStart = time.Now().Add(-SuccessDismissAfter - time.Millisecond)
+	tm.Tick()
+	assert.True(t, tm.IsShowing(id))
+
+	tm.toasts[0].PhaseStart = time.Now().Add(-ActionDismissAfter - time.Millisecond)
+	tm.Tick()
+	assert.False(t, tm.IsShowing(id), "a toast sliding out no longer offers its action")
+	assert.False(t, tm.IsShowing("does-not-exist"))
+}
+
 func TestToastSmallScreenClamp(t *testing.T) {
 	s := spinner.New()
 	tm := NewToastManager(&s)
@@ -12,10 +12,19 @@ import (
 )
 
 var (
-	previewPaneStyle    = lipgloss.NewStyle().Foreground(ColorText)
+	previewPaneStyle    lipgloss.Style
+	scrollbarTrackStyle lipgloss.Style
+	scrollbarThumbStyle lipgloss.Style
+)
+
+func init() { OnThemeChange(buildPreviewStyles) }
+
+// buildPreviewStyles derives the preview-pane styles from the active theme.
+func buildPreviewStyles() {
+	previewPaneStyle = lipgloss.NewStyle().Foreground(ColorText)
 	scrollbarTrackStyle = lipgloss.NewStyle().Foreground(ColorOverlay)
 	scrollbarThumbStyle = lipgloss.NewStyle().Foreground(ColorIris)
-)
+}
 
 // previewState holds the current display state of the preview pane.
 type previewState struct {
@@ -35,6 +44,10 @@ type PreviewPane struct {
 	previewState previewState
 	isScrolling  bool
 	viewport     viewport.Model
+	// scrollContent is the raw history captured on entering scroll mode.
+	scrollContent string
+	// search is the scrollback search over scrollContent.
+	search previewSearch
 
 	// bannerFrame is the current animation tick index for the idle banner.
 	bannerFrame int
@@ -75,6 +88,7 @@ func (p *PreviewPane) TickSpring() {
 func (p *PreviewPane) SetRawContent(content string) {
 	p.previewState = previewState{text: content}
 	p.isScrolling = false
+	p.search = previewSearch{}
 	p.isDocument = false
 	p.isRawTerminal = true
 }
@@ -103,6 +117,7 @@ func (p *PreviewPane) setFallbackState(message string) {
 func (p *PreviewPane) SetDocumentContent(content string) {
 	p.previewState = previewState{fallback: false}
 	p.isScrolling = false
+	p.search = previewSearch{}
 	p.isDocument = true
 	p.isRawTerminal = false
 	p.viewport.SetContent(content)
@@ -239,8 +254,7 @@ func (p *PreviewPane) UpdateContent(instance *session.Instance) error {
 		if err != nil {
 			return err
 		}
-		footer := lipgloss.NewStyle().Foreground(ColorMuted).Render("ESC to exit scroll mode")
-		p.viewport.SetContent(lipgloss.JoinVertical(lipgloss.Left, content, footer))
+		p.setScrollContent(content)
 	}
 	// Normal mode: live content arrives via SetRawContent from the VT emulator.
 	return nil
@@ -432,8 +446,8 @@ func (p *PreviewPane) enterScrollMode(instance *session.Instance) error {
 	if err != nil {
 		return err
 	}
-	footer := lipgloss.NewStyle().Foreground(ColorMuted).Render("ESC to exit scroll mode")
-	p.viewport.SetContent(lipgloss.JoinVertical(lipgloss.Left, content, footer))
+	p.search = previewSearch{}
+	p.setScrollContent(content)
 	p.viewport.GotoBottom()
 	p.isScrolling = true
 	return nil
@@ -522,6 +536,8 @@ func (p *PreviewPane) ResetToNormalMode(instance *session.Instance) error {
 		return nil
 	}
 	p.isScrolling = false
+	p.search = previewSearch{}
+	p.scrollContent = ""
 	p.viewport.SetContent("")
 	p.viewport.GotoTop()
 
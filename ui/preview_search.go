@@ -0,0 +1,250 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var (
+	searchMatchStyle   lipgloss.Style
+	searchCurrentStyle lipgloss.Style
+	searchFooterStyle  lipgloss.Style
+)
+
+func init() { OnThemeChange(buildPreviewSearchStyles) }
+
+// buildPreviewSearchStyles derives the scrollback search styles from the active theme.
+func buildPreviewSearchStyles() {
+	searchMatchStyle = lipgloss.NewStyle().Foreground(ColorBase).Background(ColorGold)
+	searchCurrentStyle = lipgloss.NewStyle().Foreground(ColorBase).Background(ColorIris).Bold(true)
+	searchFooterStyle = lipgloss.NewStyle().Foreground(ColorMuted)
+}
+
+// previewSearch is the scrollback search over the buffer captured for scroll
+// mode. Matches are tracked per buffer line.
+type previewSearch struct {
+	query string
+	// editing is true while the query is still being typed.
+	editing bool
+	// matches holds the indices of buffer lines containing query, ascending.
+	matches []int
+	// current indexes matches; ignored when there are none.
+	current int
+}
+
+// currentLine returns the buffer line of the focused match, or -1.
+func (s previewSearch) currentLine() int {
+	if s.current < 0 || s.current >= len(s.matches) {
+		return -1
+	}
+	return s.matches[s.current]
+}
+
+// findLineMatches returns the indices of the lines in content whose visible
+// text contains query, ignoring case and ANSI escapes.
+func findLineMatches(content, query string) []int {
+	if query == "" || content == "" {
+		return nil
+	}
+	var out []int
+	for i, line := range strings.Split(content, "\n") {
+		if len(matchSpans(ansi.Strip(line), query)) > 0 {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// matchSpans returns the rune [start, end) spans of every non-overlapping,
+// case-insensitive occurrence of query in plain.
+func matchSpans(plain, query string) [][2]int {
+	q := []rune(strings.ToLower(query))
+	if len(q) == 0 {
+		return nil
+	}
+	r := []rune(plain)
+	var spans [][2]int
+	for i := 0; i+len(q) <= len(r); {
+		j := 0
+		for j < len(q) && unicode.ToLower(r[i+j]) == q[j] {
+			j++
+		}
+		if j == len(q) {
+			spans = append(spans, [2]int{i, i + len(q)})
+			i += len(q)
+			continue
+		}
+		i++
+	}
+	return spans
+}
+
+// highlightLine renders line without its ANSI styling and with every
+// occurrence of query drawn in style.
+func highlightLine(line, query string, style lipgloss.Style) string {
+	plain := ansi.Strip(line)
+	spans := matchSpans(plain, query)
+	if len(spans) == 0 {
+		return line
+	}
+	r := []rune(plain)
+	var sb strings.Builder
+	prev := 0
+	for _, sp := range spans {
+		sb.WriteString(string(r[prev:sp[0]]))
+		sb.WriteString(style.Render(string(r[sp[0]:sp[1]])))
+		prev = sp[1]
+	}
+	sb.WriteString(string(r[prev:]))
+	return sb.String()
+}
+
+// setScrollContent stores the captured history for scroll mode, re-runs any
+// active search against it and renders it into the viewport.
+func (p *PreviewPane) setScrollContent(content string) {
+	p.scrollContent = content
+	p.rerunSearch()
+	p.renderScrollContent()
+}
+
+// rerunSearch recomputes the matches for the current query, keeping focus on
+// the previously focused line when it still matches.
+func (p *PreviewPane) rerunSearch() {
+	prevLine := p.search.currentLine()
+	p.search.matches = findLineMatches(p.scrollContent, p.search.query)
+	p.search.current = -1
+	if len(p.search.matches) == 0 {
+		return
+	}
+	p.search.current = len(p.search.matches) - 1
+	for i, line := range p.search.matches {
+		if line >= prevLine && prevLine >= 0 {
+			p.search.current = i
+			break
+		}
+	}
+}
+
+// renderScrollContent writes the scroll buffer into the viewport, highlighting
+// search matches and appending the scroll-mode footer.
+func (p *PreviewPane) renderScrollContent() {
+	content := p.scrollContent
+	if p.search.query != "" && len(p.search.matches) > 0 {
+		lines := strings.Split(content, "\n")
+		focused := p.search.currentLine()
+		for _, idx := range p.search.matches {
+			style := searchMatchStyle
+			if idx == focused {
+				style = searchCurrentStyle
+			}
+			lines[idx] = highlightLine(lines[idx], p.search.query, style)
+		}
+		content = strings.Join(lines, "\n")
+	}
+	yOffset := p.viewport.YOffset()
+	p.viewport.SetContent(lipgloss.JoinVertical(lipgloss.Left, content, p.scrollFooter()))
+	p.viewport.SetYOffset(yOffset)
+}
+
+// scrollFooter returns the status line shown under the scroll buffer.
+func (p *PreviewPane) scrollFooter() string {
+	s := p.search
+	switch {
+	case s.editing:
+		return searchFooterStyle.Render("/" + s.query + "█")
+	case s.query == "":
+		return searchFooterStyle.Render("ESC to exit scroll mode · / to search")
+	case len(s.matches) == 0:
+		return searchFooterStyle.Render(fmt.Sprintf("/%s  no matches · ESC to clear", s.query))
+	default:
+		return searchFooterStyle.Render(fmt.Sprintf("/%s  %d/%d · n/N next/prev · ESC to clear",
+			s.query, s.current+1, len(s.matches)))
+	}
+}
+
+// jumpToCurrentMatch scrolls the viewport so the focused match is centred.
+func (p *PreviewPane) jumpToCurrentMatch() {
+	line := p.search.currentLine()
+	if line < 0 {
+		return
+	}
+	p.viewport.SetYOffset(max(0, line-p.viewport.Height()/2))
+}
+
+// StartSearch begins typing a new scrollback search. No-op outside scroll mode.
+func (p *PreviewPane) StartSearch() bool {
+	if !p.isScrolling {
+		return false
+	}
+	p.search = previewSearch{editing: true}
+	p.renderScrollContent()
+	return true
+}
+
+// SetSearchQuery updates the query, focuses the match nearest above the
+// bottom of the view and scrolls to it. Returns the number of matching lines.
+func (p *PreviewPane) SetSearchQuery(query string) int {
+	p.search.query = query
+	p.search.matches = findLineMatches(p.scrollContent, query)
+	p.search.current = -1
+	if n := len(p.search.matches); n > 0 {
+		bottom := p.viewport.YOffset() + p.viewport.Height() - 1
+		p.search.current = n - 1
+		for i := n - 1; i >= 0; i-- {
+			if p.search.matches[i] <= bottom {
+				p.search.current = i
+				break
+			}
+		}
+	}
+	p.renderScrollContent()
+	p.jumpToCurrentMatch()
+	return len(p.search.matches)
+}
+
+// SearchQuery returns the current scrollback search query.
+func (p *PreviewPane) SearchQuery() string { return p.search.query }
+
+// IsSearchActive reports whether a scrollback search is being typed or shown.
+func (p *PreviewPane) IsSearchActive() bool {
+	return p.isScrolling && (p.search.editing || p.search.query != "")
+}
+
+// EndSearchInput stops editing the query, keeping its matches highlighted.
+// An empty query clears the search.
+func (p *PreviewPane) EndSearchInput() {
+	if p.search.query == "" {
+		p.ClearSearch()
+		return
+	}
+	p.search.editing = false
+	p.renderScrollContent()
+}
+
+// ClearSearch drops the search and its highlights.
+func (p *PreviewPane) ClearSearch() {
+	p.search = previewSearch{}
+	if p.isScrolling {
+		p.renderScrollContent()
+	}
+}
+
+// NextMatch focuses the next match below the current one, wrapping.
+func (p *PreviewPane) NextMatch() { p.stepMatch(1) }
+
+// PrevMatch focuses the previous match above the current one, wrapping.
+func (p *PreviewPane) PrevMatch() { p.stepMatch(-1) }
+
+func (p *PreviewPane) stepMatch(delta int) {
+	n := len(p.search.matches)
+	if n == 0 {
+		return
+	}
+	p.search.current = ((p.search.current+delta)%n + n) % n
+	p.renderScrollContent()
+	p.jumpToCurrentMatch()
+}
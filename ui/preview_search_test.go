@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const searchBuffer = "building\n\x1b[31mERROR\x1b[0m: dial tcp\nretrying\nerror again\nok"
+
+func TestFindLineMatches(t *testing.T) {
+	assert.Equal(t, []int{1, 3}, findLineMatches(searchBuffer, "error"), "case-insensitive and ANSI-blind")
+	assert.Equal(t, []int{1}, findLineMatches(searchBuffer, "error:"), "escapes between words do not break a match")
+	assert.Nil(t, findLineMatches(searchBuffer, "panic"))
+	assert.Nil(t, findLineMatches(searchBuffer, ""))
+}
+
+func TestHighlightLine_MarksEveryOccurrence(t *testing.T) {
+	style := lipgloss.NewStyle().Bold(true)
+	out := highlightLine("\x1b[31mab\x1b[0m AB ab", "ab", style)
+	assert.Equal(t, "ab AB ab", ansi.Strip(out))
+	assert.Equal(t, 3, strings.Count(out, style.Render("ab"))+strings.Count(out, style.Render("AB")))
+
+	assert.Equal(t, "plain", highlightLine("plain", "zz", style), "lines without a match are untouched")
+}
+
+func newSearchPreview(t *testing.T) *PreviewPane {
+	t.Helper()
+	p := NewPreviewPane()
+	p.SetSize(40, 3)
+	p.isScrolling = true
+	p.setScrollContent(searchBuffer)
+	p.viewport.GotoBottom()
+	require.True(t, p.StartSearch())
+	return p
+}
+
+func TestPreviewSearch_CyclesMatchesWithWrap(t *testing.T) {
+	p := newSearchPreview(t)
+
+	require.Equal(t, 2, p.SetSearchQuery("error"))
+	assert.Equal(t, 3, p.search.currentLine(), "the match nearest the bottom of the view is focused first")
+
+	p.NextMatch()
+	assert.Equal(t, 1, p.search.currentLine(), "next wraps to the first match")
+	assert.LessOrEqual(t, p.viewport.YOffset(), 1, "viewport scrolls to the focused match")
+
+	p.PrevMatch()
+	assert.Equal(t, 3, p.search.currentLine(), "previous wraps to the last match")
+
+	p.EndSearchInput()
+	assert.True(t, p.IsSearchActive())
+	assert.Contains(t, ansi.Strip(p.viewport.GetContent()), "/error  2/2")
+}
+
+func TestPreviewSearch_ReRunsOnNewContent(t *testing.T) {
+	p := newSearchPreview(t)
+	p.SetSearchQuery("error")
+	p.NextMatch() // focus line 1
+
+	p.setScrollContent(searchBuffer + "\nyet another error")
+	assert.Equal(t, []int{1, 3, 5}, p.search.matches)
+	assert.Equal(t, 1, p.search.currentLine(), "focus stays on the same line across refreshes")
+}
+
+func TestPreviewSearch_EmptyQueryClears(t *testing.T) {
+	p := newSearchPreview(t)
+	assert.Equal(t, 0, p.SetSearchQuery(""))
+	p.EndSearchInput()
+	assert.False(t, p.IsSearchActive())
+
+	assert.False(t, NewPreviewPane().StartSearch(), "search needs scroll mode")
+}
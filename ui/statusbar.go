@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"charm.land/lipgloss/v2"
+	zone "github.com/lrstanley/bubblezone/v2"
 )
 
 // TaskGlyph represents the completion state of a single task in wave progress.
@@ -30,6 +31,8 @@ type StatusBarData struct {
 	ProjectDir       string      // project directory name, shown right-aligned
 	PRState          string      // approved, changes_requested, pending (empty = no PR)
 	PRChecks         string      // passing, failing, pending (empty = unknown)
+	PlanTokensUsed   int         // tokens reported across the plan's agents (0 = unknown)
+	PlanCostUSD      float64     // cost reported across the plan's agents (0 = unknown)
 }
 
 // StatusBar renders the top status bar row of the TUI.
@@ -54,30 +57,43 @@ func (s *StatusBar) SetData(data StatusBarData) {
 }
 
 // Package-level styles — defined once to avoid repeated allocations.
-var statusBarStyle = lipgloss.NewStyle().
-	Foreground(ColorText).
-	Padding(0, 1)
+var statusBarStyle lipgloss.Style
 
 var statusBarAppNameStyle = lipgloss.NewStyle().
 	Bold(true)
 
-var statusBarVersionStyle = lipgloss.NewStyle().
-	Foreground(ColorMuted)
+var statusBarVersionStyle lipgloss.Style
 
-var statusBarSepStyle = lipgloss.NewStyle().
-	Foreground(ColorOverlay)
+var statusBarSepStyle lipgloss.Style
 
-var statusBarBranchStyle = lipgloss.NewStyle().
-	Foreground(ColorFoam)
+var statusBarBranchStyle lipgloss.Style
 
-var statusBarWaveLabelStyle = lipgloss.NewStyle().
-	Foreground(ColorSubtle)
+var statusBarWaveLabelStyle lipgloss.Style
 
-var statusBarTmuxCountStyle = lipgloss.NewStyle().
-	Foreground(ColorMuted)
+var statusBarTmuxCountStyle lipgloss.Style
 
-var statusBarProjectDirStyle = lipgloss.NewStyle().
-	Foreground(ColorMuted)
+var statusBarProjectDirStyle lipgloss.Style
+
+func init() { OnThemeChange(buildStatusBarStyles) }
+
+// buildStatusBarStyles derives the status bar styles from the active theme.
+func buildStatusBarStyles() {
+	statusBarStyle = lipgloss.NewStyle().
+		Foreground(ColorText).
+		Padding(0, 1)
+	statusBarVersionStyle = lipgloss.NewStyle().
+		Foreground(ColorMuted)
+	statusBarSepStyle = lipgloss.NewStyle().
+		Foreground(ColorOverlay)
+	statusBarBranchStyle = lipgloss.NewStyle().
+		Foreground(ColorFoam)
+	statusBarWaveLabelStyle = lipgloss.NewStyle().
+		Foreground(ColorSubtle)
+	statusBarTmuxCountStyle = lipgloss.NewStyle().
+		Foreground(ColorMuted)
+	statusBarProjectDirStyle = lipgloss.NewStyle().
+		Foreground(ColorMuted)
+}
 
 // planStatusStyle returns a styled version of status using semantic colors.
 func planStatusStyle(status string) string {
@@ -140,7 +156,8 @@ func (s *StatusBar) centerBranchGroup() string {
 }
 
 // leftStatusGroup assembles the status segment placed immediately after the logo.
-// Priority: wave-progress glyphs + label > plan status string.
+// Priority: wave-progress glyphs + label > plan status string, followed by the
+// plan's token usage when known.
 func (s *StatusBar) leftStatusGroup() string {
 	var parts []string
 
@@ -152,7 +169,11 @@ func (s *StatusBar) leftStatusGroup() string {
 		glyphs := strings.Join(rendered, " ")
 		parts = append(parts, glyphs+" "+statusBarWaveLabelStyle.Render(s.data.WaveLabel))
 	} else if s.data.PlanStatus != "" {
-		parts = append(parts, planStatusStyle(s.data.PlanStatus))
+		parts = append(parts, zone.Mark(ZonePlanStatus, planStatusStyle(s.data.PlanStatus)))
+	}
+
+	if usage := FormatTokenUsage(s.data.PlanTokensUsed, s.data.PlanCostUSD); usage != "" && len(parts) > 0 {
+		parts = append(parts, statusBarWaveLabelStyle.Render(usage))
 	}
 
 	if len(parts) == 0 {
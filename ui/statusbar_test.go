@@ -4,25 +4,13 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/charmbracelet/x/ansi"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func stripANSI(s string) string {
-	var b strings.Builder
-	inEsc := false
-	for _, r := range s {
-		if r == '\x1b' {
-			inEsc = true
-		}
-		if !inEsc {
-			b.WriteRune(r)
-		}
-		if inEsc && r == 'm' {
-			inEsc = false
-		}
-	}
-	return b.String()
+	return ansi.Strip(s)
 }
 
 func TestStatusBar_Baseline(t *testing.T) {
@@ -54,6 +42,26 @@ func TestStatusBar_PlanContext(t *testing.T) {
 	assert.Contains(t, result, "implementing")
 }
 
+func TestStatusBar_PlanTokenUsage(t *testing.T) {
+	sb := NewStatusBar()
+	sb.SetSize(120)
+	sb.SetData(StatusBarData{
+		Branch:         "plan/auth-refactor",
+		PlanName:       "auth-refactor",
+		PlanStatus:     "implementing",
+		PlanTokensUsed: 12500,
+		PlanCostUSD:    0.42,
+	})
+	assert.Contains(t, stripANSI(sb.String()), "implementing · 12.5K tok · $0.42")
+}
+
+func TestFormatTokenUsage(t *testing.T) {
+	assert.Equal(t, "", FormatTokenUsage(0, 0))
+	assert.Equal(t, "850 tok", FormatTokenUsage(850, 0))
+	assert.Equal(t, "$0.05", FormatTokenUsage(0, 0.05))
+	assert.Equal(t, "1.5M tok · $12.80", FormatTokenUsage(1_500_000, 12.8))
+}
+
 func TestStatusBar_StatusLeftAlignedAfterLogo(t *testing.T) {
 	sb := NewStatusBar()
 	sb.SetSize(120)
@@ -27,24 +27,33 @@ var (
 	// activeTabBorder lifts the active tab by making the bottom edge invisible.
 	activeTabBorder = tabBorderWithBottom("┘", " ", "└")
 
-	inactiveTabStyle = lipgloss.NewStyle().
-				Border(inactiveTabBorder, true).
-				BorderForeground(ColorIris).
-				AlignHorizontal(lipgloss.Center)
-
-	activeTabStyle = inactiveTabStyle.
-			Border(activeTabBorder, true).
-			AlignHorizontal(lipgloss.Center)
+	// inactiveTabStyle and activeTabStyle are built by buildTabStyles.
+	inactiveTabStyle lipgloss.Style
+	activeTabStyle   lipgloss.Style
 
 	windowBorder = lipgloss.RoundedBorder()
 
 	// windowStyle draws the right, bottom, and left borders of the content area.
 	// The top border is omitted because the tab row sits flush against it.
-	windowStyle = lipgloss.NewStyle().
-			BorderForeground(ColorIris).
-			Border(windowBorder, false, true, true, true)
+	windowStyle lipgloss.Style
 )
 
+func init() { OnThemeChange(buildTabStyles) }
+
+// buildTabStyles derives the tab and window border styles from the active theme.
+func buildTabStyles() {
+	inactiveTabStyle = lipgloss.NewStyle().
+		Border(inactiveTabBorder, true).
+		BorderForeground(ColorIris).
+		AlignHorizontal(lipgloss.Center)
+	activeTabStyle = inactiveTabStyle.
+		Border(activeTabBorder, true).
+		AlignHorizontal(lipgloss.Center)
+	windowStyle = lipgloss.NewStyle().
+		BorderForeground(ColorIris).
+		Border(windowBorder, false, true, true, true)
+}
+
 // Tab index constants.
 const (
 	// Deprecated: InfoTab is kept as a compile-time shim until task 4 removes all
@@ -93,6 +102,9 @@ type TabbedWindow struct {
 	instanceTabs []InstanceTab
 	// showInfo controls whether the compact info summary is visible above the tab bar.
 	showInfo bool
+	// follow keeps the preview scrolled to the bottom as new output arrives.
+	// Scrolling up turns it off; scrolling back to the bottom turns it on.
+	follow bool
 }
 
 // NewTabbedWindow creates a TabbedWindow wiring the two child panes together.
@@ -105,6 +117,7 @@ func NewTabbedWindow(preview *PreviewPane, info *InfoPane) *TabbedWindow {
 		focusedTab:  -1,
 		showWelcome: true,
 		showInfo:    true,
+		follow:      true,
 	}
 }
 
@@ -294,6 +307,52 @@ func (w *TabbedWindow) UpdatePreview(instance *session.Instance) error {
 	return w.preview.UpdateContent(instance)
 }
 
+// HandlePreviewOutput applies new live terminal output to the preview. Outside
+// scroll mode the live content is shown as-is. In scroll mode the history is
+// not touched; it reports whether the caller should re-capture it (only while
+// following, since a paused view would not show the new output anyway).
+func (w *TabbedWindow) HandlePreviewOutput(content string) bool {
+	if !w.preview.isScrolling {
+		w.preview.SetRawContent(content)
+		return false
+	}
+	return w.follow
+}
+
+// SetPreviewScrollback replaces the scroll-mode history with a fresh capture,
+// re-running any active search, and tails it when following. No-op once the
+// preview has left scroll mode.
+func (w *TabbedWindow) SetPreviewScrollback(content string) {
+	if !w.preview.isScrolling {
+		return
+	}
+	w.preview.setScrollContent(content)
+	if w.follow && !w.preview.IsSearchActive() {
+		w.preview.viewport.GotoBottom()
+	}
+}
+
+// IsFollowing reports whether the preview tails new output.
+func (w *TabbedWindow) IsFollowing() bool { return w.follow }
+
+// ToggleFollow flips follow mode and returns the new state. Turning it on
+// jumps the preview to the bottom.
+func (w *TabbedWindow) ToggleFollow() bool {
+	w.follow = !w.follow
+	if w.follow && w.preview.isScrolling {
+		w.preview.viewport.GotoBottom()
+	}
+	return w.follow
+}
+
+// syncFollow re-derives follow mode after a manual scroll: scrolling away from
+// the bottom stops following, reaching the bottom resumes it.
+func (w *TabbedWindow) syncFollow() {
+	if w.preview.isScrolling {
+		w.follow = w.preview.viewport.AtBottom()
+	}
+}
+
 // SetPreviewContent sets preview content directly from a pre-rendered string.
 // Used by the embedded terminal in focus mode to bypass tmux capture-pane.
 func (w *TabbedWindow) SetPreviewContent(content string) {
@@ -320,7 +379,9 @@ func (w *TabbedWindow) IsDocumentMode() bool { return w.preview.IsDocumentMode()
 // ViewportUpdate forwards a tea.Msg to the preview viewport for native key
 // handling (PgUp/PgDn, Home/End, etc.) regardless of active tab.
 func (w *TabbedWindow) ViewportUpdate(msg tea.Msg) tea.Cmd {
-	return w.preview.ViewportUpdate(msg)
+	cmd := w.preview.ViewportUpdate(msg)
+	w.syncFollow()
+	return cmd
 }
 
 // ViewportHandlesKey reports whether the preview viewport keymap handles msg,
@@ -337,6 +398,33 @@ func (w *TabbedWindow) ResetPreviewToNormalMode(instance *session.Instance) erro
 // IsPreviewInScrollMode reports whether the preview pane is in scroll mode.
 func (w *TabbedWindow) IsPreviewInScrollMode() bool { return w.preview.isScrolling }
 
+// StartPreviewSearch begins a scrollback search. Returns false outside scroll mode.
+func (w *TabbedWindow) StartPreviewSearch() bool { return w.preview.StartSearch() }
+
+// SetPreviewSearchQuery updates the scrollback search query and returns the
+// number of matching lines.
+func (w *TabbedWindow) SetPreviewSearchQuery(query string) int {
+	return w.preview.SetSearchQuery(query)
+}
+
+// PreviewSearchQuery returns the scrollback search query.
+func (w *TabbedWindow) PreviewSearchQuery() string { return w.preview.SearchQuery() }
+
+// IsPreviewSearchActive reports whether a scrollback search is in progress.
+func (w *TabbedWindow) IsPreviewSearchActive() bool { return w.preview.IsSearchActive() }
+
+// EndPreviewSearchInput finishes typing the query, keeping its highlights.
+func (w *TabbedWindow) EndPreviewSearchInput() { w.preview.EndSearchInput() }
+
+// ClearPreviewSearch drops the scrollback search and its highlights.
+func (w *TabbedWindow) ClearPreviewSearch() { w.preview.ClearSearch() }
+
+// NextPreviewMatch focuses the next scrollback search match.
+func (w *TabbedWindow) NextPreviewMatch() { w.preview.NextMatch() }
+
+// PrevPreviewMatch focuses the previous scrollback search match.
+func (w *TabbedWindow) PrevPreviewMatch() { w.preview.PrevMatch() }
+
 // ── Info pane delegation ──────────────────────────────────────────────────────
 
 // SetInfoData updates the metadata shown in the info pane.
@@ -352,6 +440,7 @@ func (w *TabbedWindow) ScrollUp() {
 	if err := w.preview.ScrollUp(w.instance); err != nil {
 		log.InfoLog.Printf("tabbed window failed to scroll up: %v", err)
 	}
+	w.syncFollow()
 }
 
 // ScrollDown scrolls the preview pane downward, regardless of active tab.
@@ -359,6 +448,7 @@ func (w *TabbedWindow) ScrollDown() {
 	if err := w.preview.ScrollDown(w.instance); err != nil {
 		log.InfoLog.Printf("tabbed window failed to scroll down: %v", err)
 	}
+	w.syncFollow()
 }
 
 // HalfPageUp scrolls the preview pane up by half a page, regardless of which
@@ -367,6 +457,7 @@ func (w *TabbedWindow) HalfPageUp() {
 	if err := w.preview.HalfPageUp(w.instance); err != nil {
 		log.InfoLog.Printf("tabbed window failed to half page up: %v", err)
 	}
+	w.syncFollow()
 }
 
 // HalfPageDown scrolls the preview pane down by half a page, regardless of
@@ -375,6 +466,7 @@ func (w *TabbedWindow) HalfPageDown() {
 	if err := w.preview.HalfPageDown(w.instance); err != nil {
 		log.InfoLog.Printf("tabbed window failed to half page down: %v", err)
 	}
+	w.syncFollow()
 }
 
 // ContentScrollUp scrolls the preview pane upward without file navigation,
@@ -383,6 +475,7 @@ func (w *TabbedWindow) ContentScrollUp() {
 	if err := w.preview.ScrollUp(w.instance); err != nil {
 		log.InfoLog.Printf("tabbed window failed to content scroll up: %v", err)
 	}
+	w.syncFollow()
 }
 
 // ContentScrollDown scrolls the preview pane downward without file navigation,
@@ -391,6 +484,7 @@ func (w *TabbedWindow) ContentScrollDown() {
 	if err := w.preview.ScrollDown(w.instance); err != nil {
 		log.InfoLog.Printf("tabbed window failed to content scroll down: %v", err)
 	}
+	w.syncFollow()
 }
 
 // ── Banner animation ──────────────────────────────────────────────────────────
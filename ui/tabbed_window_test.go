@@ -5,6 +5,7 @@ import (
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUpdatePreview_SkipsWhenFocusMode(t *testing.T) {
@@ -196,3 +197,48 @@ func TestUpdatePreview_AlwaysUpdatesWithoutInfoTab(t *testing.T) {
 	assert.True(t, preview.previewState.fallback,
 		"UpdatePreview should update content (no InfoTab guard)")
 }
+
+func newFollowTestWindow(t *testing.T) (*TabbedWindow, *PreviewPane) {
+	t.Helper()
+	preview := NewPreviewPane()
+	preview.SetSize(30, 5)
+	preview.isScrolling = true
+	preview.setScrollContent(testDocumentLines(40))
+	preview.viewport.GotoBottom()
+	return NewTabbedWindow(preview, NewInfoPane()), preview
+}
+
+func TestFollowMode_TailsNewOutputInScrollMode(t *testing.T) {
+	tw, preview := newFollowTestWindow(t)
+	require.True(t, tw.IsFollowing(), "follow is on by default")
+
+	assert.True(t, tw.HandlePreviewOutput(""), "a following scroll view asks for a re-capture")
+	tw.SetPreviewScrollback(testDocumentLines(80))
+	assert.True(t, preview.viewport.AtBottom(), "following keeps the view at the newest output")
+}
+
+func TestFollowMode_ScrollingUpStopsAndBottomResumes(t *testing.T) {
+	tw, preview := newFollowTestWindow(t)
+
+	tw.ViewportUpdate(tea.KeyPressMsg{Code: tea.KeyPgUp})
+	assert.False(t, tw.IsFollowing(), "scrolling up turns follow off")
+
+	offset := preview.viewport.YOffset()
+	assert.False(t, tw.HandlePreviewOutput(""), "no re-capture while the view is paused")
+	tw.SetPreviewScrollback(testDocumentLines(80))
+	assert.Equal(t, offset, preview.viewport.YOffset(), "view stays put when not following")
+
+	for !preview.viewport.AtBottom() {
+		tw.ViewportUpdate(tea.KeyPressMsg{Code: tea.KeyPgDown})
+	}
+	assert.True(t, tw.IsFollowing(), "reaching the bottom turns follow back on")
+}
+
+func TestToggleFollow_JumpsToBottom(t *testing.T) {
+	tw, preview := newFollowTestWindow(t)
+	assert.False(t, tw.ToggleFollow())
+
+	preview.viewport.GotoTop()
+	assert.True(t, tw.ToggleFollow())
+	assert.True(t, preview.viewport.AtBottom())
+}
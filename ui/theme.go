@@ -1,27 +1,205 @@
 package ui
 
-import "charm.land/lipgloss/v2"
+import (
+	"os"
+	"strconv"
+	"strings"
 
-// Rosé Pine Moon palette
+	"charm.land/lipgloss/v2"
+	"github.com/kastheco/kasmos/ui/overlay"
+)
+
+// Theme holds the named palette colors as hex strings. The active theme is
+// copied into the Color* variables by ApplyTheme.
+type Theme struct {
+	Name string
+
+	// Base tones
+	Base    string
+	Surface string
+	Overlay string
+	Muted   string
+	Subtle  string
+	Text    string
+
+	// Semantic colors
+	Love string // error, danger
+	Gold string // warning
+	Rose string // accent, secondary
+	Pine string // link
+	Foam string // info, running
+	Iris string // highlight, primary
+
+	// Gradient endpoints for the banner and focused tab label
+	GradientStart string
+	GradientEnd   string
+}
+
+// Theme names accepted by ResolveTheme (and config.Config.Theme).
+const (
+	ThemeNameDark  = "dark"
+	ThemeNameLight = "light"
+	ThemeNameAuto  = "auto"
+)
+
+// ThemeDark is the Rosé Pine Moon palette.
 // https://rosepinetheme.com/palette/
+var ThemeDark = Theme{
+	Name:          ThemeNameDark,
+	Base:          "#232136",
+	Surface:       "#2a273f",
+	Overlay:       "#393552",
+	Muted:         "#6e6a86",
+	Subtle:        "#908caa",
+	Text:          "#e0def4",
+	Love:          "#eb6f92",
+	Gold:          "#f6c177",
+	Rose:          "#ea9a97",
+	Pine:          "#3e8fb0",
+	Foam:          "#9ccfd8",
+	Iris:          "#c4a7e7",
+	GradientStart: "#9ccfd8", // foam
+	GradientEnd:   "#c4a7e7", // iris
+}
+
+// ThemeLight is based on Rosé Pine Dawn, with the muted and accent tones
+// darkened so text on Base keeps at least a 4:1 contrast ratio.
+var ThemeLight = Theme{
+	Name:          ThemeNameLight,
+	Base:          "#faf4ed",
+	Surface:       "#fffaf3",
+	Overlay:       "#dfdad9",
+	Muted:         "#797593",
+	Subtle:        "#625e7d",
+	Text:          "#464261",
+	Love:          "#b4637a",
+	Gold:          "#a0631a",
+	Rose:          "#b05e5a",
+	Pine:          "#286983",
+	Foam:          "#3b7a85",
+	Iris:          "#7a5f99",
+	GradientStart: "#3b7a85", // foam
+	GradientEnd:   "#7a5f99", // iris
+}
+
+// Palette colors of the active theme. Set by ApplyTheme; read these (not the
+// Theme structs) when building styles.
 var (
 	// Base tones
-	ColorBase    = lipgloss.Color("#232136")
-	ColorSurface = lipgloss.Color("#2a273f")
-	ColorOverlay = lipgloss.Color("#393552")
-	ColorMuted   = lipgloss.Color("#6e6a86")
-	ColorSubtle  = lipgloss.Color("#908caa")
-	ColorText    = lipgloss.Color("#e0def4")
+	ColorBase    = lipgloss.Color(ThemeDark.Base)
+	ColorSurface = lipgloss.Color(ThemeDark.Surface)
+	ColorOverlay = lipgloss.Color(ThemeDark.Overlay)
+	ColorMuted   = lipgloss.Color(ThemeDark.Muted)
+	ColorSubtle  = lipgloss.Color(ThemeDark.Subtle)
+	ColorText    = lipgloss.Color(ThemeDark.Text)
 
 	// Semantic colors
-	ColorLove = lipgloss.Color("#eb6f92") // error, danger
-	ColorGold = lipgloss.Color("#f6c177") // warning
-	ColorRose = lipgloss.Color("#ea9a97") // accent, secondary
-	ColorPine = lipgloss.Color("#3e8fb0") // link
-	ColorFoam = lipgloss.Color("#9ccfd8") // info, running
-	ColorIris = lipgloss.Color("#c4a7e7") // highlight, primary
+	ColorLove = lipgloss.Color(ThemeDark.Love) // error, danger
+	ColorGold = lipgloss.Color(ThemeDark.Gold) // warning
+	ColorRose = lipgloss.Color(ThemeDark.Rose) // accent, secondary
+	ColorPine = lipgloss.Color(ThemeDark.Pine) // link
+	ColorFoam = lipgloss.Color(ThemeDark.Foam) // info, running
+	ColorIris = lipgloss.Color(ThemeDark.Iris) // highlight, primary
 
 	// Gradient endpoints for the banner and focused tab label
-	GradientStart = "#9ccfd8" // foam
-	GradientEnd   = "#c4a7e7" // iris
+	GradientStart = ThemeDark.GradientStart
+	GradientEnd   = ThemeDark.GradientEnd
+
+	activeTheme = ThemeDark
+	themeHooks  []func()
 )
+
+// ActiveTheme returns the theme most recently applied.
+func ActiveTheme() Theme { return activeTheme }
+
+// ApplyTheme makes t the active theme and rebuilds every style derived from
+// the palette. Call it before the UI is constructed.
+func ApplyTheme(t Theme) {
+	setPalette(t)
+	for _, fn := range themeHooks {
+		fn()
+	}
+}
+
+// OnThemeChange registers fn to rebuild package-level styles from the Color*
+// variables. fn runs immediately and again after every ApplyTheme.
+func OnThemeChange(fn func()) {
+	themeHooks = append(themeHooks, fn)
+	fn()
+}
+
+// setPalette copies t into the Color* variables and the overlay palette.
+func setPalette(t Theme) {
+	activeTheme = t
+	ColorBase = lipgloss.Color(t.Base)
+	ColorSurface = lipgloss.Color(t.Surface)
+	ColorOverlay = lipgloss.Color(t.Overlay)
+	ColorMuted = lipgloss.Color(t.Muted)
+	ColorSubtle = lipgloss.Color(t.Subtle)
+	ColorText = lipgloss.Color(t.Text)
+	ColorLove = lipgloss.Color(t.Love)
+	ColorGold = lipgloss.Color(t.Gold)
+	ColorRose = lipgloss.Color(t.Rose)
+	ColorPine = lipgloss.Color(t.Pine)
+	ColorFoam = lipgloss.Color(t.Foam)
+	ColorIris = lipgloss.Color(t.Iris)
+	GradientStart = t.GradientStart
+	GradientEnd = t.GradientEnd
+
+	overlay.SetPalette(overlay.Palette{
+		Base:    ColorBase,
+		Overlay: ColorOverlay,
+		Muted:   ColorMuted,
+		Subtle:  ColorSubtle,
+		Text:    ColorText,
+		Love:    ColorLove,
+		Gold:    ColorGold,
+		Foam:    ColorFoam,
+		Iris:    ColorIris,
+		Dark:    t.Name != ThemeNameLight,
+	})
+}
+
+// queryDarkBackground asks the terminal for its background color. Tests
+// override it.
+var queryDarkBackground = func() bool {
+	return lipgloss.HasDarkBackground(os.Stdin, os.Stdout)
+}
+
+// ResolveTheme maps a config theme name to a Theme. "auto" picks light or
+// dark from COLORFGBG, falling back to querying the terminal background.
+// Unknown or empty names use the dark theme.
+func ResolveTheme(name string) Theme {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case ThemeNameLight:
+		return ThemeLight
+	case ThemeNameAuto:
+		if dark, ok := colorFGBGIsDark(os.Getenv("COLORFGBG")); ok {
+			if dark {
+				return ThemeDark
+			}
+			return ThemeLight
+		}
+		if queryDarkBackground() {
+			return ThemeDark
+		}
+		return ThemeLight
+	default:
+		return ThemeDark
+	}
+}
+
+// colorFGBGIsDark interprets a COLORFGBG value ("fg;bg" or "fg;default;bg").
+// ANSI backgrounds 7 and 9–15 are light; the rest are dark. ok is false when
+// the value is missing or unparseable.
+func colorFGBGIsDark(v string) (dark bool, ok bool) {
+	if v == "" {
+		return false, false
+	}
+	parts := strings.Split(v, ";")
+	bg, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return false, false
+	}
+	return !(bg == 7 || (bg >= 9 && bg <= 15)), true
+}
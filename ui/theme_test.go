@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThemes_DefineEveryColor(t *testing.T) {
+	hex := regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+	for _, theme := range []Theme{ThemeDark, ThemeLight} {
+		v := reflect.ValueOf(theme)
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			value := v.Field(i).String()
+			if field.Name == "Name" {
+				assert.NotEmpty(t, value)
+				continue
+			}
+			assert.Regexpf(t, hex, value, "%s theme: %s must be a #rrggbb color", theme.Name, field.Name)
+		}
+	}
+}
+
+func TestApplyTheme_RebuildsStyles(t *testing.T) {
+	t.Cleanup(func() { ApplyTheme(ThemeDark) })
+
+	ApplyTheme(ThemeLight)
+	assert.Equal(t, ThemeLight, ActiveTheme())
+	assert.Equal(t, lipgloss.Color(ThemeLight.Text), navItemStyle.GetForeground())
+	assert.Equal(t, ThemeLight.GradientStart, GradientStart)
+
+	ApplyTheme(ThemeDark)
+	assert.Equal(t, lipgloss.Color(ThemeDark.Text), navItemStyle.GetForeground())
+}
+
+func TestResolveTheme(t *testing.T) {
+	orig := queryDarkBackground
+	t.Cleanup(func() { queryDarkBackground = orig })
+	queryDarkBackground = func() bool { return false }
+
+	assert.Equal(t, ThemeNameDark, ResolveTheme("").Name)
+	assert.Equal(t, ThemeNameDark, ResolveTheme("bogus").Name)
+	assert.Equal(t, ThemeNameLight, ResolveTheme("Light").Name)
+
+	t.Setenv("COLORFGBG", "0;15")
+	assert.Equal(t, ThemeNameLight, ResolveTheme("auto").Name)
+	t.Setenv("COLORFGBG", "15;default;0")
+	assert.Equal(t, ThemeNameDark, ResolveTheme("auto").Name)
+	t.Setenv("COLORFGBG", "")
+	assert.Equal(t, ThemeNameLight, ResolveTheme("auto").Name, "falls back to querying the terminal")
+}
@@ -5,12 +5,13 @@ import "fmt"
 // Zone ID constants for bubblezone hit detection.
 // These are used both in render paths (zone.Mark) and input paths (zone.Get().InBounds).
 const (
-	ZoneNavPanel  = "zone-nav-panel"
-	ZoneNavSearch = "zone-nav-search"
-	ZoneTabAgent  = "zone-tab-agent"
-	ZoneTabInfo   = "zone-tab-info"
-	ZoneAgentPane = "zone-agent-pane"
-	ZoneViewPlan  = "zone-view-plan"
+	ZoneNavPanel   = "zone-nav-panel"
+	ZoneNavSearch  = "zone-nav-search"
+	ZoneTabAgent   = "zone-tab-agent"
+	ZoneTabInfo    = "zone-tab-info"
+	ZoneAgentPane  = "zone-agent-pane"
+	ZoneViewPlan   = "zone-view-plan"
+	ZonePlanStatus = "zone-plan-status"
 )
 
 // TabZoneIDs maps tab index to zone ID.
@@ -22,6 +23,12 @@ func NavRowZoneID(idx int) string {
 	return fmt.Sprintf("zone-nav-row-%d", idx)
 }
 
+// WaveTaskZoneID returns the zone ID for a failed task's row in the info
+// pane's wave progress grid.
+func WaveTaskZoneID(taskNumber int) string {
+	return fmt.Sprintf("zone-wave-task-%d", taskNumber)
+}
+
 // InstanceTabZoneID returns the zone ID for a dynamic instance tab at the given index.
 func InstanceTabZoneID(idx int) string {
 	return fmt.Sprintf("zone-instance-tab-%d", idx)